@@ -53,12 +53,26 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	restoreControlPlaneLoadBalancerStatus(&restored.Status.Network.SecondaryAPIServerELB, &dst.Status.Network.SecondaryAPIServerELB)
 
 	dst.Spec.S3Bucket = restored.Spec.S3Bucket
+	dst.Spec.ControlPlaneDNS = restored.Spec.ControlPlaneDNS
+	dst.Status.Network.ControlPlaneDNS = restored.Status.Network.ControlPlaneDNS
 	if restored.Status.Bastion != nil {
 		dst.Status.Bastion.InstanceMetadataOptions = restored.Status.Bastion.InstanceMetadataOptions
 		dst.Status.Bastion.PlacementGroupName = restored.Status.Bastion.PlacementGroupName
 		dst.Status.Bastion.PlacementGroupPartition = restored.Status.Bastion.PlacementGroupPartition
 		dst.Status.Bastion.PrivateDNSName = restored.Status.Bastion.PrivateDNSName
 		dst.Status.Bastion.PublicIPOnLaunch = restored.Status.Bastion.PublicIPOnLaunch
+		dst.Status.Bastion.SourceDestCheck = restored.Status.Bastion.SourceDestCheck
+		dst.Status.Bastion.InstanceLifecycle = restored.Status.Bastion.InstanceLifecycle
+		restoreSpotMarketOptions(restored.Status.Bastion.SpotMarketOptions, dst.Status.Bastion.SpotMarketOptions)
+		dst.Status.Bastion.CapacityReservationTarget = restored.Status.Bastion.CapacityReservationTarget
+		dst.Status.Bastion.HostID = restored.Status.Bastion.HostID
+		dst.Status.Bastion.HostResourceGroupARN = restored.Status.Bastion.HostResourceGroupARN
+		dst.Status.Bastion.EnclaveOptions = restored.Status.Bastion.EnclaveOptions
+		dst.Status.Bastion.HibernationOptions = restored.Status.Bastion.HibernationOptions
+		dst.Status.Bastion.TerminationProtection = restored.Status.Bastion.TerminationProtection
+		dst.Status.Bastion.MarketplaceProductCodes = restored.Status.Bastion.MarketplaceProductCodes
+		restoreVolume(restored.Status.Bastion.RootVolume, dst.Status.Bastion.RootVolume)
+		restoreVolumes(restored.Status.Bastion.NonRootVolumes, dst.Status.Bastion.NonRootVolumes)
 	}
 	dst.Spec.Partition = restored.Spec.Partition
 
@@ -84,6 +98,8 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.NetworkSpec.AdditionalControlPlaneIngressRules = restored.Spec.NetworkSpec.AdditionalControlPlaneIngressRules
+	dst.Spec.NetworkSpec.PodNetwork = restored.Spec.NetworkSpec.PodNetwork
+	dst.Status.Network.PodSubnets = restored.Status.Network.PodSubnets
 
 	if restored.Spec.NetworkSpec.VPC.IPAMPool != nil {
 		if dst.Spec.NetworkSpec.VPC.IPAMPool == nil {
@@ -104,6 +120,11 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.NetworkSpec.VPC.EmptyRoutesDefaultVPCSecurityGroup = restored.Spec.NetworkSpec.VPC.EmptyRoutesDefaultVPCSecurityGroup
 	dst.Spec.NetworkSpec.VPC.PrivateDNSHostnameTypeOnLaunch = restored.Spec.NetworkSpec.VPC.PrivateDNSHostnameTypeOnLaunch
 	dst.Spec.NetworkSpec.VPC.CarrierGatewayID = restored.Spec.NetworkSpec.VPC.CarrierGatewayID
+	dst.Spec.NetworkSpec.VPC.ExternalInternetGatewayID = restored.Spec.NetworkSpec.VPC.ExternalInternetGatewayID
+	dst.Spec.NetworkSpec.VPC.AllowedAvailabilityZones = restored.Spec.NetworkSpec.VPC.AllowedAvailabilityZones
+	dst.Spec.NetworkSpec.VPC.ExcludedAvailabilityZones = restored.Spec.NetworkSpec.VPC.ExcludedAvailabilityZones
+	dst.Spec.NetworkSpec.VPC.PublicSubnetMaskSize = restored.Spec.NetworkSpec.VPC.PublicSubnetMaskSize
+	dst.Spec.NetworkSpec.VPC.PrivateSubnetMaskSize = restored.Spec.NetworkSpec.VPC.PrivateSubnetMaskSize
 
 	if restored.Spec.NetworkSpec.VPC.ElasticIPPool != nil {
 		if dst.Spec.NetworkSpec.VPC.ElasticIPPool == nil {
@@ -117,7 +138,7 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 		}
 	}
 
-	// Restore SubnetSpec.ResourceID, SubnetSpec.ParentZoneName, and SubnetSpec.ZoneType fields, if any.
+	// Restore SubnetSpec.ResourceID, SubnetSpec.ParentZoneName, SubnetSpec.ZoneType and SubnetSpec.MapPublicIPOnLaunch fields, if any.
 	for _, subnet := range restored.Spec.NetworkSpec.Subnets {
 		for i, dstSubnet := range dst.Spec.NetworkSpec.Subnets {
 			if dstSubnet.ID == subnet.ID {
@@ -130,6 +151,9 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 				if subnet.ZoneType != nil {
 					dstSubnet.ZoneType = subnet.ZoneType
 				}
+				if subnet.MapPublicIPOnLaunch != nil {
+					dstSubnet.MapPublicIPOnLaunch = subnet.MapPublicIPOnLaunch
+				}
 				dstSubnet.DeepCopyInto(&dst.Spec.NetworkSpec.Subnets[i])
 			}
 		}
@@ -143,10 +167,13 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 func restoreControlPlaneLoadBalancerStatus(restored, dst *infrav2.LoadBalancer) {
 	dst.ARN = restored.ARN
 	dst.LoadBalancerType = restored.LoadBalancerType
+	dst.IPAddressType = restored.IPAddressType
 	dst.ELBAttributes = restored.ELBAttributes
 	dst.ELBListeners = restored.ELBListeners
 	dst.Name = restored.Name
 	dst.DNSName = restored.DNSName
+	dst.CanonicalHostedZoneID = restored.CanonicalHostedZoneID
+	dst.EndpointService = restored.EndpointService
 	dst.Scheme = restored.Scheme
 	dst.SubnetIDs = restored.SubnetIDs
 	dst.SecurityGroupIDs = restored.SecurityGroupIDs
@@ -174,12 +201,24 @@ func restoreControlPlaneLoadBalancer(restored, dst *infrav2.AWSLoadBalancerSpec)
 	dst.LoadBalancerType = restored.LoadBalancerType
 	dst.DisableHostsRewrite = restored.DisableHostsRewrite
 	dst.PreserveClientIP = restored.PreserveClientIP
+	dst.ProxyProtocolV2 = restored.ProxyProtocolV2
+	dst.DeregistrationDelaySeconds = restored.DeregistrationDelaySeconds
 	dst.IngressRules = restored.IngressRules
 	dst.AdditionalListeners = restored.AdditionalListeners
 	dst.AdditionalSecurityGroups = restored.AdditionalSecurityGroups
+	dst.SecurityGroups = restored.SecurityGroups
 	dst.Scheme = restored.Scheme
 	dst.CrossZoneLoadBalancing = restored.CrossZoneLoadBalancing
 	dst.Subnets = restored.Subnets
+	dst.EndpointService = restored.EndpointService
+	dst.IdleTimeoutSeconds = restored.IdleTimeoutSeconds
+	dst.ClientKeepAliveSeconds = restored.ClientKeepAliveSeconds
+	dst.IPAddressType = restored.IPAddressType
+	dst.DeletionProtection = restored.DeletionProtection
+	dst.CertificateARN = restored.CertificateARN
+	dst.AdditionalCertificateARNs = restored.AdditionalCertificateARNs
+	dst.SSLPolicy = restored.SSLPolicy
+	dst.AccessLogs = restored.AccessLogs
 }
 
 // ConvertFrom converts the v1beta1 AWSCluster receiver to a v1beta1 AWSCluster.
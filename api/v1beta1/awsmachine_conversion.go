@@ -41,6 +41,26 @@ func (src *AWSMachine) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.PlacementGroupPartition = restored.Spec.PlacementGroupPartition
 	dst.Spec.PrivateDNSName = restored.Spec.PrivateDNSName
 	dst.Spec.SecurityGroupOverrides = restored.Spec.SecurityGroupOverrides
+	dst.Spec.AdditionalSecurityGroupSelectors = restored.Spec.AdditionalSecurityGroupSelectors
+	dst.Spec.FailureDomainSelector = restored.Spec.FailureDomainSelector
+	dst.Spec.BootDiagnostics = restored.Spec.BootDiagnostics
+	dst.Spec.SourceDestCheck = restored.Spec.SourceDestCheck
+	restoreSpotMarketOptions(restored.Spec.SpotMarketOptions, dst.Spec.SpotMarketOptions)
+	dst.Spec.CapacityReservationTarget = restored.Spec.CapacityReservationTarget
+	dst.Spec.HostID = restored.Spec.HostID
+	dst.Spec.HostResourceGroupARN = restored.Spec.HostResourceGroupARN
+	dst.Spec.PlacementGroup = restored.Spec.PlacementGroup
+	dst.Spec.EnclaveOptions = restored.Spec.EnclaveOptions
+	dst.Spec.HibernationOptions = restored.Spec.HibernationOptions
+	dst.Spec.TerminationProtection = restored.Spec.TerminationProtection
+	dst.Spec.AMI.Filters = restored.Spec.AMI.Filters
+	dst.Spec.AMI.SSMParameter = restored.Spec.AMI.SSMParameter
+	dst.Spec.AMI.MarketplaceProductCode = restored.Spec.AMI.MarketplaceProductCode
+	dst.Spec.ImageLookupArchitecture = restored.Spec.ImageLookupArchitecture
+	dst.Spec.ImageLookupRootDeviceType = restored.Spec.ImageLookupRootDeviceType
+	restoreVolume(restored.Spec.RootVolume, dst.Spec.RootVolume)
+	restoreVolumes(restored.Spec.NonRootVolumes, dst.Spec.NonRootVolumes)
+	dst.Status.InstanceLifecycle = restored.Status.InstanceLifecycle
 	if restored.Spec.ElasticIPPool != nil {
 		if dst.Spec.ElasticIPPool == nil {
 			dst.Spec.ElasticIPPool = &infrav1.ElasticIPPool{}
@@ -102,6 +122,25 @@ func (r *AWSMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Template.Spec.PlacementGroupPartition = restored.Spec.Template.Spec.PlacementGroupPartition
 	dst.Spec.Template.Spec.PrivateDNSName = restored.Spec.Template.Spec.PrivateDNSName
 	dst.Spec.Template.Spec.SecurityGroupOverrides = restored.Spec.Template.Spec.SecurityGroupOverrides
+	dst.Spec.Template.Spec.AdditionalSecurityGroupSelectors = restored.Spec.Template.Spec.AdditionalSecurityGroupSelectors
+	dst.Spec.Template.Spec.FailureDomainSelector = restored.Spec.Template.Spec.FailureDomainSelector
+	dst.Spec.Template.Spec.BootDiagnostics = restored.Spec.Template.Spec.BootDiagnostics
+	dst.Spec.Template.Spec.SourceDestCheck = restored.Spec.Template.Spec.SourceDestCheck
+	restoreSpotMarketOptions(restored.Spec.Template.Spec.SpotMarketOptions, dst.Spec.Template.Spec.SpotMarketOptions)
+	dst.Spec.Template.Spec.CapacityReservationTarget = restored.Spec.Template.Spec.CapacityReservationTarget
+	dst.Spec.Template.Spec.HostID = restored.Spec.Template.Spec.HostID
+	dst.Spec.Template.Spec.HostResourceGroupARN = restored.Spec.Template.Spec.HostResourceGroupARN
+	dst.Spec.Template.Spec.PlacementGroup = restored.Spec.Template.Spec.PlacementGroup
+	dst.Spec.Template.Spec.EnclaveOptions = restored.Spec.Template.Spec.EnclaveOptions
+	dst.Spec.Template.Spec.HibernationOptions = restored.Spec.Template.Spec.HibernationOptions
+	dst.Spec.Template.Spec.TerminationProtection = restored.Spec.Template.Spec.TerminationProtection
+	dst.Spec.Template.Spec.AMI.Filters = restored.Spec.Template.Spec.AMI.Filters
+	dst.Spec.Template.Spec.AMI.SSMParameter = restored.Spec.Template.Spec.AMI.SSMParameter
+	dst.Spec.Template.Spec.AMI.MarketplaceProductCode = restored.Spec.Template.Spec.AMI.MarketplaceProductCode
+	dst.Spec.Template.Spec.ImageLookupArchitecture = restored.Spec.Template.Spec.ImageLookupArchitecture
+	dst.Spec.Template.Spec.ImageLookupRootDeviceType = restored.Spec.Template.Spec.ImageLookupRootDeviceType
+	restoreVolume(restored.Spec.Template.Spec.RootVolume, dst.Spec.Template.Spec.RootVolume)
+	restoreVolumes(restored.Spec.Template.Spec.NonRootVolumes, dst.Spec.Template.Spec.NonRootVolumes)
 	if restored.Spec.Template.Spec.ElasticIPPool != nil {
 		if dst.Spec.Template.Spec.ElasticIPPool == nil {
 			dst.Spec.Template.Spec.ElasticIPPool = &infrav1.ElasticIPPool{}
@@ -145,3 +184,26 @@ func (dst *AWSMachineTemplateList) ConvertFrom(srcRaw conversion.Hub) error {
 
 	return Convert_v1beta2_AWSMachineTemplateList_To_v1beta1_AWSMachineTemplateList(src, dst, nil)
 }
+
+func restoreSpotMarketOptions(restored, dst *infrav1.SpotMarketOptions) {
+	if restored == nil || dst == nil {
+		return
+	}
+	dst.InterruptionBehavior = restored.InterruptionBehavior
+}
+
+func restoreVolume(restored, dst *infrav1.Volume) {
+	if restored == nil || dst == nil {
+		return
+	}
+	dst.DeleteOnTermination = restored.DeleteOnTermination
+}
+
+func restoreVolumes(restored, dst []infrav1.Volume) {
+	if len(restored) != len(dst) {
+		return
+	}
+	for i := range dst {
+		restoreVolume(&restored[i], &dst[i])
+	}
+}
@@ -51,13 +51,19 @@ func Convert_v1beta2_Instance_To_v1beta1_Instance(in *v1beta2.Instance, out *Ins
 	return autoConvert_v1beta2_Instance_To_v1beta1_Instance(in, out, s)
 }
 
+func Convert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(in *v1beta2.ClassicELBAttributes, out *ClassicELBAttributes, s conversion.Scope) error {
+	return autoConvert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(in, out, s)
+}
+
 func Convert_v1beta1_ClassicELB_To_v1beta2_LoadBalancer(in *ClassicELB, out *v1beta2.LoadBalancer, s conversion.Scope) error {
 	out.Name = in.Name
 	out.DNSName = in.DNSName
 	out.Scheme = v1beta2.ELBScheme(in.Scheme)
 	out.HealthCheck = (*v1beta2.ClassicELBHealthCheck)(in.HealthCheck)
 	out.AvailabilityZones = in.AvailabilityZones
-	out.ClassicElbAttributes = (v1beta2.ClassicELBAttributes)(in.Attributes)
+	if err := Convert_v1beta1_ClassicELBAttributes_To_v1beta2_ClassicELBAttributes(&in.Attributes, &out.ClassicElbAttributes, s); err != nil {
+		return err
+	}
 	out.ClassicELBListeners = *(*[]v1beta2.ClassicELBListener)(unsafe.Pointer(&in.Listeners))
 	out.SecurityGroupIDs = in.SecurityGroupIDs
 	out.Tags = in.Tags
@@ -71,7 +77,9 @@ func Convert_v1beta2_LoadBalancer_To_v1beta1_ClassicELB(in *v1beta2.LoadBalancer
 	out.Scheme = ClassicELBScheme(in.Scheme)
 	out.HealthCheck = (*ClassicELBHealthCheck)(in.HealthCheck)
 	out.AvailabilityZones = in.AvailabilityZones
-	out.Attributes = (ClassicELBAttributes)(in.ClassicElbAttributes)
+	if err := Convert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(&in.ClassicElbAttributes, &out.Attributes, s); err != nil {
+		return err
+	}
 	out.Listeners = *(*[]ClassicELBListener)(unsafe.Pointer(&in.ClassicELBListeners))
 	out.SecurityGroupIDs = in.SecurityGroupIDs
 	out.Tags = in.Tags
@@ -102,3 +110,19 @@ func Convert_v1beta2_S3Bucket_To_v1beta1_S3Bucket(in *v1beta2.S3Bucket, out *S3B
 func Convert_v1beta2_Ignition_To_v1beta1_Ignition(in *v1beta2.Ignition, out *Ignition, s conversion.Scope) error {
 	return autoConvert_v1beta2_Ignition_To_v1beta1_Ignition(in, out, s)
 }
+
+func Convert_v1beta2_AWSMachineStatus_To_v1beta1_AWSMachineStatus(in *v1beta2.AWSMachineStatus, out *AWSMachineStatus, s conversion.Scope) error {
+	return autoConvert_v1beta2_AWSMachineStatus_To_v1beta1_AWSMachineStatus(in, out, s)
+}
+
+func Convert_v1beta2_SpotMarketOptions_To_v1beta1_SpotMarketOptions(in *v1beta2.SpotMarketOptions, out *SpotMarketOptions, s conversion.Scope) error {
+	return autoConvert_v1beta2_SpotMarketOptions_To_v1beta1_SpotMarketOptions(in, out, s)
+}
+
+func Convert_v1beta2_AMIReference_To_v1beta1_AMIReference(in *v1beta2.AMIReference, out *AMIReference, s conversion.Scope) error {
+	return autoConvert_v1beta2_AMIReference_To_v1beta1_AMIReference(in, out, s)
+}
+
+func Convert_v1beta2_Volume_To_v1beta1_Volume(in *v1beta2.Volume, out *Volume, s conversion.Scope) error {
+	return autoConvert_v1beta2_Volume_To_v1beta1_Volume(in, out, s)
+}
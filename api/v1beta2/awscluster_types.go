@@ -111,6 +111,12 @@ type AWSClusterSpec struct {
 	// BootstrapFormatIgnition feature flag to be enabled).
 	// +optional
 	S3Bucket *S3Bucket `json:"s3Bucket,omitempty"`
+
+	// ControlPlaneDNS contains options to publish the control plane endpoint through a
+	// Route53 hosted zone. When set, an alias record pointing at the control plane
+	// load balancer is created and its name is used as the ControlPlaneEndpoint host.
+	// +optional
+	ControlPlaneDNS *ControlPlaneDNS `json:"controlPlaneDNS,omitempty"`
 }
 
 // AWSIdentityKind defines allowed AWS identity types.
@@ -184,10 +190,11 @@ var (
 
 // AWSLoadBalancerSpec defines the desired state of an AWS load balancer.
 type AWSLoadBalancerSpec struct {
-	// Name sets the name of the classic ELB load balancer. As per AWS, the name must be unique
-	// within your set of load balancers for the region, must have a maximum of 32 characters, must
-	// contain only alphanumeric characters or hyphens, and cannot begin or end with a hyphen. Once
-	// set, the value cannot be changed.
+	// Name overrides the name of the load balancer, which otherwise defaults to `<cluster-name>-apiserver`
+	// for the control plane load balancer. As per AWS, the name must be unique within your set of load
+	// balancers for the region, must have a maximum of 32 characters, must contain only alphanumeric
+	// characters or hyphens, and cannot begin or end with a hyphen. The name of Network and Application
+	// Load Balancers also cannot begin with "internal-". Once set, the value cannot be changed.
 	// +kubebuilder:validation:MaxLength:=32
 	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9]([A-Za-z0-9]{0,31}|[-A-Za-z0-9]{0,30}[A-Za-z0-9])$`
 	// +optional
@@ -199,12 +206,13 @@ type AWSLoadBalancerSpec struct {
 	// +optional
 	Scheme *ELBScheme `json:"scheme,omitempty"`
 
-	// CrossZoneLoadBalancing enables the classic ELB cross availability zone balancing.
+	// CrossZoneLoadBalancing enables the cross availability zone balancing of classic and network load balancers.
 	//
-	// With cross-zone load balancing, each load balancer node for your Classic Load Balancer
-	// distributes requests evenly across the registered instances in all enabled Availability Zones.
+	// With cross-zone load balancing, each load balancer node distributes requests evenly across
+	// the registered instances in all enabled Availability Zones.
 	// If cross-zone load balancing is disabled, each load balancer node distributes requests evenly across
 	// the registered instances in its Availability Zone only.
+	// Application Load Balancers always balance across zones, so the field is ignored for them.
 	//
 	// Defaults to false.
 	// +optional
@@ -229,8 +237,17 @@ type AWSLoadBalancerSpec struct {
 	// +optional
 	AdditionalSecurityGroups []string `json:"additionalSecurityGroups,omitempty"`
 
+	// SecurityGroups sets the security groups used by the load balancer in place of the apiserver-lb
+	// security group managed by CAPA. Expected to be security group IDs. The security groups must allow
+	// the traffic to the listeners of the load balancer, the control plane security group allows them
+	// to reach the API server. AdditionalSecurityGroups are attached alongside them.
+	// The ingress rules of the load balancer are applied to the managed security group, so they cannot
+	// be set along with SecurityGroups.
+	// +optional
+	SecurityGroups []string `json:"securityGroups,omitempty"`
+
 	// AdditionalListeners sets the additional listeners for the control plane load balancer.
-	// This is only applicable to Network Load Balancer (NLB) types for the time being.
+	// This is only applicable to Network Load Balancer (NLB) and Application Load Balancer (ALB) types.
 	// +listType=map
 	// +listMapKey=port
 	// +optional
@@ -252,6 +269,121 @@ type AWSLoadBalancerSpec struct {
 	// PreserveClientIP lets the user control if preservation of client ips must be retained or not.
 	// If this is enabled 6443 will be opened to 0.0.0.0/0.
 	PreserveClientIP bool `json:"preserveClientIP,omitempty"`
+
+	// ProxyProtocolV2 enables the proxy protocol version 2 on the target groups of the load balancer,
+	// which prepends the connection information, such as the source IP of the client, to the traffic
+	// forwarded to the control plane instances. The targets must accept the proxy protocol, which the
+	// API server does not do on its own.
+	// This is only applicable to Network Load Balancer (NLB) types.
+	// +optional
+	ProxyProtocolV2 bool `json:"proxyProtocolV2,omitempty"`
+
+	// DeregistrationDelaySeconds is the number of seconds the load balancer waits before deregistering
+	// a target, letting the in-flight requests complete.
+	// This is only applicable to Network Load Balancer (NLB) and Application Load Balancer (ALB) types.
+	// Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	DeregistrationDelaySeconds *int64 `json:"deregistrationDelaySeconds,omitempty"`
+
+	// IdleTimeoutSeconds is the number of seconds a connection may be idle before the load balancer
+	// closes it. Long running API requests such as `kubectl logs -f`, `kubectl exec` or watches are
+	// terminated once idle for longer than this timeout.
+	// This is only applicable to classic and Application Load Balancer (ALB) types. Network Load
+	// Balancers use a fixed TCP idle timeout of 350 seconds.
+	// Defaults to 600 for classic load balancers and 60 for Application Load Balancers.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4000
+	// +optional
+	IdleTimeoutSeconds *int64 `json:"idleTimeoutSeconds,omitempty"`
+
+	// ClientKeepAliveSeconds is the maximum number of seconds a client connection is kept open by
+	// the load balancer, regardless of activity.
+	// This is only applicable to Application Load Balancer (ALB) types. Defaults to 3600.
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:validation:Maximum=604800
+	// +optional
+	ClientKeepAliveSeconds *int64 `json:"clientKeepAliveSeconds,omitempty"`
+
+	// IPAddressType sets the type of IP addresses the load balancer is reachable at. With dualstack, the
+	// DNS name of the load balancer resolves to both IPv4 and IPv6 addresses, so that IPv6-only clients can
+	// reach the API server. Defaults to dualstack when IPv6 is enabled in the VPC, and to ipv4 otherwise.
+	// dualstack requires an existing VPC whose load balancer subnets have IPv6 CIDR blocks.
+	// This is only applicable to Network Load Balancer (NLB) and Application Load Balancer (ALB) types,
+	// and cannot be changed once the load balancer is created.
+	// +kubebuilder:validation:Enum=ipv4;dualstack
+	// +optional
+	IPAddressType *LoadBalancerIPAddressType `json:"ipAddressType,omitempty"`
+
+	// DeletionProtection prevents the load balancer from being deleted outside of CAPA. The protection
+	// is lifted by the controller before it deletes the load balancer along with the cluster.
+	// This is only applicable to Network Load Balancer (NLB) and Application Load Balancer (ALB) types.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
+	// EndpointService exposes the load balancer as a VPC endpoint service (AWS PrivateLink),
+	// so that consumer VPCs and accounts can reach the API server without VPC peering.
+	// This is only applicable to Network Load Balancer (NLB) types.
+	// +optional
+	EndpointService *EndpointServiceSpec `json:"endpointService,omitempty"`
+
+	// CertificateARN is the ARN of the ACM certificate served by the HTTPS and TLS listeners of the load
+	// balancer, which terminate TLS and re-encrypt the traffic to the targets.
+	// This is required for Application Load Balancer (ALB) types, and for Network Load Balancer (NLB)
+	// types with TLS additional listeners.
+	// +optional
+	CertificateARN *string `json:"certificateArn,omitempty"`
+
+	// AdditionalCertificateARNs are the ARNs of the ACM certificates served by the HTTPS and TLS listeners
+	// in addition to certificateArn, selected by the load balancer with Server Name Indication (SNI).
+	// certificateArn is served to the clients whose hostname matches none of them.
+	// +optional
+	AdditionalCertificateARNs []string `json:"additionalCertificateArns,omitempty"`
+
+	// SSLPolicy is the name of the security policy of the HTTPS and TLS listeners, which defines the
+	// protocols and ciphers they negotiate with the clients. Defaults to the default policy of the load balancer.
+	// +optional
+	SSLPolicy *string `json:"sslPolicy,omitempty"`
+
+	// AccessLogs configures the delivery of the access logs of the load balancer to an S3 bucket.
+	// +optional
+	AccessLogs *LoadBalancerAccessLogs `json:"accessLogs,omitempty"`
+}
+
+// LoadBalancerAccessLogs defines the delivery of the access logs of a load balancer to S3.
+type LoadBalancerAccessLogs struct {
+	// Bucket is the name of the S3 bucket the access logs are delivered to.
+	// Defaults to the cluster bucket set in spec.s3Bucket, in which case the bucket policy
+	// allowing the load balancer to deliver the logs is managed by the controller. The policy
+	// of any other bucket must be managed by the user.
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+
+	// Prefix is the prefix of the keys of the log objects in the bucket.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// EmitInterval is the interval, in minutes, at which the access logs are published.
+	// This is only applicable to classic load balancers, which default to 60 minutes.
+	// Application and Network Load Balancers publish their access logs every 5 minutes.
+	// +kubebuilder:validation:Enum=5;60
+	// +optional
+	EmitInterval *int64 `json:"emitInterval,omitempty"`
+}
+
+// EndpointServiceSpec defines the desired state of a VPC endpoint service
+// exposing a control plane load balancer.
+type EndpointServiceSpec struct {
+	// AllowedPrincipals is the list of ARNs of the principals allowed to create interface
+	// endpoints to the endpoint service, e.g. "arn:aws:iam::123456789012:root".
+	// +optional
+	AllowedPrincipals []string `json:"allowedPrincipals,omitempty"`
+
+	// AcceptanceRequired indicates whether requests from consumers to connect to the
+	// endpoint service must be accepted manually. Defaults to false.
+	// +optional
+	AcceptanceRequired bool `json:"acceptanceRequired,omitempty"`
 }
 
 // AdditionalListenerSpec defines the desired state of an
@@ -263,14 +395,57 @@ type AdditionalListenerSpec struct {
 	Port int64 `json:"port"`
 
 	// Protocol sets the protocol for the additional listener.
-	// Currently only TCP is supported.
-	// +kubebuilder:validation:Enum=TCP
+	// Network Load Balancers support TCP and TLS, and Application Load Balancers support HTTP and HTTPS.
+	// TLS listeners terminate TLS with the certificate set in certificateArn and re-encrypt the traffic
+	// to the control plane instances.
+	// +kubebuilder:validation:Enum=TCP;TLS;HTTP;HTTPS
 	// +kubebuilder:default=TCP
 	Protocol ELBProtocol `json:"protocol,omitempty"`
 
+	// TargetPort sets the port of the control plane instances the listener forwards the traffic to.
+	// Defaults to the port of the listener.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	TargetPort *int64 `json:"targetPort,omitempty"`
+
 	// HealthCheck sets the optional custom health check configuration to the API target group.
 	// +optional
 	HealthCheck *TargetGroupHealthCheckAdditionalSpec `json:"healthCheck,omitempty"`
+
+	// Rules sets the rules of the listener. When rules are set, only the requests matching one of
+	// them are forwarded to the control plane instances, and the other requests get a 404 response.
+	// This is only applicable to Application Load Balancer (ALB) types.
+	// +listType=map
+	// +listMapKey=priority
+	// +optional
+	Rules []ListenerRule `json:"rules,omitempty"`
+}
+
+// GetTargetPort returns the port the listener forwards the traffic to on the control plane instances.
+func (l *AdditionalListenerSpec) GetTargetPort() int64 {
+	if l.TargetPort != nil {
+		return *l.TargetPort
+	}
+	return l.Port
+}
+
+// ListenerRule defines a rule of an Application Load Balancer listener, forwarding the matching
+// requests to the target group of the listener.
+type ListenerRule struct {
+	// Priority sets the priority of the rule. Rules are evaluated from the lowest to the highest
+	// priority, and priorities must be unique within a listener.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50000
+	Priority int64 `json:"priority"`
+
+	// PathPatterns sets the request paths matched by the rule, e.g. "/healthz" or "/apis/*".
+	// +optional
+	PathPatterns []string `json:"pathPatterns,omitempty"`
+
+	// HostHeaders sets the host names matched by the rule, e.g. "api.example.com" or "*.example.com".
+	// +optional
+	HostHeaders []string `json:"hostHeaders,omitempty"`
 }
 
 // AWSClusterStatus defines the observed state of AWSCluster.
@@ -313,6 +488,57 @@ type S3Bucket struct {
 	// BestEffortDeleteObjects defines whether access/permission errors during object deletion should be ignored.
 	// +optional
 	BestEffortDeleteObjects *bool `json:"bestEffortDeleteObjects,omitempty"`
+
+	// KMSKeyID is the ID or ARN of a customer managed KMS key used as the default encryption key of the
+	// bucket and to encrypt the bootstrap data objects. When omitted, the AWS managed aws/s3 key is used.
+	// The IAM instance profiles reading the bootstrap data must be allowed to decrypt with this key.
+	// +optional
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+
+	// Versioning, when true, enables the versioning of the objects of the bucket.
+	// Setting it back to false does not suspend the versioning of the bucket.
+	// +optional
+	Versioning bool `json:"versioning,omitempty"`
+
+	// ExpirationDays is the number of days after which the bootstrap data objects, and their noncurrent
+	// versions, are expired by a lifecycle rule of the bucket. The bootstrap data of a machine is deleted once
+	// the machine joined the cluster, so this removes the objects left behind by machines that never did.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ExpirationDays *int64 `json:"expirationDays,omitempty"`
+
+	// BlockPublicAccess, when true, blocks all public access to the bucket and its objects.
+	// +optional
+	BlockPublicAccess bool `json:"blockPublicAccess,omitempty"`
+
+	// Policy is a JSON bucket policy document whose statements are added to the bucket policy
+	// managed by the controller.
+	// +optional
+	Policy string `json:"policy,omitempty"`
+}
+
+// ControlPlaneDNS defines a Route53 hosted zone and record used to publish the control plane endpoint.
+type ControlPlaneDNS struct {
+	// HostedZoneName is the domain name of the hosted zone, e.g. "example.internal".
+	// Unless HostedZoneID is set, a private hosted zone is created and associated with
+	// the cluster VPC when it does not exist.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	HostedZoneName string `json:"hostedZoneName"`
+
+	// HostedZoneID is the ID of an existing public or private hosted zone named HostedZoneName
+	// that the record is created in, for example to publish a vanity name for an internet-facing
+	// API server. The hosted zone is managed outside of the cluster and is never created,
+	// associated with the cluster VPC, or deleted.
+	// +kubebuilder:validation:MaxLength:=64
+	// +optional
+	HostedZoneID string `json:"hostedZoneID,omitempty"`
+
+	// RecordName is the name of the alias record, relative to the hosted zone, pointing at the
+	// control plane load balancer. Defaults to "api.<cluster name>".
+	// +kubebuilder:validation:MaxLength:=63
+	// +optional
+	RecordName string `json:"recordName,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -18,12 +18,15 @@ package v1beta2
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -59,6 +62,8 @@ func (r *AWSCluster) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.Spec.S3Bucket.Validate()...)
 	allErrs = append(allErrs, r.validateNetwork()...)
 	allErrs = append(allErrs, r.validateControlPlaneLBs()...)
+	allErrs = append(allErrs, r.validateControlPlaneDNS()...)
+	allErrs = append(allErrs, r.validateGCTasksAnnotation()...)
 
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -98,6 +103,16 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 
 		allErrs = append(allErrs, r.validateControlPlaneLoadBalancerUpdate(oldLB, newLB)...)
 	}
+	allErrs = append(allErrs, r.validateEndpointServices()...)
+	allErrs = append(allErrs, r.validateLoadBalancerListeners(oldC)...)
+	allErrs = append(allErrs, r.validateLoadBalancerTimeouts()...)
+	allErrs = append(allErrs, r.validateLoadBalancerTargetGroupAttributes()...)
+	allErrs = append(allErrs, r.validateLoadBalancerSecurityGroups()...)
+	allErrs = append(allErrs, r.validateLoadBalancerDeletionProtection()...)
+	allErrs = append(allErrs, r.validateLoadBalancerIPAddressTypes()...)
+	allErrs = append(allErrs, r.validateLoadBalancerNames()...)
+	allErrs = append(allErrs, r.validateLoadBalancerHealthChecks()...)
+	allErrs = append(allErrs, r.validateLoadBalancerAccessLogs()...)
 
 	if !cmp.Equal(oldC.Spec.ControlPlaneEndpoint, clusterv1.APIEndpoint{}) &&
 		!cmp.Equal(r.Spec.ControlPlaneEndpoint, oldC.Spec.ControlPlaneEndpoint) {
@@ -106,6 +121,24 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 		)
 	}
 
+	if !cmp.Equal(oldC.Spec.ControlPlaneDNS, r.Spec.ControlPlaneDNS) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneDNS"), r.Spec.ControlPlaneDNS, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(oldC.Spec.NetworkSpec.PodNetwork, r.Spec.NetworkSpec.PodNetwork) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "network", "podNetwork"), r.Spec.NetworkSpec.PodNetwork, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(oldC.Spec.NetworkSpec.VPC.ExternalInternetGatewayID, r.Spec.NetworkSpec.VPC.ExternalInternetGatewayID) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "network", "vpc", "externalInternetGatewayId"), r.Spec.NetworkSpec.VPC.ExternalInternetGatewayID, "field is immutable"),
+		)
+	}
+
 	// Modifying VPC id is not allowed because it will cause a new VPC creation if set to nil.
 	if !cmp.Equal(oldC.Spec.NetworkSpec, NetworkSpec{}) &&
 		!cmp.Equal(oldC.Spec.NetworkSpec.VPC, VPCSpec{}) &&
@@ -141,6 +174,386 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
+// validateLoadBalancerTimeouts ensures connection timeouts are only set for the load balancer types supporting them.
+func (r *AWSCluster) validateLoadBalancerTimeouts() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, lb := range []*AWSLoadBalancerSpec{r.Spec.ControlPlaneLoadBalancer, r.Spec.SecondaryControlPlaneLoadBalancer} {
+		name := "controlPlaneLoadBalancer"
+		if i == 1 {
+			name = "secondaryControlPlaneLoadBalancer"
+		}
+		if lb == nil {
+			continue
+		}
+
+		switch lb.LoadBalancerType {
+		case LoadBalancerTypeClassic, LoadBalancerTypeELB, LoadBalancerTypeALB:
+		default:
+			if lb.IdleTimeoutSeconds != nil {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", name, "idleTimeoutSeconds"), *lb.IdleTimeoutSeconds, "idle timeout is only supported for classic and Application Load Balancers"))
+			}
+		}
+
+		if lb.ClientKeepAliveSeconds != nil && lb.LoadBalancerType != LoadBalancerTypeALB {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", name, "clientKeepAliveSeconds"), *lb.ClientKeepAliveSeconds, "client keep alive is only supported for Application Load Balancers"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateLoadBalancerTargetGroupAttributes ensures the target group attributes are only set on the load balancer types supporting them.
+func (r *AWSCluster) validateLoadBalancerTargetGroupAttributes() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, lb := range []*AWSLoadBalancerSpec{r.Spec.ControlPlaneLoadBalancer, r.Spec.SecondaryControlPlaneLoadBalancer} {
+		name := "controlPlaneLoadBalancer"
+		if i == 1 {
+			name = "secondaryControlPlaneLoadBalancer"
+		}
+		if lb == nil {
+			continue
+		}
+
+		if lb.ProxyProtocolV2 && lb.LoadBalancerType != LoadBalancerTypeNLB {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", name, "proxyProtocolV2"), lb.ProxyProtocolV2, "proxy protocol is only supported for Network Load Balancers"))
+		}
+
+		switch lb.LoadBalancerType {
+		case LoadBalancerTypeNLB, LoadBalancerTypeALB:
+		default:
+			if lb.DeregistrationDelaySeconds != nil {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", name, "deregistrationDelaySeconds"), *lb.DeregistrationDelaySeconds, "deregistration delay is only supported for Network and Application Load Balancers"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateLoadBalancerDeletionProtection ensures deletion protection is only set for the load balancer types supporting it.
+func (r *AWSCluster) validateLoadBalancerDeletionProtection() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, lb := range []*AWSLoadBalancerSpec{r.Spec.ControlPlaneLoadBalancer, r.Spec.SecondaryControlPlaneLoadBalancer} {
+		name := "controlPlaneLoadBalancer"
+		if i == 1 {
+			name = "secondaryControlPlaneLoadBalancer"
+		}
+		if lb == nil || !lb.DeletionProtection {
+			continue
+		}
+
+		switch lb.LoadBalancerType {
+		case LoadBalancerTypeNLB, LoadBalancerTypeALB:
+		default:
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", name, "deletionProtection"), lb.DeletionProtection, "deletion protection is only supported for Network and Application Load Balancers"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateLoadBalancerHealthChecks ensures the success codes of the API server health checks are only set for
+// HTTP and HTTPS health checks of the load balancer types supporting them, within the range allowed by AWS.
+func (r *AWSCluster) validateLoadBalancerHealthChecks() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, lb := range []*AWSLoadBalancerSpec{r.Spec.ControlPlaneLoadBalancer, r.Spec.SecondaryControlPlaneLoadBalancer} {
+		name := "controlPlaneLoadBalancer"
+		if i == 1 {
+			name = "secondaryControlPlaneLoadBalancer"
+		}
+		if lb == nil || lb.HealthCheck == nil || lb.HealthCheck.SuccessCodes == nil {
+			continue
+		}
+
+		successCodesPath := field.NewPath("spec", name, "healthCheck", "successCodes")
+		successCodes := *lb.HealthCheck.SuccessCodes
+		protocol := ptr.Deref(lb.HealthCheckProtocol, ELBProtocolTCP)
+		maxCode := 599
+		if lb.LoadBalancerType == LoadBalancerTypeALB {
+			// Application Load Balancers default to HTTPS health checks.
+			protocol = ptr.Deref(lb.HealthCheckProtocol, ELBProtocolHTTPS)
+			maxCode = 499
+		}
+		switch {
+		case lb.LoadBalancerType != LoadBalancerTypeNLB && lb.LoadBalancerType != LoadBalancerTypeALB:
+			allErrs = append(allErrs, field.Invalid(successCodesPath, successCodes, "success codes are only supported for Network and Application Load Balancers"))
+		case protocol != ELBProtocolHTTP && protocol != ELBProtocolHTTPS:
+			allErrs = append(allErrs, field.Invalid(successCodesPath, successCodes, "success codes require the HTTP or HTTPS health check protocol"))
+		case !successCodesInRange(successCodes, 200, maxCode):
+			allErrs = append(allErrs, field.Invalid(successCodesPath, successCodes, fmt.Sprintf("success codes must be between 200 and %d", maxCode)))
+		}
+	}
+
+	return allErrs
+}
+
+// successCodesInRange reports whether all the codes of a health check matcher, made of comma separated
+// codes or ranges of codes, are within minCode and maxCode.
+func successCodesInRange(successCodes string, minCode, maxCode int) bool {
+	for _, codes := range strings.Split(successCodes, ",") {
+		first, last, isRange := strings.Cut(codes, "-")
+		if !isRange {
+			last = first
+		}
+		from, err := strconv.Atoi(first)
+		if err != nil {
+			return false
+		}
+		to, err := strconv.Atoi(last)
+		if err != nil {
+			return false
+		}
+		if from < minCode || to > maxCode || from > to {
+			return false
+		}
+	}
+	return true
+}
+
+// validateLoadBalancerNames ensures the name overrides of the load balancers meet the naming constraints of AWS
+// not covered by the CRD schema.
+func (r *AWSCluster) validateLoadBalancerNames() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, lb := range []*AWSLoadBalancerSpec{r.Spec.ControlPlaneLoadBalancer, r.Spec.SecondaryControlPlaneLoadBalancer} {
+		name := "controlPlaneLoadBalancer"
+		if i == 1 {
+			name = "secondaryControlPlaneLoadBalancer"
+		}
+		if lb == nil || lb.Name == nil {
+			continue
+		}
+
+		if (lb.LoadBalancerType == LoadBalancerTypeNLB || lb.LoadBalancerType == LoadBalancerTypeALB) && strings.HasPrefix(*lb.Name, "internal-") {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", name, "name"), *lb.Name, "the name of Network and Application Load Balancers cannot begin with \"internal-\""))
+		}
+	}
+
+	return allErrs
+}
+
+// validateLoadBalancerIPAddressTypes ensures dual-stack load balancers are only requested for the load balancer
+// types supporting them. Since IPv6 is not supported in managed VPCs of unmanaged clusters, they also require the
+// VPC to be brought by the user, along with the IPv6 CIDR blocks of its subnets.
+func (r *AWSCluster) validateLoadBalancerIPAddressTypes() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, lb := range []*AWSLoadBalancerSpec{r.Spec.ControlPlaneLoadBalancer, r.Spec.SecondaryControlPlaneLoadBalancer} {
+		name := "controlPlaneLoadBalancer"
+		if i == 1 {
+			name = "secondaryControlPlaneLoadBalancer"
+		}
+		if lb == nil || ptr.Deref(lb.IPAddressType, LoadBalancerIPAddressTypeIPv4) != LoadBalancerIPAddressTypeDualStack {
+			continue
+		}
+
+		ipAddressTypePath := field.NewPath("spec", name, "ipAddressType")
+		switch {
+		case lb.LoadBalancerType != LoadBalancerTypeNLB && lb.LoadBalancerType != LoadBalancerTypeALB:
+			allErrs = append(allErrs, field.Invalid(ipAddressTypePath, *lb.IPAddressType, "dual-stack load balancers are only supported for Network and Application Load Balancers"))
+		case r.Spec.NetworkSpec.VPC.ID == "":
+			allErrs = append(allErrs, field.Invalid(ipAddressTypePath, *lb.IPAddressType, "dual-stack load balancers require an existing dual-stack VPC to be set in spec.network.vpc.id"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateLoadBalancerSecurityGroups ensures the ingress rules of the load balancers can be applied when their security groups are replaced.
+func (r *AWSCluster) validateLoadBalancerSecurityGroups() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, lb := range []*AWSLoadBalancerSpec{r.Spec.ControlPlaneLoadBalancer, r.Spec.SecondaryControlPlaneLoadBalancer} {
+		name := "controlPlaneLoadBalancer"
+		if i == 1 {
+			name = "secondaryControlPlaneLoadBalancer"
+		}
+		if lb == nil || len(lb.SecurityGroups) == 0 {
+			continue
+		}
+
+		if len(lb.IngressRules) > 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", name, "ingressRules"), lb.IngressRules, "ingress rules cannot be set when the security groups of the load balancer are replaced, add them to the security groups instead"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateLoadBalancerAccessLogs ensures the access logs have a bucket they can be delivered to, and only set the emit
+// interval of classic load balancers.
+func (r *AWSCluster) validateLoadBalancerAccessLogs() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, lb := range []*AWSLoadBalancerSpec{r.Spec.ControlPlaneLoadBalancer, r.Spec.SecondaryControlPlaneLoadBalancer} {
+		name := "controlPlaneLoadBalancer"
+		if i == 1 {
+			name = "secondaryControlPlaneLoadBalancer"
+		}
+		if lb == nil || lb.AccessLogs == nil {
+			continue
+		}
+		accessLogsPath := field.NewPath("spec", name, "accessLogs")
+
+		if lb.AccessLogs.Bucket == "" && r.Spec.S3Bucket == nil {
+			allErrs = append(allErrs, field.Required(accessLogsPath.Child("bucket"), "a bucket is required when spec.s3Bucket is not set"))
+		}
+
+		// Only network load balancers can deliver access logs to a bucket encrypted with a customer managed key.
+		deliveredToClusterBucket := r.Spec.S3Bucket != nil && (lb.AccessLogs.Bucket == "" || lb.AccessLogs.Bucket == r.Spec.S3Bucket.Name)
+		if deliveredToClusterBucket && r.Spec.S3Bucket.KMSKeyID != "" && lb.LoadBalancerType != LoadBalancerTypeNLB {
+			allErrs = append(allErrs, field.Forbidden(accessLogsPath, "access logs of classic and application load balancers cannot be delivered to spec.s3Bucket when it is encrypted with spec.s3Bucket.kmsKeyID"))
+		}
+
+		switch lb.LoadBalancerType {
+		case LoadBalancerTypeClassic, LoadBalancerTypeELB:
+		default:
+			if lb.AccessLogs.EmitInterval != nil {
+				allErrs = append(allErrs, field.Invalid(accessLogsPath.Child("emitInterval"), *lb.AccessLogs.EmitInterval, "emit interval is only supported for classic load balancers"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateEndpointServices ensures endpoint services are only requested for network load balancers.
+func (r *AWSCluster) validateEndpointServices() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, lb := range []*AWSLoadBalancerSpec{r.Spec.ControlPlaneLoadBalancer, r.Spec.SecondaryControlPlaneLoadBalancer} {
+		name := "controlPlaneLoadBalancer"
+		if i == 1 {
+			name = "secondaryControlPlaneLoadBalancer"
+		}
+		if lb == nil || lb.EndpointService == nil {
+			continue
+		}
+
+		if lb.LoadBalancerType != LoadBalancerTypeNLB {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", name, "endpointService"), lb.EndpointService, "endpoint services are only supported for Network Load Balancers"))
+		}
+
+		for j, principal := range lb.EndpointService.AllowedPrincipals {
+			if principal != "*" && !strings.HasPrefix(principal, "arn:") {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", name, "endpointService", "allowedPrincipals").Index(j), principal, "must be an ARN or \"*\""))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateLoadBalancerListeners ensures the listeners, certificates and listener rules match the load balancer types.
+// On update, the old cluster is given so that Application Load Balancers created before certificates were required
+// are still accepted, as long as they keep serving without a certificate.
+func (r *AWSCluster) validateLoadBalancerListeners(old *AWSCluster) field.ErrorList {
+	var allErrs field.ErrorList
+
+	var oldLBs [2]*AWSLoadBalancerSpec
+	if old != nil {
+		oldLBs = [2]*AWSLoadBalancerSpec{old.Spec.ControlPlaneLoadBalancer, old.Spec.SecondaryControlPlaneLoadBalancer}
+	}
+
+	for i, lb := range []*AWSLoadBalancerSpec{r.Spec.ControlPlaneLoadBalancer, r.Spec.SecondaryControlPlaneLoadBalancer} {
+		name := "controlPlaneLoadBalancer"
+		if i == 1 {
+			name = "secondaryControlPlaneLoadBalancer"
+		}
+		if lb == nil {
+			continue
+		}
+		lbPath := field.NewPath("spec", name)
+
+		isALB := lb.LoadBalancerType == LoadBalancerTypeALB
+		hasTLSListeners := false
+		for _, ln := range lb.AdditionalListeners {
+			if ln.Protocol == ELBProtocolTLS {
+				hasTLSListeners = true
+			}
+		}
+		switch {
+		case isALB && ptr.Deref(lb.CertificateARN, "") == "" && !isALBWithoutCertificate(oldLBs[i]):
+			allErrs = append(allErrs, field.Required(lbPath.Child("certificateArn"), "a certificate is required for Application Load Balancers"))
+		case hasTLSListeners && ptr.Deref(lb.CertificateARN, "") == "":
+			allErrs = append(allErrs, field.Required(lbPath.Child("certificateArn"), "a certificate is required for TLS listeners"))
+		case !isALB && !hasTLSListeners && lb.CertificateARN != nil:
+			allErrs = append(allErrs, field.Invalid(lbPath.Child("certificateArn"), *lb.CertificateARN, "certificates are only supported for Application Load Balancers and TLS listeners"))
+		}
+		if lb.CertificateARN == nil {
+			if len(lb.AdditionalCertificateARNs) > 0 {
+				allErrs = append(allErrs, field.Invalid(lbPath.Child("additionalCertificateArns"), lb.AdditionalCertificateARNs, "additional certificates require certificateArn to be set"))
+			}
+			if lb.SSLPolicy != nil {
+				allErrs = append(allErrs, field.Invalid(lbPath.Child("sslPolicy"), *lb.SSLPolicy, "a security policy requires certificateArn to be set"))
+			}
+		}
+
+		if isALB && lb.HealthCheckProtocol != nil && *lb.HealthCheckProtocol != ELBProtocolHTTP && *lb.HealthCheckProtocol != ELBProtocolHTTPS {
+			allErrs = append(allErrs, field.Invalid(lbPath.Child("healthCheckProtocol"), *lb.HealthCheckProtocol, "Application Load Balancers only support HTTP and HTTPS health checks"))
+		}
+
+		// Target groups are looked up by port and protocol, so listeners cannot share them.
+		targets := map[string]bool{}
+		for j, ln := range lb.AdditionalListeners {
+			lnPath := lbPath.Child("additionalListeners").Index(j)
+			target := fmt.Sprintf("%s:%d", ln.Protocol, ln.GetTargetPort())
+			if targets[target] {
+				allErrs = append(allErrs, field.Invalid(lnPath.Child("targetPort"), ln.GetTargetPort(), "additional listeners cannot forward to the same target port and protocol"))
+			}
+			targets[target] = true
+
+			isHTTP := ln.Protocol == ELBProtocolHTTP || ln.Protocol == ELBProtocolHTTPS
+			switch {
+			case isALB && !isHTTP:
+				allErrs = append(allErrs, field.Invalid(lnPath.Child("protocol"), ln.Protocol, "Application Load Balancers only support HTTP and HTTPS listeners"))
+			case !isALB && isHTTP:
+				allErrs = append(allErrs, field.Invalid(lnPath.Child("protocol"), ln.Protocol, "HTTP and HTTPS listeners are only supported for Application Load Balancers"))
+			case ln.Protocol == ELBProtocolTLS && lb.LoadBalancerType != LoadBalancerTypeNLB:
+				allErrs = append(allErrs, field.Invalid(lnPath.Child("protocol"), ln.Protocol, "TLS listeners are only supported for Network Load Balancers"))
+			}
+
+			if len(ln.Rules) > 0 && !isALB {
+				allErrs = append(allErrs, field.Invalid(lnPath.Child("rules"), ln.Rules, "listener rules are only supported for Application Load Balancers"))
+			}
+			for k, rule := range ln.Rules {
+				if len(rule.PathPatterns) == 0 && len(rule.HostHeaders) == 0 {
+					allErrs = append(allErrs, field.Required(lnPath.Child("rules").Index(k), "at least one of pathPatterns or hostHeaders is required"))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// isALBWithoutCertificate returns whether the load balancer is an Application Load Balancer without a certificate.
+func isALBWithoutCertificate(lb *AWSLoadBalancerSpec) bool {
+	return lb != nil && lb.LoadBalancerType == LoadBalancerTypeALB && ptr.Deref(lb.CertificateARN, "") == ""
+}
+
+func (r *AWSCluster) validateControlPlaneDNS() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.ControlPlaneDNS == nil {
+		return allErrs
+	}
+
+	if r.Spec.ControlPlaneLoadBalancer != nil && r.Spec.ControlPlaneLoadBalancer.LoadBalancerType == LoadBalancerTypeDisabled {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneDNS"), r.Spec.ControlPlaneDNS, "control plane DNS cannot be configured if the LoadBalancer reconciliation is disabled"))
+	}
+
+	if id := r.Spec.ControlPlaneDNS.HostedZoneID; id != "" && strings.ContainsAny(strings.TrimPrefix(id, "/hostedzone/"), "/.") {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneDNS", "hostedZoneID"), id, "must be a Route53 hosted zone ID, e.g. Z0123456789ABCDEFGHIJ"))
+	}
+
+	return allErrs
+}
+
 func (r *AWSCluster) validateControlPlaneLoadBalancerUpdate(oldlb, newlb *AWSLoadBalancerSpec) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -170,6 +583,13 @@ func (r *AWSCluster) validateControlPlaneLoadBalancerUpdate(oldlb, newlb *AWSLoa
 					newlb.Scheme, "field is immutable"),
 			)
 		}
+		// Target groups are created for the IP address type of the load balancer, and cannot be changed.
+		if !cmp.Equal(oldlb.IPAddressType, newlb.IPAddressType) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "ipAddressType"),
+					newlb.IPAddressType, "field is immutable"),
+			)
+		}
 		// The name must be defined when the AWSCluster is created. If it is not defined,
 		// then the controller generates a default name at runtime, but does not store it,
 		// so the name remains nil. In either case, the name cannot be changed.
@@ -179,6 +599,19 @@ func (r *AWSCluster) validateControlPlaneLoadBalancerUpdate(oldlb, newlb *AWSLoa
 					newlb.Name, "field is immutable"),
 			)
 		}
+		// The target group of a listener is created for its target port, and is not replaced when it changes.
+		oldTargetPorts := make(map[int64]int64, len(oldlb.AdditionalListeners))
+		for _, ln := range oldlb.AdditionalListeners {
+			oldTargetPorts[ln.Port] = ln.GetTargetPort()
+		}
+		for i, ln := range newlb.AdditionalListeners {
+			if targetPort, ok := oldTargetPorts[ln.Port]; ok && targetPort != ln.GetTargetPort() {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "additionalListeners").Index(i).Child("targetPort"),
+						ln.GetTargetPort(), "field is immutable"),
+				)
+			}
+		}
 	}
 
 	// Block the update for Protocol :
@@ -200,19 +633,24 @@ func (r *AWSCluster) Default() {
 }
 
 func (r *AWSCluster) validateGCTasksAnnotation() field.ErrorList {
-	var allErrs field.ErrorList
+	return ValidateGCTasksAnnotation(r.GetAnnotations())
+}
 
-	annotations := r.GetAnnotations()
-	if annotations == nil {
-		return nil
-	}
+// ValidateGCTasksAnnotation ensures the external resource GC tasks annotation only lists supported GC tasks.
+func ValidateGCTasksAnnotation(annotations map[string]string) field.ErrorList {
+	var allErrs field.ErrorList
 
 	if gcTasksAnnotationValue := annotations[ExternalResourceGCTasksAnnotation]; gcTasksAnnotationValue != "" {
 		gcTasks := strings.Split(gcTasksAnnotationValue, ",")
 
-		supportedGCTasks := []GCTask{GCTaskLoadBalancer, GCTaskTargetGroup, GCTaskSecurityGroup}
+		supportedGCTasks := []GCTask{GCTaskLoadBalancer, GCTaskTargetGroup, GCTaskSecurityGroup, GCTaskEBSVolume, GCTaskRoute53Record}
 
 		for _, gcTask := range gcTasks {
+			gcTask = strings.TrimSpace(gcTask)
+			if gcTask == "" {
+				continue
+			}
+
 			found := false
 
 			for _, supportedGCTask := range supportedGCTasks {
@@ -225,7 +663,7 @@ func (r *AWSCluster) validateGCTasksAnnotation() field.ErrorList {
 			if !found {
 				allErrs = append(allErrs,
 					field.Invalid(field.NewPath("metadata", "annotations"),
-						r.Annotations,
+						annotations,
 						fmt.Sprintf("annotation %s contains unsupported GC task %s", ExternalResourceGCTasksAnnotation, gcTask)),
 				)
 			}
@@ -263,10 +701,31 @@ func (r *AWSCluster) validateNetwork() field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("ipamPool"), r.Spec.NetworkSpec.VPC.IPAMPool, "ipamPool must have either id or name"))
 	}
 
+	if r.Spec.NetworkSpec.VPC.CidrBlock != "" {
+		if _, vpcCIDR, err := net.ParseCIDR(r.Spec.NetworkSpec.VPC.CidrBlock); err == nil {
+			vpcMaskSize, _ := vpcCIDR.Mask.Size()
+			if size := r.Spec.NetworkSpec.VPC.PublicSubnetMaskSize; size != nil && *size < vpcMaskSize {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "network", "vpc", "publicSubnetMaskSize"), *size, "publicSubnetMaskSize cannot be smaller than the VPC CIDR netmask size"))
+			}
+			if size := r.Spec.NetworkSpec.VPC.PrivateSubnetMaskSize; size != nil && *size < vpcMaskSize {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "network", "vpc", "privateSubnetMaskSize"), *size, "privateSubnetMaskSize cannot be smaller than the VPC CIDR netmask size"))
+			}
+		}
+	}
+
 	for _, rule := range r.Spec.NetworkSpec.AdditionalControlPlaneIngressRules {
 		allErrs = append(allErrs, r.validateIngressRule(rule)...)
 	}
 
+	if r.Spec.NetworkSpec.VPC.ExternalInternetGatewayID != nil && r.Spec.NetworkSpec.VPC.ID != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "network", "vpc", "externalInternetGatewayId"), "externalInternetGatewayId is only supported with a managed VPC"))
+	}
+
+	if r.Spec.NetworkSpec.PodNetwork != nil && r.Spec.NetworkSpec.VPC.ID != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "network", "podNetwork"), "podNetwork is only supported with a managed VPC"))
+	}
+	allErrs = append(allErrs, r.Spec.NetworkSpec.PodNetwork.Validate()...)
+
 	if r.Spec.NetworkSpec.VPC.ElasticIPPool != nil {
 		eipp := r.Spec.NetworkSpec.VPC.ElasticIPPool
 		if eipp.PublicIpv4Pool != nil {
@@ -296,7 +755,7 @@ func (r *AWSCluster) validateControlPlaneLBs() field.ErrorList {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "secondaryControlPlaneLoadBalancer", "name"), r.Spec.SecondaryControlPlaneLoadBalancer.Name, "secondary controlPlaneLoadBalancer.name cannot be empty"))
 		}
 
-		if r.Spec.SecondaryControlPlaneLoadBalancer.Name == r.Spec.ControlPlaneLoadBalancer.Name {
+		if r.Spec.ControlPlaneLoadBalancer != nil && ptr.Deref(r.Spec.SecondaryControlPlaneLoadBalancer.Name, "") == ptr.Deref(r.Spec.ControlPlaneLoadBalancer.Name, "") {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "secondaryControlPlaneLoadBalancer", "name"), r.Spec.SecondaryControlPlaneLoadBalancer.Name, "field must be different from controlPlaneLoadBalancer.name"))
 		}
 
@@ -325,6 +784,17 @@ func (r *AWSCluster) validateControlPlaneLBs() field.ErrorList {
 		}
 	}
 
+	allErrs = append(allErrs, r.validateEndpointServices()...)
+	allErrs = append(allErrs, r.validateLoadBalancerListeners(nil)...)
+	allErrs = append(allErrs, r.validateLoadBalancerTimeouts()...)
+	allErrs = append(allErrs, r.validateLoadBalancerTargetGroupAttributes()...)
+	allErrs = append(allErrs, r.validateLoadBalancerSecurityGroups()...)
+	allErrs = append(allErrs, r.validateLoadBalancerDeletionProtection()...)
+	allErrs = append(allErrs, r.validateLoadBalancerIPAddressTypes()...)
+	allErrs = append(allErrs, r.validateLoadBalancerNames()...)
+	allErrs = append(allErrs, r.validateLoadBalancerHealthChecks()...)
+	allErrs = append(allErrs, r.validateLoadBalancerAccessLogs()...)
+
 	if r.Spec.ControlPlaneLoadBalancer.LoadBalancerType == LoadBalancerTypeDisabled {
 		if r.Spec.ControlPlaneLoadBalancer.Name != nil {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "name"), r.Spec.ControlPlaneLoadBalancer.Name, "cannot configure a name if the LoadBalancer reconciliation is disabled"))
@@ -342,10 +812,18 @@ func (r *AWSCluster) validateControlPlaneLBs() field.ErrorList {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "healthCheckProtocol"), r.Spec.ControlPlaneLoadBalancer.HealthCheckProtocol, "healthcheck protocol cannot be set if the LoadBalancer reconciliation is disabled"))
 		}
 
+		if r.Spec.ControlPlaneLoadBalancer.HealthCheck != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "healthCheck"), r.Spec.ControlPlaneLoadBalancer.HealthCheck, "health check cannot be set if the LoadBalancer reconciliation is disabled"))
+		}
+
 		if len(r.Spec.ControlPlaneLoadBalancer.AdditionalSecurityGroups) > 0 {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "additionalSecurityGroups"), r.Spec.ControlPlaneLoadBalancer.AdditionalSecurityGroups, "additional Security Groups cannot be set if the LoadBalancer reconciliation is disabled"))
 		}
 
+		if len(r.Spec.ControlPlaneLoadBalancer.SecurityGroups) > 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "securityGroups"), r.Spec.ControlPlaneLoadBalancer.SecurityGroups, "security groups cannot be set if the LoadBalancer reconciliation is disabled"))
+		}
+
 		if len(r.Spec.ControlPlaneLoadBalancer.AdditionalListeners) > 0 {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "additionalListeners"), r.Spec.ControlPlaneLoadBalancer.AdditionalListeners, "cannot set additional listeners if the LoadBalancer reconciliation is disabled"))
 		}
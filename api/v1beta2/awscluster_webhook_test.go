@@ -112,6 +112,18 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "No options are allowed when LoadBalancer is disabled (securityGroups)",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						SecurityGroups:   []string{"foo"},
+						LoadBalancerType: LoadBalancerTypeDisabled,
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "No options are allowed when LoadBalancer is disabled (additionalListeners)",
 			cluster: &AWSCluster{
@@ -309,6 +321,33 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "accepts bucket with a custom policy",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					S3Bucket: &S3Bucket{
+						Name:                           "foo",
+						ControlPlaneIAMInstanceProfile: "foo",
+						NodesIAMInstanceProfiles:       []string{"bar"},
+						Policy:                         `{"Version":"2012-10-17","Statement":[{"Sid":"audit","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:role/audit"},"Action":"s3:GetObject","Resource":"arn:aws:s3:::foo/*"}]}`,
+					},
+				},
+			},
+		},
+		{
+			name: "rejects bucket with a custom policy that is not a policy document",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					S3Bucket: &S3Bucket{
+						Name:                           "foo",
+						ControlPlaneIAMInstanceProfile: "foo",
+						NodesIAMInstanceProfiles:       []string{"bar"},
+						Policy:                         "s3:GetObject",
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "does not return error when all IAM instance profiles are populated",
 			cluster: &AWSCluster{
@@ -638,6 +677,410 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Application Load Balancers require a certificate",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeALB,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Application Load Balancers do not support TCP listeners",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeALB,
+						CertificateARN:   ptr.To("arn:aws:acm:us-east-1:123456789012:certificate/abc"),
+						AdditionalListeners: []AdditionalListenerSpec{
+							{Port: 8443, Protocol: ELBProtocolTCP},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Proxy protocol is only supported for Network Load Balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeClassic,
+						ProxyProtocolV2:  true,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Network Load Balancer with proxy protocol and deregistration delay is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:           LoadBalancerTypeNLB,
+						ProxyProtocolV2:            true,
+						DeregistrationDelaySeconds: ptr.To[int64](30),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ingress rules are rejected when the load balancer security groups are replaced",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						SecurityGroups:   []string{"sg-1"},
+						IngressRules: []IngressRule{
+							{
+								Description: "Kubernetes API",
+								Protocol:    SecurityGroupProtocolTCP,
+								FromPort:    6443,
+								ToPort:      6443,
+								CidrBlocks:  []string{"10.0.0.0/8"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Load balancer security groups can be replaced",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:         LoadBalancerTypeNLB,
+						SecurityGroups:           []string{"sg-1"},
+						AdditionalSecurityGroups: []string{"sg-2"},
+						Subnets:                  []string{"subnet-1", "subnet-2"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Deletion protection is not supported for classic load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:   LoadBalancerTypeClassic,
+						DeletionProtection: true,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Application Load Balancer with deletion protection is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:   LoadBalancerTypeALB,
+						CertificateARN:     ptr.To("arn:aws:acm:us-east-1:123456789012:certificate/abc"),
+						DeletionProtection: true,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Listener rules are not supported for Network Load Balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AdditionalListeners: []AdditionalListenerSpec{
+							{Port: 8443, Protocol: ELBProtocolTCP, Rules: []ListenerRule{{Priority: 1, PathPatterns: []string{"/healthz"}}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Additional listeners cannot forward to the same target port",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AdditionalListeners: []AdditionalListenerSpec{
+							{Port: 22623, Protocol: ELBProtocolTCP},
+							{Port: 8132, Protocol: ELBProtocolTCP, TargetPort: ptr.To[int64](22623)},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Application Load Balancer with an HTTPS listener and rules is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeALB,
+						CertificateARN:   ptr.To("arn:aws:acm:us-east-1:123456789012:certificate/abc"),
+						AdditionalListeners: []AdditionalListenerSpec{
+							{Port: 8443, Protocol: ELBProtocolHTTPS, Rules: []ListenerRule{{Priority: 1, PathPatterns: []string{"/healthz"}}}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "TLS listeners require a certificate",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AdditionalListeners: []AdditionalListenerSpec{
+							{Port: 8443, Protocol: ELBProtocolTLS},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "TLS listeners are not supported for Application Load Balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeALB,
+						CertificateARN:   ptr.To("arn:aws:acm:us-east-1:123456789012:certificate/abc"),
+						AdditionalListeners: []AdditionalListenerSpec{
+							{Port: 8443, Protocol: ELBProtocolTLS},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Certificates are not supported for Network Load Balancers without TLS listeners",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						CertificateARN:   ptr.To("arn:aws:acm:us-east-1:123456789012:certificate/abc"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Security policies require a certificate",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						SSLPolicy:        ptr.To("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Network Load Balancer with a TLS listener, additional certificates and a security policy is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:          LoadBalancerTypeNLB,
+						CertificateARN:            ptr.To("arn:aws:acm:us-east-1:123456789012:certificate/abc"),
+						AdditionalCertificateARNs: []string{"arn:aws:acm:us-east-1:123456789012:certificate/def"},
+						SSLPolicy:                 ptr.To("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+						AdditionalListeners: []AdditionalListenerSpec{
+							{Port: 8443, Protocol: ELBProtocolTLS},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Dual-stack load balancers are not supported for classic load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{VPC: VPCSpec{ID: "vpc-1"}},
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeClassic,
+						IPAddressType:    ptr.To(LoadBalancerIPAddressTypeDualStack),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Dual-stack load balancers require an existing VPC",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IPAddressType:    ptr.To(LoadBalancerIPAddressTypeDualStack),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Dual-stack Network Load Balancer in an existing VPC is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{VPC: VPCSpec{ID: "vpc-1"}},
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IPAddressType:    ptr.To(LoadBalancerIPAddressTypeDualStack),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Network Load Balancer names cannot begin with internal-",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Name:             ptr.To("internal-apiserver"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Network Load Balancer name override is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Name:             ptr.To("acme-prod-k8s-api"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Secondary load balancer name must be different from the primary",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Name:             ptr.To("acme-k8s-api"),
+					},
+					SecondaryControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Name:             ptr.To("acme-k8s-api"),
+						Scheme:           &ELBSchemeInternal,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Health check success codes are accepted for HTTPS health checks of Network Load Balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:    LoadBalancerTypeNLB,
+						HealthCheckProtocol: &ELBProtocolHTTPS,
+						HealthCheck: &TargetGroupHealthCheckAPISpec{
+							SuccessCodes: ptr.To("200-399"),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Health check success codes require an HTTP or HTTPS health check",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						HealthCheck: &TargetGroupHealthCheckAPISpec{
+							SuccessCodes: ptr.To("200"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Health check success codes are not supported for classic load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:    LoadBalancerTypeClassic,
+						HealthCheckProtocol: &ELBProtocolHTTPS,
+						HealthCheck: &TargetGroupHealthCheckAPISpec{
+							SuccessCodes: ptr.To("200"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Health check success codes of Application Load Balancers must be below 500",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeALB,
+						CertificateARN:   ptr.To("arn:aws:acm:us-east-1:123456789012:certificate/1"),
+						HealthCheck: &TargetGroupHealthCheckAPISpec{
+							SuccessCodes: ptr.To("200,500-599"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Access logs require a bucket when the cluster bucket is not set",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AccessLogs:       &LoadBalancerAccessLogs{Prefix: "apiserver"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Access logs emit interval is not supported for Network Load Balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AccessLogs:       &LoadBalancerAccessLogs{Bucket: "logs", EmitInterval: ptr.To[int64](5)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Classic load balancer access logs to the cluster bucket are accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					S3Bucket: &S3Bucket{
+						Name:                           "cluster-bucket",
+						ControlPlaneIAMInstanceProfile: "control-plane.cluster-api-provider-aws.sigs.k8s.io",
+						NodesIAMInstanceProfiles:       []string{"nodes.cluster-api-provider-aws.sigs.k8s.io"},
+					},
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeClassic,
+						AccessLogs:       &LoadBalancerAccessLogs{EmitInterval: ptr.To[int64](5)},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -815,6 +1258,27 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "controlPlaneLoadBalancer ipAddressType is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{VPC: VPCSpec{ID: "vpc-1"}},
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{VPC: VPCSpec{ID: "vpc-1"}},
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IPAddressType:    ptr.To(LoadBalancerIPAddressTypeDualStack),
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "controlPlaneLoadBalancer crossZoneLoadBalancer is mutable",
 			oldCluster: &AWSCluster{
@@ -1057,6 +1521,71 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 	}
 }
 
+func TestAWSClusterValidateLoadBalancerListenersUpdate(t *testing.T) {
+	certificateARN := ptr.To("arn:aws:acm:us-east-1:123456789012:certificate/abc")
+
+	tests := []struct {
+		name    string
+		oldLB   *AWSLoadBalancerSpec
+		newLB   *AWSLoadBalancerSpec
+		wantErr bool
+	}{
+		{
+			name:  "Application Load Balancer without a certificate is kept",
+			oldLB: &AWSLoadBalancerSpec{LoadBalancerType: LoadBalancerTypeALB},
+			newLB: &AWSLoadBalancerSpec{
+				LoadBalancerType: LoadBalancerTypeALB,
+				IngressRules:     []IngressRule{{Description: "api", Protocol: SecurityGroupProtocolTCP, FromPort: 6443, ToPort: 6443, CidrBlocks: []string{"10.0.0.0/8"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "certificate of an Application Load Balancer cannot be removed",
+			oldLB:   &AWSLoadBalancerSpec{LoadBalancerType: LoadBalancerTypeALB, CertificateARN: certificateARN},
+			newLB:   &AWSLoadBalancerSpec{LoadBalancerType: LoadBalancerTypeALB},
+			wantErr: true,
+		},
+		{
+			name: "target port of an additional listener cannot be changed",
+			oldLB: &AWSLoadBalancerSpec{
+				LoadBalancerType:    LoadBalancerTypeNLB,
+				AdditionalListeners: []AdditionalListenerSpec{{Port: 8132, Protocol: ELBProtocolTCP}},
+			},
+			newLB: &AWSLoadBalancerSpec{
+				LoadBalancerType:    LoadBalancerTypeNLB,
+				AdditionalListeners: []AdditionalListenerSpec{{Port: 8132, Protocol: ELBProtocolTCP, TargetPort: ptr.To[int64](8134)}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "target port of an additional listener can be set to the port of the listener",
+			oldLB: &AWSLoadBalancerSpec{
+				LoadBalancerType:    LoadBalancerTypeNLB,
+				AdditionalListeners: []AdditionalListenerSpec{{Port: 8132, Protocol: ELBProtocolTCP}},
+			},
+			newLB: &AWSLoadBalancerSpec{
+				LoadBalancerType:    LoadBalancerTypeNLB,
+				AdditionalListeners: []AdditionalListenerSpec{{Port: 8132, Protocol: ELBProtocolTCP, TargetPort: ptr.To[int64](8132)}},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			oldCluster := &AWSCluster{Spec: AWSClusterSpec{ControlPlaneLoadBalancer: tt.oldLB}}
+			newCluster := &AWSCluster{Spec: AWSClusterSpec{ControlPlaneLoadBalancer: tt.newLB}}
+			_, err := newCluster.ValidateUpdate(oldCluster)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestAWSClusterDefaultCNIIngressRules(t *testing.T) {
 	AZUsageLimit := 3
 	defaultVPCSpec := VPCSpec{
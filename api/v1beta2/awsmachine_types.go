@@ -90,6 +90,18 @@ type AWSMachineSpec struct {
 	// image lookup the AMI is not set.
 	ImageLookupBaseOS string `json:"imageLookupBaseOS,omitempty"`
 
+	// ImageLookupArchitecture is the architecture of the AMI to look up if the AMI ID is not set, in place
+	// of the architecture picked from the instance type.
+	// +kubebuilder:validation:Enum:=x86_64;arm64
+	// +optional
+	ImageLookupArchitecture string `json:"imageLookupArchitecture,omitempty"`
+
+	// ImageLookupRootDeviceType is the root device type of the AMI to look up with the image lookup
+	// format, org and base OS. AMIs of any root device type are considered if not set.
+	// +kubebuilder:validation:Enum:=ebs;instance-store
+	// +optional
+	ImageLookupRootDeviceType string `json:"imageLookupRootDeviceType,omitempty"`
+
 	// InstanceType is the type of instance to create. Example: m4.xlarge
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength:=2
@@ -106,10 +118,9 @@ type AWSMachineSpec struct {
 	IAMInstanceProfile string `json:"iamInstanceProfile,omitempty"`
 
 	// PublicIP specifies whether the instance should get a public IP.
-	// Precedence for this setting is as follows:
-	// 1. This field if set
-	// 2. Cluster/flavor setting
-	// 3. Subnet default
+	// When unset, the instance inherits the default of its subnet. When true, the instance is placed
+	// in a public subnet and assigned a public IP. When false, no public IP is assigned, even if the
+	// subnet maps public IPs on launch.
 	// +optional
 	PublicIP *bool `json:"publicIP,omitempty"`
 
@@ -118,6 +129,13 @@ type AWSMachineSpec struct {
 	// +optional
 	ElasticIPPool *ElasticIPPool `json:"elasticIpPool,omitempty"`
 
+	// SourceDestCheck specifies whether source/destination checking is enabled on the primary network
+	// interface of the instance. It must be disabled for instances routing traffic they are not the source
+	// or destination of, e.g. when running Calico without encapsulation, Cilium egress gateways or NAT
+	// functions. Defaults to the EC2 default, which is enabled.
+	// +optional
+	SourceDestCheck *bool `json:"sourceDestCheck,omitempty"`
+
 	// AdditionalSecurityGroups is an array of references to security groups that should be applied to the
 	// instance. These security groups would be set in addition to any security groups defined
 	// at the cluster level or in the actuator. It is possible to specify either IDs of Filters. Using Filters
@@ -125,11 +143,24 @@ type AWSMachineSpec struct {
 	// +optional
 	AdditionalSecurityGroups []AWSResourceReference `json:"additionalSecurityGroups,omitempty"`
 
+	// AdditionalSecurityGroupSelectors selects additional security groups of the cluster VPC by tags.
+	// The selectors are resolved on every reconciliation, which keeps templates independent of the
+	// security group IDs of a given account. Each selector must match exactly one security group,
+	// otherwise the SecurityGroupsReady condition is set to false.
+	// +optional
+	AdditionalSecurityGroupSelectors []SecurityGroupSelector `json:"additionalSecurityGroupSelectors,omitempty"`
+
 	// Subnet is a reference to the subnet to use for this instance. If not specified,
 	// the cluster subnet will be used.
 	// +optional
 	Subnet *AWSResourceReference `json:"subnet,omitempty"`
 
+	// FailureDomainSelector restricts the failure domains and subnets the instance can be placed in.
+	// When unset, the instance is placed in an availability zone of the region, unless its failure
+	// domain or subnet is in a Local Zone or Wavelength Zone.
+	// +optional
+	FailureDomainSelector *FailureDomainSelector `json:"failureDomainSelector,omitempty"`
+
 	// SecurityGroupOverrides is an optional set of security groups to use for the node.
 	// This is optional - if not provided security groups from the cluster will be used.
 	// +optional
@@ -169,14 +200,29 @@ type AWSMachineSpec struct {
 	// +optional
 	Ignition *Ignition `json:"ignition,omitempty"`
 
+	// BootDiagnostics collects the console output of the instance when it does not become a node in time,
+	// to help debugging failures of the user data or of the boot of the instance.
+	// +optional
+	BootDiagnostics *BootDiagnostics `json:"bootDiagnostics,omitempty"`
+
 	// SpotMarketOptions allows users to configure instances to be run using AWS Spot instances.
 	// +optional
 	SpotMarketOptions *SpotMarketOptions `json:"spotMarketOptions,omitempty"`
 
+	// CapacityReservationTarget specifies the Capacity Reservation to launch the instance into.
+	// It cannot be used with SpotMarketOptions.
+	// +optional
+	CapacityReservationTarget *CapacityReservationTarget `json:"capacityReservationTarget,omitempty"`
+
 	// PlacementGroupName specifies the name of the placement group in which to launch the instance.
 	// +optional
 	PlacementGroupName string `json:"placementGroupName,omitempty"`
 
+	// PlacementGroup specifies the placement group in which to launch the instance, which is created
+	// if it does not exist. It cannot be used with PlacementGroupName.
+	// +optional
+	PlacementGroup *PlacementGroup `json:"placementGroup,omitempty"`
+
 	// PlacementGroupPartition is the partition number within the placement group in which to launch the instance.
 	// This value is only valid if the placement group, referred in `PlacementGroupName`, was created with
 	// strategy set to partition.
@@ -190,11 +236,53 @@ type AWSMachineSpec struct {
 	// +kubebuilder:validation:Enum:=default;dedicated;host
 	Tenancy string `json:"tenancy,omitempty"`
 
+	// HostID specifies the ID of the Dedicated Host on which to launch the instance.
+	// It is only valid with the host tenancy.
+	// +kubebuilder:validation:Pattern=`^h-[0-9a-f]+$`
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+
+	// HostResourceGroupARN specifies the ARN of the host resource group in which to launch the instance.
+	// It is only valid with the host tenancy, and cannot be used with HostID.
+	// +optional
+	HostResourceGroupARN *string `json:"hostResourceGroupArn,omitempty"`
+
+	// EnclaveOptions specifies the Nitro Enclaves options of the instance.
+	// +optional
+	EnclaveOptions *EnclaveOptions `json:"enclaveOptions,omitempty"`
+
+	// HibernationOptions specifies the hibernation options of the instance. Once hibernation is
+	// configured, the instance can be hibernated by setting the aws.cluster.x-k8s.io/hibernate
+	// annotation to "true" on the AWSMachine.
+	// +optional
+	HibernationOptions *HibernationOptions `json:"hibernationOptions,omitempty"`
+
+	// TerminationProtection protects the instance against accidental termination and stop through
+	// the EC2 API and console, by setting its DisableApiTermination and DisableApiStop attributes.
+	// The protection is removed by the controller before terminating the instance when the AWSMachine
+	// is deleted. Defaults to true for control plane machines, unless they use spot instances or
+	// hibernation which don't support it.
+	// +optional
+	TerminationProtection *bool `json:"terminationProtection,omitempty"`
+
 	// PrivateDNSName is the options for the instance hostname.
 	// +optional
 	PrivateDNSName *PrivateDNSName `json:"privateDnsName,omitempty"`
 }
 
+// BootDiagnostics defines the collection of boot diagnostics of an instance.
+type BootDiagnostics struct {
+	// NodeStartupTimeout is the time to wait for the running instance to become a node before
+	// collecting its boot diagnostics. Defaults to 10m.
+	// +optional
+	NodeStartupTimeout *metav1.Duration `json:"nodeStartupTimeout,omitempty"`
+
+	// Screenshot also collects a screenshot of the console of the instance.
+	// This is only supported by Nitro based instances.
+	// +optional
+	Screenshot bool `json:"screenshot,omitempty"`
+}
+
 // CloudInit defines options related to the bootstrapping systems where
 // CloudInit is used.
 type CloudInit struct {
@@ -214,7 +302,7 @@ type CloudInit struct {
 	// +optional
 	SecretPrefix string `json:"secretPrefix,omitempty"`
 
-	// SecureSecretsBackend, when set to parameter-store will utilize the AWS Systems Manager
+	// SecureSecretsBackend, when set to ssm-parameter-store will utilize the AWS Systems Manager
 	// Parameter Storage to distribute secrets. By default or with the value of secrets-manager,
 	// will use AWS Secrets Manager instead.
 	// +optional
@@ -324,6 +412,10 @@ type AWSMachineStatus struct {
 	// +optional
 	Interruptible bool `json:"interruptible,omitempty"`
 
+	// InstanceLifecycle is the purchasing option of the AWS instance for this machine, on-demand or spot.
+	// +optional
+	InstanceLifecycle InstanceLifecycle `json:"instanceLifecycle,omitempty"`
+
 	// Addresses contains the AWS instance associated addresses.
 	Addresses []clusterv1.MachineAddress `json:"addresses,omitempty"`
 
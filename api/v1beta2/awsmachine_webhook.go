@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"regexp"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -40,6 +42,16 @@ import (
 // log is for logging in this package.
 var log = ctrl.Log.WithName("awsmachine-resource")
 
+// Performance limits of the gp3 volumes.
+const (
+	gp3MinIOPS              = 3000
+	gp3MaxIOPS              = 80000
+	gp3MaxIOPSPerGiB        = 500
+	gp3MinThroughput        = 125
+	gp3MaxThroughput        = 2000
+	gp3MaxIOPSPerThroughput = 4
+)
+
 func (r *AWSMachine) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
@@ -66,6 +78,13 @@ func (r *AWSMachine) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateNetworkElasticIPPool()...)
+	allErrs = append(allErrs, ValidateCapacityReservationTarget(r.Spec.CapacityReservationTarget, r.Spec.SpotMarketOptions, field.NewPath("spec", "capacityReservationTarget"))...)
+	allErrs = append(allErrs, ValidateHostPlacement(r.Spec.Tenancy, r.Spec.HostID, r.Spec.HostResourceGroupARN, field.NewPath("spec"))...)
+	allErrs = append(allErrs, r.validatePlacementGroup()...)
+	allErrs = append(allErrs, ValidateAMIReference(r.Spec.AMI, field.NewPath("spec", "ami"))...)
+	allErrs = append(allErrs, ValidateImageLookupArchitecture(r.Spec.InstanceType, r.Spec.ImageLookupArchitecture, field.NewPath("spec", "imageLookupArchitecture"))...)
+	allErrs = append(allErrs, validateHibernationOptions(r.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateTerminationProtection(r.Spec, field.NewPath("spec"))...)
 
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -106,9 +125,15 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 	delete(oldAWSMachineSpec, "additionalTags")
 	delete(newAWSMachineSpec, "additionalTags")
 
-	// allow changes to additionalSecurityGroups
+	// allow changes to additionalSecurityGroups and additionalSecurityGroupSelectors
 	delete(oldAWSMachineSpec, "additionalSecurityGroups")
 	delete(newAWSMachineSpec, "additionalSecurityGroups")
+	delete(oldAWSMachineSpec, "additionalSecurityGroupSelectors")
+	delete(newAWSMachineSpec, "additionalSecurityGroupSelectors")
+
+	// allow changes to sourceDestCheck
+	delete(oldAWSMachineSpec, "sourceDestCheck")
+	delete(newAWSMachineSpec, "sourceDestCheck")
 
 	// allow changes to secretPrefix, secretCount, and secureSecretsBackend
 	if cloudInit, ok := oldAWSMachineSpec["cloudInit"].(map[string]interface{}); ok {
@@ -134,6 +159,11 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 		delete(privateDNSName, "enableResourceNameDnsARecord")
 	}
 
+	// allow instanceMetadataOptions to be defaulted on machines created without them
+	if _, ok := oldAWSMachineSpec["instanceMetadataOptions"]; !ok {
+		delete(newAWSMachineSpec, "instanceMetadataOptions")
+	}
+
 	if !cmp.Equal(oldAWSMachineSpec, newAWSMachineSpec) {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "cannot be modified"))
 	}
@@ -205,6 +235,16 @@ func (r *AWSMachine) validateIgnitionAndCloudInit() field.ErrorList {
 		}
 	}
 
+	// Proxy and TLS only apply to fetching the Ignition config from the cluster object store.
+	if r.Spec.Ignition.StorageType == IgnitionStorageTypeOptionUnencryptedUserData {
+		if r.Spec.Ignition.Proxy != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "ignition", "proxy"), "cannot be set if spec.ignition.storageType is UnencryptedUserData"))
+		}
+		if r.Spec.Ignition.TLS != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "ignition", "tls"), "cannot be set if spec.ignition.storageType is UnencryptedUserData"))
+		}
+	}
+
 	allErrs = append(allErrs, r.validateIgnitionProxy()...)
 	allErrs = append(allErrs, r.validateIgnitionTLS()...)
 
@@ -320,14 +360,8 @@ func (r *AWSMachine) validateRootVolume() field.ErrorList {
 		allErrs = append(allErrs, field.Required(field.NewPath("spec.rootVolume.iops"), "iops required if type is 'io1' or 'io2'"))
 	}
 
-	if r.Spec.RootVolume.Throughput != nil {
-		if r.Spec.RootVolume.Type != VolumeTypeGP3 {
-			allErrs = append(allErrs, field.Required(field.NewPath("spec.rootVolume.throughput"), "throughput is valid only for type 'gp3'"))
-		}
-		if *r.Spec.RootVolume.Throughput < 0 {
-			allErrs = append(allErrs, field.Required(field.NewPath("spec.rootVolume.throughput"), "throughput must be nonnegative"))
-		}
-	}
+	allErrs = append(allErrs, ValidateVolumePerformance(*r.Spec.RootVolume, field.NewPath("spec", "rootVolume"))...)
+	allErrs = append(allErrs, ValidateVolumeEncryption(*r.Spec.RootVolume, field.NewPath("spec", "rootVolume"))...)
 
 	if r.Spec.RootVolume.DeviceName != "" {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
@@ -369,14 +403,8 @@ func (r *AWSMachine) validateNonRootVolumes() field.ErrorList {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.iops"), "iops required if type is 'io1' or 'io2'"))
 		}
 
-		if volume.Throughput != nil {
-			if volume.Type != VolumeTypeGP3 {
-				allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.throughput"), "throughput is valid only for type 'gp3'"))
-			}
-			if *volume.Throughput < 0 {
-				allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.throughput"), "throughput must be nonnegative"))
-			}
-		}
+		allErrs = append(allErrs, ValidateVolumePerformance(volume, field.NewPath("spec", "nonRootVolumes"))...)
+		allErrs = append(allErrs, ValidateVolumeEncryption(volume, field.NewPath("spec", "nonRootVolumes"))...)
 
 		if volume.DeviceName == "" {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.deviceName"), "non root volume should have device name"))
@@ -405,6 +433,8 @@ func (r *AWSMachine) Default() {
 
 		r.Spec.Ignition.Version = DefaultIgnitionVersion
 	}
+
+	SetDefaults_AWSMachineSpec(&r.Spec)
 }
 
 func (r *AWSMachine) validateAdditionalSecurityGroups() field.ErrorList {
@@ -415,9 +445,216 @@ func (r *AWSMachine) validateAdditionalSecurityGroups() field.ErrorList {
 			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec.additionalSecurityGroups"), "only one of ID or Filters may be specified, specifying both is forbidden"))
 		}
 	}
+	for i, selector := range r.Spec.AdditionalSecurityGroupSelectors {
+		if len(selector.Tags) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "additionalSecurityGroupSelectors").Index(i).Child("tags"), "at least one tag must be specified"))
+		}
+	}
 	return allErrs
 }
 
 func (r *AWSMachine) validateSSHKeyName() field.ErrorList {
 	return validateSSHKeyName(r.Spec.SSHKeyName)
 }
+
+// ValidateCapacityReservationTarget validates the Capacity Reservation target of instances launched with
+// the given Spot market options.
+func ValidateCapacityReservationTarget(target *CapacityReservationTarget, spotMarketOptions *SpotMarketOptions, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if target == nil {
+		return allErrs
+	}
+
+	if (target.ID == nil) == (target.ResourceGroupARN == nil) {
+		allErrs = append(allErrs, field.Invalid(fldPath, target, "exactly one of id or resourceGroupArn must be specified"))
+	}
+	if spotMarketOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be used with spotMarketOptions"))
+	}
+	return allErrs
+}
+
+// ValidateHostPlacement validates the Dedicated Host, or host resource group, instances are launched on
+// with the given tenancy.
+func ValidateHostPlacement(tenancy string, hostID, hostResourceGroupARN *string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if hostID != nil && hostResourceGroupARN != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("hostResourceGroupArn"), "cannot be used with hostID"))
+	}
+	if hostID != nil && tenancy != ec2.TenancyHost {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostID"), *hostID, "can only be set with the host tenancy"))
+	}
+	if hostResourceGroupARN != nil && tenancy != ec2.TenancyHost {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostResourceGroupArn"), *hostResourceGroupARN, "can only be set with the host tenancy"))
+	}
+	return allErrs
+}
+
+// ValidateVolumePerformance validates the IOPS and throughput of the volume are within the limits of its type.
+// See: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/general-purpose.html#gp3-ebs-volume-type
+func ValidateVolumePerformance(volume Volume, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if volume.Throughput != nil {
+		if volume.Type != VolumeTypeGP3 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("throughput"), "throughput is valid only for type 'gp3'"))
+		}
+		if *volume.Throughput < 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("throughput"), "throughput must be nonnegative"))
+		}
+	}
+
+	if volume.Type != VolumeTypeGP3 {
+		return allErrs
+	}
+
+	iops := volume.IOPS
+	if iops != 0 {
+		if iops < gp3MinIOPS || iops > gp3MaxIOPS {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("iops"), iops, fmt.Sprintf("iops must be between %d and %d for type 'gp3'", gp3MinIOPS, gp3MaxIOPS)))
+		}
+		if volume.Size > 0 && iops > gp3MaxIOPSPerGiB*volume.Size {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("iops"), iops, fmt.Sprintf("iops must be at most %d per GiB of size for type 'gp3'", gp3MaxIOPSPerGiB)))
+		}
+	} else {
+		iops = gp3MinIOPS
+	}
+
+	if volume.Throughput != nil && *volume.Throughput >= 0 {
+		throughput := *volume.Throughput
+		if throughput < gp3MinThroughput || throughput > gp3MaxThroughput {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("throughput"), throughput, fmt.Sprintf("throughput must be between %d and %d MiB/s for type 'gp3'", gp3MinThroughput, gp3MaxThroughput)))
+		} else if throughput > iops/gp3MaxIOPSPerThroughput {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("throughput"), throughput, fmt.Sprintf("throughput must be at most 0.25 MiB/s per provisioned iops for type 'gp3', %d iops allow up to %d MiB/s", iops, iops/gp3MaxIOPSPerThroughput)))
+		}
+	}
+
+	return allErrs
+}
+
+// kmsKeyPattern matches the KMS key IDs, key ARNs, alias names and alias ARNs.
+var kmsKeyPattern = regexp.MustCompile(`^((arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:)?alias/[a-zA-Z0-9/_-]+|(arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/)?([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|mrk-[0-9a-f]{32}))$`)
+
+// ValidateVolumeEncryption validates the KMS key of the volume. Whether the key exists and can be used is
+// checked by the controller, before the instances are launched.
+func ValidateVolumeEncryption(volume Volume, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if volume.EncryptionKey == "" {
+		return allErrs
+	}
+
+	if !kmsKeyPattern.MatchString(volume.EncryptionKey) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("encryptionKey"), volume.EncryptionKey, "must be a KMS key ID, key ARN, alias name or alias ARN"))
+	}
+	if volume.Encrypted != nil && !*volume.Encrypted {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("encrypted"), *volume.Encrypted, "must be true when encryptionKey is set"))
+	}
+	return allErrs
+}
+
+// ValidateAMIReference validates that the AMI is looked up in a single way.
+func ValidateAMIReference(ami AMIReference, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if ami.SSMParameter != nil {
+		if ami.ID != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ssmParameter"), "cannot be used with id"))
+		}
+		if len(ami.Filters) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ssmParameter"), "cannot be used with filters"))
+		}
+	}
+
+	if ami.MarketplaceProductCode != nil {
+		if ami.ID != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("marketplaceProductCode"), "cannot be used with id"))
+		}
+		if len(ami.Filters) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("marketplaceProductCode"), "cannot be used with filters"))
+		}
+		if ami.SSMParameter != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("marketplaceProductCode"), "cannot be used with ssmParameter"))
+		}
+	}
+	return allErrs
+}
+
+// gravitonInstanceFamilyPattern matches the families of the instance types with AWS Graviton processors, whose
+// attributes include a "g" after the generation, such as m6g, c7gn or im4gn, and of the A1 instance types.
+var gravitonInstanceFamilyPattern = regexp.MustCompile(`^([a-z]+[0-9]+[a-z]*g[a-z]*|a1)$`)
+
+// ValidateImageLookupArchitecture validates that the architecture of the AMI to look up is supported by the instance type.
+func ValidateImageLookupArchitecture(instanceType, architecture string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if instanceType == "" || architecture == "" {
+		return allErrs
+	}
+
+	family, _, _ := strings.Cut(instanceType, ".")
+	if gravitonInstanceFamilyPattern.MatchString(family) && architecture != ec2.ArchitectureTypeArm64 {
+		allErrs = append(allErrs, field.Invalid(fldPath, architecture, fmt.Sprintf("must be %s for the %s instance type", ec2.ArchitectureTypeArm64, instanceType)))
+	}
+	return allErrs
+}
+
+// ValidatePlacementGroup validates the placement group instances are launched in.
+func ValidatePlacementGroup(placementGroup *PlacementGroup, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if placementGroup == nil {
+		return allErrs
+	}
+
+	if placementGroup.Strategy != PlacementGroupStrategyPartition {
+		if placementGroup.PartitionCount != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("partitionCount"), placementGroup.PartitionCount, "can only be set with the partition strategy"))
+		}
+		if placementGroup.Partition != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("partition"), placementGroup.Partition, "can only be set with the partition strategy"))
+		}
+	}
+	if placementGroup.PartitionCount != 0 && placementGroup.Partition > placementGroup.PartitionCount {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("partition"), placementGroup.Partition, "cannot be greater than partitionCount"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachine) validatePlacementGroup() field.ErrorList {
+	allErrs := ValidatePlacementGroup(r.Spec.PlacementGroup, field.NewPath("spec", "placementGroup"))
+	if r.Spec.PlacementGroup != nil && (r.Spec.PlacementGroupName != "" || r.Spec.PlacementGroupPartition != 0) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "placementGroup"), "cannot be used with placementGroupName or placementGroupPartition"))
+	}
+	return allErrs
+}
+
+// validateHibernationOptions validates that instances with hibernation configured have an encrypted root volume.
+func validateHibernationOptions(spec AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !spec.HibernationOptions.IsConfigured() {
+		return allErrs
+	}
+
+	if spec.RootVolume == nil || (!ptr.Deref(spec.RootVolume.Encrypted, false) && spec.RootVolume.EncryptionKey == "") {
+		allErrs = append(allErrs, field.Required(fldPath.Child("rootVolume", "encrypted"), "the root volume must be encrypted to configure hibernation"))
+	}
+	if spec.EnclaveOptions.IsEnabled() {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("hibernationOptions"), "cannot be configured with Nitro Enclaves enabled"))
+	}
+	return allErrs
+}
+
+// validateTerminationProtection validates that termination protection is only enabled on instances that support it.
+// Protected instances cannot be stopped through the EC2 API, so they cannot be hibernated either.
+func validateTerminationProtection(spec AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !ptr.Deref(spec.TerminationProtection, false) {
+		return allErrs
+	}
+
+	if spec.SpotMarketOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("terminationProtection"), "cannot be enabled for spot instances"))
+	}
+	if spec.HibernationOptions.IsConfigured() {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("terminationProtection"), "cannot be enabled with hibernation configured"))
+	}
+	return allErrs
+}
@@ -37,6 +37,12 @@ func TestMachineDefault(t *testing.T) {
 	machine.Default()
 	g := NewWithT(t)
 	g.Expect(machine.Spec.CloudInit.SecureSecretsBackend).To(Equal(SecretBackendSecretsManager))
+	g.Expect(machine.Spec.InstanceMetadataOptions).To(Equal(&InstanceMetadataOptions{
+		HTTPEndpoint:            InstanceMetadataEndpointStateEnabled,
+		HTTPPutResponseHopLimit: 1,
+		HTTPTokens:              HTTPTokensStateRequired,
+		InstanceMetadataTags:    InstanceMetadataEndpointStateDisabled,
+	}))
 }
 
 func TestAWSMachineCreate(t *testing.T) {
@@ -81,6 +87,83 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ensure gp3 root volume throughput and IOPS within the limits work",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Size:       100,
+						Type:       VolumeTypeGP3,
+						IOPS:       4000,
+						Throughput: aws.Int64(1000),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure gp3 root volume throughput is within the limits",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Size:       100,
+						Type:       VolumeTypeGP3,
+						Throughput: aws.Int64(100),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure gp3 root volume throughput is at most a quarter of the IOPS",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Size:       100,
+						Type:       VolumeTypeGP3,
+						Throughput: aws.Int64(1000),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure gp3 non root volume IOPS is within the limits",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []Volume{
+						{
+							DeviceName: "/dev/sdb",
+							Size:       100,
+							Type:       VolumeTypeGP3,
+							IOPS:       90000,
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure gp3 non root volume IOPS is at most 500 per GiB",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []Volume{
+						{
+							DeviceName: "/dev/sdb",
+							Size:       8,
+							Type:       VolumeTypeGP3,
+							IOPS:       5000,
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "ensure root volume with device name works (for clusterctl move)",
 			machine: &AWSMachine{
@@ -95,6 +178,232 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "ensure hibernation is configured with an encrypted root volume",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					HibernationOptions: &HibernationOptions{Configured: true},
+					RootVolume: &Volume{
+						Size:      16,
+						Encrypted: aws.Bool(true),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure hibernation is configured with a root volume encrypted by a customer managed key",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					HibernationOptions: &HibernationOptions{Configured: true},
+					RootVolume: &Volume{
+						Size:          16,
+						EncryptionKey: "alias/my-key",
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure volumes accept KMS key IDs, key ARNs, alias names and alias ARNs",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Size:          16,
+						EncryptionKey: "1234abcd-12ab-34cd-56ef-1234567890ab",
+					},
+					NonRootVolumes: []Volume{
+						{
+							DeviceName:    "/dev/sdb",
+							Size:          16,
+							EncryptionKey: "arn:aws:kms:us-east-1:123456789012:key/mrk-1234abcd12ab34cd56ef1234567890ab",
+						},
+						{
+							DeviceName:    "/dev/sdc",
+							Size:          16,
+							EncryptionKey: "alias/etcd",
+						},
+						{
+							DeviceName:    "/dev/sdd",
+							Size:          16,
+							Encrypted:     aws.Bool(true),
+							EncryptionKey: "arn:aws-us-gov:kms:us-gov-west-1:123456789012:alias/containerd",
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure volumes reject invalid KMS keys",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []Volume{
+						{
+							DeviceName:    "/dev/sdb",
+							Size:          16,
+							EncryptionKey: "my-key",
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure volumes with a KMS key are not requested unencrypted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Size:          16,
+						Encrypted:     aws.Bool(false),
+						EncryptionKey: "alias/my-key",
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure hibernation is not configured without an encrypted root volume",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					HibernationOptions: &HibernationOptions{Configured: true},
+					RootVolume: &Volume{
+						Size: 16,
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure hibernation is not configured with Nitro Enclaves enabled",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					HibernationOptions: &HibernationOptions{Configured: true},
+					EnclaveOptions:     &EnclaveOptions{Enabled: true},
+					RootVolume: &Volume{
+						Size:      16,
+						Encrypted: aws.Bool(true),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure termination protection can be enabled",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					TerminationProtection: aws.Bool(true),
+					InstanceType:          "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure termination protection is not enabled for spot instances",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					TerminationProtection: aws.Bool(true),
+					SpotMarketOptions:     &SpotMarketOptions{},
+					InstanceType:          "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure termination protection is not enabled with hibernation configured",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					TerminationProtection: aws.Bool(true),
+					HibernationOptions:    &HibernationOptions{Configured: true},
+					RootVolume: &Volume{
+						Size:      16,
+						Encrypted: aws.Bool(true),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure a placement group partition is only specified with the partition strategy",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					PlacementGroup: &PlacementGroup{
+						Name:      "etcd",
+						Strategy:  PlacementGroupStrategySpread,
+						Partition: 2,
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure a placement group cannot be used with placementGroupName",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					PlacementGroup: &PlacementGroup{
+						Name:     "etcd",
+						Strategy: PlacementGroupStrategySpread,
+					},
+					PlacementGroupName: "etcd",
+					InstanceType:       "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure a Dedicated Host is only specified with the host tenancy",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					HostID:       aws.String("h-0123456789abcdef0"),
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure a Dedicated Host and a host resource group are not both specified",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Tenancy:              "host",
+					HostID:               aws.String("h-0123456789abcdef0"),
+					HostResourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/my-hosts"),
+					InstanceType:         "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure a Capacity Reservation ID or resource group is specified",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CapacityReservationTarget: &CapacityReservationTarget{},
+					InstanceType:              "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure a Capacity Reservation cannot be targeted by spot instances",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					SpotMarketOptions: &SpotMarketOptions{},
+					CapacityReservationTarget: &CapacityReservationTarget{
+						ID: aws.String("cr-0123456789abcdef0"),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "ensure non root volume have device names",
 			machine: &AWSMachine{
@@ -204,6 +513,117 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ami may be resolved from an SSM parameter",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI: AMIReference{
+						SSMParameter: aws.String("/aws/service/bottlerocket/aws-k8s-1.29/x86_64/latest/image_id"),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ami can't have both id and SSM parameter",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI: AMIReference{
+						ID:           aws.String("ami-1234567890"),
+						SSMParameter: aws.String("/aws/service/bottlerocket/aws-k8s-1.29/x86_64/latest/image_id"),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ami can't have both filters and SSM parameter",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI: AMIReference{
+						Filters: []Filter{
+							{
+								Name:   "name",
+								Values: []string{"ubuntu-*"},
+							},
+						},
+						SSMParameter: aws.String("/aws/service/bottlerocket/aws-k8s-1.29/x86_64/latest/image_id"),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ami may be resolved from an AWS Marketplace product code",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI: AMIReference{
+						MarketplaceProductCode: aws.String("prod-abc123"),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ami can't have both id and AWS Marketplace product code",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI: AMIReference{
+						ID:                     aws.String("ami-1234567890"),
+						MarketplaceProductCode: aws.String("prod-abc123"),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ami can't have both SSM parameter and AWS Marketplace product code",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI: AMIReference{
+						SSMParameter:           aws.String("/aws/service/bottlerocket/aws-k8s-1.29/x86_64/latest/image_id"),
+						MarketplaceProductCode: aws.String("prod-abc123"),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "arm64 image lookup architecture is allowed with Graviton instance types",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					ImageLookupArchitecture: "arm64",
+					InstanceType:            "m6g.large",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "x86_64 image lookup architecture is not allowed with Graviton instance types",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					ImageLookupArchitecture: "x86_64",
+					InstanceType:            "c7gn.xlarge",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "x86_64 image lookup architecture is allowed with GPU instance types",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					ImageLookupArchitecture: "x86_64",
+					InstanceType:            "g4dn.xlarge",
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid additional tags are accepted",
 			machine: &AWSMachine{
@@ -268,6 +688,25 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "cannot use ignition proxy and TLS with unencrypted user data",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					Ignition: &Ignition{
+						Version:     "3.1",
+						StorageType: IgnitionStorageTypeOptionUnencryptedUserData,
+						Proxy: &IgnitionProxy{
+							HTTPProxy: ptr.To("http://proxy.example.com:3128"),
+						},
+						TLS: &IgnitionTLS{
+							CASources: []IgnitionCASource{"s3://example.com/ca.pem"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "ignition tls with invalid CASources URL",
 			machine: &AWSMachine{
@@ -492,6 +931,26 @@ func TestAWSMachineUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "change in instance metadata options",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					InstanceMetadataOptions: &InstanceMetadataOptions{
+						HTTPTokens: HTTPTokensStateOptional,
+					},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					InstanceMetadataOptions: &InstanceMetadataOptions{
+						HTTPTokens: HTTPTokensStateRequired,
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "change in fields other than providerid, tags and securitygroups",
 			oldMachine: &AWSMachine{
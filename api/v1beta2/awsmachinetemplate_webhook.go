@@ -60,14 +60,8 @@ func (r *AWSMachineTemplate) validateRootVolume() field.ErrorList {
 		allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.rootVolume.iops"), "iops required if type is 'io1' or 'io2'"))
 	}
 
-	if spec.RootVolume.Throughput != nil {
-		if spec.RootVolume.Type != VolumeTypeGP3 {
-			allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.rootVolume.throughput"), "throughput is valid only for type 'gp3'"))
-		}
-		if *spec.RootVolume.Throughput < 0 {
-			allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.rootVolume.throughput"), "throughput must be nonnegative"))
-		}
-	}
+	allErrs = append(allErrs, ValidateVolumePerformance(*spec.RootVolume, field.NewPath("spec", "template", "spec", "rootVolume"))...)
+	allErrs = append(allErrs, ValidateVolumeEncryption(*spec.RootVolume, field.NewPath("spec", "template", "spec", "rootVolume"))...)
 
 	if spec.RootVolume.DeviceName != "" {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
@@ -86,14 +80,8 @@ func (r *AWSMachineTemplate) validateNonRootVolumes() field.ErrorList {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.iops"), "iops required if type is 'io1' or 'io2'"))
 		}
 
-		if volume.Throughput != nil {
-			if volume.Type != VolumeTypeGP3 {
-				allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.throughput"), "throughput is valid only for type 'gp3'"))
-			}
-			if *volume.Throughput < 0 {
-				allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.throughput"), "throughput must be nonnegative"))
-			}
-		}
+		allErrs = append(allErrs, ValidateVolumePerformance(volume, field.NewPath("spec", "template", "spec", "nonRootVolumes"))...)
+		allErrs = append(allErrs, ValidateVolumeEncryption(volume, field.NewPath("spec", "template", "spec", "nonRootVolumes"))...)
 
 		if volume.DeviceName == "" {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.deviceName"), "non root volume should have device name"))
@@ -113,6 +101,21 @@ func (r *AWSMachineTemplate) validateAdditionalSecurityGroups() field.ErrorList
 			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "additionalSecurityGroups"), "only one of ID or Filters may be specified, specifying both is forbidden"))
 		}
 	}
+	for i, selector := range spec.AdditionalSecurityGroupSelectors {
+		if len(selector.Tags) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "template", "spec", "additionalSecurityGroupSelectors").Index(i).Child("tags"), "at least one tag must be specified"))
+		}
+	}
+	return allErrs
+}
+
+func (r *AWSMachineTemplate) validatePlacementGroup() field.ErrorList {
+	spec := r.Spec.Template.Spec
+	fldPath := field.NewPath("spec", "template", "spec", "placementGroup")
+	allErrs := ValidatePlacementGroup(spec.PlacementGroup, fldPath)
+	if spec.PlacementGroup != nil && (spec.PlacementGroupName != "" || spec.PlacementGroupPartition != 0) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be used with placementGroupName or placementGroupPartition"))
+	}
 	return allErrs
 }
 
@@ -204,6 +207,13 @@ func (r *AWSMachineTemplateWebhook) ValidateCreate(_ context.Context, raw runtim
 	allErrs = append(allErrs, obj.validateSSHKeyName()...)
 	allErrs = append(allErrs, obj.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, obj.Spec.Template.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, ValidateCapacityReservationTarget(spec.CapacityReservationTarget, spec.SpotMarketOptions, field.NewPath("spec", "template", "spec", "capacityReservationTarget"))...)
+	allErrs = append(allErrs, ValidateHostPlacement(spec.Tenancy, spec.HostID, spec.HostResourceGroupARN, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, obj.validatePlacementGroup()...)
+	allErrs = append(allErrs, ValidateAMIReference(spec.AMI, field.NewPath("spec", "template", "spec", "ami"))...)
+	allErrs = append(allErrs, ValidateImageLookupArchitecture(spec.InstanceType, spec.ImageLookupArchitecture, field.NewPath("spec", "template", "spec", "imageLookupArchitecture"))...)
+	allErrs = append(allErrs, validateHibernationOptions(spec, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, validateTerminationProtection(spec, field.NewPath("spec", "template", "spec"))...)
 
 	return nil, aggregateObjErrors(obj.GroupVersionKind().GroupKind(), obj.Name, allErrs)
 }
@@ -128,7 +128,7 @@ func TestAWSMachineTemplateValidateUpdate(t *testing.T) {
 							InstanceMetadataOptions: &InstanceMetadataOptions{
 								HTTPEndpoint:            InstanceMetadataEndpointStateEnabled,
 								HTTPPutResponseHopLimit: 1,
-								HTTPTokens:              HTTPTokensStateOptional,
+								HTTPTokens:              HTTPTokensStateRequired,
 								InstanceMetadataTags:    InstanceMetadataEndpointStateDisabled,
 							},
 						},
@@ -152,18 +152,38 @@ const (
 	InstanceTerminatedReason = "InstanceTerminated"
 	// InstanceStoppedReason instance is in a stopped state.
 	InstanceStoppedReason = "InstanceStopped"
+	// InstanceHibernatedReason instance is hibernated, as requested by the hibernate annotation.
+	InstanceHibernatedReason = "InstanceHibernated"
 	// InstanceNotReadyReason used when the instance is in a pending state.
 	InstanceNotReadyReason = "InstanceNotReady"
 	// InstanceProvisionStartedReason set when the provisioning of an instance started.
 	InstanceProvisionStartedReason = "InstanceProvisionStarted"
 	// InstanceProvisionFailedReason used for failures during instance provisioning.
 	InstanceProvisionFailedReason = "InstanceProvisionFailed"
+	// CapacityReservationExhaustedReason used when the instance cannot be provisioned because the targeted
+	// Capacity Reservation does not have enough available capacity.
+	CapacityReservationExhaustedReason = "CapacityReservationExhausted"
+	// MarketplaceSubscriptionRequiredReason used when the instance cannot be provisioned because the AWS account
+	// is not subscribed to the AWS Marketplace product of the AMI.
+	MarketplaceSubscriptionRequiredReason = "MarketplaceSubscriptionRequired"
 	// WaitingForClusterInfrastructureReason used when machine is waiting for cluster infrastructure to be ready before proceeding.
 	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
 	// WaitingForBootstrapDataReason used when machine is waiting for bootstrap data to be ready before proceeding.
 	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
 )
 
+const (
+	// AMINotDeprecatedCondition reports whether the AMI the instance was launched from is deprecated, or will be
+	// deprecated within the warning window of the controller. It is informational and does not affect the readiness
+	// of the machine.
+	AMINotDeprecatedCondition clusterv1.ConditionType = "AMINotDeprecated"
+
+	// AMIDeprecatedReason used when the AMI of the instance is deprecated.
+	AMIDeprecatedReason = "AMIDeprecated"
+	// AMIDeprecationScheduledReason used when the AMI of the instance will be deprecated within the warning window.
+	AMIDeprecationScheduledReason = "AMIDeprecationScheduled"
+)
+
 const (
 	// SecurityGroupsReadyCondition indicates the security groups are up to date on the AWSMachine.
 	SecurityGroupsReadyCondition clusterv1.ConditionType = "SecurityGroupsReady"
@@ -192,3 +212,26 @@ const (
 	// S3BucketFailedReason is used when any errors occur during reconciliation of an S3 bucket.
 	S3BucketFailedReason = "S3BucketCreationFailed"
 )
+
+const (
+	// ControlPlaneDNSReadyCondition indicates the control plane Route53 record has been reconciled successfully.
+	ControlPlaneDNSReadyCondition clusterv1.ConditionType = "ControlPlaneDNSReady"
+
+	// WaitForControlPlaneLoadBalancerReason is used while waiting for the control plane load balancer to be ready.
+	WaitForControlPlaneLoadBalancerReason = "WaitForControlPlaneLoadBalancer"
+	// ControlPlaneDNSFailedReason is used when any errors occur during reconciliation of the control plane Route53 record.
+	ControlPlaneDNSFailedReason = "ControlPlaneDNSFailed"
+)
+
+const (
+	// AsyncOperationsReadyCondition reports whether the long-running AWS operations of a resource, such as the creation
+	// of NAT gateways, EKS control plane updates or ASG instance refreshes, complete within their deadline.
+	AsyncOperationsReadyCondition clusterv1.ConditionType = "AsyncOperationsReady"
+
+	// NatGatewaysCreationTimedOutReason is used when NAT gateways stay pending past their deadline.
+	NatGatewaysCreationTimedOutReason = "NatGatewaysCreationTimedOut"
+	// EKSControlPlaneUpdateTimedOutReason is used when an EKS control plane update is in progress past its deadline.
+	EKSControlPlaneUpdateTimedOutReason = "EKSControlPlaneUpdateTimedOut"
+	// InstanceRefreshTimedOutReason is used when an ASG instance refresh is in progress past its deadline.
+	InstanceRefreshTimedOutReason = "InstanceRefreshTimedOut"
+)
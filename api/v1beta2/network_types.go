@@ -18,6 +18,7 @@ package v1beta2
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"time"
 
@@ -41,6 +42,8 @@ const (
 	DefaultAPIServerHealthThresholdCount = 5
 	// DefaultAPIServerUnhealthThresholdCount the API server unhealthy check threshold count.
 	DefaultAPIServerUnhealthThresholdCount = 3
+	// DefaultAPIServerClassicELBIdleTimeoutSec the API server classic load balancer idle timeout in seconds.
+	DefaultAPIServerClassicELBIdleTimeoutSec = 600
 
 	// ZoneTypeAvailabilityZone defines the regular AWS zones in the Region.
 	ZoneTypeAvailabilityZone ZoneType = "availability-zone"
@@ -48,6 +51,12 @@ const (
 	ZoneTypeLocalZone ZoneType = "local-zone"
 	// ZoneTypeWavelengthZone defines the AWS zone type in Wavelength infrastructure.
 	ZoneTypeWavelengthZone ZoneType = "wavelength-zone"
+
+	// FailureDomainZoneTypeAttribute is the failure domain attribute holding the type of the zone.
+	FailureDomainZoneTypeAttribute = "zone-type"
+	// FailureDomainParentZoneNameAttribute is the failure domain attribute holding the name of the parent zone
+	// of Local Zones and Wavelength Zones.
+	FailureDomainParentZoneNameAttribute = "parent-zone-name"
 )
 
 // NetworkStatus encapsulates AWS networking resources.
@@ -63,6 +72,41 @@ type NetworkStatus struct {
 
 	// NatGatewaysIPs contains the public IPs of the NAT Gateways
 	NatGatewaysIPs []string `json:"natGatewaysIPs,omitempty"`
+
+	// ControlPlaneDNS is the Route53 hosted zone and record publishing the control plane endpoint.
+	// +optional
+	ControlPlaneDNS *ControlPlaneDNSStatus `json:"controlPlaneDNS,omitempty"`
+
+	// PodSubnets are the subnets reserved for pod IPs, to be referenced from ENIConfig resources.
+	// +optional
+	PodSubnets []PodSubnetStatus `json:"podSubnets,omitempty"`
+}
+
+// PodSubnetStatus defines the observed state of a pod subnet.
+type PodSubnetStatus struct {
+	// ID is the subnet ID.
+	ID string `json:"id"`
+
+	// AvailabilityZone is the availability zone of the subnet.
+	AvailabilityZone string `json:"availabilityZone"`
+
+	// CidrBlock is the IPv4 CIDR block of the subnet.
+	CidrBlock string `json:"cidrBlock"`
+}
+
+// ControlPlaneDNSStatus defines the observed state of the control plane Route53 record.
+type ControlPlaneDNSStatus struct {
+	// HostedZoneID is the ID of the Route53 hosted zone holding the control plane record.
+	HostedZoneID string `json:"hostedZoneID,omitempty"`
+
+	// RecordName is the fully qualified name of the control plane record.
+	RecordName string `json:"recordName,omitempty"`
+
+	// InternalHostedZoneID is the ID of the private hosted zone holding the control plane record
+	// pointing at the internal secondary control plane load balancer, when the record of
+	// HostedZoneID points at the internet-facing one.
+	// +optional
+	InternalHostedZoneID string `json:"internalHostedZoneID,omitempty"`
 }
 
 // ELBScheme defines the scheme of a load balancer.
@@ -91,6 +135,17 @@ func (e ELBScheme) Equals(other *ELBScheme) bool {
 	return e == *other
 }
 
+// LoadBalancerIPAddressType defines the IP addresses a load balancer is reachable at.
+type LoadBalancerIPAddressType string
+
+const (
+	// LoadBalancerIPAddressTypeIPv4 defines a load balancer reachable at IPv4 addresses only.
+	LoadBalancerIPAddressTypeIPv4 = LoadBalancerIPAddressType("ipv4")
+
+	// LoadBalancerIPAddressTypeDualStack defines a load balancer reachable at both IPv4 and IPv6 addresses.
+	LoadBalancerIPAddressTypeDualStack = LoadBalancerIPAddressType("dualstack")
+)
+
 // ELBProtocol defines listener protocols for a load balancer.
 type ELBProtocol string
 
@@ -122,10 +177,31 @@ type TargetGroupHealthCheck struct {
 	TimeoutSeconds          *int64  `json:"timeoutSeconds,omitempty"`
 	ThresholdCount          *int64  `json:"thresholdCount,omitempty"`
 	UnhealthyThresholdCount *int64  `json:"unhealthyThresholdCount,omitempty"`
+	SuccessCodes            *string `json:"successCodes,omitempty"`
 }
 
 // TargetGroupHealthCheckAPISpec defines the optional health check settings for the API target group.
 type TargetGroupHealthCheckAPISpec struct {
+	// The port the load balancer uses when performing health checks on the API servers.
+	// Defaults to the API server port, 6443.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int64 `json:"port,omitempty"`
+
+	// The destination for health checks on the API servers when using the protocol HTTP or HTTPS,
+	// otherwise the path will be ignored. Defaults to /readyz.
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// The HTTP codes to use when checking for a successful response from the API servers when
+	// using the protocol HTTP or HTTPS, as a single value (200), a comma separated list (200,202)
+	// or a range (200-299). Defaults to the AWS default of the load balancer type. Not supported
+	// for classic load balancers.
+	// +kubebuilder:validation:Pattern=`^[0-9]{3}(-[0-9]{3})?(,[0-9]{3}(-[0-9]{3})?)*$`
+	// +optional
+	SuccessCodes *string `json:"successCodes,omitempty"`
+
 	// The approximate amount of time, in seconds, between health checks of an individual
 	// target.
 	// +kubebuilder:validation:Minimum=5
@@ -207,6 +283,12 @@ type TargetGroupAttribute string
 var (
 	// TargetGroupAttributeEnablePreserveClientIP defines the attribute key for enabling preserve client IP.
 	TargetGroupAttributeEnablePreserveClientIP = "preserve_client_ip.enabled"
+
+	// TargetGroupAttributeEnableProxyProtocolV2 defines the attribute key for enabling the proxy protocol version 2.
+	TargetGroupAttributeEnableProxyProtocolV2 = "proxy_protocol_v2.enabled"
+
+	// TargetGroupAttributeDeregistrationDelayTimeout defines the attribute key for the deregistration delay in seconds.
+	TargetGroupAttributeDeregistrationDelayTimeout = "deregistration_delay.timeout_seconds"
 )
 
 // LoadBalancerAttribute defines a set of attributes for a V2 load balancer.
@@ -219,6 +301,16 @@ var (
 	LoadBalancerAttributeIdleTimeTimeoutSeconds = "idle_timeout.timeout_seconds"
 	// LoadBalancerAttributeIdleTimeDefaultTimeoutSecondsInSeconds defines the default idle timeout in seconds.
 	LoadBalancerAttributeIdleTimeDefaultTimeoutSecondsInSeconds = "60"
+	// LoadBalancerAttributeClientKeepAliveSeconds defines the attribute key for the client keep alive duration.
+	LoadBalancerAttributeClientKeepAliveSeconds = "client_keep_alive.seconds"
+	// LoadBalancerAttributeDeletionProtection defines the attribute key for enabling deletion protection.
+	LoadBalancerAttributeDeletionProtection = "deletion_protection.enabled"
+	// LoadBalancerAttributeAccessLogsEnabled defines the attribute key for enabling access logs.
+	LoadBalancerAttributeAccessLogsEnabled = "access_logs.s3.enabled"
+	// LoadBalancerAttributeAccessLogsBucket defines the attribute key for the S3 bucket of the access logs.
+	LoadBalancerAttributeAccessLogsBucket = "access_logs.s3.bucket"
+	// LoadBalancerAttributeAccessLogsPrefix defines the attribute key for the S3 prefix of the access logs.
+	LoadBalancerAttributeAccessLogsPrefix = "access_logs.s3.prefix"
 )
 
 // TargetGroupSpec specifies target group settings for a given listener.
@@ -256,6 +348,11 @@ type LoadBalancer struct {
 	// DNSName is the dns name of the load balancer.
 	DNSName string `json:"dnsName,omitempty"`
 
+	// CanonicalHostedZoneID is the ID of the Route53 hosted zone associated with the load balancer,
+	// used as the target of alias records.
+	// +optional
+	CanonicalHostedZoneID string `json:"canonicalHostedZoneID,omitempty"`
+
 	// Scheme is the load balancer scheme, either internet-facing or private.
 	Scheme ELBScheme `json:"scheme,omitempty"`
 
@@ -289,6 +386,24 @@ type LoadBalancer struct {
 	// LoadBalancerType sets the type for a load balancer. The default type is classic.
 	// +kubebuilder:validation:Enum:=classic;elb;alb;nlb
 	LoadBalancerType LoadBalancerType `json:"loadBalancerType,omitempty"`
+
+	// IPAddressType is the type of IP addresses the load balancer is reachable at.
+	// +optional
+	IPAddressType LoadBalancerIPAddressType `json:"ipAddressType,omitempty"`
+
+	// EndpointService is the VPC endpoint service exposing the load balancer, if any.
+	// +optional
+	EndpointService *EndpointServiceStatus `json:"endpointService,omitempty"`
+}
+
+// EndpointServiceStatus defines the observed state of a VPC endpoint service.
+type EndpointServiceStatus struct {
+	// ID is the ID of the endpoint service, e.g. "vpce-svc-0123456789abcdef0".
+	ID string `json:"id,omitempty"`
+
+	// ServiceName is the name consumers use to create interface endpoints to the endpoint service,
+	// e.g. "com.amazonaws.vpce.us-east-1.vpce-svc-0123456789abcdef0".
+	ServiceName string `json:"serviceName,omitempty"`
 }
 
 // IsUnmanaged returns true if the Classic ELB is unmanaged.
@@ -310,6 +425,10 @@ type ClassicELBAttributes struct {
 	// CrossZoneLoadBalancing enables the classic load balancer load balancing.
 	// +optional
 	CrossZoneLoadBalancing bool `json:"crossZoneLoadBalancing,omitempty"`
+
+	// AccessLogs is the delivery of the access logs of the classic load balancer, when enabled.
+	// +optional
+	AccessLogs *LoadBalancerAccessLogs `json:"accessLogs,omitempty"`
 }
 
 // ClassicELBListener defines an AWS classic load balancer listener.
@@ -351,6 +470,36 @@ type NetworkSpec struct {
 	// AdditionalControlPlaneIngressRules is an optional set of ingress rules to add to the control plane
 	// +optional
 	AdditionalControlPlaneIngressRules []IngressRule `json:"additionalControlPlaneIngressRules,omitempty"`
+
+	// PodNetwork configures a secondary CIDR block associated with the VPC and the pod subnets
+	// allocated from it, for use with VPC CNI custom networking.
+	// Pod subnets are created and tagged, but never used to place machines or load balancers.
+	// Only supported with a managed VPC.
+	// +optional
+	PodNetwork *PodNetworkSpec `json:"podNetwork,omitempty"`
+}
+
+// PodNetworkSpec configures the secondary CIDR block and subnets reserved for pod IPs.
+type PodNetworkSpec struct {
+	// CidrBlock is the secondary IPv4 CIDR block associated with the VPC, for example 100.64.0.0/16.
+	// +kubebuilder:validation:Required
+	CidrBlock string `json:"cidrBlock"`
+
+	// Subnets are the pod subnets to create, at most one per availability zone.
+	// When empty, the CIDR block is split into one subnet per availability zone in use by the cluster.
+	// +optional
+	Subnets []PodSubnetSpec `json:"subnets,omitempty"`
+}
+
+// PodSubnetSpec configures a pod subnet.
+type PodSubnetSpec struct {
+	// CidrBlock is the IPv4 CIDR block of the subnet, within the pod network CIDR block.
+	// +kubebuilder:validation:Required
+	CidrBlock string `json:"cidrBlock"`
+
+	// AvailabilityZone is the availability zone of the subnet.
+	// +kubebuilder:validation:Required
+	AvailabilityZone string `json:"availabilityZone"`
 }
 
 // IPv6 contains ipv6 specific settings for the network.
@@ -389,6 +538,7 @@ type IPAMPool struct {
 }
 
 // VPCSpec configures an AWS VPC.
+// +kubebuilder:validation:XValidation:rule="!(has(self.allowedAvailabilityZones) && has(self.excludedAvailabilityZones))",message="allowedAvailabilityZones and excludedAvailabilityZones cannot be used together"
 type VPCSpec struct {
 	// ID is the vpc-id of the VPC this provider should use to create resources.
 	ID string `json:"id,omitempty"`
@@ -411,6 +561,13 @@ type VPCSpec struct {
 	// +optional
 	InternetGatewayID *string `json:"internetGatewayId,omitempty"`
 
+	// ExternalInternetGatewayID is the id of an existing internet gateway to use for a managed VPC
+	// instead of creating one. The internet gateway is attached to the VPC if needed, and is only
+	// detached, not deleted, when the cluster is deleted.
+	// +kubebuilder:validation:Pattern=`^igw-[0-9a-f]+$`
+	// +optional
+	ExternalInternetGatewayID *string `json:"externalInternetGatewayId,omitempty"`
+
 	// CarrierGatewayID is the id of the internet gateway associated with the VPC,
 	// for carrier network (Wavelength Zones).
 	// +optional
@@ -437,6 +594,35 @@ type VPCSpec struct {
 	// +kubebuilder:validation:Enum=Ordered;Random
 	AvailabilityZoneSelection *AZSelectionScheme `json:"availabilityZoneSelection,omitempty"`
 
+	// PublicSubnetMaskSize is the IPv4 netmask size of the public subnets created in each AZ
+	// when the VPC CIDR is automatically divided into subnets, e.g. 24 for /24 subnets.
+	// When unset, the VPC CIDR is split evenly and the public subnets share a single block.
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=28
+	// +optional
+	PublicSubnetMaskSize *int `json:"publicSubnetMaskSize,omitempty"`
+
+	// PrivateSubnetMaskSize is the IPv4 netmask size of the private subnets created in each AZ
+	// when the VPC CIDR is automatically divided into subnets, e.g. 20 for /20 subnets.
+	// When unset, the VPC CIDR is split evenly between the private subnets and the public block.
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=28
+	// +optional
+	PrivateSubnetMaskSize *int `json:"privateSubnetMaskSize,omitempty"`
+
+	// AllowedAvailabilityZones restricts the availability zones used when automatically creating
+	// subnets, and for failure domains, to the listed zones. This can be used to avoid zones lacking
+	// the required instance types or capacity.
+	// Cannot be used together with ExcludedAvailabilityZones.
+	// +optional
+	AllowedAvailabilityZones []string `json:"allowedAvailabilityZones,omitempty"`
+
+	// ExcludedAvailabilityZones lists the availability zones that must not be used when automatically
+	// creating subnets, nor for failure domains.
+	// Cannot be used together with AllowedAvailabilityZones.
+	// +optional
+	ExcludedAvailabilityZones []string `json:"excludedAvailabilityZones,omitempty"`
+
 	// EmptyRoutesDefaultVPCSecurityGroup specifies whether the default VPC security group ingress
 	// and egress rules should be removed.
 	//
@@ -468,6 +654,15 @@ func (v *VPCSpec) String() string {
 	return fmt.Sprintf("id=%s", v.ID)
 }
 
+// IsAvailabilityZoneAllowed returns true if the availability zone may be used by the cluster,
+// according to the allowed and excluded availability zones.
+func (v *VPCSpec) IsAvailabilityZoneAllowed(zone string) bool {
+	if len(v.AllowedAvailabilityZones) > 0 && !slices.Contains(v.AllowedAvailabilityZones, zone) {
+		return false
+	}
+	return !slices.Contains(v.ExcludedAvailabilityZones, zone)
+}
+
 // IsUnmanaged returns true if the VPC is unmanaged.
 func (v *VPCSpec) IsUnmanaged(clusterName string) bool {
 	return v.ID != "" && !v.Tags.HasOwned(clusterName)
@@ -537,6 +732,13 @@ type SubnetSpec struct {
 	// +optional
 	IsIPv6 bool `json:"isIpv6,omitempty"`
 
+	// MapPublicIPOnLaunch specifies whether instances launched in the subnet are assigned a public IPv4
+	// address by default. It only applies to public subnets of a managed VPC, and defaults to true when the
+	// provider creates the subnet. Existing subnets are updated when it is set.
+	// Machines can override the subnet default with their PublicIP field.
+	// +optional
+	MapPublicIPOnLaunch *bool `json:"mapPublicIpOnLaunch,omitempty"`
+
 	// RouteTableID is the routing table id associated with the subnet.
 	// +optional
 	RouteTableID *string `json:"routeTableId,omitempty"`
@@ -572,7 +774,6 @@ type SubnetSpec struct {
 	// the default route entry re-using the NAT Gateway in the Region (preferred from the
 	// parent zone, the zone type availability-zone in the region, or first table available).
 	//
-	// +kubebuilder:validation:Enum=availability-zone;local-zone;wavelength-zone
 	// +optional
 	ZoneType *ZoneType `json:"zoneType,omitempty"`
 
@@ -615,6 +816,15 @@ func (s *SubnetSpec) IsEdge() bool {
 	return false
 }
 
+// GetZoneType returns the type of the zone where the subnet is created. Subnets without
+// zone information are considered to be in availability zones.
+func (s *SubnetSpec) GetZoneType() ZoneType {
+	if s.ZoneType == nil {
+		return ZoneTypeAvailabilityZone
+	}
+	return *s.ZoneType
+}
+
 // IsEdgeWavelength returns true only when the subnet is created in Wavelength Zone.
 func (s *SubnetSpec) IsEdgeWavelength() bool {
 	if s.ZoneType == nil {
@@ -737,6 +947,17 @@ func (s Subnets) FilterPrivate() (res Subnets) {
 	return
 }
 
+// FilterPrivateWithEdge returns a slice containing all subnets marked as private, including
+// the subnets in AWS Local Zones or Wavelength.
+func (s Subnets) FilterPrivateWithEdge() (res Subnets) {
+	for _, x := range s {
+		if !x.IsPublic {
+			res = append(res, x)
+		}
+	}
+	return
+}
+
 // FilterNonCni returns the subnets that are NOT intended for usage with the CNI pod network
 // (i.e. do NOT have the `sigs.k8s.io/cluster-api-provider-aws/association=secondary` tag).
 func (s Subnets) FilterNonCni() (res Subnets) {
@@ -762,6 +983,17 @@ func (s Subnets) FilterPublic() (res Subnets) {
 	return
 }
 
+// FilterPublicWithEdge returns a slice containing all subnets marked as public, including
+// the subnets in AWS Local Zones or Wavelength.
+func (s Subnets) FilterPublicWithEdge() (res Subnets) {
+	for _, x := range s {
+		if x.IsPublic {
+			res = append(res, x)
+		}
+	}
+	return
+}
+
 // FilterByZone returns a slice containing all subnets that live in the availability zone specified.
 func (s Subnets) FilterByZone(zone string) (res Subnets) {
 	for _, x := range s {
@@ -772,6 +1004,16 @@ func (s Subnets) FilterByZone(zone string) (res Subnets) {
 	return
 }
 
+// FilterByZoneTypes returns a slice containing all subnets that live in zones of the types specified.
+func (s Subnets) FilterByZoneTypes(zoneTypes ...ZoneType) (res Subnets) {
+	for _, x := range s {
+		if slices.Contains(zoneTypes, x.GetZoneType()) {
+			res = append(res, x)
+		}
+	}
+	return
+}
+
 // GetUniqueZones returns a slice containing the unique zones of the subnets.
 func (s Subnets) GetUniqueZones() []string {
 	keys := make(map[string]bool)
@@ -1028,6 +1270,7 @@ func (i *IngressRule) Equals(o *IngressRule) bool {
 }
 
 // ZoneType defines listener AWS Availability Zone type.
+// +kubebuilder:validation:Enum=availability-zone;local-zone;wavelength-zone
 type ZoneType string
 
 // String returns the string representation for the zone type.
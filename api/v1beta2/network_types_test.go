@@ -768,6 +768,42 @@ func TestSubnets_FilterPublic(t *testing.T) {
 	}
 }
 
+func TestSubnets_FilterByZoneTypes(t *testing.T) {
+	subnets := Subnets{
+		{ResourceID: "subnet-no-zone-type"},
+		{ResourceID: "subnet-az", ZoneType: ptr.To(ZoneTypeAvailabilityZone)},
+		{ResourceID: "subnet-lz", ZoneType: ptr.To(ZoneTypeLocalZone)},
+		{ResourceID: "subnet-wl", ZoneType: ptr.To(ZoneTypeWavelengthZone)},
+	}
+	tests := []struct {
+		name      string
+		zoneTypes []ZoneType
+		want      Subnets
+	}{
+		{
+			name:      "availability zones include subnets without zone type",
+			zoneTypes: []ZoneType{ZoneTypeAvailabilityZone},
+			want:      Subnets{subnets[0], subnets[1]},
+		},
+		{
+			name:      "edge zones",
+			zoneTypes: []ZoneType{ZoneTypeLocalZone, ZoneTypeWavelengthZone},
+			want:      Subnets{subnets[2], subnets[3]},
+		},
+		{
+			name: "no zone types",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subnets.FilterByZoneTypes(tt.zoneTypes...); !cmp.Equal(got, tt.want) {
+				t.Errorf("Subnets.FilterByZoneTypes() got unwanted value:\n %v", cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
 func TestSubnets_GetUniqueZones(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"net"
+
+	"github.com/apparentlymart/go-cidr/cidr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate will validate the pod network fields.
+func (p *PodNetworkSpec) Validate() field.ErrorList {
+	if p == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	fldPath := field.NewPath("spec", "network", "podNetwork")
+
+	_, podCIDR, err := net.ParseCIDR(p.CidrBlock)
+	if err != nil || podCIDR.IP.To4() == nil {
+		return append(errs, field.Invalid(fldPath.Child("cidrBlock"), p.CidrBlock, "must be a valid IPv4 CIDR block"))
+	}
+
+	zones := map[string]struct{}{}
+	for i, subnet := range p.Subnets {
+		subnetPath := fldPath.Child("subnets").Index(i)
+
+		_, subnetCIDR, err := net.ParseCIDR(subnet.CidrBlock)
+		if err != nil || subnetCIDR.IP.To4() == nil {
+			errs = append(errs, field.Invalid(subnetPath.Child("cidrBlock"), subnet.CidrBlock, "must be a valid IPv4 CIDR block"))
+		} else if start, end := cidr.AddressRange(subnetCIDR); !podCIDR.Contains(start) || !podCIDR.Contains(end) {
+			errs = append(errs, field.Invalid(subnetPath.Child("cidrBlock"), subnet.CidrBlock, "must be within the pod network CIDR block"))
+		}
+
+		if _, ok := zones[subnet.AvailabilityZone]; ok {
+			errs = append(errs, field.Duplicate(subnetPath.Child("availabilityZone"), subnet.AvailabilityZone))
+		}
+		zones[subnet.AvailabilityZone] = struct{}{}
+	}
+
+	return errs
+}
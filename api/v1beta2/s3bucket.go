@@ -17,12 +17,14 @@ limitations under the License.
 package v1beta2
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
 )
 
 // Validate validates S3Bucket fields.
@@ -66,6 +68,15 @@ func (b *S3Bucket) Validate() []*field.Error {
 		errs = append(errs, validateS3BucketName(b.Name)...)
 	}
 
+	if b.Policy != "" {
+		policy := iamv1.PolicyDocument{}
+		if err := json.Unmarshal([]byte(b.Policy), &policy); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "s3Bucket", "policy"), b.Policy, fmt.Sprintf("must be a JSON policy document: %v", err)))
+		} else if len(policy.Statement) == 0 {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "s3Bucket", "policy"), b.Policy, "must have at least one statement"))
+		}
+	}
+
 	return errs
 }
 
@@ -37,6 +37,20 @@ type AWSResourceReference struct {
 	Filters []Filter `json:"filters,omitempty"`
 }
 
+// SecurityGroupSelector selects a security group of the cluster VPC by its tags.
+type SecurityGroupSelector struct {
+	// Tags the security group must have. The selector must match exactly one security group.
+	Tags Tags `json:"tags"`
+}
+
+// FailureDomainSelector selects the failure domains an instance can be placed in.
+type FailureDomainSelector struct {
+	// ZoneTypes are the types of the zones the instance can be placed in.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	ZoneTypes []ZoneType `json:"zoneTypes"`
+}
+
 // AMIReference is a reference to a specific AWS resource by ID, ARN, or filters.
 // Only one of ID, ARN or Filters may be specified. Specifying more than one will result in
 // a validation error.
@@ -46,9 +60,33 @@ type AMIReference struct {
 	ID *string `json:"id,omitempty"`
 
 	// EKSOptimizedLookupType If specified, will look up an EKS Optimized image in SSM Parameter store
-	// +kubebuilder:validation:Enum:=AmazonLinux;AmazonLinuxGPU
+	// +kubebuilder:validation:Enum:=AmazonLinux;AmazonLinuxGPU;AmazonLinux2023
 	// +optional
 	EKSOptimizedLookupType *EKSAMILookupType `json:"eksLookupType,omitempty"`
+
+	// Filters look up the most recent AMI matching all the filters, for example the AMIs with the GPU
+	// drivers installed. The architecture of the AMI is picked from the instance type. Filters are not
+	// used when ID is specified, and are used in place of the default AMI lookup otherwise.
+	// +optional
+	Filters []Filter `json:"filters,omitempty"`
+
+	// SSMParameter is the name of an SSM parameter holding the ID of the AMI to use, for example one of
+	// the public parameters published for the Amazon Linux, EKS optimized or Bottlerocket AMIs such as
+	// /aws/service/bottlerocket/aws-k8s-1.29/x86_64/latest/image_id. The parameter is resolved each
+	// time an instance or a launch template version is created, so new instances pick up the latest AMI.
+	// It cannot be used with ID or Filters.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=2048
+	// +optional
+	SSMParameter *string `json:"ssmParameter,omitempty"`
+
+	// MarketplaceProductCode is the product code of an AWS Marketplace product, the most recent AMI of the
+	// product and of the instance architecture is used. The AWS account must be subscribed to the product,
+	// see https://docs.aws.amazon.com/marketplace/latest/buyerguide/buyer-subscribing-to-products.html.
+	// It cannot be used with ID, Filters or SSMParameter.
+	// +kubebuilder:validation:MinLength:=1
+	// +optional
+	MarketplaceProductCode *string `json:"marketplaceProductCode,omitempty"`
 }
 
 // Filter is a filter used to identify an AWS resource.
@@ -78,6 +116,10 @@ const (
 	// ExternalResourceGCTasksAnnotation is the name of an annotation that indicates what
 	// external resources tasks should be executed by garbage collector for the cluster.
 	ExternalResourceGCTasksAnnotation = "aws.cluster.x-k8s.io/external-resource-tasks-gc"
+
+	// HibernateAnnotation is the name of an annotation that indicates if the instance of an AWSMachine
+	// should be hibernated. The instance is resumed once the annotation is removed.
+	HibernateAnnotation = "aws.cluster.x-k8s.io/hibernate"
 )
 
 // GCTask defines a task to be executed by the garbage collector.
@@ -92,6 +134,14 @@ var (
 
 	// GCTaskSecurityGroup defines a task to cleaning up resources for AWS security groups.
 	GCTaskSecurityGroup = GCTask("security-group")
+
+	// GCTaskEBSVolume defines a task to cleaning up the AWS EBS volumes provisioned by the AWS EBS CSI driver.
+	// Unlike the other tasks, it is only executed when explicitly listed in the GC tasks annotation.
+	GCTaskEBSVolume = GCTask("ebs-volume")
+
+	// GCTaskRoute53Record defines a task to cleaning up the AWS Route53 records created by external-dns for the cluster.
+	// Like GCTaskEBSVolume, it is only executed when explicitly listed in the GC tasks annotation.
+	GCTaskRoute53Record = GCTask("route53-record")
 )
 
 // AZSelectionScheme defines the scheme of selecting AZs.
@@ -218,6 +268,14 @@ type Instance struct {
 	// SpotMarketOptions option for configuring instances to be run using AWS Spot instances.
 	SpotMarketOptions *SpotMarketOptions `json:"spotMarketOptions,omitempty"`
 
+	// CapacityReservationTarget specifies the Capacity Reservation to launch the instance into.
+	// +optional
+	CapacityReservationTarget *CapacityReservationTarget `json:"capacityReservationTarget,omitempty"`
+
+	// InstanceLifecycle is the purchasing option of the instance, on-demand or spot.
+	// +optional
+	InstanceLifecycle InstanceLifecycle `json:"instanceLifecycle,omitempty"`
+
 	// PlacementGroupName specifies the name of the placement group in which to launch the instance.
 	// +optional
 	PlacementGroupName string `json:"placementGroupName,omitempty"`
@@ -234,6 +292,30 @@ type Instance struct {
 	// +optional
 	Tenancy string `json:"tenancy,omitempty"`
 
+	// HostID is the ID of the Dedicated Host the instance is launched on.
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+
+	// HostResourceGroupARN is the ARN of the host resource group the instance is launched in.
+	// +optional
+	HostResourceGroupARN *string `json:"hostResourceGroupArn,omitempty"`
+
+	// EnclaveOptions are the Nitro Enclaves options of the instance.
+	// +optional
+	EnclaveOptions *EnclaveOptions `json:"enclaveOptions,omitempty"`
+
+	// HibernationOptions are the hibernation options of the instance.
+	// +optional
+	HibernationOptions *HibernationOptions `json:"hibernationOptions,omitempty"`
+
+	// TerminationProtection is whether the instance is protected against termination and stop through the EC2 API.
+	// +optional
+	TerminationProtection *bool `json:"terminationProtection,omitempty"`
+
+	// MarketplaceProductCodes are the AWS Marketplace product codes of the AMI the instance was launched from.
+	// +optional
+	MarketplaceProductCodes []string `json:"marketplaceProductCodes,omitempty"`
+
 	// IDs of the instance's volumes
 	// +optional
 	VolumeIDs []string `json:"volumeIDs,omitempty"`
@@ -249,6 +331,10 @@ type Instance struct {
 	// PublicIPOnLaunch is the option to associate a public IP on instance launch
 	// +optional
 	PublicIPOnLaunch *bool `json:"publicIPOnLaunch,omitempty"`
+
+	// SourceDestCheck indicates whether source/destination checking is enabled on the instance.
+	// +optional
+	SourceDestCheck *bool `json:"sourceDestCheck,omitempty"`
 }
 
 // InstanceMetadataState describes the state of InstanceMetadataOptions.HttpEndpoint and InstanceMetadataOptions.InstanceMetadataTags
@@ -308,10 +394,10 @@ type InstanceMetadataOptions struct {
 	// always returns the version 2.0 credentials; the version 1.0 credentials are
 	// not available.
 	//
-	// Default: optional
+	// Default: required
 	//
 	// +kubebuilder:validation:Enum:=optional;required
-	// +kubebuilder:default=optional
+	// +kubebuilder:default=required
 	HTTPTokens HTTPTokensState `json:"httpTokens,omitempty"`
 
 	// Set to enabled to allow access to instance tags from the instance metadata.
@@ -335,7 +421,7 @@ func (obj *InstanceMetadataOptions) SetDefaults() {
 		obj.HTTPPutResponseHopLimit = 1
 	}
 	if obj.HTTPTokens == "" {
-		obj.HTTPTokens = HTTPTokensStateOptional // Defaults to IMDSv1
+		obj.HTTPTokens = HTTPTokensStateRequired // Defaults to IMDSv2
 	}
 	if obj.InstanceMetadataTags == "" {
 		obj.InstanceMetadataTags = InstanceMetadataEndpointStateDisabled
@@ -358,22 +444,32 @@ type Volume struct {
 	Type VolumeType `json:"type,omitempty"`
 
 	// IOPS is the number of IOPS requested for the disk. Not applicable to all types.
+	// For gp3 volumes, it must be between 3000 and 80000, and at most 500 per GiB of size.
 	// +optional
 	IOPS int64 `json:"iops,omitempty"`
 
 	// Throughput to provision in MiB/s supported for the volume type. Not applicable to all types.
+	// For gp3 volumes, it must be between 125 and 2000, and at most 0.25 per provisioned IOPS.
 	// +optional
 	Throughput *int64 `json:"throughput,omitempty"`
 
 	// Encrypted is whether the volume should be encrypted or not.
+	// Volumes cannot be requested unencrypted when EBS encryption by default is enabled for the account.
 	// +optional
 	Encrypted *bool `json:"encrypted,omitempty"`
 
-	// EncryptionKey is the KMS key to use to encrypt the volume. Can be either a KMS key ID or ARN.
-	// If Encrypted is set and this is omitted, the default AWS key will be used.
-	// The key must already exist and be accessible by the controller.
+	// EncryptionKey is the customer managed KMS key to use to encrypt the volume. Can be a KMS key ID,
+	// key ARN, alias name (alias/my-key) or alias ARN. The volume is encrypted when this is set.
+	// If Encrypted is set and this is omitted, the EBS default KMS key of the account will be used.
+	// The key must already exist and be accessible by the controller. The volumes of machine pools are
+	// created by the Auto Scaling service-linked role, which the controller must be allowed to create grants for.
 	// +optional
 	EncryptionKey string `json:"encryptionKey,omitempty"`
+
+	// DeleteOnTermination is whether the volume is deleted when the instance is terminated. Defaults to true.
+	// Volumes kept on termination are not deleted by the controller and are billed until deleted.
+	// +optional
+	DeleteOnTermination *bool `json:"deleteOnTermination,omitempty"`
 }
 
 // VolumeType describes the EBS volume type.
@@ -414,8 +510,128 @@ type SpotMarketOptions struct {
 	// +optional
 	// +kubebuilder:validation:pattern="^[0-9]+(\.[0-9]+)?$"
 	MaxPrice *string `json:"maxPrice,omitempty"`
+
+	// InterruptionBehavior defines the behavior of the instance when it is interrupted by AWS.
+	// Instances that stop or hibernate are launched with a persistent Spot request, which is
+	// cancelled when the machine is deleted, and are restarted by AWS once capacity is available again.
+	// Only terminate is supported by AWSMachinePools. Defaults to terminate.
+	// +kubebuilder:validation:Enum=terminate;stop;hibernate
+	// +optional
+	InterruptionBehavior InstanceInterruptionBehavior `json:"interruptionBehavior,omitempty"`
 }
 
+// InstanceInterruptionBehavior describes the behavior of a Spot instance when it is interrupted.
+type InstanceInterruptionBehavior string
+
+const (
+	// InstanceInterruptionBehaviorTerminate terminates the instance when it is interrupted.
+	InstanceInterruptionBehaviorTerminate = InstanceInterruptionBehavior("terminate")
+
+	// InstanceInterruptionBehaviorStop stops the instance when it is interrupted.
+	InstanceInterruptionBehaviorStop = InstanceInterruptionBehavior("stop")
+
+	// InstanceInterruptionBehaviorHibernate hibernates the instance when it is interrupted.
+	InstanceInterruptionBehaviorHibernate = InstanceInterruptionBehavior("hibernate")
+)
+
+// IsPersistent returns true when the Spot instance has to be requested with a persistent Spot request,
+// which is the case when it stops or hibernates on interruption.
+func (o *SpotMarketOptions) IsPersistent() bool {
+	return o.InterruptionBehavior == InstanceInterruptionBehaviorStop || o.InterruptionBehavior == InstanceInterruptionBehaviorHibernate
+}
+
+// CapacityReservationTarget defines the Capacity Reservation to launch instances into,
+// either a single Capacity Reservation or a Capacity Reservation resource group.
+type CapacityReservationTarget struct {
+	// ID is the ID of the Capacity Reservation. Instances fail to launch when the
+	// Capacity Reservation does not have enough available capacity.
+	// +kubebuilder:validation:Pattern=`^cr-[0-9a-f]+$`
+	// +optional
+	ID *string `json:"id,omitempty"`
+
+	// ResourceGroupARN is the ARN of the Capacity Reservation resource group. Instances launch
+	// into any Capacity Reservation of the group that has available capacity.
+	// +optional
+	ResourceGroupARN *string `json:"resourceGroupArn,omitempty"`
+}
+
+// EnclaveOptions defines the Nitro Enclaves options of instances.
+type EnclaveOptions struct {
+	// Enabled enables Nitro Enclaves on the instances. The instance type must support Nitro Enclaves.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// IsEnabled returns true when Nitro Enclaves are enabled.
+func (o *EnclaveOptions) IsEnabled() bool {
+	return o != nil && o.Enabled
+}
+
+// HibernationOptions defines the hibernation options of instances.
+type HibernationOptions struct {
+	// Configured enables hibernation of the instance. It requires an encrypted root volume large enough
+	// to store the memory of the instance, and an instance type supporting hibernation.
+	// +optional
+	Configured bool `json:"configured,omitempty"`
+}
+
+// IsConfigured returns true when hibernation is enabled.
+func (o *HibernationOptions) IsConfigured() bool {
+	return o != nil && o.Configured
+}
+
+// PlacementGroupStrategy describes how instances are placed within a placement group.
+type PlacementGroupStrategy string
+
+const (
+	// PlacementGroupStrategyCluster packs instances close together inside an Availability Zone.
+	PlacementGroupStrategyCluster = PlacementGroupStrategy("cluster")
+
+	// PlacementGroupStrategySpread places each instance on distinct hardware.
+	PlacementGroupStrategySpread = PlacementGroupStrategy("spread")
+
+	// PlacementGroupStrategyPartition spreads instances across logical partitions that do not share hardware.
+	PlacementGroupStrategyPartition = PlacementGroupStrategy("partition")
+)
+
+// PlacementGroup defines the placement group to launch instances in. The placement group is
+// created, and tagged as owned by the cluster, if it does not exist.
+type PlacementGroup struct {
+	// Name of the placement group.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=255
+	Name string `json:"name"`
+
+	// Strategy of the placement group. An existing placement group must have the same strategy.
+	// +kubebuilder:validation:Enum:=cluster;spread;partition
+	Strategy PlacementGroupStrategy `json:"strategy"`
+
+	// PartitionCount is the number of partitions of the placement group, used when the placement group
+	// is created. It is only valid with the partition strategy, EC2 defaults to 2 partitions.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=7
+	// +optional
+	PartitionCount int64 `json:"partitionCount,omitempty"`
+
+	// Partition is the partition number within the placement group in which to launch instances.
+	// It is only valid with the partition strategy, EC2 picks a partition when it is not set.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=7
+	// +optional
+	Partition int64 `json:"partition,omitempty"`
+}
+
+// InstanceLifecycle describes the purchasing option of an instance.
+type InstanceLifecycle string
+
+const (
+	// InstanceLifecycleOnDemand is an On-Demand instance.
+	InstanceLifecycleOnDemand = InstanceLifecycle("on-demand")
+
+	// InstanceLifecycleSpot is a Spot instance.
+	InstanceLifecycleSpot = InstanceLifecycle("spot")
+)
+
 // EKSAMILookupType specifies which AWS AMI to use for a AWSMachine and AWSMachinePool.
 type EKSAMILookupType string
 
@@ -424,6 +640,8 @@ const (
 	AmazonLinux EKSAMILookupType = "AmazonLinux"
 	// AmazonLinuxGPU is the AmazonLinux GPU AMI type.
 	AmazonLinuxGPU EKSAMILookupType = "AmazonLinuxGPU"
+	// AmazonLinux2023 is the Amazon Linux 2023 AMI type, bootstrapped with nodeadm.
+	AmazonLinux2023 EKSAMILookupType = "AmazonLinux2023"
 )
 
 // PrivateDNSName is the options for the instance hostname.
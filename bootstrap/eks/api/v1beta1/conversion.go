@@ -61,6 +61,10 @@ func (r *EKSConfig) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.NTP != nil {
 		dst.Spec.NTP = restored.Spec.NTP
 	}
+	dst.Spec.Format = restored.Spec.Format
+	if restored.Spec.Bottlerocket != nil {
+		dst.Spec.Bottlerocket = restored.Spec.Bottlerocket
+	}
 
 	return nil
 }
@@ -128,6 +132,10 @@ func (r *EKSConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.Template.Spec.NTP != nil {
 		dst.Spec.Template.Spec.NTP = restored.Spec.Template.Spec.NTP
 	}
+	dst.Spec.Template.Spec.Format = restored.Spec.Template.Spec.Format
+	if restored.Spec.Template.Spec.Bottlerocket != nil {
+		dst.Spec.Template.Spec.Bottlerocket = restored.Spec.Template.Spec.Bottlerocket
+	}
 
 	return nil
 }
@@ -74,6 +74,56 @@ type EKSConfigSpec struct {
 	// NTP specifies NTP configuration
 	// +optional
 	NTP *NTP `json:"ntp,omitempty"`
+	// Format specifies the output format of the bootstrap data. Defaults to cloud-config,
+	// bottlerocket generates Bottlerocket TOML settings and nodeadm generates the NodeConfig
+	// of Amazon Linux 2023 nodes instead.
+	// +optional
+	Format Format `json:"format,omitempty"`
+	// Bottlerocket specifies additional settings for Bottlerocket nodes. Only valid when
+	// the format is bottlerocket.
+	// +optional
+	Bottlerocket *BottlerocketSettings `json:"bottlerocket,omitempty"`
+}
+
+// Format specifies the output format of the bootstrap data.
+// +kubebuilder:validation:Enum=cloud-config;bottlerocket;nodeadm
+type Format string
+
+const (
+	// FormatCloudConfig is the format for cloud-config user data running the EKS bootstrap script.
+	FormatCloudConfig Format = "cloud-config"
+	// FormatBottlerocket is the format for Bottlerocket TOML user data.
+	FormatBottlerocket Format = "bottlerocket"
+	// FormatNodeadm is the format for MIME multi-part user data holding the nodeadm NodeConfig
+	// of Amazon Linux 2023 nodes.
+	FormatNodeadm Format = "nodeadm"
+)
+
+// BottlerocketSettings defines the Bottlerocket specific settings of a node.
+type BottlerocketSettings struct {
+	// AdminContainer configures the admin host container, which provides shell access to the node.
+	// +optional
+	AdminContainer *BottlerocketHostContainer `json:"adminContainer,omitempty"`
+	// ControlContainer configures the control host container, which gives access to the
+	// Bottlerocket API through AWS Systems Manager.
+	// +optional
+	ControlContainer *BottlerocketHostContainer `json:"controlContainer,omitempty"`
+}
+
+// BottlerocketHostContainer defines the settings of a Bottlerocket host container.
+type BottlerocketHostContainer struct {
+	// Enabled specifies whether the host container is started. When not set the
+	// Bottlerocket default is used.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Source is the URI of the host container image. When not set the image
+	// shipped with the Bottlerocket variant is used.
+	// +optional
+	Source string `json:"source,omitempty"`
+	// UserData is base64 encoded data passed to the host container, e.g. the SSH
+	// configuration of the admin container.
+	// +optional
+	UserData string `json:"userData,omitempty"`
 }
 
 // PauseContainer contains details of pause container.
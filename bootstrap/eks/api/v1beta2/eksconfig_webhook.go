@@ -17,7 +17,12 @@ limitations under the License.
 package v1beta2
 
 import (
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -38,12 +43,12 @@ var _ webhook.Validator = &EKSConfig{}
 
 // ValidateCreate will do any extra validation when creating a EKSConfig.
 func (r *EKSConfig) ValidateCreate() (admission.Warnings, error) {
-	return nil, nil
+	return nil, r.validate()
 }
 
 // ValidateUpdate will do any extra validation when updating a EKSConfig.
 func (r *EKSConfig) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
-	return nil, nil
+	return nil, r.validate()
 }
 
 // ValidateDelete allows you to add any extra validation when deleting.
@@ -51,6 +56,54 @@ func (r *EKSConfig) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+func (r *EKSConfig) validate() error {
+	allErrs := validateSpec(&r.Spec, field.NewPath("spec"))
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("EKSConfig").GroupKind(), r.Name, allErrs)
+}
+
+// validateSpec validates the fields of an EKSConfigSpec against its format. The Bottlerocket and nodeadm
+// user data do not run the EKS bootstrap script, so the fields only used to build its arguments are rejected,
+// as well as the cloud-init fields for Bottlerocket which does not run cloud-init.
+func validateSpec(spec *EKSConfigSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.Bottlerocket != nil && spec.Format != FormatBottlerocket {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("bottlerocket"), "can only be set when the format is bottlerocket"))
+	}
+
+	bootstrapScriptOnly := []Format{FormatBottlerocket, FormatNodeadm}
+	cloudInitOnly := []Format{FormatBottlerocket}
+	fields := []struct {
+		name          string
+		set           bool
+		unsupportedBy []Format
+	}{
+		{"containerRuntime", spec.ContainerRuntime != nil, bootstrapScriptOnly},
+		{"dockerConfigJson", spec.DockerConfigJSON != nil, bootstrapScriptOnly},
+		{"apiRetryAttempts", spec.APIRetryAttempts != nil, bootstrapScriptOnly},
+		{"useMaxPods", spec.UseMaxPods != nil, bootstrapScriptOnly},
+		{"pauseContainer", spec.PauseContainer != nil, bootstrapScriptOnly},
+		{"serviceIPV6Cidr", spec.ServiceIPV6Cidr != nil, cloudInitOnly},
+		{"preBootstrapCommands", len(spec.PreBootstrapCommands) > 0, cloudInitOnly},
+		{"postBootstrapCommands", len(spec.PostBootstrapCommands) > 0, bootstrapScriptOnly},
+		{"boostrapCommandOverride", spec.BootstrapCommandOverride != nil, bootstrapScriptOnly},
+		{"files", len(spec.Files) > 0, cloudInitOnly},
+		{"diskSetup", spec.DiskSetup != nil, cloudInitOnly},
+		{"mounts", len(spec.Mounts) > 0, cloudInitOnly},
+		{"users", len(spec.Users) > 0, cloudInitOnly},
+	}
+	for _, f := range fields {
+		if f.set && slices.Contains(f.unsupportedBy, spec.Format) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(f.name), fmt.Sprintf("is not supported when the format is %s", spec.Format)))
+		}
+	}
+
+	return allErrs
+}
+
 // Default will set default values for the EKSConfig.
 func (r *EKSConfig) Default() {
 }
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestEKSConfigValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    EKSConfigSpec
+		wantErr bool
+	}{
+		{
+			name: "cloud-config with cloud-init settings is accepted",
+			spec: EKSConfigSpec{
+				PreBootstrapCommands: []string{"echo hello"},
+				Files:                []File{{Path: "/etc/hello", Content: "hello"}},
+			},
+		},
+		{
+			name: "bottlerocket settings are rejected for cloud-config",
+			spec: EKSConfigSpec{
+				Bottlerocket: &BottlerocketSettings{AdminContainer: &BottlerocketHostContainer{Enabled: ptr.To(true)}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bottlerocket with bottlerocket settings is accepted",
+			spec: EKSConfigSpec{
+				Format:           FormatBottlerocket,
+				KubeletExtraArgs: map[string]string{"node-labels": "role=worker"},
+				NTP:              &NTP{Servers: []string{"169.254.169.123"}},
+				Bottlerocket:     &BottlerocketSettings{AdminContainer: &BottlerocketHostContainer{Enabled: ptr.To(true)}},
+			},
+		},
+		{
+			name: "bottlerocket with bootstrap commands is rejected",
+			spec: EKSConfigSpec{
+				Format:               FormatBottlerocket,
+				PreBootstrapCommands: []string{"echo hello"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bottlerocket with files is rejected",
+			spec: EKSConfigSpec{
+				Format: FormatBottlerocket,
+				Files:  []File{{Path: "/etc/hello", Content: "hello"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nodeadm with cloud-init settings is accepted",
+			spec: EKSConfigSpec{
+				Format:               FormatNodeadm,
+				PreBootstrapCommands: []string{"echo hello"},
+				Files:                []File{{Path: "/etc/hello", Content: "hello"}},
+			},
+		},
+		{
+			name: "nodeadm with bootstrap script arguments is rejected",
+			spec: EKSConfigSpec{
+				Format:                   FormatNodeadm,
+				BootstrapCommandOverride: ptr.To("/etc/eks/bootstrap.sh"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := &EKSConfig{Spec: tt.spec}
+			_, err := config.ValidateCreate()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			template := &EKSConfigTemplate{Spec: EKSConfigTemplateSpec{Template: EKSConfigTemplateResource{Spec: tt.spec}}}
+			_, err = template.ValidateCreate()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package v1beta2
 
 import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -38,12 +40,12 @@ var _ webhook.Validator = &EKSConfigTemplate{}
 
 // ValidateCreate will do any extra validation when creating a EKSConfigTemplate.
 func (r *EKSConfigTemplate) ValidateCreate() (admission.Warnings, error) {
-	return nil, nil
+	return nil, r.validate()
 }
 
 // ValidateUpdate will do any extra validation when updating a EKSConfigTemplate.
 func (r *EKSConfigTemplate) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
-	return nil, nil
+	return nil, r.validate()
 }
 
 // ValidateDelete allows you to add any extra validation when deleting.
@@ -51,6 +53,14 @@ func (r *EKSConfigTemplate) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+func (r *EKSConfigTemplate) validate() error {
+	allErrs := validateSpec(&r.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("EKSConfigTemplate").GroupKind(), r.Name, allErrs)
+}
+
 // Default will set default values for the EKSConfigTemplate.
 func (r *EKSConfigTemplate) Default() {
 }
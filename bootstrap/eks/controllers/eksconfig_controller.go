@@ -20,6 +20,7 @@ package controllers
 import (
 	"bytes"
 	"context"
+	"net"
 	"time"
 
 	"github.com/pkg/errors"
@@ -28,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -49,6 +51,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/secret"
 )
 
 // EKSConfigReconciler reconciles a EKSConfig object.
@@ -227,6 +230,13 @@ func (r *EKSConfigReconciler) joinWorker(ctx context.Context, cluster *clusterv1
 		return err
 	}
 
+	switch config.Spec.Format {
+	case eksbootstrapv1.FormatBottlerocket:
+		return r.joinBottlerocketWorker(ctx, cluster, config, controlPlane)
+	case eksbootstrapv1.FormatNodeadm:
+		return r.joinNodeadmWorker(ctx, cluster, config, controlPlane)
+	}
+
 	log.Info("Generating userdata")
 	files, err := r.resolveFiles(ctx, config)
 	if err != nil {
@@ -290,6 +300,136 @@ func (r *EKSConfigReconciler) joinWorker(ctx context.Context, cluster *clusterv1
 	return nil
 }
 
+// joinBottlerocketWorker generates the Bottlerocket settings of a node. Unlike the EKS bootstrap script,
+// Bottlerocket does not look up the cluster endpoint and certificate authority itself, so they are read
+// from the kubeconfig of the cluster.
+func (r *EKSConfigReconciler) joinBottlerocketWorker(ctx context.Context, cluster *clusterv1.Cluster, config *eksbootstrapv1.EKSConfig, controlPlane *ekscontrolplanev1.AWSManagedControlPlane) error {
+	log := logger.FromContext(ctx)
+
+	apiServerEndpoint, caCert, err := r.clusterConnection(ctx, cluster)
+	if err != nil {
+		log.Error(err, "Failed to get the cluster connection details")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	log.Info("Generating Bottlerocket userdata")
+	userData, err := userdata.NewBottlerocketNode(&userdata.BottlerocketInput{
+		ClusterName:       controlPlane.Spec.EKSClusterName,
+		APIServerEndpoint: apiServerEndpoint,
+		CACert:            caCert,
+		DNSClusterIP:      config.Spec.DNSClusterIP,
+		KubeletExtraArgs:  config.Spec.KubeletExtraArgs,
+		NTP:               config.Spec.NTP,
+		Settings:          config.Spec.Bottlerocket,
+	})
+	if err != nil {
+		log.Error(err, "Failed to create a Bottlerocket worker join configuration")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	if err := r.storeBootstrapData(ctx, cluster, config, userData); err != nil {
+		log.Error(err, "Failed to store bootstrap data")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, "")
+		return err
+	}
+
+	return nil
+}
+
+// joinNodeadmWorker generates the NodeConfig of an Amazon Linux 2023 node. Like Bottlerocket, nodeadm does
+// not look up the cluster endpoint, certificate authority and service CIDR itself.
+func (r *EKSConfigReconciler) joinNodeadmWorker(ctx context.Context, cluster *clusterv1.Cluster, config *eksbootstrapv1.EKSConfig, controlPlane *ekscontrolplanev1.AWSManagedControlPlane) error {
+	log := logger.FromContext(ctx)
+
+	apiServerEndpoint, caCert, err := r.clusterConnection(ctx, cluster)
+	if err != nil {
+		log.Error(err, "Failed to get the cluster connection details")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	files, err := r.resolveFiles(ctx, config)
+	if err != nil {
+		log.Info("Failed to resolve files for user data")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	log.Info("Generating nodeadm userdata")
+	userData, err := userdata.NewNodeadmNode(&userdata.NodeadmInput{
+		ClusterName:          controlPlane.Spec.EKSClusterName,
+		APIServerEndpoint:    apiServerEndpoint,
+		CACert:               caCert,
+		ServiceCIDR:          serviceCIDR(cluster, config, controlPlane),
+		DNSClusterIP:         config.Spec.DNSClusterIP,
+		KubeletExtraArgs:     config.Spec.KubeletExtraArgs,
+		PreBootstrapCommands: config.Spec.PreBootstrapCommands,
+		Files:                files,
+		DiskSetup:            config.Spec.DiskSetup,
+		Mounts:               config.Spec.Mounts,
+		Users:                config.Spec.Users,
+		NTP:                  config.Spec.NTP,
+	})
+	if err != nil {
+		log.Error(err, "Failed to create a nodeadm worker join configuration")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, "")
+		return err
+	}
+
+	if err := r.storeBootstrapData(ctx, cluster, config, userData); err != nil {
+		log.Error(err, "Failed to store bootstrap data")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, "")
+		return err
+	}
+
+	return nil
+}
+
+// serviceCIDR returns the service CIDR of the EKS cluster. When it is not set on the Cluster, EKS picks
+// 172.20.0.0/16 for VPCs in the 10.0.0.0/8 range and 10.100.0.0/16 otherwise.
+func serviceCIDR(cluster *clusterv1.Cluster, config *eksbootstrapv1.EKSConfig, controlPlane *ekscontrolplanev1.AWSManagedControlPlane) string {
+	if config.Spec.ServiceIPV6Cidr != nil && *config.Spec.ServiceIPV6Cidr != "" {
+		return *config.Spec.ServiceIPV6Cidr
+	}
+	if controlPlane.Spec.NetworkSpec.VPC.IsIPv6Enabled() {
+		return controlPlane.Spec.NetworkSpec.VPC.IPv6.CidrBlock
+	}
+	if cluster.Spec.ClusterNetwork != nil && cluster.Spec.ClusterNetwork.Services != nil && len(cluster.Spec.ClusterNetwork.Services.CIDRBlocks) > 0 {
+		return cluster.Spec.ClusterNetwork.Services.CIDRBlocks[0]
+	}
+	if _, vpcCIDR, err := net.ParseCIDR(controlPlane.Spec.NetworkSpec.VPC.CidrBlock); err == nil && vpcCIDR.IP.To4() != nil && vpcCIDR.IP.To4()[0] == 10 {
+		return "172.20.0.0/16"
+	}
+	return "10.100.0.0/16"
+}
+
+// clusterConnection returns the API server endpoint and the certificate authority data of the
+// EKS cluster from the kubeconfig Secret generated for the AWSManagedControlPlane.
+func (r *EKSConfigReconciler) clusterConnection(ctx context.Context, cluster *clusterv1.Cluster) (string, []byte, error) {
+	kubeconfigSecret, err := secret.GetFromNamespacedName(ctx, r.Client, util.ObjectKey(cluster), secret.Kubeconfig)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to get kubeconfig secret")
+	}
+
+	kubeconfig, err := clientcmd.Load(kubeconfigSecret.Data[secret.KubeconfigDataName])
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to load kubeconfig")
+	}
+
+	kubeContext, ok := kubeconfig.Contexts[kubeconfig.CurrentContext]
+	if !ok {
+		return "", nil, errors.Errorf("kubeconfig has no context %q", kubeconfig.CurrentContext)
+	}
+	kubeCluster, ok := kubeconfig.Clusters[kubeContext.Cluster]
+	if !ok {
+		return "", nil, errors.Errorf("kubeconfig has no cluster %q", kubeContext.Cluster)
+	}
+
+	return kubeCluster.Server, kubeCluster.CertificateAuthorityData, nil
+}
+
 func (r *EKSConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, option controller.Options) error {
 	b := ctrl.NewControllerManagedBy(mgr).
 		For(&eksbootstrapv1.EKSConfig{}).
@@ -345,7 +485,7 @@ func (r *EKSConfigReconciler) storeBootstrapData(ctx context.Context, cluster *c
 			return errors.Wrap(err, "failed to get data secret for EKSConfig")
 		}
 	} else {
-		updated, err := r.updateBootstrapSecret(ctx, secret, data)
+		updated, err := r.updateBootstrapSecret(ctx, secret, data, bootstrapDataFormat(config))
 		if err != nil {
 			return errors.Wrap(err, "failed to update data secret for EKSConfig")
 		}
@@ -449,7 +589,8 @@ func (r *EKSConfigReconciler) createBootstrapSecret(ctx context.Context, cluster
 			},
 		},
 		Data: map[string][]byte{
-			"value": data,
+			"value":  data,
+			"format": []byte(bootstrapDataFormat(config)),
 		},
 		Type: clusterv1.ClusterSecretType,
 	}
@@ -457,13 +598,22 @@ func (r *EKSConfigReconciler) createBootstrapSecret(ctx context.Context, cluster
 }
 
 // Update the userdata in the bootstrap Secret.
-func (r *EKSConfigReconciler) updateBootstrapSecret(ctx context.Context, secret *corev1.Secret, data []byte) (bool, error) {
+func (r *EKSConfigReconciler) updateBootstrapSecret(ctx context.Context, secret *corev1.Secret, data []byte, format eksbootstrapv1.Format) (bool, error) {
 	if secret.Data == nil {
 		secret.Data = make(map[string][]byte)
 	}
-	if !bytes.Equal(secret.Data["value"], data) {
+	if !bytes.Equal(secret.Data["value"], data) || string(secret.Data["format"]) != string(format) {
 		secret.Data["value"] = data
+		secret.Data["format"] = []byte(format)
 		return true, r.Client.Update(ctx, secret)
 	}
 	return false, nil
 }
+
+// bootstrapDataFormat returns the format of the bootstrap data generated for the EKSConfig.
+func bootstrapDataFormat(config *eksbootstrapv1.EKSConfig) eksbootstrapv1.Format {
+	if config.Spec.Format == "" {
+		return eksbootstrapv1.FormatCloudConfig
+	}
+	return config.Spec.Format
+}
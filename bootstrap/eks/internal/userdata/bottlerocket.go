@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+const bottlerocketNodeUserData = `[settings.kubernetes]
+cluster-name = {{ quote .ClusterName }}
+api-server = {{ quote .APIServerEndpoint }}
+cluster-certificate = {{ quote .ClusterCertificate }}
+{{- if .DNSClusterIP }}
+cluster-dns-ip = {{ quote .DNSClusterIP }}
+{{- end }}
+{{- if .MaxPods }}
+max-pods = {{ .MaxPods }}
+{{- end }}
+{{- if .NodeLabels }}
+
+[settings.kubernetes.node-labels]
+{{- range $k, $v := .NodeLabels }}
+{{ quote $k }} = {{ quote $v }}
+{{- end }}
+{{- end }}
+{{- if .NodeTaints }}
+
+[settings.kubernetes.node-taints]
+{{- range $k, $v := .NodeTaints }}
+{{ quote $k }} = [{{ range $i, $t := $v }}{{ if $i }}, {{ end }}{{ quote $t }}{{ end }}]
+{{- end }}
+{{- end }}
+{{- if .TimeServers }}
+
+[settings.ntp]
+time-servers = [{{ range $i, $s := .TimeServers }}{{ if $i }}, {{ end }}{{ quote $s }}{{ end }}]
+{{- end }}
+{{- template "hostContainer" hostContainer "admin" .AdminContainer }}
+{{- template "hostContainer" hostContainer "control" .ControlContainer }}
+`
+
+const bottlerocketHostContainerTemplate = `{{- define "hostContainer" -}}
+{{- if .Container }}
+
+[settings.host-containers.{{ .Name }}]
+{{- if .Container.Enabled }}
+enabled = {{ .Container.Enabled }}
+{{- end }}
+{{- if .Container.Source }}
+source = {{ quote .Container.Source }}
+{{- end }}
+{{- if .Container.UserData }}
+user-data = {{ quote .Container.UserData }}
+{{- end }}
+{{- end }}
+{{- end -}}`
+
+// BottlerocketInput defines the context to generate the user data of a Bottlerocket node.
+type BottlerocketInput struct {
+	ClusterName       string
+	APIServerEndpoint string
+	CACert            []byte
+	DNSClusterIP      *string
+	KubeletExtraArgs  map[string]string
+	NTP               *eksbootstrapv1.NTP
+	Settings          *eksbootstrapv1.BottlerocketSettings
+}
+
+type bottlerocketNode struct {
+	ClusterName        string
+	APIServerEndpoint  string
+	ClusterCertificate string
+	DNSClusterIP       string
+	MaxPods            int
+	NodeLabels         map[string]string
+	NodeTaints         map[string][]string
+	TimeServers        []string
+	AdminContainer     *eksbootstrapv1.BottlerocketHostContainer
+	ControlContainer   *eksbootstrapv1.BottlerocketHostContainer
+}
+
+// NewBottlerocketNode returns the Bottlerocket TOML settings to join a node to an EKS cluster.
+func NewBottlerocketNode(input *BottlerocketInput) ([]byte, error) {
+	node := &bottlerocketNode{
+		ClusterName:        input.ClusterName,
+		APIServerEndpoint:  input.APIServerEndpoint,
+		ClusterCertificate: base64.StdEncoding.EncodeToString(input.CACert),
+	}
+	if input.DNSClusterIP != nil {
+		node.DNSClusterIP = *input.DNSClusterIP
+	}
+	if input.NTP != nil {
+		node.TimeServers = input.NTP.Servers
+	}
+	if input.Settings != nil {
+		node.AdminContainer = input.Settings.AdminContainer
+		node.ControlContainer = input.Settings.ControlContainer
+	}
+	if err := node.setKubeletArgs(input.KubeletExtraArgs); err != nil {
+		return nil, err
+	}
+
+	tm := template.New("Node").Funcs(template.FuncMap{
+		"quote": strconv.Quote,
+		"hostContainer": func(name string, container *eksbootstrapv1.BottlerocketHostContainer) map[string]interface{} {
+			return map[string]interface{}{"Name": name, "Container": container}
+		},
+	})
+	if _, err := tm.Parse(bottlerocketHostContainerTemplate); err != nil {
+		return nil, fmt.Errorf("failed to parse host container template: %w", err)
+	}
+	t, err := tm.Parse(bottlerocketNodeUserData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Bottlerocket node template: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, node); err != nil {
+		return nil, fmt.Errorf("failed to generate Bottlerocket node template: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// setKubeletArgs maps the kubelet arguments to their Bottlerocket settings. Bottlerocket does not
+// accept arbitrary kubelet arguments, so the ones without an equivalent setting are rejected.
+func (n *bottlerocketNode) setKubeletArgs(args map[string]string) error {
+	for arg, value := range args {
+		switch arg {
+		case "node-labels":
+			n.NodeLabels = map[string]string{}
+			for _, label := range strings.Split(value, ",") {
+				k, v, _ := strings.Cut(label, "=")
+				n.NodeLabels[k] = v
+			}
+		case "register-with-taints":
+			n.NodeTaints = map[string][]string{}
+			for _, taint := range strings.Split(value, ",") {
+				k, effect, ok := strings.Cut(taint, ":")
+				if !ok {
+					return fmt.Errorf("invalid taint %q, the effect is missing", taint)
+				}
+				k, v, _ := strings.Cut(k, "=")
+				n.NodeTaints[k] = append(n.NodeTaints[k], v+":"+effect)
+			}
+		case "max-pods":
+			maxPods, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid max-pods %q: %w", value, err)
+			}
+			n.MaxPods = maxPods
+		default:
+			return fmt.Errorf("kubelet argument %q is not supported on Bottlerocket", arg)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+func TestNewBottlerocketNode(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *BottlerocketInput
+		expectedBytes []byte
+		expectErr     bool
+	}{
+		{
+			name: "only cluster connection",
+			input: &BottlerocketInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://example.eks.amazonaws.com",
+				CACert:            []byte("ca"),
+			},
+			expectedBytes: []byte(`[settings.kubernetes]
+cluster-name = "test-cluster"
+api-server = "https://example.eks.amazonaws.com"
+cluster-certificate = "Y2E="
+`),
+		},
+		{
+			name: "with kubelet args, ntp and host containers",
+			input: &BottlerocketInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://example.eks.amazonaws.com",
+				CACert:            []byte("ca"),
+				DNSClusterIP:      ptr.To[string]("10.100.0.10"),
+				KubeletExtraArgs: map[string]string{
+					"node-labels":          "role=infra,tier=backend",
+					"register-with-taints": "dedicated=infra:NoSchedule,dedicated=infra:NoExecute",
+					"max-pods":             "58",
+				},
+				NTP: &eksbootstrapv1.NTP{Servers: []string{"169.254.169.123", "time.aws.com"}},
+				Settings: &eksbootstrapv1.BottlerocketSettings{
+					AdminContainer: &eksbootstrapv1.BottlerocketHostContainer{
+						Enabled:  ptr.To[bool](true),
+						UserData: "eyJzc2giOnt9fQ==",
+					},
+					ControlContainer: &eksbootstrapv1.BottlerocketHostContainer{
+						Enabled: ptr.To[bool](false),
+						Source:  "public.ecr.aws/bottlerocket/bottlerocket-control:v0.7.0",
+					},
+				},
+			},
+			expectedBytes: []byte(`[settings.kubernetes]
+cluster-name = "test-cluster"
+api-server = "https://example.eks.amazonaws.com"
+cluster-certificate = "Y2E="
+cluster-dns-ip = "10.100.0.10"
+max-pods = 58
+
+[settings.kubernetes.node-labels]
+"role" = "infra"
+"tier" = "backend"
+
+[settings.kubernetes.node-taints]
+"dedicated" = ["infra:NoSchedule", "infra:NoExecute"]
+
+[settings.ntp]
+time-servers = ["169.254.169.123", "time.aws.com"]
+
+[settings.host-containers.admin]
+enabled = true
+user-data = "eyJzc2giOnt9fQ=="
+
+[settings.host-containers.control]
+enabled = false
+source = "public.ecr.aws/bottlerocket/bottlerocket-control:v0.7.0"
+`),
+		},
+		{
+			name: "unsupported kubelet arg",
+			input: &BottlerocketInput{
+				ClusterName:      "test-cluster",
+				KubeletExtraArgs: map[string]string{"eviction-hard": "memory.available<100Mi"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "taint without effect",
+			input: &BottlerocketInput{
+				ClusterName:      "test-cluster",
+				KubeletExtraArgs: map[string]string{"register-with-taints": "dedicated=infra"},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			bytes, err := NewBottlerocketNode(tt.input)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(bytes)).To(Equal(string(tt.expectedBytes)))
+		})
+	}
+}
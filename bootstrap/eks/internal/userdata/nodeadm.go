@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"text/template"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+const (
+	nodeadmBoundary = "//"
+
+	nodeadmNodeUserData = `MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="{{ .Boundary }}"
+
+--{{ .Boundary }}
+Content-Type: application/node.eks.aws
+
+---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: {{ quote .ClusterName }}
+    apiServerEndpoint: {{ quote .APIServerEndpoint }}
+    certificateAuthority: {{ quote .CertificateAuthority }}
+    cidr: {{ quote .ServiceCIDR }}
+{{- if or .DNSClusterIP .KubeletFlags }}
+  kubelet:
+{{- if .DNSClusterIP }}
+    config:
+      clusterDNS:
+      - {{ quote .DNSClusterIP }}
+{{- end }}
+{{- if .KubeletFlags }}
+    flags:
+{{- range .KubeletFlags }}
+    - {{ quote . }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .HasCloudConfig }}
+
+--{{ .Boundary }}
+Content-Type: text/cloud-config; charset="us-ascii"
+
+#cloud-config
+{{template "files" .Files}}
+runcmd:
+{{- template "commands" .PreBootstrapCommands }}
+{{- template "ntp" .NTP }}
+{{- template "users" .Users }}
+{{- template "disk_setup" .DiskSetup}}
+{{- template "fs_setup" .DiskSetup}}
+{{- template "mounts" .Mounts}}
+{{- end }}
+
+--{{ .Boundary }}--
+`
+)
+
+// NodeadmInput defines the context to generate the user data of an Amazon Linux 2023 node.
+type NodeadmInput struct {
+	ClusterName          string
+	APIServerEndpoint    string
+	CACert               []byte
+	ServiceCIDR          string
+	DNSClusterIP         *string
+	KubeletExtraArgs     map[string]string
+	PreBootstrapCommands []string
+	Files                []eksbootstrapv1.File
+	DiskSetup            *eksbootstrapv1.DiskSetup
+	Mounts               []eksbootstrapv1.MountPoints
+	Users                []eksbootstrapv1.User
+	NTP                  *eksbootstrapv1.NTP
+}
+
+// Boundary returns the boundary of the MIME multi-part user data.
+func (ni *NodeadmInput) Boundary() string {
+	return nodeadmBoundary
+}
+
+// CertificateAuthority returns the base64 encoded certificate authority of the cluster.
+func (ni *NodeadmInput) CertificateAuthority() string {
+	return base64.StdEncoding.EncodeToString(ni.CACert)
+}
+
+// KubeletFlags returns the kubelet extra args as sorted kubelet flags.
+func (ni *NodeadmInput) KubeletFlags() []string {
+	flags := make([]string, 0, len(ni.KubeletExtraArgs))
+	for k, v := range ni.KubeletExtraArgs {
+		flags = append(flags, fmt.Sprintf("--%s=%s", k, v))
+	}
+	sort.Strings(flags)
+	return flags
+}
+
+// HasCloudConfig returns true if the node needs cloud-init to set up files, users, disks or
+// to run commands before joining the cluster.
+func (ni *NodeadmInput) HasCloudConfig() bool {
+	return len(ni.PreBootstrapCommands) > 0 || len(ni.Files) > 0 || ni.DiskSetup != nil ||
+		len(ni.Mounts) > 0 || len(ni.Users) > 0 || ni.NTP != nil
+}
+
+// NewNodeadmNode returns the MIME multi-part user data joining an Amazon Linux 2023 node to an EKS
+// cluster with nodeadm, along with the cloud-config of the node if any.
+func NewNodeadmNode(input *NodeadmInput) ([]byte, error) {
+	tm := template.New("Node").Funcs(defaultTemplateFuncMap).Funcs(template.FuncMap{
+		"quote": strconv.Quote,
+	})
+
+	for name, tpl := range map[string]string{
+		"files":      filesTemplate,
+		"commands":   commandsTemplate,
+		"ntp":        ntpTemplate,
+		"users":      usersTemplate,
+		"disk setup": diskSetupTemplate,
+		"fs setup":   fsSetupTemplate,
+		"mounts":     mountsTemplate,
+	} {
+		if _, err := tm.Parse(tpl); err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", name, err)
+		}
+	}
+
+	t, err := tm.Parse(nodeadmNodeUserData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse nodeadm Node template: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, input); err != nil {
+		return nil, fmt.Errorf("failed to generate nodeadm Node template: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
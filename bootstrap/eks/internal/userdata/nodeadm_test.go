@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+func TestNewNodeadmNode(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *NodeadmInput
+		expectedBytes []byte
+	}{
+		{
+			name: "only cluster connection",
+			input: &NodeadmInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://example.eks.amazonaws.com",
+				CACert:            []byte("ca"),
+				ServiceCIDR:       "10.100.0.0/16",
+			},
+			expectedBytes: []byte(`MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="//"
+
+--//
+Content-Type: application/node.eks.aws
+
+---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: "test-cluster"
+    apiServerEndpoint: "https://example.eks.amazonaws.com"
+    certificateAuthority: "Y2E="
+    cidr: "10.100.0.0/16"
+
+--//--
+`),
+		},
+		{
+			name: "with kubelet settings and cloud-config",
+			input: &NodeadmInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://example.eks.amazonaws.com",
+				CACert:            []byte("ca"),
+				ServiceCIDR:       "10.100.0.0/16",
+				DNSClusterIP:      ptr.To[string]("10.100.0.10"),
+				KubeletExtraArgs: map[string]string{
+					"register-with-taints": "dedicated=infra:NoSchedule",
+					"node-labels":          "role=infra",
+				},
+				PreBootstrapCommands: []string{"echo hello"},
+				Files: []eksbootstrapv1.File{
+					{
+						Path:    "/etc/hello",
+						Content: "hello",
+					},
+				},
+			},
+			expectedBytes: []byte(`MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="//"
+
+--//
+Content-Type: application/node.eks.aws
+
+---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: "test-cluster"
+    apiServerEndpoint: "https://example.eks.amazonaws.com"
+    certificateAuthority: "Y2E="
+    cidr: "10.100.0.0/16"
+  kubelet:
+    config:
+      clusterDNS:
+      - "10.100.0.10"
+    flags:
+    - "--node-labels=role=infra"
+    - "--register-with-taints=dedicated=infra:NoSchedule"
+
+--//
+Content-Type: text/cloud-config; charset="us-ascii"
+
+#cloud-config
+write_files:
+  - path: /etc/hello
+    content: |
+      hello
+runcmd:
+  - "echo hello"
+
+--//--
+`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			bytes, err := NewNodeadmNode(tt.input)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(bytes)).To(Equal(string(tt.expectedBytes)))
+		})
+	}
+}
@@ -97,6 +97,8 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:CreateEgressOnlyInternetGateway",
 				"ec2:CreateNatGateway",
 				"ec2:CreateNetworkInterface",
+				"ec2:CreatePlacementGroup",
+				"ec2:DeletePlacementGroup",
 				"ec2:CreateRoute",
 				"ec2:CreateRouteTable",
 				"ec2:CreateSecurityGroup",
@@ -110,11 +112,13 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DeleteInternetGateway",
 				"ec2:DeleteEgressOnlyInternetGateway",
 				"ec2:DeleteNatGateway",
+				"ec2:DeleteNetworkInterface",
 				"ec2:DeleteRouteTable",
 				"ec2:ReplaceRoute",
 				"ec2:DeleteSecurityGroup",
 				"ec2:DeleteSubnet",
 				"ec2:DeleteTags",
+				"ec2:DeleteVolume",
 				"ec2:DeleteVpc",
 				"ec2:DeleteVpcEndpoints",
 				"ec2:DescribeAccountAttributes",
@@ -127,16 +131,30 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DescribeEgressOnlyInternetGateways",
 				"ec2:DescribeInstanceTypes",
 				"ec2:DescribeImages",
+				"ec2:GetEbsDefaultKmsKeyId",
+				"ec2:GetEbsEncryptionByDefault",
+				"ec2:GetConsoleOutput",
+				"ec2:GetConsoleScreenshot",
 				"ec2:DescribeNatGateways",
 				"ec2:DescribeNetworkInterfaces",
 				"ec2:DescribeNetworkInterfaceAttribute",
+				"ec2:DescribePlacementGroups",
 				"ec2:DescribeRouteTables",
 				"ec2:DescribeSecurityGroups",
+				"ec2:DescribeSecurityGroupRules",
 				"ec2:DescribeSubnets",
 				"ec2:DescribeVpcs",
 				"ec2:DescribeDhcpOptions",
 				"ec2:DescribeVpcAttribute",
 				"ec2:DescribeVpcEndpoints",
+				"ec2:CreateVpcEndpointServiceConfiguration",
+				"ec2:DescribeVpcEndpointServiceConfigurations",
+				"ec2:ModifyVpcEndpointServiceConfiguration",
+				"ec2:DeleteVpcEndpointServiceConfigurations",
+				"ec2:DescribeVpcEndpointServicePermissions",
+				"ec2:ModifyVpcEndpointServicePermissions",
+				"ec2:DescribeVpcEndpointConnections",
+				"ec2:RejectVpcEndpointConnections",
 				"ec2:DescribeVolumes",
 				"ec2:DescribeTags",
 				"ec2:DetachInternetGateway",
@@ -149,6 +167,10 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:RevokeSecurityGroupIngress",
 				"ec2:RunInstances",
 				"ec2:TerminateInstances",
+				"ec2:StopInstances",
+				"ec2:StartInstances",
+				"ec2:DescribeSpotInstanceRequests",
+				"ec2:CancelSpotInstanceRequests",
 				"tag:GetResources",
 				"elasticloadbalancing:AddTags",
 				"elasticloadbalancing:CreateLoadBalancer",
@@ -158,6 +180,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"elasticloadbalancing:DescribeLoadBalancers",
 				"elasticloadbalancing:DescribeLoadBalancerAttributes",
 				"elasticloadbalancing:DescribeTargetGroups",
+				"elasticloadbalancing:DescribeTargetGroupAttributes",
 				"elasticloadbalancing:ApplySecurityGroupsToLoadBalancer",
 				"elasticloadbalancing:DescribeTags",
 				"elasticloadbalancing:ModifyLoadBalancerAttributes",
@@ -166,14 +189,24 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"elasticloadbalancing:RemoveTags",
 				"elasticloadbalancing:SetSubnets",
 				"elasticloadbalancing:ModifyTargetGroupAttributes",
+				"elasticloadbalancing:ModifyTargetGroup",
 				"elasticloadbalancing:CreateTargetGroup",
 				"elasticloadbalancing:DescribeListeners",
 				"elasticloadbalancing:CreateListener",
+				"elasticloadbalancing:CreateRule",
+				"elasticloadbalancing:DescribeRules",
+				"elasticloadbalancing:ModifyRule",
+				"elasticloadbalancing:DeleteRule",
 				"elasticloadbalancing:DescribeTargetHealth",
 				"elasticloadbalancing:RegisterTargets",
 				"elasticloadbalancing:DeleteListener",
+				"elasticloadbalancing:ModifyListener",
+				"elasticloadbalancing:DescribeListenerCertificates",
+				"elasticloadbalancing:AddListenerCertificates",
+				"elasticloadbalancing:RemoveListenerCertificates",
 				"autoscaling:DescribeAutoScalingGroups",
 				"autoscaling:DescribeInstanceRefreshes",
+				"autoscaling:DescribeLifecycleHooks",
 				"ec2:CreateLaunchTemplate",
 				"ec2:CreateLaunchTemplateVersion",
 				"ec2:DescribeLaunchTemplates",
@@ -182,6 +215,20 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DeleteLaunchTemplateVersions",
 				"ec2:DescribeKeyPairs",
 				"ec2:ModifyInstanceMetadataOptions",
+				"ec2:CreateFleet",
+				"ec2:DescribeFleets",
+				"ec2:ModifyFleet",
+				"ec2:DeleteFleets",
+				"route53:ListHostedZonesByName",
+				"route53:CreateHostedZone",
+				"route53:GetHostedZone",
+				"route53:AssociateVPCWithHostedZone",
+				"route53:ChangeResourceRecordSets",
+				"route53:ChangeTagsForResource",
+				"route53:ListTagsForResource",
+				"route53:ListHostedZones",
+				"route53:ListResourceRecordSets",
+				"route53:DeleteHostedZone",
 			},
 		},
 		{
@@ -194,8 +241,22 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"autoscaling:UpdateAutoScalingGroup",
 				"autoscaling:CreateOrUpdateTags",
 				"autoscaling:StartInstanceRefresh",
+				"autoscaling:CancelInstanceRefresh",
 				"autoscaling:DeleteAutoScalingGroup",
 				"autoscaling:DeleteTags",
+				"autoscaling:PutWarmPool",
+				"autoscaling:DeleteWarmPool",
+				"autoscaling:SetInstanceProtection",
+				"autoscaling:EnableMetricsCollection",
+				"autoscaling:DisableMetricsCollection",
+				"autoscaling:AttachLoadBalancerTargetGroups",
+				"autoscaling:DetachLoadBalancerTargetGroups",
+				"autoscaling:AttachLoadBalancers",
+				"autoscaling:DetachLoadBalancers",
+				"autoscaling:PutLifecycleHook",
+				"autoscaling:DeleteLifecycleHook",
+				"autoscaling:CompleteLifecycleAction",
+				"autoscaling:TerminateInstanceInAutoScalingGroup",
 			},
 		},
 		{
@@ -234,6 +295,18 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				iamv1.StringLike: map[string]string{"iam:AWSServiceName": "spot.amazonaws.com"},
 			},
 		},
+		{
+			Effect: iamv1.EffectAllow,
+			Action: iamv1.Actions{
+				"iam:CreateServiceLinkedRole",
+			},
+			Resource: iamv1.Resources{
+				"arn:*:iam::*:role/aws-service-role/ec2fleet.amazonaws.com/AWSServiceRoleForEC2Fleet",
+			},
+			Condition: iamv1.Conditions{
+				iamv1.StringLike: map[string]string{"iam:AWSServiceName": "ec2fleet.amazonaws.com"},
+			},
+		},
 		{
 			Effect:   iamv1.EffectAllow,
 			Resource: t.allowedEC2InstanceProfiles(),
@@ -241,6 +314,32 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"iam:PassRole",
 			},
 		},
+		{
+			Effect:   iamv1.EffectAllow,
+			Resource: iamv1.Resources{iamv1.Any},
+			Action: iamv1.Actions{
+				"kms:DescribeKey",
+			},
+		},
+		{
+			Effect:   iamv1.EffectAllow,
+			Resource: iamv1.Resources{iamv1.Any},
+			Action: iamv1.Actions{
+				"kms:CreateGrant",
+			},
+			Condition: iamv1.Conditions{
+				iamv1.StringLike: map[string]string{"kms:GranteePrincipal": "arn:*:iam::*:role/aws-service-role/autoscaling.amazonaws.com/AWSServiceRoleForAutoScaling"},
+			},
+		},
+		{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				"arn:*:ssm:*:*:parameter/aws/service/*",
+			},
+			Action: iamv1.Actions{
+				"ssm:GetParameter",
+			},
+		},
 	}
 	for _, secureSecretBackend := range t.Spec.SecureSecretsBackends {
 		switch secureSecretBackend {
@@ -253,6 +352,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				Action: iamv1.Actions{
 					"secretsmanager:CreateSecret",
 					"secretsmanager:DeleteSecret",
+					"secretsmanager:DescribeSecret",
 					"secretsmanager:TagResource",
 				},
 			})
@@ -293,6 +393,11 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"s3:DeleteObject",
 				"s3:PutBucketPolicy",
 				"s3:PutBucketTagging",
+				"s3:PutBucketPublicAccessBlock",
+				"s3:PutBucketVersioning",
+				"s3:PutEncryptionConfiguration",
+				"s3:PutLifecycleConfiguration",
+				"s3:GetLifecycleConfiguration",
 			},
 		})
 	}
@@ -387,6 +492,10 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 			"iam:CreateRole",
 			"iam:TagRole",
 			"iam:AttachRolePolicy",
+			"iam:ListRolePolicies",
+			"iam:GetRolePolicy",
+			"iam:PutRolePolicy",
+			"iam:DeleteRolePolicy",
 		}...)
 
 		statements = append(statements, iamv1.StatementEntry{
@@ -435,6 +544,7 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 				"eks:DescribeNodegroup",
 				"eks:DeleteNodegroup",
 				"eks:UpdateNodegroupConfig",
+				"eks:DescribeUpdate",
 				"eks:CreateNodegroup",
 				"eks:AssociateEncryptionConfig",
 				"eks:ListIdentityProviderConfigs",
@@ -461,6 +571,19 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 				"eks:DescribeFargateProfile",
 				"eks:CreateFargateProfile",
 				"eks:DeleteFargateProfile",
+				"eks:ListAccessEntries",
+				"eks:CreateAccessEntry",
+				"eks:DescribeAccessEntry",
+				"eks:UpdateAccessEntry",
+				"eks:DeleteAccessEntry",
+				"eks:ListAssociatedAccessPolicies",
+				"eks:AssociateAccessPolicy",
+				"eks:DisassociateAccessPolicy",
+				"eks:ListPodIdentityAssociations",
+				"eks:CreatePodIdentityAssociation",
+				"eks:DescribePodIdentityAssociation",
+				"eks:UpdatePodIdentityAssociation",
+				"eks:DeletePodIdentityAssociation",
 			},
 			Resource: iamv1.Resources{
 				"*",
@@ -479,11 +602,26 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 				},
 			},
 			Effect: iamv1.EffectAllow,
+		}, {
+			Action: iamv1.Actions{
+				"iam:PassRole",
+			},
+			Resource: iamv1.Resources{
+				"*",
+			},
+			Condition: iamv1.Conditions{
+				"StringEquals": map[string]string{
+					"iam:PassedToService": "pods.eks.amazonaws.com",
+				},
+			},
+			Effect: iamv1.EffectAllow,
 		},
 		{
 			Action: iamv1.Actions{
 				"kms:CreateGrant",
 				"kms:DescribeKey",
+				"kms:ListResourceTags",
+				"kms:ScheduleKeyDeletion",
 			},
 			Resource: iamv1.Resources{
 				"*",
@@ -495,6 +633,19 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 				},
 			},
 		},
+		{
+			Action: iamv1.Actions{
+				"kms:CreateKey",
+				"kms:CreateAlias",
+				"kms:UpdateAlias",
+				"kms:DeleteAlias",
+				"kms:TagResource",
+			},
+			Resource: iamv1.Resources{
+				"*",
+			},
+			Effect: iamv1.EffectAllow,
+		},
 	}...)
 
 	return &iamv1.PolicyDocument{
@@ -64,6 +64,18 @@ func (t Template) sessionManagerPolicy() iamv1.StatementEntry {
 	}
 }
 
+// podIdentityAgentPolicy allows the EKS Pod Identity Agent running on the nodes to get the credentials of the
+// roles associated with the service accounts of the pods.
+func (t Template) podIdentityAgentPolicy() iamv1.StatementEntry {
+	return iamv1.StatementEntry{
+		Effect:   iamv1.EffectAllow,
+		Resource: iamv1.Resources{iamv1.Any},
+		Action: iamv1.Actions{
+			"eks-auth:AssumeRoleForPodIdentity",
+		},
+	}
+}
+
 func (t Template) nodeManagedPolicies() []string {
 	policies := t.Spec.Nodes.ExtraPolicyAttachments
 
@@ -93,6 +105,12 @@ func (t Template) nodePolicy() *iamv1.PolicyDocument {
 		policyDocument.Statement,
 		t.sessionManagerPolicy(),
 	)
+	if !t.Spec.EKS.Disable {
+		policyDocument.Statement = append(
+			policyDocument.Statement,
+			t.podIdentityAgentPolicy(),
+		)
+	}
 
 	return policyDocument
 }
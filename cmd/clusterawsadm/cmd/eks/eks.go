@@ -21,6 +21,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/eks/addons"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/eks/recommend"
 )
 
 // RootCmd is an EKS root CLI command.
@@ -33,6 +34,7 @@ func RootCmd() *cobra.Command {
 		},
 	}
 	newCmd.AddCommand(addons.RootCmd())
+	newCmd.AddCommand(recommend.DescribeRecommendedSettingsCmd())
 
 	return newCmd
 }
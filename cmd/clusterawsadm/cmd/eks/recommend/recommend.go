@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package recommend provides the describe-recommended-settings command for the eks package.
+package recommend
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/flags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/eks"
+	cmdout "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/printers"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+)
+
+const outputManifest = "manifest"
+
+// DescribeRecommendedSettingsCmd is the cmd to describe the settings recommended for an EKS cluster.
+func DescribeRecommendedSettingsCmd() *cobra.Command {
+	outputPrinterType := ""
+	region := ""
+	clusterName := ""
+	input := eks.RecommendationInput{}
+	newCmd := &cobra.Command{
+		Use:   "describe-recommended-settings",
+		Short: "Describe the settings recommended for an EKS cluster",
+		Long: cmd.LongDesc(`
+			Describe the AWSManagedControlPlane and AWSManagedMachinePool settings recommended for a Kubernetes
+			version in a region: the default version of each addon, the EKS optimized AMI type and the instance
+			types offered in every availability zone of the region.
+			By default the settings are output as manifest snippets that can be merged into the cluster
+			manifests before applying them.
+		`),
+		Example: cmd.Examples(`
+		# Describe the settings recommended for Kubernetes v1.29 in us-east-1
+		clusterawsadm eks describe-recommended-settings --kubernetes-version v1.29 --region us-east-1
+
+		# Describe the settings recommended for Arm nodes, considering specific instance types
+		clusterawsadm eks describe-recommended-settings --kubernetes-version v1.29 --architecture arm64 --instance-types m7g.xlarge,c7g.xlarge
+
+		# Print the recommendation as JSON
+		clusterawsadm eks describe-recommended-settings --kubernetes-version v1.29 -o json
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			region, err = flags.GetRegionWithError(cmd)
+			if err != nil {
+				return err
+			}
+
+			recommender, err := eks.NewRecommender(region)
+			if err != nil {
+				return err
+			}
+
+			recommendation, err := recommender.Recommend(input)
+			if err != nil {
+				return err
+			}
+
+			if outputPrinterType == outputManifest {
+				manifest, err := recommendation.ToManifest(clusterName)
+				if err != nil {
+					return err
+				}
+				_, err = os.Stdout.Write(manifest)
+				return err
+			}
+
+			outputPrinter, err := cmdout.New(outputPrinterType, os.Stdout)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed creating output printer: %s\n", err.Error())
+				return err
+			}
+			return outputPrinter.Print(recommendation)
+		},
+	}
+
+	newCmd.Flags().StringVarP(&region, "region", "r", "", "The AWS region to use")
+	newCmd.Flags().StringVar(&input.KubernetesVersion, "kubernetes-version", "", "The Kubernetes version of the cluster")
+	newCmd.Flags().StringVar(&input.Architecture, "architecture", eks.ArchitectureX86_64, "The architecture of the nodes. Possible values: x86_64, arm64")
+	newCmd.Flags().StringSliceVar(&input.Addons, "addons", eks.DefaultAddons, "The names of the addons to recommend a version for")
+	newCmd.Flags().StringSliceVar(&input.InstanceTypes, "instance-types", nil, "The instance types to consider, by order of preference. Defaults to general purpose instance types of the architecture")
+	newCmd.Flags().StringVarP(&clusterName, "cluster-name", "n", "capi-eks", "The name of the cluster used in the manifest snippets")
+	newCmd.Flags().StringVarP(&outputPrinterType, "output", "o", outputManifest, "The output format of the results. Possible values: manifest, json, yaml")
+	newCmd.MarkFlagRequired("kubernetes-version") //nolint: errcheck
+	return newCmd
+}
@@ -33,6 +33,9 @@ func newConfigureCmd() *cobra.Command {
 		namespace         string
 		kubeConfig        string
 		kubeConfigDefault string
+		dryRun            bool
+		outputPrinterType string
+		alternativeGC     bool
 		gcTasks           []string
 	)
 
@@ -46,7 +49,8 @@ func newConfigureCmd() *cobra.Command {
 		Long: cmd.LongDesc(`
 			This command will set what cleanup tasks to execute on the given cluster
 			during garbage collection (i.e. deleting) when the cluster is
-			requested to be deleted. Supported values: load-balancer, security-group, target-group.
+			requested to be deleted. Supported values: load-balancer, security-group, target-group, ebs-volume, route53-record.
+			Use --dry-run to report the AWS resources that would be deleted instead.
 		`),
 		Example: cmd.Examples(`
 			# Configure GC for a cluster to delete only load balancers and security groups using existing k8s context
@@ -54,18 +58,31 @@ func newConfigureCmd() *cobra.Command {
 
 			# Reset GC configuration for a cluster using kubeconfig
 			clusterawsadm gc configure --cluster-name=test-cluster --kubeconfig=test.kubeconfig
+
+			# Report the AWS resources that the GC tasks would delete on cluster deletion, without configuring GC
+			clusterawsadm gc configure --cluster-name=test-cluster --gc-task load-balancer --gc-task ebs-volume --dry-run -o json
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			proc, err := gcproc.New(gcproc.GCInput{
-				ClusterName:    clusterName,
-				Namespace:      namespace,
-				KubeconfigPath: kubeConfig,
+				ClusterName:           clusterName,
+				Namespace:             namespace,
+				KubeconfigPath:        kubeConfig,
+				AlternativeGCStrategy: alternativeGC,
 			})
 			if err != nil {
 				return fmt.Errorf("creating command processor: %w", err)
 			}
 
+			if dryRun {
+				report, err := proc.ConfigureDryRun(cmd.Context(), gcTasks)
+				if err != nil {
+					return fmt.Errorf("configuring garbage collection in dry-run mode: %w", err)
+				}
+
+				return printDeletionReport(report, outputPrinterType)
+			}
+
 			if err := proc.Configure(cmd.Context(), gcTasks); err != nil {
 				return fmt.Errorf("configuring garbage collection: %w", err)
 			}
@@ -79,6 +96,7 @@ func newConfigureCmd() *cobra.Command {
 	newCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "The namespace for the cluster definition")
 	newCmd.Flags().StringVar(&kubeConfig, "kubeconfig", kubeConfigDefault, "Path to the kubeconfig file to use")
 	newCmd.Flags().StringSliceVar(&gcTasks, "gc-task", []string{}, "Garbage collection tasks to execute during cluster deletion")
+	addDryRunFlags(newCmd, &dryRun, &outputPrinterType, &alternativeGC)
 
 	newCmd.MarkFlagRequired("cluster-name") //nolint: errcheck
 
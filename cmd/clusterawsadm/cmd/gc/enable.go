@@ -33,6 +33,9 @@ func newEnableCmd() *cobra.Command {
 		namespace         string
 		kubeConfig        string
 		kubeConfigDefault string
+		dryRun            bool
+		outputPrinterType string
+		alternativeGC     bool
 	)
 
 	if home := homedir.HomeDir(); home != "" {
@@ -46,7 +49,8 @@ func newEnableCmd() *cobra.Command {
 			This command will mark the given cluster as requiring external
 			resource garbage collection (i.e. deleting) when the cluster is
 			requested to be deleted. This works by adding an annotation to the
-			infra cluster.
+			infra cluster. Use --dry-run to report the AWS resources that would
+			be deleted instead.
 		`),
 		Example: cmd.Examples(`
 			# Enable GC for a cluster using existing k8s context
@@ -54,18 +58,31 @@ func newEnableCmd() *cobra.Command {
 
 			# Enable GC for a cluster using kubeconfig
 			clusterawsadm gc enable --cluster-name=test-cluster --kubeconfig=test.kubeconfig
+
+			# Report the AWS resources that would be deleted on cluster deletion, without enabling GC
+			clusterawsadm gc enable --cluster-name=test-cluster --dry-run
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			proc, err := gcproc.New(gcproc.GCInput{
-				ClusterName:    clusterName,
-				Namespace:      namespace,
-				KubeconfigPath: kubeConfig,
+				ClusterName:           clusterName,
+				Namespace:             namespace,
+				KubeconfigPath:        kubeConfig,
+				AlternativeGCStrategy: alternativeGC,
 			})
 			if err != nil {
 				return fmt.Errorf("creating command processor: %w", err)
 			}
 
+			if dryRun {
+				report, err := proc.EnableDryRun(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("enabling garbage collection in dry-run mode: %w", err)
+				}
+
+				return printDeletionReport(report, outputPrinterType)
+			}
+
 			if err := proc.Enable(cmd.Context()); err != nil {
 				return fmt.Errorf("enabling garbage collection: %w", err)
 			}
@@ -78,6 +95,7 @@ func newEnableCmd() *cobra.Command {
 	newCmd.Flags().StringVar(&clusterName, "cluster-name", "", "The name of the CAPA cluster")
 	newCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "The namespace for the cluster definition")
 	newCmd.Flags().StringVar(&kubeConfig, "kubeconfig", kubeConfigDefault, "Path to the kubeconfig file to use")
+	addDryRunFlags(newCmd, &dryRun, &outputPrinterType, &alternativeGC)
 
 	newCmd.MarkFlagRequired("cluster-name") //nolint: errcheck
 
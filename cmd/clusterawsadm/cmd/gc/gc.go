@@ -18,7 +18,13 @@ limitations under the License.
 package gc
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
+
+	gcproc "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/gc"
+	cmdout "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/printers"
 )
 
 // RootCmd is the root of the `gc command`.
@@ -38,3 +44,29 @@ func RootCmd() *cobra.Command {
 
 	return newCmd
 }
+
+func addDryRunFlags(newCmd *cobra.Command, dryRun *bool, outputPrinterType *string, alternativeGC *bool) {
+	newCmd.Flags().BoolVar(dryRun, "dry-run", false, "Report the AWS resources that garbage collection would delete, without changing the cluster")
+	newCmd.Flags().StringVarP(outputPrinterType, "output", "o", "table", "The output format of the dry-run report. Possible values: table, json, yaml")
+	newCmd.Flags().BoolVar(alternativeGC, "alternative-gc-strategy", false, "Collect the resources for the dry-run report as the controllers do with the AlternativeGCStrategy feature gate enabled")
+}
+
+func printDeletionReport(report *gcproc.DeletionReport, outputPrinterType string) error {
+	outputPrinter, err := cmdout.New(outputPrinterType, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed creating output printer: %s\n", err.Error())
+		return err
+	}
+
+	if outputPrinterType != string(cmdout.PrinterTypeTable) {
+		return outputPrinter.Print(report)
+	}
+
+	if !report.GCEnabled {
+		fmt.Fprintf(os.Stdout, "Garbage collection is disabled for cluster %s/%s, no AWS resources would be deleted\n", report.Namespace, report.ClusterName)
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "AWS resources that would be deleted for cluster %s/%s:\n\n", report.Namespace, report.ClusterName)
+	return outputPrinter.Print(report.ToTable())
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package analyze provides the analyze command for the iam package.
+package analyze
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/flags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/iam"
+	cmdout "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/printers"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+)
+
+// AnalyzeCmd is the cmd to analyze the permissions used by CAPA IAM roles.
+func AnalyzeCmd() *cobra.Command {
+	outputPrinterType := ""
+	region := ""
+	roles := []string{}
+	newCmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Analyze the permissions used by CAPA IAM roles",
+		Long: cmd.LongDesc(`
+			Compare the permissions granted to the IAM roles used by CAPA with the permissions they actually
+			used, based on the IAM last accessed data, and suggest tightened policy documents.
+			By default the control plane, nodes and controllers roles created by "clusterawsadm bootstrap iam"
+			are analyzed. Last accessed data is only tracked for a limited period and, at action level, for a
+			subset of services, so review the suggested policies before applying them.
+		`),
+		Example: cmd.Examples(`
+		# Analyze the default CAPA IAM roles
+		clusterawsadm iam analyze
+
+		# Analyze a specific role and print the result as JSON
+		clusterawsadm iam analyze --role nodes.cluster-api-provider-aws.sigs.k8s.io -o json
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			region, err = flags.GetRegion(cmd)
+			if err != nil {
+				return err
+			}
+
+			analyzer, err := iam.NewAnalyzer(region)
+			if err != nil {
+				return err
+			}
+
+			report, err := analyzer.AnalyzeRoles(roles)
+			if err != nil {
+				return err
+			}
+
+			outputPrinter, err := cmdout.New(outputPrinterType, os.Stdout)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed creating output printer: %s\n", err.Error())
+				return err
+			}
+
+			if outputPrinterType != string(cmdout.PrinterTypeTable) {
+				return outputPrinter.Print(report)
+			}
+
+			if err := outputPrinter.Print(report.ToTable()); err != nil {
+				return err
+			}
+			for _, role := range report.Roles {
+				policy, err := converters.IAMPolicyDocumentToJSON(*role.SuggestedPolicy)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(os.Stdout, "\nSuggested policy for role %s:\n%s\n", role.RoleName, policy)
+			}
+			return nil
+		},
+	}
+
+	newCmd.Flags().StringVarP(&region, "region", "r", "", "The AWS region to use")
+	newCmd.Flags().StringSliceVar(&roles, "role", defaultRoles(), "The names of the IAM roles to analyze")
+	newCmd.Flags().StringVarP(&outputPrinterType, "output", "o", "table", "The output format of the results. Possible values: table, json, yaml")
+	return newCmd
+}
+
+func defaultRoles() []string {
+	return []string{
+		"control-plane" + iamv1.DefaultNameSuffix,
+		"nodes" + iamv1.DefaultNameSuffix,
+		"controllers" + iamv1.DefaultNameSuffix,
+	}
+}
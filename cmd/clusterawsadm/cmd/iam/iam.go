@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package iam provides commands related to the AWS IAM roles used by CAPA.
+package iam
+
+import (
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/iam/analyze"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+)
+
+// RootCmd is the root of the `iam command`.
+func RootCmd() *cobra.Command {
+	newCmd := &cobra.Command{
+		Use:   "iam [command]",
+		Short: "Commands related to AWS IAM roles used by CAPA",
+		Args:  cobra.NoArgs,
+		Long: cmd.LongDesc(`
+			All AWS IAM related actions such as:
+			# Analyze the permissions used by the CAPA IAM roles
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	newCmd.AddCommand(analyze.AnalyzeCmd())
+
+	return newCmd
+}
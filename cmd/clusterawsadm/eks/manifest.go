@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+type objectMeta struct {
+	Name string `json:"name"`
+}
+
+type manifestObject struct {
+	APIVersion string      `json:"apiVersion"`
+	Kind       string      `json:"kind"`
+	Metadata   objectMeta  `json:"metadata"`
+	Spec       interface{} `json:"spec"`
+}
+
+type controlPlaneSpec struct {
+	Region  string                    `json:"region"`
+	Version string                    `json:"version"`
+	Addons  []ekscontrolplanev1.Addon `json:"addons,omitempty"`
+}
+
+type machinePoolSpec struct {
+	AMIType      expinfrav1.ManagedMachineAMIType `json:"amiType"`
+	InstanceType string                           `json:"instanceType"`
+}
+
+// ToManifest renders the recommendation as AWSManagedControlPlane and AWSManagedMachinePool manifests
+// for the given cluster name, holding only the recommended settings.
+func (r *Recommendation) ToManifest(clusterName string) ([]byte, error) {
+	objects := []manifestObject{
+		{
+			APIVersion: ekscontrolplanev1.GroupVersion.String(),
+			Kind:       "AWSManagedControlPlane",
+			Metadata:   objectMeta{Name: clusterName + "-control-plane"},
+			Spec: controlPlaneSpec{
+				Region:  r.Region,
+				Version: r.KubernetesVersion,
+				Addons:  r.Addons,
+			},
+		},
+		{
+			APIVersion: expinfrav1.GroupVersion.String(),
+			Kind:       "AWSManagedMachinePool",
+			Metadata:   objectMeta{Name: clusterName + "-pool-0"},
+			Spec: machinePoolSpec{
+				AMIType:      r.AMIType,
+				InstanceType: r.InstanceTypes[0],
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "# Recommended settings for Kubernetes %s in %s.\n", r.KubernetesVersion, r.Region)
+	if len(r.InstanceTypes) > 1 {
+		fmt.Fprintf(buf, "# Alternative instance types offered in every availability zone: %s.\n", strings.Join(r.InstanceTypes[1:], ", "))
+	}
+	for i, obj := range objects {
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(out)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eks provides a way to recommend settings for EKS clusters managed by CAPA.
+package eks
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+const (
+	// ArchitectureX86_64 is the x86-64 architecture.
+	ArchitectureX86_64 = "x86_64"
+	// ArchitectureArm64 is the Arm architecture.
+	ArchitectureArm64 = "arm64"
+)
+
+// DefaultAddons are the addons recommended by default.
+var DefaultAddons = []string{"vpc-cni", "coredns", "kube-proxy"}
+
+// defaultInstanceTypes are the instance types considered for each architecture, by order of preference.
+var defaultInstanceTypes = map[string][]string{
+	ArchitectureX86_64: {"m7i.large", "m6i.large", "m5.large", "m6a.large", "m5a.large"},
+	ArchitectureArm64:  {"m7g.large", "m6g.large"},
+}
+
+// minAL2023Version is the first Kubernetes version with AL2023 EKS optimized AMIs.
+var minAL2023Version = version.MustParseGeneric("1.23")
+
+// RecommendationInput is the input of a recommendation.
+type RecommendationInput struct {
+	KubernetesVersion string
+	Architecture      string
+	Addons            []string
+	// InstanceTypes are the instance types to consider, by order of preference. Defaults to general
+	// purpose instance types of the architecture.
+	InstanceTypes []string
+}
+
+// Recommendation holds the settings recommended for an EKS cluster.
+type Recommendation struct {
+	Region            string                           `json:"region"`
+	KubernetesVersion string                           `json:"kubernetesVersion"`
+	Addons            []ekscontrolplanev1.Addon        `json:"addons"`
+	AMIType           expinfrav1.ManagedMachineAMIType `json:"amiType"`
+	// InstanceTypes are the instance types offered in every availability zone of the region, by order of preference.
+	InstanceTypes []string `json:"instanceTypes"`
+}
+
+// Recommender recommends settings for EKS clusters.
+type Recommender struct {
+	Region    string
+	EKSClient eksiface.EKSAPI
+	EC2Client ec2iface.EC2API
+}
+
+// NewRecommender creates a Recommender using the default AWS credential chain.
+func NewRecommender(region string) (*Recommender, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config:            aws.Config{Region: aws.String(region)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recommender{
+		Region:    region,
+		EKSClient: eks.New(sess),
+		EC2Client: ec2.New(sess),
+	}, nil
+}
+
+// Recommend returns the settings recommended for the given Kubernetes version and architecture.
+func (r *Recommender) Recommend(input RecommendationInput) (*Recommendation, error) {
+	kubernetesVersion, err := version.ParseGeneric(input.KubernetesVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid Kubernetes version %q", input.KubernetesVersion)
+	}
+	eksVersion := eksVersionOf(kubernetesVersion)
+
+	instanceTypes := input.InstanceTypes
+	if len(instanceTypes) == 0 {
+		instanceTypes = defaultInstanceTypes[input.Architecture]
+	}
+	amiType, err := recommendAMIType(kubernetesVersion, input.Architecture)
+	if err != nil {
+		return nil, err
+	}
+
+	addons, err := r.recommendAddons(eksVersion, input.Addons)
+	if err != nil {
+		return nil, err
+	}
+
+	offered, err := r.recommendInstanceTypes(instanceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recommendation{
+		Region:            r.Region,
+		KubernetesVersion: "v" + eksVersion,
+		Addons:            addons,
+		AMIType:           amiType,
+		InstanceTypes:     offered,
+	}, nil
+}
+
+// eksVersionOf returns the major and minor version, as used by the EKS API.
+func eksVersionOf(v *version.Version) string {
+	return version.MajorMinor(v.Major(), v.Minor()).String()
+}
+
+func recommendAMIType(kubernetesVersion *version.Version, architecture string) (expinfrav1.ManagedMachineAMIType, error) {
+	al2023 := kubernetesVersion.AtLeast(minAL2023Version)
+	switch architecture {
+	case ArchitectureX86_64:
+		if al2023 {
+			return expinfrav1.Al2023x86_64, nil
+		}
+		return expinfrav1.Al2x86_64, nil
+	case ArchitectureArm64:
+		if al2023 {
+			return expinfrav1.Al2023Arm64, nil
+		}
+		return expinfrav1.Al2Arm64, nil
+	}
+	return "", errors.Errorf("unsupported architecture %q, expected %s or %s", architecture, ArchitectureX86_64, ArchitectureArm64)
+}
+
+// recommendAddons returns the default version of each addon for the given EKS version.
+func (r *Recommender) recommendAddons(eksVersion string, names []string) ([]ekscontrolplanev1.Addon, error) {
+	addons := []ekscontrolplanev1.Addon{}
+	for _, name := range names {
+		var defaultVersion string
+		if err := r.EKSClient.DescribeAddonVersionsPages(&eks.DescribeAddonVersionsInput{
+			AddonName:         aws.String(name),
+			KubernetesVersion: aws.String(eksVersion),
+		}, func(out *eks.DescribeAddonVersionsOutput, last bool) bool {
+			for _, info := range out.Addons {
+				for _, addonVersion := range info.AddonVersions {
+					for _, compat := range addonVersion.Compatibilities {
+						if aws.StringValue(compat.ClusterVersion) == eksVersion && aws.BoolValue(compat.DefaultVersion) {
+							defaultVersion = aws.StringValue(addonVersion.AddonVersion)
+							return false
+						}
+					}
+				}
+			}
+			return true
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to describe versions of addon %q", name)
+		}
+
+		if defaultVersion == "" {
+			return nil, errors.Errorf("no version of addon %q is compatible with Kubernetes %s, check the version is supported by EKS", name, eksVersion)
+		}
+		addons = append(addons, ekscontrolplanev1.Addon{
+			Name:    name,
+			Version: defaultVersion,
+		})
+	}
+	return addons, nil
+}
+
+// recommendInstanceTypes returns the instance types offered in every availability zone of the region,
+// by order of preference.
+func (r *Recommender) recommendInstanceTypes(instanceTypes []string) ([]string, error) {
+	zones, err := r.EC2Client.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("state"), Values: aws.StringSlice([]string{ec2.AvailabilityZoneStateAvailable})},
+			{Name: aws.String("zone-type"), Values: aws.StringSlice([]string{"availability-zone"})},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe availability zones")
+	}
+	allZones := sets.New[string]()
+	for _, zone := range zones.AvailabilityZones {
+		allZones.Insert(aws.StringValue(zone.ZoneName))
+	}
+
+	offeredZones := map[string]sets.Set[string]{}
+	if err := r.EC2Client.DescribeInstanceTypeOfferingsPages(&ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+		Filters: []*ec2.Filter{
+			{Name: aws.String("instance-type"), Values: aws.StringSlice(instanceTypes)},
+		},
+	}, func(out *ec2.DescribeInstanceTypeOfferingsOutput, last bool) bool {
+		for _, offering := range out.InstanceTypeOfferings {
+			instanceType := aws.StringValue(offering.InstanceType)
+			if offeredZones[instanceType] == nil {
+				offeredZones[instanceType] = sets.New[string]()
+			}
+			offeredZones[instanceType].Insert(aws.StringValue(offering.Location))
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to describe instance type offerings")
+	}
+
+	offered := []string{}
+	for _, instanceType := range instanceTypes {
+		if zones, ok := offeredZones[instanceType]; ok && zones.IsSuperset(allZones) {
+			offered = append(offered, instanceType)
+		}
+	}
+	if len(offered) == 0 {
+		return nil, errors.Errorf("none of the instance types %v are offered in every availability zone of the region", instanceTypes)
+	}
+	return offered, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestRecommend(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	eksMock.EXPECT().DescribeAddonVersionsPages(gomock.Eq(&eks.DescribeAddonVersionsInput{
+		AddonName:         aws.String("vpc-cni"),
+		KubernetesVersion: aws.String("1.29"),
+	}), gomock.Any()).DoAndReturn(func(_ *eks.DescribeAddonVersionsInput, fn func(*eks.DescribeAddonVersionsOutput, bool) bool) error {
+		fn(&eks.DescribeAddonVersionsOutput{
+			Addons: []*eks.AddonInfo{{
+				AddonName: aws.String("vpc-cni"),
+				AddonVersions: []*eks.AddonVersionInfo{
+					{
+						AddonVersion:    aws.String("v1.18.0-eksbuild.1"),
+						Compatibilities: []*eks.Compatibility{{ClusterVersion: aws.String("1.29"), DefaultVersion: aws.Bool(false)}},
+					},
+					{
+						AddonVersion:    aws.String("v1.16.0-eksbuild.1"),
+						Compatibilities: []*eks.Compatibility{{ClusterVersion: aws.String("1.29"), DefaultVersion: aws.Bool(true)}},
+					},
+				},
+			}},
+		}, true)
+		return nil
+	})
+
+	ec2Mock.EXPECT().DescribeAvailabilityZones(gomock.Any()).Return(&ec2.DescribeAvailabilityZonesOutput{
+		AvailabilityZones: []*ec2.AvailabilityZone{
+			{ZoneName: aws.String("us-east-1a")},
+			{ZoneName: aws.String("us-east-1b")},
+		},
+	}, nil)
+	ec2Mock.EXPECT().DescribeInstanceTypeOfferingsPages(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *ec2.DescribeInstanceTypeOfferingsInput, fn func(*ec2.DescribeInstanceTypeOfferingsOutput, bool) bool) error {
+			fn(&ec2.DescribeInstanceTypeOfferingsOutput{
+				InstanceTypeOfferings: []*ec2.InstanceTypeOffering{
+					{InstanceType: aws.String("m7i.large"), Location: aws.String("us-east-1a")},
+					{InstanceType: aws.String("m6i.large"), Location: aws.String("us-east-1a")},
+					{InstanceType: aws.String("m6i.large"), Location: aws.String("us-east-1b")},
+					{InstanceType: aws.String("m5.large"), Location: aws.String("us-east-1a")},
+					{InstanceType: aws.String("m5.large"), Location: aws.String("us-east-1b")},
+				},
+			}, true)
+			return nil
+		})
+
+	r := &Recommender{Region: "us-east-1", EKSClient: eksMock, EC2Client: ec2Mock}
+	recommendation, err := r.Recommend(RecommendationInput{
+		KubernetesVersion: "v1.29.3",
+		Architecture:      ArchitectureX86_64,
+		Addons:            []string{"vpc-cni"},
+		InstanceTypes:     []string{"m7i.large", "m6i.large", "m5.large"},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recommendation).To(Equal(&Recommendation{
+		Region:            "us-east-1",
+		KubernetesVersion: "v1.29",
+		Addons:            []ekscontrolplanev1.Addon{{Name: "vpc-cni", Version: "v1.16.0-eksbuild.1"}},
+		AMIType:           expinfrav1.Al2023x86_64,
+		InstanceTypes:     []string{"m6i.large", "m5.large"},
+	}))
+
+	manifest, err := recommendation.ToManifest("test")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(manifest)).To(Equal(`# Recommended settings for Kubernetes v1.29 in us-east-1.
+# Alternative instance types offered in every availability zone: m5.large.
+apiVersion: controlplane.cluster.x-k8s.io/v1beta2
+kind: AWSManagedControlPlane
+metadata:
+  name: test-control-plane
+spec:
+  addons:
+  - name: vpc-cni
+    version: v1.16.0-eksbuild.1
+  region: us-east-1
+  version: v1.29
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+kind: AWSManagedMachinePool
+metadata:
+  name: test-pool-0
+spec:
+  amiType: AL2023_x86_64_STANDARD
+  instanceType: m6i.large
+`))
+}
+
+func TestRecommendAMIType(t *testing.T) {
+	testCases := []struct {
+		version      string
+		architecture string
+		expect       expinfrav1.ManagedMachineAMIType
+		expectErr    bool
+	}{
+		{version: "1.22", architecture: ArchitectureX86_64, expect: expinfrav1.Al2x86_64},
+		{version: "1.22", architecture: ArchitectureArm64, expect: expinfrav1.Al2Arm64},
+		{version: "1.29", architecture: ArchitectureX86_64, expect: expinfrav1.Al2023x86_64},
+		{version: "1.29", architecture: ArchitectureArm64, expect: expinfrav1.Al2023Arm64},
+		{version: "1.29", architecture: "s390x", expectErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.version+"/"+tc.architecture, func(t *testing.T) {
+			g := NewWithT(t)
+			amiType, err := recommendAMIType(version.MustParseGeneric(tc.version), tc.architecture)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(amiType).To(Equal(tc.expect))
+		})
+	}
+}
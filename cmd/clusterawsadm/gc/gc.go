@@ -20,8 +20,10 @@ package gc
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/exec" // import all auth plugins
@@ -32,6 +34,9 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/annotations"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	gcsvc "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/gc"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -42,6 +47,7 @@ var (
 )
 
 func init() {
+	_ = corev1.AddToScheme(scheme)
 	_ = clusterv1.AddToScheme(scheme)
 	_ = infrav1.AddToScheme(scheme)
 	_ = ekscontrolplanev1.AddToScheme(scheme)
@@ -49,7 +55,8 @@ func init() {
 
 // CmdProcessor handles the garbage collection commands.
 type CmdProcessor struct {
-	client client.Client
+	client  client.Client
+	planner DeletionPlanner
 
 	clusterName string
 	namespace   string
@@ -60,8 +67,15 @@ type GCInput struct {
 	ClusterName    string
 	Namespace      string
 	KubeconfigPath string
+	// AlternativeGCStrategy collects the resources for dry-runs as the controllers do when the
+	// AlternativeGCStrategy feature gate is enabled.
+	AlternativeGCStrategy bool
 }
 
+// DeletionPlanner returns the AWS resources that the garbage collection would delete for the infra
+// cluster, as configured by its annotations.
+type DeletionPlanner func(ctx context.Context, cluster *clusterv1.Cluster, infraCluster *unstructured.Unstructured) ([]gcsvc.PlannedDeletion, error)
+
 // CmdProcessorOption is a function type to supply options when creating the command processor.
 type CmdProcessorOption func(proc *CmdProcessor) error
 
@@ -74,6 +88,15 @@ func WithClient(client client.Client) CmdProcessorOption {
 	}
 }
 
+// WithDeletionPlanner is an option that enable you to explicitly supply the planner used by dry-runs.
+func WithDeletionPlanner(planner DeletionPlanner) CmdProcessorOption {
+	return func(proc *CmdProcessor) error {
+		proc.planner = planner
+
+		return nil
+	}
+}
+
 // New creates a new instance of the command processor.
 func New(input GCInput, opts ...CmdProcessorOption) (*CmdProcessor, error) {
 	cmd := &CmdProcessor{
@@ -101,6 +124,10 @@ func New(input GCInput, opts ...CmdProcessorOption) (*CmdProcessor, error) {
 		cmd.client = cl
 	}
 
+	if cmd.planner == nil {
+		cmd.planner = cmd.newGCServicePlanner(input.AlternativeGCStrategy)
+	}
+
 	return cmd, nil
 }
 
@@ -122,9 +149,39 @@ func (c *CmdProcessor) Disable(ctx context.Context) error {
 	return nil
 }
 
+// EnableDryRun reports the AWS resources that would be deleted once external resource garbage collection is
+// enabled for a cluster, without enabling it.
+func (c *CmdProcessor) EnableDryRun(ctx context.Context) (*DeletionReport, error) {
+	return c.dryRun(ctx, infrav1.ExternalResourceGCAnnotation, "true")
+}
+
 // Configure is used to configure external resource garbage collection for a cluster.
 func (c *CmdProcessor) Configure(ctx context.Context, gcTasks []string) error {
-	supportedGCTasks := []infrav1.GCTask{infrav1.GCTaskLoadBalancer, infrav1.GCTaskTargetGroup, infrav1.GCTaskSecurityGroup}
+	if err := validateGCTasks(gcTasks); err != nil {
+		return err
+	}
+
+	annotationValue := strings.Join(gcTasks, ",")
+
+	if err := c.setAnnotationAndPatch(ctx, infrav1.ExternalResourceGCTasksAnnotation, annotationValue); err != nil {
+		return fmt.Errorf("setting gc tasks annotation to %s: %w", annotationValue, err)
+	}
+
+	return nil
+}
+
+// ConfigureDryRun reports the AWS resources that would be deleted once external resource garbage collection is
+// configured for a cluster, without configuring it.
+func (c *CmdProcessor) ConfigureDryRun(ctx context.Context, gcTasks []string) (*DeletionReport, error) {
+	if err := validateGCTasks(gcTasks); err != nil {
+		return nil, err
+	}
+
+	return c.dryRun(ctx, infrav1.ExternalResourceGCTasksAnnotation, strings.Join(gcTasks, ","))
+}
+
+func validateGCTasks(gcTasks []string) error {
+	supportedGCTasks := []infrav1.GCTask{infrav1.GCTaskLoadBalancer, infrav1.GCTaskTargetGroup, infrav1.GCTaskSecurityGroup, infrav1.GCTaskEBSVolume, infrav1.GCTaskRoute53Record}
 
 	for _, gcTask := range gcTasks {
 		found := false
@@ -141,17 +198,99 @@ func (c *CmdProcessor) Configure(ctx context.Context, gcTasks []string) error {
 		}
 	}
 
-	annotationValue := strings.Join(gcTasks, ",")
+	return nil
+}
 
-	if err := c.setAnnotationAndPatch(ctx, infrav1.ExternalResourceGCTasksAnnotation, annotationValue); err != nil {
-		return fmt.Errorf("setting gc tasks annotation to %s: %w", annotationValue, err)
+// dryRun reports the AWS resources that the garbage collection would delete with the annotation set to the
+// given value, without patching the infra cluster.
+func (c *CmdProcessor) dryRun(ctx context.Context, annotationName, annotationValue string) (*DeletionReport, error) {
+	cluster, infraObj, err := c.getClusters(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	setAnnotation(infraObj, annotationName, annotationValue)
+
+	report := &DeletionReport{
+		ClusterName: c.clusterName,
+		Namespace:   c.namespace,
+		Resources:   []ResourceToDelete{},
+	}
+
+	val, found := annotations.Get(infraObj, infrav1.ExternalResourceGCAnnotation)
+	if !found {
+		val = "true"
+	}
+	report.GCEnabled, err = strconv.ParseBool(val)
+	if err != nil {
+		return nil, fmt.Errorf("converting value %s of annotation %s to bool: %w", val, infrav1.ExternalResourceGCAnnotation, err)
+	}
+
+	planned, err := c.planner(ctx, cluster, infraObj)
+	if err != nil {
+		return nil, fmt.Errorf("planning garbage collection: %w", err)
+	}
+
+	for _, p := range planned {
+		report.Resources = append(report.Resources, ResourceToDelete{
+			Task:     string(p.Task),
+			Service:  p.Resource.ARN.Service,
+			Resource: p.Resource.ARN.Resource,
+		})
+	}
+
+	return report, nil
+}
+
+// newGCServicePlanner returns a planner that looks up the resources in AWS like the controllers do, with
+// the credentials of the identity of the cluster.
+func (c *CmdProcessor) newGCServicePlanner(alternativeGCStrategy bool) DeletionPlanner {
+	return func(ctx context.Context, cluster *clusterv1.Cluster, infraCluster *unstructured.Unstructured) ([]gcsvc.PlannedDeletion, error) {
+		var clusterScope cloud.ClusterScoper
+
+		switch infraCluster.GetKind() {
+		case "AWSCluster":
+			awsCluster := &infrav1.AWSCluster{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(infraCluster.Object, awsCluster); err != nil {
+				return nil, fmt.Errorf("converting infra cluster: %w", err)
+			}
+
+			s, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:         c.client,
+				Cluster:        cluster,
+				AWSCluster:     awsCluster,
+				ControllerName: "clusterawsadm",
+			})
+			if err != nil {
+				return nil, fmt.Errorf("creating cluster scope: %w", err)
+			}
+			clusterScope = s
+		case "AWSManagedControlPlane":
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(infraCluster.Object, controlPlane); err != nil {
+				return nil, fmt.Errorf("converting infra cluster: %w", err)
+			}
+
+			s, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client:         c.client,
+				Cluster:        cluster,
+				ControlPlane:   controlPlane,
+				ControllerName: "clusterawsadm",
+			})
+			if err != nil {
+				return nil, fmt.Errorf("creating managed control plane scope: %w", err)
+			}
+			clusterScope = s
+		default:
+			return nil, fmt.Errorf("unsupported infra cluster kind %s", infraCluster.GetKind())
+		}
+
+		return gcsvc.NewService(clusterScope, gcsvc.WithGCStrategy(alternativeGCStrategy)).PlanDeletion(ctx)
+	}
 }
 
 func (c *CmdProcessor) setAnnotationAndPatch(ctx context.Context, annotationName, annotationValue string) error {
-	infraObj, err := c.getInfraCluster(ctx)
+	_, infraObj, err := c.getClusters(ctx)
 	if err != nil {
 		return err
 	}
@@ -161,11 +300,7 @@ func (c *CmdProcessor) setAnnotationAndPatch(ctx context.Context, annotationName
 		return fmt.Errorf("creating patch helper: %w", err)
 	}
 
-	if annotationValue != "" {
-		annotations.Set(infraObj, annotationName, annotationValue)
-	} else {
-		annotations.Delete(infraObj, annotationName)
-	}
+	setAnnotation(infraObj, annotationName, annotationValue)
 
 	if err := patchHelper.Patch(ctx, infraObj); err != nil {
 		return fmt.Errorf("patching infra cluster with gc annotation: %w", err)
@@ -174,7 +309,15 @@ func (c *CmdProcessor) setAnnotationAndPatch(ctx context.Context, annotationName
 	return nil
 }
 
-func (c *CmdProcessor) getInfraCluster(ctx context.Context) (*unstructured.Unstructured, error) {
+func setAnnotation(infraObj *unstructured.Unstructured, annotationName, annotationValue string) {
+	if annotationValue != "" {
+		annotations.Set(infraObj, annotationName, annotationValue)
+	} else {
+		annotations.Delete(infraObj, annotationName)
+	}
+}
+
+func (c *CmdProcessor) getClusters(ctx context.Context) (*clusterv1.Cluster, *unstructured.Unstructured, error) {
 	cluster := &clusterv1.Cluster{}
 
 	key := client.ObjectKey{
@@ -183,14 +326,14 @@ func (c *CmdProcessor) getInfraCluster(ctx context.Context) (*unstructured.Unstr
 	}
 
 	if err := c.client.Get(ctx, key, cluster); err != nil {
-		return nil, fmt.Errorf("getting capi cluster %s/%s: %w", c.namespace, c.clusterName, err)
+		return nil, nil, fmt.Errorf("getting capi cluster %s/%s: %w", c.namespace, c.clusterName, err)
 	}
 
 	ref := cluster.Spec.InfrastructureRef
 	obj, err := external.Get(ctx, c.client, ref, cluster.Namespace)
 	if err != nil {
-		return nil, fmt.Errorf("getting infra cluster %s/%s: %w", ref.Namespace, ref.Name, err)
+		return nil, nil, fmt.Errorf("getting infra cluster %s/%s: %w", ref.Namespace, ref.Name, err)
 	}
 
-	return obj, nil
+	return cluster, obj, nil
 }
@@ -21,9 +21,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -31,6 +33,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/annotations"
+	gcsvc "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/gc"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
 )
@@ -288,6 +291,119 @@ func TestConfigureGC(t *testing.T) {
 	}
 }
 
+func TestDryRunGC(t *testing.T) {
+	RegisterTestingT(t)
+
+	lbARN, err := arn.Parse("arn:aws:elasticloadbalancing:eu-west-2:1234567890:loadbalancer/net/svc1/e979fe9bd6825433")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		existingObjs  []client.Object
+		gcTasks       []string
+		configure     bool
+		expectError   bool
+		expectReport  *DeletionReport
+		expectPlanned bool
+		expectGCTasks string
+		expectGCFlag  string
+	}{
+		{
+			name:         "no capi cluster",
+			existingObjs: []client.Object{},
+			expectError:  true,
+		},
+		{
+			name:          "enable with awscluster opted-out",
+			existingObjs:  newUnManagedClusterWithAnnotations(testClusterName, map[string]string{infrav1.ExternalResourceGCAnnotation: "false"}),
+			expectPlanned: true,
+			expectGCFlag:  "true",
+			expectReport: &DeletionReport{
+				ClusterName: testClusterName,
+				Namespace:   "default",
+				GCEnabled:   true,
+				Resources:   []ResourceToDelete{{Task: "load-balancer", Service: "elasticloadbalancing", Resource: "loadbalancer/net/svc1/e979fe9bd6825433"}},
+			},
+		},
+		{
+			name:          "configure with managed control plane",
+			existingObjs:  newManagedClusterWithAnnotations(testClusterName, map[string]string{infrav1.ExternalResourceGCTasksAnnotation: "security-group"}),
+			gcTasks:       []string{"load-balancer", "ebs-volume"},
+			configure:     true,
+			expectPlanned: true,
+			expectGCTasks: "load-balancer,ebs-volume",
+			expectGCFlag:  "",
+			expectReport: &DeletionReport{
+				ClusterName: testClusterName,
+				Namespace:   "default",
+				GCEnabled:   true,
+				Resources:   []ResourceToDelete{{Task: "load-balancer", Service: "elasticloadbalancing", Resource: "loadbalancer/net/svc1/e979fe9bd6825433"}},
+			},
+		},
+		{
+			name:         "configure with invalid gc tasks",
+			existingObjs: newUnManagedCluster(testClusterName, false),
+			gcTasks:      []string{"load-balancer", "INVALID"},
+			configure:    true,
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			input := GCInput{
+				ClusterName: testClusterName,
+				Namespace:   "default",
+			}
+
+			fake := newFakeClient(scheme, tc.existingObjs...)
+			ctx := context.TODO()
+
+			planned := false
+			planner := func(_ context.Context, cluster *clusterv1.Cluster, infraCluster *unstructured.Unstructured) ([]gcsvc.PlannedDeletion, error) {
+				planned = true
+				g.Expect(cluster.Name).To(Equal(testClusterName))
+
+				gcFlag, _ := annotations.Get(infraCluster, infrav1.ExternalResourceGCAnnotation)
+				g.Expect(gcFlag).To(Equal(tc.expectGCFlag))
+				if tc.configure {
+					gcTasks, _ := annotations.Get(infraCluster, infrav1.ExternalResourceGCTasksAnnotation)
+					g.Expect(gcTasks).To(Equal(tc.expectGCTasks))
+				}
+
+				return []gcsvc.PlannedDeletion{{Task: infrav1.GCTaskLoadBalancer, Resource: &gcsvc.AWSResource{ARN: &lbARN}}}, nil
+			}
+
+			proc, err := New(input, WithClient(fake), WithDeletionPlanner(planner))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			var report *DeletionReport
+			if tc.configure {
+				report, err = proc.ConfigureDryRun(ctx, tc.gcTasks)
+			} else {
+				report, err = proc.EnableDryRun(ctx)
+			}
+			g.Expect(planned).To(Equal(tc.expectPlanned))
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(report).To(Equal(tc.expectReport))
+
+			// The infra cluster is left untouched.
+			existing := tc.existingObjs[1]
+			obj, err := external.Get(ctx, fake, tc.existingObjs[0].(*clusterv1.Cluster).Spec.InfrastructureRef, "default")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(obj.GetAnnotations()).To(Equal(existing.GetAnnotations()))
+		})
+	}
+}
+
 func newFakeClient(scheme *runtime.Scheme, objs ...client.Object) client.Client {
 	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
 }
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceToDelete defines an AWS resource that the garbage collection would delete.
+type ResourceToDelete struct {
+	Task     string `json:"task"`
+	Service  string `json:"service"`
+	Resource string `json:"resource"`
+}
+
+// DeletionReport defines the AWS resources that the garbage collection would delete for a cluster.
+type DeletionReport struct {
+	ClusterName string             `json:"cluster_name"`
+	Namespace   string             `json:"namespace"`
+	GCEnabled   bool               `json:"gc_enabled"`
+	Resources   []ResourceToDelete `json:"resources"`
+}
+
+// ToTable converts DeletionReport to Table.
+func (r *DeletionReport) ToTable() *metav1.Table {
+	table := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: metav1.SchemeGroupVersion.String(),
+			Kind:       "Table",
+		},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{
+				Name: "Task",
+				Type: "string",
+			},
+			{
+				Name: "Service",
+				Type: "string",
+			},
+			{
+				Name: "Resource",
+				Type: "string",
+			},
+		},
+	}
+
+	for _, resource := range r.Resources {
+		row := metav1.TableRow{
+			Cells: []interface{}{resource.Task, resource.Service, resource.Resource},
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
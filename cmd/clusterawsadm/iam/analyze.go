@@ -0,0 +1,364 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package iam provides a way to analyze the permissions used by the IAM roles created by clusterawsadm.
+package iam
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+)
+
+const (
+	defaultPollInterval = 2 * time.Second
+	defaultPollTimeout  = 2 * time.Minute
+)
+
+// Analyzer compares the permissions granted to IAM roles with the permissions they actually use,
+// based on the IAM last accessed data.
+type Analyzer struct {
+	IAMClient    iamiface.IAMAPI
+	PollInterval time.Duration
+	PollTimeout  time.Duration
+}
+
+// NewAnalyzer creates an Analyzer using the default AWS credential chain.
+func NewAnalyzer(region string) (*Analyzer, error) {
+	cfg := aws.Config{}
+	if region != "" {
+		cfg.Region = aws.String(region)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config:            cfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Analyzer{
+		IAMClient:    iam.New(sess),
+		PollInterval: defaultPollInterval,
+		PollTimeout:  defaultPollTimeout,
+	}, nil
+}
+
+// AnalyzeRoles analyzes each of the given roles.
+func (a *Analyzer) AnalyzeRoles(roleNames []string) (*AnalysisReport, error) {
+	report := &AnalysisReport{}
+	for _, roleName := range roleNames {
+		analysis, err := a.AnalyzeRole(roleName)
+		if err != nil {
+			return nil, err
+		}
+		report.Roles = append(report.Roles, *analysis)
+	}
+	return report, nil
+}
+
+// AnalyzeRole fetches the policies attached to the role and its last accessed data, and computes
+// the unused permissions along with a suggested tightened policy document.
+func (a *Analyzer) AnalyzeRole(roleName string) (*RoleAnalysis, error) {
+	role, err := a.IAMClient.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get role %q", roleName)
+	}
+
+	policies, err := a.rolePolicies(roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := a.lastAccessed(aws.StringValue(role.Role.Arn))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get last accessed data for role %q", roleName)
+	}
+
+	return analyze(roleName, policies, usage), nil
+}
+
+func (a *Analyzer) rolePolicies(roleName string) ([]iamv1.PolicyDocument, error) {
+	var policyARNs []*string
+	if err := a.IAMClient.ListAttachedRolePoliciesPages(&iam.ListAttachedRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	}, func(out *iam.ListAttachedRolePoliciesOutput, last bool) bool {
+		for _, p := range out.AttachedPolicies {
+			policyARNs = append(policyARNs, p.PolicyArn)
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list attached policies of role %q", roleName)
+	}
+
+	var policies []iamv1.PolicyDocument
+	for _, policyARN := range policyARNs {
+		policy, err := a.IAMClient.GetPolicy(&iam.GetPolicyInput{PolicyArn: policyARN})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get policy %q", aws.StringValue(policyARN))
+		}
+		version, err := a.IAMClient.GetPolicyVersion(&iam.GetPolicyVersionInput{
+			PolicyArn: policyARN,
+			VersionId: policy.Policy.DefaultVersionId,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get default version of policy %q", aws.StringValue(policyARN))
+		}
+		doc, err := decodePolicyDocument(aws.StringValue(version.PolicyVersion.Document))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode policy %q", aws.StringValue(policyARN))
+		}
+		policies = append(policies, *doc)
+	}
+
+	var inlineNames []*string
+	if err := a.IAMClient.ListRolePoliciesPages(&iam.ListRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	}, func(out *iam.ListRolePoliciesOutput, last bool) bool {
+		inlineNames = append(inlineNames, out.PolicyNames...)
+		return true
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list inline policies of role %q", roleName)
+	}
+
+	for _, name := range inlineNames {
+		out, err := a.IAMClient.GetRolePolicy(&iam.GetRolePolicyInput{
+			RoleName:   aws.String(roleName),
+			PolicyName: name,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get inline policy %q of role %q", aws.StringValue(name), roleName)
+		}
+		doc, err := decodePolicyDocument(aws.StringValue(out.PolicyDocument))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode inline policy %q of role %q", aws.StringValue(name), roleName)
+		}
+		policies = append(policies, *doc)
+	}
+
+	return policies, nil
+}
+
+func (a *Analyzer) lastAccessed(roleARN string) ([]*iam.ServiceLastAccessed, error) {
+	job, err := a.IAMClient.GenerateServiceLastAccessedDetails(&iam.GenerateServiceLastAccessedDetailsInput{
+		Arn:         aws.String(roleARN),
+		Granularity: aws.String(iam.AccessAdvisorUsageGranularityTypeActionLevel),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(a.PollTimeout)
+	input := &iam.GetServiceLastAccessedDetailsInput{JobId: job.JobId}
+	var services []*iam.ServiceLastAccessed
+	for {
+		out, err := a.IAMClient.GetServiceLastAccessedDetails(input)
+		if err != nil {
+			return nil, err
+		}
+
+		switch aws.StringValue(out.JobStatus) {
+		case iam.JobStatusTypeFailed:
+			if out.Error != nil {
+				return nil, errors.Errorf("last accessed job failed: %s", aws.StringValue(out.Error.Message))
+			}
+			return nil, errors.New("last accessed job failed")
+		case iam.JobStatusTypeInProgress:
+			if time.Now().After(deadline) {
+				return nil, errors.Errorf("timed out waiting for last accessed job %q", aws.StringValue(job.JobId))
+			}
+			time.Sleep(a.PollInterval)
+			continue
+		}
+
+		services = append(services, out.ServicesLastAccessed...)
+		if !aws.BoolValue(out.IsTruncated) {
+			return services, nil
+		}
+		input.Marker = out.Marker
+	}
+}
+
+func decodePolicyDocument(encoded string) (*iamv1.PolicyDocument, error) {
+	raw, err := url.PathUnescape(encoded)
+	if err != nil {
+		return nil, err
+	}
+	doc := &iamv1.PolicyDocument{}
+	if err := json.Unmarshal([]byte(raw), doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// serviceUsage is the last accessed data of a single service namespace.
+type serviceUsage struct {
+	accessed bool
+	// tracked holds the actions IAM tracks at action level, keyed by lower-case action name.
+	tracked map[string]trackedAction
+}
+
+type trackedAction struct {
+	name string
+	used bool
+}
+
+func analyze(roleName string, policies []iamv1.PolicyDocument, lastAccessed []*iam.ServiceLastAccessed) *RoleAnalysis {
+	usage := map[string]*serviceUsage{}
+	for _, svc := range lastAccessed {
+		u := &serviceUsage{
+			accessed: svc.LastAuthenticated != nil,
+			tracked:  map[string]trackedAction{},
+		}
+		namespace := strings.ToLower(aws.StringValue(svc.ServiceNamespace))
+		for _, action := range svc.TrackedActionsLastAccessed {
+			name := namespace + ":" + aws.StringValue(action.ActionName)
+			u.tracked[strings.ToLower(name)] = trackedAction{name: name, used: action.LastAccessedTime != nil}
+		}
+		usage[namespace] = u
+	}
+
+	granted := sets.New[string]()
+	unusedActions := sets.New[string]()
+	suggested := &iamv1.PolicyDocument{Version: iamv1.CurrentVersion}
+	for _, policy := range policies {
+		for _, statement := range policy.Statement {
+			if statement.Effect != iamv1.EffectAllow || len(statement.Action) == 0 {
+				suggested.Statement = append(suggested.Statement, statement)
+				continue
+			}
+
+			actions := sets.New[string]()
+			for _, pattern := range statement.Action {
+				granted.Insert(pattern)
+				kept, unused := tightenAction(pattern, usage)
+				actions.Insert(kept...)
+				unusedActions.Insert(unused...)
+			}
+			if actions.Len() == 0 {
+				continue
+			}
+
+			statement.Action = sets.List(actions)
+			suggested.Statement = append(suggested.Statement, statement)
+		}
+	}
+
+	unusedServices := sets.New[string]()
+	for namespace, u := range usage {
+		if !u.accessed {
+			unusedServices.Insert(namespace)
+		}
+	}
+
+	return &RoleAnalysis{
+		RoleName:        roleName,
+		GrantedActions:  sets.List(granted),
+		UnusedServices:  sets.List(unusedServices),
+		UnusedActions:   sets.List(unusedActions),
+		SuggestedPolicy: suggested,
+	}
+}
+
+// tightenAction returns the actions to keep in place of the granted action pattern, and the
+// tracked actions it grants that were never used. Patterns whose usage cannot be determined are kept.
+func tightenAction(pattern string, usage map[string]*serviceUsage) ([]string, []string) {
+	if pattern == iamv1.Any {
+		var kept, unused []string
+		for namespace, u := range usage {
+			if !u.accessed {
+				continue
+			}
+			if len(u.tracked) == 0 {
+				kept = append(kept, namespace+":"+iamv1.Any)
+				continue
+			}
+			k, un := matchTracked(pattern, u)
+			kept = append(kept, k...)
+			unused = append(unused, un...)
+		}
+		return kept, unused
+	}
+
+	namespace, _, found := strings.Cut(strings.ToLower(pattern), ":")
+	u, ok := usage[namespace]
+	switch {
+	case !found || !ok:
+		return []string{pattern}, nil
+	case !u.accessed:
+		return nil, nil
+	}
+
+	kept, unused := matchTracked(pattern, u)
+	if kept == nil && unused == nil {
+		// None of the actions covered by the pattern are tracked at action level.
+		return []string{pattern}, nil
+	}
+	return kept, unused
+}
+
+func matchTracked(pattern string, u *serviceUsage) ([]string, []string) {
+	var used, unused []string
+	for key, action := range u.tracked {
+		if !matchAction(strings.ToLower(pattern), key) {
+			continue
+		}
+		if action.used {
+			used = append(used, action.name)
+		} else {
+			unused = append(unused, action.name)
+		}
+	}
+	return used, unused
+}
+
+// matchAction reports whether the action matches the IAM action pattern, which may contain the
+// "*" and "?" wildcards.
+func matchAction(pattern, action string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := len(action); i >= 0; i-- {
+				if matchAction(pattern[1:], action[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(action) == 0 {
+				return false
+			}
+		default:
+			if len(action) == 0 || pattern[0] != action[0] {
+				return false
+			}
+		}
+		pattern = pattern[1:]
+		action = action[1:]
+	}
+	return len(action) == 0
+}
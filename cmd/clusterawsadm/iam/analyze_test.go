@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	. "github.com/onsi/gomega"
+
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+)
+
+func TestMatchAction(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		action  string
+		expect  bool
+	}{
+		{pattern: "ec2:describeinstances", action: "ec2:describeinstances", expect: true},
+		{pattern: "ec2:describe*", action: "ec2:describeinstances", expect: true},
+		{pattern: "ec2:describe*", action: "ec2:runinstances", expect: false},
+		{pattern: "ec2:?uninstances", action: "ec2:runinstances", expect: true},
+		{pattern: "*", action: "ec2:runinstances", expect: true},
+		{pattern: "ec2:*instances", action: "ec2:describeinstancestatus", expect: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.pattern+"/"+tc.action, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(matchAction(tc.pattern, tc.action)).To(Equal(tc.expect))
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	g := NewWithT(t)
+	accessed := aws.Time(time.Now())
+
+	policies := []iamv1.PolicyDocument{
+		{
+			Version: iamv1.CurrentVersion,
+			Statement: iamv1.Statements{
+				{
+					Sid:      "EC2",
+					Effect:   iamv1.EffectAllow,
+					Action:   iamv1.Actions{"ec2:Describe*", "ec2:RunInstances"},
+					Resource: iamv1.Resources{iamv1.Any},
+				},
+				{
+					Sid:      "Unused",
+					Effect:   iamv1.EffectAllow,
+					Action:   iamv1.Actions{"autoscaling:DescribeAutoScalingGroups"},
+					Resource: iamv1.Resources{iamv1.Any},
+				},
+				{
+					Sid:      "Untracked",
+					Effect:   iamv1.EffectAllow,
+					Action:   iamv1.Actions{"ssm:GetParameter"},
+					Resource: iamv1.Resources{"arn:*:ssm:*:*:parameter/aws/service/eks/optimized-ami/*"},
+				},
+				{
+					Sid:      "Deny",
+					Effect:   iamv1.EffectDeny,
+					Action:   iamv1.Actions{"iam:*"},
+					Resource: iamv1.Resources{iamv1.Any},
+				},
+			},
+		},
+	}
+	lastAccessed := []*iam.ServiceLastAccessed{
+		{
+			ServiceNamespace:  aws.String("ec2"),
+			LastAuthenticated: accessed,
+			TrackedActionsLastAccessed: []*iam.TrackedActionLastAccessed{
+				{ActionName: aws.String("DescribeInstances"), LastAccessedTime: accessed},
+				{ActionName: aws.String("DescribeImages")},
+				{ActionName: aws.String("RunInstances"), LastAccessedTime: accessed},
+			},
+		},
+		{
+			ServiceNamespace: aws.String("autoscaling"),
+		},
+		{
+			ServiceNamespace:  aws.String("ssm"),
+			LastAuthenticated: accessed,
+		},
+	}
+
+	analysis := analyze("nodes", policies, lastAccessed)
+
+	g.Expect(analysis.RoleName).To(Equal("nodes"))
+	g.Expect(analysis.GrantedActions).To(ConsistOf("ec2:Describe*", "ec2:RunInstances", "autoscaling:DescribeAutoScalingGroups", "ssm:GetParameter"))
+	g.Expect(analysis.UnusedServices).To(ConsistOf("autoscaling"))
+	g.Expect(analysis.UnusedActions).To(ConsistOf("ec2:DescribeImages"))
+	g.Expect(analysis.SuggestedPolicy.Statement).To(Equal(iamv1.Statements{
+		{
+			Sid:      "EC2",
+			Effect:   iamv1.EffectAllow,
+			Action:   iamv1.Actions{"ec2:DescribeInstances", "ec2:RunInstances"},
+			Resource: iamv1.Resources{iamv1.Any},
+		},
+		{
+			Sid:      "Untracked",
+			Effect:   iamv1.EffectAllow,
+			Action:   iamv1.Actions{"ssm:GetParameter"},
+			Resource: iamv1.Resources{"arn:*:ssm:*:*:parameter/aws/service/eks/optimized-ami/*"},
+		},
+		{
+			Sid:      "Deny",
+			Effect:   iamv1.EffectDeny,
+			Action:   iamv1.Actions{"iam:*"},
+			Resource: iamv1.Resources{iamv1.Any},
+		},
+	}))
+}
+
+func TestDecodePolicyDocument(t *testing.T) {
+	g := NewWithT(t)
+	doc, err := decodePolicyDocument("%7B%22Version%22%3A%222012-10-17%22%2C%22Statement%22%3A%5B%7B%22Effect%22%3A%22Allow%22%2C%22Action%22%3A%22s3%3AGetObject%22%2C%22Resource%22%3A%22%2A%22%7D%5D%7D")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(doc.Statement).To(Equal(iamv1.Statements{
+		{Effect: iamv1.EffectAllow, Action: iamv1.Actions{"s3:GetObject"}, Resource: iamv1.Resources{iamv1.Any}},
+	}))
+}
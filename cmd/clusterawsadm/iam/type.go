@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+)
+
+// RoleAnalysis is the result of the analysis of a single IAM role.
+type RoleAnalysis struct {
+	RoleName        string                `json:"role_name"`
+	GrantedActions  []string              `json:"granted_actions"`
+	UnusedServices  []string              `json:"unused_services"`
+	UnusedActions   []string              `json:"unused_actions"`
+	SuggestedPolicy *iamv1.PolicyDocument `json:"suggested_policy"`
+}
+
+// AnalysisReport defines the list of analyzed roles.
+type AnalysisReport struct {
+	Roles []RoleAnalysis `json:"roles"`
+}
+
+// ToTable converts AnalysisReport to Table.
+func (r *AnalysisReport) ToTable() *metav1.Table {
+	table := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: metav1.SchemeGroupVersion.String(),
+			Kind:       "Table",
+		},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{
+				Name: "Role",
+				Type: "string",
+			},
+			{
+				Name: "Granted",
+				Type: "integer",
+			},
+			{
+				Name: "UnusedServices",
+				Type: "string",
+			},
+			{
+				Name: "UnusedActions",
+				Type: "string",
+			},
+		},
+	}
+
+	for _, role := range r.Roles {
+		row := metav1.TableRow{
+			Cells: []interface{}{role.RoleName, len(role.GrantedActions), strings.Join(role.UnusedServices, ","), strings.Join(role.UnusedActions, ",")},
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
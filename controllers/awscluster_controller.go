@@ -41,6 +41,8 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/internal/fake"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
@@ -48,6 +50,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/gc"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/route53"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/s3"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
@@ -74,6 +77,9 @@ type AWSClusterReconciler struct {
 	networkServiceFactory        func(scope.ClusterScope) services.NetworkInterface
 	elbServiceFactory            func(scope.ELBScope) services.ELBInterface
 	securityGroupFactory         func(scope.ClusterScope) services.SecurityGroupInterface
+	objectStoreServiceFactory    func(scope.S3Scope) services.ObjectStoreInterface
+	dnsServiceFactory            func(scope.Route53Scope) services.DNSInterface
+	lookupIP                     func(host string) ([]net.IP, error)
 	Endpoints                    []scope.ServiceEndpoint
 	WatchFilterValue             string
 	ExternalResourceGC           bool
@@ -105,6 +111,38 @@ func (r *AWSClusterReconciler) getNetworkService(scope scope.ClusterScope) servi
 	return network.NewService(&scope)
 }
 
+// getObjectStoreService factory func is added for testing purpose so that we can inject mocked ObjectStoreService to the AWSClusterReconciler.
+func (r *AWSClusterReconciler) getObjectStoreService(scope scope.S3Scope) services.ObjectStoreInterface {
+	if r.objectStoreServiceFactory != nil {
+		return r.objectStoreServiceFactory(scope)
+	}
+	return s3.NewService(scope)
+}
+
+// getDNSService factory func is added for testing purpose so that we can inject mocked DNSService to the AWSClusterReconciler.
+func (r *AWSClusterReconciler) getDNSService(scope scope.Route53Scope) services.DNSInterface {
+	if r.dnsServiceFactory != nil {
+		return r.dnsServiceFactory(scope)
+	}
+	return route53.NewService(scope)
+}
+
+// UseFakeCloud makes the AWSClusterReconciler manage the resources of the clusters in the given fake cloud
+// instead of AWS.
+func (r *AWSClusterReconciler) UseFakeCloud(fakeCloud *fake.Cloud) {
+	r.ec2ServiceFactory = fakeCloud.EC2Service
+	r.elbServiceFactory = fakeCloud.ELBService
+	r.objectStoreServiceFactory = fakeCloud.ObjectStoreService
+	r.dnsServiceFactory = fakeCloud.Route53Service
+	r.networkServiceFactory = func(scope scope.ClusterScope) services.NetworkInterface {
+		return fakeCloud.NetworkService(&scope)
+	}
+	r.securityGroupFactory = func(scope scope.ClusterScope) services.SecurityGroupInterface {
+		return fakeCloud.SecurityGroupService(&scope, securityGroupRolesForCluster(scope))
+	}
+	r.lookupIP = fakeCloud.LookupIP
+}
+
 // securityGroupRolesForCluster returns the security group roles determined by the cluster configuration.
 func securityGroupRolesForCluster(scope scope.ClusterScope) []infrav1.SecurityGroupRole {
 	// Copy to ensure we do not modify the package-level variable.
@@ -211,7 +249,7 @@ func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 	elbsvc := r.getELBService(clusterScope)
 	networkSvc := r.getNetworkService(*clusterScope)
 	sgService := r.getSecurityGroupService(*clusterScope)
-	s3Service := s3.NewService(clusterScope)
+	s3Service := r.getObjectStoreService(clusterScope)
 
 	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
 		instancestateSvc := instancestate.NewService(clusterScope)
@@ -234,6 +272,10 @@ func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 		allErrs = append(allErrs, errors.Wrapf(err, "error deleting S3 Bucket"))
 	}
 
+	if err := r.getDNSService(clusterScope).DeleteControlPlaneDNS(); err != nil {
+		allErrs = append(allErrs, errors.Wrapf(err, "error deleting control plane DNS"))
+	}
+
 	if err := elbsvc.DeleteLoadbalancers(); err != nil {
 		allErrs = append(allErrs, errors.Wrapf(err, "error deleting load balancers"))
 	}
@@ -242,6 +284,10 @@ func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 		allErrs = append(allErrs, errors.Wrapf(err, "error deleting bastion"))
 	}
 
+	if err := ec2svc.DeletePlacementGroups(); err != nil {
+		allErrs = append(allErrs, errors.Wrapf(err, "error deleting placement groups"))
+	}
+
 	if err := sgService.DeleteSecurityGroups(); err != nil {
 		allErrs = append(allErrs, errors.Wrap(err, "error deleting security groups"))
 	}
@@ -289,7 +335,11 @@ func (r *AWSClusterReconciler) reconcileLoadBalancer(clusterScope *scope.Cluster
 	}
 
 	clusterScope.Debug("Looking up IP address for DNS", "dns", awsCluster.Status.Network.APIServerELB.DNSName)
-	if _, err := net.LookupIP(awsCluster.Status.Network.APIServerELB.DNSName); err != nil {
+	lookupIP := net.LookupIP
+	if r.lookupIP != nil {
+		lookupIP = r.lookupIP
+	}
+	if _, err := lookupIP(awsCluster.Status.Network.APIServerELB.DNSName); err != nil {
 		clusterScope.Error(err, "failed to get IP address for dns name", "dns", awsCluster.Status.Network.APIServerELB.DNSName)
 		conditions.MarkFalse(awsCluster, infrav1.LoadBalancerReadyCondition, infrav1.WaitForDNSNameResolveReason, clusterv1.ConditionSeverityInfo, "")
 		clusterScope.Info("Waiting on API server ELB DNS name to resolve")
@@ -297,8 +347,25 @@ func (r *AWSClusterReconciler) reconcileLoadBalancer(clusterScope *scope.Cluster
 	}
 	conditions.MarkTrue(awsCluster, infrav1.LoadBalancerReadyCondition)
 
+	host := awsCluster.Status.Network.APIServerELB.DNSName
+	if clusterScope.ControlPlaneDNS() != nil {
+		dnsService := r.getDNSService(clusterScope)
+		if err := dnsService.ReconcileControlPlaneDNS(); err != nil {
+			if awserrors.IsFailedDependency(err) {
+				conditions.MarkFalse(awsCluster, infrav1.ControlPlaneDNSReadyCondition, infrav1.WaitForControlPlaneLoadBalancerReason, clusterv1.ConditionSeverityInfo, err.Error())
+				clusterScope.Info("Waiting on control plane DNS", "reason", err.Error())
+				return &retryAfterDuration, nil
+			}
+			clusterScope.Error(err, "failed to reconcile control plane DNS")
+			conditions.MarkFalse(awsCluster, infrav1.ControlPlaneDNSReadyCondition, infrav1.ControlPlaneDNSFailedReason, infrautilconditions.ErrorConditionAfterInit(clusterScope.ClusterObj()), err.Error())
+			return nil, err
+		}
+		conditions.MarkTrue(awsCluster, infrav1.ControlPlaneDNSReadyCondition)
+		host = dnsService.RecordName()
+	}
+
 	awsCluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{
-		Host: awsCluster.Status.Network.APIServerELB.DNSName,
+		Host: host,
 		Port: clusterScope.APIServerPort(),
 	}
 
@@ -321,7 +388,7 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 	ec2Service := r.getEC2Service(clusterScope)
 	networkSvc := r.getNetworkService(*clusterScope)
 	sgService := r.getSecurityGroupService(*clusterScope)
-	s3Service := s3.NewService(clusterScope)
+	s3Service := r.getObjectStoreService(clusterScope)
 
 	if err := networkSvc.ReconcileNetwork(); err != nil {
 		clusterScope.Error(err, "failed to reconcile network")
@@ -346,6 +413,18 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 			// non fatal error, so we continue
 			clusterScope.Error(err, "non-fatal: failed to set up EventBridge")
 		}
+		if feature.Gates.Enabled(feature.SpotInterruptionHandling) {
+			if err := instancestateSvc.ReconcileSpotInterruptionEvents(); err != nil {
+				clusterScope.Error(err, "non-fatal: failed to set up EventBridge for Spot interruption warnings")
+			}
+		}
+	}
+
+	// The bucket is reconciled before the load balancers, as its policy must allow the delivery of their access logs
+	// before they can be enabled.
+	if err := s3Service.ReconcileBucket(); err != nil {
+		conditions.MarkFalse(awsCluster, infrav1.S3BucketReadyCondition, infrav1.S3BucketFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile S3 Bucket for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
 	}
 
 	if requeueAfter, err := r.reconcileLoadBalancer(clusterScope, awsCluster); err != nil {
@@ -354,12 +433,12 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		return reconcile.Result{RequeueAfter: *requeueAfter}, err
 	}
 
-	if err := s3Service.ReconcileBucket(); err != nil {
-		conditions.MarkFalse(awsCluster, infrav1.S3BucketReadyCondition, infrav1.S3BucketFailedReason, clusterv1.ConditionSeverityError, err.Error())
-		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile S3 Bucket for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
-	}
-
-	for _, subnet := range clusterScope.Subnets().FilterPrivate() {
+	// Subnets in Local Zones and Wavelength Zones are reported as failure domains for the machines explicitly
+	// placed in them, but never host control plane machines. The secondary subnets of the pod network only host pods.
+	for _, subnet := range clusterScope.Subnets().FilterPrivateWithEdge().FilterNonCni() {
+		if !subnet.IsEdge() && !clusterScope.VPC().IsAvailabilityZoneAllowed(subnet.AvailabilityZone) {
+			continue
+		}
 		found := false
 		for _, az := range awsCluster.Status.Network.APIServerELB.AvailabilityZones {
 			if az == subnet.AvailabilityZone {
@@ -368,8 +447,15 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 			}
 		}
 
+		attributes := map[string]string{
+			infrav1.FailureDomainZoneTypeAttribute: subnet.GetZoneType().String(),
+		}
+		if subnet.IsEdge() && subnet.ParentZoneName != nil {
+			attributes[infrav1.FailureDomainParentZoneNameAttribute] = *subnet.ParentZoneName
+		}
 		clusterScope.SetFailureDomain(subnet.AvailabilityZone, clusterv1.FailureDomainSpec{
-			ControlPlane: found,
+			ControlPlane: found && !subnet.IsEdge(),
+			Attributes:   attributes,
 		})
 	}
 
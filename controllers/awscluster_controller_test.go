@@ -775,6 +775,15 @@ func mockedDeleteInstanceCalls(m *mocks.MockEC2APIMockRecorder) {
 	).Return(nil, nil)
 }
 
+func mockedDisableTerminationProtectionCalls(m *mocks.MockEC2APIMockRecorder) {
+	m.ModifyInstanceAttributeWithContext(context.TODO(),
+		gomock.Eq(&ec2.ModifyInstanceAttributeInput{
+			InstanceId:            aws.String("id-1"),
+			DisableApiTermination: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+		}),
+	).Return(&ec2.ModifyInstanceAttributeOutput{}, nil)
+}
+
 func mockedVPCCallsForExistingVPCAndSubnets(m *mocks.MockEC2APIMockRecorder) {
 	m.CreateTagsWithContext(context.TODO(), gomock.Eq(&ec2.CreateTagsInput{
 		Resources: aws.StringSlice([]string{"subnet-1"}),
@@ -1555,6 +1564,21 @@ func mockedDeleteVPCCalls(m *mocks.MockEC2APIMockRecorder) {
 				Values: aws.StringSlice([]string{ec2.VpcStatePending, ec2.VpcStateAvailable}),
 			},
 		}}), gomock.Any()).Return(nil).AnyTimes()
+	m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeNatGatewaysInput{
+		Filter: []*ec2.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []*string{aws.String("vpc-exists")},
+			},
+			{
+				Name:   aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+				Values: aws.StringSlice([]string{"owned"}),
+			},
+			{
+				Name:   aws.String("state"),
+				Values: aws.StringSlice([]string{ec2.NatGatewayStatePending, ec2.NatGatewayStateAvailable}),
+			},
+		}}), gomock.Any()).Return(nil).AnyTimes()
 	m.DescribeAddressesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeAddressesInput{
 		Filters: []*ec2.Filter{
 			{
@@ -1614,6 +1638,17 @@ func mockedDeleteVPCCalls(m *mocks.MockEC2APIMockRecorder) {
 				},
 			},
 		}, nil)
+	m.DescribeNetworkInterfacesPagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: aws.StringSlice([]string{"vpc-exists"}),
+			},
+			{
+				Name:   aws.String("status"),
+				Values: aws.StringSlice([]string{ec2.NetworkInterfaceStatusAvailable}),
+			},
+		}}), gomock.Any()).Return(nil)
 	m.DeleteSubnetWithContext(context.TODO(), gomock.Eq(&ec2.DeleteSubnetInput{
 		SubnetId: aws.String("subnet-1"),
 	}))
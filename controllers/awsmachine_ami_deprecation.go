@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// DefaultAMIDeprecationWarningWindow is the default time before the deprecation of an AMI from which the
+// machines launched from it are reported as using an AMI scheduled for deprecation.
+const DefaultAMIDeprecationWarningWindow = 30 * 24 * time.Hour
+
+// amiDeprecationCacheTTL is the time the deprecation time of an AMI is cached for. An AMI is usually shared by
+// many machines, and its deprecation time rarely changes.
+const amiDeprecationCacheTTL = time.Hour
+
+// amiDeprecation is the cached deprecation time of an AMI.
+type amiDeprecation struct {
+	deprecationTime *time.Time
+	expiresAt       time.Time
+}
+
+// reconcileAMIDeprecation reports on the AMINotDeprecated condition whether the AMI the instance was launched from
+// is deprecated, or will be within the AMI deprecation warning window, and emits a warning event on the AWSMachine
+// and on the AWSMachineTemplate it was cloned from when this changes.
+func (r *AWSMachineReconciler) reconcileAMIDeprecation(ctx context.Context, ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) error {
+	if instance.ImageID == "" {
+		return nil
+	}
+
+	// The AMI of an instance never changes, there is no need to look it up again once it is deprecated.
+	reason := conditions.GetReason(machineScope.AWSMachine, infrav1.AMINotDeprecatedCondition)
+	if reason == infrav1.AMIDeprecatedReason {
+		return nil
+	}
+
+	deprecationTime, err := r.getAMIDeprecationTime(ec2svc, instance.ImageID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	switch {
+	case deprecationTime == nil || deprecationTime.After(now.Add(r.AMIDeprecationWarningWindow)):
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.AMINotDeprecatedCondition)
+	case !deprecationTime.After(now):
+		machineScope.Info("AMI of the instance is deprecated", "ami-id", instance.ImageID, "deprecation-time", deprecationTime)
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "AMIDeprecated",
+			"AMI %s of instance %s was deprecated on %s", instance.ImageID, instance.ID, deprecationTime.Format(time.RFC3339))
+		r.recordTemplateEvent(ctx, machineScope, "AMIDeprecated",
+			"AMI %s of the machines of this template was deprecated on %s", instance.ImageID, deprecationTime.Format(time.RFC3339))
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.AMINotDeprecatedCondition, infrav1.AMIDeprecatedReason, clusterv1.ConditionSeverityWarning,
+			"AMI %s was deprecated on %s", instance.ImageID, deprecationTime.Format(time.RFC3339))
+	default:
+		if reason != infrav1.AMIDeprecationScheduledReason {
+			machineScope.Info("AMI of the instance is scheduled for deprecation", "ami-id", instance.ImageID, "deprecation-time", deprecationTime)
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "AMIDeprecationScheduled",
+				"AMI %s of instance %s will be deprecated on %s", instance.ImageID, instance.ID, deprecationTime.Format(time.RFC3339))
+			r.recordTemplateEvent(ctx, machineScope, "AMIDeprecationScheduled",
+				"AMI %s of the machines of this template will be deprecated on %s", instance.ImageID, deprecationTime.Format(time.RFC3339))
+		}
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.AMINotDeprecatedCondition, infrav1.AMIDeprecationScheduledReason, clusterv1.ConditionSeverityWarning,
+			"AMI %s will be deprecated on %s", instance.ImageID, deprecationTime.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// getAMIDeprecationTime returns the deprecation time of the AMI, which is looked up at most once per cache TTL.
+func (r *AWSMachineReconciler) getAMIDeprecationTime(ec2svc services.EC2Interface, imageID string) (*time.Time, error) {
+	if cached, ok := r.amiDeprecations.Load(imageID); ok && time.Now().Before(cached.(amiDeprecation).expiresAt) {
+		return cached.(amiDeprecation).deprecationTime, nil
+	}
+
+	deprecationTime, err := ec2svc.GetAMIDeprecationTime(imageID)
+	if err != nil {
+		return nil, err
+	}
+	r.amiDeprecations.Store(imageID, amiDeprecation{deprecationTime: deprecationTime, expiresAt: time.Now().Add(amiDeprecationCacheTTL)})
+	return deprecationTime, nil
+}
+
+// recordTemplateEvent records a warning event on the AWSMachineTemplate the AWSMachine was cloned from, if any,
+// so that the deprecation of the AMI is reported on the template to update.
+func (r *AWSMachineReconciler) recordTemplateEvent(ctx context.Context, machineScope *scope.MachineScope, reason, messageFmt string, args ...any) {
+	annotations := machineScope.AWSMachine.GetAnnotations()
+	name := annotations[clusterv1.TemplateClonedFromNameAnnotation]
+	if name == "" || annotations[clusterv1.TemplateClonedFromGroupKindAnnotation] != infrav1.GroupVersion.WithKind("AWSMachineTemplate").GroupKind().String() {
+		return
+	}
+
+	template := &infrav1.AWSMachineTemplate{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: machineScope.AWSMachine.Namespace, Name: name}, template); err != nil {
+		if !apierrors.IsNotFound(err) {
+			machineScope.Error(err, "failed to get AWSMachineTemplate", "name", name)
+		}
+		return
+	}
+	r.Recorder.Eventf(template, corev1.EventTypeWarning, reason, messageFmt, args...)
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/mock_services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestAWSMachineReconcilerReconcileAMIDeprecation(t *testing.T) {
+	const (
+		instanceID = "i-1234567890abcdef0"
+		imageID    = "ami-1234567890"
+	)
+
+	tests := []struct {
+		name                string
+		imageID             string
+		reason              string
+		clonedFromTemplate  bool
+		ec2Mocks            func(m *mock_services.MockEC2InterfaceMockRecorder)
+		expectStatus        corev1.ConditionStatus
+		expectReason        string
+		expectEventPrefix   string
+		expectTemplateEvent bool
+	}{
+		{
+			name:    "does nothing when the AMI of the instance is unknown",
+			imageID: "",
+		},
+		{
+			name:    "reports an AMI without deprecation time",
+			imageID: imageID,
+			ec2Mocks: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetAMIDeprecationTime(imageID).Return(nil, nil)
+			},
+			expectStatus: corev1.ConditionTrue,
+		},
+		{
+			name:    "reports an AMI deprecated after the warning window",
+			imageID: imageID,
+			ec2Mocks: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetAMIDeprecationTime(imageID).Return(aws.Time(time.Now().Add(2*DefaultAMIDeprecationWarningWindow)), nil)
+			},
+			expectStatus: corev1.ConditionTrue,
+		},
+		{
+			name:    "warns about an AMI deprecated within the warning window",
+			imageID: imageID,
+			ec2Mocks: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetAMIDeprecationTime(imageID).Return(aws.Time(time.Now().Add(time.Hour)), nil)
+			},
+			expectStatus:      corev1.ConditionFalse,
+			expectReason:      infrav1.AMIDeprecationScheduledReason,
+			expectEventPrefix: "Warning AMIDeprecationScheduled",
+		},
+		{
+			name:    "does not warn twice about an AMI deprecated within the warning window",
+			imageID: imageID,
+			reason:  infrav1.AMIDeprecationScheduledReason,
+			ec2Mocks: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetAMIDeprecationTime(imageID).Return(aws.Time(time.Now().Add(time.Hour)), nil)
+			},
+			expectStatus: corev1.ConditionFalse,
+			expectReason: infrav1.AMIDeprecationScheduledReason,
+		},
+		{
+			name:    "warns about a deprecated AMI",
+			imageID: imageID,
+			reason:  infrav1.AMIDeprecationScheduledReason,
+			ec2Mocks: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetAMIDeprecationTime(imageID).Return(aws.Time(time.Now().Add(-time.Hour)), nil)
+			},
+			expectStatus:      corev1.ConditionFalse,
+			expectReason:      infrav1.AMIDeprecatedReason,
+			expectEventPrefix: "Warning AMIDeprecated",
+		},
+		{
+			name:               "warns about a deprecated AMI on the AWSMachineTemplate of the machine",
+			imageID:            imageID,
+			clonedFromTemplate: true,
+			ec2Mocks: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetAMIDeprecationTime(imageID).Return(aws.Time(time.Now().Add(-time.Hour)), nil)
+			},
+			expectStatus:        corev1.ConditionFalse,
+			expectReason:        infrav1.AMIDeprecatedReason,
+			expectEventPrefix:   "Warning AMIDeprecated",
+			expectTemplateEvent: true,
+		},
+		{
+			name:         "does not look up a deprecated AMI again",
+			imageID:      imageID,
+			reason:       infrav1.AMIDeprecatedReason,
+			expectStatus: corev1.ConditionFalse,
+			expectReason: infrav1.AMIDeprecatedReason,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+			if tc.ec2Mocks != nil {
+				tc.ec2Mocks(ec2Svc.EXPECT())
+			}
+
+			awsMachine := &infrav1.AWSMachine{
+				TypeMeta:   metav1.TypeMeta{APIVersion: infrav1.GroupVersion.String(), Kind: "AWSMachine"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid"},
+			}
+			template := &infrav1.AWSMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+			}
+			if tc.clonedFromTemplate {
+				awsMachine.Annotations = map[string]string{
+					clusterv1.TemplateClonedFromNameAnnotation:      template.Name,
+					clusterv1.TemplateClonedFromGroupKindAnnotation: "AWSMachineTemplate.infrastructure.cluster.x-k8s.io",
+				}
+			}
+			if tc.reason != "" {
+				awsMachine.Status.Conditions = clusterv1.Conditions{
+					{
+						Type:     infrav1.AMINotDeprecatedCondition,
+						Status:   corev1.ConditionFalse,
+						Severity: clusterv1.ConditionSeverityWarning,
+						Reason:   tc.reason,
+					},
+				}
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(awsMachine, template).Build()
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       fakeClient,
+				Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capi-test", Namespace: "default"}},
+				Machine:      &clusterv1.Machine{},
+				InfraCluster: &scope.ClusterScope{},
+				AWSMachine:   awsMachine,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			recorder := record.NewFakeRecorder(2)
+			reconciler := &AWSMachineReconciler{
+				Client:                      fakeClient,
+				Recorder:                    recorder,
+				Log:                         klog.Background(),
+				AMIDeprecationWarningWindow: DefaultAMIDeprecationWarningWindow,
+			}
+
+			instance := &infrav1.Instance{ID: instanceID, ImageID: tc.imageID}
+			g.Expect(reconciler.reconcileAMIDeprecation(context.TODO(), ec2Svc, machineScope, instance)).To(Succeed())
+
+			condition := conditions.Get(awsMachine, infrav1.AMINotDeprecatedCondition)
+			if tc.expectStatus == "" {
+				g.Expect(condition).To(BeNil())
+			} else {
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(tc.expectStatus))
+				g.Expect(condition.Reason).To(Equal(tc.expectReason))
+			}
+
+			if tc.expectEventPrefix == "" {
+				g.Expect(recorder.Events).To(BeEmpty())
+				return
+			}
+			g.Expect(recorder.Events).To(Receive(HavePrefix(tc.expectEventPrefix)))
+			if tc.expectTemplateEvent {
+				g.Expect(recorder.Events).To(Receive(ContainSubstring("of the machines of this template")))
+			}
+			g.Expect(recorder.Events).To(BeEmpty())
+		})
+	}
+}
+
+func TestAWSMachineReconcilerGetAMIDeprecationTime(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+
+	deprecationTime := time.Now().Add(time.Hour)
+	ec2Svc.EXPECT().GetAMIDeprecationTime("ami-1").Return(&deprecationTime, nil).Times(1)
+	ec2Svc.EXPECT().GetAMIDeprecationTime("ami-2").Return(nil, nil).Times(1)
+
+	reconciler := &AWSMachineReconciler{}
+	for i := 0; i < 2; i++ {
+		got, err := reconciler.getAMIDeprecationTime(ec2Svc, "ami-1")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(got).To(Equal(&deprecationTime))
+
+		got, err = reconciler.getAMIDeprecationTime(ec2Svc, "ami-2")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(got).To(BeNil())
+	}
+}
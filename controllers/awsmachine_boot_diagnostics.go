@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// defaultNodeStartupTimeout is the default time to wait for a running instance to become a node.
+	defaultNodeStartupTimeout = 10 * time.Minute
+
+	// BootDiagnosticsConsoleOutputKey is the key of the console output in the boot diagnostics ConfigMap.
+	BootDiagnosticsConsoleOutputKey = "console-output"
+	// BootDiagnosticsScreenshotKey is the key of the console screenshot in the boot diagnostics ConfigMap.
+	BootDiagnosticsScreenshotKey = "screenshot.jpg"
+
+	// consoleOutputExcerptLines is the number of trailing lines of the console output included in events.
+	consoleOutputExcerptLines = 10
+	// consoleOutputExcerptMaxLength caps the length of the console output excerpt, in bytes.
+	consoleOutputExcerptMaxLength = 1024
+)
+
+// bootDiagnosticsConfigMapName returns the name of the ConfigMap holding the boot diagnostics of the machine.
+func bootDiagnosticsConfigMapName(awsMachine *infrav1.AWSMachine) string {
+	return awsMachine.Name + "-boot-diagnostics"
+}
+
+// reconcileBootDiagnostics stores the boot diagnostics of the instance in a ConfigMap once it has been running for
+// longer than the node startup timeout without becoming a node. The diagnostics are collected once per machine.
+// It returns the time left before the diagnostics are due, if any.
+func (r *AWSMachineReconciler) reconcileBootDiagnostics(ctx context.Context, ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) (time.Duration, error) {
+	diagnostics := machineScope.AWSMachine.Spec.BootDiagnostics
+	if diagnostics == nil || machineScope.Machine.Status.NodeRef != nil || instance.State != infrav1.InstanceStateRunning {
+		return 0, nil
+	}
+
+	ready := conditions.Get(machineScope.AWSMachine, infrav1.InstanceReadyCondition)
+	if ready == nil || ready.Status != corev1.ConditionTrue {
+		return 0, nil
+	}
+	timeout := defaultNodeStartupTimeout
+	if diagnostics.NodeStartupTimeout != nil {
+		timeout = diagnostics.NodeStartupTimeout.Duration
+	}
+	if remaining := time.Until(ready.LastTransitionTime.Add(timeout)); remaining > 0 {
+		return remaining, nil
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bootDiagnosticsConfigMapName(machineScope.AWSMachine),
+			Namespace: machineScope.Namespace(),
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: machineScope.Cluster.Name,
+			},
+		},
+	}
+	// The ConfigMaps are read without the cache of the manager, so that they are not all listed and watched.
+	if err := r.APIReader.Get(ctx, client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{}); err == nil {
+		return 0, nil
+	} else if !apierrors.IsNotFound(err) {
+		return 0, errors.Wrapf(err, "failed to get boot diagnostics ConfigMap %q", configMap.Name)
+	}
+
+	output, err := ec2svc.GetConsoleOutput(instance.ID)
+	if err != nil {
+		return 0, err
+	}
+	configMap.Data = map[string]string{
+		BootDiagnosticsConsoleOutputKey: output,
+	}
+	if diagnostics.Screenshot {
+		// Screenshots are a best effort, as they are not supported by every instance type.
+		screenshot, err := ec2svc.GetConsoleScreenshot(instance.ID)
+		if err != nil {
+			machineScope.Error(err, "failed to get console screenshot", "instance-id", instance.ID)
+		} else {
+			configMap.BinaryData = map[string][]byte{
+				BootDiagnosticsScreenshotKey: screenshot,
+			}
+		}
+	}
+
+	if err := controllerutil.SetOwnerReference(machineScope.AWSMachine, configMap, r.Client.Scheme()); err != nil {
+		return 0, err
+	}
+	if err := r.Client.Create(ctx, configMap); err != nil {
+		return 0, errors.Wrapf(err, "failed to create boot diagnostics ConfigMap %q", configMap.Name)
+	}
+
+	machineScope.Info("Instance did not become a node in time, collected its boot diagnostics", "instance-id", instance.ID, "configmap", configMap.Name)
+	r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "NodeStartupTimeout",
+		"Instance %s did not become a node within %s, its boot diagnostics are stored in ConfigMap %s. Console output excerpt:\n%s",
+		instance.ID, timeout, configMap.Name, consoleOutputExcerpt(output))
+	return 0, nil
+}
+
+// consoleOutputExcerpt returns the last lines of the console output, capped in length.
+func consoleOutputExcerpt(output string) string {
+	lines := strings.Split(strings.TrimRight(output, "\r\n\t "), "\n")
+	if len(lines) > consoleOutputExcerptLines {
+		lines = lines[len(lines)-consoleOutputExcerptLines:]
+	}
+	excerpt := strings.Join(lines, "\n")
+	if len(excerpt) > consoleOutputExcerptMaxLength {
+		excerpt = excerpt[len(excerpt)-consoleOutputExcerptMaxLength:]
+	}
+	return excerpt
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/mock_services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestAWSMachineReconcilerReconcileBootDiagnostics(t *testing.T) {
+	const instanceID = "i-1234567890abcdef0"
+
+	existingConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-boot-diagnostics", Namespace: "default"},
+	}
+
+	tests := []struct {
+		name              string
+		diagnostics       *infrav1.BootDiagnostics
+		runningFor        time.Duration
+		nodeRef           *corev1.ObjectReference
+		objects           []client.Object
+		ec2Mocks          func(m *mock_services.MockEC2InterfaceMockRecorder)
+		expectDueIn       bool
+		expectConfigMap   bool
+		expectScreenshot  bool
+		expectEventPrefix string
+	}{
+		{
+			name:        "does nothing when boot diagnostics are disabled",
+			runningFor:  time.Hour,
+			diagnostics: nil,
+		},
+		{
+			name:        "does nothing when the machine became a node",
+			runningFor:  time.Hour,
+			diagnostics: &infrav1.BootDiagnostics{},
+			nodeRef:     &corev1.ObjectReference{Name: "node"},
+		},
+		{
+			name:        "waits for the node startup timeout",
+			runningFor:  time.Minute,
+			diagnostics: &infrav1.BootDiagnostics{},
+			expectDueIn: true,
+		},
+		{
+			name:        "does not collect the diagnostics twice",
+			runningFor:  time.Hour,
+			diagnostics: &infrav1.BootDiagnostics{},
+			objects:     []client.Object{existingConfigMap},
+		},
+		{
+			name:        "collects the console output once the timeout expired",
+			runningFor:  6 * time.Minute,
+			diagnostics: &infrav1.BootDiagnostics{NodeStartupTimeout: &metav1.Duration{Duration: 5 * time.Minute}},
+			ec2Mocks: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetConsoleOutput(instanceID).Return("[    0.000000] Linux version\ncloud-init: failed to fetch user data\n", nil)
+			},
+			expectConfigMap:   true,
+			expectEventPrefix: "Warning NodeStartupTimeout Instance " + instanceID + " did not become a node within 5m0s",
+		},
+		{
+			name:        "collects the console screenshot when requested",
+			runningFor:  time.Hour,
+			diagnostics: &infrav1.BootDiagnostics{Screenshot: true},
+			ec2Mocks: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetConsoleOutput(instanceID).Return("cloud-init: failed to fetch user data", nil)
+				m.GetConsoleScreenshot(instanceID).Return([]byte("jpeg"), nil)
+			},
+			expectConfigMap:   true,
+			expectScreenshot:  true,
+			expectEventPrefix: "Warning NodeStartupTimeout",
+		},
+		{
+			name:        "stores the console output when the screenshot is not available",
+			runningFor:  time.Hour,
+			diagnostics: &infrav1.BootDiagnostics{Screenshot: true},
+			ec2Mocks: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetConsoleOutput(instanceID).Return("cloud-init: failed to fetch user data", nil)
+				m.GetConsoleScreenshot(instanceID).Return(nil, errors.New("UnsupportedOperation"))
+			},
+			expectConfigMap:   true,
+			expectEventPrefix: "Warning NodeStartupTimeout",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+			if tc.ec2Mocks != nil {
+				tc.ec2Mocks(ec2Svc.EXPECT())
+			}
+
+			awsMachine := &infrav1.AWSMachine{
+				TypeMeta:   metav1.TypeMeta{APIVersion: infrav1.GroupVersion.String(), Kind: "AWSMachine"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid"},
+				Spec:       infrav1.AWSMachineSpec{BootDiagnostics: tc.diagnostics},
+				Status: infrav1.AWSMachineStatus{
+					Conditions: clusterv1.Conditions{
+						{
+							Type:               infrav1.InstanceReadyCondition,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metav1.NewTime(time.Now().Add(-tc.runningFor)),
+						},
+					},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(append(tc.objects, awsMachine)...).Build()
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       fakeClient,
+				Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capi-test", Namespace: "default"}},
+				Machine:      &clusterv1.Machine{Status: clusterv1.MachineStatus{NodeRef: tc.nodeRef}},
+				InfraCluster: &scope.ClusterScope{},
+				AWSMachine:   awsMachine,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			recorder := record.NewFakeRecorder(1)
+			reconciler := &AWSMachineReconciler{
+				Client:    fakeClient,
+				APIReader: fakeClient,
+				Recorder:  recorder,
+				Log:       klog.Background(),
+			}
+
+			instance := &infrav1.Instance{ID: instanceID, State: infrav1.InstanceStateRunning}
+			dueIn, err := reconciler.reconcileBootDiagnostics(context.TODO(), ec2Svc, machineScope, instance)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(dueIn > 0).To(Equal(tc.expectDueIn))
+
+			configMap := &corev1.ConfigMap{}
+			err = fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "test-boot-diagnostics"}, configMap)
+			if !tc.expectConfigMap {
+				if len(tc.objects) == 0 {
+					g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				}
+				g.Expect(recorder.Events).To(BeEmpty())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(configMap.Data[BootDiagnosticsConsoleOutputKey]).To(ContainSubstring("failed to fetch user data"))
+			g.Expect(configMap.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "capi-test"))
+			g.Expect(configMap.OwnerReferences).To(HaveLen(1))
+			g.Expect(configMap.OwnerReferences[0].Name).To(Equal("test"))
+			if tc.expectScreenshot {
+				g.Expect(configMap.BinaryData).To(HaveKeyWithValue(BootDiagnosticsScreenshotKey, []byte("jpeg")))
+			} else {
+				g.Expect(configMap.BinaryData).To(BeEmpty())
+			}
+			g.Expect(recorder.Events).To(Receive(HavePrefix(tc.expectEventPrefix)))
+		})
+	}
+}
+
+func TestConsoleOutputExcerpt(t *testing.T) {
+	g := NewWithT(t)
+
+	lines := make([]string, 0, 20)
+	for i := 0; i < 20; i++ {
+		lines = append(lines, strings.Repeat("x", i))
+	}
+	excerpt := consoleOutputExcerpt(strings.Join(lines, "\n") + "\n\n")
+	g.Expect(strings.Split(excerpt, "\n")).To(Equal(lines[10:]))
+
+	long := strings.Repeat("y", 2*consoleOutputExcerptMaxLength)
+	g.Expect(consoleOutputExcerpt(long)).To(HaveLen(consoleOutputExcerptMaxLength))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -48,7 +49,9 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/internal/fake"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
@@ -78,16 +81,24 @@ const (
 // AWSMachineReconciler reconciles a AwsMachine object.
 type AWSMachineReconciler struct {
 	client.Client
+	// APIReader reads the objects which are not cached by the manager, such as the boot diagnostics ConfigMaps.
+	APIReader                    client.Reader
 	Log                          logr.Logger
 	Recorder                     record.EventRecorder
 	ec2ServiceFactory            func(scope.EC2Scope) services.EC2Interface
 	elbServiceFactory            func(scope.ELBScope) services.ELBInterface
 	secretsManagerServiceFactory func(cloud.ClusterScoper) services.SecretInterface
 	SSMServiceFactory            func(cloud.ClusterScoper) services.SecretInterface
-	objectStoreServiceFactory    func(cloud.ClusterScoper) services.ObjectStoreInterface
+	objectStoreServiceFactory    func(scope.S3Scope) services.ObjectStoreInterface
+	amiDeprecations              sync.Map
 	Endpoints                    []scope.ServiceEndpoint
 	WatchFilterValue             string
 	TagUnmanagedNetworkResources bool
+	// AMIDeprecationWarningWindow is the time before the deprecation of the AMI of an instance from which
+	// its AWSMachine reports the upcoming deprecation.
+	AMIDeprecationWarningWindow time.Duration
+	// SecretsManagerOptions configures the AWS Secrets Manager secrets storing the userdata.
+	SecretsManagerOptions secretsmanager.Options
 }
 
 const (
@@ -108,7 +119,7 @@ func (r *AWSMachineReconciler) getSecretsManagerService(scope cloud.ClusterScope
 		return r.secretsManagerServiceFactory(scope)
 	}
 
-	return secretsmanager.NewService(scope)
+	return secretsmanager.NewService(scope, r.SecretsManagerOptions)
 }
 
 func (r *AWSMachineReconciler) getSSMService(scope cloud.ClusterScoper) services.SecretInterface {
@@ -143,12 +154,26 @@ func (r *AWSMachineReconciler) getObjectStoreService(scope scope.S3Scope) servic
 	return s3.NewService(scope)
 }
 
+// UseFakeCloud makes the AWSMachineReconciler manage the instances of the machines in the given fake cloud
+// instead of AWS.
+func (r *AWSMachineReconciler) UseFakeCloud(fakeCloud *fake.Cloud) {
+	r.ec2ServiceFactory = fakeCloud.EC2Service
+	r.elbServiceFactory = fakeCloud.ELBService
+	r.objectStoreServiceFactory = fakeCloud.ObjectStoreService
+	r.secretsManagerServiceFactory = func(cloud.ClusterScoper) services.SecretInterface {
+		return fakeCloud.SecretService()
+	}
+	r.SSMServiceFactory = r.secretsManagerServiceFactory
+}
+
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinetemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 
 func (r *AWSMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -372,6 +397,26 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 			return ctrl.Result{}, err
 		}
 
+		// Persistent spot requests would launch the instance again once terminated.
+		if spot := machineScope.AWSMachine.Spec.SpotMarketOptions; spot != nil && spot.IsPersistent() {
+			if err := ec2Service.CancelSpotInstanceRequests(instance.ID); err != nil {
+				machineScope.Error(err, "failed to cancel spot instance requests")
+				conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+				r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedCancelSpotInstanceRequests", "Failed to cancel spot instance requests of instance %q: %v", instance.ID, err)
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Termination protection only guards against accidental terminations, it is removed when the AWSMachine is deleted.
+		if machineScope.TerminationProtection() {
+			if err := ec2Service.DisableTerminationProtection(instance.ID); err != nil {
+				machineScope.Error(err, "failed to disable termination protection")
+				conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+				r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDisableTerminationProtection", "Failed to disable termination protection of instance %q: %v", instance.ID, err)
+				return ctrl.Result{}, err
+			}
+		}
+
 		if err := ec2Service.TerminateInstance(instance.ID); err != nil {
 			machineScope.Error(err, "failed to terminate instance")
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
@@ -459,7 +504,7 @@ func (r *AWSMachineReconciler) findInstance(machineScope *scope.MachineScope, ec
 	return instance, nil
 }
 
-func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, elbScope scope.ELBScope, objectStoreScope scope.S3Scope) (ctrl.Result, error) {
+func (r *AWSMachineReconciler) reconcileNormal(ctx context.Context, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, elbScope scope.ELBScope, objectStoreScope scope.S3Scope) (ctrl.Result, error) {
 	machineScope.Trace("Reconciling AWSMachine")
 
 	// If the AWSMachine is in an error state, return early.
@@ -510,7 +555,7 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	// Create new instance since providerId is nil and instance could not be found by tags.
 	if instance == nil {
 		// Avoid a flickering condition between InstanceProvisionStarted and InstanceProvisionFailed if there's a persistent failure with createInstance
-		if conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition) != infrav1.InstanceProvisionFailedReason {
+		if reason := conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition); reason != infrav1.InstanceProvisionFailedReason && reason != infrav1.CapacityReservationExhaustedReason && reason != infrav1.MarketplaceSubscriptionRequiredReason {
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionStartedReason, clusterv1.ConditionSeverityInfo, "")
 			if patchErr := machineScope.PatchObject(); patchErr != nil {
 				machineScope.Error(patchErr, "failed to patch conditions")
@@ -527,11 +572,29 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		instance, err = r.createInstance(ec2svc, machineScope, clusterScope, objectStoreSvc)
 		if err != nil {
 			machineScope.Error(err, "unable to create instance")
-			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			reason := infrav1.InstanceProvisionFailedReason
+			switch code, _ := awserrors.Code(errors.Cause(err)); code {
+			case awserrors.ReservationCapacityExceeded:
+				reason = infrav1.CapacityReservationExhaustedReason
+			case awserrors.OptInRequired:
+				reason = infrav1.MarketplaceSubscriptionRequiredReason
+				r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "MarketplaceSubscriptionRequired",
+					"The AWS account must be subscribed to the AWS Marketplace product of the AMI to launch the instance: %v", errors.Cause(err))
+			}
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, reason, clusterv1.ConditionSeverityError, err.Error())
 			return ctrl.Result{}, err
 		}
 	}
 
+	// Source/destination checking cannot be set on launch, so it is updated once the instance exists.
+	if sourceDestCheck := machineScope.AWSMachine.Spec.SourceDestCheck; sourceDestCheck != nil && instance.SourceDestCheck != nil && *sourceDestCheck != *instance.SourceDestCheck {
+		if err := ec2svc.UpdateInstanceSourceDestCheck(instance.ID, *sourceDestCheck); err != nil {
+			machineScope.Error(err, "failed to update source/destination check")
+			return ctrl.Result{}, err
+		}
+		instance.SourceDestCheck = sourceDestCheck
+	}
+
 	// BYO Public IPv4 Pool feature: allocates and associates an EIP to machine when PublicIP and
 	// cluster-wide Public IPv4 Pool configuration are set. The EIP must be associated after the instance
 	// is created and transictioned from Pending state.
@@ -560,6 +623,12 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 
 	// Sets the AWSMachine status Interruptible, when the SpotMarketOptions is enabled for AWSMachine, Interruptible is set as true.
 	machineScope.SetInterruptible()
+	machineScope.SetInstanceLifecycle(instance.InstanceLifecycle)
+
+	if err := r.reconcileHibernation(ec2svc, machineScope, instance); err != nil {
+		machineScope.Error(err, "failed to reconcile hibernation")
+		return ctrl.Result{}, err
+	}
 
 	existingInstanceState := machineScope.GetInstanceState()
 	machineScope.SetInstanceState(instance.State)
@@ -577,7 +646,11 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotReadyReason, clusterv1.ConditionSeverityWarning, "")
 	case infrav1.InstanceStateStopping, infrav1.InstanceStateStopped:
 		machineScope.SetNotReady()
-		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceStoppedReason, clusterv1.ConditionSeverityError, "")
+		if hibernationRequested(machineScope.AWSMachine) {
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceHibernatedReason, clusterv1.ConditionSeverityInfo, "")
+		} else {
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceStoppedReason, clusterv1.ConditionSeverityError, "")
+		}
 	case infrav1.InstanceStateRunning:
 		machineScope.SetReady()
 		conditions.MarkTrue(machineScope.AWSMachine, infrav1.InstanceReadyCondition)
@@ -622,6 +695,11 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 			machineScope.Error(err, "failed to reconcile LB attachment")
 			return ctrl.Result{}, err
 		}
+
+		// Reporting the deprecation of the AMI is informational and must not block the reconciliation of the machine.
+		if err := r.reconcileAMIDeprecation(ctx, ec2svc, machineScope, instance); err != nil {
+			machineScope.Error(err, "failed to check the deprecation of the AMI", "ami-id", instance.ImageID)
+		}
 	}
 
 	// tasks that can only take place during operational instance states
@@ -632,11 +710,20 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		}
 	}
 
+	// Collecting boot diagnostics is a best effort and must not block the reconciliation of the machine.
+	diagnosticsDueIn, err := r.reconcileBootDiagnostics(ctx, ec2svc, machineScope, instance)
+	if err != nil {
+		machineScope.Error(err, "failed to collect boot diagnostics")
+	}
+
 	machineScope.Debug("done reconciling instance", "instance", instance)
 	if shouldRequeue {
 		machineScope.Debug("but find the instance is pending, requeue", "instance", instance.ID)
 		return ctrl.Result{RequeueAfter: DefaultReconcilerRequeue}, nil
 	}
+	if diagnosticsDueIn > 0 {
+		return ctrl.Result{RequeueAfter: diagnosticsDueIn}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -1223,19 +1310,22 @@ func (r *AWSMachineReconciler) ensureStorageTags(ec2svc services.EC2Interface, i
 	}
 	annotations := make(map[string]interface{}, len(instance.VolumeIDs))
 	for _, volumeID := range instance.VolumeIDs {
-		if subAnnotation, ok := prevAnnotations[volumeID].(map[string]interface{}); ok {
-			newAnnotation, err := r.ensureVolumeTags(ec2svc, aws.String(volumeID), subAnnotation, additionalTags)
-			if err != nil {
-				r.Log.Error(err, "Failed to fetch the changed volume tags in EC2 instance")
-			}
-			annotations[volumeID] = newAnnotation
-		} else {
-			newAnnotation, err := r.ensureVolumeTags(ec2svc, aws.String(volumeID), make(map[string]interface{}), additionalTags)
-			if err != nil {
+		subAnnotation, ok := prevAnnotations[volumeID].(map[string]interface{})
+		if !ok {
+			subAnnotation = make(map[string]interface{})
+		}
+		newAnnotation, err := r.ensureVolumeTags(ec2svc, aws.String(volumeID), subAnnotation, additionalTags)
+		if err != nil {
+			if len(instance.MarketplaceProductCodes) > 0 && isTaggingForbidden(err) {
+				// Some AWS Marketplace AMIs forbid tagging the volumes of their instances, the tags are
+				// recorded as applied so that they are not retried until they change.
+				r.Log.Info("Skipping volume tags forbidden by the AWS Marketplace AMI", "volume-id", volumeID, "product-codes", instance.MarketplaceProductCodes)
+				_, _, _, newAnnotation = r.tagsChanged(subAnnotation, additionalTags)
+			} else {
 				r.Log.Error(err, "Failed to fetch the changed volume tags in EC2 instance")
 			}
-			annotations[volumeID] = newAnnotation
 		}
+		annotations[volumeID] = newAnnotation
 	}
 
 	if !cmp.Equal(prevAnnotations, annotations, cmpopts.EquateEmpty()) {
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -44,6 +45,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	ec2Service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
@@ -188,7 +190,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 			secretsManagerServiceFactory: func(cloud.ClusterScoper) services.SecretInterface {
 				return secretSvc
 			},
-			objectStoreServiceFactory: func(cloud.ClusterScoper) services.ObjectStoreInterface {
+			objectStoreServiceFactory: func(scope.S3Scope) services.ObjectStoreInterface {
 				return objectStoreSvc
 			},
 			Recorder: recorder,
@@ -321,6 +323,24 @@ func TestAWSMachineReconciler(t *testing.T) {
 				g.Expect(ms.AWSMachine.Finalizers).To(ContainElement(infrav1.MachineFinalizer))
 				g.Expect(errors.Cause(err)).To(MatchError(expectedErr))
 			})
+
+			t.Run("should set the capacity reservation exhausted condition when the reservation has no capacity left", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+
+				providerID(t, g)
+				expectedErr := awserr.New(awserrors.ReservationCapacityExceeded, "Insufficient capacity in reservation", nil)
+				ec2Svc.EXPECT().InstanceIfExists(gomock.Any()).Return(nil, nil)
+				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
+				ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.Wrap(expectedErr, "failed to run instance"))
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+				g.Expect(errors.Cause(err)).To(MatchError(expectedErr))
+				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityError, infrav1.CapacityReservationExhaustedReason}})
+			})
 		})
 
 		t.Run("should fail to find instance if no provider ID provided", func(t *testing.T) {
@@ -1025,6 +1045,37 @@ func TestAWSMachineReconciler(t *testing.T) {
 				}
 				ec2Svc.EXPECT().ReconcileElasticIPFromPublicPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+				g.Expect(err).To(BeNil())
+			})
+		})
+		t.Run("when SourceDestCheck is set", func(t *testing.T) {
+			var instance *infrav1.Instance
+			secretPrefix := "test/secret"
+
+			t.Run("should disable source/destination check", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+
+				instance = &infrav1.Instance{
+					ID:              "myMachine",
+					State:           infrav1.InstanceStatePending,
+					SourceDestCheck: aws.Bool(true),
+				}
+
+				ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(nil, nil).AnyTimes()
+				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(secretPrefix, int32(1), nil).Times(1)
+				ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any(), gomock.Any()).Return(instance, nil).AnyTimes()
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil).Times(1)
+				ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
+				ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return(nil, nil).Times(1)
+				ec2Svc.EXPECT().UpdateInstanceSourceDestCheck("myMachine", false).Return(nil).Times(1)
+
+				ms.AWSMachine.Spec.SourceDestCheck = aws.Bool(false)
+
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 				g.Expect(err).To(BeNil())
 			})
@@ -1734,6 +1785,72 @@ func TestAWSMachineReconciler(t *testing.T) {
 				g.Expect(buf.String()).To(ContainSubstring("Terminating EC2 instance"))
 				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("FailedTerminate")))
 			})
+			t.Run("should disable the termination protection of control plane instances before terminating them", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				finalizer(t, g)
+				getRunningInstance(t, g)
+
+				ms.Machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabel: ""}
+				gomock.InOrder(
+					ec2Svc.EXPECT().DisableTerminationProtection(id).Return(nil),
+					ec2Svc.EXPECT().TerminateInstance(id).Return(nil),
+				)
+
+				_, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+				g.Expect(err).To(BeNil())
+			})
+			t.Run("should return an error when the termination protection can't be disabled", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				finalizer(t, g)
+				getRunningInstance(t, g)
+
+				ms.AWSMachine.Spec.TerminationProtection = ptr.To(true)
+				expected := errors.New("can't reach AWS to disable termination protection")
+				ec2Svc.EXPECT().DisableTerminationProtection(id).Return(expected)
+
+				_, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+				g.Expect(errors.Cause(err)).To(MatchError(expected))
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("FailedDisableTerminationProtection")))
+			})
+			t.Run("should cancel the spot instance requests of instances stopping on interruption", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				finalizer(t, g)
+				getRunningInstance(t, g)
+
+				ms.AWSMachine.Spec.SpotMarketOptions = &infrav1.SpotMarketOptions{InterruptionBehavior: infrav1.InstanceInterruptionBehaviorStop}
+				gomock.InOrder(
+					ec2Svc.EXPECT().CancelSpotInstanceRequests(id).Return(nil),
+					ec2Svc.EXPECT().TerminateInstance(id).Return(nil),
+				)
+
+				_, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+				g.Expect(err).To(BeNil())
+			})
+			t.Run("should return an error when the spot instance requests can't be cancelled", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				finalizer(t, g)
+				getRunningInstance(t, g)
+
+				ms.AWSMachine.Spec.SpotMarketOptions = &infrav1.SpotMarketOptions{InterruptionBehavior: infrav1.InstanceInterruptionBehaviorHibernate}
+				expected := errors.New("can't reach AWS to cancel spot instance requests")
+				ec2Svc.EXPECT().CancelSpotInstanceRequests(id).Return(expected)
+
+				_, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+				g.Expect(errors.Cause(err)).To(MatchError(expected))
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("FailedCancelSpotInstanceRequests")))
+			})
 			t.Run("when instance can be shut down", func(t *testing.T) {
 				terminateInstance := func(t *testing.T, g *WithT) {
 					t.Helper()
@@ -2723,7 +2840,7 @@ func TestAWSMachineReconcilerReconcileDefaultsToLoadBalancerTypeClassic(t *testi
 						MetadataOptions: &ec2.InstanceMetadataOptionsResponse{
 							HttpEndpoint:            aws.String(string(infrav1.InstanceMetadataEndpointStateEnabled)),
 							HttpPutResponseHopLimit: aws.Int64(1),
-							HttpTokens:              aws.String(string(infrav1.HTTPTokensStateOptional)),
+							HttpTokens:              aws.String(string(infrav1.HTTPTokensStateRequired)),
 							InstanceMetadataTags:    aws.String(string(infrav1.InstanceMetadataEndpointStateDisabled)),
 						},
 					},
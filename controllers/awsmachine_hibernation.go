@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// hibernationRequested returns true when the hibernate annotation is set to "true" on the AWSMachine.
+func hibernationRequested(awsMachine *infrav1.AWSMachine) bool {
+	return awsMachine.GetAnnotations()[infrav1.HibernateAnnotation] == "true"
+}
+
+// reconcileHibernation hibernates the running instance when the hibernate annotation is set, and resumes
+// the instance once the annotation is removed. Only the instances hibernated by the controller are resumed,
+// instances stopped out of band are left stopped. The instance state is updated in place on success.
+func (r *AWSMachineReconciler) reconcileHibernation(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) error {
+	awsMachine := machineScope.AWSMachine
+
+	if hibernationRequested(awsMachine) {
+		if instance.State != infrav1.InstanceStateRunning {
+			return nil
+		}
+		if !awsMachine.Spec.HibernationOptions.IsConfigured() {
+			r.Recorder.Eventf(awsMachine, corev1.EventTypeWarning, "HibernationNotConfigured", "Cannot hibernate instance %q, hibernation is not configured", instance.ID)
+			return nil
+		}
+		if err := ec2svc.HibernateInstance(instance.ID); err != nil {
+			r.Recorder.Eventf(awsMachine, corev1.EventTypeWarning, "FailedHibernateInstance", "Failed to hibernate instance %q: %v", instance.ID, err)
+			return err
+		}
+		r.Recorder.Eventf(awsMachine, corev1.EventTypeNormal, "SuccessfulHibernateInstance", "Hibernated instance %q", instance.ID)
+		machineScope.Info("Hibernated instance", "instance-id", instance.ID)
+		instance.State = infrav1.InstanceStateStopping
+		return nil
+	}
+
+	if instance.State != infrav1.InstanceStateStopped || conditions.GetReason(awsMachine, infrav1.InstanceReadyCondition) != infrav1.InstanceHibernatedReason {
+		return nil
+	}
+	if err := ec2svc.StartInstance(instance.ID); err != nil {
+		r.Recorder.Eventf(awsMachine, corev1.EventTypeWarning, "FailedResumeInstance", "Failed to resume instance %q: %v", instance.ID, err)
+		return err
+	}
+	r.Recorder.Eventf(awsMachine, corev1.EventTypeNormal, "SuccessfulResumeInstance", "Resumed instance %q", instance.ID)
+	machineScope.Info("Resumed instance", "instance-id", instance.ID)
+	instance.State = infrav1.InstanceStatePending
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/mock_services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestAWSMachineReconcilerReconcileHibernation(t *testing.T) {
+	const instanceID = "i-1234567890abcdef0"
+
+	tests := []struct {
+		name              string
+		hibernate         bool
+		configured        bool
+		state             infrav1.InstanceState
+		readyReason       string
+		ec2Mocks          func(m *mock_services.MockEC2InterfaceMockRecorder)
+		expectErr         bool
+		expectState       infrav1.InstanceState
+		expectEventPrefix string
+	}{
+		{
+			name:        "does nothing when hibernation is not requested",
+			configured:  true,
+			state:       infrav1.InstanceStateRunning,
+			expectState: infrav1.InstanceStateRunning,
+		},
+		{
+			name:              "does not hibernate when hibernation is not configured",
+			hibernate:         true,
+			state:             infrav1.InstanceStateRunning,
+			expectState:       infrav1.InstanceStateRunning,
+			expectEventPrefix: "Warning HibernationNotConfigured",
+		},
+		{
+			name:       "hibernates the running instance",
+			hibernate:  true,
+			configured: true,
+			state:      infrav1.InstanceStateRunning,
+			ec2Mocks: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.HibernateInstance(instanceID).Return(nil)
+			},
+			expectState:       infrav1.InstanceStateStopping,
+			expectEventPrefix: "Normal SuccessfulHibernateInstance",
+		},
+		{
+			name:       "reports the failure to hibernate the instance",
+			hibernate:  true,
+			configured: true,
+			state:      infrav1.InstanceStateRunning,
+			ec2Mocks: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.HibernateInstance(instanceID).Return(errors.New("UnsupportedHibernationConfiguration"))
+			},
+			expectErr:         true,
+			expectState:       infrav1.InstanceStateRunning,
+			expectEventPrefix: "Warning FailedHibernateInstance",
+		},
+		{
+			name:        "does not hibernate the instance twice",
+			hibernate:   true,
+			configured:  true,
+			state:       infrav1.InstanceStateStopped,
+			readyReason: infrav1.InstanceHibernatedReason,
+			expectState: infrav1.InstanceStateStopped,
+		},
+		{
+			name:        "resumes the hibernated instance once the annotation is removed",
+			configured:  true,
+			state:       infrav1.InstanceStateStopped,
+			readyReason: infrav1.InstanceHibernatedReason,
+			ec2Mocks: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.StartInstance(instanceID).Return(nil)
+			},
+			expectState:       infrav1.InstanceStatePending,
+			expectEventPrefix: "Normal SuccessfulResumeInstance",
+		},
+		{
+			name:        "does not start instances stopped out of band",
+			configured:  true,
+			state:       infrav1.InstanceStateStopped,
+			readyReason: infrav1.InstanceStoppedReason,
+			expectState: infrav1.InstanceStateStopped,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+			if tc.ec2Mocks != nil {
+				tc.ec2Mocks(ec2Svc.EXPECT())
+			}
+
+			awsMachine := &infrav1.AWSMachine{
+				TypeMeta:   metav1.TypeMeta{APIVersion: infrav1.GroupVersion.String(), Kind: "AWSMachine"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid"},
+			}
+			if tc.hibernate {
+				awsMachine.Annotations = map[string]string{infrav1.HibernateAnnotation: "true"}
+			}
+			if tc.configured {
+				awsMachine.Spec.HibernationOptions = &infrav1.HibernationOptions{Configured: true}
+			}
+			if tc.readyReason != "" {
+				awsMachine.Status.Conditions = clusterv1.Conditions{
+					{
+						Type:     infrav1.InstanceReadyCondition,
+						Status:   corev1.ConditionFalse,
+						Severity: clusterv1.ConditionSeverityInfo,
+						Reason:   tc.readyReason,
+					},
+				}
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(awsMachine).Build()
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       fakeClient,
+				Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capi-test", Namespace: "default"}},
+				Machine:      &clusterv1.Machine{},
+				InfraCluster: &scope.ClusterScope{},
+				AWSMachine:   awsMachine,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			recorder := record.NewFakeRecorder(1)
+			reconciler := &AWSMachineReconciler{
+				Client:   fakeClient,
+				Recorder: recorder,
+				Log:      klog.Background(),
+			}
+
+			instance := &infrav1.Instance{ID: instanceID, State: tc.state}
+			err = reconciler.reconcileHibernation(ec2Svc, machineScope, instance)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(instance.State).To(Equal(tc.expectState))
+
+			if tc.expectEventPrefix == "" {
+				g.Expect(recorder.Events).To(BeEmpty())
+				return
+			}
+			g.Expect(recorder.Events).To(Receive(HavePrefix(tc.expectEventPrefix)))
+		})
+	}
+}
@@ -54,6 +54,14 @@ func (r *AWSMachineReconciler) ensureSecurityGroups(ec2svc service.EC2Interface,
 		return false, err
 	}
 
+	if selectors := scope.AWSMachine.Spec.AdditionalSecurityGroupSelectors; len(selectors) > 0 {
+		selectedIDs, err := ec2svc.GetSecurityGroupSelectorsIDs(selectors)
+		if err != nil {
+			return false, err
+		}
+		additionalSecurityGroupsIDs = append(additionalSecurityGroupsIDs, selectedIDs...)
+	}
+
 	changed, ids := r.securityGroupsChanged(annotation, core, additionalSecurityGroupsIDs, existing)
 	if !changed {
 		return false, nil
@@ -17,7 +17,10 @@ limitations under the License.
 package controllers
 
 import (
+	"github.com/pkg/errors"
+
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 )
 
@@ -84,6 +87,13 @@ func (r *AWSMachineReconciler) ensureVolumeTags(svc service.EC2Interface, volume
 	return subAnnotation, nil
 }
 
+// isTaggingForbidden reports whether the tags were rejected because the resource can't be tagged,
+// as opposed to a transient failure.
+func isTaggingForbidden(err error) bool {
+	code, _ := awserrors.Code(errors.Cause(err))
+	return code == awserrors.UnsupportedOperation || code == awserrors.OperationNotPermitted
+}
+
 // tagsChanged determines which tags to delete and which to add.
 func (r *AWSMachineReconciler) tagsChanged(annotation map[string]interface{}, src map[string]string) (bool, map[string]string, map[string]string, map[string]interface{}) {
 	// Bool tracking if we found any changed state.
@@ -40,6 +40,31 @@ func (r *AWSManagedControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	}
 	dst.Spec.VpcCni.Disable = r.Spec.DisableVPCCNI
 	dst.Spec.Partition = restored.Spec.Partition
+	dst.Spec.DisableClusterDeletion = restored.Spec.DisableClusterDeletion
+	dst.Spec.ClusterSecurityGroupIngressRules = restored.Spec.ClusterSecurityGroupIngressRules
+	dst.Spec.AccessConfig = restored.Spec.AccessConfig
+	dst.Spec.IRSARoles = restored.Spec.IRSARoles
+	dst.Spec.PodIdentityAssociations = restored.Spec.PodIdentityAssociations
+	dst.Spec.PodIdentityAgent = restored.Spec.PodIdentityAgent
+	dst.Status.OIDCProvider.IRSARoles = restored.Status.OIDCProvider.IRSARoles
+	dst.Status.ClusterSecurityGroupID = restored.Status.ClusterSecurityGroupID
+	dst.Status.EncryptionKeyARN = restored.Status.EncryptionKeyARN
+	if restored.Spec.EncryptionConfig != nil && dst.Spec.EncryptionConfig != nil {
+		dst.Spec.EncryptionConfig.CreateKey = restored.Spec.EncryptionConfig.CreateKey
+	}
+	if restored.Spec.Addons != nil && dst.Spec.Addons != nil {
+		for i := range *dst.Spec.Addons {
+			if i < len(*restored.Spec.Addons) {
+				(*dst.Spec.Addons)[i].PreserveOnDelete = (*restored.Spec.Addons)[i].PreserveOnDelete
+				(*dst.Spec.Addons)[i].PodIdentityAssociations = (*restored.Spec.Addons)[i].PodIdentityAssociations
+			}
+		}
+	}
+	for i := range dst.Status.Addons {
+		if i < len(restored.Status.Addons) {
+			dst.Status.Addons[i].PreserveOnDelete = restored.Status.Addons[i].PreserveOnDelete
+		}
+	}
 
 	return nil
 }
@@ -108,6 +133,16 @@ func Convert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControlPlan
 	return autoConvert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControlPlaneSpec(in, out, s)
 }
 
+// Convert_v1beta2_OIDCProviderStatus_To_v1beta1_OIDCProviderStatus is a generated conversion function.
+func Convert_v1beta2_OIDCProviderStatus_To_v1beta1_OIDCProviderStatus(in *ekscontrolplanev1.OIDCProviderStatus, out *OIDCProviderStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_OIDCProviderStatus_To_v1beta1_OIDCProviderStatus(in, out, s)
+}
+
+// Convert_v1beta2_EncryptionConfig_To_v1beta1_EncryptionConfig is a generated conversion function.
+func Convert_v1beta2_EncryptionConfig_To_v1beta1_EncryptionConfig(in *ekscontrolplanev1.EncryptionConfig, out *EncryptionConfig, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_EncryptionConfig_To_v1beta1_EncryptionConfig(in, out, s)
+}
+
 func Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(in *ekscontrolplanev1.VpcCni, out *VpcCni, s apiconversion.Scope) error {
 	return autoConvert_v1beta2_VpcCni_To_v1beta1_VpcCni(in, out, s)
 }
@@ -116,3 +151,18 @@ func Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(in *ekscontrolplanev1.VpcCni, out
 func Convert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in *ekscontrolplanev1.AWSManagedControlPlaneSpec, out *AWSManagedControlPlaneSpec, scope apiconversion.Scope) error {
 	return autoConvert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in, out, scope)
 }
+
+// Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus is a generated conversion function
+func Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in *ekscontrolplanev1.AWSManagedControlPlaneStatus, out *AWSManagedControlPlaneStatus, scope apiconversion.Scope) error {
+	return autoConvert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in, out, scope)
+}
+
+// Convert_v1beta2_Addon_To_v1beta1_Addon is a generated conversion function.
+func Convert_v1beta2_Addon_To_v1beta1_Addon(in *ekscontrolplanev1.Addon, out *Addon, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_Addon_To_v1beta1_Addon(in, out, s)
+}
+
+// Convert_v1beta2_AddonState_To_v1beta1_AddonState is a generated conversion function.
+func Convert_v1beta2_AddonState_To_v1beta1_AddonState(in *ekscontrolplanev1.AddonState, out *AddonState, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_AddonState_To_v1beta1_AddonState(in, out, s)
+}
@@ -30,6 +30,10 @@ const (
 
 	// AWSManagedControlPlaneKind is the Kind of AWSManagedControlPlane.
 	AWSManagedControlPlaneKind = "AWSManagedControlPlane"
+
+	// ConfirmClusterDeletionAnnotation must be set to "true" on an AWSManagedControlPlane
+	// with DisableClusterDeletion enabled before the EKS cluster is deleted.
+	ConfirmClusterDeletionAnnotation = "aws.cluster.x-k8s.io/confirm-cluster-deletion"
 )
 
 // AWSManagedControlPlaneSpec defines the desired state of an Amazon EKS Cluster.
@@ -89,7 +93,8 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	RoleAdditionalPolicies *[]string `json:"roleAdditionalPolicies,omitempty"`
 
 	// Logging specifies which EKS Cluster logs should be enabled. Entries for
-	// each of the enabled logs will be sent to CloudWatch
+	// each of the enabled logs will be sent to CloudWatch. Changes are applied to
+	// existing clusters. When unset, the logs of the cluster are left unchanged.
 	// +optional
 	Logging *ControlPlaneLoggingSpec `json:"logging,omitempty"`
 
@@ -104,14 +109,26 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 
 	// IAMAuthenticatorConfig allows the specification of any additional user or role mappings
 	// for use when generating the aws-iam-authenticator configuration. If this is nil the
-	// default configuration is still generated for the cluster.
+	// default configuration is still generated for the cluster. With the API authentication
+	// mode, the mappings are reconciled as access entries instead.
 	// +optional
 	IAMAuthenticatorConfig *IAMAuthenticatorConfig `json:"iamAuthenticatorConfig,omitempty"`
 
+	// AccessConfig specifies how the IAM principals are authenticated with the cluster. The access
+	// entries are managed in the API and API_AND_CONFIG_MAP authentication modes.
+	// +optional
+	AccessConfig *AccessConfig `json:"accessConfig,omitempty"`
+
 	// Endpoints specifies access to this cluster's control plane endpoints
 	// +optional
 	EndpointAccess EndpointAccess `json:"endpointAccess,omitempty"`
 
+	// ClusterSecurityGroupIngressRules is an optional set of ingress rules to add to the cluster
+	// security group created by EKS, for example to allow access from the management cluster.
+	// Only the rules added by this controller are managed, the rules added by EKS are left untouched.
+	// +optional
+	ClusterSecurityGroupIngressRules []infrav1.IngressRule `json:"clusterSecurityGroupIngressRules,omitempty"`
+
 	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
 	// +optional
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
@@ -160,12 +177,30 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	// +kubebuilder:default=false
 	AssociateOIDCProvider bool `json:"associateOIDCProvider,omitempty"`
 
+	// IRSARoles are IAM roles to create for use with IAM roles for service accounts. Each role
+	// can be assumed by a single service account through the OIDC provider of the cluster, which
+	// requires AssociateOIDCProvider to be enabled.
+	// +optional
+	IRSARoles []IRSARole `json:"irsaRoles,omitempty"`
+
+	// PodIdentityAssociations associate IAM roles with service accounts of the cluster with EKS Pod
+	// Identity, which requires the EKS Pod Identity Agent to run in the cluster. Associations removed
+	// from the spec are deleted.
+	// +optional
+	PodIdentityAssociations []PodIdentityAssociation `json:"podIdentityAssociations,omitempty"`
+
+	// PodIdentityAgent is used to install the EKS Pod Identity Agent, which the pods need to get the
+	// credentials of the roles of their pod identity associations.
+	// +optional
+	PodIdentityAgent PodIdentityAgent `json:"podIdentityAgent,omitempty"`
+
 	// Addons defines the EKS addons to enable with the EKS cluster.
 	// +optional
 	Addons *[]Addon `json:"addons,omitempty"`
 
 	// IdentityProviderconfig is used to specify the oidc provider config
-	// to be attached with this eks cluster
+	// to be attached with this eks cluster. Removing it disassociates the
+	// identity provider from the cluster.
 	// +optional
 	OIDCIdentityProviderConfig *OIDCIdentityProviderConfig `json:"oidcIdentityProviderConfig,omitempty"`
 
@@ -175,6 +210,12 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 
 	// KubeProxy defines managed attributes of the kube-proxy daemonset
 	KubeProxy KubeProxy `json:"kubeProxy,omitempty"`
+
+	// DisableClusterDeletion protects the EKS cluster from accidental deletion. When enabled,
+	// the controller will not delete the EKS cluster, or any of its infrastructure, until the
+	// AWSManagedControlPlane is annotated with "aws.cluster.x-k8s.io/confirm-cluster-deletion: true".
+	// +optional
+	DisableClusterDeletion bool `json:"disableClusterDeletion,omitempty"`
 }
 
 // KubeProxy specifies how the kube-proxy daemonset is managed.
@@ -202,6 +243,19 @@ type VpcCni struct {
 	Env []corev1.EnvVar `json:"env,omitempty"`
 }
 
+// PodIdentityAgent specifies how the EKS Pod Identity Agent is managed.
+type PodIdentityAgent struct {
+	// Enable installs the EKS Pod Identity Agent in the cluster as the eks-pod-identity-agent addon.
+	// The addon is deleted when it is disabled. You cannot set this to true if the addon is also
+	// listed in the addons.
+	// +optional
+	Enable bool `json:"enable,omitempty"`
+	// Version is the version of the eks-pod-identity-agent addon. Defaults to the default version of
+	// the addon for the Kubernetes version of the cluster when the addon is installed.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
 // EndpointAccess specifies how control plane endpoints are accessible.
 type EndpointAccess struct {
 	// Public controls whether control plane endpoints are publicly accessible
@@ -221,6 +275,21 @@ type EncryptionConfig struct {
 	Provider *string `json:"provider,omitempty"`
 	// Resources specifies the resources to be encrypted
 	Resources []*string `json:"resources,omitempty"`
+	// CreateKey specifies that the provider creates a dedicated KMS key for the cluster, instead of using
+	// the one of Provider. The key is tagged as owned by the cluster, aliased
+	// "cluster-api-provider-aws-<eks cluster name>", its key policy allows the control plane IAM role to use it,
+	// and it is scheduled for deletion when the cluster is deleted.
+	// Resources defaults to secrets when a key is created.
+	// +optional
+	CreateKey bool `json:"createKey,omitempty"`
+}
+
+// GetAuthenticationMode returns the authentication mode of the cluster.
+func (s *AWSManagedControlPlaneSpec) GetAuthenticationMode() EKSAuthenticationMode {
+	if s.AccessConfig == nil || s.AccessConfig.AuthenticationMode == "" {
+		return EKSAuthenticationModeConfigMap
+	}
+	return s.AccessConfig.AuthenticationMode
 }
 
 // OIDCProviderStatus holds the status of the AWS OIDC identity provider.
@@ -229,6 +298,18 @@ type OIDCProviderStatus struct {
 	ARN string `json:"arn,omitempty"`
 	// TrustPolicy contains the boilerplate IAM trust policy to use for IRSA
 	TrustPolicy string `json:"trustPolicy,omitempty"`
+	// IRSARoles holds the IAM roles created for use with IAM roles for service accounts
+	// +optional
+	IRSARoles []IRSARoleStatus `json:"irsaRoles,omitempty"`
+}
+
+// IRSARoleStatus holds the status of an IAM role created for use with IAM roles for service accounts.
+type IRSARoleStatus struct {
+	// RoleName is the name of the IAM role
+	RoleName string `json:"roleName"`
+	// ARN is the ARN of the IAM role, to set in the eks.amazonaws.com/role-arn annotation of the
+	// service account
+	ARN string `json:"arn"`
 }
 
 // IdentityProviderStatus holds the status for associated identity provider.
@@ -279,6 +360,13 @@ type AWSManagedControlPlaneStatus struct {
 	// associated identity provider
 	// +optional
 	IdentityProviderStatus IdentityProviderStatus `json:"identityProviderStatus,omitempty"`
+	// ClusterSecurityGroupID is the ID of the cluster security group created by EKS
+	// +optional
+	ClusterSecurityGroupID string `json:"clusterSecurityGroupId,omitempty"`
+	// EncryptionKeyARN is the ARN of the KMS key created by the provider to encrypt
+	// the resources of the cluster, when EncryptionConfig.CreateKey is set.
+	// +optional
+	EncryptionKeyARN string `json:"encryptionKeyARN,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -19,8 +19,13 @@ package v1beta2
 import (
 	"fmt"
 	"net"
+	"net/url"
+	"strings"
 
 	"github.com/apparentlymart/go-cidr/cidr"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -30,27 +35,30 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks"
 )
 
 const (
-	minAddonVersion          = "v1.18.0"
-	minKubeVersionForIPv6    = "v1.21.0"
-	minVpcCniVersionForIPv6  = "1.10.2"
-	maxClusterNameLength     = 100
-	hostnameTypeResourceName = "resource-name"
+	minAddonVersion                   = "v1.18.0"
+	minKubeVersionForIPv6             = "v1.21.0"
+	minKubeVersionForPodIdentityAgent = "v1.24.0"
+	minVpcCniVersionForIPv6           = "1.10.2"
+	maxClusterNameLength              = 100
+	hostnameTypeResourceName          = "resource-name"
 )
 
 // log is for logging in this package.
 var mcpLog = ctrl.Log.WithName("awsmanagedcontrolplane-resource")
 
 const (
-	cidrSizeMax    = 65536
-	cidrSizeMin    = 16
-	vpcCniAddon    = "vpc-cni"
-	kubeProxyAddon = "kube-proxy"
+	cidrSizeMax           = 65536
+	cidrSizeMin           = 16
+	vpcCniAddon           = "vpc-cni"
+	kubeProxyAddon        = "kube-proxy"
+	podIdentityAgentAddon = "eks-pod-identity-agent"
 )
 
 // SetupWebhookWithManager will setup the webhooks for the AWSManagedControlPlane.
@@ -88,13 +96,22 @@ func (r *AWSManagedControlPlane) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.validateEKSVersion(nil)...)
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
 	allErrs = append(allErrs, r.validateIAMAuthConfig()...)
+	allErrs = append(allErrs, r.validateAccessConfig(nil)...)
+	allErrs = append(allErrs, r.validateIRSARoles()...)
+	allErrs = append(allErrs, r.validatePodIdentityAssociations()...)
+	allErrs = append(allErrs, r.validateEncryptionConfig()...)
+	allErrs = append(allErrs, r.validateOIDCIdentityProviderConfig()...)
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
+	allErrs = append(allErrs, r.validateEKSAddonsConfiguration()...)
 	allErrs = append(allErrs, r.validateDisableVPCCNI()...)
 	allErrs = append(allErrs, r.validateKubeProxy()...)
+	allErrs = append(allErrs, r.validatePodIdentityAgent()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateNetwork()...)
 	allErrs = append(allErrs, r.validatePrivateDNSHostnameTypeOnLaunch()...)
+	allErrs = append(allErrs, r.validateClusterSecurityGroupIngressRules()...)
+	allErrs = append(allErrs, infrav1.ValidateGCTasksAnnotation(r.GetAnnotations())...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -123,12 +140,21 @@ func (r *AWSManagedControlPlane) ValidateUpdate(old runtime.Object) (admission.W
 	allErrs = append(allErrs, r.validateEKSVersion(oldAWSManagedControlplane)...)
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
 	allErrs = append(allErrs, r.validateIAMAuthConfig()...)
+	allErrs = append(allErrs, r.validateAccessConfig(oldAWSManagedControlplane)...)
+	allErrs = append(allErrs, r.validateIRSARoles()...)
+	allErrs = append(allErrs, r.validatePodIdentityAssociations()...)
+	allErrs = append(allErrs, r.validateEncryptionConfig()...)
+	allErrs = append(allErrs, r.validateOIDCIdentityProviderConfig()...)
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
+	allErrs = append(allErrs, r.validateEKSAddonsConfiguration()...)
 	allErrs = append(allErrs, r.validateDisableVPCCNI()...)
 	allErrs = append(allErrs, r.validateKubeProxy()...)
+	allErrs = append(allErrs, r.validatePodIdentityAgent()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validatePrivateDNSHostnameTypeOnLaunch()...)
+	allErrs = append(allErrs, r.validateClusterSecurityGroupIngressRules()...)
+	allErrs = append(allErrs, infrav1.ValidateGCTasksAnnotation(r.GetAnnotations())...)
 
 	if r.Spec.Region != oldAWSManagedControlplane.Spec.Region {
 		allErrs = append(allErrs,
@@ -136,6 +162,12 @@ func (r *AWSManagedControlPlane) ValidateUpdate(old runtime.Object) (admission.W
 		)
 	}
 
+	if !cmp.Equal(oldAWSManagedControlplane.Spec.NetworkSpec.PodNetwork, r.Spec.NetworkSpec.PodNetwork) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "network", "podNetwork"), r.Spec.NetworkSpec.PodNetwork, "field is immutable"),
+		)
+	}
+
 	// If encryptionConfig is already set, do not allow removal of it.
 	if oldAWSManagedControlplane.Spec.EncryptionConfig != nil && r.Spec.EncryptionConfig == nil {
 		allErrs = append(allErrs,
@@ -154,6 +186,16 @@ func (r *AWSManagedControlPlane) ValidateUpdate(old runtime.Object) (admission.W
 		)
 	}
 
+	// If encryption is already enabled, do not allow switching between a provided and a created key
+	if r.Spec.EncryptionConfig != nil &&
+		oldAWSManagedControlplane.Spec.EncryptionConfig != nil &&
+		r.Spec.EncryptionConfig.CreateKey != oldAWSManagedControlplane.Spec.EncryptionConfig.CreateKey &&
+		(oldAWSManagedControlplane.Spec.EncryptionConfig.CreateKey || aws.StringValue(oldAWSManagedControlplane.Spec.EncryptionConfig.Provider) != "") {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "encryptionConfig", "createKey"), r.Spec.EncryptionConfig.CreateKey, "changing EKS encryption is not allowed after it has been enabled"),
+		)
+	}
+
 	// If a identityRef is already set, do not allow removal of it.
 	if oldAWSManagedControlplane.Spec.IdentityRef != nil && r.Spec.IdentityRef == nil {
 		allErrs = append(allErrs,
@@ -233,6 +275,29 @@ func (r *AWSManagedControlPlane) validateEKSVersion(old *AWSManagedControlPlane)
 	return allErrs
 }
 
+// validateEKSAddonsConfiguration checks that the configuration of the addons is a JSON or YAML object, which is what
+// EKS accepts as configuration values.
+func (r *AWSManagedControlPlane) validateEKSAddonsConfiguration() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.Addons == nil {
+		return allErrs
+	}
+
+	for i, addon := range *r.Spec.Addons {
+		if addon.Configuration == "" {
+			continue
+		}
+		configuration := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(addon.Configuration), &configuration); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "addons").Index(i).Child("configuration"), addon.Configuration,
+				fmt.Sprintf("must be a JSON or YAML object: %v", err)))
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateEKSAddons() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -318,6 +383,195 @@ func (r *AWSManagedControlPlane) validateIAMAuthConfig() field.ErrorList {
 	return allErrs
 }
 
+// authenticationModeOrder is the order in which the authentication modes can be changed.
+var authenticationModeOrder = map[EKSAuthenticationMode]int{
+	EKSAuthenticationModeConfigMap:       0,
+	EKSAuthenticationModeAPIAndConfigMap: 1,
+	EKSAuthenticationModeAPI:             2,
+}
+
+func (r *AWSManagedControlPlane) validateAccessConfig(old *AWSManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+
+	parentPath := field.NewPath("spec", "accessConfig")
+
+	mode := r.Spec.GetAuthenticationMode()
+	if old != nil {
+		oldMode := old.Spec.GetAuthenticationMode()
+		if authenticationModeOrder[mode] < authenticationModeOrder[oldMode] {
+			allErrs = append(allErrs, field.Invalid(parentPath.Child("authenticationMode"), mode,
+				fmt.Sprintf("authentication mode cannot be changed from %s to %s", oldMode, mode)))
+		}
+	}
+
+	cfg := r.Spec.AccessConfig
+	if cfg == nil {
+		return allErrs
+	}
+
+	if len(cfg.AccessEntries) > 0 && mode == EKSAuthenticationModeConfigMap {
+		allErrs = append(allErrs, field.Forbidden(parentPath.Child("accessEntries"),
+			"access entries require the API or API_AND_CONFIG_MAP authentication mode"))
+	}
+
+	principals := map[string]struct{}{}
+	if mode == EKSAuthenticationModeAPI && r.Spec.IAMAuthenticatorConfig != nil {
+		// the aws-auth mappings are reconciled as access entries with the API authentication mode
+		iamAuthPath := field.NewPath("spec", "iamAuthenticatorConfig")
+		for i, mapping := range r.Spec.IAMAuthenticatorConfig.RoleMappings {
+			principals[mapping.RoleARN] = struct{}{}
+			allErrs = append(allErrs, validateAccessEntryGroups(iamAuthPath.Child("mapRoles").Index(i).Child("groups"), mapping.Groups)...)
+		}
+		for i, mapping := range r.Spec.IAMAuthenticatorConfig.UserMappings {
+			principals[mapping.UserARN] = struct{}{}
+			allErrs = append(allErrs, validateAccessEntryGroups(iamAuthPath.Child("mapUsers").Index(i).Child("groups"), mapping.Groups)...)
+		}
+	}
+
+	for i, entry := range cfg.AccessEntries {
+		entryPath := parentPath.Child("accessEntries").Index(i)
+		if _, ok := principals[entry.PrincipalARN]; ok {
+			allErrs = append(allErrs, field.Duplicate(entryPath.Child("principalARN"), entry.PrincipalARN))
+		}
+		principals[entry.PrincipalARN] = struct{}{}
+		allErrs = append(allErrs, validateAccessEntryGroups(entryPath.Child("kubernetesGroups"), entry.KubernetesGroups)...)
+
+		if entry.Type != "" && entry.Type != AccessEntryTypeStandard {
+			if len(entry.KubernetesGroups) > 0 || entry.Username != "" || len(entry.AccessPolicies) > 0 {
+				allErrs = append(allErrs, field.Forbidden(entryPath,
+					fmt.Sprintf("kubernetesGroups, username and accessPolicies cannot be set on %s access entries", entry.Type)))
+			}
+		}
+
+		for j, policy := range entry.AccessPolicies {
+			scopePath := entryPath.Child("accessPolicies").Index(j).Child("accessScope")
+			switch policy.AccessScope.Type {
+			case AccessScopeTypeNamespace:
+				if len(policy.AccessScope.Namespaces) == 0 {
+					allErrs = append(allErrs, field.Required(scopePath.Child("namespaces"), "namespaces are required for the namespace scope"))
+				}
+			default:
+				if len(policy.AccessScope.Namespaces) > 0 {
+					allErrs = append(allErrs, field.Forbidden(scopePath.Child("namespaces"), "namespaces can only be set for the namespace scope"))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSManagedControlPlane) validateIRSARoles() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(r.Spec.IRSARoles) == 0 {
+		return allErrs
+	}
+
+	parentPath := field.NewPath("spec", "irsaRoles")
+	if !r.Spec.AssociateOIDCProvider {
+		allErrs = append(allErrs, field.Forbidden(parentPath, "IRSA roles require associateOIDCProvider to be enabled"))
+	}
+
+	roleNames := map[string]struct{}{}
+	for i, role := range r.Spec.IRSARoles {
+		if _, ok := roleNames[role.RoleName]; ok {
+			allErrs = append(allErrs, field.Duplicate(parentPath.Index(i).Child("roleName"), role.RoleName))
+		}
+		roleNames[role.RoleName] = struct{}{}
+	}
+
+	return allErrs
+}
+
+// validatePodIdentityAssociations checks the pod identity associations of the spec and of the addons, as a service
+// account can only be associated with a single role.
+func (r *AWSManagedControlPlane) validatePodIdentityAssociations() field.ErrorList {
+	var allErrs field.ErrorList
+
+	serviceAccounts := map[ServiceAccountReference]struct{}{}
+	validate := func(associationPath *field.Path, association PodIdentityAssociation) {
+		if _, ok := serviceAccounts[association.ServiceAccount]; ok {
+			allErrs = append(allErrs, field.Duplicate(associationPath.Child("serviceAccount"),
+				fmt.Sprintf("%s/%s", association.ServiceAccount.Namespace, association.ServiceAccount.Name)))
+		}
+		serviceAccounts[association.ServiceAccount] = struct{}{}
+
+		if parsed, err := arn.Parse(association.RoleARN); err != nil || parsed.Service != "iam" {
+			allErrs = append(allErrs, field.Invalid(associationPath.Child("roleARN"), association.RoleARN, "must be the ARN of an IAM role"))
+		}
+	}
+
+	for i, association := range r.Spec.PodIdentityAssociations {
+		validate(field.NewPath("spec", "podIdentityAssociations").Index(i), association)
+	}
+	if r.Spec.Addons != nil {
+		for i, addon := range *r.Spec.Addons {
+			for j, association := range addon.PodIdentityAssociations {
+				validate(field.NewPath("spec", "addons").Index(i).Child("podIdentityAssociations").Index(j), association)
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSManagedControlPlane) validateEncryptionConfig() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.EncryptionConfig == nil || !r.Spec.EncryptionConfig.CreateKey {
+		return allErrs
+	}
+
+	if aws.StringValue(r.Spec.EncryptionConfig.Provider) != "" {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "encryptionConfig", "provider"), r.Spec.EncryptionConfig.Provider, "provider cannot be set when createKey is enabled"))
+	}
+
+	return allErrs
+}
+
+// validateOIDCIdentityProviderConfig checks the OIDC identity provider configuration is accepted by EKS.
+func (r *AWSManagedControlPlane) validateOIDCIdentityProviderConfig() field.ErrorList {
+	var allErrs field.ErrorList
+
+	cfg := r.Spec.OIDCIdentityProviderConfig
+	if cfg == nil {
+		return allErrs
+	}
+
+	parentPath := field.NewPath("spec", "oidcIdentityProviderConfig")
+	issuerURL, err := url.Parse(cfg.IssuerURL)
+	switch {
+	case err != nil || issuerURL.Host == "":
+		allErrs = append(allErrs, field.Invalid(parentPath.Child("issuerUrl"), cfg.IssuerURL, "must be a valid URL"))
+	case issuerURL.Scheme != "https":
+		allErrs = append(allErrs, field.Invalid(parentPath.Child("issuerUrl"), cfg.IssuerURL, "must begin with https://"))
+	case issuerURL.RawQuery != "" || issuerURL.Fragment != "":
+		allErrs = append(allErrs, field.Invalid(parentPath.Child("issuerUrl"), cfg.IssuerURL, "cannot contain a query or a fragment"))
+	}
+
+	if strings.HasPrefix(aws.StringValue(cfg.UsernamePrefix), "system:") {
+		allErrs = append(allErrs, field.Invalid(parentPath.Child("usernamePrefix"), aws.StringValue(cfg.UsernamePrefix), "cannot start with system:"))
+	}
+	if strings.HasPrefix(aws.StringValue(cfg.GroupsPrefix), "system:") {
+		allErrs = append(allErrs, field.Invalid(parentPath.Child("groupsPrefix"), aws.StringValue(cfg.GroupsPrefix), "cannot start with system:"))
+	}
+
+	return allErrs
+}
+
+// validateAccessEntryGroups checks that the Kubernetes groups of an access entry aren't reserved, which
+// EKS rejects.
+func validateAccessEntryGroups(groupsPath *field.Path, groups []string) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, group := range groups {
+		if strings.HasPrefix(group, "system:") {
+			allErrs = append(allErrs, field.Invalid(groupsPath.Index(i), group, "the groups of access entries cannot start with system:"))
+		}
+	}
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateSecondaryCIDR() field.ErrorList {
 	var allErrs field.ErrorList
 	if r.Spec.SecondaryCidrBlock != nil {
@@ -340,8 +594,14 @@ func (r *AWSManagedControlPlane) validateSecondaryCIDR() field.ErrorList {
 		if (!validRange1.Contains(start) || !validRange1.Contains(end)) && (!validRange2.Contains(start) || !validRange2.Contains(end)) {
 			allErrs = append(allErrs, field.Invalid(cidrField, *r.Spec.SecondaryCidrBlock, "must be within the 100.64.0.0/10 or 198.19.0.0/16 range"))
 		}
+
+		if r.Spec.NetworkSpec.PodNetwork != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "network", "podNetwork"), "cannot be set together with spec.secondaryCidrBlock"))
+		}
 	}
 
+	allErrs = append(allErrs, r.Spec.NetworkSpec.PodNetwork.Validate()...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -370,6 +630,33 @@ func (r *AWSManagedControlPlane) validateKubeProxy() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validatePodIdentityAgent() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !r.Spec.PodIdentityAgent.Enable {
+		return allErrs
+	}
+
+	enableField := field.NewPath("spec", "podIdentityAgent", "enable")
+	if r.Spec.Addons != nil {
+		for _, addon := range *r.Spec.Addons {
+			if addon.Name == podIdentityAgentAddon {
+				allErrs = append(allErrs, field.Invalid(enableField, r.Spec.PodIdentityAgent.Enable, "cannot enable the pod identity agent if the eks-pod-identity-agent addon is specified"))
+				break
+			}
+		}
+	}
+
+	if r.Spec.Version != nil {
+		v, err := parseEKSVersion(*r.Spec.Version)
+		if err == nil && v.LessThan(version.MustParseSemantic(minKubeVersionForPodIdentityAgent)) {
+			allErrs = append(allErrs, field.Invalid(enableField, r.Spec.PodIdentityAgent.Enable, fmt.Sprintf("the pod identity agent requires Kubernetes %s or greater", minKubeVersionForPodIdentityAgent)))
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateDisableVPCCNI() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -429,6 +716,20 @@ func (r *AWSManagedControlPlane) validateNetwork() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validateClusterSecurityGroupIngressRules() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, rule := range r.Spec.ClusterSecurityGroupIngressRules {
+		if len(rule.CidrBlocks) == 0 && len(rule.IPv6CidrBlocks) == 0 && len(rule.SourceSecurityGroupIDs) == 0 &&
+			len(rule.SourceSecurityGroupRoles) == 0 && !rule.NatGatewaysIPsSource {
+			rulePath := field.NewPath("spec", "clusterSecurityGroupIngressRules").Index(i)
+			allErrs = append(allErrs, field.Required(rulePath, "at least one source of the ingress rule must be set"))
+		}
+	}
+
+	return allErrs
+}
+
 // Default will set default values for the AWSManagedControlPlane.
 func (r *AWSManagedControlPlane) Default() {
 	mcpLog.Info("AWSManagedControlPlane setting defaults", "control-plane", klog.KObj(r))
@@ -452,6 +753,10 @@ func (r *AWSManagedControlPlane) Default() {
 		}
 	}
 
+	if r.Spec.EncryptionConfig != nil && r.Spec.EncryptionConfig.CreateKey && len(r.Spec.EncryptionConfig.Resources) == 0 {
+		r.Spec.EncryptionConfig.Resources = []*string{aws.String("secrets")}
+	}
+
 	infrav1.SetDefaults_Bastion(&r.Spec.Bastion)
 	infrav1.SetDefaults_NetworkSpec(&r.Spec.NetworkSpec)
 }
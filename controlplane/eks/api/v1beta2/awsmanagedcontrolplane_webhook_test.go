@@ -500,6 +500,197 @@ func TestWebhookCreateIPv6Details(t *testing.T) {
 	}
 }
 
+func TestWebhookCreateAddonsConfiguration(t *testing.T) {
+	tests := []struct {
+		name          string
+		configuration string
+		err           string
+	}{
+		{
+			name:          "JSON configuration",
+			configuration: `{"replicaCount": 3, "resources": {"limits": {"memory": "200Mi"}}}`,
+		},
+		{
+			name:          "YAML configuration",
+			configuration: "replicaCount: 3\nresources:\n  limits:\n    memory: 200Mi\n",
+		},
+		{
+			name:          "invalid configuration",
+			configuration: `{"replicaCount": 3`,
+			err:           "must be a JSON or YAML object",
+		},
+		{
+			name:          "configuration which isn't an object",
+			configuration: "- replicaCount",
+			err:           "must be a JSON or YAML object",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "mcp-",
+					Namespace:    "default",
+				},
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName: "test-cluster",
+					Addons: &[]Addon{
+						{
+							Name:          "coredns",
+							Version:       "v1.10.1-eksbuild.1",
+							Configuration: tc.configuration,
+						},
+					},
+					Version: aws.String("v1.22"),
+				},
+			}
+			err := testEnv.Create(ctx, mcp)
+
+			if tc.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.err)))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestWebhookCreatePodIdentityAgent(t *testing.T) {
+	tests := []struct {
+		name       string
+		eksVersion string
+		addons     []Addon
+		err        string
+	}{
+		{
+			name:       "pod identity agent enabled",
+			eksVersion: "v1.29",
+		},
+		{
+			name:       "pod identity agent with not allowed k8s version",
+			eksVersion: "v1.23",
+			err:        "the pod identity agent requires Kubernetes v1.24.0 or greater",
+		},
+		{
+			name:       "pod identity agent enabled with the pod identity agent addon",
+			eksVersion: "v1.29",
+			addons: []Addon{
+				{
+					Name:    podIdentityAgentAddon,
+					Version: "v1.2.0-eksbuild.1",
+				},
+			},
+			err: "cannot enable the pod identity agent if the eks-pod-identity-agent addon is specified",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "mcp-",
+					Namespace:    "default",
+				},
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName:   "test-cluster",
+					PodIdentityAgent: PodIdentityAgent{Enable: true},
+					Version:          aws.String(tc.eksVersion),
+				},
+			}
+			if tc.addons != nil {
+				mcp.Spec.Addons = &tc.addons
+			}
+			err := testEnv.Create(ctx, mcp)
+
+			if tc.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.err)))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestWebhookCreatePodIdentityAssociations(t *testing.T) {
+	externalDNS := PodIdentityAssociation{
+		ServiceAccount: ServiceAccountReference{Namespace: "kube-system", Name: "external-dns"},
+		RoleARN:        "arn:aws:iam::123456789012:role/external-dns",
+	}
+	tests := []struct {
+		name              string
+		associations      []PodIdentityAssociation
+		addonAssociations []PodIdentityAssociation
+		err               string
+	}{
+		{
+			name:         "valid associations",
+			associations: []PodIdentityAssociation{externalDNS},
+			addonAssociations: []PodIdentityAssociation{
+				{
+					ServiceAccount: ServiceAccountReference{Namespace: "kube-system", Name: "ebs-csi-controller-sa"},
+					RoleARN:        "arn:aws:iam::123456789012:role/ebs-csi",
+				},
+			},
+		},
+		{
+			name: "invalid role ARN",
+			associations: []PodIdentityAssociation{
+				{
+					ServiceAccount: externalDNS.ServiceAccount,
+					RoleARN:        "external-dns",
+				},
+			},
+			err: "must be the ARN of an IAM role",
+		},
+		{
+			name:              "service account associated twice",
+			associations:      []PodIdentityAssociation{externalDNS},
+			addonAssociations: []PodIdentityAssociation{externalDNS},
+			err:               "Duplicate value",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "mcp-",
+					Namespace:    "default",
+				},
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName:          "test-cluster",
+					PodIdentityAssociations: tc.associations,
+					Addons: &[]Addon{
+						{
+							Name:                    "aws-ebs-csi-driver",
+							Version:                 "v1.25.0-eksbuild.1",
+							PodIdentityAssociations: tc.addonAssociations,
+						},
+					},
+					Version: aws.String("v1.22"),
+				},
+			}
+			err := testEnv.Create(ctx, mcp)
+
+			if tc.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.err)))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
 func TestWebhookUpdate(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -639,6 +830,65 @@ func TestWebhookUpdate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "change in encryption config from nil to a created key",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				EncryptionConfig: &EncryptionConfig{
+					CreateKey: true,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "change in encryption config from provider to a created key",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				EncryptionConfig: &EncryptionConfig{
+					Provider: ptr.To[string]("provider"),
+				},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				EncryptionConfig: &EncryptionConfig{
+					CreateKey: true,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "change in encryption config from a created key to provider",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				EncryptionConfig: &EncryptionConfig{
+					CreateKey: true,
+				},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				EncryptionConfig: &EncryptionConfig{
+					Provider: ptr.To[string]("provider"),
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "encryption config with both provider and a created key",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				EncryptionConfig: &EncryptionConfig{
+					Provider:  ptr.To[string]("provider"),
+					CreateKey: true,
+				},
+			},
+			expectError: true,
+		},
 		{
 			name: "ekscluster specified, same name, invalid tags",
 			oldClusterSpec: AWSManagedControlPlaneSpec{
@@ -700,6 +950,48 @@ func TestWebhookUpdate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "authentication mode changed from CONFIG_MAP to API_AND_CONFIG_MAP",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig: &AccessConfig{
+					AuthenticationMode: EKSAuthenticationModeAPIAndConfigMap,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "authentication mode changed from API to API_AND_CONFIG_MAP",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig: &AccessConfig{
+					AuthenticationMode: EKSAuthenticationModeAPI,
+				},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig: &AccessConfig{
+					AuthenticationMode: EKSAuthenticationModeAPIAndConfigMap,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "access config removed after switching to the API authentication mode",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig: &AccessConfig{
+					AuthenticationMode: EKSAuthenticationModeAPI,
+				},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -726,6 +1018,158 @@ func TestWebhookUpdate(t *testing.T) {
 	}
 }
 
+func TestWebhookCreateAccessConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		accessConfig *AccessConfig
+		iamAuthCfg   *IAMAuthenticatorConfig
+		expectError  bool
+	}{
+		{
+			name: "access entries with the API authentication mode",
+			accessConfig: &AccessConfig{
+				AuthenticationMode: EKSAuthenticationModeAPI,
+				AccessEntries: []AccessEntry{{
+					PrincipalARN:     "arn:aws:iam::123456789012:role/admins",
+					KubernetesGroups: []string{"admins"},
+					AccessPolicies: []AccessPolicyReference{{
+						PolicyARN:   "arn:aws:eks::aws:cluster-access-policy/AmazonEKSViewPolicy",
+						AccessScope: AccessScope{Type: AccessScopeTypeNamespace, Namespaces: []string{"default"}},
+					}},
+				}},
+			},
+			expectError: false,
+		},
+		{
+			name: "access entries with the CONFIG_MAP authentication mode",
+			accessConfig: &AccessConfig{
+				AuthenticationMode: EKSAuthenticationModeConfigMap,
+				AccessEntries:      []AccessEntry{{PrincipalARN: "arn:aws:iam::123456789012:role/admins"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "duplicate access entries",
+			accessConfig: &AccessConfig{
+				AuthenticationMode: EKSAuthenticationModeAPI,
+				AccessEntries: []AccessEntry{
+					{PrincipalARN: "arn:aws:iam::123456789012:role/admins"},
+					{PrincipalARN: "arn:aws:iam::123456789012:role/admins"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "access policies on a node access entry",
+			accessConfig: &AccessConfig{
+				AuthenticationMode: EKSAuthenticationModeAPI,
+				AccessEntries: []AccessEntry{{
+					PrincipalARN:   "arn:aws:iam::123456789012:role/nodes",
+					Type:           AccessEntryTypeEC2Linux,
+					AccessPolicies: []AccessPolicyReference{{PolicyARN: "arn:aws:eks::aws:cluster-access-policy/AmazonEKSViewPolicy"}},
+				}},
+			},
+			expectError: true,
+		},
+		{
+			name: "namespace scope without namespaces",
+			accessConfig: &AccessConfig{
+				AuthenticationMode: EKSAuthenticationModeAPI,
+				AccessEntries: []AccessEntry{{
+					PrincipalARN: "arn:aws:iam::123456789012:role/admins",
+					AccessPolicies: []AccessPolicyReference{{
+						PolicyARN:   "arn:aws:eks::aws:cluster-access-policy/AmazonEKSViewPolicy",
+						AccessScope: AccessScope{Type: AccessScopeTypeNamespace},
+					}},
+				}},
+			},
+			expectError: true,
+		},
+		{
+			name: "aws-auth role mappings with the API authentication mode",
+			accessConfig: &AccessConfig{
+				AuthenticationMode: EKSAuthenticationModeAPI,
+			},
+			iamAuthCfg: &IAMAuthenticatorConfig{
+				RoleMappings: []RoleMapping{{
+					RoleARN:           "arn:aws:iam::123456789012:role/admins",
+					KubernetesMapping: KubernetesMapping{UserName: "admin", Groups: []string{"admins"}},
+				}},
+			},
+			expectError: false,
+		},
+		{
+			name: "aws-auth role mappings with reserved groups with the API authentication mode",
+			accessConfig: &AccessConfig{
+				AuthenticationMode: EKSAuthenticationModeAPI,
+			},
+			iamAuthCfg: &IAMAuthenticatorConfig{
+				RoleMappings: []RoleMapping{{
+					RoleARN:           "arn:aws:iam::123456789012:role/admins",
+					KubernetesMapping: KubernetesMapping{UserName: "admin", Groups: []string{"system:masters"}},
+				}},
+			},
+			expectError: true,
+		},
+		{
+			name: "aws-auth user mapping and access entry for the same principal with the API authentication mode",
+			accessConfig: &AccessConfig{
+				AuthenticationMode: EKSAuthenticationModeAPI,
+				AccessEntries: []AccessEntry{{
+					PrincipalARN: "arn:aws:iam::123456789012:user/admin",
+				}},
+			},
+			iamAuthCfg: &IAMAuthenticatorConfig{
+				UserMappings: []UserMapping{{
+					UserARN:           "arn:aws:iam::123456789012:user/admin",
+					KubernetesMapping: KubernetesMapping{UserName: "admin", Groups: []string{"admins"}},
+				}},
+			},
+			expectError: true,
+		},
+		{
+			name: "aws-auth role mappings with reserved groups with the API_AND_CONFIG_MAP authentication mode",
+			accessConfig: &AccessConfig{
+				AuthenticationMode: EKSAuthenticationModeAPIAndConfigMap,
+			},
+			iamAuthCfg: &IAMAuthenticatorConfig{
+				RoleMappings: []RoleMapping{{
+					RoleARN:           "arn:aws:iam::123456789012:role/admins",
+					KubernetesMapping: KubernetesMapping{UserName: "admin", Groups: []string{"system:masters"}},
+				}},
+			},
+			expectError: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "mcp-",
+					Namespace:    "default",
+				},
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName:         "default_cluster1",
+					AccessConfig:           tc.accessConfig,
+					IAMAuthenticatorConfig: tc.iamAuthCfg,
+				},
+			}
+
+			err := testEnv.Create(ctx, mcp)
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
 func TestValidatingWebhookCreateSecondaryCidr(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -866,3 +1310,189 @@ func TestValidatingWebhookUpdateSecondaryCidr(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatingWebhookCreateGCTasks(t *testing.T) {
+	tests := []struct {
+		name        string
+		gcTasks     string
+		expectError bool
+	}{
+		{
+			name:        "all gc tasks",
+			gcTasks:     "load-balancer,target-group,security-group",
+			expectError: false,
+		},
+		{
+			name:        "empty gc tasks",
+			gcTasks:     "",
+			expectError: false,
+		},
+		{
+			name:        "unsupported gc task",
+			gcTasks:     "load-balancer,volume",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						infrav1.ExternalResourceGCTasksAnnotation: tc.gcTasks,
+					},
+				},
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName: "default_cluster1",
+				},
+			}
+			warn, err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+			// Nothing emits warnings yet
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
+
+func TestWebhookCreateIRSARoles(t *testing.T) {
+	irsaRole := IRSARole{
+		RoleName: "external-dns",
+		ServiceAccount: ServiceAccountReference{
+			Namespace: "kube-system",
+			Name:      "external-dns",
+		},
+		PolicyARNs: []string{"arn:aws:iam::123456789012:policy/external-dns"},
+	}
+
+	tests := []struct {
+		name                  string
+		associateOIDCProvider bool
+		irsaRoles             []IRSARole
+		expectError           bool
+	}{
+		{
+			name:                  "IRSA roles with the OIDC provider",
+			associateOIDCProvider: true,
+			irsaRoles:             []IRSARole{irsaRole},
+			expectError:           false,
+		},
+		{
+			name:                  "IRSA roles without the OIDC provider",
+			associateOIDCProvider: false,
+			irsaRoles:             []IRSARole{irsaRole},
+			expectError:           true,
+		},
+		{
+			name:                  "duplicate IRSA role names",
+			associateOIDCProvider: true,
+			irsaRoles:             []IRSARole{irsaRole, irsaRole},
+			expectError:           true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "mcp-",
+					Namespace:    "default",
+				},
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName:        "default_cluster1",
+					AssociateOIDCProvider: tc.associateOIDCProvider,
+					IRSARoles:             tc.irsaRoles,
+				},
+			}
+
+			err := testEnv.Create(ctx, mcp)
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestWebhookCreateOIDCIdentityProviderConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		issuerURL      string
+		usernamePrefix *string
+		groupsPrefix   *string
+		expectError    bool
+	}{
+		{
+			name:           "valid identity provider",
+			issuerURL:      "https://sso.example.com/oauth2",
+			usernamePrefix: ptr.To[string]("oidc:"),
+			groupsPrefix:   ptr.To[string]("oidc:"),
+			expectError:    false,
+		},
+		{
+			name:        "issuer not using https",
+			issuerURL:   "http://sso.example.com",
+			expectError: true,
+		},
+		{
+			name:        "issuer with a query",
+			issuerURL:   "https://sso.example.com?tenant=example",
+			expectError: true,
+		},
+		{
+			name:           "reserved username prefix",
+			issuerURL:      "https://sso.example.com",
+			usernamePrefix: ptr.To[string]("system:oidc:"),
+			expectError:    true,
+		},
+		{
+			name:         "reserved groups prefix",
+			issuerURL:    "https://sso.example.com",
+			groupsPrefix: ptr.To[string]("system:"),
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "mcp-",
+					Namespace:    "default",
+				},
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName: "default_cluster1",
+					OIDCIdentityProviderConfig: &OIDCIdentityProviderConfig{
+						ClientID:                   "kubernetes",
+						IdentityProviderConfigName: "corporate-sso",
+						IssuerURL:                  tc.issuerURL,
+						UsernamePrefix:             tc.usernamePrefix,
+						GroupsPrefix:               tc.groupsPrefix,
+					},
+				},
+			}
+
+			err := testEnv.Create(ctx, mcp)
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
@@ -29,6 +29,9 @@ const (
 	EKSControlPlaneUpdatingCondition clusterv1.ConditionType = "EKSControlPlaneUpdating"
 	// EKSControlPlaneReconciliationFailedReason used to report failures while reconciling EKS control plane.
 	EKSControlPlaneReconciliationFailedReason = "EKSControlPlaneReconciliationFailed"
+	// EKSControlPlaneDeletionBlockedReason used when deletion of the EKS cluster is waiting
+	// for an explicit confirmation.
+	EKSControlPlaneDeletionBlockedReason = "EKSControlPlaneDeletionBlocked"
 )
 
 const (
@@ -50,6 +53,10 @@ const (
 	EKSAddonsConfiguredCondition clusterv1.ConditionType = "EKSAddonsConfigured"
 	// EKSAddonsConfiguredFailedReason used to report failures while reconciling the EKS addons.
 	EKSAddonsConfiguredFailedReason = "EKSAddonsConfiguredFailed"
+	// EKSAddonsHealthyCondition condition reports on the health of the installed EKS addons.
+	EKSAddonsHealthyCondition clusterv1.ConditionType = "EKSAddonsHealthy"
+	// EKSAddonsDegradedReason used when an EKS addon is degraded or failed.
+	EKSAddonsDegradedReason = "EKSAddonsDegraded"
 )
 
 const (
@@ -58,3 +65,19 @@ const (
 	// EKSIdentityProviderConfiguredFailedReason used to report failures while reconciling the identity provider config association.
 	EKSIdentityProviderConfiguredFailedReason = "EKSIdentityProviderConfiguredFailed"
 )
+
+const (
+	// EKSAccessEntriesConfiguredCondition condition reports on the successful reconciliation of the EKS access entries.
+	EKSAccessEntriesConfiguredCondition clusterv1.ConditionType = "EKSAccessEntriesConfigured"
+	// EKSAccessEntriesConfiguredFailedReason used to report failures while reconciling the EKS access entries.
+	EKSAccessEntriesConfiguredFailedReason = "EKSAccessEntriesConfiguredFailed"
+)
+
+const (
+	// EKSPodIdentityAssociationsConfiguredCondition condition reports on the successful reconciliation of the EKS pod
+	// identity associations.
+	EKSPodIdentityAssociationsConfiguredCondition clusterv1.ConditionType = "EKSPodIdentityAssociationsConfigured"
+	// EKSPodIdentityAssociationsConfiguredFailedReason used to report failures while reconciling the EKS pod identity
+	// associations.
+	EKSPodIdentityAssociationsConfiguredFailedReason = "EKSPodIdentityAssociationsConfiguredFailed"
+)
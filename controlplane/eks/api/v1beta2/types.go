@@ -130,17 +130,28 @@ type Addon struct {
 	Name string `json:"name"`
 	// Version is the version of the addon to use
 	Version string `json:"version"`
-	// Configuration of the EKS addon
+	// Configuration of the EKS addon, as a JSON or YAML document. The schema of the
+	// configuration of an addon version is given by the describe-addon-configuration
+	// EKS API. Changes to it are applied to the installed addon.
 	// +optional
 	Configuration string `json:"configuration,omitempty"`
 	// ConflictResolution is used to declare what should happen if there
-	// are parameter conflicts. Defaults to none
+	// are parameter conflicts when the addon is created or updated. Defaults to overwrite
 	// +kubebuilder:default=overwrite
-	// +kubebuilder:validation:Enum=overwrite;none
+	// +kubebuilder:validation:Enum=overwrite;none;preserve
 	ConflictResolution *AddonResolution `json:"conflictResolution,omitempty"`
 	// ServiceAccountRoleArn is the ARN of an IAM role to bind to the addons service account
 	// +optional
 	ServiceAccountRoleArn *string `json:"serviceAccountRoleARN,omitempty"`
+	// PreserveOnDelete keeps the resources of the addon in the cluster when the addon is
+	// removed from the spec. The resources are no longer managed by EKS afterwards.
+	// +optional
+	PreserveOnDelete bool `json:"preserveOnDelete,omitempty"`
+	// PodIdentityAssociations associate IAM roles with the service accounts of the addon with
+	// EKS Pod Identity, as an alternative to ServiceAccountRoleArn. The associations are deleted
+	// with the addon.
+	// +optional
+	PodIdentityAssociations []PodIdentityAssociation `json:"podIdentityAssociations,omitempty"`
 }
 
 // AddonResolution defines the method for resolving parameter conflicts.
@@ -154,6 +165,10 @@ var (
 	// AddonResolutionNone indicates that if there are parameter conflicts then
 	// resolution will not be done and an error will be reported.
 	AddonResolutionNone = AddonResolution("none")
+
+	// AddonResolutionPreserve indicates that if there are parameter conflicts then
+	// the values set in the cluster will be kept.
+	AddonResolutionPreserve = AddonResolution("preserve")
 )
 
 // AddonStatus defines the status for an addon.
@@ -200,6 +215,10 @@ type AddonState struct {
 	Status *string `json:"status,omitempty"`
 	// Issues is a list of issue associated with the addon
 	Issues []AddonIssue `json:"issues,omitempty"`
+	// PreserveOnDelete reports whether the resources of the addon are kept in the
+	// cluster when the addon is removed from the spec.
+	// +optional
+	PreserveOnDelete bool `json:"preserveOnDelete,omitempty"`
 }
 
 // AddonIssue represents an issue with an addon.
@@ -279,3 +298,161 @@ type OIDCIdentityProviderConfig struct {
 	// +optional
 	Tags infrav1.Tags `json:"tags,omitempty"`
 }
+
+// EKSAuthenticationMode defines the sources of the authenticated IAM principals of an EKS cluster.
+type EKSAuthenticationMode string
+
+var (
+	// EKSAuthenticationModeConfigMap indicates that only the aws-auth config map is used to authenticate
+	// the IAM principals.
+	EKSAuthenticationModeConfigMap = EKSAuthenticationMode("CONFIG_MAP")
+
+	// EKSAuthenticationModeAPIAndConfigMap indicates that both the access entries and the aws-auth config map
+	// are used to authenticate the IAM principals.
+	EKSAuthenticationModeAPIAndConfigMap = EKSAuthenticationMode("API_AND_CONFIG_MAP")
+
+	// EKSAuthenticationModeAPI indicates that only the access entries are used to authenticate the IAM principals.
+	EKSAuthenticationModeAPI = EKSAuthenticationMode("API")
+)
+
+// AccessConfig represents the access configuration of an EKS cluster.
+type AccessConfig struct {
+	// AuthenticationMode is the source of the authenticated IAM principals. The mode can only be changed
+	// from CONFIG_MAP to API_AND_CONFIG_MAP, and from API_AND_CONFIG_MAP to API.
+	// +kubebuilder:default=CONFIG_MAP
+	// +kubebuilder:validation:Enum=CONFIG_MAP;API_AND_CONFIG_MAP;API
+	// +optional
+	AuthenticationMode EKSAuthenticationMode `json:"authenticationMode,omitempty"`
+
+	// BootstrapClusterCreatorAdminPermissions grants cluster admin permissions to the IAM principal
+	// creating the cluster. It is only used when the cluster is created.
+	// +kubebuilder:default=true
+	// +optional
+	BootstrapClusterCreatorAdminPermissions *bool `json:"bootstrapClusterCreatorAdminPermissions,omitempty"`
+
+	// AccessEntries is the list of access entries of the IAM principals allowed to access the cluster.
+	// It requires the API or API_AND_CONFIG_MAP authentication mode. The access entries of the node roles
+	// are managed by the controller, or by EKS for the managed node groups and Fargate profiles.
+	// +optional
+	AccessEntries []AccessEntry `json:"accessEntries,omitempty"`
+}
+
+// AccessEntryType defines the type of an access entry.
+type AccessEntryType string
+
+var (
+	// AccessEntryTypeStandard is the type of the access entries of the IAM principals other than nodes.
+	AccessEntryTypeStandard = AccessEntryType("STANDARD")
+
+	// AccessEntryTypeEC2Linux is the type of the access entries of the Linux nodes.
+	AccessEntryTypeEC2Linux = AccessEntryType("EC2_LINUX")
+
+	// AccessEntryTypeEC2Windows is the type of the access entries of the Windows nodes.
+	AccessEntryTypeEC2Windows = AccessEntryType("EC2_WINDOWS")
+
+	// AccessEntryTypeFargateLinux is the type of the access entries of the Fargate pods.
+	AccessEntryTypeFargateLinux = AccessEntryType("FARGATE_LINUX")
+)
+
+// AccessEntry represents an access entry of an IAM principal in an EKS cluster.
+type AccessEntry struct {
+	// PrincipalARN is the ARN of the IAM role or user allowed to access the cluster.
+	// +kubebuilder:validation:MinLength:=31
+	PrincipalARN string `json:"principalARN"`
+
+	// Type is the type of the access entry. The kubernetes groups, username and access policies
+	// can only be set on the STANDARD access entries.
+	// +kubebuilder:default=STANDARD
+	// +kubebuilder:validation:Enum=STANDARD;EC2_LINUX;EC2_WINDOWS;FARGATE_LINUX
+	// +optional
+	Type AccessEntryType `json:"type,omitempty"`
+
+	// KubernetesGroups is the list of kubernetes RBAC groups of the principal.
+	// +optional
+	KubernetesGroups []string `json:"kubernetesGroups,omitempty"`
+
+	// Username is the kubernetes RBAC user of the principal. Defaults to the username generated by EKS.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// AccessPolicies is the list of EKS access policies associated with the principal.
+	// +optional
+	AccessPolicies []AccessPolicyReference `json:"accessPolicies,omitempty"`
+}
+
+// AccessScopeType defines the scope of an access policy.
+type AccessScopeType string
+
+var (
+	// AccessScopeTypeCluster indicates that the access policy applies to the whole cluster.
+	AccessScopeTypeCluster = AccessScopeType("cluster")
+
+	// AccessScopeTypeNamespace indicates that the access policy applies to a list of namespaces.
+	AccessScopeTypeNamespace = AccessScopeType("namespace")
+)
+
+// AccessPolicyReference represents an EKS access policy associated with an access entry.
+type AccessPolicyReference struct {
+	// PolicyARN is the ARN of the access policy, for example
+	// arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy.
+	// +kubebuilder:validation:MinLength:=1
+	PolicyARN string `json:"policyARN"`
+
+	// AccessScope is the scope of the access policy.
+	// +optional
+	AccessScope AccessScope `json:"accessScope,omitempty"`
+}
+
+// AccessScope represents the scope of an access policy.
+type AccessScope struct {
+	// Type is the type of the scope.
+	// +kubebuilder:default=cluster
+	// +kubebuilder:validation:Enum=cluster;namespace
+	// +optional
+	Type AccessScopeType `json:"type,omitempty"`
+
+	// Namespaces is the list of namespaces the access policy applies to. It is required for the
+	// namespace scope.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// IRSARole is an IAM role that a service account of the cluster can assume with IAM roles for
+// service accounts.
+type IRSARole struct {
+	// RoleName is the name of the IAM role.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=64
+	RoleName string `json:"roleName"`
+
+	// ServiceAccount is the service account allowed to assume the role.
+	ServiceAccount ServiceAccountReference `json:"serviceAccount"`
+
+	// PolicyARNs are the ARNs of the managed policies to attach to the role.
+	// +optional
+	PolicyARNs []string `json:"policyARNs,omitempty"`
+}
+
+// PodIdentityAssociation associates an IAM role with a service account of the cluster with EKS Pod
+// Identity. The pods using the service account get the credentials of the role from the EKS Pod
+// Identity Agent.
+type PodIdentityAssociation struct {
+	// ServiceAccount is the service account the role is associated with.
+	ServiceAccount ServiceAccountReference `json:"serviceAccount"`
+
+	// RoleARN is the ARN of the IAM role associated with the service account. The trust policy of
+	// the role must allow the pods.eks.amazonaws.com service principal to assume it and tag sessions.
+	// +kubebuilder:validation:MinLength:=1
+	RoleARN string `json:"roleARN"`
+}
+
+// ServiceAccountReference references a service account of the cluster.
+type ServiceAccountReference struct {
+	// Namespace is the namespace of the service account.
+	// +kubebuilder:validation:MinLength:=1
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the service account.
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+}
@@ -39,6 +39,7 @@ import (
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/internal/fake"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/awsnode"
@@ -51,6 +52,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	infrarecord "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	capiannotations "sigs.k8s.io/cluster-api/util/annotations"
@@ -90,7 +92,7 @@ type AWSManagedControlPlaneReconciler struct {
 
 	awsNodeServiceFactory          func(scope.AWSNodeScope) services.AWSNodeInterface
 	ec2ServiceFactory              func(scope.EC2Scope) services.EC2Interface
-	eksServiceFactory              func(*scope.ManagedControlPlaneScope) *eks.Service
+	eksServiceFactory              func(*scope.ManagedControlPlaneScope) services.EKSInterface
 	iamAuthenticatorServiceFactory func(scope.IAMAuthScope, iamauth.BackendType, client.Client) services.IAMAuthenticatorInterface
 	kubeProxyServiceFactory        func(scope.KubeProxyScope) services.KubeProxyInterface
 	networkServiceFactory          func(scope.NetworkScope) services.NetworkInterface
@@ -103,6 +105,7 @@ type AWSManagedControlPlaneReconciler struct {
 	AlternativeGCStrategy        bool
 	WaitInfraPeriod              time.Duration
 	TagUnmanagedNetworkResources bool
+	KMSAliasPrefix               string
 }
 
 // getAWSNodeService factory func is added for testing purpose so that we can inject mocked AWSNodeInterface to the AWSManagedControlPlaneReconciler.
@@ -122,8 +125,8 @@ func (r *AWSManagedControlPlaneReconciler) getEC2Service(scope scope.EC2Scope) s
 }
 
 // getEC2Service factory func is added for testing purpose so that we can inject mocked EC2Service to the AWSManagedControlPlaneReconciler.
-func (r *AWSManagedControlPlaneReconciler) getEKSService(scope *scope.ManagedControlPlaneScope) *eks.Service {
-	if r.ec2ServiceFactory != nil {
+func (r *AWSManagedControlPlaneReconciler) getEKSService(scope *scope.ManagedControlPlaneScope) services.EKSInterface {
+	if r.eksServiceFactory != nil {
 		return r.eksServiceFactory(scope)
 	}
 	return eks.NewService(scope)
@@ -161,6 +164,26 @@ func (r *AWSManagedControlPlaneReconciler) getSecurityGroupService(scope *scope.
 	return securitygroup.NewService(scope, securityGroupRolesForControlPlane(scope))
 }
 
+// UseFakeCloud makes the AWSManagedControlPlaneReconciler manage the EKS control planes in the given fake cloud
+// instead of AWS.
+func (r *AWSManagedControlPlaneReconciler) UseFakeCloud(fakeCloud *fake.Cloud) {
+	r.awsNodeServiceFactory = func(scope.AWSNodeScope) services.AWSNodeInterface {
+		return fakeCloud.AWSNodeService()
+	}
+	r.ec2ServiceFactory = fakeCloud.EC2Service
+	r.eksServiceFactory = fakeCloud.EKSService
+	r.iamAuthenticatorServiceFactory = func(scope.IAMAuthScope, iamauth.BackendType, client.Client) services.IAMAuthenticatorInterface {
+		return fakeCloud.IAMAuthenticatorService()
+	}
+	r.kubeProxyServiceFactory = func(scope.KubeProxyScope) services.KubeProxyInterface {
+		return fakeCloud.KubeProxyService()
+	}
+	r.networkServiceFactory = fakeCloud.NetworkService
+	r.securityGroupServiceFactory = func(scope *scope.ManagedControlPlaneScope) services.SecurityGroupInterface {
+		return fakeCloud.SecurityGroupService(scope, securityGroupRolesForControlPlane(scope))
+	}
+}
+
 // SetupWithManager is used to setup the controller.
 func (r *AWSManagedControlPlaneReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	log := logger.FromContext(ctx)
@@ -251,6 +274,7 @@ func (r *AWSManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ct
 		AllowAdditionalRoles:         r.AllowAdditionalRoles,
 		Endpoints:                    r.Endpoints,
 		TagUnmanagedNetworkResources: r.TagUnmanagedNetworkResources,
+		KMSAliasPrefix:               r.KMSAliasPrefix,
 		Logger:                       log,
 	})
 	if err != nil {
@@ -368,6 +392,11 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 			// non fatal error, so we continue
 			managedScope.Error(err, "non-fatal: failed to set up EventBridge")
 		}
+		if feature.Gates.Enabled(feature.SpotInterruptionHandling) {
+			if err := instancestateSvc.ReconcileSpotInterruptionEvents(); err != nil {
+				managedScope.Error(err, "non-fatal: failed to set up EventBridge for Spot interruption warnings")
+			}
+		}
 	}
 	if err := authService.ReconcileIAMAuthenticator(ctx); err != nil {
 		conditions.MarkFalse(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition, ekscontrolplanev1.IAMAuthenticatorConfigurationFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -376,6 +405,9 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 	conditions.MarkTrue(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition)
 
 	for _, subnet := range managedScope.Subnets().FilterPrivate() {
+		if !managedScope.VPC().IsAvailabilityZoneAllowed(subnet.AvailabilityZone) {
+			continue
+		}
 		managedScope.SetFailureDomain(subnet.AvailabilityZone, clusterv1.FailureDomainSpec{
 			ControlPlane: true,
 		})
@@ -391,6 +423,17 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 
 	controlPlane := managedScope.ControlPlane
 
+	if controlPlane.Spec.DisableClusterDeletion && controlPlane.Annotations[ekscontrolplanev1.ConfirmClusterDeletionAnnotation] != "true" {
+		if !conditions.IsFalse(controlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition) ||
+			conditions.GetReason(controlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition) != ekscontrolplanev1.EKSControlPlaneDeletionBlockedReason {
+			infrarecord.Warnf(controlPlane, "DeletionBlocked", "Deletion of EKS cluster %s is disabled, annotate with %s=true to confirm", managedScope.KubernetesClusterName(), ekscontrolplanev1.ConfirmClusterDeletionAnnotation)
+		}
+		conditions.MarkFalse(controlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition, ekscontrolplanev1.EKSControlPlaneDeletionBlockedReason, clusterv1.ConditionSeverityWarning,
+			"cluster deletion is disabled, annotate with %s=true to confirm", ekscontrolplanev1.ConfirmClusterDeletionAnnotation)
+		log.Info("EKS cluster deletion is disabled and has not been confirmed", "annotation", ekscontrolplanev1.ConfirmClusterDeletionAnnotation)
+		return reconcile.Result{RequeueAfter: deleteRequeueAfter}, nil
+	}
+
 	numDependencies, err := r.dependencyCount(ctx, managedScope)
 	if err != nil {
 		log.Error(err, "error getting controlplane dependencies", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
@@ -402,10 +445,10 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 	}
 	log.Info("EKS cluster has no dependencies")
 
-	ekssvc := eks.NewService(managedScope)
-	ec2svc := ec2.NewService(managedScope)
-	networkSvc := network.NewService(managedScope)
-	sgService := securitygroup.NewService(managedScope, securityGroupRolesForControlPlane(managedScope))
+	ekssvc := r.getEKSService(managedScope)
+	ec2svc := r.getEC2Service(managedScope)
+	networkSvc := r.getNetworkService(managedScope)
+	sgService := r.getSecurityGroupService(managedScope)
 
 	if err := ekssvc.DeleteControlPlane(); err != nil {
 		log.Error(err, "error deleting EKS cluster for EKS control plane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
@@ -417,6 +460,11 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 		return reconcile.Result{}, err
 	}
 
+	if err := ec2svc.DeletePlacementGroups(); err != nil {
+		log.Error(err, "error deleting placement groups for AWSManagedControlPlane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
+		return reconcile.Result{}, err
+	}
+
 	if err := sgService.DeleteSecurityGroups(); err != nil {
 		log.Error(err, "error deleting general security groups for AWSManagedControlPlane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
 		return reconcile.Result{}, err
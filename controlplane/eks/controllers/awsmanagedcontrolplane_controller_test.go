@@ -164,7 +164,7 @@ func TestAWSManagedControlPlaneReconcilerIntegrationTests(t *testing.T) {
 		eksSvc.EKSClient = eksMock
 		eksSvc.IAMService.IAMClient = iamMock
 		eksSvc.STSClient = stsMock
-		reconciler.eksServiceFactory = func(scope *scope.ManagedControlPlaneScope) *eksService.Service {
+		reconciler.eksServiceFactory = func(scope *scope.ManagedControlPlaneScope) services.EKSInterface {
 			return eksSvc
 		}
 
@@ -17,11 +17,19 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	fakecloud "sigs.k8s.io/cluster-api-provider-aws/v2/internal/fake"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestSecurityGroupRolesForCluster(t *testing.T) {
@@ -61,3 +69,63 @@ func TestSecurityGroupRolesForCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileDeleteConfirmation(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		expectedDeleted bool
+	}{
+		{
+			name:            "Should block the deletion when it is not confirmed",
+			expectedDeleted: false,
+		},
+		{
+			name:            "Should block the deletion when the confirmation is not true",
+			annotations:     map[string]string{ekscontrolplanev1.ConfirmClusterDeletionAnnotation: "false"},
+			expectedDeleted: false,
+		},
+		{
+			name:            "Should delete the cluster when the deletion is confirmed",
+			annotations:     map[string]string{ekscontrolplanev1.ConfirmClusterDeletionAnnotation: "true"},
+			expectedDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, _, awsManagedControlPlane := getManagedClusterObjects("test", "test")
+			awsManagedControlPlane.Annotations = tt.annotations
+			awsManagedControlPlane.Spec.DisableClusterDeletion = true
+			awsManagedControlPlane.Spec.Bastion.Enabled = false
+			controllerutil.AddFinalizer(&awsManagedControlPlane, ekscontrolplanev1.ManagedControlPlaneFinalizer)
+			managedScope, err := getManagedControlPlaneScope(awsManagedControlPlane)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			g.Expect(expinfrav1.AddToScheme(scheme)).To(Succeed())
+			reconciler := &AWSManagedControlPlaneReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			}
+			reconciler.UseFakeCloud(fakecloud.NewCloud())
+
+			result, err := reconciler.reconcileDelete(context.TODO(), managedScope)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			controlPlane := managedScope.ControlPlane
+			if tt.expectedDeleted {
+				g.Expect(result.RequeueAfter).To(BeZero())
+				g.Expect(controlPlane.Finalizers).NotTo(ContainElement(ekscontrolplanev1.ManagedControlPlaneFinalizer))
+				g.Expect(conditions.GetReason(controlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition)).NotTo(Equal(ekscontrolplanev1.EKSControlPlaneDeletionBlockedReason))
+			} else {
+				g.Expect(result.RequeueAfter).To(Equal(deleteRequeueAfter))
+				g.Expect(controlPlane.Finalizers).To(ContainElement(ekscontrolplanev1.ManagedControlPlaneFinalizer))
+				g.Expect(conditions.IsFalse(controlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition)).To(BeTrue())
+				g.Expect(conditions.GetReason(controlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition)).To(Equal(ekscontrolplanev1.EKSControlPlaneDeletionBlockedReason))
+			}
+		})
+	}
+}
@@ -41,8 +41,23 @@ func (src *AWSMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.SuspendProcesses != nil {
 		dst.Spec.SuspendProcesses = restored.Spec.SuspendProcesses
 	}
+	dst.Spec.ScaleInProtection = restored.Spec.ScaleInProtection
+	dst.Spec.WarmPool = restored.Spec.WarmPool
+	dst.Spec.MetricsCollection = restored.Spec.MetricsCollection
+	dst.Spec.MaxInstanceLifetime = restored.Spec.MaxInstanceLifetime
+	dst.Spec.TargetGroupARNs = restored.Spec.TargetGroupARNs
+	dst.Spec.LoadBalancerNames = restored.Spec.LoadBalancerNames
+	dst.Spec.EC2Fleet = restored.Spec.EC2Fleet
+	dst.Spec.ExistingASG = restored.Spec.ExistingASG
+	dst.Spec.TerminationDrain = restored.Spec.TerminationDrain
 	if dst.Spec.RefreshPreferences != nil && restored.Spec.RefreshPreferences != nil {
 		dst.Spec.RefreshPreferences.Disable = restored.Spec.RefreshPreferences.Disable
+		dst.Spec.RefreshPreferences.RefreshOnBootstrapDataChange = restored.Spec.RefreshPreferences.RefreshOnBootstrapDataChange
+		dst.Spec.RefreshPreferences.MaxHealthyPercentage = restored.Spec.RefreshPreferences.MaxHealthyPercentage
+		dst.Spec.RefreshPreferences.CheckpointPercentages = restored.Spec.RefreshPreferences.CheckpointPercentages
+		dst.Spec.RefreshPreferences.CheckpointDelay = restored.Spec.RefreshPreferences.CheckpointDelay
+		dst.Spec.RefreshPreferences.SkipMatching = restored.Spec.RefreshPreferences.SkipMatching
+		dst.Spec.RefreshPreferences.ScaleInProtectedInstances = restored.Spec.RefreshPreferences.ScaleInProtectedInstances
 	}
 	if restored.Spec.AWSLaunchTemplate.InstanceMetadataOptions != nil {
 		dst.Spec.AWSLaunchTemplate.InstanceMetadataOptions = restored.Spec.AWSLaunchTemplate.InstanceMetadataOptions
@@ -54,8 +69,36 @@ func (src *AWSMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.AWSLaunchTemplate.PrivateDNSName != nil {
 		dst.Spec.AWSLaunchTemplate.PrivateDNSName = restored.Spec.AWSLaunchTemplate.PrivateDNSName
 	}
+	dst.Spec.AWSLaunchTemplate.PublicIP = restored.Spec.AWSLaunchTemplate.PublicIP
+	if restored.Spec.AWSLaunchTemplate.SpotMarketOptions != nil && dst.Spec.AWSLaunchTemplate.SpotMarketOptions != nil {
+		dst.Spec.AWSLaunchTemplate.SpotMarketOptions.InterruptionBehavior = restored.Spec.AWSLaunchTemplate.SpotMarketOptions.InterruptionBehavior
+	}
+	dst.Spec.AWSLaunchTemplate.CapacityReservationTarget = restored.Spec.AWSLaunchTemplate.CapacityReservationTarget
+	dst.Spec.AWSLaunchTemplate.Tenancy = restored.Spec.AWSLaunchTemplate.Tenancy
+	dst.Spec.AWSLaunchTemplate.HostID = restored.Spec.AWSLaunchTemplate.HostID
+	dst.Spec.AWSLaunchTemplate.HostResourceGroupARN = restored.Spec.AWSLaunchTemplate.HostResourceGroupARN
+	dst.Spec.AWSLaunchTemplate.PlacementGroup = restored.Spec.AWSLaunchTemplate.PlacementGroup
+	dst.Spec.AWSLaunchTemplate.EnclaveOptions = restored.Spec.AWSLaunchTemplate.EnclaveOptions
+	dst.Spec.AWSLaunchTemplate.AMI.Filters = restored.Spec.AWSLaunchTemplate.AMI.Filters
+	dst.Spec.AWSLaunchTemplate.AMI.SSMParameter = restored.Spec.AWSLaunchTemplate.AMI.SSMParameter
+	dst.Spec.AWSLaunchTemplate.AMI.MarketplaceProductCode = restored.Spec.AWSLaunchTemplate.AMI.MarketplaceProductCode
+	dst.Spec.AWSLaunchTemplate.ImageLookupArchitecture = restored.Spec.AWSLaunchTemplate.ImageLookupArchitecture
+	dst.Spec.AWSLaunchTemplate.ImageLookupRootDeviceType = restored.Spec.AWSLaunchTemplate.ImageLookupRootDeviceType
+	dst.Spec.AWSLaunchTemplate.InstanceRequirements = restored.Spec.AWSLaunchTemplate.InstanceRequirements
+	dst.Spec.AWSLaunchTemplate.NonRootVolumes = restored.Spec.AWSLaunchTemplate.NonRootVolumes
+	dst.Spec.AWSLaunchTemplate.AdditionalVolumeTags = restored.Spec.AWSLaunchTemplate.AdditionalVolumeTags
+	dst.Spec.AWSLaunchTemplate.AdditionalNetworkInterfaceTags = restored.Spec.AWSLaunchTemplate.AdditionalNetworkInterfaceTags
+	dst.Spec.AWSLaunchTemplate.PreBootstrapUserData = restored.Spec.AWSLaunchTemplate.PreBootstrapUserData
+	dst.Spec.AWSLaunchTemplate.UncompressedUserData = restored.Spec.AWSLaunchTemplate.UncompressedUserData
+	dst.Spec.AWSLaunchTemplate.VersionsToKeep = restored.Spec.AWSLaunchTemplate.VersionsToKeep
+	if restored.Spec.AWSLaunchTemplate.RootVolume != nil && dst.Spec.AWSLaunchTemplate.RootVolume != nil {
+		dst.Spec.AWSLaunchTemplate.RootVolume.DeleteOnTermination = restored.Spec.AWSLaunchTemplate.RootVolume.DeleteOnTermination
+	}
 
 	dst.Spec.DefaultInstanceWarmup = restored.Spec.DefaultInstanceWarmup
+	dst.Status.InstanceRefresh = restored.Status.InstanceRefresh
+	dst.Status.FleetID = restored.Status.FleetID
+	dst.Status.InstantFleetLaunch = restored.Status.InstantFleetLaunch
 
 	return nil
 }
@@ -105,10 +148,37 @@ func (src *AWSManagedMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		if restored.Spec.AWSLaunchTemplate.PrivateDNSName != nil {
 			dst.Spec.AWSLaunchTemplate.PrivateDNSName = restored.Spec.AWSLaunchTemplate.PrivateDNSName
 		}
+		dst.Spec.AWSLaunchTemplate.PublicIP = restored.Spec.AWSLaunchTemplate.PublicIP
+		if restored.Spec.AWSLaunchTemplate.SpotMarketOptions != nil && dst.Spec.AWSLaunchTemplate.SpotMarketOptions != nil {
+			dst.Spec.AWSLaunchTemplate.SpotMarketOptions.InterruptionBehavior = restored.Spec.AWSLaunchTemplate.SpotMarketOptions.InterruptionBehavior
+		}
+		dst.Spec.AWSLaunchTemplate.CapacityReservationTarget = restored.Spec.AWSLaunchTemplate.CapacityReservationTarget
+		dst.Spec.AWSLaunchTemplate.Tenancy = restored.Spec.AWSLaunchTemplate.Tenancy
+		dst.Spec.AWSLaunchTemplate.HostID = restored.Spec.AWSLaunchTemplate.HostID
+		dst.Spec.AWSLaunchTemplate.HostResourceGroupARN = restored.Spec.AWSLaunchTemplate.HostResourceGroupARN
+		dst.Spec.AWSLaunchTemplate.PlacementGroup = restored.Spec.AWSLaunchTemplate.PlacementGroup
+		dst.Spec.AWSLaunchTemplate.EnclaveOptions = restored.Spec.AWSLaunchTemplate.EnclaveOptions
+		dst.Spec.AWSLaunchTemplate.AMI.Filters = restored.Spec.AWSLaunchTemplate.AMI.Filters
+		dst.Spec.AWSLaunchTemplate.AMI.SSMParameter = restored.Spec.AWSLaunchTemplate.AMI.SSMParameter
+		dst.Spec.AWSLaunchTemplate.AMI.MarketplaceProductCode = restored.Spec.AWSLaunchTemplate.AMI.MarketplaceProductCode
+		dst.Spec.AWSLaunchTemplate.ImageLookupArchitecture = restored.Spec.AWSLaunchTemplate.ImageLookupArchitecture
+		dst.Spec.AWSLaunchTemplate.ImageLookupRootDeviceType = restored.Spec.AWSLaunchTemplate.ImageLookupRootDeviceType
+		dst.Spec.AWSLaunchTemplate.InstanceRequirements = restored.Spec.AWSLaunchTemplate.InstanceRequirements
+		dst.Spec.AWSLaunchTemplate.NonRootVolumes = restored.Spec.AWSLaunchTemplate.NonRootVolumes
+		dst.Spec.AWSLaunchTemplate.AdditionalVolumeTags = restored.Spec.AWSLaunchTemplate.AdditionalVolumeTags
+		dst.Spec.AWSLaunchTemplate.AdditionalNetworkInterfaceTags = restored.Spec.AWSLaunchTemplate.AdditionalNetworkInterfaceTags
+		dst.Spec.AWSLaunchTemplate.PreBootstrapUserData = restored.Spec.AWSLaunchTemplate.PreBootstrapUserData
+		dst.Spec.AWSLaunchTemplate.UncompressedUserData = restored.Spec.AWSLaunchTemplate.UncompressedUserData
+		dst.Spec.AWSLaunchTemplate.VersionsToKeep = restored.Spec.AWSLaunchTemplate.VersionsToKeep
+		if restored.Spec.AWSLaunchTemplate.RootVolume != nil && dst.Spec.AWSLaunchTemplate.RootVolume != nil {
+			dst.Spec.AWSLaunchTemplate.RootVolume.DeleteOnTermination = restored.Spec.AWSLaunchTemplate.RootVolume.DeleteOnTermination
+		}
 	}
 	if restored.Spec.AvailabilityZoneSubnetType != nil {
 		dst.Spec.AvailabilityZoneSubnetType = restored.Spec.AvailabilityZoneSubnetType
 	}
+	dst.Spec.RoleInlinePolicies = restored.Spec.RoleInlinePolicies
+	dst.Status.Updates = restored.Status.Updates
 
 	return nil
 }
@@ -129,6 +199,12 @@ func Convert_v1beta2_AWSManagedMachinePoolSpec_To_v1beta1_AWSManagedMachinePoolS
 	return autoConvert_v1beta2_AWSManagedMachinePoolSpec_To_v1beta1_AWSManagedMachinePoolSpec(in, out, s)
 }
 
+// Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus converts the v1beta2 AWSManagedMachinePoolStatus receiver to a v1beta1 AWSManagedMachinePoolStatus.
+func Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(in *infrav1exp.AWSManagedMachinePoolStatus, out *AWSManagedMachinePoolStatus, s apiconversion.Scope) error {
+	// status.updates has been added to v1beta2.
+	return autoConvert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(in, out, s)
+}
+
 // ConvertTo converts the v1beta1 AWSManagedMachinePoolList receiver to a v1beta2 AWSManagedMachinePoolList.
 func (src *AWSManagedMachinePoolList) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*infrav1exp.AWSManagedMachinePoolList)
@@ -210,6 +286,12 @@ func Convert_v1beta2_AutoScalingGroup_To_v1beta1_AutoScalingGroup(in *infrav1exp
 	return autoConvert_v1beta2_AutoScalingGroup_To_v1beta1_AutoScalingGroup(in, out, s)
 }
 
+// Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus converts the v1beta2 AWSMachinePoolStatus receiver to a v1beta1 AWSMachinePoolStatus.
+func Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in *infrav1exp.AWSMachinePoolStatus, out *AWSMachinePoolStatus, s apiconversion.Scope) error {
+	// status.instanceRefresh has been added to v1beta2.
+	return autoConvert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in, out, s)
+}
+
 // Convert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences converts the v1beta2 RefreshPreferences receiver to a v1beta1 RefreshPreferences.
 func Convert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences(in *infrav1exp.RefreshPreferences, out *RefreshPreferences, s apiconversion.Scope) error {
 	// spec.refreshPreferences.disable has been added to v1beta2.
@@ -35,6 +35,8 @@ import (
 const (
 	maxProfileNameLength = 100
 	maxIAMRoleNameLength = 64
+	maxProfileSelectors  = 5
+	maxSelectorLabels    = 5
 )
 
 // SetupWebhookWithManager will setup the webhooks for the AWSFargateProfile.
@@ -137,6 +139,11 @@ func (r *AWSFargateProfile) ValidateCreate() (admission.Warnings, error) {
 	var allErrs field.ErrorList
 
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.validateSelectors()...)
+
+	if len(r.Spec.RoleName) > maxIAMRoleNameLength {
+		allErrs = append(allErrs, field.TooLong(field.NewPath("spec", "roleName"), r.Spec.RoleName, maxIAMRoleNameLength))
+	}
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -148,6 +155,26 @@ func (r *AWSFargateProfile) ValidateCreate() (admission.Warnings, error) {
 	)
 }
 
+// validateSelectors checks the selectors against the limits enforced by EKS.
+func (r *AWSFargateProfile) validateSelectors() field.ErrorList {
+	var allErrs field.ErrorList
+
+	selectorsPath := field.NewPath("spec", "selectors")
+	if len(r.Spec.Selectors) > maxProfileSelectors {
+		allErrs = append(allErrs, field.TooMany(selectorsPath, len(r.Spec.Selectors), maxProfileSelectors))
+	}
+	for i, selector := range r.Spec.Selectors {
+		if selector.Namespace == "" {
+			allErrs = append(allErrs, field.Required(selectorsPath.Index(i).Child("namespace"), "namespace is required"))
+		}
+		if len(selector.Labels) > maxSelectorLabels {
+			allErrs = append(allErrs, field.TooMany(selectorsPath.Index(i).Child("labels"), len(selector.Labels), maxSelectorLabels))
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
 func (r *AWSFargateProfile) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
@@ -177,6 +177,65 @@ func TestAWSFargateProfileValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "profile with selectors is accepted",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName: "cluster-1",
+					Selectors: []FargateSelector{
+						{Namespace: "default", Labels: map[string]string{"app": "web"}},
+						{Namespace: "kube-system"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "selector without namespace is rejected",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName: "cluster-1",
+					Selectors: []FargateSelector{
+						{Labels: map[string]string{"app": "web"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "too many selectors are rejected",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName: "cluster-1",
+					Selectors: []FargateSelector{
+						{Namespace: "a"}, {Namespace: "b"}, {Namespace: "c"}, {Namespace: "d"}, {Namespace: "e"}, {Namespace: "f"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "selector with too many labels is rejected",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName: "cluster-1",
+					Selectors: []FargateSelector{
+						{Namespace: "default", Labels: map[string]string{"1": "", "2": "", "3": "", "4": "", "5": "", "6": ""}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "too long role name is rejected",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName: "cluster-1",
+					RoleName:    strings.Repeat("a", maxIAMRoleNameLength+1),
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -18,7 +18,10 @@ package v1beta2
 
 import (
 	"reflect"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -31,6 +34,14 @@ import (
 const (
 	// LaunchTemplateLatestVersion defines the launching of the latest version of the template.
 	LaunchTemplateLatestVersion = "$Latest"
+
+	// DefaultLaunchTemplateVersionsToKeep is the default number of the most recent launch template
+	// versions which are kept when a new version is created.
+	DefaultLaunchTemplateVersionsToKeep = 2
+
+	// DefaultTerminationDrainHeartbeatTimeout is the default maximum time an instance terminated by the ASG
+	// waits for its node to be drained.
+	DefaultTerminationDrainHeartbeatTimeout = 10 * time.Minute
 )
 
 // AWSMachinePoolSpec defines the desired state of AWSMachinePool.
@@ -94,13 +105,114 @@ type AWSMachinePoolSpec struct {
 	// +optional
 	RefreshPreferences *RefreshPreferences `json:"refreshPreferences,omitempty"`
 
-	// Enable or disable the capacity rebalance autoscaling group feature
+	// Enable or disable the capacity rebalance autoscaling group feature.
+	// When enabled, the ASG launches a replacement for the Spot instances at an elevated risk of interruption,
+	// before they are interrupted. It has no effect on the groups without Spot instances.
 	// +optional
 	CapacityRebalance bool `json:"capacityRebalance,omitempty"`
 
 	// SuspendProcesses defines a list of processes to suspend for the given ASG. This is constantly reconciled.
 	// If a process is removed from this list it will automatically be resumed.
 	SuspendProcesses *SuspendProcessesTypes `json:"suspendProcesses,omitempty"`
+
+	// ScaleInProtection protects the instances of the ASG from being terminated by scale in. The protection
+	// of an instance is only removed once its node has been cordoned and drained, so that the ASG never
+	// terminates an instance whose node still runs workloads.
+	// +optional
+	ScaleInProtection bool `json:"scaleInProtection,omitempty"`
+
+	// WarmPool configures a pool of pre-initialized instances for the ASG. On scale out, instances are
+	// moved from the warm pool to the group instead of being launched and bootstrapped from scratch,
+	// which reduces the scale-out latency. The warm pool is deleted when the field is removed.
+	// +optional
+	WarmPool *WarmPool `json:"warmPool,omitempty"`
+
+	// MetricsCollection enables the collection of the group metrics of the ASG in CloudWatch, at no
+	// additional charge. The collection of the group metrics is disabled when the field is removed.
+	// +optional
+	MetricsCollection *MetricsCollection `json:"metricsCollection,omitempty"`
+
+	// MaxInstanceLifetime is the maximum amount of time an instance can be in service. The instances
+	// reaching it are replaced by the ASG, which recycles them periodically. It must be between 1 and
+	// 365 days, the instances are not replaced if it is not set.
+	// +optional
+	MaxInstanceLifetime *metav1.Duration `json:"maxInstanceLifetime,omitempty"`
+
+	// TargetGroupARNs are the ARNs of the target groups of network or application load balancers, e.g.
+	// created outside of the cluster, the instances of the ASG are registered with. Only the target groups
+	// attached by CAPA are detached from the ASG when they are removed from the list.
+	// +optional
+	TargetGroupARNs []string `json:"targetGroupARNs,omitempty"`
+
+	// LoadBalancerNames are the names of the classic load balancers the instances of the ASG are registered
+	// with. Only the load balancers attached by CAPA are detached from the ASG when they are removed from
+	// the list.
+	// +optional
+	LoadBalancerNames []string `json:"loadBalancerNames,omitempty"`
+
+	// EC2Fleet, if set, launches the instances of the pool with an EC2 Fleet instead of an ASG, for the
+	// allocation strategies and capacity behaviors ASGs don't support. The features specific to ASGs,
+	// e.g. warm pools, load balancers or instance refreshes, are not available. It cannot be added or removed
+	// once set, and only the On-Demand target capacity of a maintain fleet can be changed.
+	// +optional
+	EC2Fleet *EC2Fleet `json:"ec2Fleet,omitempty"`
+
+	// ExistingASG, if set, adopts a pre-existing ASG instead of creating one. The instances of the ASG
+	// are reflected in the status, the ASG is never recreated. It cannot be changed once set.
+	// +optional
+	ExistingASG *ExistingASG `json:"existingASG,omitempty"`
+
+	// TerminationDrain, if set, adds a termination lifecycle hook to the ASG, so that the instances terminated
+	// by the ASG, e.g. on scale in or by an instance refresh, wait for their node to be cordoned and drained
+	// before being terminated. The lifecycle actions are received through an SQS queue fed by an EventBridge
+	// rule, both managed by CAPA.
+	// +optional
+	TerminationDrain *TerminationDrain `json:"terminationDrain,omitempty"`
+}
+
+// TerminationDrain defines the drain of the nodes of the instances terminated by an ASG.
+type TerminationDrain struct {
+	// HeartbeatTimeout is the maximum time an instance waits for its node to be drained. The instance is
+	// terminated once it elapses, even if the node isn't drained yet. Defaults to 10 minutes.
+	// +optional
+	HeartbeatTimeout *metav1.Duration `json:"heartbeatTimeout,omitempty"`
+}
+
+// GetHeartbeatTimeout returns the heartbeat timeout of the termination lifecycle hook, or its default.
+func (t *TerminationDrain) GetHeartbeatTimeout() time.Duration {
+	if t.HeartbeatTimeout == nil {
+		return DefaultTerminationDrainHeartbeatTimeout
+	}
+	return t.HeartbeatTimeout.Duration
+}
+
+// ExistingASG defines a pre-existing ASG adopted by an AWSMachinePool.
+type ExistingASG struct {
+	// Name is the name or the ARN of the ASG.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// ManageLaunchTemplate, if true, takes over the configuration of the ASG: it is updated to launch its
+	// instances from the launch template of the AWSMachinePool, with the size, subnets and other settings of
+	// the AWSMachinePool. It is only deleted along with the AWSMachinePool if it is tagged as owned by the
+	// cluster. Otherwise the configuration of the ASG is left unchanged, the replicas of the MachinePool follow
+	// the desired capacity of the ASG, and the ASG is kept when the AWSMachinePool is deleted.
+	// +optional
+	ManageLaunchTemplate bool `json:"manageLaunchTemplate,omitempty"`
+
+	// ManageTags, if true, applies the additional tags of the AWSMachinePool to the ASG.
+	// +optional
+	ManageTags bool `json:"manageTags,omitempty"`
+}
+
+// ASGName returns the name of the ASG, extracted from its ARN if needed.
+func (e *ExistingASG) ASGName() string {
+	if parsed, err := arn.Parse(e.Name); err == nil {
+		if _, name, ok := strings.Cut(parsed.Resource, "autoScalingGroupName/"); ok {
+			return name
+		}
+	}
+	return e.Name
 }
 
 // SuspendProcessesTypes contains user friendly auto-completable values for suspended process names.
@@ -172,6 +284,105 @@ type RefreshPreferences struct {
 	// during an instance refresh. The default is 90.
 	// +optional
 	MinHealthyPercentage *int64 `json:"minHealthyPercentage,omitempty"`
+
+	// MaxHealthyPercentage is the amount of capacity as a percentage in ASG that can be in service and
+	// healthy, or pending, during an instance refresh, to replace instances before terminating the previous
+	// ones. It must be between 100 and 200, and at most 100 above MinHealthyPercentage, which must be set too.
+	// The default is to terminate instances before launching their replacements.
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=200
+	// +optional
+	MaxHealthyPercentage *int64 `json:"maxHealthyPercentage,omitempty"`
+
+	// CheckpointPercentages is the list of percentages of replaced instances at which the instance refresh
+	// pauses for CheckpointDelay, in ascending order. To replace all instances, the last value must be 100.
+	// +optional
+	CheckpointPercentages []int64 `json:"checkpointPercentages,omitempty"`
+
+	// CheckpointDelay is the number of seconds to wait after reaching a checkpoint before continuing the
+	// instance refresh. The default is 3600 seconds when CheckpointPercentages is set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CheckpointDelay *int64 `json:"checkpointDelay,omitempty"`
+
+	// SkipMatching, if true, skips replacing the instances that already use the latest launch template version.
+	// +optional
+	SkipMatching *bool `json:"skipMatching,omitempty"`
+
+	// ScaleInProtectedInstances defines how instances protected from scale in are handled by the instance refresh.
+	// Refresh replaces them, Ignore skips them and Wait waits for the protection to be removed, up to one hour.
+	// The default is Ignore.
+	// +kubebuilder:validation:Enum=Refresh;Ignore;Wait
+	// +optional
+	ScaleInProtectedInstances *ScaleInProtectedInstances `json:"scaleInProtectedInstances,omitempty"`
+
+	// RefreshOnBootstrapDataChange, if true, replaces the instances which did not register a node within
+	// the time to live of the bootstrap token, as they can't join the cluster anymore once the token is rotated.
+	// The instances which registered a node are kept when only the bootstrap data changes.
+	// +optional
+	RefreshOnBootstrapDataChange bool `json:"refreshOnBootstrapDataChange,omitempty"`
+}
+
+// ScaleInProtectedInstances defines how an instance refresh handles the instances protected from scale in.
+type ScaleInProtectedInstances string
+
+const (
+	// ScaleInProtectedInstancesRefresh replaces the instances protected from scale in.
+	ScaleInProtectedInstancesRefresh = ScaleInProtectedInstances("Refresh")
+	// ScaleInProtectedInstancesIgnore skips the instances protected from scale in.
+	ScaleInProtectedInstancesIgnore = ScaleInProtectedInstances("Ignore")
+	// ScaleInProtectedInstancesWait waits for the scale-in protection of the instances to be removed.
+	ScaleInProtectedInstancesWait = ScaleInProtectedInstances("Wait")
+)
+
+// InstanceRefreshStatus defines the observed state of the latest instance refresh of an ASG.
+type InstanceRefreshStatus struct {
+	// ID is the ID of the instance refresh.
+	ID string `json:"id"`
+
+	// Status is the status of the instance refresh, for example InProgress or Successful.
+	Status string `json:"status"`
+
+	// StatusReason is the explanation of the status of the instance refresh.
+	// +optional
+	StatusReason string `json:"statusReason,omitempty"`
+
+	// PercentageComplete is the percentage of the instance refresh that is complete.
+	// +optional
+	PercentageComplete *int64 `json:"percentageComplete,omitempty"`
+
+	// InstancesToUpdate is the number of instances remaining to be replaced.
+	// +optional
+	InstancesToUpdate *int64 `json:"instancesToUpdate,omitempty"`
+
+	// StartTime is the time the instance refresh started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the time the instance refresh ended.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// InstantFleetLaunchStatus defines the instances launched with an instant EC2 Fleet which are not listed by EC2 yet.
+type InstantFleetLaunchStatus struct {
+	// InstanceIDs are the IDs of the launched instances which are not listed by EC2 yet.
+	InstanceIDs []string `json:"instanceIDs"`
+
+	// Time is the time the instances were launched.
+	Time metav1.Time `json:"time"`
+}
+
+// IsInProgress returns true if the instance refresh has not reached a final status yet.
+func (s *InstanceRefreshStatus) IsInProgress() bool {
+	if s == nil {
+		return false
+	}
+	switch s.Status {
+	case "Successful", "Failed", "Cancelled", "RollbackSuccessful", "RollbackFailed":
+		return false
+	}
+	return true
 }
 
 // AWSMachinePoolStatus defines the observed state of AWSMachinePool.
@@ -238,6 +449,19 @@ type AWSMachinePoolStatus struct {
 	FailureMessage *string `json:"failureMessage,omitempty"`
 
 	ASGStatus *ASGStatus `json:"asgStatus,omitempty"`
+
+	// InstanceRefresh is the status of the latest instance refresh of the ASG.
+	// +optional
+	InstanceRefresh *InstanceRefreshStatus `json:"instanceRefresh,omitempty"`
+
+	// FleetID is the ID of the EC2 Fleet of type maintain launching the instances of the pool.
+	// +optional
+	FleetID string `json:"fleetID,omitempty"`
+
+	// InstantFleetLaunch is the latest launch of instances with an instant EC2 Fleet, as long as some of its
+	// instances are not listed by EC2 yet.
+	// +optional
+	InstantFleetLaunch *InstantFleetLaunchStatus `json:"instantFleetLaunch,omitempty"`
 }
 
 // AWSMachinePoolInstanceStatus defines the status of the AWSMachinePoolInstance.
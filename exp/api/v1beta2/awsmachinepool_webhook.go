@@ -17,10 +17,14 @@ limitations under the License.
 package v1beta2
 
 import (
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/google/go-cmp/cmp"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -66,14 +70,8 @@ func (r *AWSMachinePool) validateRootVolume() field.ErrorList {
 		allErrs = append(allErrs, field.Required(field.NewPath("spec.awsLaunchTemplate.rootVolume.iops"), "iops required if type is 'io1' or 'io2'"))
 	}
 
-	if r.Spec.AWSLaunchTemplate.RootVolume.Throughput != nil {
-		if r.Spec.AWSLaunchTemplate.RootVolume.Type != v1beta2.VolumeTypeGP3 {
-			allErrs = append(allErrs, field.Required(field.NewPath("spec.awsLaunchTemplate.rootVolume.throughput"), "throughput is valid only for type 'gp3'"))
-		}
-		if *r.Spec.AWSLaunchTemplate.RootVolume.Throughput < 0 {
-			allErrs = append(allErrs, field.Required(field.NewPath("spec.awsLaunchTemplate.rootVolume.throughput"), "throughput must be nonnegative"))
-		}
-	}
+	allErrs = append(allErrs, v1beta2.ValidateVolumePerformance(*r.Spec.AWSLaunchTemplate.RootVolume, field.NewPath("spec", "awsLaunchTemplate", "rootVolume"))...)
+	allErrs = append(allErrs, v1beta2.ValidateVolumeEncryption(*r.Spec.AWSLaunchTemplate.RootVolume, field.NewPath("spec", "awsLaunchTemplate", "rootVolume"))...)
 
 	if r.Spec.AWSLaunchTemplate.RootVolume.DeviceName != "" {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
@@ -82,6 +80,64 @@ func (r *AWSMachinePool) validateRootVolume() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSMachinePool) validateNonRootVolumes() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, volume := range r.Spec.AWSLaunchTemplate.NonRootVolumes {
+		if v1beta2.VolumeTypesProvisioned.Has(string(volume.Type)) && volume.IOPS == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec.awsLaunchTemplate.nonRootVolumes.iops"), "iops required if type is 'io1' or 'io2'"))
+		}
+
+		allErrs = append(allErrs, v1beta2.ValidateVolumePerformance(volume, field.NewPath("spec", "awsLaunchTemplate", "nonRootVolumes"))...)
+		allErrs = append(allErrs, v1beta2.ValidateVolumeEncryption(volume, field.NewPath("spec", "awsLaunchTemplate", "nonRootVolumes"))...)
+
+		if volume.DeviceName == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec.awsLaunchTemplate.nonRootVolumes.deviceName"), "non root volume should have device name"))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateLaunchTemplateTags() field.ErrorList {
+	return validateLaunchTemplateTags(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))
+}
+
+func (r *AWSMachinePool) validatePreBootstrapUserData() field.ErrorList {
+	return validatePreBootstrapUserData(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate", "preBootstrapUserData"))
+}
+
+// validateLaunchTemplateTags validates the tags specific to the volumes and network interfaces of a launch template.
+func validateLaunchTemplateTags(lt *AWSLaunchTemplate, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateResourceTags(lt.AdditionalVolumeTags, fldPath.Child("additionalVolumeTags"))...)
+	allErrs = append(allErrs, validateResourceTags(lt.AdditionalNetworkInterfaceTags, fldPath.Child("additionalNetworkInterfaceTags"))...)
+	return allErrs
+}
+
+// validatePreBootstrapUserData validates that the pre-bootstrap user data of a launch template is a cloud-config,
+// a boothook or a script, which can be merged with the bootstrap data.
+func validatePreBootstrapUserData(lt *AWSLaunchTemplate, fldPath *field.Path) field.ErrorList {
+	if lt.PreBootstrapUserData == "" {
+		return nil
+	}
+	for _, prefix := range []string{"#cloud-config", "#cloud-boothook", "#!"} {
+		if strings.HasPrefix(lt.PreBootstrapUserData, prefix) {
+			return nil
+		}
+	}
+	return field.ErrorList{field.Invalid(fldPath, lt.PreBootstrapUserData, "must start with #cloud-config, #cloud-boothook or #!")}
+}
+
+// validateResourceTags validates the tags, reporting the errors against fldPath rather than spec.additionalTags.
+func validateResourceTags(tags v1beta2.Tags, fldPath *field.Path) field.ErrorList {
+	errs := tags.Validate()
+	for _, err := range errs {
+		err.Field = fldPath.String()
+	}
+	return errs
+}
+
 func (r *AWSMachinePool) validateSubnets() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -113,9 +169,303 @@ func (r *AWSMachinePool) validateSpotInstances() field.ErrorList {
 	if r.Spec.AWSLaunchTemplate.SpotMarketOptions != nil && r.Spec.MixedInstancesPolicy != nil {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec.awsLaunchTemplate.spotMarketOptions"), "either spec.awsLaunchTemplate.spotMarketOptions or spec.mixedInstancesPolicy should be used"))
 	}
+	if spot := r.Spec.AWSLaunchTemplate.SpotMarketOptions; spot != nil && spot.IsPersistent() {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec.awsLaunchTemplate.spotMarketOptions.interruptionBehavior"), spot.InterruptionBehavior, "only terminate is supported by auto scaling groups"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateMixedInstancesPolicy() field.ErrorList {
+	var allErrs field.ErrorList
+	policy := r.Spec.MixedInstancesPolicy
+	if policy == nil {
+		return allErrs
+	}
+	fldPath := field.NewPath("spec", "mixedInstancesPolicy", "overrides")
+	// The instance types matching the instance requirements of the launch template replace the overrides.
+	if r.Spec.AWSLaunchTemplate.InstanceRequirements != nil && len(policy.Overrides) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "instance type overrides cannot be used with spec.awsLaunchTemplate.instanceRequirements"))
+	}
+	instanceTypes := make(map[string]bool, len(policy.Overrides))
+	for i, override := range policy.Overrides {
+		switch {
+		case override.InstanceType == "":
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("instanceType"), "instance type must be set"))
+		case instanceTypes[override.InstanceType]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("instanceType"), override.InstanceType))
+		}
+		instanceTypes[override.InstanceType] = true
+	}
+	if distribution := policy.InstancesDistribution; distribution != nil {
+		fldPath := field.NewPath("spec", "mixedInstancesPolicy", "instancesDistribution")
+		if base := distribution.OnDemandBaseCapacity; base != nil && *base < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("onDemandBaseCapacity"), *base, "must be greater than or equal to 0"))
+		}
+		if percentage := distribution.OnDemandPercentageAboveBaseCapacity; percentage != nil && (*percentage < 0 || *percentage > 100) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("onDemandPercentageAboveBaseCapacity"), *percentage, "must be between 0 and 100"))
+		}
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateInstanceRequirements() field.ErrorList {
+	var allErrs field.ErrorList
+	requirements := r.Spec.AWSLaunchTemplate.InstanceRequirements
+	if requirements == nil {
+		return allErrs
+	}
+	fldPath := field.NewPath("spec", "awsLaunchTemplate", "instanceRequirements")
+	if r.Spec.AWSLaunchTemplate.InstanceType != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be used with spec.awsLaunchTemplate.instanceType"))
+	}
+	allErrs = append(allErrs, validateInstanceRequirementsRange(requirements.VCPUCount, fldPath.Child("vCPUCount"))...)
+	allErrs = append(allErrs, validateInstanceRequirementsRange(requirements.MemoryMiB, fldPath.Child("memoryMiB"))...)
+	if requirements.AcceleratorCount != nil {
+		allErrs = append(allErrs, validateInstanceRequirementsRange(*requirements.AcceleratorCount, fldPath.Child("acceleratorCount"))...)
+	}
+	// The instances share the AMI, so the CPUs of the instance types must have the same architecture.
+	if manufacturers := sets.New[string](requirements.CPUManufacturers...); manufacturers.Has("amazon-web-services") && manufacturers.Len() > 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cpuManufacturers"), requirements.CPUManufacturers, "amazon-web-services cannot be combined with other manufacturers, as their CPU architectures differ"))
+	}
+	return allErrs
+}
+
+func validateInstanceRequirementsRange(r InstanceRequirementsRange, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("max"), *r.Max, "must be greater than or equal to min"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateRefreshPreferences() field.ErrorList {
+	var allErrs field.ErrorList
+	prefs := r.Spec.RefreshPreferences
+	if prefs == nil {
+		return allErrs
+	}
+	fldPath := field.NewPath("spec", "refreshPreferences")
+	if prefs.MinHealthyPercentage != nil && (*prefs.MinHealthyPercentage < 0 || *prefs.MinHealthyPercentage > 100) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minHealthyPercentage"), *prefs.MinHealthyPercentage, "must be between 0 and 100"))
+	}
+	if prefs.MaxHealthyPercentage != nil {
+		// Auto Scaling rejects a max healthy percentage without a min healthy percentage.
+		switch {
+		case prefs.MinHealthyPercentage == nil:
+			allErrs = append(allErrs, field.Required(fldPath.Child("minHealthyPercentage"), "must be set with spec.refreshPreferences.maxHealthyPercentage"))
+		case *prefs.MaxHealthyPercentage-*prefs.MinHealthyPercentage > 100:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxHealthyPercentage"), *prefs.MaxHealthyPercentage, "must be at most 100 above spec.refreshPreferences.minHealthyPercentage"))
+		}
+	}
+	for i, percentage := range prefs.CheckpointPercentages {
+		if percentage < 1 || percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("checkpointPercentages").Index(i), percentage, "must be between 1 and 100"))
+		} else if i > 0 && percentage <= prefs.CheckpointPercentages[i-1] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("checkpointPercentages").Index(i), percentage, "must be in ascending order"))
+		}
+	}
+	if prefs.CheckpointDelay != nil && len(prefs.CheckpointPercentages) == 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("checkpointDelay"), "cannot be set without spec.refreshPreferences.checkpointPercentages"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateWarmPool() field.ErrorList {
+	var allErrs field.ErrorList
+	warmPool := r.Spec.WarmPool
+	if warmPool == nil {
+		return allErrs
+	}
+	if r.Spec.MixedInstancesPolicy != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "warmPool"), "warm pools cannot be used with spec.mixedInstancesPolicy"))
+	}
+	if r.Spec.AWSLaunchTemplate.SpotMarketOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "warmPool"), "warm pools cannot be used with spec.awsLaunchTemplate.spotMarketOptions"))
+	}
+	if warmPool.MaxGroupPreparedCapacity != nil && *warmPool.MaxGroupPreparedCapacity < warmPool.MinSize {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "warmPool", "maxGroupPreparedCapacity"), *warmPool.MaxGroupPreparedCapacity, "must be greater than or equal to spec.warmPool.minSize"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateMaxInstanceLifetime() field.ErrorList {
+	var allErrs field.ErrorList
+	lifetime := r.Spec.MaxInstanceLifetime
+	if lifetime == nil {
+		return allErrs
+	}
+	if lifetime.Duration < 24*time.Hour || lifetime.Duration > 365*24*time.Hour {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "maxInstanceLifetime"), lifetime.Duration.String(), "must be between 1 and 365 days"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateTerminationDrain() field.ErrorList {
+	var allErrs field.ErrorList
+	drain := r.Spec.TerminationDrain
+	if drain == nil || drain.HeartbeatTimeout == nil {
+		return allErrs
+	}
+	if timeout := drain.HeartbeatTimeout.Duration; timeout < 30*time.Second || timeout > 2*time.Hour {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "terminationDrain", "heartbeatTimeout"), timeout.String(), "must be between 30 seconds and 2 hours"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateLoadBalancers() field.ErrorList {
+	var allErrs field.ErrorList
+
+	targetGroupARNs := make(map[string]bool, len(r.Spec.TargetGroupARNs))
+	for i, targetGroupARN := range r.Spec.TargetGroupARNs {
+		fldPath := field.NewPath("spec", "targetGroupARNs").Index(i)
+		parsed, err := arn.Parse(targetGroupARN)
+		if err != nil || parsed.Service != "elasticloadbalancing" || !strings.HasPrefix(parsed.Resource, "targetgroup/") {
+			allErrs = append(allErrs, field.Invalid(fldPath, targetGroupARN, "must be the ARN of a target group"))
+			continue
+		}
+		if targetGroupARNs[targetGroupARN] {
+			allErrs = append(allErrs, field.Duplicate(fldPath, targetGroupARN))
+		}
+		targetGroupARNs[targetGroupARN] = true
+	}
+
+	loadBalancerNames := make(map[string]bool, len(r.Spec.LoadBalancerNames))
+	for i, name := range r.Spec.LoadBalancerNames {
+		fldPath := field.NewPath("spec", "loadBalancerNames").Index(i)
+		if name == "" {
+			allErrs = append(allErrs, field.Required(fldPath, "load balancer name must not be empty"))
+			continue
+		}
+		if loadBalancerNames[name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath, name))
+		}
+		loadBalancerNames[name] = true
+	}
+
 	return allErrs
 }
 
+// asgSettings returns the sorted names of the ASG settings set on the AWSMachinePool.
+func (r *AWSMachinePool) asgSettings() []string {
+	settings := map[string]bool{
+		"mixedInstancesPolicy": r.Spec.MixedInstancesPolicy != nil,
+		"warmPool":             r.Spec.WarmPool != nil,
+		"targetGroupARNs":      len(r.Spec.TargetGroupARNs) > 0,
+		"loadBalancerNames":    len(r.Spec.LoadBalancerNames) > 0,
+		"maxInstanceLifetime":  r.Spec.MaxInstanceLifetime != nil,
+		"metricsCollection":    r.Spec.MetricsCollection != nil,
+		"scaleInProtection":    r.Spec.ScaleInProtection,
+		"capacityRebalance":    r.Spec.CapacityRebalance,
+		"suspendProcesses":     r.Spec.SuspendProcesses != nil,
+		"terminationDrain":     r.Spec.TerminationDrain != nil,
+	}
+	var names []string
+	for _, name := range sets.List(sets.KeySet(settings)) {
+		if settings[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (r *AWSMachinePool) validateEC2Fleet() field.ErrorList {
+	var allErrs field.ErrorList
+	fleet := r.Spec.EC2Fleet
+	if fleet == nil {
+		return allErrs
+	}
+	fldPath := field.NewPath("spec", "ec2Fleet")
+
+	for _, name := range r.asgSettings() {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be used with spec."+name))
+	}
+	if r.Spec.AWSLaunchTemplate.SpotMarketOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be used with spec.awsLaunchTemplate.spotMarketOptions, use spec.ec2Fleet.defaultTargetCapacityType instead"))
+	}
+	if r.Spec.AWSLaunchTemplate.InstanceRequirements != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be used with spec.awsLaunchTemplate.instanceRequirements"))
+	}
+
+	if fleet.GetType() != EC2FleetTypeInstant {
+		if fleet.SingleInstanceType {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("singleInstanceType"), "only supported by instant fleets"))
+		}
+		if fleet.SingleAvailabilityZone {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("singleAvailabilityZone"), "only supported by instant fleets"))
+		}
+		if fleet.MinTargetCapacity != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("minTargetCapacity"), "only supported by instant fleets"))
+		}
+	}
+
+	instanceTypes := make(map[string]bool, len(fleet.Overrides))
+	for i, override := range fleet.Overrides {
+		if instanceTypes[override.InstanceType] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("overrides").Index(i).Child("instanceType"), override.InstanceType))
+		}
+		instanceTypes[override.InstanceType] = true
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateEC2FleetUpdate(old *AWSMachinePool) field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "ec2Fleet")
+	switch {
+	case (old.Spec.EC2Fleet == nil) != (r.Spec.EC2Fleet == nil):
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be added or removed, the pool cannot switch between an ASG and an EC2 Fleet"))
+	case r.Spec.EC2Fleet == nil:
+	case old.Spec.EC2Fleet.GetType() != r.Spec.EC2Fleet.GetType():
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), r.Spec.EC2Fleet.Type, "field is immutable"))
+	case r.Spec.EC2Fleet.GetType() == EC2FleetTypeMaintain:
+		// Only the target capacity of an existing maintain fleet is updated.
+		oldFleet, newFleet := old.Spec.EC2Fleet.DeepCopy(), r.Spec.EC2Fleet.DeepCopy()
+		oldFleet.Type, newFleet.Type = EC2FleetTypeMaintain, EC2FleetTypeMaintain
+		oldFleet.OnDemandTargetCapacity, newFleet.OnDemandTargetCapacity = nil, nil
+		if !cmp.Equal(oldFleet, newFleet) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "only onDemandTargetCapacity can be changed on a maintain fleet"))
+		}
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateExistingASG() field.ErrorList {
+	var allErrs field.ErrorList
+	existingASG := r.Spec.ExistingASG
+	if existingASG == nil {
+		return allErrs
+	}
+	fldPath := field.NewPath("spec", "existingASG")
+
+	if strings.HasPrefix(existingASG.Name, "arn:") {
+		parsed, err := arn.Parse(existingASG.Name)
+		if err != nil || parsed.Service != "autoscaling" || !strings.Contains(parsed.Resource, "autoScalingGroupName/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), existingASG.Name, "must be the name or the ARN of an ASG"))
+		}
+	}
+	if r.Spec.EC2Fleet != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be used with spec.ec2Fleet"))
+	}
+	// The configuration of the ASG is left unchanged unless the launch template is managed.
+	if !existingASG.ManageLaunchTemplate {
+		for _, name := range r.asgSettings() {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("manageLaunchTemplate"), "must be true to use spec."+name))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateCapacityReservationTarget() field.ErrorList {
+	return v1beta2.ValidateCapacityReservationTarget(r.Spec.AWSLaunchTemplate.CapacityReservationTarget, r.Spec.AWSLaunchTemplate.SpotMarketOptions, field.NewPath("spec", "awsLaunchTemplate", "capacityReservationTarget"))
+}
+
+func (r *AWSMachinePool) validateHostPlacement() field.ErrorList {
+	lt := r.Spec.AWSLaunchTemplate
+	return v1beta2.ValidateHostPlacement(lt.Tenancy, lt.HostID, lt.HostResourceGroupARN, field.NewPath("spec", "awsLaunchTemplate"))
+}
+
 // ValidateCreate will do any extra validation when creating a AWSMachinePool.
 func (r *AWSMachinePool) ValidateCreate() (admission.Warnings, error) {
 	log.Info("AWSMachinePool validate create", "machine-pool", klog.KObj(r))
@@ -124,10 +474,27 @@ func (r *AWSMachinePool) ValidateCreate() (admission.Warnings, error) {
 
 	allErrs = append(allErrs, r.validateDefaultCoolDown()...)
 	allErrs = append(allErrs, r.validateRootVolume()...)
+	allErrs = append(allErrs, r.validateNonRootVolumes()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.validateLaunchTemplateTags()...)
+	allErrs = append(allErrs, r.validatePreBootstrapUserData()...)
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, r.validateSpotInstances()...)
+	allErrs = append(allErrs, r.validateMixedInstancesPolicy()...)
+	allErrs = append(allErrs, r.validateInstanceRequirements()...)
+	allErrs = append(allErrs, r.validateWarmPool()...)
+	allErrs = append(allErrs, r.validateRefreshPreferences()...)
+	allErrs = append(allErrs, r.validateMaxInstanceLifetime()...)
+	allErrs = append(allErrs, r.validateLoadBalancers()...)
+	allErrs = append(allErrs, r.validateTerminationDrain()...)
+	allErrs = append(allErrs, r.validateEC2Fleet()...)
+	allErrs = append(allErrs, r.validateExistingASG()...)
+	allErrs = append(allErrs, r.validateCapacityReservationTarget()...)
+	allErrs = append(allErrs, r.validateHostPlacement()...)
+	allErrs = append(allErrs, v1beta2.ValidatePlacementGroup(r.Spec.AWSLaunchTemplate.PlacementGroup, field.NewPath("spec", "awsLaunchTemplate", "placementGroup"))...)
+	allErrs = append(allErrs, v1beta2.ValidateAMIReference(r.Spec.AWSLaunchTemplate.AMI, field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
+	allErrs = append(allErrs, v1beta2.ValidateImageLookupArchitecture(r.Spec.AWSLaunchTemplate.InstanceType, r.Spec.AWSLaunchTemplate.ImageLookupArchitecture, field.NewPath("spec", "awsLaunchTemplate", "imageLookupArchitecture"))...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -141,14 +508,38 @@ func (r *AWSMachinePool) ValidateCreate() (admission.Warnings, error) {
 }
 
 // ValidateUpdate will do any extra validation when updating a AWSMachinePool.
-func (r *AWSMachinePool) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
+func (r *AWSMachinePool) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	var allErrs field.ErrorList
 
+	if oldPool, ok := old.(*AWSMachinePool); ok {
+		allErrs = append(allErrs, r.validateEC2FleetUpdate(oldPool)...)
+		if !cmp.Equal(oldPool.Spec.ExistingASG, r.Spec.ExistingASG) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "existingASG"), "field is immutable"))
+		}
+	}
+
 	allErrs = append(allErrs, r.validateDefaultCoolDown()...)
+	allErrs = append(allErrs, r.validateNonRootVolumes()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.validateLaunchTemplateTags()...)
+	allErrs = append(allErrs, r.validatePreBootstrapUserData()...)
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, r.validateSpotInstances()...)
+	allErrs = append(allErrs, r.validateMixedInstancesPolicy()...)
+	allErrs = append(allErrs, r.validateInstanceRequirements()...)
+	allErrs = append(allErrs, r.validateWarmPool()...)
+	allErrs = append(allErrs, r.validateRefreshPreferences()...)
+	allErrs = append(allErrs, r.validateMaxInstanceLifetime()...)
+	allErrs = append(allErrs, r.validateLoadBalancers()...)
+	allErrs = append(allErrs, r.validateTerminationDrain()...)
+	allErrs = append(allErrs, r.validateEC2Fleet()...)
+	allErrs = append(allErrs, r.validateExistingASG()...)
+	allErrs = append(allErrs, r.validateCapacityReservationTarget()...)
+	allErrs = append(allErrs, r.validateHostPlacement()...)
+	allErrs = append(allErrs, v1beta2.ValidatePlacementGroup(r.Spec.AWSLaunchTemplate.PlacementGroup, field.NewPath("spec", "awsLaunchTemplate", "placementGroup"))...)
+	allErrs = append(allErrs, v1beta2.ValidateAMIReference(r.Spec.AWSLaunchTemplate.AMI, field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
+	allErrs = append(allErrs, v1beta2.ValidateImageLookupArchitecture(r.Spec.AWSLaunchTemplate.InstanceType, r.Spec.AWSLaunchTemplate.ImageLookupArchitecture, field.NewPath("spec", "awsLaunchTemplate", "imageLookupArchitecture"))...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -177,4 +568,13 @@ func (r *AWSMachinePool) Default() {
 		log.Info("DefaultInstanceWarmup is zero, setting 300 seconds as default")
 		r.Spec.DefaultInstanceWarmup.Duration = 300 * time.Second
 	}
+
+	// The instance metadata options are only defaulted on creation, defaulting them on existing pools
+	// would create a new launch template version and replace their instances.
+	if r.CreationTimestamp.IsZero() {
+		if r.Spec.AWSLaunchTemplate.InstanceMetadataOptions == nil {
+			r.Spec.AWSLaunchTemplate.InstanceMetadataOptions = &v1beta2.InstanceMetadataOptions{}
+		}
+		r.Spec.AWSLaunchTemplate.InstanceMetadataOptions.SetDefaults()
+	}
 }
@@ -19,6 +19,7 @@ package v1beta2
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	. "github.com/onsi/gomega"
@@ -35,6 +36,14 @@ func TestAWSMachinePoolDefault(t *testing.T) {
 	m.Default()
 	g := NewWithT(t)
 	g.Expect(m.Spec.DefaultCoolDown.Duration).To(BeNumerically(">=", 0))
+	g.Expect(m.Spec.AWSLaunchTemplate.InstanceMetadataOptions.HTTPTokens).To(Equal(infrav1.HTTPTokensStateRequired))
+
+	t.Run("does not default the instance metadata options of existing pools", func(t *testing.T) {
+		g := NewWithT(t)
+		existing := &AWSMachinePool{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", CreationTimestamp: metav1.Now()}}
+		existing.Default()
+		g.Expect(existing.Spec.AWSLaunchTemplate.InstanceMetadataOptions).To(BeNil())
+	})
 }
 
 func TestAWSMachinePoolValidateCreate(t *testing.T) {
@@ -46,61 +55,716 @@ func TestAWSMachinePoolValidateCreate(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "pool with valid tags is accepted",
+			name: "pool with valid tags is accepted",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AdditionalTags: infrav1.Tags{
+						"key-1": "value-1",
+						"key-2": "value-2",
+					},
+				},
+			},
+
+			wantErr: false,
+		},
+		{
+			name: "invalid tags are rejected",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AdditionalTags: infrav1.Tags{
+						"key-1":                    "value-1",
+						"":                         "value-2",
+						strings.Repeat("CAPI", 33): "value-3",
+						"key-4":                    strings.Repeat("CAPI", 65),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pool with valid volume and network interface tags is accepted",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						AdditionalVolumeTags:           infrav1.Tags{"cost-center": "storage"},
+						AdditionalNetworkInterfaceTags: infrav1.Tags{"cost-center": "network"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid volume tags are rejected",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						AdditionalVolumeTags: infrav1.Tags{"aws:cost-center": "storage"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid network interface tags are rejected",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						AdditionalNetworkInterfaceTags: infrav1.Tags{"cost-center": strings.Repeat("CAPI", 65)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if additional security groups are provided with both ID and Filters",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{AdditionalSecurityGroups: []infrav1.AWSResourceReference{{
+						ID: aws.String("sg-1"),
+						Filters: []infrav1.Filter{
+							{
+								Name:   "sg-1",
+								Values: []string{"test"},
+							},
+						},
+					}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if both AMI ID and SSM parameter are passed in AWSLaunchTemplate",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						AMI: infrav1.AMIReference{
+							ID:           ptr.To[string]("ami-1234567890"),
+							SSMParameter: ptr.To[string]("/aws/service/eks/optimized-ami/1.29/amazon-linux-2/recommended/image_id"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if only an AMI SSM parameter is passed in AWSLaunchTemplate",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						AMI: infrav1.AMIReference{
+							SSMParameter: ptr.To[string]("/aws/service/eks/optimized-ami/1.29/amazon-linux-2/recommended/image_id"),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if both subnet ID and filters passed in AWSMachinePool spec",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AdditionalTags: infrav1.Tags{
+						"key-1": "value-1",
+						"key-2": "value-2",
+					},
+					Subnets: []infrav1.AWSResourceReference{
+						{
+							ID:      ptr.To[string]("subnet-id"),
+							Filters: []infrav1.Filter{{Name: "filter_name", Values: []string{"filter_value"}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if either subnet ID or filters passed in AWSMachinePool spec",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AdditionalTags: infrav1.Tags{
+						"key-1": "value-1",
+						"key-2": "value-2",
+					},
+					Subnets: []infrav1.AWSResourceReference{
+						{
+							ID: ptr.To[string]("subnet-id"),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ensure root volume with device name works (for clusterctl move)",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						RootVolume: &infrav1.Volume{
+							DeviceName: "name",
+							Type:       "gp2",
+							Size:       *aws.Int64(8),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should pass if the gp3 root volume throughput and IOPS are within the limits",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						RootVolume: &infrav1.Volume{
+							Size:       100,
+							Type:       infrav1.VolumeTypeGP3,
+							IOPS:       6000,
+							Throughput: aws.Int64(500),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the gp3 root volume throughput exceeds the limits",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						RootVolume: &infrav1.Volume{
+							Size:       100,
+							Type:       infrav1.VolumeTypeGP3,
+							IOPS:       16000,
+							Throughput: aws.Int64(2500),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the gp3 root volume IOPS is below the baseline",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						RootVolume: &infrav1.Volume{
+							Size: 100,
+							Type: infrav1.VolumeTypeGP3,
+							IOPS: 1000,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if non root volumes have a device name",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NonRootVolumes: []infrav1.Volume{
+							{
+								DeviceName:          "/dev/sdb",
+								Size:                100,
+								Type:                infrav1.VolumeTypeGP3,
+								DeleteOnTermination: ptr.To[bool](false),
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a non root volume has no device name",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NonRootVolumes: []infrav1.Volume{
+							{
+								Size: 100,
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a provisioned IOPS non root volume has no IOPS",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NonRootVolumes: []infrav1.Volume{
+							{
+								DeviceName: "/dev/sdb",
+								Size:       100,
+								Type:       infrav1.VolumeTypeIO2,
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if the volumes are encrypted with customer managed KMS keys",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						RootVolume: &infrav1.Volume{
+							Size:          100,
+							EncryptionKey: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+						},
+						NonRootVolumes: []infrav1.Volume{
+							{
+								DeviceName:    "/dev/sdb",
+								Size:          100,
+								EncryptionKey: "alias/containerd",
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a volume KMS key is neither a key nor an alias",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NonRootVolumes: []infrav1.Volume{
+							{
+								DeviceName:    "/dev/sdb",
+								Size:          100,
+								EncryptionKey: "arn:aws:kms:us-east-1:123456789012:key/containerd",
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if both spot market options or mixed instances policy are set",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "t3.medium"}},
+					},
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						SpotMarketOptions: &infrav1.SpotMarketOptions{MaxPrice: aws.String("0.1")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if a mixed instances policy has several instance types",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						InstancesDistribution: &InstancesDistribution{
+							OnDemandAllocationStrategy: OnDemandAllocationStrategyPrioritized,
+							SpotAllocationStrategy:     SpotAllocationStrategyPriceCapacityOptimized,
+						},
+						Overrides: []Overrides{{InstanceType: "m5.large"}, {InstanceType: "m5a.large"}, {InstanceType: "m6i.large"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should pass if a mixed instances policy has no instance type overrides",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						InstancesDistribution: &InstancesDistribution{
+							SpotAllocationStrategy: SpotAllocationStrategyPriceCapacityOptimized,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a mixed instances policy has an empty instance type",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "m5.large"}, {}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a mixed instances policy has duplicate instance types",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "m5.large"}, {InstanceType: "m5.large"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if a mixed instances policy keeps an On-Demand base capacity",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						InstancesDistribution: &InstancesDistribution{
+							OnDemandBaseCapacity:                aws.Int64(2),
+							OnDemandPercentageAboveBaseCapacity: aws.Int64(0),
+						},
+						Overrides: []Overrides{{InstanceType: "m5.large"}, {InstanceType: "m5a.large"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the On-Demand base capacity is negative",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						InstancesDistribution: &InstancesDistribution{
+							OnDemandBaseCapacity: aws.Int64(-1),
+						},
+						Overrides: []Overrides{{InstanceType: "m5.large"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the On-Demand percentage above base capacity is over 100",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						InstancesDistribution: &InstancesDistribution{
+							OnDemandPercentageAboveBaseCapacity: aws.Int64(150),
+						},
+						Overrides: []Overrides{{InstanceType: "m5.large"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if the max instance lifetime is a week",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MaxInstanceLifetime: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the max instance lifetime is less than a day",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MaxInstanceLifetime: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the max instance lifetime is more than a year",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MaxInstanceLifetime: &metav1.Duration{Duration: 400 * 24 * time.Hour},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if the termination drain uses the default heartbeat timeout",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TerminationDrain: &TerminationDrain{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the termination drain heartbeat timeout is more than 2 hours",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TerminationDrain: &TerminationDrain{HeartbeatTimeout: &metav1.Duration{Duration: 3 * time.Hour}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the termination drain is used with an EC2 Fleet",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TerminationDrain: &TerminationDrain{},
+					EC2Fleet:         &EC2Fleet{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if target groups and classic load balancers are set",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TargetGroupARNs:   []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/ingress/73e2d6bc24d8a067"},
+					LoadBalancerNames: []string{"ingress"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a target group ARN is not the ARN of a target group",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TargetGroupARNs: []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/ingress/50dc6c495c0c9188"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a target group ARN is duplicated",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TargetGroupARNs: []string{
+						"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/ingress/73e2d6bc24d8a067",
+						"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/ingress/73e2d6bc24d8a067",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a classic load balancer name is empty",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LoadBalancerNames: []string{""},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if spot instances stop on interruption",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						SpotMarketOptions: &infrav1.SpotMarketOptions{InterruptionBehavior: infrav1.InstanceInterruptionBehaviorStop},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if spot instances terminate on interruption",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						SpotMarketOptions: &infrav1.SpotMarketOptions{InterruptionBehavior: infrav1.InstanceInterruptionBehaviorTerminate},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should pass if instance refresh preferences are valid",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						MinHealthyPercentage:      aws.Int64(90),
+						MaxHealthyPercentage:      aws.Int64(110),
+						CheckpointPercentages:     []int64{20, 50, 100},
+						CheckpointDelay:           aws.Int64(600),
+						SkipMatching:              aws.Bool(true),
+						ScaleInProtectedInstances: ptr.To(ScaleInProtectedInstancesWait),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the max healthy percentage is more than 100 above the min healthy percentage",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						MinHealthyPercentage: aws.Int64(50),
+						MaxHealthyPercentage: aws.Int64(200),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the max healthy percentage is set without the min healthy percentage",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						MaxHealthyPercentage: aws.Int64(110),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the min healthy percentage is above 100",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						MinHealthyPercentage: aws.Int64(110),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the checkpoint percentages are not in ascending order",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						CheckpointPercentages: []int64{50, 20, 100},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a checkpoint delay is set without checkpoints",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						CheckpointDelay: aws.Int64(600),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if a warm pool is specified",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					WarmPool: &WarmPool{
+						MinSize:                  1,
+						MaxGroupPreparedCapacity: aws.Int64(5),
+						PoolState:                WarmPoolStateStopped,
+						InstanceReusePolicy:      &WarmPoolInstanceReusePolicy{ReuseOnScaleIn: true},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the warm pool max group prepared capacity is less than its min size",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					WarmPool: &WarmPool{
+						MinSize:                  3,
+						MaxGroupPreparedCapacity: aws.Int64(2),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a warm pool is used with spot instances",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						SpotMarketOptions: &infrav1.SpotMarketOptions{MaxPrice: aws.String("0.1")},
+					},
+					WarmPool: &WarmPool{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a warm pool is used with a mixed instances policy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "m5.large"}},
+					},
+					WarmPool: &WarmPool{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if a partition placement group is specified",
 			pool: &AWSMachinePool{
 				Spec: AWSMachinePoolSpec{
-					AdditionalTags: infrav1.Tags{
-						"key-1": "value-1",
-						"key-2": "value-2",
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PlacementGroup: &infrav1.PlacementGroup{
+							Name:           "hpc",
+							Strategy:       infrav1.PlacementGroupStrategyPartition,
+							PartitionCount: 3,
+							Partition:      1,
+						},
 					},
 				},
 			},
-
 			wantErr: false,
 		},
 		{
-			name: "invalid tags are rejected",
+			name: "Should fail if the placement group partition is greater than the partition count",
 			pool: &AWSMachinePool{
 				Spec: AWSMachinePoolSpec{
-					AdditionalTags: infrav1.Tags{
-						"key-1":                    "value-1",
-						"":                         "value-2",
-						strings.Repeat("CAPI", 33): "value-3",
-						"key-4":                    strings.Repeat("CAPI", 65),
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PlacementGroup: &infrav1.PlacementGroup{
+							Name:           "hpc",
+							Strategy:       infrav1.PlacementGroupStrategyPartition,
+							PartitionCount: 2,
+							Partition:      3,
+						},
 					},
 				},
 			},
 			wantErr: true,
 		},
 		{
-			name: "Should fail if additional security groups are provided with both ID and Filters",
+			name: "Should pass if a Dedicated Host is specified with the host tenancy",
 			pool: &AWSMachinePool{
 				Spec: AWSMachinePoolSpec{
-					AWSLaunchTemplate: AWSLaunchTemplate{AdditionalSecurityGroups: []infrav1.AWSResourceReference{{
-						ID: aws.String("sg-1"),
-						Filters: []infrav1.Filter{
-							{
-								Name:   "sg-1",
-								Values: []string{"test"},
-							},
-						},
-					}}},
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						Tenancy: "host",
+						HostID:  aws.String("h-0123456789abcdef0"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a host resource group is specified without the host tenancy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						Tenancy:              "dedicated",
+						HostResourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/my-hosts"),
+					},
 				},
 			},
 			wantErr: true,
 		},
 		{
-			name: "Should fail if both subnet ID and filters passed in AWSMachinePool spec",
+			name: "Should pass if a Capacity Reservation ID is specified",
 			pool: &AWSMachinePool{
 				Spec: AWSMachinePoolSpec{
-					AdditionalTags: infrav1.Tags{
-						"key-1": "value-1",
-						"key-2": "value-2",
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						CapacityReservationTarget: &infrav1.CapacityReservationTarget{ID: aws.String("cr-0123456789abcdef0")},
 					},
-					Subnets: []infrav1.AWSResourceReference{
-						{
-							ID:      ptr.To[string]("subnet-id"),
-							Filters: []infrav1.Filter{{Name: "filter_name", Values: []string{"filter_value"}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if both a Capacity Reservation ID and resource group are specified",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						CapacityReservationTarget: &infrav1.CapacityReservationTarget{
+							ID:               aws.String("cr-0123456789abcdef0"),
+							ResourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/my-reservations"),
 						},
 					},
 				},
@@ -108,16 +772,32 @@ func TestAWSMachinePoolValidateCreate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "Should pass if either subnet ID or filters passed in AWSMachinePool spec",
+			name: "Should fail if a Capacity Reservation is targeted by spot instances",
 			pool: &AWSMachinePool{
 				Spec: AWSMachinePoolSpec{
-					AdditionalTags: infrav1.Tags{
-						"key-1": "value-1",
-						"key-2": "value-2",
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						SpotMarketOptions:         &infrav1.SpotMarketOptions{},
+						CapacityReservationTarget: &infrav1.CapacityReservationTarget{ID: aws.String("cr-0123456789abcdef0")},
 					},
-					Subnets: []infrav1.AWSResourceReference{
-						{
-							ID: ptr.To[string]("subnet-id"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if instance requirements are used with a mixed instances policy without overrides",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceRequirements: &InstanceRequirements{
+							VCPUCount:        InstanceRequirementsRange{Min: aws.Int64(2), Max: aws.Int64(8)},
+							MemoryMiB:        InstanceRequirementsRange{Min: aws.Int64(4096)},
+							AcceleratorCount: &InstanceRequirementsRange{Max: aws.Int64(0)},
+							CPUManufacturers: []string{"intel", "amd"},
+						},
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						InstancesDistribution: &InstancesDistribution{
+							OnDemandPercentageAboveBaseCapacity: aws.Int64(0),
 						},
 					},
 				},
@@ -125,34 +805,174 @@ func TestAWSMachinePoolValidateCreate(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "Ensure root volume with device name works (for clusterctl move)",
+			name: "Should fail if instance requirements are used with an instance type",
 			pool: &AWSMachinePool{
 				Spec: AWSMachinePoolSpec{
 					AWSLaunchTemplate: AWSLaunchTemplate{
-						RootVolume: &infrav1.Volume{
-							DeviceName: "name",
-							Type:       "gp2",
-							Size:       *aws.Int64(8),
+						InstanceType: "m5.large",
+						InstanceRequirements: &InstanceRequirements{
+							VCPUCount: InstanceRequirementsRange{Min: aws.Int64(2)},
+							MemoryMiB: InstanceRequirementsRange{Min: aws.Int64(4096)},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if instance requirements are used with instance type overrides",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceRequirements: &InstanceRequirements{
+							VCPUCount: InstanceRequirementsRange{Min: aws.Int64(2)},
+							MemoryMiB: InstanceRequirementsRange{Min: aws.Int64(4096)},
+						},
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "m5.large"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the minimum of an instance requirements range is greater than the maximum",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceRequirements: &InstanceRequirements{
+							VCPUCount: InstanceRequirementsRange{Min: aws.Int64(8), Max: aws.Int64(2)},
+							MemoryMiB: InstanceRequirementsRange{Min: aws.Int64(4096)},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if Graviton CPUs are combined with other CPU manufacturers",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceRequirements: &InstanceRequirements{
+							VCPUCount:        InstanceRequirementsRange{Min: aws.Int64(2)},
+							MemoryMiB:        InstanceRequirementsRange{Min: aws.Int64(4096)},
+							CPUManufacturers: []string{"amazon-web-services", "intel"},
 						},
 					},
 				},
 			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if an EC2 Fleet is specified",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{
+						Type:                      EC2FleetTypeInstant,
+						DefaultTargetCapacityType: EC2FleetCapacityTypeSpot,
+						OnDemandTargetCapacity:    aws.Int64(1),
+						SingleAvailabilityZone:    true,
+						MinTargetCapacity:         aws.Int64(2),
+						Overrides:                 []Overrides{{InstanceType: "m5.large"}, {InstanceType: "m6i.large"}},
+					},
+				},
+			},
 			wantErr: false,
 		},
 		{
-			name: "Should fail if both spot market options or mixed instances policy are set",
+			name: "Should fail if an EC2 Fleet is used with ASG features",
 			pool: &AWSMachinePool{
 				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{},
+					WarmPool: &WarmPool{},
 					MixedInstancesPolicy: &MixedInstancesPolicy{
-						Overrides: []Overrides{{InstanceType: "t3.medium"}},
+						Overrides: []Overrides{{InstanceType: "m5.large"}},
 					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if an EC2 Fleet is used with spot market options",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{},
 					AWSLaunchTemplate: AWSLaunchTemplate{
-						SpotMarketOptions: &infrav1.SpotMarketOptions{MaxPrice: aws.String("0.1")},
+						SpotMarketOptions: &infrav1.SpotMarketOptions{},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a maintain EC2 Fleet sets instant fleet options",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{
+						Type:              EC2FleetTypeMaintain,
+						MinTargetCapacity: aws.Int64(1),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if an EC2 Fleet has duplicate instance types",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{
+						Overrides: []Overrides{{InstanceType: "m5.large"}, {InstanceType: "m5.large"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if an existing ASG is adopted by ARN",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ExistingASG: &ExistingASG{
+						Name:                 "arn:aws:autoscaling:us-east-1:123456789012:autoScalingGroup:6b2a5c5e-0f5e-4f3f-9c1e-1b1a0c9b1f0d:autoScalingGroupName/workers",
+						ManageLaunchTemplate: true,
+					},
+					WarmPool: &WarmPool{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the existing ASG ARN is not the ARN of an ASG",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ExistingASG: &ExistingASG{
+						Name: "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/workers/73e2d6bc24d8a067",
 					},
 				},
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should fail if ASG settings are used with an existing ASG whose launch template isn't managed",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ExistingASG:       &ExistingASG{Name: "workers"},
+					ScaleInProtection: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if an existing ASG is used with an EC2 Fleet",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ExistingASG: &ExistingASG{Name: "workers", ManageLaunchTemplate: true},
+					EC2Fleet:    &EC2Fleet{},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -217,6 +1037,24 @@ func TestAWSMachinePoolValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "adding a non root volume without a device name is rejected",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{},
+			},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NonRootVolumes: []infrav1.Volume{
+							{
+								Size: 100,
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Should fail update if both subnetID and filters passed in AWSMachinePool spec",
 			old: &AWSMachinePool{
@@ -287,6 +1125,86 @@ func TestAWSMachinePoolValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should fail update if an EC2 Fleet is added",
+			old:  &AWSMachinePool{},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail update if the EC2 Fleet type changes",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{},
+				},
+			},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{Type: EC2FleetTypeInstant},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass update if the On-Demand target capacity of a maintain EC2 Fleet changes",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{},
+				},
+			},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{Type: EC2FleetTypeMaintain, OnDemandTargetCapacity: aws.Int64(2)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail update if the allocation strategy of a maintain EC2 Fleet changes",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{},
+				},
+			},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{SpotAllocationStrategy: SpotAllocationStrategyDiversified},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass update if the instance types of an instant EC2 Fleet change",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{Type: EC2FleetTypeInstant},
+				},
+			},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					EC2Fleet: &EC2Fleet{Type: EC2FleetTypeInstant, Overrides: []Overrides{{InstanceType: "m5.large"}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail update if the existing ASG changes",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ExistingASG: &ExistingASG{Name: "workers"},
+				},
+			},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ExistingASG: &ExistingASG{Name: "workers", ManageTags: true},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
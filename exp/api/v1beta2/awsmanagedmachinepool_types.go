@@ -60,6 +60,18 @@ var (
 	DefaultEKSNodegroupRole = fmt.Sprintf("eks-nodegroup%s", iamv1.DefaultNameSuffix)
 )
 
+// InlinePolicy is an IAM policy embedded in a role.
+type InlinePolicy struct {
+	// Name of the inline policy.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	Name string `json:"name"`
+
+	// Document is the JSON policy document.
+	// +kubebuilder:validation:MinLength=1
+	Document string `json:"document"`
+}
+
 // AWSManagedMachinePoolSpec defines the desired state of AWSManagedMachinePool.
 type AWSManagedMachinePoolSpec struct {
 	// EKSNodegroupName specifies the name of the nodegroup in AWS
@@ -93,6 +105,12 @@ type AWSManagedMachinePoolSpec struct {
 	// +optional
 	RoleAdditionalPolicies []string `json:"roleAdditionalPolicies,omitempty"`
 
+	// RoleInlinePolicies allows you to embed additional inline policies in
+	// the node group role. You must enable the EKSAllowAddRoles
+	// feature flag to incorporate these into the created role.
+	// +optional
+	RoleInlinePolicies []InlinePolicy `json:"roleInlinePolicies,omitempty"`
+
 	// RoleName specifies the name of IAM role for the node group.
 	// If the role is pre-existing we will treat it as unmanaged
 	// and not delete it on deletion. If the EKSEnableIAM feature
@@ -151,6 +169,8 @@ type AWSManagedMachinePoolSpec struct {
 
 	// UpdateConfig holds the optional config to control the behaviour of the update
 	// to the nodegroup.
+	// When unset, the update configuration of the nodegroup is left to EKS, which
+	// defaults it to one unavailable node.
 	// +optional
 	UpdateConfig *UpdateConfig `json:"updateConfig,omitempty"`
 
@@ -180,6 +200,23 @@ type ManagedRemoteAccess struct {
 	Public bool `json:"public,omitempty"`
 }
 
+// NodegroupUpdate is the status of an update of an EKS managed node group.
+type NodegroupUpdate struct {
+	// ID is the ID of the update.
+	ID string `json:"id"`
+
+	// Type is the type of the update, ConfigUpdate for the labels, taints, scaling and update configuration of
+	// the node group, or VersionUpdate for its Kubernetes version, AMI release version and launch template.
+	Type string `json:"type"`
+
+	// Status is the status of the update, one of InProgress, Failed, Cancelled or Successful.
+	Status string `json:"status"`
+
+	// Errors are the errors which caused the update to fail.
+	// +optional
+	Errors []string `json:"errors,omitempty"`
+}
+
 // AWSManagedMachinePoolStatus defines the observed state of AWSManagedMachinePool.
 type AWSManagedMachinePoolStatus struct {
 	// Ready denotes that the AWSManagedMachinePool nodegroup has joined
@@ -199,6 +236,10 @@ type AWSManagedMachinePoolStatus struct {
 	// +optional
 	LaunchTemplateVersion *string `json:"launchTemplateVersion,omitempty"`
 
+	// Updates are the last updates of each type started on the node group by the controller.
+	// +optional
+	Updates []NodegroupUpdate `json:"updates,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the MachinePool and will contain a succinct value suitable
 	// for machine interpretation.
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 
@@ -31,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks"
 )
 
@@ -134,6 +136,27 @@ func (r *AWSManagedMachinePool) validateLaunchTemplate() field.ErrorList {
 	if r.Spec.AWSLaunchTemplate.IamInstanceProfile != "" {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "AWSLaunchTemplate", "IamInstanceProfile"), r.Spec.AWSLaunchTemplate.IamInstanceProfile, "IAM instance profile in launch template is prohibited in EKS managed node group"))
 	}
+	if r.Spec.AWSLaunchTemplate.InstanceRequirements != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "awsLaunchTemplate", "instanceRequirements"), "instance requirements are not supported by EKS managed node groups"))
+	}
+	allErrs = append(allErrs, infrav1.ValidateCapacityReservationTarget(r.Spec.AWSLaunchTemplate.CapacityReservationTarget, r.Spec.AWSLaunchTemplate.SpotMarketOptions, field.NewPath("spec", "awsLaunchTemplate", "capacityReservationTarget"))...)
+	allErrs = append(allErrs, infrav1.ValidateHostPlacement(r.Spec.AWSLaunchTemplate.Tenancy, r.Spec.AWSLaunchTemplate.HostID, r.Spec.AWSLaunchTemplate.HostResourceGroupARN, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, infrav1.ValidatePlacementGroup(r.Spec.AWSLaunchTemplate.PlacementGroup, field.NewPath("spec", "awsLaunchTemplate", "placementGroup"))...)
+	allErrs = append(allErrs, infrav1.ValidateAMIReference(r.Spec.AWSLaunchTemplate.AMI, field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
+	allErrs = append(allErrs, infrav1.ValidateImageLookupArchitecture(r.Spec.AWSLaunchTemplate.InstanceType, r.Spec.AWSLaunchTemplate.ImageLookupArchitecture, field.NewPath("spec", "awsLaunchTemplate", "imageLookupArchitecture"))...)
+	if r.Spec.AWSLaunchTemplate.RootVolume != nil {
+		allErrs = append(allErrs, infrav1.ValidateVolumePerformance(*r.Spec.AWSLaunchTemplate.RootVolume, field.NewPath("spec", "awsLaunchTemplate", "rootVolume"))...)
+		allErrs = append(allErrs, infrav1.ValidateVolumeEncryption(*r.Spec.AWSLaunchTemplate.RootVolume, field.NewPath("spec", "awsLaunchTemplate", "rootVolume"))...)
+	}
+	allErrs = append(allErrs, validateLaunchTemplateTags(r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, validatePreBootstrapUserData(r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate", "preBootstrapUserData"))...)
+	for _, volume := range r.Spec.AWSLaunchTemplate.NonRootVolumes {
+		allErrs = append(allErrs, infrav1.ValidateVolumePerformance(volume, field.NewPath("spec", "awsLaunchTemplate", "nonRootVolumes"))...)
+		allErrs = append(allErrs, infrav1.ValidateVolumeEncryption(volume, field.NewPath("spec", "awsLaunchTemplate", "nonRootVolumes"))...)
+		if volume.DeviceName == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "awsLaunchTemplate", "nonRootVolumes", "deviceName"), "non root volume should have device name"))
+		}
+	}
 
 	return allErrs
 }
@@ -159,6 +182,7 @@ func (r *AWSManagedMachinePool) ValidateCreate() (admission.Warnings, error) {
 	if errs := r.validateLaunchTemplate(); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
+	allErrs = append(allErrs, r.validateRoleInlinePolicies()...)
 
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
@@ -196,6 +220,7 @@ func (r *AWSManagedMachinePool) ValidateUpdate(old runtime.Object) (admission.Wa
 	if errs := r.validateLaunchTemplate(); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
+	allErrs = append(allErrs, r.validateRoleInlinePolicies()...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -208,6 +233,25 @@ func (r *AWSManagedMachinePool) ValidateUpdate(old runtime.Object) (admission.Wa
 	)
 }
 
+func (r *AWSManagedMachinePool) validateRoleInlinePolicies() field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := map[string]bool{}
+	for i, policy := range r.Spec.RoleInlinePolicies {
+		path := field.NewPath("spec", "roleInlinePolicies").Index(i)
+		if names[policy.Name] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), policy.Name))
+		}
+		names[policy.Name] = true
+
+		if !json.Valid([]byte(policy.Document)) {
+			allErrs = append(allErrs, field.Invalid(path.Child("document"), policy.Document, "must be a valid JSON policy document"))
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateDelete allows you to add any extra validation when deleting.
 func (r *AWSManagedMachinePool) ValidateDelete() (admission.Warnings, error) {
 	mmpLog.Info("AWSManagedMachinePool validate delete", "managed-machine-pool", klog.KObj(r))
@@ -281,4 +325,13 @@ func (r *AWSManagedMachinePool) Default() {
 			MaxUnavailable: ptr.To[int](1),
 		}
 	}
+
+	// The instance metadata options are only defaulted on creation, defaulting them on existing node groups
+	// would create a new launch template version and replace their nodes.
+	if r.CreationTimestamp.IsZero() && r.Spec.AWSLaunchTemplate != nil {
+		if r.Spec.AWSLaunchTemplate.InstanceMetadataOptions == nil {
+			r.Spec.AWSLaunchTemplate.InstanceMetadataOptions = &infrav1.InstanceMetadataOptions{}
+		}
+		r.Spec.AWSLaunchTemplate.InstanceMetadataOptions.SetDefaults()
+	}
 }
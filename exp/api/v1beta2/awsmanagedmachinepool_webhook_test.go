@@ -46,6 +46,28 @@ func TestAWSManagedMachinePoolDefault(t *testing.T) {
 	fargate.Default()
 }
 
+func TestAWSManagedMachinePoolDefaultInstanceMetadataOptions(t *testing.T) {
+	g := NewWithT(t)
+
+	pool := &AWSManagedMachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: AWSManagedMachinePoolSpec{
+			AWSLaunchTemplate: &AWSLaunchTemplate{},
+		},
+	}
+	pool.Default()
+	g.Expect(pool.Spec.AWSLaunchTemplate.InstanceMetadataOptions).To(Equal(&infrav1.InstanceMetadataOptions{
+		HTTPEndpoint:            infrav1.InstanceMetadataEndpointStateEnabled,
+		HTTPPutResponseHopLimit: 1,
+		HTTPTokens:              infrav1.HTTPTokensStateRequired,
+		InstanceMetadataTags:    infrav1.InstanceMetadataEndpointStateDisabled,
+	}))
+
+	withoutLaunchTemplate := &AWSManagedMachinePool{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+	withoutLaunchTemplate.Default()
+	g.Expect(withoutLaunchTemplate.Spec.AWSLaunchTemplate).To(BeNil())
+}
+
 func TestAWSManagedMachinePoolValidateCreate(t *testing.T) {
 	g := NewWithT(t)
 
@@ -103,6 +125,57 @@ func TestAWSManagedMachinePoolValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid launch template volume tags are rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						AdditionalVolumeTags: infrav1.Tags{"": "storage"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "launch template pre-bootstrap script is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						PreBootstrapUserData: "#!/bin/bash\necho hello\n",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "launch template pre-bootstrap user data without a header is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						PreBootstrapUserData: "echo hello\n",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "launch template instance requirements are rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						InstanceRequirements: &InstanceRequirements{
+							VCPUCount: InstanceRequirementsRange{Min: aws.Int64(2)},
+							MemoryMiB: InstanceRequirementsRange{Min: aws.Int64(4096)},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "valid update config",
 			pool: &AWSManagedMachinePool{
@@ -28,6 +28,18 @@ const (
 	// ASGDeletionInProgress ASG is in a deletion in progress state.
 	ASGDeletionInProgress = "ASGDeletionInProgress"
 
+	// EC2FleetReadyCondition reports on current status of the EC2 Fleet of an AWSMachinePool. Ready indicates the
+	// instances of the pool are launched.
+	EC2FleetReadyCondition clusterv1.ConditionType = "EC2FleetReady"
+	// EC2FleetProvisionFailedReason used for failures during EC2 Fleet provisioning.
+	EC2FleetProvisionFailedReason = "EC2FleetProvisionFailed"
+
+	// TerminationDrainReadyCondition reports on the termination lifecycle hook of an AWSMachinePool. Ready indicates
+	// the nodes are drained before the ASG terminates their instances.
+	TerminationDrainReadyCondition clusterv1.ConditionType = "TerminationDrainReady"
+	// TerminationDrainReconciliationFailedReason used for failures while reconciling the termination lifecycle hook.
+	TerminationDrainReconciliationFailedReason = "TerminationDrainReconciliationFailed"
+
 	// LaunchTemplateReadyCondition represents the status of an AWSMachinePool's associated Launch Template.
 	LaunchTemplateReadyCondition clusterv1.ConditionType = "LaunchTemplateReady"
 	// LaunchTemplateNotFoundReason is used when an associated Launch Template can't be found.
@@ -86,6 +98,22 @@ const (
 	EKSFargateDeletedReason = "Deleted"
 	// EKSFargateFailedReason used when the profile failed.
 	EKSFargateFailedReason = "Failed"
+	// EKSFargateCreateFailedReason used when EKS reports that the profile failed to create.
+	EKSFargateCreateFailedReason = "CreateFailed"
+	// EKSFargateDeleteFailedReason used when EKS reports that the profile failed to delete.
+	EKSFargateDeleteFailedReason = "DeleteFailed"
+
+	// EKSFargateSubnetsValidCondition condition reports on whether the profile
+	// subnets are private subnets of the cluster network.
+	EKSFargateSubnetsValidCondition clusterv1.ConditionType = "EKSFargateSubnetsValid"
+	// EKSFargateInvalidSubnetsReason used when the profile subnets are public or none could be selected.
+	EKSFargateInvalidSubnetsReason = "InvalidSubnets"
+
+	// EKSFargateRoleAttachedCondition condition reports on whether the profile
+	// uses the expected pod execution role.
+	EKSFargateRoleAttachedCondition clusterv1.ConditionType = "EKSFargateRoleAttached"
+	// EKSFargateRoleMismatchReason used when the profile uses a different pod execution role.
+	EKSFargateRoleMismatchReason = "RoleMismatch"
 )
 
 const (
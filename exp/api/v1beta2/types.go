@@ -89,13 +89,49 @@ type AWSLaunchTemplate struct {
 	// image lookup the AMI is not set.
 	ImageLookupBaseOS string `json:"imageLookupBaseOS,omitempty"`
 
+	// ImageLookupArchitecture is the architecture of the AMI to look up if the AMI ID is not set, in place
+	// of the architecture picked from the instance type.
+	// +kubebuilder:validation:Enum:=x86_64;arm64
+	// +optional
+	ImageLookupArchitecture string `json:"imageLookupArchitecture,omitempty"`
+
+	// ImageLookupRootDeviceType is the root device type of the AMI to look up with the image lookup
+	// format, org and base OS. AMIs of any root device type are considered if not set.
+	// +kubebuilder:validation:Enum:=ebs;instance-store
+	// +optional
+	ImageLookupRootDeviceType string `json:"imageLookupRootDeviceType,omitempty"`
+
 	// InstanceType is the type of instance to create. Example: m4.xlarge
 	InstanceType string `json:"instanceType,omitempty"`
 
+	// InstanceRequirements are the attributes of the instance types the instances can be launched with,
+	// instead of an explicit instance type. The Auto Scaling group launches the instances with any of the
+	// instance types matching them, which spreads Spot pools over more capacity pools. It cannot be set
+	// along with InstanceType, and is not supported by AWSManagedMachinePools.
+	// +optional
+	InstanceRequirements *InstanceRequirements `json:"instanceRequirements,omitempty"`
+
 	// RootVolume encapsulates the configuration options for the root volume
 	// +optional
 	RootVolume *infrav1.Volume `json:"rootVolume,omitempty"`
 
+	// NonRootVolumes specifies the additional data volumes to attach to the instances, for example
+	// to store the etcd data or the container images on dedicated disks.
+	// +optional
+	NonRootVolumes []infrav1.Volume `json:"nonRootVolumes,omitempty"`
+
+	// AdditionalVolumeTags is an optional set of tags to add to the EBS volumes of the instances, on top of
+	// the additional tags of the pool and of the cluster. Tags with the same key override the additional tags,
+	// so volumes can be tagged differently than the instances, for example for cost allocation.
+	// +optional
+	AdditionalVolumeTags infrav1.Tags `json:"additionalVolumeTags,omitempty"`
+
+	// AdditionalNetworkInterfaceTags is an optional set of tags to add to the network interfaces of the
+	// instances. When set, the network interfaces are also tagged with the additional tags of the pool and
+	// of the cluster, which tags with the same key override.
+	// +optional
+	AdditionalNetworkInterfaceTags infrav1.Tags `json:"additionalNetworkInterfaceTags,omitempty"`
+
 	// SSHKeyName is the name of the ssh key to attach to the instance. Valid values are empty string
 	// (do not use SSH keys), a valid SSH key name, or omitted (use the default SSH key name)
 	// +optional
@@ -117,6 +153,36 @@ type AWSLaunchTemplate struct {
 	// SpotMarketOptions are options for configuring AWSMachinePool instances to be run using AWS Spot instances.
 	SpotMarketOptions *infrav1.SpotMarketOptions `json:"spotMarketOptions,omitempty"`
 
+	// CapacityReservationTarget specifies the Capacity Reservation to launch instances into.
+	// It cannot be used with SpotMarketOptions.
+	// +optional
+	CapacityReservationTarget *infrav1.CapacityReservationTarget `json:"capacityReservationTarget,omitempty"`
+
+	// Tenancy indicates if instances should run on shared or single-tenant hardware.
+	// +optional
+	// +kubebuilder:validation:Enum:=default;dedicated;host
+	Tenancy string `json:"tenancy,omitempty"`
+
+	// PlacementGroup specifies the placement group in which to launch instances, which is created
+	// if it does not exist.
+	// +optional
+	PlacementGroup *infrav1.PlacementGroup `json:"placementGroup,omitempty"`
+
+	// HostID specifies the ID of the Dedicated Host on which to launch instances.
+	// It is only valid with the host tenancy.
+	// +kubebuilder:validation:Pattern=`^h-[0-9a-f]+$`
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+
+	// HostResourceGroupARN specifies the ARN of the host resource group in which to launch instances.
+	// It is only valid with the host tenancy, and cannot be used with HostID.
+	// +optional
+	HostResourceGroupARN *string `json:"hostResourceGroupArn,omitempty"`
+
+	// EnclaveOptions specifies the Nitro Enclaves options of instances.
+	// +optional
+	EnclaveOptions *infrav1.EnclaveOptions `json:"enclaveOptions,omitempty"`
+
 	// InstanceMetadataOptions defines the behavior for applying metadata to instances.
 	// +optional
 	InstanceMetadataOptions *infrav1.InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
@@ -124,6 +190,94 @@ type AWSLaunchTemplate struct {
 	// PrivateDNSName is the options for the instance hostname.
 	// +optional
 	PrivateDNSName *infrav1.PrivateDNSName `json:"privateDnsName,omitempty"`
+
+	// PublicIP specifies whether the instances should get a public IP.
+	// When unset, the instances inherit the default of their subnet. When set, it overrides
+	// the subnet default.
+	// +optional
+	PublicIP *bool `json:"publicIP,omitempty"`
+
+	// PreBootstrapUserData is custom user data which runs before the bootstrap data. It is merged with
+	// the bootstrap data into a multipart MIME document, and must be a cloud-config, starting with
+	// #cloud-config, a boothook, starting with #cloud-boothook, or a script, starting with #!.
+	// The bootstrap data must be cloud-init or nodeadm user data.
+	// +optional
+	PreBootstrapUserData string `json:"preBootstrapUserData,omitempty"`
+
+	// UncompressedUserData specifies whether the user data is gzip-compressed before it is stored
+	// in the launch template. Set it to false to compress the user data, which keeps large cloud-init
+	// or Ignition configurations under the 16KB user data limit. The bootstrap data must be in a
+	// format whose consumer decompresses gzip, Bottlerocket and nodeadm user data cannot be compressed.
+	// +optional
+	UncompressedUserData *bool `json:"uncompressedUserData,omitempty"`
+
+	// VersionsToKeep is the number of the most recent versions of the launch template, including the
+	// latest one, which are kept when a new version is created. The older versions are deleted, except
+	// the default version which cannot be deleted. It must be at least 2, so that the version in use
+	// is kept until the new version is rolled out. Defaults to 2.
+	// +kubebuilder:validation:Minimum=2
+	// +optional
+	VersionsToKeep *int64 `json:"versionsToKeep,omitempty"`
+}
+
+// GetVersionsToKeep returns the number of the most recent versions of the launch template to keep.
+func (t *AWSLaunchTemplate) GetVersionsToKeep() int64 {
+	if t.VersionsToKeep == nil {
+		return DefaultLaunchTemplateVersionsToKeep
+	}
+	return *t.VersionsToKeep
+}
+
+// InstanceRequirements are the attributes of the instance types the instances can be launched with.
+type InstanceRequirements struct {
+	// VCPUCount is the range of the number of vCPUs of the instance types.
+	VCPUCount InstanceRequirementsRange `json:"vCPUCount"`
+
+	// MemoryMiB is the range of the amount of memory of the instance types, in MiB.
+	MemoryMiB InstanceRequirementsRange `json:"memoryMiB"`
+
+	// CPUManufacturers are the manufacturers of the CPUs of the instance types, amazon-web-services
+	// being the manufacturer of the Graviton CPUs. The CPU architecture of the instance types must match
+	// the one of the AMI, see ImageLookupArchitecture. Instance types of any manufacturer are used if not set.
+	// +kubebuilder:validation:items:Enum:=intel;amd;amazon-web-services
+	// +optional
+	CPUManufacturers []string `json:"cpuManufacturers,omitempty"`
+
+	// AcceleratorCount is the range of the number of accelerators, e.g. GPUs, of the instance types. Set the
+	// maximum to 0 to exclude the instance types with accelerators. Any number is accepted if not set.
+	// +optional
+	AcceleratorCount *InstanceRequirementsRange `json:"acceleratorCount,omitempty"`
+
+	// ExcludedInstanceTypes are the instance types to exclude, which may use * as a wildcard to exclude
+	// whole families or generations, e.g. t2.*, m5a.* or r*.
+	// +kubebuilder:validation:MaxItems=400
+	// +optional
+	ExcludedInstanceTypes []string `json:"excludedInstanceTypes,omitempty"`
+
+	// InstanceGenerations are the generations of the instance types, current or previous. Instance types of
+	// the current generation are used if not set.
+	// +kubebuilder:validation:items:Enum:=current;previous
+	// +optional
+	InstanceGenerations []string `json:"instanceGenerations,omitempty"`
+
+	// BurstablePerformance indicates whether the burstable performance instance types, e.g. T3, are
+	// included, excluded or required. They are excluded if not set.
+	// +kubebuilder:validation:Enum:=included;excluded;required
+	// +optional
+	BurstablePerformance string `json:"burstablePerformance,omitempty"`
+}
+
+// InstanceRequirementsRange is a range of values of an attribute of the instance types.
+type InstanceRequirementsRange struct {
+	// Min is the minimum value, there is no minimum if not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Min *int64 `json:"min,omitempty"`
+
+	// Max is the maximum value, there is no maximum if not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Max *int64 `json:"max,omitempty"`
 }
 
 // Overrides are used to override the instance type specified by the launch template with multiple
@@ -168,6 +322,10 @@ var (
 	// instances using Spot pools that consider both price and available Spot capacity to
 	// provide a balance between cost savings and allocation reliability.
 	SpotAllocationStrategyPriceCapacityOptimized = SpotAllocationStrategy("price-capacity-optimized")
+
+	// SpotAllocationStrategyDiversified will make the EC2 Fleet launch instances evenly
+	// distributed across all the Spot pools. It is only supported by EC2 Fleets.
+	SpotAllocationStrategyDiversified = SpotAllocationStrategy("diversified")
 )
 
 // InstancesDistribution to configure distribution of On-Demand Instances and Spot Instances.
@@ -180,9 +338,16 @@ type InstancesDistribution struct {
 	// +kubebuilder:default=lowest-price
 	SpotAllocationStrategy SpotAllocationStrategy `json:"spotAllocationStrategy,omitempty"`
 
+	// OnDemandBaseCapacity is the minimum number of On-Demand instances of the group,
+	// launched before any Spot instance.
+	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:default=0
 	OnDemandBaseCapacity *int64 `json:"onDemandBaseCapacity,omitempty"`
 
+	// OnDemandPercentageAboveBaseCapacity is the percentage of On-Demand instances of the group
+	// beyond OnDemandBaseCapacity, the remaining instances being Spot instances.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
 	// +kubebuilder:default=100
 	OnDemandPercentageAboveBaseCapacity *int64 `json:"onDemandPercentageAboveBaseCapacity,omitempty"`
 }
@@ -196,6 +361,187 @@ type MixedInstancesPolicy struct {
 // Tags is a mapping for tags.
 type Tags map[string]string
 
+// WarmPoolState is the state of the instances in the warm pool of an ASG.
+type WarmPoolState string
+
+const (
+	// WarmPoolStateStopped keeps the instances of the warm pool stopped.
+	WarmPoolStateStopped = WarmPoolState("Stopped")
+	// WarmPoolStateHibernated keeps the instances of the warm pool hibernated.
+	// The launch template must enable hibernation on the instances.
+	WarmPoolStateHibernated = WarmPoolState("Hibernated")
+)
+
+// WarmPool defines the warm pool of pre-initialized instances of an ASG.
+type WarmPool struct {
+	// MinSize is the minimum number of instances to keep in the warm pool.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinSize int64 `json:"minSize,omitempty"`
+
+	// MaxGroupPreparedCapacity is the maximum number of instances allowed to be in the ASG and in the warm pool
+	// together. The size of the warm pool is this value minus the desired capacity of the ASG, but no less
+	// than MinSize. If not set, the maximum size of the ASG is used.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxGroupPreparedCapacity *int64 `json:"maxGroupPreparedCapacity,omitempty"`
+
+	// PoolState is the state of the instances in the warm pool. Running warm pools are not supported, as their
+	// instances would be registered as nodes of the cluster while they are not in service.
+	// +kubebuilder:validation:Enum=Stopped;Hibernated
+	// +kubebuilder:default=Stopped
+	// +optional
+	PoolState WarmPoolState `json:"poolState,omitempty"`
+
+	// InstanceReusePolicy defines whether the instances of the ASG can be returned to the warm pool on scale in.
+	// +optional
+	InstanceReusePolicy *WarmPoolInstanceReusePolicy `json:"instanceReusePolicy,omitempty"`
+}
+
+// WarmPoolInstanceReusePolicy defines the reuse of the instances of an ASG by its warm pool.
+type WarmPoolInstanceReusePolicy struct {
+	// ReuseOnScaleIn, if true, returns the instances to the warm pool on scale in instead of terminating them.
+	// +optional
+	ReuseOnScaleIn bool `json:"reuseOnScaleIn,omitempty"`
+}
+
+// MetricsGranularityOneMinute is the only granularity of the group metrics of an ASG.
+const MetricsGranularityOneMinute = "1Minute"
+
+// GroupMetric is a CloudWatch metric of an ASG.
+// +kubebuilder:validation:Enum=GroupMinSize;GroupMaxSize;GroupDesiredCapacity;GroupInServiceInstances;GroupPendingInstances;GroupStandbyInstances;GroupTerminatingInstances;GroupTotalInstances;GroupInServiceCapacity;GroupPendingCapacity;GroupStandbyCapacity;GroupTerminatingCapacity;GroupTotalCapacity;WarmPoolDesiredCapacity;WarmPoolWarmedCapacity;WarmPoolPendingCapacity;WarmPoolTerminatingCapacity;WarmPoolTotalCapacity;GroupAndWarmPoolDesiredCapacity;GroupAndWarmPoolTotalCapacity
+type GroupMetric string
+
+// GroupMetrics are all the CloudWatch metrics of an ASG.
+var GroupMetrics = []GroupMetric{
+	"GroupMinSize",
+	"GroupMaxSize",
+	"GroupDesiredCapacity",
+	"GroupInServiceInstances",
+	"GroupPendingInstances",
+	"GroupStandbyInstances",
+	"GroupTerminatingInstances",
+	"GroupTotalInstances",
+	"GroupInServiceCapacity",
+	"GroupPendingCapacity",
+	"GroupStandbyCapacity",
+	"GroupTerminatingCapacity",
+	"GroupTotalCapacity",
+	"WarmPoolDesiredCapacity",
+	"WarmPoolWarmedCapacity",
+	"WarmPoolPendingCapacity",
+	"WarmPoolTerminatingCapacity",
+	"WarmPoolTotalCapacity",
+	"GroupAndWarmPoolDesiredCapacity",
+	"GroupAndWarmPoolTotalCapacity",
+}
+
+// MetricsCollection defines the group metrics of an ASG collected in CloudWatch.
+type MetricsCollection struct {
+	// Granularity is the frequency at which the group metrics are collected. The only valid value is 1Minute.
+	// +kubebuilder:validation:Enum="1Minute"
+	// +kubebuilder:default="1Minute"
+	// +optional
+	Granularity string `json:"granularity,omitempty"`
+
+	// Metrics are the group metrics to collect. All the group metrics are collected if empty.
+	// +optional
+	Metrics []GroupMetric `json:"metrics,omitempty"`
+}
+
+// MetricNames returns the names of the collected metrics, or of all the group metrics if none is set.
+func (m *MetricsCollection) MetricNames() []string {
+	metrics := m.Metrics
+	if len(metrics) == 0 {
+		metrics = GroupMetrics
+	}
+	names := make([]string, len(metrics))
+	for i, metric := range metrics {
+		names[i] = string(metric)
+	}
+	return names
+}
+
+// EC2FleetType is the type of the EC2 Fleet backing an AWSMachinePool.
+type EC2FleetType string
+
+const (
+	// EC2FleetTypeMaintain keeps the target capacity of the fleet, replacing the interrupted and terminated instances.
+	EC2FleetTypeMaintain = EC2FleetType("maintain")
+	// EC2FleetTypeInstant launches the instances once, the missing instances are launched by new fleets.
+	EC2FleetTypeInstant = EC2FleetType("instant")
+)
+
+const (
+	// EC2FleetCapacityTypeOnDemand launches On-Demand instances.
+	EC2FleetCapacityTypeOnDemand = "on-demand"
+	// EC2FleetCapacityTypeSpot launches Spot instances.
+	EC2FleetCapacityTypeSpot = "spot"
+)
+
+// EC2Fleet defines the EC2 Fleet launching the instances of an AWSMachinePool instead of an ASG.
+type EC2Fleet struct {
+	// Type is the type of the fleet. A maintain fleet keeps the number of instances of the pool, replacing
+	// the interrupted Spot instances. An instant fleet launches the instances synchronously, and fails if
+	// the capacity isn't available, the controller then launches the missing instances with new fleets.
+	// +kubebuilder:validation:Enum=maintain;instant
+	// +kubebuilder:default=maintain
+	// +optional
+	Type EC2FleetType `json:"type,omitempty"`
+
+	// DefaultTargetCapacityType is the capacity type of the instances beyond OnDemandTargetCapacity.
+	// +kubebuilder:validation:Enum=on-demand;spot
+	// +kubebuilder:default=on-demand
+	// +optional
+	DefaultTargetCapacityType string `json:"defaultTargetCapacityType,omitempty"`
+
+	// OnDemandTargetCapacity is the number of On-Demand instances of the pool, the remaining instances being of
+	// the DefaultTargetCapacityType.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	OnDemandTargetCapacity *int64 `json:"onDemandTargetCapacity,omitempty"`
+
+	// OnDemandAllocationStrategy is the strategy allocating the On-Demand instances to the instance types.
+	// +kubebuilder:validation:Enum=lowest-price;prioritized
+	// +kubebuilder:default=lowest-price
+	// +optional
+	OnDemandAllocationStrategy OnDemandAllocationStrategy `json:"onDemandAllocationStrategy,omitempty"`
+
+	// SpotAllocationStrategy is the strategy allocating the Spot instances to the Spot pools.
+	// +kubebuilder:validation:Enum=lowest-price;diversified;capacity-optimized;capacity-optimized-prioritized;price-capacity-optimized
+	// +kubebuilder:default=price-capacity-optimized
+	// +optional
+	SpotAllocationStrategy SpotAllocationStrategy `json:"spotAllocationStrategy,omitempty"`
+
+	// SingleInstanceType, if true, launches all the instances of each fleet with the same instance type.
+	// Only supported by instant fleets.
+	// +optional
+	SingleInstanceType bool `json:"singleInstanceType,omitempty"`
+
+	// SingleAvailabilityZone, if true, launches all the instances of each fleet in the same availability zone.
+	// Only supported by instant fleets.
+	// +optional
+	SingleAvailabilityZone bool `json:"singleAvailabilityZone,omitempty"`
+
+	// MinTargetCapacity is the minimum number of instances each fleet must launch, no instance is launched
+	// otherwise. It is capped to the number of instances to launch. Only supported by instant fleets.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinTargetCapacity *int64 `json:"minTargetCapacity,omitempty"`
+
+	// Overrides are the instance types the fleet may launch, replacing the instance type of the launch template.
+	// +optional
+	Overrides []Overrides `json:"overrides,omitempty"`
+}
+
+// GetType returns the type of the fleet, defaulting to maintain.
+func (f *EC2Fleet) GetType() EC2FleetType {
+	if f.Type == "" {
+		return EC2FleetTypeMaintain
+	}
+	return f.Type
+}
+
 // AutoScalingGroup describes an AWS autoscaling group.
 type AutoScalingGroup struct {
 	// The tags associated with the instance.
@@ -215,6 +561,22 @@ type AutoScalingGroup struct {
 	Status                    ASGStatus
 	Instances                 []infrav1.Instance `json:"instances,omitempty"`
 	CurrentlySuspendProcesses []string           `json:"currentlySuspendProcesses,omitempty"`
+	WarmPool                  *WarmPool          `json:"warmPool,omitempty"`
+
+	// NewInstancesProtectedFromScaleIn is true if the instances launched by the ASG are protected from scale in.
+	NewInstancesProtectedFromScaleIn bool `json:"newInstancesProtectedFromScaleIn,omitempty"`
+	// InstancesProtectedFromScaleIn are the IDs of the instances of the ASG protected from scale in.
+	InstancesProtectedFromScaleIn []string `json:"instancesProtectedFromScaleIn,omitempty"`
+	// EnabledMetrics are the names of the group metrics collected for the ASG.
+	EnabledMetrics []string `json:"enabledMetrics,omitempty"`
+	// MaxInstanceLifetime is the maximum amount of time an instance of the ASG can be in service.
+	MaxInstanceLifetime *metav1.Duration `json:"maxInstanceLifetime,omitempty"`
+	// TargetGroupARNs are the ARNs of the target groups attached to the ASG.
+	TargetGroupARNs []string `json:"targetGroupARNs,omitempty"`
+	// LoadBalancerNames are the names of the classic load balancers attached to the ASG.
+	LoadBalancerNames []string `json:"loadBalancerNames,omitempty"`
+	// LaunchTemplateID is the ID of the launch template the ASG launches its instances from, if any.
+	LaunchTemplateID string `json:"launchTemplateID,omitempty"`
 }
 
 // ASGStatus is a status string returned by the autoscaling API.
@@ -223,6 +585,25 @@ type ASGStatus string
 // ASGStatusDeleteInProgress is the string representing an ASG that is currently deleting.
 var ASGStatusDeleteInProgress = ASGStatus("Delete in progress")
 
+// Fleet describes an EC2 Fleet.
+type Fleet struct {
+	ID                     string       `json:"id,omitempty"`
+	Type                   EC2FleetType `json:"type,omitempty"`
+	State                  string       `json:"state,omitempty"`
+	TotalTargetCapacity    int64        `json:"totalTargetCapacity,omitempty"`
+	OnDemandTargetCapacity int64        `json:"onDemandTargetCapacity,omitempty"`
+}
+
+// LifecycleAction describes a pending termination lifecycle action of an ASG, received from an SQS queue.
+type LifecycleAction struct {
+	ASGName       string `json:"asgName,omitempty"`
+	HookName      string `json:"hookName,omitempty"`
+	InstanceID    string `json:"instanceID,omitempty"`
+	Token         string `json:"token,omitempty"`
+	QueueURL      string `json:"queueURL,omitempty"`
+	ReceiptHandle string `json:"receiptHandle,omitempty"`
+}
+
 // TaintEffect is the effect for a Kubernetes taint.
 type TaintEffect string
 
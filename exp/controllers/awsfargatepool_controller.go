@@ -116,6 +116,7 @@ func (r *AWSFargateProfileReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	defer func() {
 		applicableConditions := []clusterv1.ConditionType{
 			expinfrav1.IAMFargateRolesReadyCondition,
+			expinfrav1.EKSFargateRoleAttachedCondition,
 			expinfrav1.EKSFargateProfileReadyCondition,
 		}
 
@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
@@ -28,8 +29,10 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -159,7 +162,9 @@ func (r *AWSMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		conditions.SetSummary(machinePoolScope.AWSMachinePool,
 			conditions.WithConditions(
 				expinfrav1.ASGReadyCondition,
+				expinfrav1.EC2FleetReadyCondition,
 				expinfrav1.LaunchTemplateReadyCondition,
+				expinfrav1.TerminationDrainReadyCondition,
 			),
 			conditions.WithStepCounterIfOnly(
 				expinfrav1.ASGReadyCondition,
@@ -178,18 +183,33 @@ func (r *AWSMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			return ctrl.Result{}, r.reconcileDelete(machinePoolScope, infraScope, infraScope)
 		}
 
-		return ctrl.Result{}, r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
+		return reconcileNormalResult(machinePoolScope, r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope))
 	case *scope.ClusterScope:
 		if !awsMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
 			return ctrl.Result{}, r.reconcileDelete(machinePoolScope, infraScope, infraScope)
 		}
 
-		return ctrl.Result{}, r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
+		return reconcileNormalResult(machinePoolScope, r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope))
 	default:
 		return ctrl.Result{}, errors.New("infraCluster has unknown type")
 	}
 }
 
+// reconcileNormalResult requeues the AWSMachinePools whose instances launched with an instant fleet are not
+// listed yet, and the ones whose nodes are drained on termination, so that their lifecycle actions are received
+// periodically.
+func reconcileNormalResult(machinePoolScope *scope.MachinePoolScope, err error) (ctrl.Result, error) {
+	switch {
+	case err != nil:
+		return ctrl.Result{}, err
+	case machinePoolScope.AWSMachinePool.Status.InstantFleetLaunch != nil:
+		return ctrl.Result{RequeueAfter: instantFleetLaunchPollInterval}, nil
+	case machinePoolScope.AWSMachinePool.Spec.TerminationDrain != nil:
+		return ctrl.Result{RequeueAfter: terminationDrainPollInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
 func (r *AWSMachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
@@ -235,6 +255,10 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		return nil
 	}
 
+	if machinePoolScope.AWSMachinePool.Spec.EC2Fleet != nil {
+		return r.reconcileFleet(ctx, machinePoolScope, clusterScope, ec2Scope)
+	}
+
 	ec2Svc := r.getEC2Service(ec2Scope)
 	asgsvc := r.getASGService(clusterScope)
 	reconSvc := r.getReconcileService(ec2Scope)
@@ -246,6 +270,15 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		return err
 	}
 
+	// An adopted ASG is never created, and its configuration is only managed if requested.
+	existingASG := machinePoolScope.AWSMachinePool.Spec.ExistingASG
+	if existingASG != nil && asg == nil {
+		err := errors.Errorf("ASG %q to adopt not found", existingASG.Name)
+		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGNotFoundReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+	manageASG := existingASG == nil || existingASG.ManageLaunchTemplate
+
 	canUpdateLaunchTemplate := func() (bool, error) {
 		// If there is a change: before changing the template, check if there exist an ongoing instance refresh,
 		// because only 1 instance refresh can be "InProgress". If template is updated when refresh cannot be started,
@@ -280,14 +313,16 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		machinePoolScope.Info("starting instance refresh", "number of instances", machinePoolScope.MachinePool.Spec.Replicas)
 		return asgsvc.StartASGInstanceRefresh(machinePoolScope)
 	}
-	if err := reconSvc.ReconcileLaunchTemplate(machinePoolScope, ec2Svc, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
-		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
-		machinePoolScope.Error(err, "failed to reconcile launch template")
-		return err
-	}
+	if manageASG {
+		if err := reconSvc.ReconcileLaunchTemplate(machinePoolScope, ec2Svc, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
+			machinePoolScope.Error(err, "failed to reconcile launch template")
+			return err
+		}
 
-	// set the LaunchTemplateReady condition
-	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.LaunchTemplateReadyCondition)
+		// set the LaunchTemplateReady condition
+		conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.LaunchTemplateReadyCondition)
+	}
 
 	if asg == nil {
 		// Create new ASG
@@ -298,9 +333,9 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		return nil
 	}
 
-	if annotations.ReplicasManagedByExternalAutoscaler(machinePoolScope.MachinePool) {
-		// Set MachinePool replicas to the ASG DesiredCapacity
-		if *machinePoolScope.MachinePool.Spec.Replicas != *asg.DesiredCapacity {
+	if annotations.ReplicasManagedByExternalAutoscaler(machinePoolScope.MachinePool) || !manageASG {
+		// Set MachinePool replicas to the ASG DesiredCapacity, the replicas may not be set yet.
+		if asg.DesiredCapacity != nil && !ptr.Equal(machinePoolScope.MachinePool.Spec.Replicas, asg.DesiredCapacity) {
 			machinePoolScope.Info("Setting MachinePool replicas to ASG DesiredCapacity",
 				"local", machinePoolScope.MachinePool.Spec.Replicas,
 				"external", asg.DesiredCapacity)
@@ -311,26 +346,55 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		}
 	}
 
-	if err := r.updatePool(machinePoolScope, clusterScope, asg); err != nil {
-		machinePoolScope.Error(err, "error updating AWSMachinePool")
-		return err
+	if manageASG {
+		if err := r.updatePool(machinePoolScope, clusterScope, asg); err != nil {
+			machinePoolScope.Error(err, "error updating AWSMachinePool")
+			return err
+		}
+
+		if machinePoolScope.AWSMachinePool.Status.InstanceRefresh.IsInProgress() {
+			// The status only reports the progress of the refresh, so it is updated on the next reconciliation
+			// rather than blocking this one.
+			if err := asgsvc.UpdateInstanceRefreshStatus(machinePoolScope); err != nil {
+				machinePoolScope.Error(err, "failed to update instance refresh status")
+			}
+		}
+
+		if err := r.reconcileScaleInProtection(ctx, machinePoolScope, asgsvc, asg); err != nil {
+			machinePoolScope.Error(err, "failed to reconcile scale-in protection")
+			return err
+		}
+
+		if err := r.reconcileTerminationDrain(ctx, machinePoolScope, asgsvc); err != nil {
+			machinePoolScope.Error(err, "failed to reconcile termination drain")
+			return err
+		}
+
+		if err := r.reconcileUnregisteredInstances(ctx, machinePoolScope, asgsvc, asg); err != nil {
+			machinePoolScope.Error(err, "failed to reconcile unregistered instances")
+			return err
+		}
 	}
 
 	launchTemplateID := machinePoolScope.GetLaunchTemplateIDStatus()
-	asgName := machinePoolScope.Name()
-	resourceServiceToUpdate := []scope.ResourceServiceToUpdate{
-		{
+	asgName := machinePoolScope.ASGName()
+	var resourceServiceToUpdate []scope.ResourceServiceToUpdate
+	if manageASG {
+		resourceServiceToUpdate = append(resourceServiceToUpdate, scope.ResourceServiceToUpdate{
 			ResourceID:      &launchTemplateID,
 			ResourceService: ec2Svc,
-		},
-		{
+		})
+	}
+	if existingASG == nil || existingASG.ManageTags {
+		resourceServiceToUpdate = append(resourceServiceToUpdate, scope.ResourceServiceToUpdate{
 			ResourceID:      &asgName,
 			ResourceService: asgsvc,
-		},
+		})
 	}
-	err = reconSvc.ReconcileTags(machinePoolScope, resourceServiceToUpdate)
-	if err != nil {
-		return errors.Wrap(err, "error updating tags")
+	if len(resourceServiceToUpdate) > 0 {
+		if err := reconSvc.ReconcileTags(machinePoolScope, resourceServiceToUpdate); err != nil {
+			return errors.Wrap(err, "error updating tags")
+		}
 	}
 
 	// Make sure Spec.ProviderID is always set.
@@ -362,30 +426,17 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 	ec2Svc := r.getEC2Service(ec2Scope)
 	asgSvc := r.getASGService(clusterScope)
 
-	asg, err := r.findASG(machinePoolScope, asgSvc)
-	if err != nil {
+	// The instances terminated along with the ASG don't wait for their node to be drained.
+	if err := r.deleteTerminationDrain(machinePoolScope, asgSvc); err != nil {
 		return err
 	}
 
-	if asg == nil {
-		machinePoolScope.Warn("Unable to locate ASG")
-		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, expinfrav1.ASGNotFoundReason, "Unable to find matching ASG")
-	} else {
-		machinePoolScope.SetASGStatus(asg.Status)
-		switch asg.Status {
-		case expinfrav1.ASGStatusDeleteInProgress:
-			// ASG is already deleting
-			machinePoolScope.SetNotReady()
-			conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGDeletionInProgress, clusterv1.ConditionSeverityWarning, "")
-			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "DeletionInProgress", "ASG deletion in progress: %q", asg.Name)
-			machinePoolScope.Info("ASG is already deleting", "name", asg.Name)
-		default:
-			machinePoolScope.Info("Deleting ASG", "id", asg.Name, "status", asg.Status)
-			if err := asgSvc.DeleteASGAndWait(asg.Name); err != nil {
-				r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete ASG %q: %v", asg.Name, err)
-				return errors.Wrap(err, "failed to delete ASG")
-			}
+	if machinePoolScope.AWSMachinePool.Spec.EC2Fleet != nil {
+		if err := r.deleteFleet(machinePoolScope, ec2Svc); err != nil {
+			return err
 		}
+	} else if err := r.deleteASG(machinePoolScope, clusterScope, asgSvc); err != nil {
+		return err
 	}
 
 	launchTemplateID := machinePoolScope.AWSMachinePool.Status.LaunchTemplateID
@@ -415,6 +466,49 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 	return nil
 }
 
+// deleteASG deletes the ASG of the pool, if any. An adopted ASG is only deleted if its configuration is managed
+// and it is tagged as owned by the cluster, otherwise it is kept.
+func (r *AWSMachinePoolReconciler) deleteASG(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, asgSvc services.ASGInterface) error {
+	existingASG := machinePoolScope.AWSMachinePool.Spec.ExistingASG
+	if existingASG != nil && !existingASG.ManageLaunchTemplate {
+		machinePoolScope.Info("Keeping adopted ASG", "name", existingASG.Name)
+		return nil
+	}
+
+	asg, err := r.findASG(machinePoolScope, asgSvc)
+	if err != nil {
+		return err
+	}
+
+	if asg != nil && existingASG != nil && !asg.Tags.HasOwned(clusterScope.KubernetesClusterName()) {
+		machinePoolScope.Info("Keeping adopted ASG not owned by the cluster", "name", asg.Name)
+		return nil
+	}
+
+	if asg == nil {
+		machinePoolScope.Warn("Unable to locate ASG")
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, expinfrav1.ASGNotFoundReason, "Unable to find matching ASG")
+	} else {
+		machinePoolScope.SetASGStatus(asg.Status)
+		switch asg.Status {
+		case expinfrav1.ASGStatusDeleteInProgress:
+			// ASG is already deleting
+			machinePoolScope.SetNotReady()
+			conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGDeletionInProgress, clusterv1.ConditionSeverityWarning, "")
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "DeletionInProgress", "ASG deletion in progress: %q", asg.Name)
+			machinePoolScope.Info("ASG is already deleting", "name", asg.Name)
+		default:
+			machinePoolScope.Info("Deleting ASG", "id", asg.Name, "status", asg.Status)
+			if err := asgSvc.DeleteASGAndWait(asg.Name); err != nil {
+				r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete ASG %q: %v", asg.Name, err)
+				return errors.Wrap(err, "failed to delete ASG")
+			}
+		}
+	}
+
+	return nil
+}
+
 func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, existingASG *expinfrav1.AutoScalingGroup) error {
 	asgSvc := r.getASGService(clusterScope)
 
@@ -435,7 +529,12 @@ func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoo
 	if asgDiff != "" {
 		machinePoolScope.Debug("asg diff detected", "asgDiff", asgDiff, "subnetDiff", subnetDiff)
 	}
-	if asgDiff != "" || subnetDiff != "" {
+	// An adopted ASG is switched to the launch template of the pool.
+	adopting := machinePoolScope.AWSMachinePool.Spec.ExistingASG != nil && existingASG.LaunchTemplateID != machinePoolScope.GetLaunchTemplateIDStatus()
+	if adopting {
+		machinePoolScope.Debug("adopted asg launch template differs", "existing", existingASG.LaunchTemplateID, "desired", machinePoolScope.GetLaunchTemplateIDStatus())
+	}
+	if asgDiff != "" || subnetDiff != "" || adopting {
 		machinePoolScope.Info("updating AutoScalingGroup")
 
 		if err := asgSvc.UpdateASG(machinePoolScope); err != nil {
@@ -445,57 +544,112 @@ func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoo
 	}
 
 	suspendedProcessesSlice := machinePoolScope.AWSMachinePool.Spec.SuspendProcesses.ConvertSetValuesToStringSlice()
-	if !cmp.Equal(existingASG.CurrentlySuspendProcesses, suspendedProcessesSlice) {
+	// The order of the suspended processes returned by AWS is not stable, only the differences are reconciled.
+	if toBeSuspended, toBeResumed := diffSets(existingASG.CurrentlySuspendProcesses, suspendedProcessesSlice); len(toBeSuspended) > 0 || len(toBeResumed) > 0 {
 		clusterScope.Info("reconciling processes", "suspend-processes", suspendedProcessesSlice)
-		var (
-			toBeSuspended []string
-			toBeResumed   []string
-
-			currentlySuspended = make(map[string]struct{})
-			desiredSuspended   = make(map[string]struct{})
-		)
-
-		// Convert the items to a map, so it's easy to create an effective diff from these two slices.
-		for _, p := range existingASG.CurrentlySuspendProcesses {
-			currentlySuspended[p] = struct{}{}
+		if len(toBeSuspended) > 0 {
+			clusterScope.Info("suspending processes", "processes", toBeSuspended)
+			if err := asgSvc.SuspendProcesses(existingASG.Name, toBeSuspended); err != nil {
+				return errors.Wrapf(err, "failed to suspend processes while trying update pool")
+			}
 		}
+		if len(toBeResumed) > 0 {
+			clusterScope.Info("resuming processes", "processes", toBeResumed)
+			if err := asgSvc.ResumeProcesses(existingASG.Name, toBeResumed); err != nil {
+				return errors.Wrapf(err, "failed to resume processes while trying update pool")
+			}
+		}
+	}
 
-		for _, p := range suspendedProcessesSlice {
-			desiredSuspended[p] = struct{}{}
+	var desiredMetrics []string
+	metricsCollection := machinePoolScope.AWSMachinePool.Spec.MetricsCollection
+	if metricsCollection != nil {
+		desiredMetrics = metricsCollection.MetricNames()
+	}
+	toBeEnabled, toBeDisabled := diffSets(existingASG.EnabledMetrics, desiredMetrics)
+	if len(toBeEnabled) > 0 {
+		clusterScope.Info("enabling metrics collection", "metrics", toBeEnabled)
+		if err := asgSvc.EnableMetricsCollection(existingASG.Name, metricsGranularity(metricsCollection), toBeEnabled); err != nil {
+			return errors.Wrapf(err, "failed to enable metrics collection while trying update pool")
+		}
+	}
+	if len(toBeDisabled) > 0 {
+		clusterScope.Info("disabling metrics collection", "metrics", toBeDisabled)
+		if err := asgSvc.DisableMetricsCollection(existingASG.Name, toBeDisabled); err != nil {
+			return errors.Wrapf(err, "failed to disable metrics collection while trying update pool")
 		}
+	}
+
+	if err := r.reconcileLoadBalancers(machinePoolScope, asgSvc, existingASG); err != nil {
+		return errors.Wrapf(err, "failed to reconcile load balancers while trying update pool")
+	}
 
-		// Anything that remains in the desired items is not currently suspended so must be suspended.
-		// Anything that remains in the currentlySuspended list must be resumed since they were not part of
-		// desiredSuspended.
-		for k := range desiredSuspended {
-			if _, ok := currentlySuspended[k]; ok {
-				delete(desiredSuspended, k)
+	if warmPoolDiff := diffWarmPool(machinePoolScope, existingASG); warmPoolDiff != "" {
+		machinePoolScope.Debug("warm pool diff detected", "diff", warmPoolDiff)
+		if warmPool := machinePoolScope.AWSMachinePool.Spec.WarmPool; warmPool != nil {
+			clusterScope.Info("updating warm pool")
+			if err := asgSvc.PutWarmPool(existingASG.Name, warmPool); err != nil {
+				return errors.Wrapf(err, "failed to update warm pool while trying update pool")
+			}
+		} else {
+			clusterScope.Info("deleting warm pool")
+			if err := asgSvc.DeleteWarmPool(existingASG.Name); err != nil {
+				return errors.Wrapf(err, "failed to delete warm pool while trying update pool")
 			}
-			delete(currentlySuspended, k)
 		}
+	}
+	return nil
+}
 
-		// Convert them back into lists to pass them to resume/suspend.
-		for k := range desiredSuspended {
-			toBeSuspended = append(toBeSuspended, k)
+// reconcileScaleInProtection protects the instances of the ASG from scale in, and removes the protection of
+// the instances whose node has been drained so that the ASG can terminate them.
+func (r *AWSMachinePoolReconciler) reconcileScaleInProtection(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgSvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup) error {
+	protected := sets.New[string](existingASG.InstancesProtectedFromScaleIn...)
+
+	if !machinePoolScope.AWSMachinePool.Spec.ScaleInProtection {
+		if protected.Len() == 0 {
+			return nil
 		}
+		machinePoolScope.Info("removing scale-in protection", "instances", sets.List(protected))
+		return asgSvc.SetInstanceProtection(existingASG.Name, sets.List(protected), false)
+	}
 
-		for k := range currentlySuspended {
-			toBeResumed = append(toBeResumed, k)
+	inService := make([]string, 0, len(existingASG.Instances))
+	for _, instance := range existingASG.Instances {
+		if instance.State == infrav1.InstanceState(autoscaling.LifecycleStateInService) {
+			inService = append(inService, instance.ID)
 		}
+	}
 
-		if len(toBeSuspended) > 0 {
-			clusterScope.Info("suspending processes", "processes", toBeSuspended)
-			if err := asgSvc.SuspendProcesses(existingASG.Name, toBeSuspended); err != nil {
-				return errors.Wrapf(err, "failed to suspend processes while trying update pool")
-			}
+	drainedInstanceIDs, err := machinePoolScope.DrainedInstanceIDs(ctx, inService)
+	if err != nil {
+		return errors.Wrap(err, "failed to get drained instances")
+	}
+	drained := sets.New[string](drainedInstanceIDs...)
+
+	var toBeProtected, toBeUnprotected []string
+	for _, id := range inService {
+		switch {
+		case drained.Has(id) && protected.Has(id):
+			toBeUnprotected = append(toBeUnprotected, id)
+		case !drained.Has(id) && !protected.Has(id):
+			toBeProtected = append(toBeProtected, id)
 		}
-		if len(toBeResumed) > 0 {
-			clusterScope.Info("resuming processes", "processes", toBeResumed)
-			if err := asgSvc.ResumeProcesses(existingASG.Name, toBeResumed); err != nil {
-				return errors.Wrapf(err, "failed to resume processes while trying update pool")
-			}
+	}
+
+	if len(toBeProtected) > 0 {
+		machinePoolScope.Info("protecting instances from scale in", "instances", toBeProtected)
+		if err := asgSvc.SetInstanceProtection(existingASG.Name, toBeProtected, true); err != nil {
+			return err
 		}
 	}
+	if len(toBeUnprotected) > 0 {
+		machinePoolScope.Info("removing scale-in protection of drained instances", "instances", toBeUnprotected)
+		if err := asgSvc.SetInstanceProtection(existingASG.Name, toBeUnprotected, false); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -509,9 +663,40 @@ func (r *AWSMachinePoolReconciler) createPool(machinePoolScope *scope.MachinePoo
 		return errors.Wrapf(err, "failed to create AWSMachinePool")
 	}
 
+	// The target groups and classic load balancers are attached when the ASG is created.
+	spec := machinePoolScope.AWSMachinePool.Spec
+	if err := setLastAppliedAttachments(machinePoolScope, TargetGroupsLastAppliedAnnotation, nil, spec.TargetGroupARNs); err != nil {
+		return err
+	}
+	if err := setLastAppliedAttachments(machinePoolScope, LoadBalancersLastAppliedAnnotation, nil, spec.LoadBalancerNames); err != nil {
+		return err
+	}
+
+	if warmPool := machinePoolScope.AWSMachinePool.Spec.WarmPool; warmPool != nil {
+		machinePoolScope.Info("Creating warm pool")
+		if err := asgsvc.PutWarmPool(machinePoolScope.Name(), warmPool); err != nil {
+			return errors.Wrapf(err, "failed to create warm pool")
+		}
+	}
+
+	if metricsCollection := machinePoolScope.AWSMachinePool.Spec.MetricsCollection; metricsCollection != nil {
+		machinePoolScope.Info("Enabling metrics collection")
+		if err := asgsvc.EnableMetricsCollection(machinePoolScope.Name(), metricsGranularity(metricsCollection), metricsCollection.MetricNames()); err != nil {
+			return errors.Wrapf(err, "failed to enable metrics collection")
+		}
+	}
+
 	return nil
 }
 
+// metricsGranularity returns the granularity of the group metrics, defaulting to one minute.
+func metricsGranularity(metricsCollection *expinfrav1.MetricsCollection) string {
+	if metricsCollection == nil || metricsCollection.Granularity == "" {
+		return expinfrav1.MetricsGranularityOneMinute
+	}
+	return metricsCollection.Granularity
+}
+
 func (r *AWSMachinePoolReconciler) findASG(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) (*expinfrav1.AutoScalingGroup, error) {
 	// Query the instance using tags.
 	asg, err := asgsvc.GetASGByName(machinePoolScope)
@@ -522,6 +707,14 @@ func (r *AWSMachinePoolReconciler) findASG(machinePoolScope *scope.MachinePoolSc
 	return asg, nil
 }
 
+// diffSets returns the sorted items which are desired but not current, and the items which are current
+// but no longer desired.
+func diffSets(currentItems, desiredItems []string) (toBeAdded, toBeRemoved []string) {
+	current := sets.New[string](currentItems...)
+	desired := sets.New[string](desiredItems...)
+	return sets.List(desired.Difference(current)), sets.List(current.Difference(desired))
+}
+
 // diffASG compares incoming AWSMachinePool and compares against existing ASG.
 func diffASG(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) string {
 	detectedMachinePoolSpec := machinePoolScope.MachinePool.Spec.DeepCopy()
@@ -537,6 +730,8 @@ func diffASG(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.A
 	detectedAWSMachinePoolSpec.MaxSize = existingASG.MaxSize
 	detectedAWSMachinePoolSpec.MinSize = existingASG.MinSize
 	detectedAWSMachinePoolSpec.CapacityRebalance = existingASG.CapacityRebalance
+	detectedAWSMachinePoolSpec.ScaleInProtection = existingASG.NewInstancesProtectedFromScaleIn
+	detectedAWSMachinePoolSpec.MaxInstanceLifetime = existingASG.MaxInstanceLifetime
 	{
 		mixedInstancesPolicy := machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy
 		// InstancesDistribution is optional, and the default values come from AWS, so
@@ -555,6 +750,21 @@ func diffASG(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.A
 	return cmp.Diff(machinePoolScope.AWSMachinePool.Spec, *detectedAWSMachinePoolSpec)
 }
 
+// diffWarmPool returns the difference between the desired warm pool and the warm pool of the existing ASG,
+// using the values AWS reports for the unset fields.
+func diffWarmPool(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) string {
+	warmPool := machinePoolScope.AWSMachinePool.Spec.WarmPool.DeepCopy()
+	if warmPool != nil {
+		if warmPool.PoolState == "" {
+			warmPool.PoolState = expinfrav1.WarmPoolStateStopped
+		}
+		if warmPool.InstanceReusePolicy != nil && !warmPool.InstanceReusePolicy.ReuseOnScaleIn {
+			warmPool.InstanceReusePolicy = nil
+		}
+	}
+	return cmp.Diff(warmPool, existingASG.WarmPool)
+}
+
 // getOwnerMachinePool returns the MachinePool object owning the current resource.
 func getOwnerMachinePool(ctx context.Context, c client.Client, obj metav1.ObjectMeta) (*expclusterv1.MachinePool, error) {
 	for _, ref := range obj.OwnerReferences {
@@ -0,0 +1,288 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// launchTemplateVersionTag is the tag set by EC2 on the instances launched from a launch template.
+const launchTemplateVersionTag = "aws:ec2launchtemplate:version"
+
+const (
+	// instantFleetLaunchTimeout is the maximum time the instances launched with an instant fleet are counted as
+	// instances of the pool while they are not listed by EC2, e.g. because they were terminated right away.
+	instantFleetLaunchTimeout = 5 * time.Minute
+
+	// instantFleetLaunchPollInterval is the interval at which an AWSMachinePool is requeued until the instances
+	// launched with an instant fleet are listed by EC2.
+	instantFleetLaunchPollInterval = 10 * time.Second
+)
+
+// reconcileFleet launches the instances of the AWSMachinePool with EC2 Fleets instead of an ASG.
+func (r *AWSMachinePoolReconciler) reconcileFleet(ctx context.Context, machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) error {
+	ec2Svc := r.getEC2Service(ec2Scope)
+	asgSvc := r.getASGService(clusterScope)
+	reconSvc := r.getReconcileService(ec2Scope)
+
+	// The fleets launch the new instances from the latest version of the launch template, the
+	// running instances are not replaced when it changes.
+	canUpdateLaunchTemplate := func() (bool, error) {
+		return true, nil
+	}
+	runPostLaunchTemplateUpdateOperation := func() error {
+		return nil
+	}
+	if err := reconSvc.ReconcileLaunchTemplate(machinePoolScope, ec2Svc, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
+		machinePoolScope.Error(err, "failed to reconcile launch template")
+		return err
+	}
+
+	// set the LaunchTemplateReady condition
+	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.LaunchTemplateReadyCondition)
+
+	launchTemplateID := machinePoolScope.GetLaunchTemplateIDStatus()
+	instances, err := ec2Svc.GetFleetInstances(launchTemplateID)
+	if err != nil {
+		conditions.MarkUnknown(machinePoolScope.AWSMachinePool, expinfrav1.EC2FleetReadyCondition, expinfrav1.EC2FleetProvisionFailedReason, err.Error())
+		return err
+	}
+
+	fleet := machinePoolScope.AWSMachinePool.Spec.EC2Fleet
+	replicas := int64(ptr.Deref(machinePoolScope.MachinePool.Spec.Replicas, 1))
+	onDemand := min(ptr.Deref(fleet.OnDemandTargetCapacity, 0), replicas)
+
+	switch fleet.GetType() {
+	case expinfrav1.EC2FleetTypeInstant:
+		err = r.reconcileInstantFleet(machinePoolScope, ec2Svc, asgSvc, instances, replicas, onDemand)
+	default:
+		err = r.reconcileMaintainFleet(machinePoolScope, ec2Svc, asgSvc, replicas, onDemand)
+	}
+	if err != nil {
+		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.EC2FleetReadyCondition, expinfrav1.EC2FleetProvisionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	if err := reconSvc.ReconcileTags(machinePoolScope, []scope.ResourceServiceToUpdate{
+		{
+			ResourceID:      &launchTemplateID,
+			ResourceService: ec2Svc,
+		},
+	}); err != nil {
+		return errors.Wrap(err, "error updating tags")
+	}
+
+	// The instant fleets are deleted once they launched the instances, only maintain fleets identify the pool.
+	if fleet.GetType() == expinfrav1.EC2FleetTypeMaintain {
+		machinePoolScope.AWSMachinePool.Spec.ProviderID = machinePoolScope.AWSMachinePool.Status.FleetID
+	}
+	providerIDList := make([]string, len(instances))
+	for i, instance := range instances {
+		providerIDList[i] = fmt.Sprintf("aws:///%s/%s", instance.AvailabilityZone, instance.ID)
+	}
+
+	machinePoolScope.SetAnnotation("cluster-api-provider-aws", "true")
+
+	machinePoolScope.AWSMachinePool.Spec.ProviderIDList = providerIDList
+	machinePoolScope.AWSMachinePool.Status.Replicas = int32(len(providerIDList))
+	machinePoolScope.AWSMachinePool.Status.Ready = true
+	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.EC2FleetReadyCondition)
+
+	if err := machinePoolScope.UpdateInstanceStatuses(ctx, instances); err != nil {
+		machinePoolScope.Error(err, "failed updating instances", "instances", instances)
+	}
+
+	return nil
+}
+
+// reconcileMaintainFleet creates the fleet of type maintain of the pool, or updates its target capacity.
+func (r *AWSMachinePoolReconciler) reconcileMaintainFleet(machinePoolScope *scope.MachinePoolScope, ec2Svc services.EC2Interface, asgSvc services.ASGInterface, replicas, onDemand int64) error {
+	var existing *expinfrav1.Fleet
+	if fleetID := machinePoolScope.AWSMachinePool.Status.FleetID; fleetID != "" {
+		var err error
+		if existing, err = ec2Svc.GetFleet(fleetID); err != nil {
+			return err
+		}
+	}
+
+	if existing == nil {
+		subnetIDs, err := asgSvc.SubnetIDs(machinePoolScope)
+		if err != nil {
+			return errors.Wrap(err, "failed to get subnets of the EC2 Fleet")
+		}
+
+		machinePoolScope.Info("Creating EC2 Fleet", "replicas", replicas)
+		fleetID, _, err := ec2Svc.CreateFleet(machinePoolScope, subnetIDs, replicas, onDemand)
+		if err != nil {
+			return err
+		}
+		machinePoolScope.AWSMachinePool.Status.FleetID = fleetID
+		return nil
+	}
+
+	if existing.TotalTargetCapacity == replicas && existing.OnDemandTargetCapacity == onDemand {
+		return nil
+	}
+	if existing.State == "modifying" {
+		machinePoolScope.Debug("EC2 Fleet is being modified, skipping target capacity update", "fleet-id", existing.ID)
+		return nil
+	}
+
+	machinePoolScope.Info("Updating EC2 Fleet target capacity", "fleet-id", existing.ID, "from", existing.TotalTargetCapacity, "to", replicas)
+	return ec2Svc.ModifyFleetTargetCapacity(existing.ID, replicas, onDemand)
+}
+
+// reconcileInstantFleet launches the missing instances of the pool with a new instant fleet, or terminates
+// the excess instances, starting with the ones launched from older versions of the launch template.
+func (r *AWSMachinePoolReconciler) reconcileInstantFleet(machinePoolScope *scope.MachinePoolScope, ec2Svc services.EC2Interface, asgSvc services.ASGInterface, instances []infrav1.Instance, replicas, onDemand int64) error {
+	// The instances launched by a previous reconciliation may not be listed by EC2 yet, they are counted as
+	// instances of the pool so that they are not launched twice.
+	pending := pendingInstantFleetInstances(machinePoolScope, instances)
+	missing := replicas - int64(len(instances)) - int64(len(pending))
+
+	switch {
+	case missing > 0:
+		runningOnDemand := int64(0)
+		for _, instance := range instances {
+			if instance.InstanceLifecycle == infrav1.InstanceLifecycleOnDemand {
+				runningOnDemand++
+			}
+		}
+
+		subnetIDs, err := asgSvc.SubnetIDs(machinePoolScope)
+		if err != nil {
+			return errors.Wrap(err, "failed to get subnets of the EC2 Fleet")
+		}
+
+		machinePoolScope.Info("Launching instances with EC2 Fleet", "count", missing)
+		_, launched, err := ec2Svc.CreateFleet(machinePoolScope, subnetIDs, missing, min(max(onDemand-runningOnDemand, 0), missing))
+		if err != nil {
+			return err
+		}
+		machinePoolScope.AWSMachinePool.Status.InstantFleetLaunch = &expinfrav1.InstantFleetLaunchStatus{
+			InstanceIDs: append(pending, launched...),
+			Time:        metav1.Now(),
+		}
+	case missing < 0:
+		for _, instance := range instancesToTerminate(instances, machinePoolScope.AWSMachinePool.Status.LaunchTemplateVersion, -missing) {
+			machinePoolScope.Info("Terminating excess instance", "instance-id", instance.ID)
+			if err := ec2Svc.TerminateInstance(instance.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// pendingInstantFleetInstances returns the IDs of the instances launched with an instant fleet which are not
+// listed by EC2 yet, and forgets the latest launch once all its instances are listed or once it timed out.
+func pendingInstantFleetInstances(machinePoolScope *scope.MachinePoolScope, instances []infrav1.Instance) []string {
+	launch := machinePoolScope.AWSMachinePool.Status.InstantFleetLaunch
+	if launch == nil {
+		return nil
+	}
+
+	listed := sets.New[string]()
+	for _, instance := range instances {
+		listed.Insert(instance.ID)
+	}
+	var pending []string
+	for _, id := range launch.InstanceIDs {
+		if !listed.Has(id) {
+			pending = append(pending, id)
+		}
+	}
+
+	if len(pending) == 0 || time.Since(launch.Time.Time) > instantFleetLaunchTimeout {
+		machinePoolScope.AWSMachinePool.Status.InstantFleetLaunch = nil
+		return nil
+	}
+	machinePoolScope.Debug("Instances launched with EC2 Fleet not listed yet", "instance-ids", pending)
+	launch.InstanceIDs = pending
+	return pending
+}
+
+// instancesToTerminate returns the given number of instances, preferring the ones which weren't launched
+// from the latest version of the launch template.
+func instancesToTerminate(instances []infrav1.Instance, latestVersion *string, count int64) []infrav1.Instance {
+	sorted := make([]infrav1.Instance, len(instances))
+	copy(sorted, instances)
+
+	isOutdated := func(instance infrav1.Instance) bool {
+		return latestVersion != nil && instance.Tags[launchTemplateVersionTag] != *latestVersion
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if isOutdated(sorted[i]) != isOutdated(sorted[j]) {
+			return isOutdated(sorted[i])
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	return sorted[:min(count, int64(len(sorted)))]
+}
+
+// deleteFleet deletes the fleet of type maintain of the pool and terminates the instances launched
+// from the launch template of the pool.
+func (r *AWSMachinePoolReconciler) deleteFleet(machinePoolScope *scope.MachinePoolScope, ec2Svc services.EC2Interface) error {
+	if fleetID := machinePoolScope.AWSMachinePool.Status.FleetID; fleetID != "" {
+		machinePoolScope.Info("Deleting EC2 Fleet", "fleet-id", fleetID)
+		if err := ec2Svc.DeleteFleet(fleetID); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete EC2 Fleet %q: %v", fleetID, err)
+			return errors.Wrap(err, "failed to delete EC2 Fleet")
+		}
+	}
+
+	launchTemplateID := machinePoolScope.GetLaunchTemplateIDStatus()
+	if launchTemplateID == "" {
+		return nil
+	}
+
+	instances, err := ec2Svc.GetFleetInstances(launchTemplateID)
+	if err != nil {
+		return err
+	}
+	for _, instance := range instances {
+		machinePoolScope.Info("Terminating instance", "instance-id", instance.ID)
+		if err := ec2Svc.TerminateInstance(instance.ID); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to terminate instance %q: %v", instance.ID, err)
+			return errors.Wrap(err, "failed to terminate instance")
+		}
+	}
+
+	conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.EC2FleetReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+)
+
+const (
+	// TargetGroupsLastAppliedAnnotation is the key for the AWSMachinePool object
+	// annotation which tracks the target groups that the AWSMachinePool actuator
+	// attached to the ASG. The target groups attached outside of CAPA are never
+	// detached from the ASG.
+	TargetGroupsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws-last-applied-target-groups"
+
+	// LoadBalancersLastAppliedAnnotation is the key for the AWSMachinePool object
+	// annotation which tracks the classic load balancers that the AWSMachinePool
+	// actuator attached to the ASG. The classic load balancers attached outside of
+	// CAPA are never detached from the ASG.
+	LoadBalancersLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws-last-applied-load-balancers"
+)
+
+// reconcileLoadBalancers attaches the target groups and the classic load balancers of the AWSMachinePool
+// to the ASG, and detaches the ones attached by CAPA which were removed from the AWSMachinePool.
+func (r *AWSMachinePoolReconciler) reconcileLoadBalancers(machinePoolScope *scope.MachinePoolScope, asgSvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup) error {
+	spec := machinePoolScope.AWSMachinePool.Spec
+
+	if err := reconcileAttachments(machinePoolScope, TargetGroupsLastAppliedAnnotation, existingASG.TargetGroupARNs, spec.TargetGroupARNs,
+		func(targetGroupARNs []string) error {
+			machinePoolScope.Info("attaching target groups", "target-groups", targetGroupARNs)
+			return asgSvc.AttachLoadBalancerTargetGroups(existingASG.Name, targetGroupARNs)
+		},
+		func(targetGroupARNs []string) error {
+			machinePoolScope.Info("detaching target groups", "target-groups", targetGroupARNs)
+			return asgSvc.DetachLoadBalancerTargetGroups(existingASG.Name, targetGroupARNs)
+		},
+	); err != nil {
+		return errors.Wrap(err, "failed to reconcile target groups")
+	}
+
+	if err := reconcileAttachments(machinePoolScope, LoadBalancersLastAppliedAnnotation, existingASG.LoadBalancerNames, spec.LoadBalancerNames,
+		func(loadBalancerNames []string) error {
+			machinePoolScope.Info("attaching classic load balancers", "load-balancers", loadBalancerNames)
+			return asgSvc.AttachLoadBalancers(existingASG.Name, loadBalancerNames)
+		},
+		func(loadBalancerNames []string) error {
+			machinePoolScope.Info("detaching classic load balancers", "load-balancers", loadBalancerNames)
+			return asgSvc.DetachLoadBalancers(existingASG.Name, loadBalancerNames)
+		},
+	); err != nil {
+		return errors.Wrap(err, "failed to reconcile classic load balancers")
+	}
+
+	return nil
+}
+
+// reconcileAttachments attaches the desired items which are not attached yet, detaches the items which are
+// no longer desired if they were attached by CAPA, and records the desired items in the annotation.
+func reconcileAttachments(machinePoolScope *scope.MachinePoolScope, annotation string, current, desired []string, attach, detach func([]string) error) error {
+	lastApplied, err := ec2.MachinePoolAnnotationJSON(machinePoolScope, annotation)
+	if err != nil {
+		return err
+	}
+
+	toBeAttached, toBeRemoved := diffSets(current, desired)
+	toBeDetached := make([]string, 0, len(toBeRemoved))
+	for _, item := range toBeRemoved {
+		if _, ok := lastApplied[item]; ok {
+			toBeDetached = append(toBeDetached, item)
+		}
+	}
+
+	if len(toBeAttached) > 0 {
+		if err := attach(toBeAttached); err != nil {
+			return err
+		}
+	}
+	if len(toBeDetached) > 0 {
+		if err := detach(toBeDetached); err != nil {
+			return err
+		}
+	}
+
+	return setLastAppliedAttachments(machinePoolScope, annotation, lastApplied, desired)
+}
+
+// setLastAppliedAttachments records the items attached by CAPA in the annotation, if they changed.
+func setLastAppliedAttachments(machinePoolScope *scope.MachinePoolScope, annotation string, lastApplied map[string]interface{}, desired []string) error {
+	if sets.KeySet(lastApplied).Equal(sets.New[string](desired...)) {
+		return nil
+	}
+
+	newAnnotation := make(map[string]interface{}, len(desired))
+	for _, item := range desired {
+		newAnnotation[item] = struct{}{}
+	}
+	return ec2.UpdateMachinePoolAnnotationJSON(machinePoolScope, annotation, newAnnotation)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// terminationDrainPollInterval is the interval at which the termination lifecycle actions of an AWSMachinePool
+// are received.
+const terminationDrainPollInterval = 30 * time.Second
+
+// reconcileTerminationDrain manages the termination lifecycle hook of the ASG, and lets the ASG terminate the
+// instances whose node has been cordoned and drained.
+func (r *AWSMachinePoolReconciler) reconcileTerminationDrain(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgSvc services.ASGInterface) error {
+	if machinePoolScope.AWSMachinePool.Spec.TerminationDrain == nil {
+		return r.deleteTerminationDrain(machinePoolScope, asgSvc)
+	}
+
+	if err := asgSvc.ReconcileTerminationLifecycleHook(machinePoolScope); err != nil {
+		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.TerminationDrainReadyCondition, expinfrav1.TerminationDrainReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.TerminationDrainReadyCondition)
+
+	actions, err := asgSvc.GetTerminationLifecycleActions(machinePoolScope)
+	if err != nil {
+		return errors.Wrap(err, "failed to get termination lifecycle actions")
+	}
+
+	// The instances are drained one at a time: the evictions of the pods of an instance are requested, and its
+	// lifecycle action is completed on a later reconciliation, once its pods are gone.
+	for _, action := range actions {
+		drained, err := machinePoolScope.DrainInstance(ctx, action.InstanceID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to drain instance %q", action.InstanceID)
+		}
+		if !drained {
+			return nil
+		}
+
+		machinePoolScope.Info("Completing termination lifecycle action of drained instance", "instance-id", action.InstanceID)
+		if err := asgSvc.CompleteTerminationLifecycleAction(action); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteTerminationDrain deletes the termination lifecycle hook of the ASG, if it was created.
+func (r *AWSMachinePoolReconciler) deleteTerminationDrain(machinePoolScope *scope.MachinePoolScope, asgSvc services.ASGInterface) error {
+	if !conditions.Has(machinePoolScope.AWSMachinePool, expinfrav1.TerminationDrainReadyCondition) {
+		return nil
+	}
+
+	if err := asgSvc.DeleteTerminationLifecycleHook(machinePoolScope); err != nil {
+		return errors.Wrap(err, "failed to delete termination lifecycle hook")
+	}
+	conditions.Delete(machinePoolScope.AWSMachinePool, expinfrav1.TerminationDrainReadyCondition)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+)
+
+// bootstrapTokenTTL is the default time to live of the bootstrap tokens of the kubeadm bootstrap provider.
+// An instance which did not register a node within it can't join the cluster anymore once its token is rotated.
+const bootstrapTokenTTL = 15 * time.Minute
+
+// reconcileUnregisteredInstances replaces the in service instances which did not register a node before their
+// bootstrap token expired. The instances which registered a node are kept, even if the bootstrap data changed.
+func (r *AWSMachinePoolReconciler) reconcileUnregisteredInstances(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgSvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup) error {
+	if !machinePoolScope.RefreshOnBootstrapDataChange() {
+		return nil
+	}
+
+	inService := make([]string, 0, len(existingASG.Instances))
+	for _, instance := range existingASG.Instances {
+		if instance.State == infrav1.InstanceState(autoscaling.LifecycleStateInService) {
+			inService = append(inService, instance.ID)
+		}
+	}
+
+	unregistered, err := machinePoolScope.UnregisteredInstanceIDs(ctx, inService)
+	if err != nil {
+		return errors.Wrap(err, "failed to get unregistered instances")
+	}
+	if len(unregistered) == 0 {
+		return nil
+	}
+
+	launchTimes, err := asgSvc.GetInstanceLaunchTimes(unregistered)
+	if err != nil {
+		return err
+	}
+	for _, id := range unregistered {
+		launchTime, ok := launchTimes[id]
+		if !ok || time.Since(launchTime) < bootstrapTokenTTL {
+			continue
+		}
+		machinePoolScope.Info("replacing instance which did not register a node before its bootstrap token expired", "instance-id", id, "launch-time", launchTime)
+		if err := asgSvc.ReplaceInstance(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
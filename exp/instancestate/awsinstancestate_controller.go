@@ -56,10 +56,13 @@ type AwsInstanceStateReconciler struct {
 	queueURLs         sync.Map
 	Endpoints         []scope.ServiceEndpoint
 	WatchFilterValue  string
+	// SpotInterruptionHandling drains the nodes of the interrupted Spot instances and deletes their machines.
+	SpotInterruptionHandling bool
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;delete
 
 func (r *AwsInstanceStateReconciler) getSQSService(region string) (sqsiface.SQSAPI, error) {
 	if r.sqsServiceFactory != nil {
@@ -177,7 +180,16 @@ func (r *AwsInstanceStateReconciler) watchQueuesForInstanceEvents() {
 
 // processMessage triggers a reconcile on an AWSMachine if its EC2 instance state changed.
 func (r *AwsInstanceStateReconciler) processMessage(ctx context.Context, msg message) {
-	if msg.Source != "aws.ec2" || msg.DetailType != instancestate.Ec2StateChangeNotification || msg.MessageDetail == nil {
+	if msg.Source != "aws.ec2" || msg.MessageDetail == nil {
+		return
+	}
+	if msg.DetailType == instancestate.Ec2SpotInterruptionWarning && r.SpotInterruptionHandling {
+		if err := r.processSpotInterruptionWarning(ctx, msg.MessageDetail.InstanceID); err != nil {
+			r.Log.Error(err, "unable to handle Spot interruption warning", "instanceID", msg.MessageDetail.InstanceID)
+		}
+		return
+	}
+	if msg.DetailType != instancestate.Ec2StateChangeNotification {
 		return
 	}
 
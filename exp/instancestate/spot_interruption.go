@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+// processSpotInterruptionWarning drains the node of an interrupted Spot instance, and deletes its machine so
+// that it gets replaced before the instance is reclaimed. Only the machines owned by a MachineSet are deleted.
+func (r *AwsInstanceStateReconciler) processSpotInterruptionWarning(ctx context.Context, instanceID string) error {
+	awsMachines := &infrav1.AWSMachineList{}
+	if err := r.List(ctx, awsMachines, client.MatchingFields{controllers.InstanceIDIndex: instanceID}); err != nil {
+		return errors.Wrap(err, "unable to list machines by instance ID")
+	}
+	if len(awsMachines.Items) == 0 {
+		// the instance isn't backing a machine of this management cluster
+		return nil
+	}
+
+	awsMachine := awsMachines.Items[0]
+	if !awsMachine.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	machine, err := util.GetOwnerMachine(ctx, r.Client, awsMachine.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "unable to get owner machine")
+	}
+	if machine == nil || !machine.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	r.Log.Info("Spot instance is going to be interrupted", "instanceID", instanceID, "machine", machine.Name)
+
+	if machine.Status.NodeRef != nil {
+		if err := r.drainNode(ctx, machine); err != nil {
+			// the node is drained by the machine deletion as well
+			r.Log.Error(err, "unable to drain node", "node", machine.Status.NodeRef.Name)
+		}
+	}
+
+	if util.IsControlPlaneMachine(machine) || !isOwnedByMachineSet(machine) {
+		return nil
+	}
+	if err := r.Delete(ctx, machine); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "unable to delete machine %s", machine.Name)
+	}
+
+	return nil
+}
+
+// drainNode cordons the node of the machine and evicts its pods.
+func (r *AwsInstanceStateReconciler) drainNode(ctx context.Context, machine *clusterv1.Machine) error {
+	restConfig, err := remote.RESTConfig(ctx, "awsinstancestate", r.Client, client.ObjectKey{Namespace: machine.Namespace, Name: machine.Spec.ClusterName})
+	if err != nil {
+		return err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to create workload cluster client")
+	}
+
+	node, err := kubeClient.CoreV1().Nodes().Get(ctx, machine.Status.NodeRef.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get node %q", machine.Status.NodeRef.Name)
+	}
+
+	_, err = scope.DrainNode(ctx, kubeClient, node, r.Log.Info)
+	return err
+}
+
+func isOwnedByMachineSet(machine *clusterv1.Machine) bool {
+	for _, ref := range machine.OwnerReferences {
+		if ref.Kind == "MachineSet" {
+			return true
+		}
+	}
+	return false
+}
@@ -59,6 +59,11 @@ const (
 	// alpha: v0.7?
 	EventBridgeInstanceState featuregate.Feature = "EventBridgeInstanceState"
 
+	// SpotInterruptionHandling will cordon and drain the nodes of the Spot instances receiving an interruption warning,
+	// and delete their Machine so that it is replaced. It requires EventBridgeInstanceState.
+	// alpha: v2.5
+	SpotInterruptionHandling featuregate.Feature = "SpotInterruptionHandling"
+
 	// AutoControllerIdentityCreator will create AWSClusterControllerIdentity instance that allows all namespaces to use it.
 	// owner: @sedefsavas
 	// alpha: v0.6
@@ -101,6 +106,7 @@ var defaultCAPAFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	EKSAllowAddRoles:              {Default: false, PreRelease: featuregate.Beta},
 	EKSFargate:                    {Default: false, PreRelease: featuregate.Alpha},
 	EventBridgeInstanceState:      {Default: false, PreRelease: featuregate.Alpha},
+	SpotInterruptionHandling:      {Default: false, PreRelease: featuregate.Alpha},
 	MachinePool:                   {Default: true, PreRelease: featuregate.Beta},
 	AutoControllerIdentityCreator: {Default: true, PreRelease: featuregate.Alpha},
 	BootstrapFormatIgnition:       {Default: false, PreRelease: featuregate.Alpha},
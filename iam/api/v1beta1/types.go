@@ -126,6 +126,21 @@ func (actions *Actions) UnmarshalJSON(data []byte) error {
 // Resources is the list of resources.
 type Resources []string
 
+// UnmarshalJSON is a Resources Unmarshaler.
+func (resources *Resources) UnmarshalJSON(data []byte) error {
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err == nil {
+		*resources = Resources(ids)
+		return nil
+	}
+	var id string
+	if err := json.Unmarshal(data, &id); err != nil {
+		return errors.Wrap(err, "couldn't unmarshal as either []string or string")
+	}
+	*resources = []string{id}
+	return nil
+}
+
 // PrincipalID represents the list of all identities, such as ARNs.
 type PrincipalID []string
 
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides an in-memory implementation of the AWS services used by the controllers,
+// so that they can be exercised end-to-end without an AWS account.
+package fake
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// dnsSuffix is the suffix of the DNS names of the fake load balancers and EKS clusters.
+	dnsSuffix = ".fake.amazonaws.com"
+	// accountID is the ID of the AWS account owning the fake resources.
+	accountID = "123456789012"
+)
+
+// errNotSupported is returned by the methods the fake cloud does not implement.
+var errNotSupported = errors.New("not supported by the fake cloud")
+
+// Cloud holds the state of the fake AWS resources. The services it provides can be shared by all
+// the controllers of a manager, and are safe for concurrent use.
+type Cloud struct {
+	mu sync.Mutex
+
+	nextID        int
+	instances     map[string]*infrav1.Instance
+	loadBalancers map[string]*loadBalancer
+	secrets       map[string][]byte
+	objects       map[string][]byte
+	eksClusters   map[string]clusterv1.APIEndpoint
+	hostedZones   map[string]*hostedZone
+}
+
+type loadBalancer struct {
+	infrav1.LoadBalancer
+	ip             net.IP
+	targetGroupARN string
+	instances      map[string]bool
+}
+
+// NewCloud creates a fake cloud without any resource.
+func NewCloud() *Cloud {
+	return &Cloud{
+		instances:     map[string]*infrav1.Instance{},
+		loadBalancers: map[string]*loadBalancer{},
+		secrets:       map[string][]byte{},
+		objects:       map[string][]byte{},
+		eksClusters:   map[string]clusterv1.APIEndpoint{},
+		hostedZones:   map[string]*hostedZone{},
+	}
+}
+
+// LookupIP resolves the DNS names of the fake load balancers and of the control plane records pointing at
+// them. Other names are resolved with net.LookupIP.
+func (c *Cloud) LookupIP(host string) ([]net.IP, error) {
+	name := c.resolveRecord(host)
+	if !strings.HasSuffix(name, dnsSuffix) {
+		return net.LookupIP(name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, lb := range c.loadBalancers {
+		if lb.DNSName == name {
+			return []net.IP{lb.ip}, nil
+		}
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// resolveRecord returns the DNS name of the load balancer a control plane record points at, or the given
+// name when there is no such record.
+func (c *Cloud) resolveRecord(name string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, zone := range c.hostedZones {
+		if target, ok := zone.records[strings.TrimSuffix(name, ".")]; ok {
+			return target
+		}
+	}
+	return name
+}
+
+// newID returns a new resource ID with the given prefix, such as "i" or "vpc". It must be called
+// with the lock held.
+func (c *Cloud) newID(prefix string) string {
+	c.nextID++
+	return fmt.Sprintf("%s-%017x", prefix, c.nextID)
+}
+
+// newIP returns a new private IP address. It must be called with the lock held.
+func (c *Cloud) newIP() net.IP {
+	c.nextID++
+	return net.IPv4(10, byte(c.nextID>>16), byte(c.nextID>>8), byte(c.nextID))
+}
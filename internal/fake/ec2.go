@@ -0,0 +1,544 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	defaultInstanceType        = "t3.large"
+	defaultBastionInstanceType = "t3.micro"
+)
+
+type ec2Service struct {
+	cloud *Cloud
+	scope scope.EC2Scope
+}
+
+var _ services.EC2Interface = &ec2Service{}
+
+// EC2Service returns an EC2 service running the instances of the cluster in the fake cloud.
+// Launch templates are not supported.
+func (c *Cloud) EC2Service(ec2Scope scope.EC2Scope) services.EC2Interface {
+	return &ec2Service{cloud: c, scope: ec2Scope}
+}
+
+func (s *ec2Service) InstanceIfExists(id *string) (*infrav1.Instance, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	instance, ok := s.cloud.instances[*id]
+	if !ok {
+		return nil, ec2.ErrInstanceNotFoundByID
+	}
+	return instance.DeepCopy(), nil
+}
+
+func (s *ec2Service) CreateInstance(machineScope *scope.MachineScope, userData []byte, _ string) (*infrav1.Instance, error) {
+	securityGroupIDs, err := s.GetCoreSecurityGroups(machineScope)
+	if err != nil {
+		return nil, err
+	}
+	additionalSecurityGroupIDs, err := s.GetAdditionalSecurityGroupsIDs(machineScope.AWSMachine.Spec.AdditionalSecurityGroups)
+	if err != nil {
+		return nil, err
+	}
+	securityGroupIDs = append(securityGroupIDs, additionalSecurityGroupIDs...)
+
+	subnet, err := s.findSubnet(machineScope)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	spec := machineScope.AWSMachine.Spec
+	instance := &infrav1.Instance{
+		ID:                      s.cloud.newID("i"),
+		State:                   infrav1.InstanceStateRunning,
+		Type:                    spec.InstanceType,
+		SubnetID:                subnet.GetResourceID(),
+		ImageID:                 ptr.Deref(spec.AMI.ID, ""),
+		SSHKeyName:              spec.SSHKeyName,
+		SecurityGroupIDs:        securityGroupIDs,
+		UserData:                ptr.To(base64.StdEncoding.EncodeToString(userData)),
+		IAMProfile:              spec.IAMInstanceProfile,
+		RootVolume:              spec.RootVolume.DeepCopy(),
+		NonRootVolumes:          spec.NonRootVolumes,
+		NetworkInterfaces:       []string{s.cloud.newID("eni")},
+		AvailabilityZone:        subnet.AvailabilityZone,
+		InstanceMetadataOptions: spec.InstanceMetadataOptions.DeepCopy(),
+		Tags: infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.scope.Name(),
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        aws.String(machineScope.Name()),
+			Role:        aws.String(machineScope.Role()),
+			Additional:  machineScope.AdditionalTags(),
+		}),
+	}
+	if instance.Type == "" {
+		instance.Type = defaultInstanceType
+	}
+	instance.InstanceLifecycle = infrav1.InstanceLifecycleOnDemand
+	if spec.SpotMarketOptions != nil {
+		instance.InstanceLifecycle = infrav1.InstanceLifecycleSpot
+	}
+	if instance.ImageID == "" {
+		instance.ImageID = s.cloud.newID("ami")
+	}
+	if machineScope.TerminationProtection() {
+		instance.TerminationProtection = ptr.To(true)
+	}
+	s.setAddresses(instance, ptr.Deref(spec.PublicIP, false))
+
+	s.cloud.instances[instance.ID] = instance
+	return instance.DeepCopy(), nil
+}
+
+func (s *ec2Service) GetRunningInstanceByTags(machineScope *scope.MachineScope) (*infrav1.Instance, error) {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	for _, instance := range s.cloud.instances {
+		if instance.State != infrav1.InstanceStateRunning && instance.State != infrav1.InstanceStatePending {
+			continue
+		}
+		if instance.Tags["Name"] == machineScope.Name() &&
+			instance.Tags[infrav1.NameAWSClusterAPIRole] == machineScope.Role() &&
+			infrav1.Tags(instance.Tags).HasOwned(s.scope.Name()) {
+			return instance.DeepCopy(), nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *ec2Service) TerminateInstance(id string) error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	instance, ok := s.cloud.instances[id]
+	if !ok {
+		return errors.Errorf("failed to terminate instance with id %q: instance not found", id)
+	}
+	if ptr.Deref(instance.TerminationProtection, false) {
+		return errors.Errorf("failed to terminate instance with id %q: termination protection is enabled", id)
+	}
+	instance.State = infrav1.InstanceStateTerminated
+	for _, lb := range s.cloud.loadBalancers {
+		delete(lb.instances, id)
+	}
+	return nil
+}
+
+func (s *ec2Service) DisableTerminationProtection(instanceID string) error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	instance, ok := s.cloud.instances[instanceID]
+	if !ok {
+		return errors.Errorf("failed to disable termination protection of instance with id %q: instance not found", instanceID)
+	}
+	instance.TerminationProtection = nil
+	return nil
+}
+
+func (s *ec2Service) HibernateInstance(instanceID string) error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	instance, ok := s.cloud.instances[instanceID]
+	if !ok {
+		return errors.Errorf("failed to hibernate instance with id %q: instance not found", instanceID)
+	}
+	instance.State = infrav1.InstanceStateStopped
+	return nil
+}
+
+func (s *ec2Service) StartInstance(instanceID string) error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	instance, ok := s.cloud.instances[instanceID]
+	if !ok {
+		return errors.Errorf("failed to start instance with id %q: instance not found", instanceID)
+	}
+	instance.State = infrav1.InstanceStateRunning
+	return nil
+}
+
+func (s *ec2Service) CancelSpotInstanceRequests(instanceID string) error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	if _, ok := s.cloud.instances[instanceID]; !ok {
+		return errors.Errorf("failed to cancel spot instance requests of instance with id %q: instance not found", instanceID)
+	}
+	return nil
+}
+
+func (s *ec2Service) TerminateInstanceAndWait(instanceID string) error {
+	return s.TerminateInstance(instanceID)
+}
+
+func (s *ec2Service) GetAdditionalSecurityGroupsIDs(securityGroups []infrav1.AWSResourceReference) ([]string, error) {
+	ids := make([]string, 0, len(securityGroups))
+	for _, sg := range securityGroups {
+		if sg.ID == nil {
+			return nil, errors.Wrap(errNotSupported, "security groups can only be referenced by ID")
+		}
+		ids = append(ids, *sg.ID)
+	}
+	return ids, nil
+}
+
+func (s *ec2Service) GetSecurityGroupSelectorsIDs(selectors []infrav1.SecurityGroupSelector) ([]string, error) {
+	if len(selectors) > 0 {
+		return nil, errors.Wrap(errNotSupported, "security group selectors")
+	}
+	return nil, nil
+}
+
+func (s *ec2Service) GetCoreSecurityGroups(machineScope *scope.MachineScope) ([]string, error) {
+	roles := []infrav1.SecurityGroupRole{infrav1.SecurityGroupNode}
+	if !machineScope.IsEKSManaged() {
+		roles = append(roles, infrav1.SecurityGroupLB)
+	}
+	switch machineScope.Role() {
+	case "node":
+		if machineScope.IsEKSManaged() {
+			roles = append(roles, infrav1.SecurityGroupEKSNodeAdditional)
+		}
+	case "control-plane":
+		roles = append(roles, infrav1.SecurityGroupControlPlane)
+	default:
+		return nil, errors.Errorf("Unknown node role %q", machineScope.Role())
+	}
+
+	ids := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if id, ok := machineScope.AWSMachine.Spec.SecurityGroupOverrides[role]; ok {
+			ids = append(ids, id)
+			continue
+		}
+		sg, ok := s.scope.SecurityGroups()[role]
+		if !ok {
+			return nil, errors.Errorf("%s security group not available", role)
+		}
+		ids = append(ids, sg.ID)
+	}
+	return ids, nil
+}
+
+func (s *ec2Service) GetInstanceSecurityGroups(instanceID string) (map[string][]string, error) {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	instance, ok := s.cloud.instances[instanceID]
+	if !ok {
+		return nil, ec2.ErrInstanceNotFoundByID
+	}
+	groups := map[string][]string{}
+	for _, eni := range instance.NetworkInterfaces {
+		groups[eni] = append([]string{}, instance.SecurityGroupIDs...)
+	}
+	return groups, nil
+}
+
+func (s *ec2Service) UpdateInstanceSecurityGroups(id string, securityGroups []string) error {
+	return s.updateInstance(id, func(instance *infrav1.Instance) {
+		instance.SecurityGroupIDs = securityGroups
+	})
+}
+
+func (s *ec2Service) UpdateInstanceSourceDestCheck(id string, enabled bool) error {
+	return s.updateInstance(id, func(instance *infrav1.Instance) {
+		instance.SourceDestCheck = ptr.To(enabled)
+	})
+}
+
+func (s *ec2Service) UpdateResourceTags(resourceID *string, create, remove map[string]string) error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	// Only the tags of instances are recorded, the tags of other resources are ignored.
+	instance, ok := s.cloud.instances[aws.StringValue(resourceID)]
+	if !ok {
+		return nil
+	}
+	if instance.Tags == nil {
+		instance.Tags = infrav1.Tags{}
+	}
+	for k := range remove {
+		delete(instance.Tags, k)
+	}
+	for k, v := range create {
+		instance.Tags[k] = v
+	}
+	return nil
+}
+
+func (s *ec2Service) ModifyInstanceMetadataOptions(instanceID string, options *infrav1.InstanceMetadataOptions) error {
+	return s.updateInstance(instanceID, func(instance *infrav1.Instance) {
+		instance.InstanceMetadataOptions = options.DeepCopy()
+	})
+}
+
+func (s *ec2Service) GetConsoleOutput(instanceID string) (string, error) {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	if _, ok := s.cloud.instances[instanceID]; !ok {
+		return "", ec2.ErrInstanceNotFoundByID
+	}
+	return fmt.Sprintf("Fake console output of instance %s\n", instanceID), nil
+}
+
+func (s *ec2Service) GetConsoleScreenshot(instanceID string) ([]byte, error) {
+	return nil, errors.Errorf("console screenshots are not supported by the fake cloud, instance %q", instanceID)
+}
+
+func (s *ec2Service) GetAMIDeprecationTime(_ string) (*time.Time, error) {
+	return nil, nil
+}
+
+func (s *ec2Service) DetachSecurityGroupsFromNetworkInterface(_ []string, _ string) error {
+	return nil
+}
+
+func (s *ec2Service) DiscoverLaunchTemplateAMI(_ scope.LaunchTemplateScope) (*string, error) {
+	return nil, errors.Wrap(errNotSupported, "launch templates")
+}
+
+func (s *ec2Service) GetLaunchTemplate(_ string) (*expinfrav1.AWSLaunchTemplate, string, *apimachinerytypes.NamespacedName, error) {
+	return nil, "", nil, errors.Wrap(errNotSupported, "launch templates")
+}
+
+func (s *ec2Service) GetLaunchTemplateID(_ string) (string, error) {
+	return "", errors.Wrap(errNotSupported, "launch templates")
+}
+
+func (s *ec2Service) GetLaunchTemplateLatestVersion(_ string) (string, error) {
+	return "", errors.Wrap(errNotSupported, "launch templates")
+}
+
+func (s *ec2Service) CreateLaunchTemplate(_ scope.LaunchTemplateScope, _ *string, _ apimachinerytypes.NamespacedName, _ []byte) (string, error) {
+	return "", errors.Wrap(errNotSupported, "launch templates")
+}
+
+func (s *ec2Service) CreateLaunchTemplateVersion(_ string, _ scope.LaunchTemplateScope, _ *string, _ apimachinerytypes.NamespacedName, _ []byte) error {
+	return errors.Wrap(errNotSupported, "launch templates")
+}
+
+func (s *ec2Service) PruneLaunchTemplateVersions(_ string, _ int64) error {
+	return errors.Wrap(errNotSupported, "launch templates")
+}
+
+func (s *ec2Service) DeleteLaunchTemplate(_ string) error {
+	return errors.Wrap(errNotSupported, "launch templates")
+}
+
+func (s *ec2Service) LaunchTemplateNeedsUpdate(_ scope.LaunchTemplateScope, _ *expinfrav1.AWSLaunchTemplate, _ *expinfrav1.AWSLaunchTemplate) (bool, error) {
+	return false, errors.Wrap(errNotSupported, "launch templates")
+}
+
+func (s *ec2Service) GetFleet(_ string) (*expinfrav1.Fleet, error) {
+	return nil, errors.Wrap(errNotSupported, "EC2 Fleets")
+}
+
+func (s *ec2Service) GetFleetInstances(_ string) ([]infrav1.Instance, error) {
+	return nil, errors.Wrap(errNotSupported, "EC2 Fleets")
+}
+
+func (s *ec2Service) CreateFleet(_ *scope.MachinePoolScope, _ []string, _, _ int64) (string, []string, error) {
+	return "", nil, errors.Wrap(errNotSupported, "EC2 Fleets")
+}
+
+func (s *ec2Service) ModifyFleetTargetCapacity(_ string, _, _ int64) error {
+	return errors.Wrap(errNotSupported, "EC2 Fleets")
+}
+
+func (s *ec2Service) DeleteFleet(_ string) error {
+	return errors.Wrap(errNotSupported, "EC2 Fleets")
+}
+
+// ReconcileBastion runs the bastion instance of the cluster when it is enabled, and terminates it otherwise.
+func (s *ec2Service) ReconcileBastion() error {
+	if !s.scope.Bastion().Enabled {
+		return s.DeleteBastion()
+	}
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	if bastion := s.findBastion(); bastion != nil {
+		s.scope.SetBastionInstance(bastion.DeepCopy())
+		conditions.MarkTrue(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition)
+		return nil
+	}
+
+	subnets := s.scope.Subnets().FilterPublic()
+	if len(subnets) == 0 {
+		return errors.New("failed to run bastion: no public subnets available")
+	}
+
+	name := fmt.Sprintf("%s-bastion", s.scope.Name())
+	bastion := &infrav1.Instance{
+		ID:                s.cloud.newID("i"),
+		State:             infrav1.InstanceStateRunning,
+		Type:              s.scope.Bastion().InstanceType,
+		SubnetID:          subnets[0].GetResourceID(),
+		ImageID:           s.scope.Bastion().AMI,
+		SSHKeyName:        s.scope.SSHKeyName(),
+		NetworkInterfaces: []string{s.cloud.newID("eni")},
+		AvailabilityZone:  subnets[0].AvailabilityZone,
+		Tags: infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.scope.Name(),
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        aws.String(name),
+			Role:        aws.String(infrav1.BastionRoleTagValue),
+			Additional:  s.scope.AdditionalTags(),
+		}),
+	}
+	if sg, ok := s.scope.SecurityGroups()[infrav1.SecurityGroupBastion]; ok {
+		bastion.SecurityGroupIDs = []string{sg.ID}
+	}
+	if bastion.Type == "" {
+		bastion.Type = defaultBastionInstanceType
+	}
+	if bastion.ImageID == "" {
+		bastion.ImageID = s.cloud.newID("ami")
+	}
+	s.setAddresses(bastion, true)
+
+	s.cloud.instances[bastion.ID] = bastion
+	s.scope.SetBastionInstance(bastion.DeepCopy())
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition)
+	return nil
+}
+
+// DeletePlacementGroups does nothing, as the fake cloud has no placement groups.
+func (s *ec2Service) DeletePlacementGroups() error {
+	return nil
+}
+
+// DeleteBastion terminates the bastion instance of the cluster, if any.
+func (s *ec2Service) DeleteBastion() error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	if bastion := s.findBastion(); bastion != nil {
+		bastion.State = infrav1.InstanceStateTerminated
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	}
+	s.scope.SetBastionInstance(nil)
+	return nil
+}
+
+func (s *ec2Service) ReconcileElasticIPFromPublicPool(_ *infrav1.ElasticIPPool, _ *infrav1.Instance) error {
+	return nil
+}
+
+func (s *ec2Service) ReleaseElasticIP(_ string) error {
+	return nil
+}
+
+// findBastion returns the running bastion instance of the cluster. It must be called with the lock held.
+func (s *ec2Service) findBastion() *infrav1.Instance {
+	for _, instance := range s.cloud.instances {
+		if instance.State == infrav1.InstanceStateRunning &&
+			instance.Tags[infrav1.NameAWSClusterAPIRole] == infrav1.BastionRoleTagValue &&
+			infrav1.Tags(instance.Tags).HasOwned(s.scope.Name()) {
+			return instance
+		}
+	}
+	return nil
+}
+
+// findSubnet returns the subnet referenced by the machine, or the first subnet in its failure domain.
+func (s *ec2Service) findSubnet(machineScope *scope.MachineScope) (*infrav1.SubnetSpec, error) {
+	if ref := machineScope.AWSMachine.Spec.Subnet; ref != nil {
+		if ref.ID == nil {
+			return nil, errors.Wrap(errNotSupported, "subnets can only be referenced by ID")
+		}
+		if subnet := s.scope.Subnets().FindByID(*ref.ID); subnet != nil {
+			return subnet, nil
+		}
+		return nil, errors.Errorf("failed to run machine %q, subnet %q not found", machineScope.Name(), *ref.ID)
+	}
+
+	failureDomain := machineScope.Machine.Spec.FailureDomain
+	public := ptr.Deref(machineScope.AWSMachine.Spec.PublicIP, false)
+	for i, subnet := range s.scope.Subnets() {
+		if subnet.IsPublic != public || subnet.IsEdge() {
+			continue
+		}
+		if failureDomain != nil && subnet.AvailabilityZone != *failureDomain {
+			continue
+		}
+		return &s.scope.Subnets()[i], nil
+	}
+	return nil, errors.Errorf("failed to run machine %q, no subnets available", machineScope.Name())
+}
+
+// setAddresses assigns the addresses of the instance. It must be called with the lock held.
+func (s *ec2Service) setAddresses(instance *infrav1.Instance, public bool) {
+	privateIP := s.cloud.newIP().String()
+	instance.PrivateIP = ptr.To(privateIP)
+	instance.Addresses = []clusterv1.MachineAddress{
+		{Type: clusterv1.MachineInternalIP, Address: privateIP},
+		{Type: clusterv1.MachineInternalDNS, Address: fmt.Sprintf("ip-%s.%s.compute.internal", strings.ReplaceAll(privateIP, ".", "-"), s.scope.Region())},
+	}
+	if public {
+		publicIP := s.cloud.newIP().String()
+		instance.PublicIP = ptr.To(publicIP)
+		instance.Addresses = append(instance.Addresses, clusterv1.MachineAddress{Type: clusterv1.MachineExternalIP, Address: publicIP})
+	}
+}
+
+func (s *ec2Service) updateInstance(id string, update func(instance *infrav1.Instance)) error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	instance, ok := s.cloud.instances[id]
+	if !ok {
+		return ec2.ErrInstanceNotFoundByID
+	}
+	update(instance)
+	return nil
+}
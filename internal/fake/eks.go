@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+type eksService struct {
+	cloud *Cloud
+	scope *scope.ManagedControlPlaneScope
+}
+
+var _ services.EKSInterface = &eksService{}
+
+// EKSService returns an EKS service running the control plane of the cluster in the fake cloud. The control
+// plane is ready as soon as it is created, but no kubeconfig is generated as there is no API server behind it.
+func (c *Cloud) EKSService(managedScope *scope.ManagedControlPlaneScope) services.EKSInterface {
+	return &eksService{cloud: c, scope: managedScope}
+}
+
+func (s *eksService) ReconcileControlPlane(_ context.Context) error {
+	key := s.clusterKey()
+
+	s.cloud.mu.Lock()
+	endpoint, ok := s.cloud.eksClusters[key]
+	if !ok {
+		endpoint = clusterv1.APIEndpoint{
+			Host: fmt.Sprintf("%s.gr7.%s.eks%s", s.cloud.newID("eks"), s.scope.Region(), dnsSuffix),
+			Port: 443,
+		}
+		s.cloud.eksClusters[key] = endpoint
+	}
+	s.cloud.mu.Unlock()
+
+	s.scope.ControlPlane.Spec.ControlPlaneEndpoint = endpoint
+	s.scope.ControlPlane.Status.Ready = true
+
+	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.IAMControlPlaneRolesReadyCondition)
+	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition)
+	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSAddonsConfiguredCondition)
+	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSIdentityProviderConfiguredCondition)
+	return nil
+}
+
+func (s *eksService) DeleteControlPlane() error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	delete(s.cloud.eksClusters, s.clusterKey())
+	s.scope.ControlPlane.Status.Ready = false
+	return nil
+}
+
+func (s *eksService) clusterKey() string {
+	return s.scope.Region() + "/" + s.scope.KubernetesClusterName()
+}
+
+// workloadService stands for the services configuring the workload cluster of EKS control planes, which
+// does not exist in the fake cloud.
+type workloadService struct{}
+
+var (
+	_ services.AWSNodeInterface          = workloadService{}
+	_ services.KubeProxyInterface        = workloadService{}
+	_ services.IAMAuthenticatorInterface = workloadService{}
+)
+
+// AWSNodeService returns a service leaving the CNI of the workload cluster untouched.
+func (c *Cloud) AWSNodeService() services.AWSNodeInterface {
+	return workloadService{}
+}
+
+// KubeProxyService returns a service leaving kube-proxy of the workload cluster untouched.
+func (c *Cloud) KubeProxyService() services.KubeProxyInterface {
+	return workloadService{}
+}
+
+// IAMAuthenticatorService returns a service leaving the aws-iam-authenticator configuration of the workload
+// cluster untouched.
+func (c *Cloud) IAMAuthenticatorService() services.IAMAuthenticatorInterface {
+	return workloadService{}
+}
+
+func (workloadService) ReconcileCNI(_ context.Context) error {
+	return nil
+}
+
+func (workloadService) ReconcileKubeProxy(_ context.Context) error {
+	return nil
+}
+
+func (workloadService) ReconcileIAMAuthenticator(_ context.Context) error {
+	return nil
+}
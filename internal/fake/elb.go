@@ -0,0 +1,258 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/elb"
+)
+
+type elbService struct {
+	cloud *Cloud
+	scope scope.ELBScope
+}
+
+var _ services.ELBInterface = &elbService{}
+
+// ELBService returns an ELB service running the control plane load balancers of the cluster in the fake cloud.
+// Listeners, target groups and health checks are not modelled.
+func (c *Cloud) ELBService(elbScope scope.ELBScope) services.ELBInterface {
+	return &elbService{cloud: c, scope: elbScope}
+}
+
+func (s *elbService) ReconcileLoadbalancers() error {
+	var errs []error
+	for i, lbSpec := range s.scope.ControlPlaneLoadBalancers() {
+		if lbSpec == nil {
+			continue
+		}
+		status := &s.scope.Network().APIServerELB
+		if i > 0 {
+			status = &s.scope.Network().SecondaryAPIServerELB
+		}
+		errs = append(errs, s.reconcileLoadBalancer(lbSpec, status))
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+func (s *elbService) reconcileLoadBalancer(lbSpec *infrav1.AWSLoadBalancerSpec, status *infrav1.LoadBalancer) error {
+	name, err := s.loadBalancerName(lbSpec)
+	if err != nil {
+		return err
+	}
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	lb, ok := s.cloud.loadBalancers[name]
+	if !ok {
+		lb = &loadBalancer{
+			LoadBalancer: infrav1.LoadBalancer{
+				Name:             name,
+				LoadBalancerType: lbSpec.LoadBalancerType,
+				Tags: infrav1.Build(infrav1.BuildParams{
+					ClusterName: s.scope.Name(),
+					Lifecycle:   infrav1.ResourceLifecycleOwned,
+					Name:        ptr.To(name),
+					Role:        ptr.To(infrav1.APIServerRoleTagValue),
+					Additional:  s.scope.AdditionalTags(),
+				}),
+			},
+			ip:        s.cloud.newIP(),
+			instances: map[string]bool{},
+		}
+		id := s.cloud.newID("lb")
+		lb.DNSName = fmt.Sprintf("%s-%s.elb.%s%s", name, id, s.scope.Region(), dnsSuffix)
+		if lbSpec.LoadBalancerType != infrav1.LoadBalancerTypeClassic {
+			lb.ARN = fmt.Sprintf("arn:aws:elasticloadbalancing:%s:%s:loadbalancer/%s/%s/%s", s.scope.Region(), accountID, loadBalancerKind(lbSpec.LoadBalancerType), name, id)
+			lb.targetGroupARN = fmt.Sprintf("arn:aws:elasticloadbalancing:%s:%s:targetgroup/%s/%s", s.scope.Region(), accountID, name, id)
+		}
+		s.cloud.loadBalancers[name] = lb
+	}
+
+	lb.Scheme = ptr.Deref(lbSpec.Scheme, infrav1.ELBSchemeInternetFacing)
+	switch {
+	case lbSpec.LoadBalancerType == infrav1.LoadBalancerTypeClassic:
+		lb.ClassicElbAttributes.CrossZoneLoadBalancing = lbSpec.CrossZoneLoadBalancing
+	case lbSpec.LoadBalancerType != infrav1.LoadBalancerTypeALB:
+		lb.ELBAttributes = map[string]*string{
+			infrav1.LoadBalancerAttributeEnableLoadBalancingCrossZone: ptr.To(strconv.FormatBool(lbSpec.CrossZoneLoadBalancing)),
+		}
+	}
+	lb.SubnetIDs, lb.AvailabilityZones = s.loadBalancerSubnets(lbSpec, lb.Scheme)
+	lb.SecurityGroupIDs = lbSpec.AdditionalSecurityGroups
+	if sg, ok := s.scope.SecurityGroups()[infrav1.SecurityGroupAPIServerLB]; ok {
+		lb.SecurityGroupIDs = append([]string{sg.ID}, lbSpec.AdditionalSecurityGroups...)
+	}
+
+	lb.LoadBalancer.DeepCopyInto(status)
+	return nil
+}
+
+func (s *elbService) DeleteLoadbalancers() error {
+	for i, lbSpec := range s.scope.ControlPlaneLoadBalancers() {
+		if lbSpec == nil {
+			continue
+		}
+		name, err := s.loadBalancerName(lbSpec)
+		if err != nil {
+			return err
+		}
+
+		s.cloud.mu.Lock()
+		delete(s.cloud.loadBalancers, name)
+		s.cloud.mu.Unlock()
+
+		if i > 0 {
+			s.scope.Network().SecondaryAPIServerELB = infrav1.LoadBalancer{}
+		} else {
+			s.scope.Network().APIServerELB = infrav1.LoadBalancer{}
+		}
+	}
+	return nil
+}
+
+func (s *elbService) IsInstanceRegisteredWithAPIServerELB(i *infrav1.Instance) (bool, error) {
+	_, registered, err := s.IsInstanceRegisteredWithAPIServerLB(i, s.scope.ControlPlaneLoadBalancer())
+	return registered, err
+}
+
+func (s *elbService) IsInstanceRegisteredWithAPIServerLB(i *infrav1.Instance, lbSpec *infrav1.AWSLoadBalancerSpec) ([]string, bool, error) {
+	lb, err := s.findLoadBalancer(lbSpec)
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	if !lb.instances[i.ID] {
+		return nil, false, nil
+	}
+	return []string{lb.targetGroupARN}, true, nil
+}
+
+func (s *elbService) RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error {
+	return s.RegisterInstanceWithAPIServerLB(i, s.scope.ControlPlaneLoadBalancer())
+}
+
+func (s *elbService) RegisterInstanceWithAPIServerLB(i *infrav1.Instance, lbSpec *infrav1.AWSLoadBalancerSpec) error {
+	lb, err := s.findLoadBalancer(lbSpec)
+	if err != nil {
+		return err
+	}
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	lb.instances[i.ID] = true
+	return nil
+}
+
+func (s *elbService) DeregisterInstanceFromAPIServerELB(i *infrav1.Instance) error {
+	lb, err := s.findLoadBalancer(s.scope.ControlPlaneLoadBalancer())
+	if err != nil {
+		return err
+	}
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	delete(lb.instances, i.ID)
+	return nil
+}
+
+func (s *elbService) DeregisterInstanceFromAPIServerLB(targetGroupArn string, i *infrav1.Instance) error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	for _, lb := range s.cloud.loadBalancers {
+		if lb.targetGroupARN == targetGroupArn {
+			delete(lb.instances, i.ID)
+		}
+	}
+	return nil
+}
+
+func (s *elbService) loadBalancerName(lbSpec *infrav1.AWSLoadBalancerSpec) (string, error) {
+	if lbSpec.LoadBalancerType == infrav1.LoadBalancerTypeClassic {
+		return elb.ELBName(s.scope)
+	}
+	return elb.LBName(s.scope, lbSpec)
+}
+
+func (s *elbService) findLoadBalancer(lbSpec *infrav1.AWSLoadBalancerSpec) (*loadBalancer, error) {
+	name, err := s.loadBalancerName(lbSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	lb, ok := s.cloud.loadBalancers[name]
+	if !ok {
+		return nil, errors.Errorf("load balancer %q not found", name)
+	}
+	return lb, nil
+}
+
+// loadBalancerSubnets returns the subnets of the load balancer and their availability zones. Unless set in the
+// spec, a subnet of the matching visibility is picked in each availability zone.
+func (s *elbService) loadBalancerSubnets(lbSpec *infrav1.AWSLoadBalancerSpec, scheme infrav1.ELBScheme) ([]string, []string) {
+	var subnetIDs, zones []string
+	if len(lbSpec.Subnets) > 0 {
+		for _, id := range lbSpec.Subnets {
+			subnetIDs = append(subnetIDs, id)
+			if subnet := s.scope.Subnets().FindByID(id); subnet != nil {
+				zones = append(zones, subnet.AvailabilityZone)
+			}
+		}
+		return subnetIDs, zones
+	}
+
+	subnets := s.scope.Subnets().FilterPrivate()
+	if scheme == infrav1.ELBSchemeInternetFacing {
+		subnets = s.scope.Subnets().FilterPublic()
+	}
+	seen := map[string]bool{}
+	for _, subnet := range subnets {
+		if subnet.IsEdge() || seen[subnet.AvailabilityZone] {
+			continue
+		}
+		seen[subnet.AvailabilityZone] = true
+		subnetIDs = append(subnetIDs, subnet.GetResourceID())
+		zones = append(zones, subnet.AvailabilityZone)
+	}
+	return subnetIDs, zones
+}
+
+func loadBalancerKind(lbType infrav1.LoadBalancerType) string {
+	if lbType == infrav1.LoadBalancerTypeALB {
+		return "app"
+	}
+	return "net"
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+var allSecurityGroupRoles = []infrav1.SecurityGroupRole{
+	infrav1.SecurityGroupAPIServerLB,
+	infrav1.SecurityGroupLB,
+	infrav1.SecurityGroupControlPlane,
+	infrav1.SecurityGroupNode,
+}
+
+func TestCloud(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	client := crfake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: infrav1.AWSClusterSpec{
+			Region: "us-west-2",
+			ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+			},
+		},
+	}
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     client,
+		Cluster:    cluster,
+		AWSCluster: awsCluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cloud := NewCloud()
+
+	g.Expect(cloud.NetworkService(clusterScope).ReconcileNetwork()).To(Succeed())
+	g.Expect(clusterScope.VPC().ID).NotTo(BeEmpty())
+	g.Expect(clusterScope.Subnets().FilterPublic()).To(HaveLen(3))
+	g.Expect(clusterScope.Subnets().FilterPrivate()).To(HaveLen(3))
+	g.Expect(clusterScope.Subnets().FilterByZone("us-west-2c").FilterPrivate()[0].CidrBlock).To(Equal("10.0.160.0/19"))
+	g.Expect(conditions.IsTrue(awsCluster, infrav1.VpcReadyCondition)).To(BeTrue())
+
+	g.Expect(cloud.SecurityGroupService(clusterScope, allSecurityGroupRoles).ReconcileSecurityGroups()).To(Succeed())
+	g.Expect(clusterScope.SecurityGroups()).To(HaveLen(len(allSecurityGroupRoles)))
+
+	elbService := cloud.ELBService(clusterScope)
+	g.Expect(elbService.ReconcileLoadbalancers()).To(Succeed())
+	lb := clusterScope.Network().APIServerELB
+	g.Expect(lb.ARN).NotTo(BeEmpty())
+	g.Expect(lb.SubnetIDs).To(HaveLen(3))
+	g.Expect(lb.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeEnableLoadBalancingCrossZone, ptr.To("false")))
+	g.Expect(lb.SecurityGroupIDs).To(ConsistOf(clusterScope.SecurityGroups()[infrav1.SecurityGroupAPIServerLB].ID))
+	ips, err := cloud.LookupIP(lb.DNSName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ips).To(HaveLen(1))
+
+	// Reconciling again keeps the same load balancer.
+	g.Expect(elbService.ReconcileLoadbalancers()).To(Succeed())
+	g.Expect(clusterScope.Network().APIServerELB.DNSName).To(Equal(lb.DNSName))
+
+	machineScope := newMachineScope(t, client, cluster, clusterScope)
+	ec2Service := cloud.EC2Service(clusterScope)
+	instance, err := ec2Service.CreateInstance(machineScope, []byte("userdata"), "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instance.State).To(Equal(infrav1.InstanceStateRunning))
+	g.Expect(instance.AvailabilityZone).To(Equal("us-west-2b"))
+	g.Expect(instance.SecurityGroupIDs).To(HaveLen(3))
+	g.Expect(instance.PrivateIP).NotTo(BeNil())
+
+	found, err := ec2Service.GetRunningInstanceByTags(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found.ID).To(Equal(instance.ID))
+
+	g.Expect(elbService.RegisterInstanceWithAPIServerLB(instance, awsCluster.Spec.ControlPlaneLoadBalancer)).To(Succeed())
+	targetGroups, registered, err := elbService.IsInstanceRegisteredWithAPIServerLB(instance, awsCluster.Spec.ControlPlaneLoadBalancer)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(registered).To(BeTrue())
+	g.Expect(targetGroups).To(HaveLen(1))
+
+	// Control plane instances are protected against termination.
+	g.Expect(instance.TerminationProtection).To(Equal(ptr.To(true)))
+	g.Expect(ec2Service.TerminateInstanceAndWait(instance.ID)).NotTo(Succeed())
+	g.Expect(ec2Service.DisableTerminationProtection(instance.ID)).To(Succeed())
+
+	g.Expect(ec2Service.TerminateInstanceAndWait(instance.ID)).To(Succeed())
+	found, err = ec2Service.GetRunningInstanceByTags(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeNil())
+	_, registered, err = elbService.IsInstanceRegisteredWithAPIServerLB(instance, awsCluster.Spec.ControlPlaneLoadBalancer)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(registered).To(BeFalse())
+
+	_, err = ec2Service.InstanceIfExists(ptr.To("i-unknown"))
+	g.Expect(err).To(MatchError(ec2.ErrInstanceNotFoundByID))
+
+	g.Expect(elbService.DeleteLoadbalancers()).To(Succeed())
+	_, err = cloud.LookupIP(lb.DNSName)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestReconcileBastion(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: infrav1.AWSClusterSpec{
+			Region:  "us-west-2",
+			Bastion: infrav1.Bastion{Enabled: true},
+		},
+	}
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     crfake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}},
+		AWSCluster: awsCluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cloud := NewCloud()
+	g.Expect(cloud.NetworkService(clusterScope).ReconcileNetwork()).To(Succeed())
+
+	ec2Service := cloud.EC2Service(clusterScope)
+	g.Expect(ec2Service.ReconcileBastion()).To(Succeed())
+	bastion := awsCluster.Status.Bastion
+	g.Expect(bastion).NotTo(BeNil())
+	g.Expect(bastion.PublicIP).NotTo(BeNil())
+	g.Expect(conditions.IsTrue(awsCluster, infrav1.BastionHostReadyCondition)).To(BeTrue())
+
+	g.Expect(ec2Service.ReconcileBastion()).To(Succeed())
+	g.Expect(awsCluster.Status.Bastion.ID).To(Equal(bastion.ID))
+
+	awsCluster.Spec.Bastion.Enabled = false
+	g.Expect(ec2Service.ReconcileBastion()).To(Succeed())
+	g.Expect(awsCluster.Status.Bastion).To(BeNil())
+	g.Expect(conditions.IsFalse(awsCluster, infrav1.BastionHostReadyCondition)).To(BeTrue())
+}
+
+func TestControlPlaneDNS(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: infrav1.AWSClusterSpec{
+			Region: "us-west-2",
+			ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+			},
+			ControlPlaneDNS: &infrav1.ControlPlaneDNS{HostedZoneName: "example.internal."},
+		},
+	}
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     crfake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}},
+		AWSCluster: awsCluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cloud := NewCloud()
+	dnsService := cloud.Route53Service(clusterScope)
+	g.Expect(dnsService.RecordName()).To(Equal("api.test.example.internal"))
+
+	// The record waits for the control plane load balancer.
+	g.Expect(dnsService.ReconcileControlPlaneDNS()).NotTo(Succeed())
+
+	g.Expect(cloud.NetworkService(clusterScope).ReconcileNetwork()).To(Succeed())
+	g.Expect(cloud.SecurityGroupService(clusterScope, allSecurityGroupRoles).ReconcileSecurityGroups()).To(Succeed())
+	g.Expect(cloud.ELBService(clusterScope).ReconcileLoadbalancers()).To(Succeed())
+
+	g.Expect(dnsService.ReconcileControlPlaneDNS()).To(Succeed())
+	status := awsCluster.Status.Network.ControlPlaneDNS
+	g.Expect(status).NotTo(BeNil())
+	g.Expect(status.HostedZoneID).NotTo(BeEmpty())
+	g.Expect(status.RecordName).To(Equal("api.test.example.internal"))
+
+	lbIPs, err := cloud.LookupIP(awsCluster.Status.Network.APIServerELB.DNSName)
+	g.Expect(err).NotTo(HaveOccurred())
+	recordIPs, err := cloud.LookupIP(status.RecordName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recordIPs).To(Equal(lbIPs))
+
+	// Reconciling again keeps the same hosted zone.
+	g.Expect(dnsService.ReconcileControlPlaneDNS()).To(Succeed())
+	g.Expect(awsCluster.Status.Network.ControlPlaneDNS.HostedZoneID).To(Equal(status.HostedZoneID))
+
+	g.Expect(dnsService.DeleteControlPlaneDNS()).To(Succeed())
+	g.Expect(awsCluster.Status.Network.ControlPlaneDNS).To(BeNil())
+	g.Expect(cloud.hostedZones).To(BeEmpty())
+}
+
+func newMachineScope(t *testing.T, client client.Client, cluster *clusterv1.Cluster, clusterScope *scope.ClusterScope) *scope.MachineScope {
+	t.Helper()
+
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-control-plane-0",
+			Labels:    map[string]string{clusterv1.MachineControlPlaneLabel: ""},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName:   cluster.Name,
+			FailureDomain: ptr.To("us-west-2b"),
+		},
+	}
+	awsMachine := &infrav1.AWSMachine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-control-plane-0"},
+	}
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:       client,
+		Cluster:      cluster,
+		Machine:      machine,
+		AWSMachine:   awsMachine,
+		InfraCluster: clusterScope,
+	})
+	if err != nil {
+		t.Fatalf("failed to create machine scope: %v", err)
+	}
+	return machineScope
+}
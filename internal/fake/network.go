@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"net/netip"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	defaultVPCCidr                    = "10.0.0.0/16"
+	defaultAvailabilityZoneUsageLimit = 3
+)
+
+type networkService struct {
+	cloud *Cloud
+	scope scope.NetworkScope
+}
+
+var _ services.NetworkInterface = &networkService{}
+
+// NetworkService returns a network service creating the VPC and subnets of the cluster in the fake cloud.
+func (c *Cloud) NetworkService(networkScope scope.NetworkScope) services.NetworkInterface {
+	return &networkService{cloud: c, scope: networkScope}
+}
+
+// ReconcileNetwork assigns IDs to the VPC and subnets of the cluster, and creates a public and a private
+// subnet in each availability zone when none is defined.
+func (s *networkService) ReconcileNetwork() error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	vpc := s.scope.VPC()
+	if vpc.ID == "" {
+		if vpc.CidrBlock == "" {
+			vpc.CidrBlock = defaultVPCCidr
+		}
+		vpc.ID = s.cloud.newID("vpc")
+		vpc.InternetGatewayID = ptr.To(s.cloud.newID("igw"))
+		vpc.Tags = infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.scope.Name(),
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        aws.String(fmt.Sprintf("%s-vpc", s.scope.Name())),
+			Role:        aws.String(infrav1.CommonRoleTagValue),
+			Additional:  s.scope.AdditionalTags(),
+		})
+	}
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.VpcReadyCondition)
+
+	subnets := s.scope.Subnets()
+	if len(subnets) == 0 {
+		defaults, err := s.defaultSubnets()
+		if err != nil {
+			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, infrav1.SubnetsReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return err
+		}
+		subnets = defaults
+	}
+	for i := range subnets {
+		if subnets[i].ResourceID == "" {
+			subnets[i].ResourceID = s.cloud.newID("subnet")
+		}
+		if subnets[i].RouteTableID == nil {
+			subnets[i].RouteTableID = ptr.To(s.cloud.newID("rtb"))
+		}
+	}
+	s.scope.SetSubnets(subnets)
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition)
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.InternetGatewayReadyCondition)
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition)
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition)
+	return nil
+}
+
+// DeleteNetwork is a no-op, as the network only lives in the spec and status of the cluster.
+func (s *networkService) DeleteNetwork() error {
+	return nil
+}
+
+func (s *networkService) defaultSubnets() (infrav1.Subnets, error) {
+	limit := ptr.Deref(s.scope.VPC().AvailabilityZoneUsageLimit, defaultAvailabilityZoneUsageLimit)
+	zones := make([]string, 0, limit)
+	for i := 0; i < limit; i++ {
+		zones = append(zones, fmt.Sprintf("%s%c", s.scope.Region(), 'a'+i))
+	}
+
+	cidrs, err := splitIPv4CIDR(s.scope.VPC().CidrBlock, 2*len(zones))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed splitting VPC CIDR %q into subnets", s.scope.VPC().CidrBlock)
+	}
+
+	subnets := infrav1.Subnets{}
+	for i, zone := range zones {
+		for j, public := range []bool{true, false} {
+			visibility := "private"
+			if public {
+				visibility = "public"
+			}
+			subnets = append(subnets, infrav1.SubnetSpec{
+				ID:               fmt.Sprintf("%s-subnet-%s-%s", s.scope.Name(), visibility, zone),
+				CidrBlock:        cidrs[2*i+j],
+				AvailabilityZone: zone,
+				IsPublic:         public,
+			})
+		}
+	}
+	return subnets, nil
+}
+
+// splitIPv4CIDR splits an IPv4 CIDR block into the given number of equally sized subnets,
+// rounded up to the next power of two like the network service does.
+func splitIPv4CIDR(cidrBlock string, numSubnets int) ([]string, error) {
+	prefix, err := netip.ParsePrefix(cidrBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse CIDR")
+	}
+	if !prefix.Addr().Is4() {
+		return nil, errors.Errorf("unexpected IP address type: %s", cidrBlock)
+	}
+
+	subnetLen := prefix.Bits() + bits.Len(uint(numSubnets-1))
+	if subnetLen > 32 {
+		return nil, errors.Errorf("cidr %s cannot accommodate %d subnets", cidrBlock, numSubnets)
+	}
+
+	base := prefix.Masked().Addr().As4()
+	subnets := make([]string, 0, numSubnets)
+	for i := 0; i < numSubnets; i++ {
+		var ip [4]byte
+		binary.BigEndian.PutUint32(ip[:], binary.BigEndian.Uint32(base[:])+uint32(i)<<(32-subnetLen))
+		subnets = append(subnets, netip.PrefixFrom(netip.AddrFrom4(ip), subnetLen).String())
+	}
+	return subnets, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"strings"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/route53"
+)
+
+type hostedZone struct {
+	name string
+	// owner is the cluster that created the hosted zone, or empty when the hosted zone was referenced by ID.
+	owner string
+	// records maps the names of the records to the DNS names of the load balancers they point at.
+	records map[string]string
+}
+
+type route53Service struct {
+	cloud *Cloud
+	scope scope.Route53Scope
+}
+
+var _ services.DNSInterface = &route53Service{}
+
+// Route53Service returns a service publishing the control plane endpoint of the cluster in the hosted zones of
+// the fake cloud. Records always point at the primary control plane load balancer, internal hosted zones are not
+// modelled.
+func (c *Cloud) Route53Service(route53Scope scope.Route53Scope) services.DNSInterface {
+	return &route53Service{cloud: c, scope: route53Scope}
+}
+
+func (s *route53Service) ReconcileControlPlaneDNS() error {
+	spec := s.scope.ControlPlaneDNS()
+	if spec == nil {
+		return nil
+	}
+
+	lb := s.scope.Network().APIServerELB
+	if lb.DNSName == "" {
+		return awserrors.NewFailedDependency("control plane load balancer is not ready yet")
+	}
+
+	zoneName := strings.TrimSuffix(spec.HostedZoneName, ".")
+	recordName := s.RecordName()
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	zoneID := strings.TrimPrefix(spec.HostedZoneID, "/hostedzone/")
+	if zoneID == "" {
+		zoneID = s.findOwnedHostedZone(zoneName)
+		if zoneID == "" {
+			zoneID = strings.ToUpper(s.cloud.newID("z"))
+			s.cloud.hostedZones[zoneID] = &hostedZone{name: zoneName, owner: s.owner(), records: map[string]string{}}
+		}
+	} else if _, ok := s.cloud.hostedZones[zoneID]; !ok {
+		// Referenced hosted zones are managed outside of the cluster, and assumed to exist.
+		s.cloud.hostedZones[zoneID] = &hostedZone{name: zoneName, records: map[string]string{}}
+	}
+	s.cloud.hostedZones[zoneID].records[recordName] = lb.DNSName
+
+	s.scope.Network().ControlPlaneDNS = &infrav1.ControlPlaneDNSStatus{
+		HostedZoneID: zoneID,
+		RecordName:   recordName,
+	}
+	return nil
+}
+
+func (s *route53Service) DeleteControlPlaneDNS() error {
+	status := s.scope.Network().ControlPlaneDNS
+	if s.scope.ControlPlaneDNS() == nil || status == nil || status.HostedZoneID == "" {
+		return nil
+	}
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	if zone, ok := s.cloud.hostedZones[status.HostedZoneID]; ok {
+		delete(zone.records, status.RecordName)
+		if zone.owner == s.owner() {
+			delete(s.cloud.hostedZones, status.HostedZoneID)
+		}
+	}
+
+	s.scope.Network().ControlPlaneDNS = nil
+	return nil
+}
+
+func (s *route53Service) RecordName() string {
+	return route53.RecordName(s.scope.ControlPlaneDNS(), s.scope.Name())
+}
+
+// findOwnedHostedZone returns the ID of the hosted zone with the given name created for the cluster. It must be
+// called with the lock held.
+func (s *route53Service) findOwnedHostedZone(name string) string {
+	for id, zone := range s.cloud.hostedZones {
+		if zone.name == name && zone.owner == s.owner() {
+			return id
+		}
+	}
+	return ""
+}
+
+func (s *route53Service) owner() string {
+	return s.scope.Namespace() + "/" + s.scope.Name()
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+)
+
+type objectStoreService struct {
+	cloud *Cloud
+	scope scope.S3Scope
+}
+
+var _ services.ObjectStoreInterface = &objectStoreService{}
+
+// ObjectStoreService returns an object store service storing the bootstrap data of the machines in the
+// fake cloud, in the bucket of the cluster.
+func (c *Cloud) ObjectStoreService(s3Scope scope.S3Scope) services.ObjectStoreInterface {
+	return &objectStoreService{cloud: c, scope: s3Scope}
+}
+
+func (s *objectStoreService) ReconcileBucket() error {
+	return nil
+}
+
+func (s *objectStoreService) DeleteBucket() error {
+	return nil
+}
+
+func (s *objectStoreService) Create(m *scope.MachineScope, data []byte) (string, error) {
+	if s.scope.Bucket() == nil {
+		return "", errors.New("requested object creation but bucket management is not enabled")
+	}
+
+	key := s.objectKey(m)
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	s.cloud.objects[key] = append([]byte{}, data...)
+	return fmt.Sprintf("s3://%s", key), nil
+}
+
+func (s *objectStoreService) Delete(m *scope.MachineScope) error {
+	if s.scope.Bucket() == nil {
+		return nil
+	}
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	delete(s.cloud.objects, s.objectKey(m))
+	return nil
+}
+
+func (s *objectStoreService) objectKey(m *scope.MachineScope) string {
+	return path.Join(s.scope.Bucket().Name, m.Role(), m.Name())
+}
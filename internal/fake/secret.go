@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+)
+
+type secretService struct {
+	cloud *Cloud
+}
+
+var _ services.SecretInterface = &secretService{}
+
+// SecretService returns a secret service storing the bootstrap data of the machines in the fake cloud.
+// It stands for both the Secrets Manager and SSM Parameter Store backends.
+func (c *Cloud) SecretService() services.SecretInterface {
+	return &secretService{cloud: c}
+}
+
+func (s *secretService) Create(m *scope.MachineScope, data []byte) (string, int32, error) {
+	prefix := m.GetSecretPrefix()
+
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	if prefix == "" {
+		prefix = path.Join("aws.cluster.x-k8s.io", s.cloud.newID("secret"))
+	}
+	s.cloud.secrets[prefix] = append([]byte{}, data...)
+	return prefix, 1, nil
+}
+
+func (s *secretService) UserData(secretPrefix string, _ int32, _ string, _ []scope.ServiceEndpoint) ([]byte, error) {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	data, ok := s.cloud.secrets[secretPrefix]
+	if !ok {
+		return nil, errors.Errorf("secret %q not found", secretPrefix)
+	}
+	return data, nil
+}
+
+func (s *secretService) Delete(m *scope.MachineScope) error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	delete(s.cloud.secrets, m.GetSecretPrefix())
+	return nil
+}
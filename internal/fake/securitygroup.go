@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+type securityGroupService struct {
+	cloud *Cloud
+	scope scope.SGScope
+	roles []infrav1.SecurityGroupRole
+}
+
+var _ services.SecurityGroupInterface = &securityGroupService{}
+
+// SecurityGroupService returns a security group service creating the security groups of the given roles
+// in the fake cloud.
+func (c *Cloud) SecurityGroupService(sgScope scope.SGScope, roles []infrav1.SecurityGroupRole) services.SecurityGroupInterface {
+	return &securityGroupService{cloud: c, scope: sgScope, roles: roles}
+}
+
+// ReconcileSecurityGroups records a security group for each role in the network status of the cluster,
+// unless it is overridden.
+func (s *securityGroupService) ReconcileSecurityGroups() error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	if s.scope.Network().SecurityGroups == nil {
+		s.scope.Network().SecurityGroups = make(map[infrav1.SecurityGroupRole]infrav1.SecurityGroup)
+	}
+
+	overrides := s.scope.SecurityGroupOverrides()
+	for _, role := range s.roles {
+		name := fmt.Sprintf("%s-%s", s.scope.Name(), role)
+		if id, ok := overrides[role]; ok {
+			s.scope.Network().SecurityGroups[role] = infrav1.SecurityGroup{ID: id, Name: name}
+			continue
+		}
+		if _, ok := s.scope.Network().SecurityGroups[role]; ok {
+			continue
+		}
+
+		s.scope.Network().SecurityGroups[role] = infrav1.SecurityGroup{
+			ID:   s.cloud.newID("sg"),
+			Name: name,
+			Tags: infrav1.Build(infrav1.BuildParams{
+				ClusterName: s.scope.Name(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        aws.String(name),
+				Role:        aws.String(string(role)),
+				Additional:  s.scope.AdditionalTags(),
+			}),
+		}
+	}
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.ClusterSecurityGroupsReadyCondition)
+	return nil
+}
+
+// DeleteSecurityGroups removes the security groups from the network status of the cluster.
+func (s *securityGroupService) DeleteSecurityGroups() error {
+	s.cloud.mu.Lock()
+	defer s.cloud.mu.Unlock()
+
+	for _, role := range s.roles {
+		delete(s.scope.Network().SecurityGroups, role)
+	}
+
+	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.ClusterSecurityGroupsReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	return nil
+}
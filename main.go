@@ -60,8 +60,10 @@ import (
 	expcontrollers "sigs.k8s.io/cluster-api-provider-aws/v2/exp/controllers"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/exp/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/internal/fake"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/endpoints"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/secretsmanager"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/version"
@@ -109,6 +111,13 @@ var (
 	webhookCertDir              string
 	healthAddr                  string
 	serviceEndpoints            string
+	cloudProvider               string
+	amiDeprecationWarningWindow time.Duration
+	secretsManagerOptions       secretsmanager.Options
+	eksKMSAliasPrefix           string
+
+	// fakeCloud holds the resources managed by the controllers when running with --cloud=fake.
+	fakeCloud *fake.Cloud
 
 	// maxEKSSyncPeriod is the maximum allowed duration for the sync-period flag when using EKS. It is set to 10 minutes
 	// because during resync it will create a new AWS auth token which can a maximum life of 15 minutes and this ensures
@@ -116,6 +125,7 @@ var (
 	maxEKSSyncPeriod         = time.Minute * 10
 	errMaxSyncPeriodExceeded = errors.New("sync period greater than maximum allowed")
 	errEKSInvalidFlags       = errors.New("invalid EKS flag combination")
+	errInvalidCloud          = errors.New("invalid cloud, expected aws or fake")
 
 	logOptions         = logs.NewOptions()
 	diagnosticsOptions = flags.DiagnosticsOptions{}
@@ -216,6 +226,21 @@ func main() {
 		setupLog.Info("Enabling Ignition support for machine bootstrap data")
 	}
 
+	switch cloudProvider {
+	case "aws":
+	case "fake":
+		setupLog.Info("using the fake cloud, no AWS resources will be created")
+		fakeCloud = fake.NewCloud()
+	default:
+		setupLog.Error(errInvalidCloud, "unable to set up the cloud", "cloud", cloudProvider)
+		os.Exit(1)
+	}
+
+	if err := secretsManagerOptions.Validate(); err != nil {
+		setupLog.Error(err, "invalid AWS Secrets Manager options")
+		os.Exit(1)
+	}
+
 	// Parse service endpoints.
 	awsServiceEndpoints, err := endpoints.ParseFlag(serviceEndpoints)
 	if err != nil {
@@ -295,19 +320,26 @@ func main() {
 func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServiceEndpoints []scope.ServiceEndpoint,
 	externalResourceGC, alternativeGCStrategy bool,
 ) {
-	if err := (&controllers.AWSMachineReconciler{
+	awsMachineReconciler := &controllers.AWSMachineReconciler{
 		Client:                       mgr.GetClient(),
+		APIReader:                    mgr.GetAPIReader(),
 		Log:                          ctrl.Log.WithName("controllers").WithName("AWSMachine"),
 		Recorder:                     mgr.GetEventRecorderFor("awsmachine-controller"),
 		Endpoints:                    awsServiceEndpoints,
 		WatchFilterValue:             watchFilterValue,
 		TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
-	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachineConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
+		AMIDeprecationWarningWindow:  amiDeprecationWarningWindow,
+		SecretsManagerOptions:        secretsManagerOptions,
+	}
+	if fakeCloud != nil {
+		awsMachineReconciler.UseFakeCloud(fakeCloud)
+	}
+	if err := awsMachineReconciler.SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachineConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSMachine")
 		os.Exit(1)
 	}
 
-	if err := (&controllers.AWSClusterReconciler{
+	awsClusterReconciler := &controllers.AWSClusterReconciler{
 		Client:                       mgr.GetClient(),
 		Recorder:                     mgr.GetEventRecorderFor("awscluster-controller"),
 		Endpoints:                    awsServiceEndpoints,
@@ -315,7 +347,11 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		ExternalResourceGC:           externalResourceGC,
 		AlternativeGCStrategy:        alternativeGCStrategy,
 		TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
-	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
+	}
+	if fakeCloud != nil {
+		awsClusterReconciler.UseFakeCloud(fakeCloud)
+	}
+	if err := awsClusterReconciler.SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSCluster")
 		os.Exit(1)
 	}
@@ -341,10 +377,11 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
 		setupLog.Info("EventBridge notifications enabled. enabling AWSInstanceStateController")
 		if err := (&instancestate.AwsInstanceStateReconciler{
-			Client:           mgr.GetClient(),
-			Log:              ctrl.Log.WithName("controllers").WithName("AWSInstanceStateController"),
-			Endpoints:        awsServiceEndpoints,
-			WatchFilterValue: watchFilterValue,
+			Client:                   mgr.GetClient(),
+			Log:                      ctrl.Log.WithName("controllers").WithName("AWSInstanceStateController"),
+			Endpoints:                awsServiceEndpoints,
+			WatchFilterValue:         watchFilterValue,
+			SpotInterruptionHandling: feature.Gates.Enabled(feature.SpotInterruptionHandling),
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: instanceStateConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSInstanceStateController")
 			os.Exit(1)
@@ -414,7 +451,7 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 	}
 
 	setupLog.Debug("enabling EKS control plane controller")
-	if err := (&ekscontrolplanecontrollers.AWSManagedControlPlaneReconciler{
+	managedControlPlaneReconciler := &ekscontrolplanecontrollers.AWSManagedControlPlaneReconciler{
 		Client:                       mgr.GetClient(),
 		EnableIAM:                    enableIAM,
 		AllowAdditionalRoles:         allowAddRoles,
@@ -424,7 +461,12 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 		AlternativeGCStrategy:        alternativeGCStrategy,
 		WaitInfraPeriod:              waitInfraPeriod,
 		TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
-	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
+		KMSAliasPrefix:               eksKMSAliasPrefix,
+	}
+	if fakeCloud != nil {
+		managedControlPlaneReconciler.UseFakeCloud(fakeCloud)
+	}
+	if err := managedControlPlaneReconciler.SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSManagedControlPlane")
 		os.Exit(1)
 	}
@@ -567,6 +609,36 @@ func initFlags(fs *pflag.FlagSet) {
 		"The minimum interval at which reconcile process wait for infrastructure to be ready.",
 	)
 
+	fs.DurationVar(&amiDeprecationWarningWindow,
+		"ami-deprecation-warning-window",
+		controllers.DefaultAMIDeprecationWarningWindow,
+		"The time before the deprecation of the AMI of an instance from which its AWSMachine reports the upcoming deprecation with the AMINotDeprecated condition and a warning event.",
+	)
+
+	fs.StringVar(&eksKMSAliasPrefix,
+		"eks-kms-alias-prefix",
+		"",
+		"The KMS alias prefix set in the eks.kmsAliasPrefix of the clusterawsadm configuration, which the IAM policies of the controllers restrict the KMS keys to. The KMS keys created to encrypt EKS clusters are given an alias with this prefix. Defaults to cluster-api-provider-aws-*.",
+	)
+
+	fs.StringVar(&secretsManagerOptions.NamePrefix,
+		"secrets-manager-name-prefix",
+		"",
+		"The prefix of the names of the AWS Secrets Manager secrets storing the userdata of the AWSMachines. Defaults to aws.cluster.x-k8s.io.",
+	)
+
+	fs.StringVar(&secretsManagerOptions.KMSKeyID,
+		"secrets-manager-kms-key-id",
+		"",
+		"The ID, ARN or alias of the KMS key encrypting the AWS Secrets Manager secrets storing the userdata of the AWSMachines. Defaults to the AWS managed aws/secretsmanager key.",
+	)
+
+	fs.Int64Var(&secretsManagerOptions.RecoveryWindowInDays,
+		"secrets-manager-recovery-window-days",
+		0,
+		"The number of days, between 7 and 30, during which the AWS Secrets Manager secrets storing userdata that are deleted by the controller can be recovered. When 0, the secrets are deleted without recovery.",
+	)
+
 	fs.DurationVar(&syncPeriod,
 		"sync-period",
 		10*time.Minute,
@@ -594,6 +666,12 @@ func initFlags(fs *pflag.FlagSet) {
 		"Set custom AWS service endpoins in semi-colon separated format: ${SigningRegion1}:${ServiceID1}=${URL},${ServiceID2}=${URL};${SigningRegion2}...",
 	)
 
+	fs.StringVar(&cloudProvider,
+		"cloud",
+		"aws",
+		"The cloud the controllers manage resources in, either aws or fake. The fake cloud keeps the resources of the AWSClusters, AWSMachines and AWSManagedControlPlanes in memory and is meant for local development only.",
+	)
+
 	fs.StringVar(
 		&watchFilterValue,
 		"watch-filter",
@@ -26,15 +26,18 @@ import (
 
 // Error singletons for AWS errors.
 const (
+	AccessDeniedException             = "AccessDeniedException"
 	AssociationIDNotFound             = "InvalidAssociationID.NotFound"
 	AuthFailure                       = "AuthFailure"
 	BucketAlreadyOwnedByYou           = "BucketAlreadyOwnedByYou"
 	EIPNotFound                       = "InvalidElasticIpID.NotFound"
 	GatewayNotFound                   = "InvalidGatewayID.NotFound"
 	GroupNotFound                     = "InvalidGroup.NotFound"
+	ImageNotFound                     = "InvalidAMIID.NotFound"
 	InternetGatewayNotFound           = "InvalidInternetGatewayID.NotFound"
 	InvalidCarrierGatewayNotFound     = "InvalidCarrierGatewayID.NotFound"
 	EgressOnlyInternetGatewayNotFound = "InvalidEgressOnlyInternetGatewayID.NotFound"
+	FleetNotFound                     = "InvalidFleetId.NotFound"
 	InUseIPAddress                    = "InvalidIPAddress.InUse"
 	InvalidAccessKeyID                = "InvalidAccessKeyId"
 	InvalidClientTokenID              = "InvalidClientTokenId"
@@ -43,18 +46,27 @@ const (
 	LaunchTemplateNameNotFound        = "InvalidLaunchTemplateName.NotFoundException"
 	LoadBalancerNotFound              = "LoadBalancerNotFound"
 	NATGatewayNotFound                = "InvalidNatGatewayID.NotFound"
+	NetworkInterfaceInUse             = "InvalidNetworkInterface.InUse"
+	NetworkInterfaceNotFound          = "InvalidNetworkInterfaceID.NotFound"
 	//nolint:gosec
 	NoCredentialProviders                   = "NoCredentialProviders"
 	NoSuchKey                               = "NoSuchKey"
+	OperationNotPermitted                   = "OperationNotPermitted"
+	OptInRequired                           = "OptInRequired"
 	PermissionNotFound                      = "InvalidPermission.NotFound"
+	PlacementGroupDuplicate                 = "InvalidPlacementGroup.Duplicate"
+	ReservationCapacityExceeded             = "ReservationCapacityExceeded"
 	ResourceExists                          = "ResourceExistsException"
 	ResourceNotFound                        = "InvalidResourceID.NotFound"
 	RouteTableNotFound                      = "InvalidRouteTableID.NotFound"
 	SubnetNotFound                          = "InvalidSubnetID.NotFound"
 	UnrecognizedClientException             = "UnrecognizedClientException"
 	UnauthorizedOperation                   = "UnauthorizedOperation"
+	UnsupportedOperation                    = "UnsupportedOperation"
 	VPCNotFound                             = "InvalidVpcID.NotFound"
 	VPCMissingParameter                     = "MissingParameter"
+	VolumeInUse                             = "VolumeInUse"
+	VolumeNotFound                          = "InvalidVolume.NotFound"
 	ErrCodeRepositoryAlreadyExistsException = "RepositoryAlreadyExistsException"
 )
 
@@ -168,6 +180,8 @@ func IsInvalidNotFoundError(err error) bool {
 			return true
 		case InvalidInstanceID:
 			return true
+		case FleetNotFound:
+			return true
 		case ssm.ErrCodeParameterNotFound:
 			return true
 		case LaunchTemplateNameNotFound:
@@ -25,6 +25,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/ssm"
 
@@ -94,6 +96,17 @@ func V2TagsToMap(src []*elbv2.Tag) infrav1.Tags {
 	return tags
 }
 
+// Route53TagsToMap converts a []*route53.Tag into a infrav1.Tags.
+func Route53TagsToMap(src []*route53.Tag) infrav1.Tags {
+	tags := make(infrav1.Tags, len(src))
+
+	for _, t := range src {
+		tags[*t.Key] = *t.Value
+	}
+
+	return tags
+}
+
 // MapToELBTags converts a infrav1.Tags to a []*elb.Tag.
 func MapToELBTags(src infrav1.Tags) []*elb.Tag {
 	tags := make([]*elb.Tag, 0, len(src))
@@ -170,6 +183,25 @@ func MapToSSMTags(src infrav1.Tags) []*ssm.Tag {
 	return tags
 }
 
+// MapToRoute53Tags converts a infrav1.Tags to a []*route53.Tag.
+func MapToRoute53Tags(src infrav1.Tags) []*route53.Tag {
+	tags := make([]*route53.Tag, 0, len(src))
+
+	for k, v := range src {
+		tag := &route53.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		}
+
+		tags = append(tags, tag)
+	}
+
+	// Sort so that unit tests can expect a stable order
+	sort.Slice(tags, func(i, j int) bool { return *tags[i].Key < *tags[j].Key })
+
+	return tags
+}
+
 // MapToIAMTags converts a infrav1.Tags to a []*iam.Tag.
 func MapToIAMTags(src infrav1.Tags) []*iam.Tag {
 	tags := make([]*iam.Tag, 0, len(src))
@@ -189,6 +221,36 @@ func MapToIAMTags(src infrav1.Tags) []*iam.Tag {
 	return tags
 }
 
+// MapToKMSTags converts a infrav1.Tags to a []*kms.Tag.
+func MapToKMSTags(src infrav1.Tags) []*kms.Tag {
+	tags := make([]*kms.Tag, 0, len(src))
+
+	for k, v := range src {
+		tag := &kms.Tag{
+			TagKey:   aws.String(k),
+			TagValue: aws.String(v),
+		}
+
+		tags = append(tags, tag)
+	}
+
+	// Sort so that unit tests can expect a stable order
+	sort.Slice(tags, func(i, j int) bool { return *tags[i].TagKey < *tags[j].TagKey })
+
+	return tags
+}
+
+// KMSTagsToMap converts a []*kms.Tag into a infrav1.Tags.
+func KMSTagsToMap(src []*kms.Tag) infrav1.Tags {
+	tags := make(infrav1.Tags, len(src))
+
+	for _, t := range src {
+		tags[*t.TagKey] = *t.TagValue
+	}
+
+	return tags
+}
+
 // ASGTagsToMap converts a []*autoscaling.TagDescription into a infrav1.Tags.
 func ASGTagsToMap(src []*autoscaling.TagDescription) infrav1.Tags {
 	tags := make(infrav1.Tags, len(src))
@@ -129,6 +129,14 @@ func (ec2Filters) NATGatewayStates(states ...string) *ec2.Filter {
 	}
 }
 
+// NetworkInterfaceStates returns a filter based on the list of states passed in.
+func (ec2Filters) NetworkInterfaceStates(states ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("status"),
+		Values: aws.StringSlice(states),
+	}
+}
+
 // InstanceStates returns a filter based on the list of states passed in.
 func (ec2Filters) InstanceStates(states ...string) *ec2.Filter {
 	return &ec2.Filter{
@@ -137,6 +145,14 @@ func (ec2Filters) InstanceStates(states ...string) *ec2.Filter {
 	}
 }
 
+// LaunchTemplate returns a filter matching the instances launched from the launch template.
+func (ec2Filters) LaunchTemplate(id string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("tag:aws:ec2launchtemplate:id"),
+		Values: aws.StringSlice([]string{id}),
+	}
+}
+
 // VPCStates returns a filter based on the list of states passed in.
 func (ec2Filters) VPCStates(states ...string) *ec2.Filter {
 	return &ec2.Filter{
@@ -36,6 +36,7 @@ const (
 	metricRequestCountKey    = "api_requests_total"
 	metricRequestDurationKey = "api_request_duration_seconds"
 	metricAPICallRetries     = "api_call_retries"
+	metricSecretCleanupFails = "secret_cleanup_failures_total"
 	metricServiceLabel       = "service"
 	metricRegionLabel        = "region"
 	metricOperationLabel     = "operation"
@@ -61,12 +62,23 @@ var (
 		Help:      "Number of retries made against an AWS API",
 		Buckets:   []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
 	}, []string{metricControllerLabel, metricServiceLabel, metricRegionLabel, metricOperationLabel})
+	secretCleanupFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricAWSSubsystem,
+		Name:      metricSecretCleanupFails,
+		Help:      "Total number of AWS secrets holding userdata that failed to be deleted",
+	}, []string{metricServiceLabel})
 )
 
 func init() {
 	metrics.Registry.MustRegister(awsRequestCount)
 	metrics.Registry.MustRegister(awsRequestDurationSeconds)
 	metrics.Registry.MustRegister(awsCallRetries)
+	metrics.Registry.MustRegister(secretCleanupFailures)
+}
+
+// RecordSecretCleanupFailure counts a secret holding userdata of the given service that failed to be deleted.
+func RecordSecretCleanupFailure(service string) {
+	secretCleanupFailures.WithLabelValues(service).Inc()
 }
 
 // CaptureRequestMetrics will monitor and capture request metrics.
@@ -148,9 +148,17 @@ func (s *ClusterScope) SecurityGroups() map[infrav1.SecurityGroupRole]infrav1.Se
 	return s.AWSCluster.Status.Network.SecurityGroups
 }
 
-// SecondaryCidrBlock is currently unimplemented for non-managed clusters.
+// SecondaryCidrBlock returns the CIDR block of the pod network, if any.
 func (s *ClusterScope) SecondaryCidrBlock() *string {
-	return nil
+	if s.AWSCluster.Spec.NetworkSpec.PodNetwork == nil {
+		return nil
+	}
+	return &s.AWSCluster.Spec.NetworkSpec.PodNetwork.CidrBlock
+}
+
+// PodNetwork returns the pod network configuration.
+func (s *ClusterScope) PodNetwork() *infrav1.PodNetworkSpec {
+	return s.AWSCluster.Spec.NetworkSpec.PodNetwork
 }
 
 // Name returns the CAPI cluster name.
@@ -219,6 +227,11 @@ func (s *ClusterScope) Bucket() *infrav1.S3Bucket {
 	return s.AWSCluster.Spec.S3Bucket
 }
 
+// ControlPlaneDNS returns the cluster control plane Route53 configuration.
+func (s *ClusterScope) ControlPlaneDNS() *infrav1.ControlPlaneDNS {
+	return s.AWSCluster.Spec.ControlPlaneDNS
+}
+
 // ControlPlaneConfigMapName returns the name of the ConfigMap used to
 // coordinate the bootstrapping of control plane nodes.
 func (s *ClusterScope) ControlPlaneConfigMapName() string {
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kubectl/pkg/drain"
+)
+
+// DrainNode cordons the node and requests the eviction of its pods, respecting their PodDisruptionBudgets. It doesn't
+// wait for the evicted pods to be deleted, and returns true once the node is drained. The evictions which are refused
+// are retried on the next call.
+func DrainNode(ctx context.Context, kubeClient kubernetes.Interface, node *corev1.Node, log func(msg string, keysAndValues ...any)) (bool, error) {
+	out := drainLogWriter{log: log}
+	drainer := &drain.Helper{
+		Ctx:                 ctx,
+		Client:              kubeClient,
+		Force:               true,
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  true,
+		GracePeriodSeconds:  -1,
+		// The pods of the unreachable nodes are never deleted, don't wait for them.
+		SkipWaitForDeleteTimeoutSeconds: 60,
+		Out:                             out,
+		ErrOut:                          out,
+	}
+
+	if err := drain.RunCordonOrUncordon(drainer, node, true); err != nil {
+		return false, errors.Wrapf(err, "failed to cordon node %q", node.Name)
+	}
+
+	podList, errs := drainer.GetPodsForDeletion(node.Name)
+	if len(errs) > 0 {
+		return false, errors.Wrapf(kerrors.NewAggregate(errs), "failed to get the pods of node %q", node.Name)
+	}
+	if warnings := podList.Warnings(); warnings != "" {
+		log(warnings, "node", node.Name)
+	}
+	pods := podList.Pods()
+	if len(pods) == 0 {
+		return true, nil
+	}
+
+	evictionGroupVersion, err := drain.CheckEvictionSupport(kubeClient)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check the support of evictions")
+	}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if evictionGroupVersion.Empty() {
+			err = drainer.DeletePod(pod)
+		} else {
+			err = drainer.EvictPod(pod, evictionGroupVersion)
+		}
+		switch {
+		case err == nil || apierrors.IsNotFound(err):
+		case apierrors.IsTooManyRequests(err):
+			log("Pod eviction refused, will retry", "pod", klog.KObj(&pod), "reason", err.Error())
+		default:
+			return false, errors.Wrapf(err, "failed to evict pod %s/%s", pod.Namespace, pod.Name)
+		}
+	}
+
+	log("Node not drained yet", "node", node.Name, "remaining-pods", len(pods))
+	return false, nil
+}
+
+// drainLogWriter writes the output of the drains to the logs.
+type drainLogWriter struct {
+	log func(msg string, keysAndValues ...any)
+}
+
+func (w drainLogWriter) Write(p []byte) (int, error) {
+	w.log(strings.TrimSpace(string(p)))
+	return len(p), nil
+}
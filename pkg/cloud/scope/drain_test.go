@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDrainNode(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	pod := func(mutate func(*corev1.Pod)) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "node-1"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if mutate != nil {
+			mutate(p)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name          string
+		pods          []runtime.Object
+		evictionErr   error
+		wantDrained   bool
+		wantEvictions int
+	}{
+		{
+			name:        "node without pods is drained",
+			wantDrained: true,
+		},
+		{
+			name:          "evictions are requested without waiting for the pods to be deleted",
+			pods:          []runtime.Object{pod(nil)},
+			wantEvictions: 1,
+		},
+		{
+			name: "pods being deleted are not evicted again",
+			pods: []runtime.Object{pod(func(p *corev1.Pod) {
+				p.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+				p.Finalizers = []string{"test"}
+			})},
+		},
+		{
+			name:          "refused evictions are retried later",
+			pods:          []runtime.Object{pod(nil)},
+			evictionErr:   apierrors.NewTooManyRequests("disruption budget", 10),
+			wantEvictions: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kubeClient := kubefake.NewSimpleClientset(append([]runtime.Object{node.DeepCopy()}, tt.pods...)...)
+			kubeClient.Resources = []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{
+						{Name: "pods/eviction", Kind: "Eviction", Group: policyv1.GroupName, Version: "v1"},
+					},
+				},
+			}
+			evictions := 0
+			kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				evictions++
+				return true, nil, tt.evictionErr
+			})
+
+			drained, err := DrainNode(context.TODO(), kubeClient, node.DeepCopy(), func(string, ...any) {})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drained).To(Equal(tt.wantDrained))
+			g.Expect(evictions).To(Equal(tt.wantEvictions))
+
+			cordoned, err := kubeClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(cordoned.Spec.Unschedulable).To(BeTrue())
+		})
+	}
+}
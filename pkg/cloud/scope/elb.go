@@ -55,4 +55,7 @@ type ELBScope interface {
 	// ControlPlaneLoadBalancers returns both the ControlPlaneLoadBalancer and SecondaryControlPlaneLoadBalancer AWSLoadBalancerSpecs.
 	// The control plane load balancers should always be returned in the above order.
 	ControlPlaneLoadBalancers() []*infrav1.AWSLoadBalancerSpec
+
+	// Bucket returns the cluster bucket configuration.
+	Bucket() *infrav1.S3Bucket
 }
@@ -196,6 +196,7 @@ func (s *FargateProfileScope) PatchObject() error {
 			expinfrav1.EKSFargateCreatingCondition,
 			expinfrav1.EKSFargateDeletingCondition,
 			expinfrav1.IAMFargateRolesReadyCondition,
+			expinfrav1.EKSFargateRoleAttachedCondition,
 		}})
 }
 
@@ -31,4 +31,6 @@ type IAMAuthScope interface {
 	RemoteClient() (client.Client, error)
 	// IAMAuthConfig returns the IAM authenticator config
 	IAMAuthConfig() *ekscontrolplanev1.IAMAuthenticatorConfig
+	// AuthenticationMode returns the authentication mode of the EKS cluster
+	AuthenticationMode() ekscontrolplanev1.EKSAuthenticationMode
 }
@@ -114,6 +114,16 @@ func (m *MachineScope) IsControlPlane() bool {
 	return util.IsControlPlaneMachine(m.Machine)
 }
 
+// TerminationProtection returns whether the instance should be protected against termination and stop
+// through the EC2 API. Unless set on the AWSMachine, control plane instances are protected, except spot
+// instances which don't support termination protection and instances which can be hibernated.
+func (m *MachineScope) TerminationProtection() bool {
+	if m.AWSMachine.Spec.TerminationProtection != nil {
+		return *m.AWSMachine.Spec.TerminationProtection
+	}
+	return m.IsControlPlane() && m.AWSMachine.Spec.SpotMarketOptions == nil && !m.AWSMachine.Spec.HibernationOptions.IsConfigured()
+}
+
 // Role returns the machine role from the labels.
 func (m *MachineScope) Role() string {
 	if util.IsControlPlaneMachine(m.Machine) {
@@ -191,7 +201,7 @@ func (m *MachineScope) SetAnnotation(key, value string) {
 // UseSecretsManager returns the computed value of whether or not
 // userdata should be stored using AWS Secrets Manager.
 func (m *MachineScope) UseSecretsManager(userDataFormat string) bool {
-	return !m.AWSMachine.Spec.CloudInit.InsecureSkipSecretsManager && !m.UseIgnition(userDataFormat)
+	return !m.AWSMachine.Spec.CloudInit.InsecureSkipSecretsManager && !m.UseIgnition(userDataFormat) && !m.UseBottlerocket(userDataFormat) && !m.UseNodeadm(userDataFormat)
 }
 
 // UseIgnition returns true if the AWSMachine should use Ignition.
@@ -199,6 +209,18 @@ func (m *MachineScope) UseIgnition(userDataFormat string) bool {
 	return userDataFormat == "ignition" || (m.AWSMachine.Spec.Ignition != nil)
 }
 
+// UseBottlerocket returns true if the bootstrap data is made of Bottlerocket settings, which are
+// read as is from the instance user data.
+func (m *MachineScope) UseBottlerocket(userDataFormat string) bool {
+	return userDataFormat == "bottlerocket"
+}
+
+// UseNodeadm returns true if the bootstrap data holds the nodeadm NodeConfig of an Amazon Linux 2023
+// node, which nodeadm reads as is from the instance user data.
+func (m *MachineScope) UseNodeadm(userDataFormat string) bool {
+	return userDataFormat == "nodeadm"
+}
+
 // SecureSecretsBackend returns the chosen secret backend.
 func (m *MachineScope) SecureSecretsBackend() infrav1.SecretBackend {
 	return m.AWSMachine.Spec.CloudInit.SecureSecretsBackend
@@ -207,7 +229,7 @@ func (m *MachineScope) SecureSecretsBackend() infrav1.SecretBackend {
 // CompressUserData returns the computed value of whether or not
 // userdata should be compressed using gzip.
 func (m *MachineScope) CompressUserData(userDataFormat string) bool {
-	if m.UseIgnition(userDataFormat) {
+	if m.UseIgnition(userDataFormat) || m.UseBottlerocket(userDataFormat) || m.UseNodeadm(userDataFormat) {
 		return false
 	}
 
@@ -312,6 +334,7 @@ func (m *MachineScope) PatchObject() error {
 			infrav1.InstanceReadyCondition,
 			infrav1.SecurityGroupsReadyCondition,
 			infrav1.ELBAttachedCondition,
+			infrav1.AMINotDeprecatedCondition,
 		}})
 }
 
@@ -401,6 +424,11 @@ func (m *MachineScope) SetInterruptible() {
 	}
 }
 
+// SetInstanceLifecycle sets the AWSMachine status instance lifecycle.
+func (m *MachineScope) SetInstanceLifecycle(v infrav1.InstanceLifecycle) {
+	m.AWSMachine.Status.InstanceLifecycle = v
+}
+
 // GetElasticIPPool returns the Elastic IP Pool for an machine, when exists.
 func (m *MachineScope) GetElasticIPPool() *infrav1.ElasticIPPool {
 	if m.AWSMachine == nil {
@@ -258,6 +258,28 @@ func TestUseSecretsManagerTrue(t *testing.T) {
 	}
 }
 
+func TestUseSecretsManagerFalseForBottlerocket(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if scope.UseSecretsManager("bottlerocket") {
+		t.Fatalf("UseSecretsManager should be false")
+	}
+}
+
+func TestUseSecretsManagerFalseForNodeadm(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if scope.UseSecretsManager("nodeadm") {
+		t.Fatalf("UseSecretsManager should be false")
+	}
+}
+
 func TestUseIgnition(t *testing.T) {
 	t.Run("returns_true_when_given_bootstrap_data_format_is_ignition", func(t *testing.T) {
 		scope, err := setupMachineScope()
@@ -295,6 +317,32 @@ func TestCompressUserData(t *testing.T) {
 			t.Fatalf("User data would be compressed despite Ignition format")
 		}
 	})
+
+	// Bottlerocket reads its settings from the uncompressed user data.
+	t.Run("returns_false_when_bootstrap_data_is_in_bottlerocket_format", func(t *testing.T) {
+		scope, err := setupMachineScope()
+		if err != nil {
+			t.Fatal(err)
+		}
+		scope.AWSMachine.Spec.UncompressedUserData = ptr.To[bool](false)
+
+		if scope.CompressUserData("bottlerocket") {
+			t.Fatalf("User data would be compressed despite Bottlerocket format")
+		}
+	})
+
+	// nodeadm reads its NodeConfig from the uncompressed user data.
+	t.Run("returns_false_when_bootstrap_data_is_in_nodeadm_format", func(t *testing.T) {
+		scope, err := setupMachineScope()
+		if err != nil {
+			t.Fatal(err)
+		}
+		scope.AWSMachine.Spec.UncompressedUserData = ptr.To[bool](false)
+
+		if scope.CompressUserData("nodeadm") {
+			t.Fatalf("User data would be compressed despite nodeadm format")
+		}
+	})
 }
 
 func TestGetSecretARNDefaultIsNil(t *testing.T) {
@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -126,6 +127,15 @@ func (m *MachinePoolScope) Name() string {
 	return m.AWSMachinePool.Name
 }
 
+// ASGName returns the name of the ASG of the machine pool, which is the name of the AWSMachinePool
+// unless it adopts an existing ASG.
+func (m *MachinePoolScope) ASGName() string {
+	if existingASG := m.AWSMachinePool.Spec.ExistingASG; existingASG != nil {
+		return existingASG.ASGName()
+	}
+	return m.Name()
+}
+
 // Namespace returns the namespace name.
 func (m *MachinePoolScope) Namespace() string {
 	return m.AWSMachinePool.Namespace
@@ -277,6 +287,13 @@ func (m *MachinePoolScope) IsEKSManaged() bool {
 	return m.InfraCluster.InfraCluster().GetObjectKind().GroupVersionKind().Kind == ekscontrolplanev1.AWSManagedControlPlaneKind
 }
 
+// RefreshOnBootstrapDataChange returns true if the instances which did not register a node before their
+// bootstrap token expired should be replaced.
+func (m *MachinePoolScope) RefreshOnBootstrapDataChange() bool {
+	prefs := m.AWSMachinePool.Spec.RefreshPreferences
+	return prefs != nil && prefs.RefreshOnBootstrapDataChange
+}
+
 // SubnetIDs returns the machine pool subnet IDs.
 func (m *MachinePoolScope) SubnetIDs(subnetIDs []string) ([]string, error) {
 	strategy, err := newDefaultSubnetPlacementStrategy(&m.Logger)
@@ -367,6 +384,152 @@ func (m *MachinePoolScope) getNodeStatusByProviderID(ctx context.Context, provid
 	return nodeStatusMap, nil
 }
 
+// DrainedInstanceIDs returns the IDs of the instances whose node has been cordoned and drained.
+func (m *MachinePoolScope) DrainedInstanceIDs(ctx context.Context, instanceIDs []string) ([]string, error) {
+	if len(instanceIDs) == 0 {
+		return nil, nil
+	}
+
+	instanceIDSet := make(map[string]struct{}, len(instanceIDs))
+	for _, id := range instanceIDs {
+		instanceIDSet[id] = struct{}{}
+	}
+
+	workloadClient, err := remote.NewClusterClient(ctx, "", m.Client, util.ObjectKey(m.Cluster))
+	if err != nil {
+		return nil, err
+	}
+
+	var drainedInstanceIDs []string
+	nodeList := corev1.NodeList{}
+	for {
+		if err := workloadClient.List(ctx, &nodeList, client.Continue(nodeList.Continue)); err != nil {
+			return nil, errors.Wrapf(err, "failed to List nodes")
+		}
+
+		for _, node := range nodeList.Items {
+			strList := strings.Split(node.Spec.ProviderID, "/")
+			instanceID := strList[len(strList)-1]
+			if _, ok := instanceIDSet[instanceID]; !ok || !node.Spec.Unschedulable {
+				continue
+			}
+
+			drained, err := nodeIsDrained(ctx, workloadClient, node.Name)
+			if err != nil {
+				return nil, err
+			}
+			if drained {
+				drainedInstanceIDs = append(drainedInstanceIDs, instanceID)
+			}
+		}
+
+		if nodeList.Continue == "" {
+			break
+		}
+	}
+
+	return drainedInstanceIDs, nil
+}
+
+// UnregisteredInstanceIDs returns the IDs of the instances which did not register a node.
+func (m *MachinePoolScope) UnregisteredInstanceIDs(ctx context.Context, instanceIDs []string) ([]string, error) {
+	if len(instanceIDs) == 0 {
+		return nil, nil
+	}
+
+	workloadClient, err := remote.NewClusterClient(ctx, "", m.Client, util.ObjectKey(m.Cluster))
+	if err != nil {
+		return nil, err
+	}
+
+	registered := make(map[string]struct{}, len(instanceIDs))
+	nodeList := corev1.NodeList{}
+	for {
+		if err := workloadClient.List(ctx, &nodeList, client.Continue(nodeList.Continue)); err != nil {
+			return nil, errors.Wrapf(err, "failed to List nodes")
+		}
+
+		for _, node := range nodeList.Items {
+			strList := strings.Split(node.Spec.ProviderID, "/")
+			registered[strList[len(strList)-1]] = struct{}{}
+		}
+
+		if nodeList.Continue == "" {
+			break
+		}
+	}
+
+	var unregisteredInstanceIDs []string
+	for _, id := range instanceIDs {
+		if _, ok := registered[id]; !ok {
+			unregisteredInstanceIDs = append(unregisteredInstanceIDs, id)
+		}
+	}
+	return unregisteredInstanceIDs, nil
+}
+
+// DrainInstance cordons the node of the instance and requests the eviction of its pods, without waiting for them
+// to be deleted. It returns true once the node is drained, or if the instance has no node.
+func (m *MachinePoolScope) DrainInstance(ctx context.Context, instanceID string) (bool, error) {
+	restConfig, err := remote.RESTConfig(ctx, "", m.Client, util.ObjectKey(m.Cluster))
+	if err != nil {
+		return false, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create workload cluster client")
+	}
+
+	var node *corev1.Node
+	opts := metav1.ListOptions{}
+	for node == nil {
+		nodeList, err := kubeClient.CoreV1().Nodes().List(ctx, opts)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to List nodes")
+		}
+		for i := range nodeList.Items {
+			strList := strings.Split(nodeList.Items[i].Spec.ProviderID, "/")
+			if strList[len(strList)-1] == instanceID {
+				node = &nodeList.Items[i]
+				break
+			}
+		}
+		if nodeList.Continue == "" {
+			break
+		}
+		opts.Continue = nodeList.Continue
+	}
+	if node == nil {
+		return true, nil
+	}
+
+	return DrainNode(ctx, kubeClient, node, m.Info)
+}
+
+// nodeIsDrained returns true if the node no longer runs pods, except for the pods of DaemonSets,
+// which are not evicted by drains, and the static pods.
+func nodeIsDrained(ctx context.Context, c client.Client, nodeName string) (bool, error) {
+	podList := corev1.PodList{}
+	if err := c.List(ctx, &podList, client.MatchingFields{"spec.nodeName": nodeName}); err != nil {
+		return false, errors.Wrapf(err, "failed to List pods of node %q", nodeName)
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+			continue
+		}
+		if owner := metav1.GetControllerOf(&pod); owner != nil && owner.Kind == "DaemonSet" {
+			continue
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
 func nodeIsReady(node corev1.Node) bool {
 	for _, n := range node.Status.Conditions {
 		if n.Type == corev1.NodeReady {
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+func TestNodeIsDrained(t *testing.T) {
+	pod := func(name, nodeName string, mutate func(*corev1.Pod)) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if mutate != nil {
+			mutate(p)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name        string
+		pods        []client.Object
+		wantDrained bool
+	}{
+		{
+			name:        "node without pods is drained",
+			wantDrained: true,
+		},
+		{
+			name: "node running a workload pod is not drained",
+			pods: []client.Object{
+				pod("workload", "node-1", nil),
+			},
+			wantDrained: false,
+		},
+		{
+			name: "pods of other nodes are ignored",
+			pods: []client.Object{
+				pod("workload", "node-2", nil),
+			},
+			wantDrained: true,
+		},
+		{
+			name: "daemonset, static and completed pods are ignored",
+			pods: []client.Object{
+				pod("daemonset", "node-1", func(p *corev1.Pod) {
+					p.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "ds", Controller: ptr.To[bool](true)}}
+				}),
+				pod("static", "node-1", func(p *corev1.Pod) {
+					p.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "hash"}
+				}),
+				pod("completed", "node-1", func(p *corev1.Pod) {
+					p.Status.Phase = corev1.PodSucceeded
+				}),
+			},
+			wantDrained: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.pods...).WithIndex(&corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+				return []string{o.(*corev1.Pod).Spec.NodeName}
+			}).Build()
+
+			drained, err := nodeIsDrained(context.TODO(), c, "node-1")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drained).To(Equal(tt.wantDrained))
+		})
+	}
+}
+
+func TestASGName(t *testing.T) {
+	tests := []struct {
+		name        string
+		existingASG *expinfrav1.ExistingASG
+		want        string
+	}{
+		{
+			name: "ASG created by CAPA is named after the AWSMachinePool",
+			want: "pool",
+		},
+		{
+			name:        "adopted ASG by name",
+			existingASG: &expinfrav1.ExistingASG{Name: "workers"},
+			want:        "workers",
+		},
+		{
+			name:        "adopted ASG by ARN",
+			existingASG: &expinfrav1.ExistingASG{Name: "arn:aws:autoscaling:us-east-1:123456789012:autoScalingGroup:6b2a5c5e-0f5e-4f3f-9c1e-1b1a0c9b1f0d:autoScalingGroupName/workers"},
+			want:        "workers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &MachinePoolScope{
+				AWSMachinePool: &expinfrav1.AWSMachinePool{
+					ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+					Spec:       expinfrav1.AWSMachinePoolSpec{ExistingASG: tt.existingASG},
+				},
+			}
+			g.Expect(m.ASGName()).To(Equal(tt.want))
+		})
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	bootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/bootstrap/v1beta1"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
@@ -67,6 +68,7 @@ type ManagedControlPlaneScopeParams struct {
 	EnableIAM                    bool
 	AllowAdditionalRoles         bool
 	TagUnmanagedNetworkResources bool
+	KMSAliasPrefix               string
 }
 
 // NewManagedControlPlaneScope creates a new Scope from the supplied parameters.
@@ -95,6 +97,7 @@ func NewManagedControlPlaneScope(params ManagedControlPlaneScopeParams) (*Manage
 		allowAdditionalRoles:         params.AllowAdditionalRoles,
 		enableIAM:                    params.EnableIAM,
 		tagUnmanagedNetworkResources: params.TagUnmanagedNetworkResources,
+		kmsAliasPrefix:               params.KMSAliasPrefix,
 	}
 	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, managedScope, params.ControlPlane.Spec.Region, params.Endpoints, params.Logger)
 	if err != nil {
@@ -129,6 +132,7 @@ type ManagedControlPlaneScope struct {
 	enableIAM                    bool
 	allowAdditionalRoles         bool
 	tagUnmanagedNetworkResources bool
+	kmsAliasPrefix               string
 }
 
 // RemoteClient returns the Kubernetes client for connecting to the workload cluster.
@@ -203,11 +207,19 @@ func (s *ManagedControlPlaneScope) SecurityGroups() map[infrav1.SecurityGroupRol
 	return s.ControlPlane.Status.Network.SecurityGroups
 }
 
-// SecondaryCidrBlock returns the SecondaryCidrBlock of the control plane.
+// SecondaryCidrBlock returns the SecondaryCidrBlock of the control plane, or the CIDR block of the pod network.
 func (s *ManagedControlPlaneScope) SecondaryCidrBlock() *string {
+	if s.ControlPlane.Spec.SecondaryCidrBlock == nil && s.ControlPlane.Spec.NetworkSpec.PodNetwork != nil {
+		return &s.ControlPlane.Spec.NetworkSpec.PodNetwork.CidrBlock
+	}
 	return s.ControlPlane.Spec.SecondaryCidrBlock
 }
 
+// PodNetwork returns the pod network configuration.
+func (s *ManagedControlPlaneScope) PodNetwork() *infrav1.PodNetworkSpec {
+	return s.ControlPlane.Spec.NetworkSpec.PodNetwork
+}
+
 // SecurityGroupOverrides returns the security groups that are overrides in the ControlPlane spec.
 func (s *ManagedControlPlaneScope) SecurityGroupOverrides() map[infrav1.SecurityGroupRole]string {
 	return s.ControlPlane.Spec.NetworkSpec.SecurityGroupOverrides
@@ -361,6 +373,15 @@ func (s *ManagedControlPlaneScope) AllowAdditionalRoles() bool {
 	return s.allowAdditionalRoles
 }
 
+// KMSAliasPrefix returns the KMS alias pattern clusterawsadm restricts the permissions of the controllers
+// on KMS keys to, defaulting to the one of clusterawsadm.
+func (s *ManagedControlPlaneScope) KMSAliasPrefix() string {
+	if s.kmsAliasPrefix == "" {
+		return bootstrapv1.DefaultKMSAliasPattern
+	}
+	return s.kmsAliasPrefix
+}
+
 // ImageLookupFormat returns the format string to use when looking up AMIs.
 func (s *ManagedControlPlaneScope) ImageLookupFormat() string {
 	return s.ControlPlane.Spec.ImageLookupFormat
@@ -384,6 +405,11 @@ func (s *ManagedControlPlaneScope) IAMAuthConfig() *ekscontrolplanev1.IAMAuthent
 	return s.ControlPlane.Spec.IAMAuthenticatorConfig
 }
 
+// AuthenticationMode returns the authentication mode of the EKS cluster.
+func (s *ManagedControlPlaneScope) AuthenticationMode() ekscontrolplanev1.EKSAuthenticationMode {
+	return s.ControlPlane.Spec.GetAuthenticationMode()
+}
+
 // Addons returns the list of addons for a EKS cluster.
 func (s *ManagedControlPlaneScope) Addons() []ekscontrolplanev1.Addon {
 	if s.ControlPlane.Spec.Addons == nil {
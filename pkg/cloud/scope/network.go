@@ -39,6 +39,8 @@ type NetworkScope interface {
 	SecurityGroups() map[infrav1.SecurityGroupRole]infrav1.SecurityGroup
 	// SecondaryCidrBlock returns the optional secondary CIDR block to use for pod IPs
 	SecondaryCidrBlock() *string
+	// PodNetwork returns the optional pod network configuration.
+	PodNetwork() *infrav1.PodNetworkSpec
 
 	// Bastion returns the bastion details for the cluster.
 	Bastion() *infrav1.Bastion
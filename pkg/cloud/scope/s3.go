@@ -26,4 +26,8 @@ type S3Scope interface {
 	cloud.ClusterScoper
 
 	Bucket() *infrav1.S3Bucket
+
+	// ControlPlaneLoadBalancers returns the control plane load balancers, whose access logs may
+	// be delivered to the bucket.
+	ControlPlaneLoadBalancers() []*infrav1.AWSLoadBalancerSpec
 }
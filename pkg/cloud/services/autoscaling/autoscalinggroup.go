@@ -22,12 +22,14 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -36,7 +38,9 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 // SDKToAutoScalingGroup converts an AWS EC2 SDK AutoScalingGroup to the CAPA AutoScalingGroup type.
@@ -45,10 +49,11 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 		ID:   aws.StringValue(v.AutoScalingGroupARN),
 		Name: aws.StringValue(v.AutoScalingGroupName),
 		// TODO(rudoi): this is just terrible
-		DesiredCapacity:   aws.Int32(int32(aws.Int64Value(v.DesiredCapacity))),
-		MaxSize:           int32(aws.Int64Value(v.MaxSize)),
-		MinSize:           int32(aws.Int64Value(v.MinSize)),
-		CapacityRebalance: aws.BoolValue(v.CapacityRebalance),
+		DesiredCapacity:                  aws.Int32(int32(aws.Int64Value(v.DesiredCapacity))),
+		MaxSize:                          int32(aws.Int64Value(v.MaxSize)),
+		MinSize:                          int32(aws.Int64Value(v.MinSize)),
+		CapacityRebalance:                aws.BoolValue(v.CapacityRebalance),
+		NewInstancesProtectedFromScaleIn: aws.BoolValue(v.NewInstancesProtectedFromScaleIn),
 		// TODO: determine what additional values go here and what else should be in the struct
 	}
 
@@ -56,6 +61,13 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 		i.Subnets = strings.Split(*v.VPCZoneIdentifier, ",")
 	}
 
+	switch {
+	case v.LaunchTemplate != nil:
+		i.LaunchTemplateID = aws.StringValue(v.LaunchTemplate.LaunchTemplateId)
+	case v.MixedInstancesPolicy != nil && v.MixedInstancesPolicy.LaunchTemplate != nil && v.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification != nil:
+		i.LaunchTemplateID = aws.StringValue(v.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification.LaunchTemplateId)
+	}
+
 	if v.MixedInstancesPolicy != nil {
 		i.MixedInstancesPolicy = &expinfrav1.MixedInstancesPolicy{
 			InstancesDistribution: &expinfrav1.InstancesDistribution{
@@ -109,9 +121,28 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 				AvailabilityZone: *autoscalingInstance.AvailabilityZone,
 			}
 			i.Instances = append(i.Instances, *tmp)
+			if aws.BoolValue(autoscalingInstance.ProtectedFromScaleIn) {
+				i.InstancesProtectedFromScaleIn = append(i.InstancesProtectedFromScaleIn, tmp.ID)
+			}
 		}
 	}
 
+	if lifetime := aws.Int64Value(v.MaxInstanceLifetime); lifetime > 0 {
+		i.MaxInstanceLifetime = &metav1.Duration{Duration: time.Duration(lifetime) * time.Second}
+	}
+
+	if len(v.TargetGroupARNs) > 0 {
+		i.TargetGroupARNs = aws.StringValueSlice(v.TargetGroupARNs)
+	}
+
+	if len(v.LoadBalancerNames) > 0 {
+		i.LoadBalancerNames = aws.StringValueSlice(v.LoadBalancerNames)
+	}
+
+	for _, metric := range v.EnabledMetrics {
+		i.EnabledMetrics = append(i.EnabledMetrics, aws.StringValue(metric.Metric))
+	}
+
 	if len(v.SuspendedProcesses) > 0 {
 		currentlySuspendedProcesses := make([]string, len(v.SuspendedProcesses))
 		for i, service := range v.SuspendedProcesses {
@@ -120,6 +151,21 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 		i.CurrentlySuspendProcesses = currentlySuspendedProcesses
 	}
 
+	// A warm pool pending deletion is no longer considered part of the ASG.
+	if wp := v.WarmPoolConfiguration; wp != nil && aws.StringValue(wp.Status) != autoscaling.WarmPoolStatusPendingDelete {
+		i.WarmPool = &expinfrav1.WarmPool{
+			MinSize:   aws.Int64Value(wp.MinSize),
+			PoolState: expinfrav1.WarmPoolState(aws.StringValue(wp.PoolState)),
+		}
+		// AWS reports -1 when the maximum prepared capacity is not set.
+		if wp.MaxGroupPreparedCapacity != nil && *wp.MaxGroupPreparedCapacity >= 0 {
+			i.WarmPool.MaxGroupPreparedCapacity = wp.MaxGroupPreparedCapacity
+		}
+		if wp.InstanceReusePolicy != nil && aws.BoolValue(wp.InstanceReusePolicy.ReuseOnScaleIn) {
+			i.WarmPool.InstanceReusePolicy = &expinfrav1.WarmPoolInstanceReusePolicy{ReuseOnScaleIn: true}
+		}
+	}
+
 	return i, nil
 }
 
@@ -152,7 +198,7 @@ func (s *Service) ASGIfExists(name *string) (*expinfrav1.AutoScalingGroup, error
 
 // GetASGByName returns the existing ASG or nothing if it doesn't exist.
 func (s *Service) GetASGByName(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
-	name := scope.Name()
+	name := scope.ASGName()
 	return s.ASGIfExists(&name)
 }
 
@@ -172,6 +218,11 @@ func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfra
 		DefaultInstanceWarmup: machinePoolScope.AWSMachinePool.Spec.DefaultInstanceWarmup,
 		CapacityRebalance:     machinePoolScope.AWSMachinePool.Spec.CapacityRebalance,
 		MixedInstancesPolicy:  machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy,
+
+		NewInstancesProtectedFromScaleIn: machinePoolScope.AWSMachinePool.Spec.ScaleInProtection,
+		MaxInstanceLifetime:              machinePoolScope.AWSMachinePool.Spec.MaxInstanceLifetime,
+		TargetGroupARNs:                  machinePoolScope.AWSMachinePool.Spec.TargetGroupARNs,
+		LoadBalancerNames:                machinePoolScope.AWSMachinePool.Spec.LoadBalancerNames,
 	}
 
 	// Default value of MachinePool replicas set by CAPI is 1.
@@ -226,12 +277,26 @@ func (s *Service) runPool(i *expinfrav1.AutoScalingGroup, launchTemplateID strin
 		DefaultCooldown:       aws.Int64(int64(i.DefaultCoolDown.Duration.Seconds())),
 		DefaultInstanceWarmup: aws.Int64(int64(i.DefaultInstanceWarmup.Duration.Seconds())),
 		CapacityRebalance:     aws.Bool(i.CapacityRebalance),
+
+		NewInstancesProtectedFromScaleIn: aws.Bool(i.NewInstancesProtectedFromScaleIn),
 	}
 
 	if i.DesiredCapacity != nil {
 		input.DesiredCapacity = aws.Int64(int64(aws.Int32Value(i.DesiredCapacity)))
 	}
 
+	if i.MaxInstanceLifetime != nil {
+		input.MaxInstanceLifetime = aws.Int64(int64(i.MaxInstanceLifetime.Duration.Seconds()))
+	}
+
+	if len(i.TargetGroupARNs) > 0 {
+		input.TargetGroupARNs = aws.StringSlice(i.TargetGroupARNs)
+	}
+
+	if len(i.LoadBalancerNames) > 0 {
+		input.LoadBalancerNames = aws.StringSlice(i.LoadBalancerNames)
+	}
+
 	if i.MixedInstancesPolicy != nil {
 		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(i.Name, i.MixedInstancesPolicy)
 	} else {
@@ -296,19 +361,27 @@ func (s *Service) UpdateASG(machinePoolScope *scope.MachinePoolScope) error {
 	}
 
 	input := &autoscaling.UpdateAutoScalingGroupInput{
-		AutoScalingGroupName: aws.String(machinePoolScope.Name()), // TODO: define dynamically - borrow logic from ec2
+		AutoScalingGroupName: aws.String(machinePoolScope.ASGName()),
 		MaxSize:              aws.Int64(int64(machinePoolScope.AWSMachinePool.Spec.MaxSize)),
 		MinSize:              aws.Int64(int64(machinePoolScope.AWSMachinePool.Spec.MinSize)),
 		VPCZoneIdentifier:    aws.String(strings.Join(subnetIDs, ",")),
 		CapacityRebalance:    aws.Bool(machinePoolScope.AWSMachinePool.Spec.CapacityRebalance),
+
+		NewInstancesProtectedFromScaleIn: aws.Bool(machinePoolScope.AWSMachinePool.Spec.ScaleInProtection),
 	}
 
 	if machinePoolScope.MachinePool.Spec.Replicas != nil && !annotations.ReplicasManagedByExternalAutoscaler(machinePoolScope.MachinePool) {
 		input.DesiredCapacity = aws.Int64(int64(*machinePoolScope.MachinePool.Spec.Replicas))
 	}
 
+	// 0 clears the maximum instance lifetime of the ASG.
+	input.MaxInstanceLifetime = aws.Int64(0)
+	if lifetime := machinePoolScope.AWSMachinePool.Spec.MaxInstanceLifetime; lifetime != nil {
+		input.MaxInstanceLifetime = aws.Int64(int64(lifetime.Duration.Seconds()))
+	}
+
 	if machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy != nil {
-		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(machinePoolScope.Name(), machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy)
+		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(machinePoolScope.LaunchTemplateName(), machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy)
 	} else {
 		input.LaunchTemplate = &autoscaling.LaunchTemplateSpecification{
 			LaunchTemplateId: aws.String(machinePoolScope.AWSMachinePool.Status.LaunchTemplateID),
@@ -317,25 +390,38 @@ func (s *Service) UpdateASG(machinePoolScope *scope.MachinePoolScope) error {
 	}
 
 	if _, err := s.ASGClient.UpdateAutoScalingGroupWithContext(context.TODO(), input); err != nil {
-		return errors.Wrapf(err, "failed to update ASG %q", machinePoolScope.Name())
+		return errors.Wrapf(err, "failed to update ASG %q", machinePoolScope.ASGName())
 	}
 
 	return nil
 }
 
+// instanceRefreshTimeout is the deadline for an ASG instance refresh to complete. Instance refreshes
+// in progress past the deadline are cancelled, so that a new one can be started.
+const instanceRefreshTimeout = 6 * time.Hour
+
 // CanStartASGInstanceRefresh will start an ASG instance with refresh.
 func (s *Service) CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (bool, error) {
-	describeInput := &autoscaling.DescribeInstanceRefreshesInput{AutoScalingGroupName: aws.String(scope.Name())}
+	describeInput := &autoscaling.DescribeInstanceRefreshesInput{AutoScalingGroupName: aws.String(scope.ASGName())}
 	refreshes, err := s.ASGClient.DescribeInstanceRefreshesWithContext(context.TODO(), describeInput)
 	if err != nil {
 		return false, err
 	}
 	hasUnfinishedRefresh := false
 	if err == nil && len(refreshes.InstanceRefreshes) != 0 {
+		// Instance refreshes are returned from the most recent one.
+		scope.AWSMachinePool.Status.InstanceRefresh = sdkToInstanceRefreshStatus(refreshes.InstanceRefreshes[0])
 		for i := range refreshes.InstanceRefreshes {
-			if *refreshes.InstanceRefreshes[i].Status == autoscaling.InstanceRefreshStatusInProgress ||
-				*refreshes.InstanceRefreshes[i].Status == autoscaling.InstanceRefreshStatusPending ||
-				*refreshes.InstanceRefreshes[i].Status == autoscaling.InstanceRefreshStatusCancelling {
+			switch *refreshes.InstanceRefreshes[i].Status {
+			case autoscaling.InstanceRefreshStatusInProgress, autoscaling.InstanceRefreshStatusPending:
+				hasUnfinishedRefresh = true
+				startTime := refreshes.InstanceRefreshes[i].StartTime
+				if startTime != nil && time.Since(*startTime) > instanceRefreshTimeout {
+					if err := s.cancelTimedOutASGInstanceRefresh(scope, refreshes.InstanceRefreshes[i]); err != nil {
+						return false, err
+					}
+				}
+			case autoscaling.InstanceRefreshStatusCancelling:
 				hasUnfinishedRefresh = true
 			}
 		}
@@ -343,38 +429,109 @@ func (s *Service) CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (boo
 	if hasUnfinishedRefresh {
 		return false, nil
 	}
+	if conditions.GetReason(scope.AWSMachinePool, infrav1.AsyncOperationsReadyCondition) == infrav1.InstanceRefreshTimedOutReason {
+		conditions.MarkTrue(scope.AWSMachinePool, infrav1.AsyncOperationsReadyCondition)
+	}
 	return true, nil
 }
 
+// sdkToInstanceRefreshStatus converts an AWS SDK instance refresh to the CAPA InstanceRefreshStatus type.
+func sdkToInstanceRefreshStatus(refresh *autoscaling.InstanceRefresh) *expinfrav1.InstanceRefreshStatus {
+	status := &expinfrav1.InstanceRefreshStatus{
+		ID:                 aws.StringValue(refresh.InstanceRefreshId),
+		Status:             aws.StringValue(refresh.Status),
+		StatusReason:       aws.StringValue(refresh.StatusReason),
+		PercentageComplete: refresh.PercentageComplete,
+		InstancesToUpdate:  refresh.InstancesToUpdate,
+	}
+	if refresh.StartTime != nil {
+		status.StartTime = &metav1.Time{Time: *refresh.StartTime}
+	}
+	if refresh.EndTime != nil {
+		status.EndTime = &metav1.Time{Time: *refresh.EndTime}
+	}
+	return status
+}
+
+// cancelTimedOutASGInstanceRefresh cancels an instance refresh in progress past its deadline, and reports it
+// in the AsyncOperationsReady condition.
+func (s *Service) cancelTimedOutASGInstanceRefresh(scope *scope.MachinePoolScope, refresh *autoscaling.InstanceRefresh) error {
+	refreshID := aws.StringValue(refresh.InstanceRefreshId)
+	record.Warnf(scope.AWSMachinePool, "InstanceRefreshTimedOut", "Instance refresh %q of ASG %q has been in progress for more than %s, cancelling it", refreshID, scope.ASGName(), instanceRefreshTimeout)
+
+	if _, err := s.ASGClient.CancelInstanceRefreshWithContext(context.TODO(), &autoscaling.CancelInstanceRefreshInput{
+		AutoScalingGroupName: aws.String(scope.ASGName()),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to cancel ASG instance refresh %q", refreshID)
+	}
+
+	conditions.MarkFalse(scope.AWSMachinePool, infrav1.AsyncOperationsReadyCondition, infrav1.InstanceRefreshTimedOutReason, clusterv1.ConditionSeverityWarning,
+		"Instance refresh %s has been in progress for more than %s and was cancelled", refreshID, instanceRefreshTimeout)
+	return nil
+}
+
 // StartASGInstanceRefresh will start an ASG instance with refresh.
 func (s *Service) StartASGInstanceRefresh(scope *scope.MachinePoolScope) error {
 	strategy := ptr.To[string](autoscaling.RefreshStrategyRolling)
-	var minHealthyPercentage, instanceWarmup *int64
-	if scope.AWSMachinePool.Spec.RefreshPreferences != nil {
-		if scope.AWSMachinePool.Spec.RefreshPreferences.Strategy != nil {
-			strategy = scope.AWSMachinePool.Spec.RefreshPreferences.Strategy
+	preferences := &autoscaling.RefreshPreferences{}
+	if prefs := scope.AWSMachinePool.Spec.RefreshPreferences; prefs != nil {
+		if prefs.Strategy != nil {
+			strategy = prefs.Strategy
 		}
-		if scope.AWSMachinePool.Spec.RefreshPreferences.InstanceWarmup != nil {
-			instanceWarmup = scope.AWSMachinePool.Spec.RefreshPreferences.InstanceWarmup
+		preferences.InstanceWarmup = prefs.InstanceWarmup
+		preferences.MinHealthyPercentage = prefs.MinHealthyPercentage
+		preferences.MaxHealthyPercentage = prefs.MaxHealthyPercentage
+		if len(prefs.CheckpointPercentages) > 0 {
+			preferences.CheckpointPercentages = aws.Int64Slice(prefs.CheckpointPercentages)
+			preferences.CheckpointDelay = prefs.CheckpointDelay
 		}
-		if scope.AWSMachinePool.Spec.RefreshPreferences.MinHealthyPercentage != nil {
-			minHealthyPercentage = scope.AWSMachinePool.Spec.RefreshPreferences.MinHealthyPercentage
+		if prefs.ScaleInProtectedInstances != nil {
+			preferences.ScaleInProtectedInstances = aws.String(string(*prefs.ScaleInProtectedInstances))
 		}
+		preferences.SkipMatching = prefs.SkipMatching
 	}
 
 	input := &autoscaling.StartInstanceRefreshInput{
-		AutoScalingGroupName: aws.String(scope.Name()),
+		AutoScalingGroupName: aws.String(scope.ASGName()),
 		Strategy:             strategy,
-		Preferences: &autoscaling.RefreshPreferences{
-			InstanceWarmup:       instanceWarmup,
-			MinHealthyPercentage: minHealthyPercentage,
-		},
+		Preferences:          preferences,
+	}
+
+	out, err := s.ASGClient.StartInstanceRefreshWithContext(context.TODO(), input)
+	if err != nil {
+		return errors.Wrapf(err, "failed to start ASG instance refresh %q", scope.ASGName())
+	}
+
+	scope.AWSMachinePool.Status.InstanceRefresh = &expinfrav1.InstanceRefreshStatus{
+		ID:        aws.StringValue(out.InstanceRefreshId),
+		Status:    autoscaling.InstanceRefreshStatusPending,
+		StartTime: ptr.To(metav1.Now()),
 	}
 
-	if _, err := s.ASGClient.StartInstanceRefreshWithContext(context.TODO(), input); err != nil {
-		return errors.Wrapf(err, "failed to start ASG instance refresh %q", scope.Name())
+	return nil
+}
+
+// UpdateInstanceRefreshStatus updates the status of the latest instance refresh of the ASG.
+func (s *Service) UpdateInstanceRefreshStatus(scope *scope.MachinePoolScope) error {
+	refresh := scope.AWSMachinePool.Status.InstanceRefresh
+	if refresh == nil {
+		return nil
+	}
+
+	out, err := s.ASGClient.DescribeInstanceRefreshesWithContext(context.TODO(), &autoscaling.DescribeInstanceRefreshesInput{
+		AutoScalingGroupName: aws.String(scope.ASGName()),
+		InstanceRefreshIds:   aws.StringSlice([]string{refresh.ID}),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe ASG instance refresh %q", refresh.ID)
+	}
+	if len(out.InstanceRefreshes) == 0 {
+		// The instance refresh is too old to be described.
+		scope.AWSMachinePool.Status.InstanceRefresh = nil
+		return nil
 	}
 
+	scope.AWSMachinePool.Status.InstanceRefresh = sdkToInstanceRefreshStatus(out.InstanceRefreshes[0])
 	return nil
 }
 
@@ -496,6 +653,183 @@ func (s *Service) ResumeProcesses(name string, processes []string) error {
 	return nil
 }
 
+// EnableMetricsCollection enables the collection of group metrics for an autoscaling group.
+func (s *Service) EnableMetricsCollection(name, granularity string, metrics []string) error {
+	input := &autoscaling.EnableMetricsCollectionInput{
+		AutoScalingGroupName: aws.String(name),
+		Granularity:          aws.String(granularity),
+		Metrics:              aws.StringSlice(metrics),
+	}
+	if _, err := s.ASGClient.EnableMetricsCollectionWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to enable metrics collection for AutoScalingGroup: %q", name)
+	}
+	return nil
+}
+
+// DisableMetricsCollection disables the collection of group metrics for an autoscaling group.
+func (s *Service) DisableMetricsCollection(name string, metrics []string) error {
+	input := &autoscaling.DisableMetricsCollectionInput{
+		AutoScalingGroupName: aws.String(name),
+		Metrics:              aws.StringSlice(metrics),
+	}
+	if _, err := s.ASGClient.DisableMetricsCollectionWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to disable metrics collection for AutoScalingGroup: %q", name)
+	}
+	return nil
+}
+
+// maxInstanceProtectionBatchSize is the maximum number of instances whose scale-in protection can be
+// set in a single call.
+const maxInstanceProtectionBatchSize = 50
+
+// SetInstanceProtection sets or removes the scale-in protection of instances of an autoscaling group.
+func (s *Service) SetInstanceProtection(name string, instanceIDs []string, protected bool) error {
+	for start := 0; start < len(instanceIDs); start += maxInstanceProtectionBatchSize {
+		end := min(start+maxInstanceProtectionBatchSize, len(instanceIDs))
+		input := &autoscaling.SetInstanceProtectionInput{
+			AutoScalingGroupName: aws.String(name),
+			InstanceIds:          aws.StringSlice(instanceIDs[start:end]),
+			ProtectedFromScaleIn: aws.Bool(protected),
+		}
+		if _, err := s.ASGClient.SetInstanceProtectionWithContext(context.TODO(), input); err != nil {
+			return errors.Wrapf(err, "failed to set instance protection for AutoScalingGroup: %q", name)
+		}
+	}
+	return nil
+}
+
+// GetInstanceLaunchTimes returns the launch time of the instances, by instance ID.
+func (s *Service) GetInstanceLaunchTimes(instanceIDs []string) (map[string]time.Time, error) {
+	launchTimes := make(map[string]time.Time, len(instanceIDs))
+	input := &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice(instanceIDs)}
+	if err := s.EC2Client.DescribeInstancesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				launchTimes[aws.StringValue(instance.InstanceId)] = aws.TimeValue(instance.LaunchTime)
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to describe instances")
+	}
+	return launchTimes, nil
+}
+
+// ReplaceInstance terminates an instance of an autoscaling group without decrementing its desired capacity,
+// so that the autoscaling group launches a new instance in its place.
+func (s *Service) ReplaceInstance(instanceID string) error {
+	if _, err := s.ASGClient.TerminateInstanceInAutoScalingGroupWithContext(context.TODO(), &autoscaling.TerminateInstanceInAutoScalingGroupInput{
+		InstanceId:                     aws.String(instanceID),
+		ShouldDecrementDesiredCapacity: aws.Bool(false),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to terminate instance %q", instanceID)
+	}
+	return nil
+}
+
+// maxLoadBalancerBatchSize is the maximum number of target groups or classic load balancers that can be
+// attached to or detached from an autoscaling group in a single call.
+const maxLoadBalancerBatchSize = 10
+
+// AttachLoadBalancerTargetGroups attaches target groups to an autoscaling group.
+func (s *Service) AttachLoadBalancerTargetGroups(name string, targetGroupARNs []string) error {
+	for start := 0; start < len(targetGroupARNs); start += maxLoadBalancerBatchSize {
+		end := min(start+maxLoadBalancerBatchSize, len(targetGroupARNs))
+		input := &autoscaling.AttachLoadBalancerTargetGroupsInput{
+			AutoScalingGroupName: aws.String(name),
+			TargetGroupARNs:      aws.StringSlice(targetGroupARNs[start:end]),
+		}
+		if _, err := s.ASGClient.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), input); err != nil {
+			return errors.Wrapf(err, "failed to attach target groups to AutoScalingGroup: %q", name)
+		}
+	}
+	return nil
+}
+
+// DetachLoadBalancerTargetGroups detaches target groups from an autoscaling group.
+func (s *Service) DetachLoadBalancerTargetGroups(name string, targetGroupARNs []string) error {
+	for start := 0; start < len(targetGroupARNs); start += maxLoadBalancerBatchSize {
+		end := min(start+maxLoadBalancerBatchSize, len(targetGroupARNs))
+		input := &autoscaling.DetachLoadBalancerTargetGroupsInput{
+			AutoScalingGroupName: aws.String(name),
+			TargetGroupARNs:      aws.StringSlice(targetGroupARNs[start:end]),
+		}
+		if _, err := s.ASGClient.DetachLoadBalancerTargetGroupsWithContext(context.TODO(), input); err != nil {
+			return errors.Wrapf(err, "failed to detach target groups from AutoScalingGroup: %q", name)
+		}
+	}
+	return nil
+}
+
+// AttachLoadBalancers attaches classic load balancers to an autoscaling group.
+func (s *Service) AttachLoadBalancers(name string, loadBalancerNames []string) error {
+	for start := 0; start < len(loadBalancerNames); start += maxLoadBalancerBatchSize {
+		end := min(start+maxLoadBalancerBatchSize, len(loadBalancerNames))
+		input := &autoscaling.AttachLoadBalancersInput{
+			AutoScalingGroupName: aws.String(name),
+			LoadBalancerNames:    aws.StringSlice(loadBalancerNames[start:end]),
+		}
+		if _, err := s.ASGClient.AttachLoadBalancersWithContext(context.TODO(), input); err != nil {
+			return errors.Wrapf(err, "failed to attach load balancers to AutoScalingGroup: %q", name)
+		}
+	}
+	return nil
+}
+
+// DetachLoadBalancers detaches classic load balancers from an autoscaling group.
+func (s *Service) DetachLoadBalancers(name string, loadBalancerNames []string) error {
+	for start := 0; start < len(loadBalancerNames); start += maxLoadBalancerBatchSize {
+		end := min(start+maxLoadBalancerBatchSize, len(loadBalancerNames))
+		input := &autoscaling.DetachLoadBalancersInput{
+			AutoScalingGroupName: aws.String(name),
+			LoadBalancerNames:    aws.StringSlice(loadBalancerNames[start:end]),
+		}
+		if _, err := s.ASGClient.DetachLoadBalancersWithContext(context.TODO(), input); err != nil {
+			return errors.Wrapf(err, "failed to detach load balancers from AutoScalingGroup: %q", name)
+		}
+	}
+	return nil
+}
+
+// PutWarmPool creates or updates the warm pool of an autoscaling group.
+func (s *Service) PutWarmPool(name string, warmPool *expinfrav1.WarmPool) error {
+	poolState := warmPool.PoolState
+	if poolState == "" {
+		poolState = expinfrav1.WarmPoolStateStopped
+	}
+
+	input := &autoscaling.PutWarmPoolInput{
+		AutoScalingGroupName: aws.String(name),
+		MinSize:              aws.Int64(warmPool.MinSize),
+		// -1 unsets the maximum prepared capacity, so that the maximum size of the ASG is used.
+		MaxGroupPreparedCapacity: aws.Int64(-1),
+		PoolState:                aws.String(string(poolState)),
+		InstanceReusePolicy: &autoscaling.InstanceReusePolicy{
+			ReuseOnScaleIn: aws.Bool(warmPool.InstanceReusePolicy != nil && warmPool.InstanceReusePolicy.ReuseOnScaleIn),
+		},
+	}
+	if warmPool.MaxGroupPreparedCapacity != nil {
+		input.MaxGroupPreparedCapacity = warmPool.MaxGroupPreparedCapacity
+	}
+
+	if _, err := s.ASGClient.PutWarmPoolWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to put warm pool for AutoScalingGroup: %q", name)
+	}
+	return nil
+}
+
+// DeleteWarmPool deletes the warm pool of an autoscaling group, along with its instances.
+func (s *Service) DeleteWarmPool(name string) error {
+	input := &autoscaling.DeleteWarmPoolInput{
+		AutoScalingGroupName: aws.String(name),
+		ForceDelete:          aws.Bool(true),
+	}
+	if _, err := s.ASGClient.DeleteWarmPoolWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to delete warm pool for AutoScalingGroup: %q", name)
+	}
+	return nil
+}
+
 func mapToTags(input map[string]string, resourceID *string) []*autoscaling.Tag {
 	tags := make([]*autoscaling.Tag, 0)
 	for k, v := range input {
@@ -18,8 +18,10 @@ package asg
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
@@ -42,6 +44,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestServiceGetASGByName(t *testing.T) {
@@ -209,6 +212,152 @@ func TestServiceSDKToAutoScalingGroup(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid input - warm pool",
+			input: &autoscaling.Group{
+				DesiredCapacity: aws.Int64(1234),
+				MaxSize:         aws.Int64(1234),
+				MinSize:         aws.Int64(1234),
+				WarmPoolConfiguration: &autoscaling.WarmPoolConfiguration{
+					MinSize:                  aws.Int64(1),
+					MaxGroupPreparedCapacity: aws.Int64(-1),
+					PoolState:                aws.String("Hibernated"),
+					InstanceReusePolicy:      &autoscaling.InstanceReusePolicy{ReuseOnScaleIn: aws.Bool(true)},
+				},
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+				WarmPool: &expinfrav1.WarmPool{
+					MinSize:             1,
+					PoolState:           expinfrav1.WarmPoolStateHibernated,
+					InstanceReusePolicy: &expinfrav1.WarmPoolInstanceReusePolicy{ReuseOnScaleIn: true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - warm pool pending deletion",
+			input: &autoscaling.Group{
+				DesiredCapacity: aws.Int64(1234),
+				MaxSize:         aws.Int64(1234),
+				MinSize:         aws.Int64(1234),
+				WarmPoolConfiguration: &autoscaling.WarmPoolConfiguration{
+					MinSize:   aws.Int64(1),
+					PoolState: aws.String("Stopped"),
+					Status:    aws.String("PendingDelete"),
+				},
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - scale-in protection",
+			input: &autoscaling.Group{
+				DesiredCapacity:                  aws.Int64(1234),
+				MaxSize:                          aws.Int64(1234),
+				MinSize:                          aws.Int64(1234),
+				NewInstancesProtectedFromScaleIn: aws.Bool(true),
+				Instances: []*autoscaling.Instance{
+					{
+						AvailabilityZone:     aws.String("us-east-1a"),
+						InstanceId:           aws.String("instance-1"),
+						LifecycleState:       aws.String("InService"),
+						ProtectedFromScaleIn: aws.Bool(true),
+					},
+					{
+						AvailabilityZone:     aws.String("us-east-1a"),
+						InstanceId:           aws.String("instance-2"),
+						LifecycleState:       aws.String("InService"),
+						ProtectedFromScaleIn: aws.Bool(false),
+					},
+				},
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity:                  aws.Int32(1234),
+				MaxSize:                          int32(1234),
+				MinSize:                          int32(1234),
+				NewInstancesProtectedFromScaleIn: true,
+				Instances: []infrav1.Instance{
+					{
+						ID:               "instance-1",
+						State:            infrav1.InstanceState("InService"),
+						AvailabilityZone: "us-east-1a",
+					},
+					{
+						ID:               "instance-2",
+						State:            infrav1.InstanceState("InService"),
+						AvailabilityZone: "us-east-1a",
+					},
+				},
+				InstancesProtectedFromScaleIn: []string{"instance-1"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - enabled metrics",
+			input: &autoscaling.Group{
+				DesiredCapacity: aws.Int64(1234),
+				MaxSize:         aws.Int64(1234),
+				MinSize:         aws.Int64(1234),
+				EnabledMetrics: []*autoscaling.EnabledMetric{
+					{
+						Granularity: aws.String("1Minute"),
+						Metric:      aws.String("GroupInServiceInstances"),
+					},
+					{
+						Granularity: aws.String("1Minute"),
+						Metric:      aws.String("GroupDesiredCapacity"),
+					},
+				},
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+				EnabledMetrics:  []string{"GroupInServiceInstances", "GroupDesiredCapacity"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - max instance lifetime",
+			input: &autoscaling.Group{
+				DesiredCapacity:     aws.Int64(1234),
+				MaxSize:             aws.Int64(1234),
+				MinSize:             aws.Int64(1234),
+				MaxInstanceLifetime: aws.Int64(604800),
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity:     aws.Int32(1234),
+				MaxSize:             int32(1234),
+				MinSize:             int32(1234),
+				MaxInstanceLifetime: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - load balancers",
+			input: &autoscaling.Group{
+				DesiredCapacity:   aws.Int64(1234),
+				MaxSize:           aws.Int64(1234),
+				MinSize:           aws.Int64(1234),
+				TargetGroupARNs:   aws.StringSlice([]string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/tg/73e2d6bc24d8a067"}),
+				LoadBalancerNames: aws.StringSlice([]string{"elb"}),
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity:   aws.Int32(1234),
+				MaxSize:           int32(1234),
+				MinSize:           int32(1234),
+				TargetGroupARNs:   []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/tg/73e2d6bc24d8a067"},
+				LoadBalancerNames: []string{"elb"},
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid input - all fields filled",
 			input: &autoscaling.Group{
@@ -511,9 +660,10 @@ func TestServiceCreateASG(t *testing.T) {
 							},
 						},
 					},
-					DesiredCapacity: aws.Int64(1),
-					MaxSize:         aws.Int64(2),
-					MinSize:         aws.Int64(1),
+					DesiredCapacity:                  aws.Int64(1),
+					MaxSize:                          aws.Int64(2),
+					MinSize:                          aws.Int64(1),
+					NewInstancesProtectedFromScaleIn: aws.Bool(false),
 					Tags: []*autoscaling.Tag{
 						{
 							Key:               aws.String("kubernetes.io/cluster/test"),
@@ -723,6 +873,34 @@ func TestServiceUpdateASG(t *testing.T) {
 				})
 			},
 		},
+		{
+			name:            "should set the max instance lifetime",
+			machinePoolName: "update-asg-max-instance-lifetime",
+			wantErr:         false,
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.MaxInstanceLifetime = &metav1.Duration{Duration: 7 * 24 * time.Hour}
+			},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, g *WithT) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).DoAndReturn(func(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+					g.Expect(input.MaxInstanceLifetime).To(BeComparableTo(ptr.To[int64](604800)))
+					return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+				})
+			},
+		},
+		{
+			name:            "should clear the max instance lifetime",
+			machinePoolName: "update-asg-no-max-instance-lifetime",
+			wantErr:         false,
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.MaxInstanceLifetime = nil
+			},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, g *WithT) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).DoAndReturn(func(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+					g.Expect(input.MaxInstanceLifetime).To(BeComparableTo(ptr.To[int64](0)))
+					return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+				})
+			},
+		},
 		{
 			name:            "should return error if update ASG fails",
 			machinePoolName: "update-asg-fail",
@@ -1111,10 +1289,12 @@ func TestServiceCanStartASGInstanceRefresh(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	tests := []struct {
-		name     string
-		wantErr  bool
-		canStart bool
-		expect   func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		name                       string
+		wantErr                    bool
+		canStart                   bool
+		expectAsyncOperationsReady *bool
+		expectInstanceRefreshID    string
+		expect                     func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
 	}{
 		{
 			name:     "should return error if describe instance refresh failed",
@@ -1155,6 +1335,36 @@ func TestServiceCanStartASGInstanceRefresh(t *testing.T) {
 					}, nil)
 			},
 		},
+		{
+			name:                       "should cancel the instance refresh in progress past its deadline",
+			wantErr:                    false,
+			canStart:                   false,
+			expectAsyncOperationsReady: ptr.To(false),
+			expectInstanceRefreshID:    "refresh-1",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeInstanceRefreshesInput{
+					AutoScalingGroupName: aws.String("machinePoolName"),
+				})).
+					Return(&autoscaling.DescribeInstanceRefreshesOutput{
+						InstanceRefreshes: []*autoscaling.InstanceRefresh{
+							{
+								InstanceRefreshId: aws.String("refresh-1"),
+								Status:            aws.String(autoscaling.InstanceRefreshStatusInProgress),
+								StartTime:         aws.Time(time.Now().Add(-2 * instanceRefreshTimeout)),
+							},
+							{
+								InstanceRefreshId: aws.String("refresh-0"),
+								Status:            aws.String(autoscaling.InstanceRefreshStatusSuccessful),
+								StartTime:         aws.Time(time.Now().Add(-3 * instanceRefreshTimeout)),
+							},
+						},
+					}, nil)
+				m.CancelInstanceRefreshWithContext(context.TODO(), gomock.Eq(&autoscaling.CancelInstanceRefreshInput{
+					AutoScalingGroupName: aws.String("machinePoolName"),
+				})).
+					Return(&autoscaling.CancelInstanceRefreshOutput{}, nil)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1175,6 +1385,13 @@ func TestServiceCanStartASGInstanceRefresh(t *testing.T) {
 
 			out, err := s.CanStartASGInstanceRefresh(mps)
 			checkErr(tt.wantErr, err, g)
+			if tt.expectAsyncOperationsReady != nil {
+				g.Expect(conditions.IsTrue(mps.AWSMachinePool, infrav1.AsyncOperationsReadyCondition)).To(Equal(*tt.expectAsyncOperationsReady))
+			}
+			if tt.expectInstanceRefreshID != "" {
+				g.Expect(mps.AWSMachinePool.Status.InstanceRefresh).ToNot(BeNil())
+				g.Expect(mps.AWSMachinePool.Status.InstanceRefresh.ID).To(Equal(tt.expectInstanceRefreshID))
+			}
 			if tt.canStart {
 				g.Expect(out).To(BeTrue())
 				return
@@ -1189,9 +1406,10 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	tests := []struct {
-		name    string
-		wantErr bool
-		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		name               string
+		wantErr            bool
+		refreshPreferences func(prefs *expinfrav1.RefreshPreferences)
+		expect             func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
 	}{
 		{
 			name:    "should return error if start instance refresh failed",
@@ -1223,6 +1441,33 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 					Return(&autoscaling.StartInstanceRefreshOutput{}, nil)
 			},
 		},
+		{
+			name:    "should pass all the refresh preferences",
+			wantErr: false,
+			refreshPreferences: func(prefs *expinfrav1.RefreshPreferences) {
+				prefs.MaxHealthyPercentage = aws.Int64(120)
+				prefs.CheckpointPercentages = []int64{50, 100}
+				prefs.CheckpointDelay = aws.Int64(300)
+				prefs.SkipMatching = aws.Bool(false)
+				prefs.ScaleInProtectedInstances = ptr.To(expinfrav1.ScaleInProtectedInstancesRefresh)
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.StartInstanceRefreshWithContext(context.TODO(), gomock.Eq(&autoscaling.StartInstanceRefreshInput{
+					AutoScalingGroupName: aws.String("mpn"),
+					Strategy:             aws.String("Rolling"),
+					Preferences: &autoscaling.RefreshPreferences{
+						InstanceWarmup:            aws.Int64(100),
+						MinHealthyPercentage:      aws.Int64(80),
+						MaxHealthyPercentage:      aws.Int64(120),
+						CheckpointPercentages:     aws.Int64Slice([]int64{50, 100}),
+						CheckpointDelay:           aws.Int64(300),
+						SkipMatching:              aws.Bool(false),
+						ScaleInProtectedInstances: aws.String("Refresh"),
+					},
+				})).
+					Return(&autoscaling.StartInstanceRefreshOutput{}, nil)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1240,6 +1485,9 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 			mps, err := getMachinePoolScope(fakeClient, clusterScope)
 			g.Expect(err).ToNot(HaveOccurred())
 			mps.AWSMachinePool.Name = "mpn"
+			if tt.refreshPreferences != nil {
+				tt.refreshPreferences(mps.AWSMachinePool.Spec.RefreshPreferences)
+			}
 
 			err = s.StartASGInstanceRefresh(mps)
 			checkErr(tt.wantErr, err, g)
@@ -1247,6 +1495,515 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 	}
 }
 
+func TestServiceUpdateInstanceRefreshStatus(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name    string
+		status  *expinfrav1.InstanceRefreshStatus
+		wantErr bool
+		want    *expinfrav1.InstanceRefreshStatus
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:   "should do nothing if there is no instance refresh",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {},
+		},
+		{
+			name:   "should update the progress of the instance refresh",
+			status: &expinfrav1.InstanceRefreshStatus{ID: "refresh-1", Status: "Pending"},
+			want: &expinfrav1.InstanceRefreshStatus{
+				ID:                 "refresh-1",
+				Status:             "InProgress",
+				StatusReason:       "Waiting for instances to warm up",
+				PercentageComplete: aws.Int64(50),
+				InstancesToUpdate:  aws.Int64(2),
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeInstanceRefreshesInput{
+					AutoScalingGroupName: aws.String("mpn"),
+					InstanceRefreshIds:   aws.StringSlice([]string{"refresh-1"}),
+				})).
+					Return(&autoscaling.DescribeInstanceRefreshesOutput{
+						InstanceRefreshes: []*autoscaling.InstanceRefresh{
+							{
+								InstanceRefreshId:  aws.String("refresh-1"),
+								Status:             aws.String(autoscaling.InstanceRefreshStatusInProgress),
+								StatusReason:       aws.String("Waiting for instances to warm up"),
+								PercentageComplete: aws.Int64(50),
+								InstancesToUpdate:  aws.Int64(2),
+							},
+						},
+					}, nil)
+			},
+		},
+		{
+			name:   "should clear the status of an instance refresh which cannot be described anymore",
+			status: &expinfrav1.InstanceRefreshStatus{ID: "refresh-1", Status: "InProgress"},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Any()).
+					Return(&autoscaling.DescribeInstanceRefreshesOutput{}, nil)
+			},
+		},
+		{
+			name:    "should return an error if the instance refresh cannot be described",
+			status:  &expinfrav1.InstanceRefreshStatus{ID: "refresh-1", Status: "InProgress"},
+			wantErr: true,
+			want:    &expinfrav1.InstanceRefreshStatus{ID: "refresh-1", Status: "InProgress"},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserrors.NewConflict("some error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = "mpn"
+			mps.AWSMachinePool.Status.InstanceRefresh = tt.status
+
+			err = s.UpdateInstanceRefreshStatus(mps)
+			checkErr(tt.wantErr, err, g)
+			g.Expect(mps.AWSMachinePool.Status.InstanceRefresh).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestServicePutWarmPool(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name     string
+		warmPool *expinfrav1.WarmPool
+		wantErr  bool
+		expect   func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:     "should default the pool state and unset the max group prepared capacity",
+			warmPool: &expinfrav1.WarmPool{},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutWarmPoolWithContext(context.TODO(), gomock.Eq(&autoscaling.PutWarmPoolInput{
+					AutoScalingGroupName:     aws.String("asgName"),
+					MinSize:                  aws.Int64(0),
+					MaxGroupPreparedCapacity: aws.Int64(-1),
+					PoolState:                aws.String("Stopped"),
+					InstanceReusePolicy:      &autoscaling.InstanceReusePolicy{ReuseOnScaleIn: aws.Bool(false)},
+				})).
+					Return(&autoscaling.PutWarmPoolOutput{}, nil)
+			},
+		},
+		{
+			name: "should put the warm pool with all fields set",
+			warmPool: &expinfrav1.WarmPool{
+				MinSize:                  2,
+				MaxGroupPreparedCapacity: aws.Int64(10),
+				PoolState:                expinfrav1.WarmPoolStateHibernated,
+				InstanceReusePolicy:      &expinfrav1.WarmPoolInstanceReusePolicy{ReuseOnScaleIn: true},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutWarmPoolWithContext(context.TODO(), gomock.Eq(&autoscaling.PutWarmPoolInput{
+					AutoScalingGroupName:     aws.String("asgName"),
+					MinSize:                  aws.Int64(2),
+					MaxGroupPreparedCapacity: aws.Int64(10),
+					PoolState:                aws.String("Hibernated"),
+					InstanceReusePolicy:      &autoscaling.InstanceReusePolicy{ReuseOnScaleIn: aws.Bool(true)},
+				})).
+					Return(&autoscaling.PutWarmPoolOutput{}, nil)
+			},
+		},
+		{
+			name:     "should return an error if the warm pool cannot be put",
+			warmPool: &expinfrav1.WarmPool{},
+			wantErr:  true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutWarmPoolWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.PutWarmPool("asgName", tt.warmPool)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceDeleteWarmPool(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	fakeClient := getFakeClient()
+
+	clusterScope, err := getClusterScope(fakeClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	asgMock.EXPECT().DeleteWarmPoolWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteWarmPoolInput{
+		AutoScalingGroupName: aws.String("asgName"),
+		ForceDelete:          aws.Bool(true),
+	})).
+		Return(&autoscaling.DeleteWarmPoolOutput{}, nil)
+	s := NewService(clusterScope)
+	s.ASGClient = asgMock
+
+	g.Expect(s.DeleteWarmPool("asgName")).To(Succeed())
+}
+
+func TestServiceEnableMetricsCollection(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should enable the collection of the metrics",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.EnableMetricsCollectionWithContext(context.TODO(), gomock.Eq(&autoscaling.EnableMetricsCollectionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					Granularity:          aws.String("1Minute"),
+					Metrics:              aws.StringSlice([]string{"GroupDesiredCapacity", "GroupInServiceInstances"}),
+				})).
+					Return(&autoscaling.EnableMetricsCollectionOutput{}, nil)
+			},
+		},
+		{
+			name:    "should return an error if enabling the collection fails",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.EnableMetricsCollectionWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.EnableMetricsCollectionInput{})).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.EnableMetricsCollection("asgName", "1Minute", []string{"GroupDesiredCapacity", "GroupInServiceInstances"})
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceDisableMetricsCollection(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	fakeClient := getFakeClient()
+
+	clusterScope, err := getClusterScope(fakeClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	asgMock.EXPECT().DisableMetricsCollectionWithContext(context.TODO(), gomock.Eq(&autoscaling.DisableMetricsCollectionInput{
+		AutoScalingGroupName: aws.String("asgName"),
+		Metrics:              aws.StringSlice([]string{"GroupDesiredCapacity"}),
+	})).
+		Return(&autoscaling.DisableMetricsCollectionOutput{}, nil)
+	s := NewService(clusterScope)
+	s.ASGClient = asgMock
+
+	g.Expect(s.DisableMetricsCollection("asgName", []string{"GroupDesiredCapacity"})).To(Succeed())
+}
+
+func TestServiceSetInstanceProtection(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	instanceIDs := make([]string, 60)
+	for i := range instanceIDs {
+		instanceIDs[i] = fmt.Sprintf("instance-%d", i)
+	}
+
+	tests := []struct {
+		name        string
+		instanceIDs []string
+		protected   bool
+		wantErr     bool
+		expect      func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:        "should protect the instances",
+			instanceIDs: []string{"instance-1", "instance-2"},
+			protected:   true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.SetInstanceProtectionWithContext(context.TODO(), gomock.Eq(&autoscaling.SetInstanceProtectionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					InstanceIds:          aws.StringSlice([]string{"instance-1", "instance-2"}),
+					ProtectedFromScaleIn: aws.Bool(true),
+				})).
+					Return(&autoscaling.SetInstanceProtectionOutput{}, nil)
+			},
+		},
+		{
+			name:        "should remove the protection of the instances in batches",
+			instanceIDs: instanceIDs,
+			protected:   false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.SetInstanceProtectionWithContext(context.TODO(), gomock.Eq(&autoscaling.SetInstanceProtectionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					InstanceIds:          aws.StringSlice(instanceIDs[:50]),
+					ProtectedFromScaleIn: aws.Bool(false),
+				})).
+					Return(&autoscaling.SetInstanceProtectionOutput{}, nil)
+				m.SetInstanceProtectionWithContext(context.TODO(), gomock.Eq(&autoscaling.SetInstanceProtectionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					InstanceIds:          aws.StringSlice(instanceIDs[50:]),
+					ProtectedFromScaleIn: aws.Bool(false),
+				})).
+					Return(&autoscaling.SetInstanceProtectionOutput{}, nil)
+			},
+		},
+		{
+			name:        "should return an error if setting the protection fails",
+			instanceIDs: []string{"instance-1"},
+			protected:   true,
+			wantErr:     true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.SetInstanceProtectionWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.SetInstanceProtectionInput{})).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.SetInstanceProtection("asgName", tt.instanceIDs, tt.protected)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceGetInstanceLaunchTimes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	fakeClient := getFakeClient()
+
+	clusterScope, err := getClusterScope(fakeClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	launchTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().DescribeInstancesPagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstancesInput{
+		InstanceIds: aws.StringSlice([]string{"instance-1", "instance-2"}),
+	}), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+			fn(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{
+						Instances: []*ec2.Instance{
+							{InstanceId: aws.String("instance-1"), LaunchTime: aws.Time(launchTime)},
+							{InstanceId: aws.String("instance-2"), LaunchTime: aws.Time(launchTime.Add(time.Hour))},
+						},
+					},
+				},
+			}, true)
+			return nil
+		})
+	s := NewService(clusterScope)
+	s.EC2Client = ec2Mock
+
+	launchTimes, err := s.GetInstanceLaunchTimes([]string{"instance-1", "instance-2"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(launchTimes).To(Equal(map[string]time.Time{
+		"instance-1": launchTime,
+		"instance-2": launchTime.Add(time.Hour),
+	}))
+}
+
+func TestServiceReplaceInstance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	fakeClient := getFakeClient()
+
+	clusterScope, err := getClusterScope(fakeClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	asgMock.EXPECT().TerminateInstanceInAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+		InstanceId:                     aws.String("instance-1"),
+		ShouldDecrementDesiredCapacity: aws.Bool(false),
+	})).
+		Return(&autoscaling.TerminateInstanceInAutoScalingGroupOutput{}, nil)
+	s := NewService(clusterScope)
+	s.ASGClient = asgMock
+
+	g.Expect(s.ReplaceInstance("instance-1")).To(Succeed())
+}
+
+func TestServiceAttachLoadBalancerTargetGroups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	targetGroupARNs := make([]string, 12)
+	for i := range targetGroupARNs {
+		targetGroupARNs[i] = fmt.Sprintf("arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/tg-%d/73e2d6bc24d8a067", i)
+	}
+
+	tests := []struct {
+		name            string
+		targetGroupARNs []string
+		wantErr         bool
+		expect          func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:            "should attach the target groups in batches",
+			targetGroupARNs: targetGroupARNs,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Eq(&autoscaling.AttachLoadBalancerTargetGroupsInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					TargetGroupARNs:      aws.StringSlice(targetGroupARNs[:10]),
+				})).
+					Return(&autoscaling.AttachLoadBalancerTargetGroupsOutput{}, nil)
+				m.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Eq(&autoscaling.AttachLoadBalancerTargetGroupsInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					TargetGroupARNs:      aws.StringSlice(targetGroupARNs[10:]),
+				})).
+					Return(&autoscaling.AttachLoadBalancerTargetGroupsOutput{}, nil)
+			},
+		},
+		{
+			name:            "should return an error if attaching the target groups fails",
+			targetGroupARNs: targetGroupARNs[:1],
+			wantErr:         true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.AttachLoadBalancerTargetGroupsInput{})).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.AttachLoadBalancerTargetGroups("asgName", tt.targetGroupARNs)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceDetachLoadBalancerTargetGroups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	fakeClient := getFakeClient()
+
+	clusterScope, err := getClusterScope(fakeClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	asgMock.EXPECT().DetachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Eq(&autoscaling.DetachLoadBalancerTargetGroupsInput{
+		AutoScalingGroupName: aws.String("asgName"),
+		TargetGroupARNs:      aws.StringSlice([]string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/tg/73e2d6bc24d8a067"}),
+	})).
+		Return(&autoscaling.DetachLoadBalancerTargetGroupsOutput{}, nil)
+	s := NewService(clusterScope)
+	s.ASGClient = asgMock
+
+	g.Expect(s.DetachLoadBalancerTargetGroups("asgName", []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/tg/73e2d6bc24d8a067"})).To(Succeed())
+}
+
+func TestServiceAttachLoadBalancers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	fakeClient := getFakeClient()
+
+	clusterScope, err := getClusterScope(fakeClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	asgMock.EXPECT().AttachLoadBalancersWithContext(context.TODO(), gomock.Eq(&autoscaling.AttachLoadBalancersInput{
+		AutoScalingGroupName: aws.String("asgName"),
+		LoadBalancerNames:    aws.StringSlice([]string{"elb"}),
+	})).
+		Return(&autoscaling.AttachLoadBalancersOutput{}, nil)
+	s := NewService(clusterScope)
+	s.ASGClient = asgMock
+
+	g.Expect(s.AttachLoadBalancers("asgName", []string{"elb"})).To(Succeed())
+}
+
+func TestServiceDetachLoadBalancers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	fakeClient := getFakeClient()
+
+	clusterScope, err := getClusterScope(fakeClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	asgMock.EXPECT().DetachLoadBalancersWithContext(context.TODO(), gomock.Eq(&autoscaling.DetachLoadBalancersInput{
+		AutoScalingGroupName: aws.String("asgName"),
+		LoadBalancerNames:    aws.StringSlice([]string{"elb"}),
+	})).
+		Return(&autoscaling.DetachLoadBalancersOutput{}, nil)
+	s := NewService(clusterScope)
+	s.ASGClient = asgMock
+
+	g.Expect(s.DetachLoadBalancers("asgName", []string{"elb"})).To(Succeed())
+}
+
 func getFakeClient() client.Client {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
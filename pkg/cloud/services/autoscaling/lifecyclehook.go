@@ -0,0 +1,365 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/pkg/errors"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
+)
+
+const (
+	// terminationDrainHookName is the name of the termination lifecycle hook of the ASGs whose nodes are drained.
+	terminationDrainHookName = "capa-termination-drain"
+
+	// terminateLifecycleActionDetailType is the detail type of the EventBridge events sent by the ASGs when an
+	// instance reaches a termination lifecycle hook.
+	terminateLifecycleActionDetailType = "EC2 Instance-terminate Lifecycle Action"
+
+	// terminationDrainVisibilityTimeout is the time during which a received lifecycle action isn't received
+	// again, in seconds.
+	terminationDrainVisibilityTimeout = 30
+)
+
+// invalidResourceNameChars matches the characters of an ASG name which can't be used in SQS queue and
+// EventBridge rule names.
+var invalidResourceNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// ReconcileTerminationLifecycleHook creates or updates the termination lifecycle hook of the ASG, along with the
+// SQS queue and the EventBridge rule delivering its lifecycle actions.
+func (s *Service) ReconcileTerminationLifecycleHook(scope *scope.MachinePoolScope) error {
+	asgName := scope.ASGName()
+	heartbeatTimeout := int64(scope.AWSMachinePool.Spec.TerminationDrain.GetHeartbeatTimeout().Seconds())
+
+	out, err := s.ASGClient.DescribeLifecycleHooksWithContext(context.TODO(), &autoscaling.DescribeLifecycleHooksInput{
+		AutoScalingGroupName: aws.String(asgName),
+		LifecycleHookNames:   aws.StringSlice([]string{terminationDrainHookName}),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe lifecycle hooks of AutoScalingGroup: %q", asgName)
+	}
+	if len(out.LifecycleHooks) == 1 && aws.Int64Value(out.LifecycleHooks[0].HeartbeatTimeout) == heartbeatTimeout {
+		return nil
+	}
+
+	name, err := terminationDrainResourceName(asgName)
+	if err != nil {
+		return err
+	}
+
+	queueURL, queueARN, err := s.reconcileTerminationDrainQueue(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(lifecycleActionEventPattern{
+		Source:     []string{"aws.autoscaling"},
+		DetailType: []string{terminateLifecycleActionDetailType},
+		Detail: lifecycleActionEventPatternDetail{
+			AutoScalingGroupName: []string{asgName},
+			LifecycleHookName:    []string{terminationDrainHookName},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	rule, err := s.EventBridgeClient.PutRuleWithContext(context.TODO(), &eventbridge.PutRuleInput{
+		Name:         aws.String(name),
+		EventPattern: aws.String(string(data)),
+		State:        aws.String(eventbridge.RuleStateEnabled),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to put rule %q", name)
+	}
+	if _, err := s.EventBridgeClient.PutTargetsWithContext(context.TODO(), &eventbridge.PutTargetsInput{
+		Rule: aws.String(name),
+		Targets: []*eventbridge.Target{{
+			Arn: aws.String(queueARN),
+			Id:  aws.String(name),
+		}},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to add SQS target %q to rule %q", name, name)
+	}
+
+	policy, err := json.Marshal(iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		ID:      queueARN,
+		Statement: iamv1.Statements{
+			iamv1.StatementEntry{
+				Sid:       fmt.Sprintf("CAPAEvents_%s", name),
+				Effect:    iamv1.EffectAllow,
+				Principal: iamv1.Principals{iamv1.PrincipalService: iamv1.PrincipalID{"events.amazonaws.com"}},
+				Action:    iamv1.Actions{"sqs:SendMessage"},
+				Resource:  iamv1.Resources{queueARN},
+				Condition: iamv1.Conditions{
+					"ArnEquals": map[string]string{"aws:SourceArn": aws.StringValue(rule.RuleArn)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to JSON marshal policy")
+	}
+	// The lifecycle actions are no longer pending once the heartbeat timeout elapses, so they aren't kept longer.
+	if _, err := s.SQSClient.SetQueueAttributesWithContext(context.TODO(), &sqs.SetQueueAttributesInput{
+		QueueUrl: aws.String(queueURL),
+		Attributes: aws.StringMap(map[string]string{
+			sqs.QueueAttributeNamePolicy:                 string(policy),
+			sqs.QueueAttributeNameMessageRetentionPeriod: strconv.FormatInt(max(heartbeatTimeout, 60), 10),
+		}),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to update attributes of queue %q", name)
+	}
+
+	scope.Info("Putting termination lifecycle hook", "heartbeat-timeout", heartbeatTimeout)
+	if _, err := s.ASGClient.PutLifecycleHookWithContext(context.TODO(), &autoscaling.PutLifecycleHookInput{
+		AutoScalingGroupName: aws.String(asgName),
+		LifecycleHookName:    aws.String(terminationDrainHookName),
+		LifecycleTransition:  aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+		HeartbeatTimeout:     aws.Int64(heartbeatTimeout),
+		DefaultResult:        aws.String("CONTINUE"),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to put lifecycle hook of AutoScalingGroup: %q", asgName)
+	}
+
+	return nil
+}
+
+// reconcileTerminationDrainQueue creates the SQS queue receiving the lifecycle actions, if it doesn't exist, and
+// returns its URL and ARN.
+func (s *Service) reconcileTerminationDrainQueue(name string) (string, string, error) {
+	var queueURL string
+	out, err := s.SQSClient.GetQueueUrlWithContext(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String(name)})
+	switch {
+	case err == nil:
+		queueURL = aws.StringValue(out.QueueUrl)
+	case isQueueNotFound(err):
+		created, err := s.SQSClient.CreateQueueWithContext(context.TODO(), &sqs.CreateQueueInput{
+			QueueName: aws.String(name),
+			Attributes: aws.StringMap(map[string]string{
+				sqs.QueueAttributeNameVisibilityTimeout: strconv.Itoa(terminationDrainVisibilityTimeout),
+			}),
+		})
+		if err != nil {
+			return "", "", errors.Wrapf(err, "failed to create queue %q", name)
+		}
+		queueURL = aws.StringValue(created.QueueUrl)
+	default:
+		return "", "", errors.Wrapf(err, "failed to get URL of queue %q", name)
+	}
+
+	attrs, err := s.SQSClient.GetQueueAttributesWithContext(context.TODO(), &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(queueURL),
+		AttributeNames: aws.StringSlice([]string{sqs.QueueAttributeNameQueueArn}),
+	})
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to get attributes of queue %q", name)
+	}
+
+	return queueURL, aws.StringValue(attrs.Attributes[sqs.QueueAttributeNameQueueArn]), nil
+}
+
+// DeleteTerminationLifecycleHook deletes the termination lifecycle hook of the ASG, along with the SQS queue
+// and the EventBridge rule delivering its lifecycle actions.
+func (s *Service) DeleteTerminationLifecycleHook(scope *scope.MachinePoolScope) error {
+	asgName := scope.ASGName()
+
+	scope.Info("Deleting termination lifecycle hook")
+	if _, err := s.ASGClient.DeleteLifecycleHookWithContext(context.TODO(), &autoscaling.DeleteLifecycleHookInput{
+		AutoScalingGroupName: aws.String(asgName),
+		LifecycleHookName:    aws.String(terminationDrainHookName),
+	}); err != nil && !isValidationError(err) {
+		// A ValidationError is returned if the hook or the ASG doesn't exist.
+		return errors.Wrapf(err, "failed to delete lifecycle hook of AutoScalingGroup: %q", asgName)
+	}
+
+	name, err := terminationDrainResourceName(asgName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.EventBridgeClient.RemoveTargetsWithContext(context.TODO(), &eventbridge.RemoveTargetsInput{
+		Rule: aws.String(name),
+		Ids:  aws.StringSlice([]string{name}),
+	}); err != nil && !isRuleNotFound(err) {
+		return errors.Wrapf(err, "failed to remove target %q of rule %q", name, name)
+	}
+	if _, err := s.EventBridgeClient.DeleteRuleWithContext(context.TODO(), &eventbridge.DeleteRuleInput{
+		Name: aws.String(name),
+	}); err != nil && !isRuleNotFound(err) {
+		return errors.Wrapf(err, "failed to delete rule %q", name)
+	}
+
+	out, err := s.SQSClient.GetQueueUrlWithContext(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String(name)})
+	if err != nil {
+		if isQueueNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get URL of queue %q", name)
+	}
+	if _, err := s.SQSClient.DeleteQueueWithContext(context.TODO(), &sqs.DeleteQueueInput{QueueUrl: out.QueueUrl}); err != nil && !isQueueNotFound(err) {
+		return errors.Wrapf(err, "failed to delete queue %q", name)
+	}
+
+	return nil
+}
+
+// GetTerminationLifecycleActions returns the pending termination lifecycle actions of the ASG. The actions which
+// aren't completed are received again once the visibility timeout of the queue elapses.
+func (s *Service) GetTerminationLifecycleActions(scope *scope.MachinePoolScope) ([]expinfrav1.LifecycleAction, error) {
+	name, err := terminationDrainResourceName(scope.ASGName())
+	if err != nil {
+		return nil, err
+	}
+
+	urlOut, err := s.SQSClient.GetQueueUrlWithContext(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String(name)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get URL of queue %q", name)
+	}
+	queueURL := aws.StringValue(urlOut.QueueUrl)
+
+	out, err := s.SQSClient.ReceiveMessageWithContext(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(queueURL),
+		MaxNumberOfMessages: aws.Int64(10),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to receive messages of queue %q", name)
+	}
+
+	actions := make([]expinfrav1.LifecycleAction, 0, len(out.Messages))
+	for _, msg := range out.Messages {
+		event := lifecycleActionEvent{}
+		if err := json.Unmarshal([]byte(aws.StringValue(msg.Body)), &event); err != nil || event.DetailType != terminateLifecycleActionDetailType {
+			scope.Info("Deleting unexpected message from the termination lifecycle hook queue", "message-id", aws.StringValue(msg.MessageId))
+			if _, err := s.SQSClient.DeleteMessageWithContext(context.TODO(), &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(queueURL),
+				ReceiptHandle: msg.ReceiptHandle,
+			}); err != nil {
+				return nil, errors.Wrapf(err, "failed to delete message of queue %q", name)
+			}
+			continue
+		}
+
+		actions = append(actions, expinfrav1.LifecycleAction{
+			ASGName:       event.Detail.AutoScalingGroupName,
+			HookName:      event.Detail.LifecycleHookName,
+			InstanceID:    event.Detail.EC2InstanceID,
+			Token:         event.Detail.LifecycleActionToken,
+			QueueURL:      queueURL,
+			ReceiptHandle: aws.StringValue(msg.ReceiptHandle),
+		})
+	}
+
+	return actions, nil
+}
+
+// CompleteTerminationLifecycleAction lets the ASG terminate the instance of the lifecycle action, and deletes the
+// action from the queue.
+func (s *Service) CompleteTerminationLifecycleAction(action expinfrav1.LifecycleAction) error {
+	if _, err := s.ASGClient.CompleteLifecycleActionWithContext(context.TODO(), &autoscaling.CompleteLifecycleActionInput{
+		AutoScalingGroupName:  aws.String(action.ASGName),
+		LifecycleHookName:     aws.String(action.HookName),
+		LifecycleActionToken:  aws.String(action.Token),
+		InstanceId:            aws.String(action.InstanceID),
+		LifecycleActionResult: aws.String("CONTINUE"),
+	}); err != nil && !isValidationError(err) {
+		// A ValidationError is returned if the action is no longer pending, e.g. its heartbeat timed out.
+		return errors.Wrapf(err, "failed to complete lifecycle action of instance %q", action.InstanceID)
+	}
+
+	if _, err := s.SQSClient.DeleteMessageWithContext(context.TODO(), &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(action.QueueURL),
+		ReceiptHandle: aws.String(action.ReceiptHandle),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to delete lifecycle action of instance %q", action.InstanceID)
+	}
+
+	return nil
+}
+
+// terminationDrainResourceName returns the name of the SQS queue and of the EventBridge rule delivering the
+// lifecycle actions of the ASG. The names are hashed if they don't fit in the 64 characters of a rule name.
+func terminationDrainResourceName(asgName string) (string, error) {
+	name := invalidResourceNameChars.ReplaceAllString(asgName, "-") + "-drain"
+	if len(name) <= 64 {
+		return name, nil
+	}
+
+	hashed, err := hash.Base36TruncatedHash(asgName, 32)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create termination drain resource name")
+	}
+	return hashed + "-drain", nil
+}
+
+func isQueueNotFound(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == sqs.ErrCodeQueueDoesNotExist
+	}
+	return false
+}
+
+func isRuleNotFound(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == eventbridge.ErrCodeResourceNotFoundException
+	}
+	return false
+}
+
+func isValidationError(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == "ValidationError"
+	}
+	return false
+}
+
+type lifecycleActionEventPattern struct {
+	Source     []string                          `json:"source"`
+	DetailType []string                          `json:"detail-type"`
+	Detail     lifecycleActionEventPatternDetail `json:"detail"`
+}
+
+type lifecycleActionEventPatternDetail struct {
+	AutoScalingGroupName []string `json:"AutoScalingGroupName"`
+	LifecycleHookName    []string `json:"LifecycleHookName"`
+}
+
+type lifecycleActionEvent struct {
+	DetailType string                     `json:"detail-type"`
+	Detail     lifecycleActionEventDetail `json:"detail"`
+}
+
+type lifecycleActionEventDetail struct {
+	AutoScalingGroupName string `json:"AutoScalingGroupName"`
+	LifecycleHookName    string `json:"LifecycleHookName"`
+	LifecycleActionToken string `json:"LifecycleActionToken"`
+	EC2InstanceID        string `json:"EC2InstanceId"`
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_eventbridgeiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_sqsiface"
+)
+
+func TestServiceReconcileTerminationLifecycleHook(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(a *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, q *mock_sqsiface.MockSQSAPIMockRecorder, e *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder)
+	}{
+		{
+			name: "does nothing if the hook is up to date",
+			expect: func(a *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, q *mock_sqsiface.MockSQSAPIMockRecorder, e *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				a.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeLifecycleHooksInput{
+					AutoScalingGroupName: aws.String("pool"),
+					LifecycleHookNames:   aws.StringSlice([]string{"capa-termination-drain"}),
+				})).Return(&autoscaling.DescribeLifecycleHooksOutput{
+					LifecycleHooks: []*autoscaling.LifecycleHook{{HeartbeatTimeout: aws.Int64(300)}},
+				}, nil)
+			},
+		},
+		{
+			name: "creates the queue, the rule and the hook",
+			expect: func(a *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, q *mock_sqsiface.MockSQSAPIMockRecorder, e *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				a.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Any()).Return(&autoscaling.DescribeLifecycleHooksOutput{}, nil)
+				q.GetQueueUrlWithContext(context.TODO(), gomock.Eq(&sqs.GetQueueUrlInput{QueueName: aws.String("pool-drain")})).
+					Return(nil, awserr.New(sqs.ErrCodeQueueDoesNotExist, "", nil))
+				q.CreateQueueWithContext(context.TODO(), gomock.Any()).Return(&sqs.CreateQueueOutput{QueueUrl: aws.String("https://queue")}, nil)
+				q.GetQueueAttributesWithContext(context.TODO(), gomock.Any()).Return(&sqs.GetQueueAttributesOutput{
+					Attributes: aws.StringMap(map[string]string{sqs.QueueAttributeNameQueueArn: "arn:aws:sqs:us-east-1:123456789012:pool-drain"}),
+				}, nil)
+				e.PutRuleWithContext(context.TODO(), gomock.Any()).DoAndReturn(func(_ context.Context, input *eventbridge.PutRuleInput, _ ...request.Option) (*eventbridge.PutRuleOutput, error) {
+					g := NewWithT(t)
+					g.Expect(aws.StringValue(input.Name)).To(Equal("pool-drain"))
+					g.Expect(aws.StringValue(input.EventPattern)).To(Equal(`{"source":["aws.autoscaling"],"detail-type":["EC2 Instance-terminate Lifecycle Action"],"detail":{"AutoScalingGroupName":["pool"],"LifecycleHookName":["capa-termination-drain"]}}`))
+					return &eventbridge.PutRuleOutput{RuleArn: aws.String("arn:aws:events:us-east-1:123456789012:rule/pool-drain")}, nil
+				})
+				e.PutTargetsWithContext(context.TODO(), gomock.Eq(&eventbridge.PutTargetsInput{
+					Rule: aws.String("pool-drain"),
+					Targets: []*eventbridge.Target{{
+						Arn: aws.String("arn:aws:sqs:us-east-1:123456789012:pool-drain"),
+						Id:  aws.String("pool-drain"),
+					}},
+				})).Return(&eventbridge.PutTargetsOutput{}, nil)
+				q.SetQueueAttributesWithContext(context.TODO(), gomock.Any()).DoAndReturn(func(_ context.Context, input *sqs.SetQueueAttributesInput, _ ...request.Option) (*sqs.SetQueueAttributesOutput, error) {
+					g := NewWithT(t)
+					g.Expect(aws.StringValue(input.Attributes[sqs.QueueAttributeNameMessageRetentionPeriod])).To(Equal("300"))
+					g.Expect(aws.StringValue(input.Attributes[sqs.QueueAttributeNamePolicy])).To(ContainSubstring("arn:aws:events:us-east-1:123456789012:rule/pool-drain"))
+					return &sqs.SetQueueAttributesOutput{}, nil
+				})
+				a.PutLifecycleHookWithContext(context.TODO(), gomock.Eq(&autoscaling.PutLifecycleHookInput{
+					AutoScalingGroupName: aws.String("pool"),
+					LifecycleHookName:    aws.String("capa-termination-drain"),
+					LifecycleTransition:  aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+					HeartbeatTimeout:     aws.Int64(300),
+					DefaultResult:        aws.String("CONTINUE"),
+				})).Return(&autoscaling.PutLifecycleHookOutput{}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			sqsMock := mock_sqsiface.NewMockSQSAPI(mockCtrl)
+			eventBridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT(), sqsMock.EXPECT(), eventBridgeMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+			s.SQSClient = sqsMock
+			s.EventBridgeClient = eventBridgeMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = "pool"
+			mps.AWSMachinePool.Spec.TerminationDrain = &expinfrav1.TerminationDrain{HeartbeatTimeout: &metav1.Duration{Duration: 5 * time.Minute}}
+
+			g.Expect(s.ReconcileTerminationLifecycleHook(mps)).To(Succeed())
+		})
+	}
+}
+
+func TestServiceGetTerminationLifecycleActions(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	fakeClient := getFakeClient()
+
+	clusterScope, err := getClusterScope(fakeClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	sqsMock := mock_sqsiface.NewMockSQSAPI(mockCtrl)
+	s := NewService(clusterScope)
+	s.SQSClient = sqsMock
+
+	mps, err := getMachinePoolScope(fakeClient, clusterScope)
+	g.Expect(err).ToNot(HaveOccurred())
+	mps.AWSMachinePool.Name = "pool"
+
+	sqsMock.EXPECT().GetQueueUrlWithContext(context.TODO(), gomock.Eq(&sqs.GetQueueUrlInput{QueueName: aws.String("pool-drain")})).
+		Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("https://queue")}, nil)
+	sqsMock.EXPECT().ReceiveMessageWithContext(context.TODO(), gomock.Any()).Return(&sqs.ReceiveMessageOutput{
+		Messages: []*sqs.Message{
+			{
+				ReceiptHandle: aws.String("handle-1"),
+				Body: aws.String(`{"detail-type":"EC2 Instance-terminate Lifecycle Action","detail":{"AutoScalingGroupName":"pool",` +
+					`"LifecycleHookName":"capa-termination-drain","LifecycleActionToken":"token-1","EC2InstanceId":"i-1"}}`),
+			},
+			{
+				ReceiptHandle: aws.String("handle-2"),
+				Body:          aws.String(`{"detail-type":"EC2 Instance State-change Notification"}`),
+			},
+		},
+	}, nil)
+	sqsMock.EXPECT().DeleteMessageWithContext(context.TODO(), gomock.Eq(&sqs.DeleteMessageInput{
+		QueueUrl:      aws.String("https://queue"),
+		ReceiptHandle: aws.String("handle-2"),
+	})).Return(&sqs.DeleteMessageOutput{}, nil)
+
+	actions, err := s.GetTerminationLifecycleActions(mps)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(actions).To(Equal([]expinfrav1.LifecycleAction{{
+		ASGName:       "pool",
+		HookName:      "capa-termination-drain",
+		InstanceID:    "i-1",
+		Token:         "token-1",
+		QueueURL:      "https://queue",
+		ReceiptHandle: "handle-1",
+	}}))
+}
+
+func TestServiceCompleteTerminationLifecycleAction(t *testing.T) {
+	tests := []struct {
+		name        string
+		completeErr error
+		wantErr     bool
+	}{
+		{
+			name: "completes the action and deletes it from the queue",
+		},
+		{
+			name:        "deletes the action from the queue if it is no longer pending",
+			completeErr: awserr.New("ValidationError", "No active Lifecycle Action found with instance ID i-1", nil),
+		},
+		{
+			name:        "keeps the action in the queue if it can't be completed",
+			completeErr: awserr.New("ResourceContention", "", nil),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			clusterScope, err := getClusterScope(getFakeClient())
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			sqsMock := mock_sqsiface.NewMockSQSAPI(mockCtrl)
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+			s.SQSClient = sqsMock
+
+			asgMock.EXPECT().CompleteLifecycleActionWithContext(context.TODO(), gomock.Eq(&autoscaling.CompleteLifecycleActionInput{
+				AutoScalingGroupName:  aws.String("pool"),
+				LifecycleHookName:     aws.String("capa-termination-drain"),
+				LifecycleActionToken:  aws.String("token-1"),
+				InstanceId:            aws.String("i-1"),
+				LifecycleActionResult: aws.String("CONTINUE"),
+			})).Return(&autoscaling.CompleteLifecycleActionOutput{}, tt.completeErr)
+			if !tt.wantErr {
+				sqsMock.EXPECT().DeleteMessageWithContext(context.TODO(), gomock.Eq(&sqs.DeleteMessageInput{
+					QueueUrl:      aws.String("https://queue"),
+					ReceiptHandle: aws.String("handle-1"),
+				})).Return(&sqs.DeleteMessageOutput{}, nil)
+			}
+
+			err = s.CompleteTerminationLifecycleAction(expinfrav1.LifecycleAction{
+				ASGName:       "pool",
+				HookName:      "capa-termination-drain",
+				InstanceID:    "i-1",
+				Token:         "token-1",
+				QueueURL:      "https://queue",
+				ReceiptHandle: "handle-1",
+			})
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestTerminationDrainResourceName(t *testing.T) {
+	g := NewWithT(t)
+
+	name, err := terminationDrainResourceName("my.pool")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(name).To(Equal("my-pool-drain"))
+
+	name, err = terminationDrainResourceName(strings.Repeat("a", 64))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(name).To(HaveLen(38))
+	g.Expect(name).To(HaveSuffix("-drain"))
+}
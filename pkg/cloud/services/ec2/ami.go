@@ -62,6 +62,9 @@ const (
 	// Description regex for fetching Ubuntu AMIs for bastion host.
 	ubuntuImageDescription = "Canonical??Ubuntu??20.04?LTS??amd64?focal?image*"
 
+	// Description regex for fetching arm64 Ubuntu AMIs for bastion host.
+	ubuntuArm64ImageDescription = "Canonical??Ubuntu??20.04?LTS??arm64?focal?image*"
+
 	// defaultMachineAMILookupBaseOS is the default base operating system to use
 	// when looking up machine AMIs.
 	defaultMachineAMILookupBaseOS = "ubuntu-18.04"
@@ -85,6 +88,9 @@ const (
 
 	// EKS GPU AMI ID SSM Parameter name.
 	eksGPUAmiSSMParameterFormat = "/aws/service/eks/optimized-ami/%s/amazon-linux-2-gpu/recommended/image_id"
+
+	// EKS Amazon Linux 2023 AMI ID SSM Parameter name, for the Kubernetes version and the architecture.
+	eksAL2023AmiSSMParameterFormat = "/aws/service/eks/optimized-ami/%s/amazon-linux-2023/%s/standard/recommended/image_id"
 )
 
 // AMILookup contains the parameters used to template AMI names used for lookup.
@@ -160,8 +166,8 @@ archCheck:
 	return architecture, nil
 }
 
-// DefaultAMILookup will do a default AMI lookup.
-func DefaultAMILookup(ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat string) (*ec2.Image, error) {
+// DefaultAMILookup will do a default AMI lookup. AMIs of any root device type are considered if rootDeviceType is empty.
+func DefaultAMILookup(ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat, rootDeviceType string) (*ec2.Image, error) {
 	if amiNameFormat == "" {
 		amiNameFormat = DefaultAmiNameFormat
 	}
@@ -200,6 +206,12 @@ func DefaultAMILookup(ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVers
 			},
 		},
 	}
+	if rootDeviceType != "" {
+		describeImageInput.Filters = append(describeImageInput.Filters, &ec2.Filter{
+			Name:   aws.String("root-device-type"),
+			Values: []*string{aws.String(rootDeviceType)},
+		})
+	}
 
 	out, err := ec2Client.DescribeImagesWithContext(context.TODO(), describeImageInput)
 	if err != nil {
@@ -217,8 +229,8 @@ func DefaultAMILookup(ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVers
 }
 
 // defaultAMIIDLookup returns the default AMI based on region.
-func (s *Service) defaultAMIIDLookup(amiNameFormat, ownerID, baseOS, architecture, kubernetesVersion string) (string, error) {
-	latestImage, err := DefaultAMILookup(s.EC2Client, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat)
+func (s *Service) defaultAMIIDLookup(amiNameFormat, ownerID, baseOS, architecture, kubernetesVersion, rootDeviceType string) (string, error) {
+	latestImage, err := DefaultAMILookup(s.EC2Client, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat, rootDeviceType)
 	if err != nil {
 		record.Eventf(s.scope.InfraCluster(), "FailedDescribeImages", "Failed to find ami for OS=%s, Architecture=%s and Kubernetes-version=%s: %v", baseOS, architecture, kubernetesVersion, err)
 		return "", errors.Wrapf(err, "failed to find ami")
@@ -228,6 +240,58 @@ func (s *Service) defaultAMIIDLookup(amiNameFormat, ownerID, baseOS, architectur
 	return aws.StringValue(latestImage.ImageId), nil
 }
 
+// filteredAMIIDLookup returns the most recent AMI of the architecture matching all the filters.
+func (s *Service) filteredAMIIDLookup(filters []infrav1.Filter, architecture string) (string, error) {
+	input := &ec2.DescribeImagesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("architecture"),
+				Values: []*string{aws.String(architecture)},
+			},
+			{
+				Name:   aws.String("state"),
+				Values: []*string{aws.String("available")},
+			},
+		},
+	}
+	for _, f := range filters {
+		input.Filters = append(input.Filters, &ec2.Filter{
+			Name:   aws.String(f.Name),
+			Values: aws.StringSlice(f.Values),
+		})
+	}
+
+	out, err := s.EC2Client.DescribeImagesWithContext(context.TODO(), input)
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeImages", "Failed to find ami matching the filters for Architecture=%s: %v", architecture, err)
+		return "", errors.Wrap(err, "failed to find ami matching the filters")
+	}
+	if out == nil || len(out.Images) == 0 {
+		return "", errors.Errorf("found no AMIs of architecture %q matching the filters", architecture)
+	}
+	latestImage, err := GetLatestImage(out.Images)
+	if err != nil {
+		return "", err
+	}
+
+	s.scope.Debug("Found and using an existing AMI matching the filters", "ami-id", aws.StringValue(latestImage.ImageId))
+	return aws.StringValue(latestImage.ImageId), nil
+}
+
+// marketplaceAMIFilters returns the filters matching the AMIs of the AWS Marketplace product.
+func marketplaceAMIFilters(productCode string) []infrav1.Filter {
+	return []infrav1.Filter{
+		{
+			Name:   "product-code",
+			Values: []string{productCode},
+		},
+		{
+			Name:   "owner-alias",
+			Values: []string{"aws-marketplace"},
+		},
+	}
+}
+
 type images []*ec2.Image
 
 // Len is the number of elements in the collection.
@@ -261,12 +325,18 @@ func GetLatestImage(imgs []*ec2.Image) (*ec2.Image, error) {
 	return imgs[len(imgs)-1], nil
 }
 
-func (s *Service) defaultBastionAMILookup() (string, error) {
+// defaultBastionAMILookup returns the latest Ubuntu AMI of the architecture for the bastion host.
+func (s *Service) defaultBastionAMILookup(architecture string) (string, error) {
+	description := ubuntuImageDescription
+	if architecture == Arm64ArchitectureTag {
+		description = ubuntuArm64ImageDescription
+	}
+
 	describeImageInput := &ec2.DescribeImagesInput{
 		Filters: []*ec2.Filter{
 			{
 				Name:   aws.String("architecture"),
-				Values: []*string{aws.String("x86_64")},
+				Values: []*string{aws.String(architecture)},
 			},
 			{
 				Name:   aws.String("state"),
@@ -278,7 +348,7 @@ func (s *Service) defaultBastionAMILookup() (string, error) {
 			},
 			{
 				Name:   aws.String("description"),
-				Values: aws.StringSlice([]string{ubuntuImageDescription}),
+				Values: aws.StringSlice([]string{description}),
 			},
 		},
 	}
@@ -325,6 +395,13 @@ func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, am
 	switch *amiType {
 	case infrav1.AmazonLinuxGPU:
 		paramName = fmt.Sprintf(eksGPUAmiSSMParameterFormat, formattedVersion)
+	case infrav1.AmazonLinux2023:
+		switch architecture {
+		case Arm64ArchitectureTag, Amd64ArchitectureTag:
+			paramName = fmt.Sprintf(eksAL2023AmiSSMParameterFormat, formattedVersion, architecture)
+		default:
+			return "", fmt.Errorf("cannot look up eks-optimized image for architecture %q", architecture)
+		}
 	default:
 		switch architecture {
 		case Arm64ArchitectureTag:
@@ -336,6 +413,17 @@ func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, am
 		}
 	}
 
+	id, err := s.ssmParameterAMIIDLookup(paramName)
+	if err != nil {
+		return "", err
+	}
+	s.scope.Info("found AMI", "id", id, "version", formattedVersion)
+
+	return id, nil
+}
+
+// ssmParameterAMIIDLookup returns the ID of the AMI stored in the SSM parameter.
+func (s *Service) ssmParameterAMIIDLookup(paramName string) (string, error) {
 	input := &ssm.GetParameterInput{
 		Name: aws.String(paramName),
 	}
@@ -352,11 +440,34 @@ func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, am
 	}
 
 	id := aws.StringValue(out.Parameter.Value)
-	s.scope.Info("found AMI", "id", id, "version", formattedVersion)
-
+	s.scope.Debug("Found AMI in SSM parameter", "ami-id", id, "parameter", paramName)
 	return id, nil
 }
 
+// GetAMIDeprecationTime returns the time at which the AMI is deprecated, or nil if the AMI has no deprecation time
+// or no longer exists.
+func (s *Service) GetAMIDeprecationTime(imageID string) (*time.Time, error) {
+	out, err := s.EC2Client.DescribeImagesWithContext(context.TODO(), &ec2.DescribeImagesInput{
+		ImageIds:          []*string{aws.String(imageID)},
+		IncludeDeprecated: aws.Bool(true),
+	})
+	if err != nil {
+		if code, _ := awserrors.Code(err); code == awserrors.ImageNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to describe AMI %q", imageID)
+	}
+	if len(out.Images) == 0 || out.Images[0].DeprecationTime == nil {
+		return nil, nil
+	}
+
+	deprecationTime, err := time.Parse(time.RFC3339, aws.StringValue(out.Images[0].DeprecationTime))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the deprecation time of AMI %q", imageID)
+	}
+	return &deprecationTime, nil
+}
+
 func formatVersionForEKS(version string) (string, error) {
 	parsed, err := semver.ParseTolerant(version)
 	if err != nil {
@@ -19,8 +19,10 @@ package ec2
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/golang/mock/gomock"
@@ -43,6 +45,7 @@ func TestDefaultAMILookup(t *testing.T) {
 		architecture      string
 		kubernetesVersion string
 		amiNameFormat     string
+		rootDeviceType    string
 	}
 
 	testCases := []struct {
@@ -84,6 +87,59 @@ func TestDefaultAMILookup(t *testing.T) {
 				g.Expect(*img.ImageId).Should(ContainSubstring("latest"))
 			},
 		},
+		{
+			name: "Should filter AMIs by root device type if passed",
+			args: args{
+				ownerID:           "ownerID",
+				baseOS:            "baseOS",
+				architecture:      "arm64",
+				kubernetesVersion: "v1.0.0",
+				amiNameFormat:     "golden-{{.BaseOS}}-{{.K8sVersion}}-*",
+				rootDeviceType:    "ebs",
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("owner-id"),
+							Values: []*string{aws.String("ownerID")},
+						},
+						{
+							Name:   aws.String("name"),
+							Values: []*string{aws.String("golden-baseOS-1.0.0-*")},
+						},
+						{
+							Name:   aws.String("architecture"),
+							Values: []*string{aws.String("arm64")},
+						},
+						{
+							Name:   aws.String("state"),
+							Values: []*string{aws.String("available")},
+						},
+						{
+							Name:   aws.String("virtualization-type"),
+							Values: []*string{aws.String("hvm")},
+						},
+						{
+							Name:   aws.String("root-device-type"),
+							Values: []*string{aws.String("ebs")},
+						},
+					},
+				})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								ImageId:      aws.String("golden"),
+								CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+			},
+			check: func(g *WithT, img *ec2.Image, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(*img.ImageId).Should(Equal("golden"))
+			},
+		},
 		{
 			name: "Should return with error if AWS DescribeImages call failed with some error",
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
@@ -115,7 +171,7 @@ func TestDefaultAMILookup(t *testing.T) {
 			ec2Mock := mocks.NewMockEC2API(mockCtrl)
 			tc.expect(ec2Mock.EXPECT())
 
-			img, err := DefaultAMILookup(ec2Mock, tc.args.ownerID, tc.args.baseOS, tc.args.kubernetesVersion, tc.args.architecture, tc.args.amiNameFormat)
+			img, err := DefaultAMILookup(ec2Mock, tc.args.ownerID, tc.args.baseOS, tc.args.kubernetesVersion, tc.args.architecture, tc.args.amiNameFormat, tc.args.rootDeviceType)
 			tc.check(g, img, err)
 		})
 	}
@@ -203,7 +259,7 @@ func TestDefaultAMILookupArm64(t *testing.T) {
 			ec2Mock := mocks.NewMockEC2API(mockCtrl)
 			tc.expect(ec2Mock.EXPECT())
 
-			img, err := DefaultAMILookup(ec2Mock, tc.args.ownerID, tc.args.baseOS, tc.args.kubernetesVersion, tc.args.architecture, tc.args.amiNameFormat)
+			img, err := DefaultAMILookup(ec2Mock, tc.args.ownerID, tc.args.baseOS, tc.args.kubernetesVersion, tc.args.architecture, tc.args.amiNameFormat, "")
 			tc.check(g, img, err)
 		})
 	}
@@ -288,7 +344,96 @@ func TestAMIs(t *testing.T) {
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
 
-			id, err := s.defaultAMIIDLookup("", "", "base os-baseos version", "x86_64", "v1.11.1")
+			id, err := s.defaultAMIIDLookup("", "", "base os-baseos version", "x86_64", "v1.11.1", "")
+			tc.check(g, id, err)
+		})
+	}
+}
+
+func TestFilteredAMIIDLookup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	filters := []infrav1.Filter{
+		{
+			Name:   "name",
+			Values: []string{"Deep Learning Base OSS Nvidia Driver GPU AMI*"},
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		expect func(m *mocks.MockEC2APIMockRecorder)
+		check  func(g *WithT, id string, err error)
+	}{
+		{
+			name: "Should return the latest AMI matching the filters",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("architecture"),
+							Values: []*string{aws.String("x86_64")},
+						},
+						{
+							Name:   aws.String("state"),
+							Values: []*string{aws.String("available")},
+						},
+						{
+							Name:   aws.String("name"),
+							Values: []*string{aws.String("Deep Learning Base OSS Nvidia Driver GPU AMI*")},
+						},
+					},
+				})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								ImageId:      aws.String("ancient"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+							{
+								ImageId:      aws.String("latest"),
+								CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+			},
+			check: func(g *WithT, id string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(id).Should(Equal("latest"))
+			},
+		},
+		{
+			name: "Should return error if no AMI matches the filters",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+					Return(&ec2.DescribeImagesOutput{}, nil)
+			},
+			check: func(g *WithT, id string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(id).Should(BeEmpty())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			id, err := s.filteredAMIIDLookup(filters, "x86_64")
 			tc.check(g, id, err)
 		})
 	}
@@ -480,6 +625,7 @@ func TestEKSAMILookUp(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	gpuAMI := infrav1.AmazonLinuxGPU
+	al2023AMI := infrav1.AmazonLinux2023
 	tests := []struct {
 		name       string
 		k8sVersion string
@@ -522,6 +668,23 @@ func TestEKSAMILookUp(t *testing.T) {
 			want:    "id",
 			wantErr: false,
 		},
+		{
+			name:       "Should return an id corresponding to the architecture if Amazon Linux 2023 AMI type passed",
+			k8sVersion: "v1.29.1",
+			arch:       "arm64",
+			amiType:    &al2023AMI,
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String("/aws/service/eks/optimized-ami/1.29/amazon-linux-2023/arm64/standard/recommended/image_id"),
+				})).Return(&ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{
+						Value: aws.String("id"),
+					},
+				}, nil)
+			},
+			want:    "id",
+			wantErr: false,
+		},
 		{
 			name:       "Should return an error if GetParameter call fails with some AWS error",
 			k8sVersion: "v1.23.3",
@@ -579,3 +742,164 @@ func TestEKSAMILookUp(t *testing.T) {
 		})
 	}
 }
+
+func TestSSMParameterAMIIDLookup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	paramName := "/aws/service/bottlerocket/aws-k8s-1.29/x86_64/latest/image_id"
+	tests := []struct {
+		name    string
+		expect  func(m *mock_ssmiface.MockSSMAPIMockRecorder)
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Should return the AMI ID held by the SSM parameter",
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String(paramName),
+				})).Return(&ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{
+						Value: aws.String("ami-1234567890"),
+					},
+				}, nil)
+			},
+			want: "ami-1234567890",
+		},
+		{
+			name: "Should return an error if GetParameter call fails with some AWS error",
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String(paramName),
+				})).Return(nil, awserrors.NewNotFound("parameter not found"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return an error if the SSM parameter has no value",
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String(paramName),
+				})).Return(&ssm.GetParameterOutput{}, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+			tt.expect(ssmMock.EXPECT())
+
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(clusterScope)
+			s.SSMClient = ssmMock
+
+			got, err := s.ssmParameterAMIIDLookup(paramName)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).Should(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetAMIDeprecationTime(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	imageID := "ami-1234567890"
+	input := &ec2.DescribeImagesInput{
+		ImageIds:          []*string{aws.String(imageID)},
+		IncludeDeprecated: aws.Bool(true),
+	}
+	tests := []struct {
+		name    string
+		expect  func(m *mocks.MockEC2APIMockRecorder)
+		want    *time.Time
+		wantErr bool
+	}{
+		{
+			name: "Should return the deprecation time of the AMI",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(input)).Return(&ec2.DescribeImagesOutput{
+					Images: []*ec2.Image{
+						{
+							ImageId:         aws.String(imageID),
+							DeprecationTime: aws.String("2024-06-01T00:00:00.000Z"),
+						},
+					},
+				}, nil)
+			},
+			want: aws.Time(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "Should return nil if the AMI has no deprecation time",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(input)).Return(&ec2.DescribeImagesOutput{
+					Images: []*ec2.Image{
+						{
+							ImageId: aws.String(imageID),
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "Should return nil if the AMI no longer exists",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(input)).
+					Return(nil, awserr.New(awserrors.ImageNotFound, "The image id does not exist", nil))
+			},
+		},
+		{
+			name: "Should return an error if DescribeImages call fails with some AWS error",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(input)).
+					Return(nil, awserr.New(awserrors.UnauthorizedOperation, "", nil))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tt.expect(ec2Mock.EXPECT())
+
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			got, err := s.GetAMIDeprecationTime(imageID)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tt.want == nil {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got).NotTo(BeNil())
+			g.Expect(got.Equal(*tt.want)).To(BeTrue())
+		})
+	}
+}
@@ -96,6 +96,15 @@ func (s *Service) ReconcileBastion() error {
 
 	// TODO(vincepri): check for possible changes between the default spec and the instance.
 
+	// The bastion host consumes its public address from the cluster-wide BYO Public IPv4 Pool when
+	// defined. The Elastic IP replaces the address assigned by the public subnet on launch.
+	if pool := s.scope.VPC().GetElasticIPPool(); pool != nil && pool.PublicIpv4Pool != nil {
+		if err := s.reconcileBastionElasticIP(pool, instance); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedAssociateBastionEIP", "Failed to associate Elastic IP to bastion instance %q: %v", instance.ID, err)
+			return err
+		}
+	}
+
 	s.scope.SetBastionInstance(instance.DeepCopy())
 	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition)
 	s.scope.Debug("Reconcile bastion completed successfully")
@@ -125,6 +134,13 @@ func (s *Service) DeleteBastion() error {
 		return errors.Wrap(err, "unable to delete bastion instance")
 	}
 
+	if pool := s.scope.VPC().GetElasticIPPool(); pool != nil && pool.PublicIpv4Pool != nil {
+		if err := s.ReleaseElasticIP(instance.ID); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedReleaseBastionEIP", "Failed to release Elastic IP of bastion instance %q: %v", instance.ID, err)
+			return errors.Wrap(err, "unable to release bastion elastic IP")
+		}
+	}
+
 	s.scope.SetBastionInstance(nil)
 
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
@@ -179,17 +195,26 @@ func (s *Service) getDefaultBastion(instanceType, ami string) (*infrav1.Instance
 
 	subnet := s.scope.Subnets().FilterPublic()[0]
 
+	// The fallback instance types are x86_64, the architecture only needs to be looked up for the instance type
+	// configured by the user, which may be Graviton based.
+	architecture := DefaultArchitectureTag
 	if instanceType == "" {
 		if strings.Contains(subnet.AvailabilityZone, "us-east-1") {
 			instanceType = fallbackBastionUsEast1InstanceType
 		} else {
 			instanceType = fallbackBastionInstanceType
 		}
+	} else if ami == "" {
+		var err error
+		architecture, err = s.pickArchitectureForInstanceType(instanceType)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if ami == "" {
 		var err error
-		ami, err = s.defaultBastionAMILookup()
+		ami, err = s.defaultBastionAMILookup(architecture)
 		if err != nil {
 			return nil, err
 		}
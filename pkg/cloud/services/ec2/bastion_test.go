@@ -27,6 +27,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -259,11 +260,12 @@ func TestServiceReconcileBastion(t *testing.T) {
 	}
 
 	tests := []struct {
-		name           string
-		bastionEnabled bool
-		expect         func(m *mocks.MockEC2APIMockRecorder)
-		expectError    bool
-		bastionStatus  *infrav1.Instance
+		name                string
+		bastionEnabled      bool
+		bastionInstanceType string
+		expect              func(m *mocks.MockEC2APIMockRecorder)
+		expectError         bool
+		bastionStatus       *infrav1.Instance
 	}{
 		{
 			name: "Should ignore reconciliation if instance not found",
@@ -368,15 +370,105 @@ func TestServiceReconcileBastion(t *testing.T) {
 			bastionEnabled: true,
 			expectError:    false,
 			bastionStatus: &infrav1.Instance{
-				ID:               "id123",
-				State:            "running",
-				Type:             "t3.micro",
-				SubnetID:         "subnet-1",
-				ImageID:          "ubuntu-ami-id-latest",
-				IAMProfile:       "foo",
-				Addresses:        []clusterv1.MachineAddress{},
-				AvailabilityZone: "us-east-1",
-				VolumeIDs:        []string{"volume-1"},
+				ID:                "id123",
+				State:             "running",
+				Type:              "t3.micro",
+				SubnetID:          "subnet-1",
+				InstanceLifecycle: infrav1.InstanceLifecycleOnDemand,
+				ImageID:           "ubuntu-ami-id-latest",
+				IAMProfile:        "foo",
+				Addresses:         []clusterv1.MachineAddress{},
+				AvailabilityZone:  "us-east-1",
+				VolumeIDs:         []string{"volume-1"},
+			},
+		},
+		{
+			name:                "Should create an arm64 bastion for Graviton instance types",
+			bastionInstanceType: "t4g.micro",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstancesWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeInstancesOutput{}, nil).MinTimes(1)
+				m.DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+					InstanceTypes: []*string{aws.String("t4g.micro")},
+				})).Return(&ec2.DescribeInstanceTypesOutput{
+					InstanceTypes: []*ec2.InstanceTypeInfo{
+						{
+							ProcessorInfo: &ec2.ProcessorInfo{
+								SupportedArchitectures: aws.StringSlice([]string{"arm64"}),
+							},
+						},
+					},
+				}, nil)
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{Filters: []*ec2.Filter{
+					{
+						Name:   aws.String("architecture"),
+						Values: aws.StringSlice([]string{"arm64"}),
+					},
+					{
+						Name:   aws.String("state"),
+						Values: aws.StringSlice([]string{"available"}),
+					},
+					{
+						Name:   aws.String("virtualization-type"),
+						Values: aws.StringSlice([]string{"hvm"}),
+					},
+					{
+						Name:   aws.String("description"),
+						Values: aws.StringSlice([]string{ubuntuArm64ImageDescription}),
+					},
+					{
+						Name:   aws.String("owner-id"),
+						Values: aws.StringSlice([]string{ubuntuOwnerID}),
+					},
+				}})).Return(&ec2.DescribeImagesOutput{Images: images{
+					{
+						ImageId:      aws.String("ubuntu-arm64-ami-id-latest"),
+						CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+					},
+				}}, nil)
+				m.RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNameRunning),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("id123"),
+								InstanceType:   aws.String("t4g.micro"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ubuntu-arm64-ami-id-latest"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: aws.String("us-east-1"),
+								},
+							},
+						},
+					}, nil)
+			},
+			bastionEnabled: true,
+			expectError:    false,
+			bastionStatus: &infrav1.Instance{
+				ID:                "id123",
+				State:             "running",
+				Type:              "t4g.micro",
+				SubnetID:          "subnet-1",
+				InstanceLifecycle: infrav1.InstanceLifecycleOnDemand,
+				ImageID:           "ubuntu-arm64-ami-id-latest",
+				IAMProfile:        "foo",
+				Addresses:         []clusterv1.MachineAddress{},
+				AvailabilityZone:  "us-east-1",
+				VolumeIDs:         []string{"volume-1"},
 			},
 		},
 	}
@@ -414,7 +506,7 @@ func TestServiceReconcileBastion(t *testing.T) {
 								},
 							},
 						},
-						Bastion: infrav1.Bastion{Enabled: tc.bastionEnabled},
+						Bastion: infrav1.Bastion{Enabled: tc.bastionEnabled, InstanceType: tc.bastionInstanceType},
 					},
 				}
 
@@ -600,15 +692,16 @@ func TestServiceReconcileBastionUSGOV(t *testing.T) {
 			bastionEnabled: true,
 			expectError:    false,
 			bastionStatus: &infrav1.Instance{
-				ID:               "id123",
-				State:            "running",
-				Type:             "t3.micro",
-				SubnetID:         "subnet-1",
-				ImageID:          "ubuntu-ami-id-latest",
-				IAMProfile:       "foo",
-				Addresses:        []clusterv1.MachineAddress{},
-				AvailabilityZone: "us-gov-east-1",
-				VolumeIDs:        []string{"volume-1"},
+				ID:                "id123",
+				State:             "running",
+				Type:              "t3.micro",
+				SubnetID:          "subnet-1",
+				InstanceLifecycle: infrav1.InstanceLifecycleOnDemand,
+				ImageID:           "ubuntu-ami-id-latest",
+				IAMProfile:        "foo",
+				Addresses:         []clusterv1.MachineAddress{},
+				AvailabilityZone:  "us-gov-east-1",
+				VolumeIDs:         []string{"volume-1"},
 			},
 		},
 	}
@@ -688,3 +781,152 @@ func TestServiceReconcileBastionUSGOV(t *testing.T) {
 		}
 	}
 }
+
+func TestServiceReconcileBastionElasticIPPool(t *testing.T) {
+	clusterName := "cluster"
+	poolID := "ipv4pool-ec2-0123456789abcdef0"
+
+	foundOutput := &ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{
+			{
+				Instances: []*ec2.Instance{
+					{
+						InstanceId: aws.String("id123"),
+						State: &ec2.InstanceState{
+							Name: aws.String(ec2.InstanceStateNameRunning),
+						},
+						Placement: &ec2.Placement{
+							AvailabilityZone: aws.String("us-east-1"),
+						},
+					},
+				},
+			},
+		},
+	}
+	describeBastionAddresses := &ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.Cluster(clusterName),
+			filter.EC2.ProviderRole("ec2-id123"),
+			{Name: aws.String("instance-id"), Values: aws.StringSlice([]string{"id123"})},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "Should associate an Elastic IP from the Public IPv4 Pool",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstancesWithContext(context.TODO(), gomock.Any()).Return(foundOutput, nil)
+				m.DescribeAddressesWithContext(context.TODO(), gomock.Eq(describeBastionAddresses)).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
+				m.DescribeAddressesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
+				m.DescribePublicIpv4Pools(gomock.Eq(&ec2.DescribePublicIpv4PoolsInput{
+					PoolIds: aws.StringSlice([]string{poolID}),
+				})).Return(&ec2.DescribePublicIpv4PoolsOutput{
+					PublicIpv4Pools: []*ec2.PublicIpv4Pool{{PoolId: aws.String(poolID), TotalAvailableAddressCount: aws.Int64(1)}},
+				}, nil)
+				m.AllocateAddressWithContext(context.TODO(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, input *ec2.AllocateAddressInput, _ ...interface{}) (*ec2.AllocateAddressOutput, error) {
+						if aws.StringValue(input.PublicIpv4Pool) != poolID {
+							t.Fatalf("unexpected public IPv4 pool %q", aws.StringValue(input.PublicIpv4Pool))
+						}
+						return &ec2.AllocateAddressOutput{AllocationId: aws.String("eipalloc-1")}, nil
+					})
+				m.AssociateAddressWithContext(context.TODO(), gomock.Eq(&ec2.AssociateAddressInput{
+					InstanceId:   aws.String("id123"),
+					AllocationId: aws.String("eipalloc-1"),
+				})).Return(&ec2.AssociateAddressOutput{}, nil)
+			},
+		},
+		{
+			name: "Should not associate an Elastic IP when one is already associated",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstancesWithContext(context.TODO(), gomock.Any()).Return(foundOutput, nil)
+				m.DescribeAddressesWithContext(context.TODO(), gomock.Eq(describeBastionAddresses)).
+					Return(&ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{
+						AllocationId:  aws.String("eipalloc-1"),
+						AssociationId: aws.String("eipassoc-1"),
+						InstanceId:    aws.String("id123"),
+					}}}, nil)
+			},
+		},
+		{
+			name: "Should fail reconcile if the Elastic IP cannot be associated",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstancesWithContext(context.TODO(), gomock.Any()).Return(foundOutput, nil)
+				m.DescribeAddressesWithContext(context.TODO(), gomock.Eq(describeBastionAddresses)).
+					Return(nil, errors.New("some error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockControl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).To(BeNil())
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpcID",
+							ElasticIPPool: &infrav1.ElasticIPPool{
+								PublicIpv4Pool:              aws.String(poolID),
+								PublicIpv4PoolFallBackOrder: ptr.To(infrav1.PublicIpv4PoolFallbackOrderNone),
+							},
+						},
+						Subnets: infrav1.Subnets{
+							{
+								ID: "subnet-1",
+							},
+							{
+								ID:       "subnet-2",
+								IsPublic: true,
+							},
+						},
+					},
+					Bastion: infrav1.Bastion{Enabled: true},
+				},
+			}
+
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).WithStatusSubresource(awsCluster).Build()
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				AWSCluster: awsCluster,
+				Client:     client,
+			})
+			g.Expect(err).To(BeNil())
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+			s.netService.EC2Client = ec2Mock
+
+			err = s.ReconcileBastion()
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+)
+
+// GetConsoleOutput returns the console output of the instance, as buffered by EC2.
+func (s *Service) GetConsoleOutput(instanceID string) (string, error) {
+	out, err := s.EC2Client.GetConsoleOutputWithContext(context.TODO(), &ec2.GetConsoleOutputInput{
+		InstanceId: aws.String(instanceID),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get console output of instance %q", instanceID)
+	}
+
+	output, err := base64.StdEncoding.DecodeString(aws.StringValue(out.Output))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to decode console output of instance %q", instanceID)
+	}
+	return string(output), nil
+}
+
+// GetConsoleScreenshot returns a JPG screenshot of the console of the instance.
+func (s *Service) GetConsoleScreenshot(instanceID string) ([]byte, error) {
+	out, err := s.EC2Client.GetConsoleScreenshotWithContext(context.TODO(), &ec2.GetConsoleScreenshotInput{
+		InstanceId: aws.String(instanceID),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get console screenshot of instance %q", instanceID)
+	}
+
+	screenshot, err := base64.StdEncoding.DecodeString(aws.StringValue(out.ImageData))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode console screenshot of instance %q", instanceID)
+	}
+	return screenshot, nil
+}
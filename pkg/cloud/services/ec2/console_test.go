@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestGetConsoleOutput(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster:    &clusterv1.Cluster{},
+		AWSCluster: &infrav1.AWSCluster{},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ec2Mock.EXPECT().GetConsoleOutputWithContext(context.TODO(), gomock.Eq(&ec2.GetConsoleOutputInput{
+		InstanceId: aws.String("i-1"),
+	})).Return(&ec2.GetConsoleOutputOutput{
+		Output: aws.String(base64.StdEncoding.EncodeToString([]byte("cloud-init: failed to fetch user data"))),
+	}, nil)
+	ec2Mock.EXPECT().GetConsoleScreenshotWithContext(context.TODO(), gomock.Eq(&ec2.GetConsoleScreenshotInput{
+		InstanceId: aws.String("i-1"),
+	})).Return(&ec2.GetConsoleScreenshotOutput{
+		ImageData: aws.String(base64.StdEncoding.EncodeToString([]byte("jpeg"))),
+	}, nil)
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+
+	output, err := s.GetConsoleOutput("i-1")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(output).To(Equal("cloud-init: failed to fetch user data"))
+
+	screenshot, err := s.GetConsoleScreenshot("i-1")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(screenshot).To(Equal([]byte("jpeg")))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
 
@@ -26,6 +27,26 @@ func (s *Service) ReconcileElasticIPFromPublicPool(pool *infrav1.ElasticIPPool,
 	return nil
 }
 
+// reconcileBastionElasticIP associates an Elastic IP from the cluster-wide Public IPv4 Pool to the
+// bastion host, unless the bastion already has one associated.
+func (s *Service) reconcileBastionElasticIP(pool *infrav1.ElasticIPPool, instance *infrav1.Instance) error {
+	role := getElasticIPRoleName(instance.ID)
+	out, err := s.EC2Client.DescribeAddressesWithContext(context.TODO(), &ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.Cluster(s.scope.Name()),
+			filter.EC2.ProviderRole(role),
+			{Name: aws.String("instance-id"), Values: aws.StringSlice([]string{instance.ID})},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe Elastic IPs of bastion host %q: %w", instance.ID, err)
+	}
+	if len(out.Addresses) > 0 {
+		return nil
+	}
+	return s.ReconcileElasticIPFromPublicPool(pool, instance)
+}
+
 // ReleaseElasticIP releases a specific elastic IP based on the instance role.
 func (s *Service) ReleaseElasticIP(instanceID string) error {
 	return s.netService.ReleaseAddressByRole(getElasticIPRoleName(instanceID))
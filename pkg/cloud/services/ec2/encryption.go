@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
+)
+
+// autoScalingServiceLinkedRole is the path and name of the service-linked role Auto Scaling groups, including the
+// ones of EKS managed node groups, launch instances with.
+const autoScalingServiceLinkedRole = "aws-service-role/autoscaling.amazonaws.com/AWSServiceRoleForAutoScaling"
+
+// ebsKeyGrantOperations are the operations EBS needs on the KMS key to create and attach encrypted volumes.
+var ebsKeyGrantOperations = []string{
+	kms.GrantOperationDecrypt,
+	kms.GrantOperationGenerateDataKeyWithoutPlaintext,
+	kms.GrantOperationReEncryptFrom,
+	kms.GrantOperationReEncryptTo,
+	kms.GrantOperationCreateGrant,
+	kms.GrantOperationDescribeKey,
+}
+
+// checkVolumeEncryption verifies the encryption requested for the volumes is consistent with the EBS
+// encryption settings of the account, which apply to every volume created in the region:
+//   - when encryption by default is enabled, volumes explicitly requested unencrypted would be
+//     encrypted anyway, so they are rejected.
+//   - encrypted volumes without an encryption key use the default KMS key of the account, which may
+//     not be the AWS managed key, so the key used is logged.
+//
+// The account settings are only fetched when a volume could be affected by them.
+func (s *Service) checkVolumeEncryption(volumes ...*infrav1.Volume) error {
+	var unencrypted, defaultKeyed []string
+	for _, v := range volumes {
+		if v == nil || v.EncryptionKey != "" {
+			continue
+		}
+		switch {
+		case v.Encrypted == nil:
+		case *v.Encrypted:
+			defaultKeyed = append(defaultKeyed, v.DeviceName)
+		default:
+			unencrypted = append(unencrypted, v.DeviceName)
+		}
+	}
+	if len(unencrypted) == 0 && len(defaultKeyed) == 0 {
+		return nil
+	}
+
+	if len(unencrypted) > 0 {
+		out, err := s.EC2Client.GetEbsEncryptionByDefaultWithContext(context.TODO(), &ec2.GetEbsEncryptionByDefaultInput{})
+		switch {
+		case awserrors.IsPermissionsError(err):
+			s.scope.Info("Unable to check EBS encryption by default, skipping", "error", err)
+		case err != nil:
+			return errors.Wrap(err, "failed to get EBS encryption by default")
+		case aws.BoolValue(out.EbsEncryptionByDefault):
+			return errors.Errorf("volumes %v are requested unencrypted, but EBS encryption by default is enabled for the account in region %q: "+
+				"set encrypted to true or disable encryption by default", unencrypted, s.scope.Region())
+		}
+	}
+
+	if len(defaultKeyed) > 0 {
+		out, err := s.EC2Client.GetEbsDefaultKmsKeyIdWithContext(context.TODO(), &ec2.GetEbsDefaultKmsKeyIdInput{})
+		switch {
+		case awserrors.IsPermissionsError(err):
+			s.scope.Info("Unable to get the EBS default KMS key, skipping", "error", err)
+		case err != nil:
+			return errors.Wrap(err, "failed to get EBS default KMS key")
+		default:
+			s.scope.Info("Volumes will be encrypted with the EBS default KMS key of the account", "volumes", defaultKeyed, "kms-key-id", aws.StringValue(out.KmsKeyId))
+		}
+	}
+
+	return nil
+}
+
+// checkEncryptionKeys verifies the customer managed KMS keys of the volumes exist, are enabled and can encrypt
+// EBS volumes, so a wrong key is reported before the instances fail to launch. It returns the ARNs of the keys.
+// The check is skipped when the controller is not allowed to describe the keys.
+func (s *Service) checkEncryptionKeys(volumes ...*infrav1.Volume) ([]string, error) {
+	checked := map[string]struct{}{}
+	keyARNs := []string{}
+	for _, v := range volumes {
+		if v == nil || v.EncryptionKey == "" {
+			continue
+		}
+		if _, ok := checked[v.EncryptionKey]; ok {
+			continue
+		}
+		checked[v.EncryptionKey] = struct{}{}
+
+		out, err := s.KMSClient.DescribeKeyWithContext(context.TODO(), &kms.DescribeKeyInput{KeyId: aws.String(v.EncryptionKey)})
+		if err != nil {
+			switch code, _ := awserrors.Code(errors.Cause(err)); code {
+			case awserrors.AccessDeniedException:
+				s.scope.Info("Unable to describe the KMS key of the volume, skipping", "volume", v.DeviceName, "kms-key-id", v.EncryptionKey, "error", err)
+				continue
+			case kms.ErrCodeNotFoundException:
+				return nil, errors.Errorf("KMS key %q of volume %q does not exist in region %q", v.EncryptionKey, v.DeviceName, s.scope.Region())
+			}
+			return nil, errors.Wrapf(err, "failed to describe KMS key %q of volume %q", v.EncryptionKey, v.DeviceName)
+		}
+
+		key := out.KeyMetadata
+		switch {
+		case aws.StringValue(key.KeyState) != kms.KeyStateEnabled:
+			return nil, errors.Errorf("KMS key %q of volume %q is %s, it must be enabled", v.EncryptionKey, v.DeviceName, aws.StringValue(key.KeyState))
+		case aws.StringValue(key.KeyUsage) != kms.KeyUsageTypeEncryptDecrypt || aws.StringValue(key.KeySpec) != kms.KeySpecSymmetricDefault:
+			return nil, errors.Errorf("KMS key %q of volume %q cannot encrypt volumes, EBS only supports symmetric encryption keys", v.EncryptionKey, v.DeviceName)
+		}
+		keyARNs = append(keyARNs, aws.StringValue(key.Arn))
+	}
+	return keyARNs, nil
+}
+
+// checkAutoScalingGrants verifies grants can be created on the KMS keys for the Auto Scaling service-linked role,
+// which needs them to launch instances with volumes encrypted by the keys. A missing grant permission is otherwise
+// only reported as instances of the group failing to launch. The grants are not created, the requests are dry runs.
+func (s *Service) checkAutoScalingGrants(keyARNs []string) error {
+	if len(keyARNs) == 0 {
+		return nil
+	}
+
+	identity, err := s.STSClient.GetCallerIdentityWithContext(context.TODO(), &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get account ID")
+	}
+	roleARN := fmt.Sprintf("arn:%s:iam::%s:role/%s", system.GetPartitionFromRegion(s.scope.Region()), aws.StringValue(identity.Account), autoScalingServiceLinkedRole)
+
+	for _, keyARN := range keyARNs {
+		_, err := s.KMSClient.CreateGrantWithContext(context.TODO(), &kms.CreateGrantInput{
+			DryRun:           aws.Bool(true),
+			KeyId:            aws.String(keyARN),
+			GranteePrincipal: aws.String(roleARN),
+			Operations:       aws.StringSlice(ebsKeyGrantOperations),
+		})
+		switch code, _ := awserrors.Code(errors.Cause(err)); code {
+		case kms.ErrCodeDryRunOperationException:
+		case awserrors.AccessDeniedException:
+			return errors.Errorf("grants cannot be created on KMS key %q for the Auto Scaling service-linked role %q: "+
+				"the key policy must allow the controller to create grants for the role", keyARN, roleARN)
+		default:
+			return errors.Wrapf(err, "failed to check grants can be created on KMS key %q", keyARN)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/kms/mock_kmsiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/sts/mock_stsiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestCheckVolumeEncryption(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	encryptionByDefault := func(m *mocks.MockEC2APIMockRecorder, enabled bool) {
+		m.GetEbsEncryptionByDefaultWithContext(context.TODO(), gomock.Any()).
+			Return(&ec2.GetEbsEncryptionByDefaultOutput{EbsEncryptionByDefault: aws.Bool(enabled)}, nil)
+	}
+
+	testCases := []struct {
+		name        string
+		volumes     []*infrav1.Volume
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "does not check the account settings when no volume sets the encryption",
+			volumes: []*infrav1.Volume{
+				{DeviceName: "/dev/sda1"},
+				{DeviceName: "/dev/sdb", Encrypted: aws.Bool(true), EncryptionKey: "arn:aws:kms:us-east-1:123456789012:key/mine"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:    "allows unencrypted volumes when encryption by default is disabled",
+			volumes: []*infrav1.Volume{{DeviceName: "/dev/sda1", Encrypted: aws.Bool(false)}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				encryptionByDefault(m, false)
+			},
+		},
+		{
+			name:    "rejects unencrypted volumes when encryption by default is enabled",
+			volumes: []*infrav1.Volume{{DeviceName: "/dev/sda1", Encrypted: aws.Bool(false)}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				encryptionByDefault(m, true)
+			},
+			expectError: true,
+		},
+		{
+			name:    "skips the check when the settings cannot be read",
+			volumes: []*infrav1.Volume{{DeviceName: "/dev/sda1", Encrypted: aws.Bool(false)}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.GetEbsEncryptionByDefaultWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(awserrors.UnauthorizedOperation, "not authorized", nil))
+			},
+		},
+		{
+			name:    "gets the default KMS key of encrypted volumes without encryption key",
+			volumes: []*infrav1.Volume{{DeviceName: "/dev/sda1", Encrypted: aws.Bool(true)}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.GetEbsDefaultKmsKeyIdWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.GetEbsDefaultKmsKeyIdOutput{KmsKeyId: aws.String("alias/aws/ebs")}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.checkVolumeEncryption(tc.volumes...)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestCheckEncryptionKeys(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	const keyARN = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+	describeKey := func(m *mock_kmsiface.MockKMSAPIMockRecorder, keyID string, metadata *kms.KeyMetadata) {
+		m.DescribeKeyWithContext(context.TODO(), &kms.DescribeKeyInput{KeyId: aws.String(keyID)}).
+			Return(&kms.DescribeKeyOutput{KeyMetadata: metadata}, nil)
+	}
+	enabledKey := &kms.KeyMetadata{
+		Arn:      aws.String(keyARN),
+		KeyState: aws.String(kms.KeyStateEnabled),
+		KeyUsage: aws.String(kms.KeyUsageTypeEncryptDecrypt),
+		KeySpec:  aws.String(kms.KeySpecSymmetricDefault),
+	}
+
+	testCases := []struct {
+		name        string
+		volumes     []*infrav1.Volume
+		expect      func(m *mock_kmsiface.MockKMSAPIMockRecorder)
+		wantKeyARNs []string
+		expectError bool
+	}{
+		{
+			name: "does not describe keys when no volume sets an encryption key",
+			volumes: []*infrav1.Volume{
+				{DeviceName: "/dev/sda1"},
+				{DeviceName: "/dev/sdb", Encrypted: aws.Bool(true)},
+			},
+			expect:      func(m *mock_kmsiface.MockKMSAPIMockRecorder) {},
+			wantKeyARNs: []string{},
+		},
+		{
+			name: "describes each key once and returns their ARNs",
+			volumes: []*infrav1.Volume{
+				{DeviceName: "/dev/sda1", EncryptionKey: "alias/my-key"},
+				{DeviceName: "/dev/sdb", EncryptionKey: "alias/my-key"},
+			},
+			expect: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				describeKey(m, "alias/my-key", enabledKey)
+			},
+			wantKeyARNs: []string{keyARN},
+		},
+		{
+			name:    "rejects keys which do not exist",
+			volumes: []*infrav1.Volume{{DeviceName: "/dev/sda1", EncryptionKey: "alias/missing"}},
+			expect: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				m.DescribeKeyWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(kms.ErrCodeNotFoundException, "not found", nil))
+			},
+			expectError: true,
+		},
+		{
+			name:    "rejects disabled keys",
+			volumes: []*infrav1.Volume{{DeviceName: "/dev/sda1", EncryptionKey: keyARN}},
+			expect: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				describeKey(m, keyARN, &kms.KeyMetadata{
+					Arn:      aws.String(keyARN),
+					KeyState: aws.String(kms.KeyStatePendingDeletion),
+					KeyUsage: aws.String(kms.KeyUsageTypeEncryptDecrypt),
+					KeySpec:  aws.String(kms.KeySpecSymmetricDefault),
+				})
+			},
+			expectError: true,
+		},
+		{
+			name:    "rejects asymmetric keys",
+			volumes: []*infrav1.Volume{{DeviceName: "/dev/sda1", EncryptionKey: keyARN}},
+			expect: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				describeKey(m, keyARN, &kms.KeyMetadata{
+					Arn:      aws.String(keyARN),
+					KeyState: aws.String(kms.KeyStateEnabled),
+					KeyUsage: aws.String(kms.KeyUsageTypeEncryptDecrypt),
+					KeySpec:  aws.String(kms.KeySpecRsa2048),
+				})
+			},
+			expectError: true,
+		},
+		{
+			name:    "skips the check when the key cannot be described",
+			volumes: []*infrav1.Volume{{DeviceName: "/dev/sda1", EncryptionKey: keyARN}},
+			expect: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				m.DescribeKeyWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(awserrors.AccessDeniedException, "not authorized", nil))
+			},
+			wantKeyARNs: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			kmsMock := mock_kmsiface.NewMockKMSAPI(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(kmsMock.EXPECT())
+
+			s := NewService(scope)
+			s.KMSClient = kmsMock
+
+			keyARNs, err := s.checkEncryptionKeys(tc.volumes...)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(keyARNs).To(Equal(tc.wantKeyARNs))
+		})
+	}
+}
+
+func TestCheckAutoScalingGrants(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	const keyARN = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+	testCases := []struct {
+		name        string
+		keyARNs     []string
+		expect      func(s *mock_stsiface.MockSTSAPIMockRecorder, m *mock_kmsiface.MockKMSAPIMockRecorder)
+		expectError bool
+	}{
+		{
+			name:    "does nothing without keys",
+			keyARNs: []string{},
+			expect:  func(_ *mock_stsiface.MockSTSAPIMockRecorder, _ *mock_kmsiface.MockKMSAPIMockRecorder) {},
+		},
+		{
+			name:    "allows keys the grants can be created on for the service-linked role",
+			keyARNs: []string{keyARN},
+			expect: func(s *mock_stsiface.MockSTSAPIMockRecorder, m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				s.GetCallerIdentityWithContext(context.TODO(), gomock.Any()).
+					Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+				m.CreateGrantWithContext(context.TODO(), &kms.CreateGrantInput{
+					DryRun:           aws.Bool(true),
+					KeyId:            aws.String(keyARN),
+					GranteePrincipal: aws.String("arn:aws:iam::123456789012:role/aws-service-role/autoscaling.amazonaws.com/AWSServiceRoleForAutoScaling"),
+					Operations:       aws.StringSlice(ebsKeyGrantOperations),
+				}).Return(nil, awserr.New(kms.ErrCodeDryRunOperationException, "dry run", nil))
+			},
+		},
+		{
+			name:    "rejects keys the grants cannot be created on",
+			keyARNs: []string{keyARN},
+			expect: func(s *mock_stsiface.MockSTSAPIMockRecorder, m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				s.GetCallerIdentityWithContext(context.TODO(), gomock.Any()).
+					Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+				m.CreateGrantWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(awserrors.AccessDeniedException, "not authorized", nil))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			kmsMock := mock_kmsiface.NewMockKMSAPI(mockCtrl)
+			stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:  client,
+				Cluster: &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{Region: "us-east-1"},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(stsMock.EXPECT(), kmsMock.EXPECT())
+
+			s := NewService(scope)
+			s.KMSClient = kmsMock
+			s.STSClient = stsMock
+
+			err = s.checkAutoScalingGrants(tc.keyARNs)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// GetFleet returns the EC2 Fleet with the given ID, or nil if it doesn't exist or is deleted.
+func (s *Service) GetFleet(id string) (*expinfrav1.Fleet, error) {
+	out, err := s.EC2Client.DescribeFleetsWithContext(context.TODO(), &ec2.DescribeFleetsInput{
+		FleetIds: aws.StringSlice([]string{id}),
+	})
+	switch {
+	case awserrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to describe EC2 Fleet %q", id)
+	}
+
+	for _, fleet := range out.Fleets {
+		if aws.StringValue(fleet.FleetId) != id || strings.HasPrefix(aws.StringValue(fleet.FleetState), ec2.FleetStateCodeDeleted) {
+			continue
+		}
+
+		f := &expinfrav1.Fleet{
+			ID:    id,
+			Type:  expinfrav1.EC2FleetType(aws.StringValue(fleet.Type)),
+			State: aws.StringValue(fleet.FleetState),
+		}
+		if spec := fleet.TargetCapacitySpecification; spec != nil {
+			f.TotalTargetCapacity = aws.Int64Value(spec.TotalTargetCapacity)
+			f.OnDemandTargetCapacity = aws.Int64Value(spec.OnDemandTargetCapacity)
+		}
+		return f, nil
+	}
+
+	return nil, nil
+}
+
+// CreateFleet creates an EC2 Fleet launching the instances of the machine pool from its launch template in
+// the given subnets, and returns the ID of the fleet. Instant fleets launch the instances synchronously, their
+// IDs are returned as well, and an error is returned if none could be launched.
+func (s *Service) CreateFleet(scope *scope.MachinePoolScope, subnetIDs []string, totalCapacity, onDemandCapacity int64) (string, []string, error) {
+	fleet := scope.AWSMachinePool.Spec.EC2Fleet
+	launchTemplateID := scope.GetLaunchTemplateIDStatus()
+
+	input := &ec2.CreateFleetInput{
+		Type: aws.String(string(fleet.GetType())),
+		LaunchTemplateConfigs: []*ec2.FleetLaunchTemplateConfigRequest{
+			{
+				LaunchTemplateSpecification: &ec2.FleetLaunchTemplateSpecificationRequest{
+					LaunchTemplateId: aws.String(launchTemplateID),
+					Version:          aws.String("$Latest"),
+				},
+				Overrides: getFleetOverridesRequest(fleet, subnetIDs),
+			},
+		},
+		TargetCapacitySpecification: &ec2.TargetCapacitySpecificationRequest{
+			TotalTargetCapacity:       aws.Int64(totalCapacity),
+			OnDemandTargetCapacity:    aws.Int64(onDemandCapacity),
+			DefaultTargetCapacityType: aws.String(fleet.DefaultTargetCapacityType),
+		},
+		OnDemandOptions: &ec2.OnDemandOptionsRequest{
+			AllocationStrategy: aws.String(string(fleet.OnDemandAllocationStrategy)),
+		},
+		SpotOptions: &ec2.SpotOptionsRequest{
+			AllocationStrategy: aws.String(string(fleet.SpotAllocationStrategy)),
+		},
+		TagSpecifications: []*ec2.TagSpecification{
+			fleetTagSpecification(s.launchTemplateTags(scope)),
+		},
+	}
+
+	switch fleet.GetType() {
+	case expinfrav1.EC2FleetTypeMaintain:
+		input.ExcessCapacityTerminationPolicy = aws.String(ec2.FleetExcessCapacityTerminationPolicyTermination)
+	case expinfrav1.EC2FleetTypeInstant:
+		input.OnDemandOptions.SingleInstanceType = aws.Bool(fleet.SingleInstanceType)
+		input.OnDemandOptions.SingleAvailabilityZone = aws.Bool(fleet.SingleAvailabilityZone)
+		input.SpotOptions.SingleInstanceType = aws.Bool(fleet.SingleInstanceType)
+		input.SpotOptions.SingleAvailabilityZone = aws.Bool(fleet.SingleAvailabilityZone)
+		if fleet.MinTargetCapacity != nil {
+			minTargetCapacity := min(*fleet.MinTargetCapacity, totalCapacity)
+			input.OnDemandOptions.MinTargetCapacity = aws.Int64(minTargetCapacity)
+			input.SpotOptions.MinTargetCapacity = aws.Int64(minTargetCapacity)
+		}
+	}
+
+	out, err := s.EC2Client.CreateFleetWithContext(context.TODO(), input)
+	if err != nil {
+		record.Warnf(scope.AWSMachinePool, "FailedCreateEC2Fleet", "Failed to create EC2 Fleet: %v", err)
+		return "", nil, errors.Wrap(err, "failed to create EC2 Fleet")
+	}
+
+	fleetID := aws.StringValue(out.FleetId)
+	var instanceIDs []string
+	if fleet.GetType() == expinfrav1.EC2FleetTypeInstant {
+		for _, instances := range out.Instances {
+			instanceIDs = append(instanceIDs, aws.StringValueSlice(instances.InstanceIds)...)
+		}
+		launched := len(instanceIDs)
+
+		if launched == 0 && len(out.Errors) > 0 {
+			errs := make([]error, 0, len(out.Errors))
+			for _, e := range out.Errors {
+				errs = append(errs, errors.Errorf("%s: %s", aws.StringValue(e.ErrorCode), aws.StringValue(e.ErrorMessage)))
+			}
+			record.Warnf(scope.AWSMachinePool, "FailedCreateEC2Fleet", "EC2 Fleet %q failed to launch instances: %v", fleetID, kerrors.NewAggregate(errs))
+			return "", nil, errors.Wrapf(kerrors.NewAggregate(errs), "EC2 Fleet %q failed to launch instances", fleetID)
+		}
+
+		s.scope.Info("Launched instances with EC2 Fleet", "fleet-id", fleetID, "launched", launched, "requested", totalCapacity)
+	}
+
+	record.Eventf(scope.AWSMachinePool, "SuccessfulCreateEC2Fleet", "Created new EC2 Fleet %q", fleetID)
+
+	return fleetID, instanceIDs, nil
+}
+
+// ModifyFleetTargetCapacity updates the target capacity of the EC2 Fleet of type maintain. The excess
+// instances are terminated when the capacity is decreased.
+func (s *Service) ModifyFleetTargetCapacity(id string, totalCapacity, onDemandCapacity int64) error {
+	input := &ec2.ModifyFleetInput{
+		FleetId:                         aws.String(id),
+		ExcessCapacityTerminationPolicy: aws.String(ec2.FleetExcessCapacityTerminationPolicyTermination),
+		TargetCapacitySpecification: &ec2.TargetCapacitySpecificationRequest{
+			TotalTargetCapacity:    aws.Int64(totalCapacity),
+			OnDemandTargetCapacity: aws.Int64(onDemandCapacity),
+		},
+	}
+
+	if _, err := s.EC2Client.ModifyFleetWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to modify target capacity of EC2 Fleet %q", id)
+	}
+
+	s.scope.Debug("Modified EC2 Fleet target capacity", "fleet-id", id, "total-capacity", totalCapacity, "on-demand-capacity", onDemandCapacity)
+
+	return nil
+}
+
+// DeleteFleet deletes the EC2 Fleet and terminates its instances.
+func (s *Service) DeleteFleet(id string) error {
+	out, err := s.EC2Client.DeleteFleetsWithContext(context.TODO(), &ec2.DeleteFleetsInput{
+		FleetIds:           aws.StringSlice([]string{id}),
+		TerminateInstances: aws.Bool(true),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete EC2 Fleet %q", id)
+	}
+
+	for _, e := range out.UnsuccessfulFleetDeletions {
+		if e.Error == nil || aws.StringValue(e.Error.Code) == ec2.DeleteFleetErrorCodeFleetIdDoesNotExist {
+			continue
+		}
+		return errors.Errorf("failed to delete EC2 Fleet %q: %s: %s", id, aws.StringValue(e.Error.Code), aws.StringValue(e.Error.Message))
+	}
+
+	s.scope.Debug("Deleted EC2 Fleet", "fleet-id", id)
+
+	return nil
+}
+
+// GetFleetInstances returns the pending and running instances launched from the launch template.
+func (s *Service) GetFleetInstances(launchTemplateID string) ([]infrav1.Instance, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.LaunchTemplate(launchTemplateID),
+			filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning),
+		},
+	}
+
+	var instances []infrav1.Instance
+	if err := s.EC2Client.DescribeInstancesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				instances = append(instances, sdkToFleetInstance(instance))
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instances of launch template %q", launchTemplateID)
+	}
+
+	return instances, nil
+}
+
+// getFleetOverridesRequest returns an override per subnet and instance type of the fleet.
+func getFleetOverridesRequest(fleet *expinfrav1.EC2Fleet, subnetIDs []string) []*ec2.FleetLaunchTemplateOverridesRequest {
+	overrides := make([]*ec2.FleetLaunchTemplateOverridesRequest, 0, len(subnetIDs)*max(len(fleet.Overrides), 1))
+	for _, subnetID := range subnetIDs {
+		if len(fleet.Overrides) == 0 {
+			overrides = append(overrides, &ec2.FleetLaunchTemplateOverridesRequest{
+				SubnetId: aws.String(subnetID),
+			})
+			continue
+		}
+
+		for i, override := range fleet.Overrides {
+			overrides = append(overrides, &ec2.FleetLaunchTemplateOverridesRequest{
+				SubnetId:     aws.String(subnetID),
+				InstanceType: aws.String(override.InstanceType),
+				Priority:     aws.Float64(float64(i)),
+			})
+		}
+	}
+	return overrides
+}
+
+// fleetTagSpecification returns the tag specification of the fleet, sorting the tags for the tests to work.
+func fleetTagSpecification(tags infrav1.Tags) *ec2.TagSpecification {
+	spec := &ec2.TagSpecification{ResourceType: aws.String(ec2.ResourceTypeFleet)}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		spec.Tags = append(spec.Tags, &ec2.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(tags[key]),
+		})
+	}
+	return spec
+}
+
+// sdkToFleetInstance converts an instance launched by a fleet. Unlike SDKToInstance, it doesn't look up
+// the network and storage details of the instance, which aren't needed to track the instances of the pool.
+func sdkToFleetInstance(v *ec2.Instance) infrav1.Instance {
+	i := infrav1.Instance{
+		ID:                aws.StringValue(v.InstanceId),
+		Type:              aws.StringValue(v.InstanceType),
+		ImageID:           aws.StringValue(v.ImageId),
+		PrivateIP:         v.PrivateIpAddress,
+		Tags:              converters.TagsToMap(v.Tags),
+		InstanceLifecycle: infrav1.InstanceLifecycleOnDemand,
+	}
+	if v.State != nil {
+		i.State = infrav1.InstanceState(aws.StringValue(v.State.Name))
+	}
+	if v.Placement != nil {
+		i.AvailabilityZone = aws.StringValue(v.Placement.AvailabilityZone)
+	}
+	if lifecycle := aws.StringValue(v.InstanceLifecycle); lifecycle != "" {
+		i.InstanceLifecycle = infrav1.InstanceLifecycle(lifecycle)
+	}
+	return i
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestCreateFleet(t *testing.T) {
+	tests := []struct {
+		name            string
+		fleet           *expinfrav1.EC2Fleet
+		expect          func(m *mocks.MockEC2APIMockRecorder)
+		wantInstanceIDs []string
+		wantErr         bool
+	}{
+		{
+			name: "maintain fleet launches every instance type in every subnet",
+			fleet: &expinfrav1.EC2Fleet{
+				Type:                      expinfrav1.EC2FleetTypeMaintain,
+				DefaultTargetCapacityType: expinfrav1.EC2FleetCapacityTypeSpot,
+				SpotAllocationStrategy:    expinfrav1.SpotAllocationStrategyPriceCapacityOptimized,
+				Overrides:                 []expinfrav1.Overrides{{InstanceType: "m5.large"}, {InstanceType: "m6i.large"}},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.CreateFleetWithContext(context.TODO(), gomock.Any()).DoAndReturn(func(_ context.Context, input *ec2.CreateFleetInput, _ ...request.Option) (*ec2.CreateFleetOutput, error) {
+					g := NewWithT(t)
+					g.Expect(aws.StringValue(input.Type)).To(Equal("maintain"))
+					g.Expect(aws.StringValue(input.ExcessCapacityTerminationPolicy)).To(Equal(ec2.FleetExcessCapacityTerminationPolicyTermination))
+					g.Expect(aws.StringValue(input.LaunchTemplateConfigs[0].LaunchTemplateSpecification.LaunchTemplateId)).To(Equal("lt-1"))
+					g.Expect(input.LaunchTemplateConfigs[0].Overrides).To(HaveLen(4))
+					g.Expect(aws.StringValue(input.LaunchTemplateConfigs[0].Overrides[1].SubnetId)).To(Equal("subnet-1"))
+					g.Expect(aws.StringValue(input.LaunchTemplateConfigs[0].Overrides[1].InstanceType)).To(Equal("m6i.large"))
+					g.Expect(aws.Int64Value(input.TargetCapacitySpecification.TotalTargetCapacity)).To(Equal(int64(3)))
+					g.Expect(aws.StringValue(input.TargetCapacitySpecification.DefaultTargetCapacityType)).To(Equal("spot"))
+					g.Expect(input.SpotOptions.MinTargetCapacity).To(BeNil())
+					g.Expect(aws.StringValue(input.TagSpecifications[0].ResourceType)).To(Equal(ec2.ResourceTypeFleet))
+					return &ec2.CreateFleetOutput{FleetId: aws.String("fleet-1")}, nil
+				})
+			},
+		},
+		{
+			name: "instant fleet caps the minimum target capacity to the instances to launch",
+			fleet: &expinfrav1.EC2Fleet{
+				Type:                   expinfrav1.EC2FleetTypeInstant,
+				SingleAvailabilityZone: true,
+				MinTargetCapacity:      aws.Int64(5),
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.CreateFleetWithContext(context.TODO(), gomock.Any()).DoAndReturn(func(_ context.Context, input *ec2.CreateFleetInput, _ ...request.Option) (*ec2.CreateFleetOutput, error) {
+					g := NewWithT(t)
+					g.Expect(aws.StringValue(input.Type)).To(Equal("instant"))
+					g.Expect(input.ExcessCapacityTerminationPolicy).To(BeNil())
+					g.Expect(input.LaunchTemplateConfigs[0].Overrides).To(HaveLen(2))
+					g.Expect(aws.BoolValue(input.SpotOptions.SingleAvailabilityZone)).To(BeTrue())
+					g.Expect(aws.Int64Value(input.SpotOptions.MinTargetCapacity)).To(Equal(int64(3)))
+					g.Expect(aws.Int64Value(input.OnDemandOptions.MinTargetCapacity)).To(Equal(int64(3)))
+					return &ec2.CreateFleetOutput{
+						FleetId:   aws.String("fleet-1"),
+						Instances: []*ec2.CreateFleetInstance{{InstanceIds: aws.StringSlice([]string{"i-1", "i-2", "i-3"})}},
+					}, nil
+				})
+			},
+			wantInstanceIDs: []string{"i-1", "i-2", "i-3"},
+		},
+		{
+			name: "instant fleet fails if no instance is launched",
+			fleet: &expinfrav1.EC2Fleet{
+				Type: expinfrav1.EC2FleetTypeInstant,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.CreateFleetWithContext(context.TODO(), gomock.Any()).Return(&ec2.CreateFleetOutput{
+					FleetId: aws.String("fleet-1"),
+					Errors: []*ec2.CreateFleetError{{
+						ErrorCode:    aws.String("InsufficientInstanceCapacity"),
+						ErrorMessage: aws.String("no capacity"),
+					}},
+				}, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+			ms.AWSMachinePool.Spec.EC2Fleet = tt.fleet
+			ms.AWSMachinePool.Status.LaunchTemplateID = "lt-1"
+
+			tt.expect(ec2Mock.EXPECT())
+
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+
+			fleetID, instanceIDs, err := s.CreateFleet(ms, []string{"subnet-1", "subnet-2"}, 3, 0)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(fleetID).To(Equal("fleet-1"))
+			g.Expect(instanceIDs).To(Equal(tt.wantInstanceIDs))
+		})
+	}
+}
+
+func TestGetFleet(t *testing.T) {
+	tests := []struct {
+		name      string
+		fleets    []*ec2.FleetData
+		wantFleet *expinfrav1.Fleet
+	}{
+		{
+			name: "returns the target capacity of the active fleet",
+			fleets: []*ec2.FleetData{{
+				FleetId:    aws.String("fleet-1"),
+				FleetState: aws.String(ec2.FleetStateCodeActive),
+				Type:       aws.String("maintain"),
+				TargetCapacitySpecification: &ec2.TargetCapacitySpecification{
+					TotalTargetCapacity:    aws.Int64(3),
+					OnDemandTargetCapacity: aws.Int64(1),
+				},
+			}},
+			wantFleet: &expinfrav1.Fleet{
+				ID:                     "fleet-1",
+				Type:                   expinfrav1.EC2FleetTypeMaintain,
+				State:                  ec2.FleetStateCodeActive,
+				TotalTargetCapacity:    3,
+				OnDemandTargetCapacity: 1,
+			},
+		},
+		{
+			name: "ignores the deleted fleet",
+			fleets: []*ec2.FleetData{{
+				FleetId:    aws.String("fleet-1"),
+				FleetState: aws.String(ec2.FleetStateCodeDeletedTerminating),
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			cs, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock.EXPECT().DescribeFleetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeFleetsInput{
+				FleetIds: aws.StringSlice([]string{"fleet-1"}),
+			})).Return(&ec2.DescribeFleetsOutput{Fleets: tt.fleets}, nil)
+
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+
+			fleet, err := s.GetFleet("fleet-1")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(fleet).To(Equal(tt.wantFleet))
+		})
+	}
+}
+
+func TestGetFleetInstances(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	cs, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ec2Mock.EXPECT().DescribeInstancesPagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag:aws:ec2launchtemplate:id"),
+				Values: aws.StringSlice([]string{"lt-1"}),
+			},
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: aws.StringSlice([]string{ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning}),
+			},
+		},
+	}), gomock.Any()).DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+		fn(&ec2.DescribeInstancesOutput{
+			Reservations: []*ec2.Reservation{{
+				Instances: []*ec2.Instance{{
+					InstanceId:        aws.String("i-1"),
+					InstanceType:      aws.String("m5.large"),
+					InstanceLifecycle: aws.String(ec2.InstanceLifecycleTypeSpot),
+					State:             &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+					Placement:         &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")},
+					Tags:              []*ec2.Tag{{Key: aws.String("aws:ec2launchtemplate:version"), Value: aws.String("2")}},
+				}},
+			}},
+		}, true)
+		return nil
+	})
+
+	s := NewService(cs)
+	s.EC2Client = ec2Mock
+
+	instances, err := s.GetFleetInstances("lt-1")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instances).To(Equal([]infrav1.Instance{{
+		ID:                "i-1",
+		Type:              "m5.large",
+		State:             infrav1.InstanceStateRunning,
+		AvailabilityZone:  "us-east-1a",
+		InstanceLifecycle: infrav1.InstanceLifecycleSpot,
+		Tags:              map[string]string{"aws:ec2launchtemplate:version": "2"},
+	}}))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -40,6 +41,10 @@ import (
 	capierrors "sigs.k8s.io/cluster-api/errors"
 )
 
+// bottlerocketDataVolumeDeviceName is the device name of the volume Bottlerocket stores
+// container images and pod data on.
+const bottlerocketDataVolumeDeviceName = "/dev/xvdb"
+
 // GetRunningInstanceByTags returns the existing instance or nothing if it doesn't exist.
 func (s *Service) GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.Instance, error) {
 	s.scope.Debug("Looking for existing machine instance by tags")
@@ -132,14 +137,32 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 
 	var err error
 
-	imageArchitecture, err := s.pickArchitectureForInstanceType(input.Type)
-	if err != nil {
-		return nil, err
+	imageArchitecture := scope.AWSMachine.Spec.ImageLookupArchitecture
+	if imageArchitecture == "" {
+		imageArchitecture, err = s.pickArchitectureForInstanceType(input.Type)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Pick image from the machine configuration, or use a default one.
 	if scope.AWSMachine.Spec.AMI.ID != nil { //nolint:nestif
 		input.ImageID = *scope.AWSMachine.Spec.AMI.ID
+	} else if len(scope.AWSMachine.Spec.AMI.Filters) > 0 {
+		input.ImageID, err = s.filteredAMIIDLookup(scope.AWSMachine.Spec.AMI.Filters, imageArchitecture)
+		if err != nil {
+			return nil, err
+		}
+	} else if code := scope.AWSMachine.Spec.AMI.MarketplaceProductCode; code != nil {
+		input.ImageID, err = s.filteredAMIIDLookup(marketplaceAMIFilters(*code), imageArchitecture)
+		if err != nil {
+			return nil, err
+		}
+	} else if scope.AWSMachine.Spec.AMI.SSMParameter != nil {
+		input.ImageID, err = s.ssmParameterAMIIDLookup(*scope.AWSMachine.Spec.AMI.SSMParameter)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		if scope.Machine.Spec.Version == nil {
 			err := errors.New("Either AWSMachine's spec.ami.id or Machine's spec.version must be defined")
@@ -169,7 +192,7 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 				return nil, err
 			}
 		} else {
-			input.ImageID, err = s.defaultAMIIDLookup(imageLookupFormat, imageLookupOrg, imageLookupBaseOS, imageArchitecture, *scope.Machine.Spec.Version)
+			input.ImageID, err = s.defaultAMIIDLookup(imageLookupFormat, imageLookupOrg, imageLookupBaseOS, imageArchitecture, *scope.Machine.Spec.Version, scope.AWSMachine.Spec.ImageLookupRootDeviceType)
 			if err != nil {
 				return nil, err
 			}
@@ -206,6 +229,15 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 
 	input.UserData = ptr.To[string](base64.StdEncoding.EncodeToString(userData))
 
+	// Bottlerocket boots from a small OS volume of a fixed size and keeps its workloads on a separate
+	// data volume, so the requested root volume is applied to the data volume unless it is set explicitly.
+	if scope.UseBottlerocket(userDataFormat) && input.RootVolume != nil && !hasVolumeWithDeviceName(input.NonRootVolumes, bottlerocketDataVolumeDeviceName) {
+		dataVolume := *input.RootVolume
+		dataVolume.DeviceName = bottlerocketDataVolumeDeviceName
+		input.NonRootVolumes = append(append([]infrav1.Volume{}, input.NonRootVolumes...), dataVolume)
+		input.RootVolume = nil
+	}
+
 	// Set security groups.
 	ids, err := s.GetCoreSecurityGroups(scope)
 	if err != nil {
@@ -213,6 +245,16 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	}
 	input.SecurityGroupIDs = append(input.SecurityGroupIDs, ids...)
 
+	// Security groups selected by tags are resolved before the launch, so that the instance
+	// doesn't run without them until the security groups are reconciled.
+	if selectors := scope.AWSMachine.Spec.AdditionalSecurityGroupSelectors; len(selectors) > 0 {
+		selectedIDs, err := s.GetSecurityGroupSelectorsIDs(selectors)
+		if err != nil {
+			return nil, err
+		}
+		input.SecurityGroupIDs = append(input.SecurityGroupIDs, selectedIDs...)
+	}
+
 	// If SSHKeyName WAS NOT provided in the AWSMachine Spec, fallback to the value provided in the AWSCluster Spec.
 	// If a value was not provided in the AWSCluster Spec, then use the defaultSSHKeyName
 	// Note that:
@@ -241,14 +283,36 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 
 	input.SpotMarketOptions = scope.AWSMachine.Spec.SpotMarketOptions
 
+	input.CapacityReservationTarget = scope.AWSMachine.Spec.CapacityReservationTarget
+
 	input.InstanceMetadataOptions = scope.AWSMachine.Spec.InstanceMetadataOptions
 
 	input.Tenancy = scope.AWSMachine.Spec.Tenancy
 
+	input.HostID = scope.AWSMachine.Spec.HostID
+
+	input.HostResourceGroupARN = scope.AWSMachine.Spec.HostResourceGroupARN
+
+	input.EnclaveOptions = scope.AWSMachine.Spec.EnclaveOptions
+
+	input.HibernationOptions = scope.AWSMachine.Spec.HibernationOptions
+
+	if scope.TerminationProtection() {
+		input.TerminationProtection = aws.Bool(true)
+	}
+
 	input.PlacementGroupName = scope.AWSMachine.Spec.PlacementGroupName
 
 	input.PlacementGroupPartition = scope.AWSMachine.Spec.PlacementGroupPartition
 
+	if placementGroup := scope.AWSMachine.Spec.PlacementGroup; placementGroup != nil {
+		if err := s.reconcilePlacementGroup(placementGroup); err != nil {
+			return nil, err
+		}
+		input.PlacementGroupName = placementGroup.Name
+		input.PlacementGroupPartition = placementGroup.Partition
+	}
+
 	input.PrivateDNSName = scope.AWSMachine.Spec.PrivateDNSName
 
 	s.scope.Debug("Running instance", "machine-role", scope.Role())
@@ -309,6 +373,10 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 	// Check Machine.Spec.FailureDomain first as it's used by KubeadmControlPlane to spread machines across failure domains.
 	failureDomain := scope.Machine.Spec.FailureDomain
+	var zoneTypes []infrav1.ZoneType
+	if scope.AWSMachine.Spec.FailureDomainSelector != nil {
+		zoneTypes = scope.AWSMachine.Spec.FailureDomainSelector.ZoneTypes
+	}
 
 	// We basically have 2 sources for subnets:
 	//   1. If subnet.id or subnet.filters are specified, we directly query AWS
@@ -348,6 +416,18 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 				continue
 			}
 
+			if len(zoneTypes) > 0 {
+				zoneType, err := s.getZoneType(*subnet.AvailabilityZone)
+				if err != nil {
+					return "", err
+				}
+				if !slices.Contains(zoneTypes, zoneType) {
+					errMessage += fmt.Sprintf(" subnet %q zone %q is a %s, which is not selected.",
+						*subnet.SubnetId, *subnet.AvailabilityZone, zoneType)
+					continue
+				}
+			}
+
 			if ptr.Deref(scope.AWSMachine.Spec.PublicIP, false) {
 				matchingSubnet := s.scope.Subnets().FindByID(*subnet.SubnetId)
 				if matchingSubnet == nil {
@@ -374,8 +454,22 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 		}
 		return *filtered[0].SubnetId, nil
 	case failureDomain != nil:
+		if len(zoneTypes) > 0 {
+			zoneType, err := s.getZoneType(*failureDomain)
+			if err != nil {
+				return "", err
+			}
+			if !slices.Contains(zoneTypes, zoneType) {
+				errMessage := fmt.Sprintf("failed to run machine %q, failure domain %q is a %s, which is not selected",
+					scope.Name(), *failureDomain, zoneType)
+				record.Warnf(scope.AWSMachine, "FailedCreate", errMessage)
+				return "", awserrors.NewFailedDependency(errMessage)
+			}
+		}
+
+		// Subnets in Local Zones and Wavelength Zones are only used by the machines placed in their zone.
 		if scope.AWSMachine.Spec.PublicIP != nil && *scope.AWSMachine.Spec.PublicIP {
-			subnets := s.scope.Subnets().FilterPublic().FilterByZone(*failureDomain)
+			subnets := s.scope.Subnets().FilterPublicWithEdge().FilterByZone(*failureDomain)
 			if len(subnets) == 0 {
 				errMessage := fmt.Sprintf("failed to run machine %q with public IP, no public subnets available in availability zone %q",
 					scope.Name(), *failureDomain)
@@ -385,7 +479,7 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 			return subnets[0].GetResourceID(), nil
 		}
 
-		subnets := s.scope.Subnets().FilterPrivate().FilterByZone(*failureDomain)
+		subnets := s.scope.Subnets().FilterPrivateWithEdge().FilterNonCni().FilterByZone(*failureDomain)
 		if len(subnets) == 0 {
 			errMessage := fmt.Sprintf("failed to run machine %q, no subnets available in availability zone %q",
 				scope.Name(), *failureDomain)
@@ -395,6 +489,9 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 		return subnets[0].GetResourceID(), nil
 	case scope.AWSMachine.Spec.PublicIP != nil && *scope.AWSMachine.Spec.PublicIP:
 		subnets := s.scope.Subnets().FilterPublic()
+		if len(zoneTypes) > 0 {
+			subnets = s.scope.Subnets().FilterPublicWithEdge().FilterByZoneTypes(zoneTypes...)
+		}
 		if len(subnets) == 0 {
 			errMessage := fmt.Sprintf("failed to run machine %q with public IP, no public subnets available", scope.Name())
 			record.Eventf(scope.AWSMachine, "FailedCreate", errMessage)
@@ -406,7 +503,10 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 		// with control plane machines.
 
 	default:
-		sns := s.scope.Subnets().FilterPrivate()
+		sns := s.scope.Subnets().FilterPrivate().FilterNonCni()
+		if len(zoneTypes) > 0 {
+			sns = s.scope.Subnets().FilterPrivateWithEdge().FilterNonCni().FilterByZoneTypes(zoneTypes...)
+		}
 		if len(sns) == 0 {
 			errMessage := fmt.Sprintf("failed to run machine %q, no subnets available", scope.Name())
 			record.Eventf(s.scope.InfraCluster(), "FailedCreateInstance", errMessage)
@@ -416,6 +516,27 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 	}
 }
 
+// getZoneType returns the type of the zone, preferring the zone information of the cluster subnets.
+func (s *Service) getZoneType(zone string) (infrav1.ZoneType, error) {
+	for _, subnet := range s.scope.Subnets().FilterByZone(zone) {
+		if subnet.ZoneType != nil {
+			return *subnet.ZoneType, nil
+		}
+	}
+
+	out, err := s.EC2Client.DescribeAvailabilityZonesWithContext(context.TODO(), &ec2.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: aws.Bool(true),
+		ZoneNames:            aws.StringSlice([]string{zone}),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe zone %q", zone)
+	}
+	if len(out.AvailabilityZones) == 0 || out.AvailabilityZones[0].ZoneType == nil {
+		return "", errors.Errorf("failed to find the type of zone %q", zone)
+	}
+	return infrav1.ZoneType(*out.AvailabilityZones[0].ZoneType), nil
+}
+
 // getFilteredSubnets fetches subnets filtered based on the criteria passed.
 func (s *Service) getFilteredSubnets(criteria ...*ec2.Filter) ([]*ec2.Subnet, error) {
 	out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{Filters: criteria})
@@ -517,6 +638,95 @@ func (s *Service) TerminateInstance(instanceID string) error {
 	return nil
 }
 
+// DisableTerminationProtection removes the termination protection of an EC2 instance, so that it can be terminated.
+func (s *Service) DisableTerminationProtection(instanceID string) error {
+	s.scope.Debug("Attempting to disable termination protection of instance", "instance-id", instanceID)
+
+	input := &ec2.ModifyInstanceAttributeInput{
+		InstanceId:            aws.String(instanceID),
+		DisableApiTermination: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+	}
+
+	if _, err := s.EC2Client.ModifyInstanceAttributeWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to disable termination protection of instance with id %q", instanceID)
+	}
+
+	s.scope.Debug("Disabled termination protection of instance", "instance-id", instanceID)
+	return nil
+}
+
+// HibernateInstance stops an EC2 instance, saving the content of its memory to the root volume.
+func (s *Service) HibernateInstance(instanceID string) error {
+	s.scope.Debug("Attempting to hibernate instance", "instance-id", instanceID)
+
+	input := &ec2.StopInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+		Hibernate:   aws.Bool(true),
+	}
+
+	if _, err := s.EC2Client.StopInstancesWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to hibernate instance with id %q", instanceID)
+	}
+
+	s.scope.Debug("Hibernated instance", "instance-id", instanceID)
+	return nil
+}
+
+// StartInstance starts a stopped EC2 instance, resuming it when it was hibernated.
+func (s *Service) StartInstance(instanceID string) error {
+	s.scope.Debug("Attempting to start instance", "instance-id", instanceID)
+
+	input := &ec2.StartInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	}
+
+	if _, err := s.EC2Client.StartInstancesWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to start instance with id %q", instanceID)
+	}
+
+	s.scope.Debug("Started instance", "instance-id", instanceID)
+	return nil
+}
+
+// CancelSpotInstanceRequests cancels the Spot requests of an EC2 instance, so that a persistent
+// request does not launch the instance again once it is terminated.
+func (s *Service) CancelSpotInstanceRequests(instanceID string) error {
+	s.scope.Debug("Attempting to cancel spot instance requests", "instance-id", instanceID)
+
+	out, err := s.EC2Client.DescribeSpotInstanceRequestsWithContext(context.TODO(), &ec2.DescribeSpotInstanceRequestsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("instance-id"),
+				Values: aws.StringSlice([]string{instanceID}),
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe spot instance requests of instance with id %q", instanceID)
+	}
+
+	ids := []*string{}
+	for _, request := range out.SpotInstanceRequests {
+		switch aws.StringValue(request.State) {
+		case ec2.SpotInstanceStateCancelled, ec2.SpotInstanceStateClosed:
+			continue
+		}
+		ids = append(ids, request.SpotInstanceRequestId)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := s.EC2Client.CancelSpotInstanceRequestsWithContext(context.TODO(), &ec2.CancelSpotInstanceRequestsInput{
+		SpotInstanceRequestIds: ids,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to cancel spot instance requests of instance with id %q", instanceID)
+	}
+
+	s.scope.Debug("Cancelled spot instance requests", "instance-id", instanceID)
+	return nil
+}
+
 // TerminateInstanceAndWait terminates and waits
 // for an EC2 instance to terminate.
 func (s *Service) TerminateInstanceAndWait(instanceID string) error {
@@ -563,7 +773,9 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 
 		input.NetworkInterfaces = netInterfaces
 	} else {
-		if ptr.Deref(i.PublicIPOnLaunch, false) {
+		// The public IP option can only be set on a network interface. It is set when explicitly defined,
+		// so that it also overrides subnets that map public IPs on launch.
+		if i.PublicIPOnLaunch != nil {
 			input.NetworkInterfaces = []*ec2.InstanceNetworkInterfaceSpecification{
 				{
 					DeviceIndex:              aws.Int64(0),
@@ -588,6 +800,7 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 	}
 
 	blockdeviceMappings := []*ec2.BlockDeviceMapping{}
+	volumes := []*infrav1.Volume{}
 
 	if i.RootVolume != nil {
 		rootDeviceName, err := s.checkRootVolume(i.RootVolume, i.ImageID)
@@ -598,6 +811,7 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		i.RootVolume.DeviceName = aws.StringValue(rootDeviceName)
 		blockDeviceMapping := volumeToBlockDeviceMapping(i.RootVolume)
 		blockdeviceMappings = append(blockdeviceMappings, blockDeviceMapping)
+		volumes = append(volumes, i.RootVolume)
 	}
 
 	for vi := range i.NonRootVolumes {
@@ -609,6 +823,14 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 
 		blockDeviceMapping := volumeToBlockDeviceMapping(&nonRootVolume)
 		blockdeviceMappings = append(blockdeviceMappings, blockDeviceMapping)
+		volumes = append(volumes, &nonRootVolume)
+	}
+
+	if err := s.checkVolumeEncryption(volumes...); err != nil {
+		return nil, err
+	}
+	if _, err := s.checkEncryptionKeys(volumes...); err != nil {
+		return nil, err
 	}
 
 	if len(blockdeviceMappings) != 0 {
@@ -634,6 +856,21 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 	}
 
 	input.InstanceMarketOptions = getInstanceMarketOptionsRequest(i.SpotMarketOptions)
+	input.CapacityReservationSpecification = getCapacityReservationSpecification(i.CapacityReservationTarget)
+	if i.EnclaveOptions != nil {
+		input.EnclaveOptions = &ec2.EnclaveOptionsRequest{
+			Enabled: aws.Bool(i.EnclaveOptions.Enabled),
+		}
+	}
+	if i.HibernationOptions != nil {
+		input.HibernationOptions = &ec2.HibernationOptionsRequest{
+			Configured: aws.Bool(i.HibernationOptions.Configured),
+		}
+	}
+	if aws.BoolValue(i.TerminationProtection) {
+		input.DisableApiTermination = aws.Bool(true)
+		input.DisableApiStop = aws.Bool(true)
+	}
 	input.MetadataOptions = getInstanceMetadataOptionsRequest(i.InstanceMetadataOptions)
 	input.PrivateDnsNameOptions = getPrivateDNSNameOptionsRequest(i.PrivateDNSName)
 
@@ -643,6 +880,14 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 	}
 
+	if i.HostID != nil || i.HostResourceGroupARN != nil {
+		if input.Placement == nil {
+			input.Placement = &ec2.Placement{}
+		}
+		input.Placement.HostId = i.HostID
+		input.Placement.HostResourceGroupArn = i.HostResourceGroupARN
+	}
+
 	if i.PlacementGroupName == "" && i.PlacementGroupPartition != 0 {
 		return nil, errors.Errorf("placementGroupPartition is set but placementGroupName is empty")
 	}
@@ -671,7 +916,7 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 
 func volumeToBlockDeviceMapping(v *infrav1.Volume) *ec2.BlockDeviceMapping {
 	ebsDevice := &ec2.EbsBlockDevice{
-		DeleteOnTermination: aws.Bool(true),
+		DeleteOnTermination: aws.Bool(ptr.Deref(v.DeleteOnTermination, true)),
 		VolumeSize:          aws.Int64(v.Size),
 		Encrypted:           v.Encrypted,
 	}
@@ -739,6 +984,38 @@ func (s *Service) UpdateInstanceSecurityGroups(instanceID string, ids []string)
 	return nil
 }
 
+// UpdateInstanceSourceDestCheck enables or disables source/destination checking on the primary
+// ENI of the given EC2 instance. The instance attribute cannot be modified on instances with
+// several ENIs attached, so the primary ENI is modified directly.
+func (s *Service) UpdateInstanceSourceDestCheck(instanceID string, enabled bool) error {
+	s.scope.Debug("Attempting to update source/destination check on instance", "instance-id", instanceID, "enabled", enabled)
+
+	enis, err := s.getInstanceENIs(instanceID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get ENIs for instance %q", instanceID)
+	}
+
+	var primary *ec2.NetworkInterface
+	for _, eni := range enis {
+		if eni.Attachment != nil && aws.Int64Value(eni.Attachment.DeviceIndex) == 0 {
+			primary = eni
+			break
+		}
+	}
+	if primary == nil {
+		return errors.Errorf("failed to find primary ENI for instance %q", instanceID)
+	}
+
+	if _, err := s.EC2Client.ModifyNetworkInterfaceAttributeWithContext(context.TODO(), &ec2.ModifyNetworkInterfaceAttributeInput{
+		NetworkInterfaceId: primary.NetworkInterfaceId,
+		SourceDestCheck:    &ec2.AttributeBooleanValue{Value: aws.Bool(enabled)},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to modify source/destination check on network interface %q of instance %q", aws.StringValue(primary.NetworkInterfaceId), instanceID)
+	}
+
+	return nil
+}
+
 // UpdateResourceTags updates the tags for an instance.
 // This will be called if there is anything to create (update) or delete.
 // We may not always have to perform each action, so we check what we're
@@ -857,16 +1134,23 @@ func (s *Service) getImageSnapshotSize(imageID string) (*int64, error) {
 // additional call to EC2 is required to get this value.
 func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 	i := &infrav1.Instance{
-		ID:           aws.StringValue(v.InstanceId),
-		State:        infrav1.InstanceState(*v.State.Name),
-		Type:         aws.StringValue(v.InstanceType),
-		SubnetID:     aws.StringValue(v.SubnetId),
-		ImageID:      aws.StringValue(v.ImageId),
-		SSHKeyName:   v.KeyName,
-		PrivateIP:    v.PrivateIpAddress,
-		PublicIP:     v.PublicIpAddress,
-		ENASupport:   v.EnaSupport,
-		EBSOptimized: v.EbsOptimized,
+		ID:              aws.StringValue(v.InstanceId),
+		State:           infrav1.InstanceState(*v.State.Name),
+		Type:            aws.StringValue(v.InstanceType),
+		SubnetID:        aws.StringValue(v.SubnetId),
+		ImageID:         aws.StringValue(v.ImageId),
+		SSHKeyName:      v.KeyName,
+		PrivateIP:       v.PrivateIpAddress,
+		PublicIP:        v.PublicIpAddress,
+		ENASupport:      v.EnaSupport,
+		EBSOptimized:    v.EbsOptimized,
+		SourceDestCheck: v.SourceDestCheck,
+	}
+
+	if lifecycle := aws.StringValue(v.InstanceLifecycle); lifecycle != "" {
+		i.InstanceLifecycle = infrav1.InstanceLifecycle(lifecycle)
+	} else {
+		i.InstanceLifecycle = infrav1.InstanceLifecycleOnDemand
 	}
 
 	// Extract IAM Instance Profile name from ARN
@@ -883,6 +1167,12 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 		i.SecurityGroupIDs = append(i.SecurityGroupIDs, *sg.GroupId)
 	}
 
+	for _, productCode := range v.ProductCodes {
+		if aws.StringValue(productCode.ProductCodeType) == ec2.ProductCodeValuesMarketplace {
+			i.MarketplaceProductCodes = append(i.MarketplaceProductCodes, aws.StringValue(productCode.ProductCodeId))
+		}
+	}
+
 	if len(v.Tags) > 0 {
 		i.Tags = converters.TagsToMap(v.Tags)
 	}
@@ -1023,6 +1313,15 @@ func (s *Service) DetachSecurityGroupsFromNetworkInterface(groups []string, inte
 	return nil
 }
 
+func hasVolumeWithDeviceName(volumes []infrav1.Volume, deviceName string) bool {
+	for _, volume := range volumes {
+		if volume.DeviceName == deviceName {
+			return true
+		}
+	}
+	return false
+}
+
 // checkRootVolume checks the input root volume options against the requested AMI's defaults
 // and returns the AMI's root device name.
 func (s *Service) checkRootVolume(rootVolume *infrav1.Volume, imageID string) (*string, error) {
@@ -1128,14 +1427,21 @@ func getInstanceMarketOptionsRequest(spotMarketOptions *infrav1.SpotMarketOption
 	// Set required values for Spot instances
 	spotOptions := &ec2.SpotMarketOptions{}
 
-	// The following two options ensure that:
+	// By default, the following two options ensure that:
 	// - If an instance is interrupted, it is terminated rather than hibernating or stopping
 	// - No replacement instance will be created if the instance is interrupted
 	// - If the spot request cannot immediately be fulfilled, it will not be created
 	// This behaviour should satisfy the 1:1 mapping of Machines to Instances as
 	// assumed by the Cluster API.
-	spotOptions.SetInstanceInterruptionBehavior(ec2.InstanceInterruptionBehaviorTerminate)
-	spotOptions.SetSpotInstanceType(ec2.SpotInstanceTypeOneTime)
+	// Instances stopping or hibernating on interruption require a persistent request, which
+	// restarts the same instance, and is cancelled when the Machine is deleted.
+	if spotMarketOptions.IsPersistent() {
+		spotOptions.SetInstanceInterruptionBehavior(string(spotMarketOptions.InterruptionBehavior))
+		spotOptions.SetSpotInstanceType(ec2.SpotInstanceTypePersistent)
+	} else {
+		spotOptions.SetInstanceInterruptionBehavior(ec2.InstanceInterruptionBehaviorTerminate)
+		spotOptions.SetSpotInstanceType(ec2.SpotInstanceTypeOneTime)
+	}
 
 	maxPrice := spotMarketOptions.MaxPrice
 	if maxPrice != nil && *maxPrice != "" {
@@ -1149,6 +1455,19 @@ func getInstanceMarketOptionsRequest(spotMarketOptions *infrav1.SpotMarketOption
 	return instanceMarketOptionsRequest
 }
 
+func getCapacityReservationSpecification(target *infrav1.CapacityReservationTarget) *ec2.CapacityReservationSpecification {
+	if target == nil {
+		return nil
+	}
+
+	return &ec2.CapacityReservationSpecification{
+		CapacityReservationTarget: &ec2.CapacityReservationTarget{
+			CapacityReservationId:               target.ID,
+			CapacityReservationResourceGroupArn: target.ResourceGroupARN,
+		},
+	}
+}
+
 func getInstanceMetadataOptionsRequest(metadataOptions *infrav1.InstanceMetadataOptions) *ec2.InstanceMetadataOptionsRequest {
 	if metadataOptions == nil {
 		return nil
@@ -274,39 +274,1526 @@ func TestTerminateInstance(t *testing.T) {
 	}
 }
 
+func TestDisableTerminationProtection(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	instanceNotFoundError := errors.New("instance not found")
+
+	testCases := []struct {
+		name       string
+		instanceID string
+		expect     func(m *mocks.MockEC2APIMockRecorder)
+		check      func(err error)
+	}{
+		{
+			name:       "instance exists",
+			instanceID: "i-exist",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.ModifyInstanceAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifyInstanceAttributeInput{
+					InstanceId:            aws.String("i-exist"),
+					DisableApiTermination: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+				})).
+					Return(&ec2.ModifyInstanceAttributeOutput{}, nil)
+			},
+			check: func(err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name:       "instance does not exist",
+			instanceID: "i-donotexist",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.ModifyInstanceAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifyInstanceAttributeInput{
+					InstanceId:            aws.String("i-donotexist"),
+					DisableApiTermination: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+				})).
+					Return(nil, instanceNotFoundError)
+			},
+			check: func(err error) {
+				if err == nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.DisableTerminationProtection(tc.instanceID)
+			tc.check(err)
+		})
+	}
+}
+
+func TestHibernateInstance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	instanceNotFoundError := errors.New("instance not found")
+
+	testCases := []struct {
+		name       string
+		instanceID string
+		expect     func(m *mocks.MockEC2APIMockRecorder)
+		check      func(err error)
+	}{
+		{
+			name:       "instance exists",
+			instanceID: "i-exist",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.StopInstancesWithContext(context.TODO(), gomock.Eq(&ec2.StopInstancesInput{
+					InstanceIds: []*string{aws.String("i-exist")},
+					Hibernate:   aws.Bool(true),
+				})).
+					Return(&ec2.StopInstancesOutput{}, nil)
+			},
+			check: func(err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name:       "instance does not exist",
+			instanceID: "i-donotexist",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.StopInstancesWithContext(context.TODO(), gomock.Eq(&ec2.StopInstancesInput{
+					InstanceIds: []*string{aws.String("i-donotexist")},
+					Hibernate:   aws.Bool(true),
+				})).
+					Return(&ec2.StopInstancesOutput{}, instanceNotFoundError)
+			},
+			check: func(err error) {
+				if err == nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.HibernateInstance(tc.instanceID)
+			tc.check(err)
+		})
+	}
+}
+
+func TestStartInstance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	instanceNotFoundError := errors.New("instance not found")
+
+	testCases := []struct {
+		name       string
+		instanceID string
+		expect     func(m *mocks.MockEC2APIMockRecorder)
+		check      func(err error)
+	}{
+		{
+			name:       "instance exists",
+			instanceID: "i-exist",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.StartInstancesWithContext(context.TODO(), gomock.Eq(&ec2.StartInstancesInput{
+					InstanceIds: []*string{aws.String("i-exist")},
+				})).
+					Return(&ec2.StartInstancesOutput{}, nil)
+			},
+			check: func(err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name:       "instance does not exist",
+			instanceID: "i-donotexist",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.StartInstancesWithContext(context.TODO(), gomock.Eq(&ec2.StartInstancesInput{
+					InstanceIds: []*string{aws.String("i-donotexist")},
+				})).
+					Return(&ec2.StartInstancesOutput{}, instanceNotFoundError)
+			},
+			check: func(err error) {
+				if err == nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.StartInstance(tc.instanceID)
+			tc.check(err)
+		})
+	}
+}
+
+func TestCancelSpotInstanceRequests(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeInput := &ec2.DescribeSpotInstanceRequestsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("instance-id"),
+				Values: []*string{aws.String("i-spot")},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		expect    func(m *mocks.MockEC2APIMockRecorder)
+		expectErr bool
+	}{
+		{
+			name: "open requests are cancelled",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSpotInstanceRequestsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeSpotInstanceRequestsOutput{
+						SpotInstanceRequests: []*ec2.SpotInstanceRequest{
+							{SpotInstanceRequestId: aws.String("sir-active"), State: aws.String(ec2.SpotInstanceStateActive)},
+							{SpotInstanceRequestId: aws.String("sir-cancelled"), State: aws.String(ec2.SpotInstanceStateCancelled)},
+						},
+					}, nil)
+				m.CancelSpotInstanceRequestsWithContext(context.TODO(), gomock.Eq(&ec2.CancelSpotInstanceRequestsInput{
+					SpotInstanceRequestIds: []*string{aws.String("sir-active")},
+				})).
+					Return(&ec2.CancelSpotInstanceRequestsOutput{}, nil)
+			},
+		},
+		{
+			name: "no open requests",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSpotInstanceRequestsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeSpotInstanceRequestsOutput{}, nil)
+			},
+		},
+		{
+			name: "cancel fails",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSpotInstanceRequestsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeSpotInstanceRequestsOutput{
+						SpotInstanceRequests: []*ec2.SpotInstanceRequest{
+							{SpotInstanceRequestId: aws.String("sir-active"), State: aws.String(ec2.SpotInstanceStateActive)},
+						},
+					}, nil)
+				m.CancelSpotInstanceRequestsWithContext(context.TODO(), gomock.Any()).
+					Return(nil, errors.New("unauthorized"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.CancelSpotInstanceRequests("i-spot")
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestUpdateInstanceSourceDestCheck(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeENIs := func(m *mocks.MockEC2APIMockRecorder, enis ...*ec2.NetworkInterface) {
+		m.DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeNetworkInterfacesInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("attachment.instance-id"),
+					Values: []*string{aws.String("i-exist")},
+				},
+			},
+		})).Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: enis}, nil)
+	}
+
+	testCases := []struct {
+		name        string
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "modifies the primary ENI of an instance with several ENIs",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeENIs(m,
+					&ec2.NetworkInterface{
+						NetworkInterfaceId: aws.String("eni-secondary"),
+						Attachment:         &ec2.NetworkInterfaceAttachment{DeviceIndex: aws.Int64(1)},
+					},
+					&ec2.NetworkInterface{
+						NetworkInterfaceId: aws.String("eni-primary"),
+						Attachment:         &ec2.NetworkInterfaceAttachment{DeviceIndex: aws.Int64(0)},
+					},
+				)
+				m.ModifyNetworkInterfaceAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifyNetworkInterfaceAttributeInput{
+					NetworkInterfaceId: aws.String("eni-primary"),
+					SourceDestCheck:    &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+				})).Return(&ec2.ModifyNetworkInterfaceAttributeOutput{}, nil)
+			},
+		},
+		{
+			name: "returns an error when the instance has no primary ENI",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeENIs(m)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.UpdateInstanceSourceDestCheck("i-exist", false)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
 func TestCreateInstance(t *testing.T) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "bootstrap-data",
 		},
-		Data: map[string][]byte{
-			"value": []byte("data"),
+		Data: map[string][]byte{
+			"value": []byte("data"),
+		},
+	}
+
+	az := "test-zone-1a"
+	tenancy := "dedicated"
+
+	data := []byte("userData")
+
+	userDataCompressed, err := userdata.GzipBytes(data)
+	if err != nil {
+		t.Fatal("Failed to gzip test user data")
+	}
+
+	isUncompressedFalse := false
+	isUncompressedTrue := true
+
+	testcases := []struct {
+		name           string
+		machine        *clusterv1.Machine
+		machineConfig  *infrav1.AWSMachineSpec
+		awsCluster     *infrav1.AWSCluster
+		userDataFormat string
+		expect         func(m *mocks.MockEC2APIMockRecorder)
+		check          func(instance *infrav1.Instance, err error)
+	}{
+		{
+			name: "simple",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with availability zone",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+					FailureDomain: aws.String("us-east-1c"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.2xlarge",
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+								IsPublic:         false,
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+								IsPublic:         false,
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-3",
+								AvailabilityZone: "us-east-1c",
+								IsPublic:         false,
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-3-public",
+								AvailabilityZone: "us-east-1c",
+								IsPublic:         true,
+							},
+						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.2xlarge"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-3"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if instance.SubnetID != "subnet-3" {
+					t.Fatalf("expected subnet-3 from availability zone us-east-1c, got %q", instance.SubnetID)
+				}
+			},
+		},
+		{
+			name: "when multiple subnets match filters, subnets in the cluster vpc are preferred",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: aws.String("bootstrap-data"),
+					},
+					FailureDomain: aws.String("us-east-1c"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.2xlarge",
+				Subnet: &infrav1.AWSResourceReference{
+					Filters: []infrav1.Filter{
+						{
+							Name:   "availability-zone",
+							Values: []string{"us-east-1c"},
+						},
+					},
+				},
+				UncompressedUserData: &isUncompressedFalse,
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-foo",
+						},
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+								IsPublic:         false,
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+								IsPublic:         false,
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-3",
+								AvailabilityZone: "us-east-1c",
+								IsPublic:         false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.2xlarge"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{
+					Filters: []*ec2.Filter{
+						filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+						{
+							Name:   aws.String("availability-zone"),
+							Values: aws.StringSlice([]string{"us-east-1c"}),
+						},
+					}})).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{
+						{
+							VpcId:               aws.String("vpc-bar"),
+							SubnetId:            aws.String("subnet-4"),
+							AvailabilityZone:    aws.String("us-east-1c"),
+							CidrBlock:           aws.String("10.0.10.0/24"),
+							MapPublicIpOnLaunch: aws.Bool(false),
+						},
+						{
+							VpcId:            aws.String("vpc-foo"),
+							SubnetId:         aws.String("subnet-3"),
+							AvailabilityZone: aws.String("us-east-1c"),
+							CidrBlock:        aws.String("10.0.11.0/24"),
+						},
+					},
+				}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), &ec2.RunInstancesInput{
+						ImageId:          aws.String("abc"),
+						InstanceType:     aws.String("m5.2xlarge"),
+						KeyName:          aws.String("default"),
+						SecurityGroupIds: aws.StringSlice([]string{"2", "3"}),
+						SubnetId:         aws.String("subnet-3"),
+						TagSpecifications: []*ec2.TagSpecification{
+							{
+								ResourceType: aws.String("instance"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("/"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("node"),
+									},
+								},
+							},
+						},
+						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
+						MaxCount: aws.Int64(1),
+						MinCount: aws.Int64(1),
+					}).Return(&ec2.Reservation{
+					Instances: []*ec2.Instance{
+						{
+							State: &ec2.InstanceState{
+								Name: aws.String(ec2.InstanceStateNamePending),
+							},
+							IamInstanceProfile: &ec2.IamInstanceProfile{
+								Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+							},
+							InstanceId:     aws.String("two"),
+							InstanceType:   aws.String("m5.large"),
+							SubnetId:       aws.String("subnet-3"),
+							ImageId:        aws.String("ami-1"),
+							RootDeviceName: aws.String("device-1"),
+							BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+								{
+									DeviceName: aws.String("device-1"),
+									Ebs: &ec2.EbsInstanceBlockDevice{
+										VolumeId: aws.String("volume-1"),
+									},
+								},
+							},
+							Placement: &ec2.Placement{
+								AvailabilityZone: &az,
+							},
+						},
+					},
+				}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if instance.SubnetID != "subnet-3" {
+					t.Fatalf("expected subnet-3 from availability zone us-east-1c, got %q", instance.SubnetID)
+				}
+			},
+		},
+		{
+			name: "with a subnet outside the cluster vpc",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: aws.String("bootstrap-data"),
+					},
+					FailureDomain: aws.String("us-east-1c"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.2xlarge",
+				Subnet: &infrav1.AWSResourceReference{
+					Filters: []infrav1.Filter{
+						{
+							Name:   "vpc-id",
+							Values: []string{"vpc-bar"},
+						},
+						{
+							Name:   "availability-zone",
+							Values: []string{"us-east-1c"},
+						},
+					},
+				},
+				SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{
+					infrav1.SecurityGroupNode: "4",
+				},
+				UncompressedUserData: &isUncompressedFalse,
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-foo",
+						},
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+								IsPublic:         false,
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+								IsPublic:         false,
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-3",
+								AvailabilityZone: "us-east-1c",
+								IsPublic:         false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.2xlarge"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{
+					Filters: []*ec2.Filter{
+						filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+						filter.EC2.VPC("vpc-bar"),
+						{
+							Name:   aws.String("availability-zone"),
+							Values: aws.StringSlice([]string{"us-east-1c"}),
+						},
+					}})).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{
+						{
+							VpcId:            aws.String("vpc-bar"),
+							SubnetId:         aws.String("subnet-5"),
+							AvailabilityZone: aws.String("us-east-1c"),
+							CidrBlock:        aws.String("10.0.11.0/24"),
+						},
+					},
+				}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), &ec2.RunInstancesInput{
+						ImageId:          aws.String("abc"),
+						InstanceType:     aws.String("m5.2xlarge"),
+						KeyName:          aws.String("default"),
+						SecurityGroupIds: aws.StringSlice([]string{"4", "3"}),
+						SubnetId:         aws.String("subnet-5"),
+						TagSpecifications: []*ec2.TagSpecification{
+							{
+								ResourceType: aws.String("instance"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("/"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("node"),
+									},
+								},
+							},
+						},
+						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
+						MaxCount: aws.Int64(1),
+						MinCount: aws.Int64(1),
+					}).Return(&ec2.Reservation{
+					Instances: []*ec2.Instance{
+						{
+							State: &ec2.InstanceState{
+								Name: aws.String(ec2.InstanceStateNamePending),
+							},
+							IamInstanceProfile: &ec2.IamInstanceProfile{
+								Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+							},
+							InstanceId:     aws.String("two"),
+							InstanceType:   aws.String("m5.large"),
+							SubnetId:       aws.String("subnet-5"),
+							ImageId:        aws.String("ami-1"),
+							RootDeviceName: aws.String("device-1"),
+							BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+								{
+									DeviceName: aws.String("device-1"),
+									Ebs: &ec2.EbsInstanceBlockDevice{
+										VolumeId: aws.String("volume-1"),
+									},
+								},
+							},
+							Placement: &ec2.Placement{
+								AvailabilityZone: &az,
+							},
+						},
+					},
+				}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if instance.SubnetID != "subnet-5" {
+					t.Fatalf("expected subnet-5 from availability zone us-east-1c, got %q", instance.SubnetID)
+				}
+			},
+		},
+		{
+			name: "with ImageLookupOrg specified at the machine level",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+					Version: ptr.To[string]("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				ImageLookupOrg: "test-org-123",
+				InstanceType:   "m6g.large",
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				amiName, err := GenerateAmiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-18.04", "1.16.1")
+				if err != nil {
+					t.Fatalf("Failed to process ami format: %v", err)
+				}
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m6g.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("arm64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				// verify that the ImageLookupOrg is used when finding AMIs
+				m.
+					DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
+						Filters: []*ec2.Filter{
+							{
+								Name:   aws.String("owner-id"),
+								Values: []*string{aws.String("test-org-123")},
+							},
+							{
+								Name:   aws.String("name"),
+								Values: []*string{aws.String(amiName)},
+							},
+							{
+								Name:   aws.String("architecture"),
+								Values: []*string{aws.String("arm64")},
+							},
+							{
+								Name:   aws.String("state"),
+								Values: []*string{aws.String("available")},
+							},
+							{
+								Name:   aws.String("virtualization-type"),
+								Values: []*string{aws.String("hvm")},
+							},
+						},
+					})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2006-01-02T15:04:05.000Z"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with ImageLookupOrg specified at the cluster-level",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+					Version: ptr.To[string]("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
+					},
+					ImageLookupOrg: "cluster-level-image-lookup-org",
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				amiName, err := GenerateAmiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-18.04", "1.16.1")
+				if err != nil {
+					t.Fatalf("Failed to process ami format: %v", err)
+				}
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				// verify that the ImageLookupOrg is used when finding AMIs
+				m.
+					DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
+						Filters: []*ec2.Filter{
+							{
+								Name:   aws.String("owner-id"),
+								Values: []*string{aws.String("cluster-level-image-lookup-org")},
+							},
+							{
+								Name:   aws.String("name"),
+								Values: []*string{aws.String(amiName)},
+							},
+							{
+								Name:   aws.String("architecture"),
+								Values: []*string{aws.String("x86_64")},
+							},
+							{
+								Name:   aws.String("state"),
+								Values: []*string{aws.String("available")},
+							},
+							{
+								Name:   aws.String("virtualization-type"),
+								Values: []*string{aws.String("hvm")},
+							},
+						},
+					})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2006-01-02T15:04:05.000Z"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "AWSMachine ImageLookupOrg overrides AWSCluster ImageLookupOrg",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+					Version: ptr.To[string]("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType:   "m5.large",
+				ImageLookupOrg: "machine-level-image-lookup-org",
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
+					},
+					ImageLookupOrg: "cluster-level-image-lookup-org",
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				amiName, err := GenerateAmiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-18.04", "1.16.1")
+				if err != nil {
+					t.Fatalf("Failed to process ami format: %v", err)
+				}
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				// verify that the ImageLookupOrg is used when finding AMIs
+				m.
+					DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
+						Filters: []*ec2.Filter{
+							{
+								Name:   aws.String("owner-id"),
+								Values: []*string{aws.String("machine-level-image-lookup-org")},
+							},
+							{
+								Name:   aws.String("name"),
+								Values: []*string{aws.String(amiName)},
+							},
+							{
+								Name:   aws.String("architecture"),
+								Values: []*string{aws.String("x86_64")},
+							},
+							{
+								Name:   aws.String("state"),
+								Values: []*string{aws.String("available")},
+							},
+							{
+								Name:   aws.String("virtualization-type"),
+								Values: []*string{aws.String("hvm")},
+							},
+						},
+					})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2006-01-02T15:04:05.000Z"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
 		},
-	}
-
-	az := "test-zone-1a"
-	tenancy := "dedicated"
-
-	data := []byte("userData")
-
-	userDataCompressed, err := userdata.GzipBytes(data)
-	if err != nil {
-		t.Fatal("Failed to gzip test user data")
-	}
-
-	isUncompressedFalse := false
-	isUncompressedTrue := true
-
-	testcases := []struct {
-		name          string
-		machine       *clusterv1.Machine
-		machineConfig *infrav1.AWSMachineSpec
-		awsCluster    *infrav1.AWSCluster
-		expect        func(m *mocks.MockEC2APIMockRecorder)
-		check         func(instance *infrav1.Instance, err error)
-	}{
 		{
-			name: "simple",
+			name: "subnet filter and failureDomain defined",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -315,6 +1802,7 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
+					FailureDomain: aws.String("us-east-1b"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
@@ -322,23 +1810,146 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					Filters: []infrav1.Filter{{
+						Name:   "tag:some-tag",
+						Values: []string{"some-value"},
+					}},
+				},
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						Subnets: infrav1.Subnets{
-							infrav1.SubnetSpec{
-								ID:       "subnet-1",
-								IsPublic: false,
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-id",
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
 							},
-							infrav1.SubnetSpec{
-								IsPublic: false,
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("tag:some-tag"), Values: aws.StringSlice([]string{"some-value"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:         aws.String("filtered-subnet-1"),
+							AvailabilityZone: aws.String("us-east-1b"),
+						}},
+					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
 							},
 						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with subnet ID that belongs to Cluster",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					ID: aws.String("matching-subnet"),
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
-							ID: "vpc-test",
+							ID: "vpc-id",
 						},
+						Subnets: infrav1.Subnets{{
+							ID: "matching-subnet",
+						}},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -361,6 +1972,19 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"matching-subnet"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:         aws.String("matching-subnet"),
+							AvailabilityZone: aws.String("us-east-1b"),
+						}},
+					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
@@ -378,7 +2002,7 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
+				m.
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
@@ -391,7 +2015,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("subnet-1"),
+								SubnetId:       aws.String("matching-subnet"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -422,7 +2046,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "with availability zone",
+			name: "with subnet ID that does not exist",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -431,44 +2055,27 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					FailureDomain: aws.String("us-east-1c"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
 				AMI: infrav1.AMIReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType: "m5.2xlarge",
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					ID: aws.String("non-matching-subnet"),
+				},
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						Subnets: infrav1.Subnets{
-							infrav1.SubnetSpec{
-								ID:               "subnet-1",
-								AvailabilityZone: "us-east-1a",
-								IsPublic:         false,
-							},
-							infrav1.SubnetSpec{
-								ID:               "subnet-2",
-								AvailabilityZone: "us-east-1b",
-								IsPublic:         false,
-							},
-							infrav1.SubnetSpec{
-								ID:               "subnet-3",
-								AvailabilityZone: "us-east-1c",
-								IsPublic:         false,
-							},
-							infrav1.SubnetSpec{
-								ID:               "subnet-3-public",
-								AvailabilityZone: "us-east-1c",
-								IsPublic:         true,
-							},
-						},
 						VPC: infrav1.VPCSpec{
-							ID: "vpc-test",
+							ID: "vpc-id",
 						},
+						Subnets: infrav1.Subnets{{
+							ID: "subnet-1",
+						}},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -494,7 +2101,7 @@ func TestCreateInstance(t *testing.T) {
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
-							aws.String("m5.2xlarge"),
+							aws.String("m5.large"),
 						},
 					})).
 					Return(&ec2.DescribeInstanceTypesOutput{
@@ -509,104 +2116,58 @@ func TestCreateInstance(t *testing.T) {
 						},
 					}, nil)
 				m.
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.Reservation{
-						Instances: []*ec2.Instance{
-							{
-								State: &ec2.InstanceState{
-									Name: aws.String(ec2.InstanceStateNamePending),
-								},
-								IamInstanceProfile: &ec2.IamInstanceProfile{
-									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-								},
-								InstanceId:     aws.String("two"),
-								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("subnet-3"),
-								ImageId:        aws.String("ami-1"),
-								RootDeviceName: aws.String("device-1"),
-								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-									{
-										DeviceName: aws.String("device-1"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-1"),
-										},
-									},
-								},
-								Placement: &ec2.Placement{
-									AvailabilityZone: &az,
-								},
-							},
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"non-matching-subnet"})},
 						},
-					}, nil)
-				m.
-					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.DescribeNetworkInterfacesOutput{
-						NetworkInterfaces: []*ec2.NetworkInterface{},
-						NextToken:         nil,
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{},
 					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				if err != nil {
-					t.Fatalf("did not expect error: %v", err)
+				expectedErrMsg := "failed to run machine \"aws-test1\", no subnets available matching criteria"
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
 				}
 
-				if instance.SubnetID != "subnet-3" {
-					t.Fatalf("expected subnet-3 from availability zone us-east-1c, got %q", instance.SubnetID)
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: %s", expectedErrMsg, err.Error())
 				}
 			},
 		},
 		{
-			name: "when multiple subnets match filters, subnets in the cluster vpc are preferred",
+			name: "with subnet ID that does not belong to Cluster",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
 				},
 				Spec: clusterv1.MachineSpec{
 					Bootstrap: clusterv1.Bootstrap{
-						DataSecretName: aws.String("bootstrap-data"),
+						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					FailureDomain: aws.String("us-east-1c"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
 				AMI: infrav1.AMIReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType: "m5.2xlarge",
+				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					Filters: []infrav1.Filter{
-						{
-							Name:   "availability-zone",
-							Values: []string{"us-east-1c"},
-						},
-					},
+					ID: aws.String("matching-subnet"),
 				},
-				UncompressedUserData: &isUncompressedFalse,
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
-							ID: "vpc-foo",
-						},
-						Subnets: infrav1.Subnets{
-							infrav1.SubnetSpec{
-								ID:               "subnet-1",
-								AvailabilityZone: "us-east-1a",
-								IsPublic:         false,
-							},
-							infrav1.SubnetSpec{
-								ID:               "subnet-2",
-								AvailabilityZone: "us-east-1b",
-								IsPublic:         false,
-							},
-							infrav1.SubnetSpec{
-								ID:               "subnet-3",
-								AvailabilityZone: "us-east-1c",
-								IsPublic:         false,
-							},
+							ID: "vpc-id",
 						},
+						Subnets: infrav1.Subnets{{
+							ID: "subnet-1",
+						}},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -629,10 +2190,40 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("matching-subnet"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
-							aws.String("m5.2xlarge"),
+							aws.String("m5.large"),
 						},
 					})).
 					Return(&ec2.DescribeInstanceTypesOutput{
@@ -646,95 +2237,18 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{
-					Filters: []*ec2.Filter{
-						filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-						{
-							Name:   aws.String("availability-zone"),
-							Values: aws.StringSlice([]string{"us-east-1c"}),
-						},
-					}})).Return(&ec2.DescribeSubnetsOutput{
-					Subnets: []*ec2.Subnet{
-						{
-							VpcId:               aws.String("vpc-bar"),
-							SubnetId:            aws.String("subnet-4"),
-							AvailabilityZone:    aws.String("us-east-1c"),
-							CidrBlock:           aws.String("10.0.10.0/24"),
-							MapPublicIpOnLaunch: aws.Bool(false),
-						},
-						{
-							VpcId:            aws.String("vpc-foo"),
-							SubnetId:         aws.String("subnet-3"),
-							AvailabilityZone: aws.String("us-east-1c"),
-							CidrBlock:        aws.String("10.0.11.0/24"),
-						},
-					},
-				}, nil)
 				m.
-					RunInstancesWithContext(context.TODO(), &ec2.RunInstancesInput{
-						ImageId:          aws.String("abc"),
-						InstanceType:     aws.String("m5.2xlarge"),
-						KeyName:          aws.String("default"),
-						SecurityGroupIds: aws.StringSlice([]string{"2", "3"}),
-						SubnetId:         aws.String("subnet-3"),
-						TagSpecifications: []*ec2.TagSpecification{
-							{
-								ResourceType: aws.String("instance"),
-								Tags: []*ec2.Tag{
-									{
-										Key:   aws.String("MachineName"),
-										Value: aws.String("/"),
-									},
-									{
-										Key:   aws.String("Name"),
-										Value: aws.String("aws-test1"),
-									},
-									{
-										Key:   aws.String("kubernetes.io/cluster/test1"),
-										Value: aws.String("owned"),
-									},
-									{
-										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
-										Value: aws.String("owned"),
-									},
-									{
-										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
-										Value: aws.String("node"),
-									},
-								},
-							},
-						},
-						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
-						MaxCount: aws.Int64(1),
-						MinCount: aws.Int64(1),
-					}).Return(&ec2.Reservation{
-					Instances: []*ec2.Instance{
-						{
-							State: &ec2.InstanceState{
-								Name: aws.String(ec2.InstanceStateNamePending),
-							},
-							IamInstanceProfile: &ec2.IamInstanceProfile{
-								Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-							},
-							InstanceId:     aws.String("two"),
-							InstanceType:   aws.String("m5.large"),
-							SubnetId:       aws.String("subnet-3"),
-							ImageId:        aws.String("ami-1"),
-							RootDeviceName: aws.String("device-1"),
-							BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-								{
-									DeviceName: aws.String("device-1"),
-									Ebs: &ec2.EbsInstanceBlockDevice{
-										VolumeId: aws.String("volume-1"),
-									},
-								},
-							},
-							Placement: &ec2.Placement{
-								AvailabilityZone: &az,
-							},
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"matching-subnet"})},
 						},
-					},
-				}, nil)
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId: aws.String("matching-subnet"),
+						}},
+					}, nil)
 				m.
 					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeNetworkInterfacesOutput{
@@ -746,71 +2260,41 @@ func TestCreateInstance(t *testing.T) {
 				if err != nil {
 					t.Fatalf("did not expect error: %v", err)
 				}
-
-				if instance.SubnetID != "subnet-3" {
-					t.Fatalf("expected subnet-3 from availability zone us-east-1c, got %q", instance.SubnetID)
-				}
 			},
 		},
 		{
-			name: "with a subnet outside the cluster vpc",
+			name: "subnet id and failureDomain don't match",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
 				},
 				Spec: clusterv1.MachineSpec{
 					Bootstrap: clusterv1.Bootstrap{
-						DataSecretName: aws.String("bootstrap-data"),
+						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					FailureDomain: aws.String("us-east-1c"),
+					FailureDomain: aws.String("us-east-1b"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
 				AMI: infrav1.AMIReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType: "m5.2xlarge",
+				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					Filters: []infrav1.Filter{
-						{
-							Name:   "vpc-id",
-							Values: []string{"vpc-bar"},
-						},
-						{
-							Name:   "availability-zone",
-							Values: []string{"us-east-1c"},
-						},
-					},
-				},
-				SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{
-					infrav1.SecurityGroupNode: "4",
+					ID: aws.String("subnet-1"),
 				},
-				UncompressedUserData: &isUncompressedFalse,
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
-							ID: "vpc-foo",
-						},
-						Subnets: infrav1.Subnets{
-							infrav1.SubnetSpec{
-								ID:               "subnet-1",
-								AvailabilityZone: "us-east-1a",
-								IsPublic:         false,
-							},
-							infrav1.SubnetSpec{
-								ID:               "subnet-2",
-								AvailabilityZone: "us-east-1b",
-								IsPublic:         false,
-							},
-							infrav1.SubnetSpec{
-								ID:               "subnet-3",
-								AvailabilityZone: "us-east-1c",
-								IsPublic:         false,
-							},
+							ID: "vpc-id",
 						},
+						Subnets: infrav1.Subnets{{
+							ID:               "subnet-1",
+							AvailabilityZone: "us-west-1b",
+						}},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -833,10 +2317,23 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"subnet-1"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:         aws.String("subnet-1"),
+							AvailabilityZone: aws.String("us-west-1b"),
+						}},
+					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
-							aws.String("m5.2xlarge"),
+							aws.String("m5.large"),
 						},
 					})).
 					Return(&ec2.DescribeInstanceTypesOutput{
@@ -850,108 +2347,103 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{
-					Filters: []*ec2.Filter{
-						filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-						filter.EC2.VPC("vpc-bar"),
-						{
-							Name:   aws.String("availability-zone"),
-							Values: aws.StringSlice([]string{"us-east-1c"}),
-						},
-					}})).Return(&ec2.DescribeSubnetsOutput{
-					Subnets: []*ec2.Subnet{
-						{
-							VpcId:            aws.String("vpc-bar"),
-							SubnetId:         aws.String("subnet-5"),
-							AvailabilityZone: aws.String("us-east-1c"),
-							CidrBlock:        aws.String("10.0.11.0/24"),
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				expectedErrMsg := "failed to run machine \"aws-test1\", found 1 subnets matching criteria but post-filtering failed. subnet \"subnet-1\" availability zone \"us-west-1b\" does not match failure domain \"us-east-1b\""
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
+				}
+			},
+		},
+		{
+			name: "public IP true and failureDomain doesn't have public subnet",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+					FailureDomain: aws.String("us-east-1b"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				PublicIP:     aws.Bool(true),
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-id",
 						},
+						Subnets: infrav1.Subnets{{
+							ID:               "private-subnet-1",
+							AvailabilityZone: "us-east-1b",
+							IsPublic:         false,
+						}},
 					},
-				}, nil)
-				m.
-					RunInstancesWithContext(context.TODO(), &ec2.RunInstancesInput{
-						ImageId:          aws.String("abc"),
-						InstanceType:     aws.String("m5.2xlarge"),
-						KeyName:          aws.String("default"),
-						SecurityGroupIds: aws.StringSlice([]string{"4", "3"}),
-						SubnetId:         aws.String("subnet-5"),
-						TagSpecifications: []*ec2.TagSpecification{
-							{
-								ResourceType: aws.String("instance"),
-								Tags: []*ec2.Tag{
-									{
-										Key:   aws.String("MachineName"),
-										Value: aws.String("/"),
-									},
-									{
-										Key:   aws.String("Name"),
-										Value: aws.String("aws-test1"),
-									},
-									{
-										Key:   aws.String("kubernetes.io/cluster/test1"),
-										Value: aws.String("owned"),
-									},
-									{
-										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
-										Value: aws.String("owned"),
-									},
-									{
-										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
-										Value: aws.String("node"),
-									},
-								},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
 							},
-						},
-						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
-						MaxCount: aws.Int64(1),
-						MinCount: aws.Int64(1),
-					}).Return(&ec2.Reservation{
-					Instances: []*ec2.Instance{
-						{
-							State: &ec2.InstanceState{
-								Name: aws.String(ec2.InstanceStateNamePending),
+							infrav1.SecurityGroupNode: {
+								ID: "2",
 							},
-							IamInstanceProfile: &ec2.IamInstanceProfile{
-								Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+							infrav1.SecurityGroupLB: {
+								ID: "3",
 							},
-							InstanceId:     aws.String("two"),
-							InstanceType:   aws.String("m5.large"),
-							SubnetId:       aws.String("subnet-5"),
-							ImageId:        aws.String("ami-1"),
-							RootDeviceName: aws.String("device-1"),
-							BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-								{
-									DeviceName: aws.String("device-1"),
-									Ebs: &ec2.EbsInstanceBlockDevice{
-										VolumeId: aws.String("volume-1"),
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
 									},
 								},
 							},
-							Placement: &ec2.Placement{
-								AvailabilityZone: &az,
-							},
 						},
-					},
-				}, nil)
-				m.
-					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.DescribeNetworkInterfacesOutput{
-						NetworkInterfaces: []*ec2.NetworkInterface{},
-						NextToken:         nil,
 					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				if err != nil {
-					t.Fatalf("did not expect error: %v", err)
+				expectedErrMsg := "failed to run machine \"aws-test1\" with public IP, no public subnets available in availability zone \"us-east-1b\""
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
 				}
 
-				if instance.SubnetID != "subnet-5" {
-					t.Fatalf("expected subnet-5 from availability zone us-east-1c, got %q", instance.SubnetID)
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
 				}
 			},
 		},
 		{
-			name: "with ImageLookupOrg specified at the machine level",
+			name: "failure domain in a Local Zone not selected by the failure domain selector",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -960,29 +2452,30 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					Version: ptr.To[string]("v1.16.1"),
+					FailureDomain: aws.String("us-east-1-nyc-1a"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
-				ImageLookupOrg: "test-org-123",
-				InstanceType:   "m6g.large",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				FailureDomainSelector: &infrav1.FailureDomainSelector{
+					ZoneTypes: []infrav1.ZoneType{infrav1.ZoneTypeAvailabilityZone},
+				},
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						Subnets: infrav1.Subnets{
-							infrav1.SubnetSpec{
-								ID:       "subnet-1",
-								IsPublic: false,
-							},
-							infrav1.SubnetSpec{
-								IsPublic: false,
-							},
-						},
 						VPC: infrav1.VPCSpec{
-							ID: "vpc-test",
+							ID: "vpc-id",
 						},
+						Subnets: infrav1.Subnets{{
+							ID:               "private-subnet-1",
+							AvailabilityZone: "us-east-1-nyc-1a",
+							ZoneType:         ptr.To(infrav1.ZoneTypeLocalZone),
+						}},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -1002,17 +2495,13 @@ func TestCreateInstance(t *testing.T) {
 							DNSName: "test-apiserver.us-east-1.aws",
 						},
 					},
-				},
-			},
-			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				amiName, err := GenerateAmiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-18.04", "1.16.1")
-				if err != nil {
-					t.Fatalf("Failed to process ami format: %v", err)
-				}
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
-							aws.String("m6g.large"),
+							aws.String("m5.large"),
 						},
 					})).
 					Return(&ec2.DescribeInstanceTypesOutput{
@@ -1020,91 +2509,26 @@ func TestCreateInstance(t *testing.T) {
 							{
 								ProcessorInfo: &ec2.ProcessorInfo{
 									SupportedArchitectures: []*string{
-										aws.String("arm64"),
-									},
-								},
-							},
-						},
-					}, nil)
-				// verify that the ImageLookupOrg is used when finding AMIs
-				m.
-					DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
-						Filters: []*ec2.Filter{
-							{
-								Name:   aws.String("owner-id"),
-								Values: []*string{aws.String("test-org-123")},
-							},
-							{
-								Name:   aws.String("name"),
-								Values: []*string{aws.String(amiName)},
-							},
-							{
-								Name:   aws.String("architecture"),
-								Values: []*string{aws.String("arm64")},
-							},
-							{
-								Name:   aws.String("state"),
-								Values: []*string{aws.String("available")},
-							},
-							{
-								Name:   aws.String("virtualization-type"),
-								Values: []*string{aws.String("hvm")},
-							},
-						},
-					})).
-					Return(&ec2.DescribeImagesOutput{
-						Images: []*ec2.Image{
-							{
-								Name:         aws.String("ami-1"),
-								CreationDate: aws.String("2006-01-02T15:04:05.000Z"),
-							},
-						},
-					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.Reservation{
-						Instances: []*ec2.Instance{
-							{
-								State: &ec2.InstanceState{
-									Name: aws.String(ec2.InstanceStateNamePending),
-								},
-								IamInstanceProfile: &ec2.IamInstanceProfile{
-									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-								},
-								InstanceId:     aws.String("two"),
-								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("subnet-1"),
-								ImageId:        aws.String("ami-1"),
-								RootDeviceName: aws.String("device-1"),
-								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-									{
-										DeviceName: aws.String("device-1"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-1"),
-										},
+										aws.String("x86_64"),
 									},
 								},
-								Placement: &ec2.Placement{
-									AvailabilityZone: &az,
-								},
 							},
 						},
 					}, nil)
-				m.
-					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.DescribeNetworkInterfacesOutput{
-						NetworkInterfaces: []*ec2.NetworkInterface{},
-						NextToken:         nil,
-					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				if err != nil {
-					t.Fatalf("did not expect error: %v", err)
+				expectedErrMsg := "failed to run machine \"aws-test1\", failure domain \"us-east-1-nyc-1a\" is a local-zone, which is not selected"
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
 				}
 			},
 		},
 		{
-			name: "with ImageLookupOrg specified at the cluster-level",
+			name: "public IP true and public subnet ID given",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1113,30 +2537,30 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					Version: ptr.To[string]("v1.16.1"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
 				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					ID: aws.String("public-subnet-1"),
+				},
+				PublicIP: aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						Subnets: infrav1.Subnets{
-							infrav1.SubnetSpec{
-								ID:       "subnet-1",
-								IsPublic: false,
-							},
-							infrav1.SubnetSpec{
-								IsPublic: false,
-							},
-						},
 						VPC: infrav1.VPCSpec{
-							ID: "vpc-test",
+							ID: "vpc-id",
 						},
+						Subnets: infrav1.Subnets{{
+							ID:       "public-subnet-1",
+							IsPublic: true,
+						}},
 					},
-					ImageLookupOrg: "cluster-level-image-lookup-org",
 				},
 				Status: infrav1.AWSClusterStatus{
 					Network: infrav1.NetworkStatus{
@@ -1158,10 +2582,20 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				amiName, err := GenerateAmiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-18.04", "1.16.1")
-				if err != nil {
-					t.Fatalf("Failed to process ami format: %v", err)
-				}
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"public-subnet-1"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:            aws.String("public-subnet-1"),
+							AvailabilityZone:    aws.String("us-east-1b"),
+							MapPublicIpOnLaunch: aws.Bool(true),
+						}},
+					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
@@ -1179,41 +2613,7 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				// verify that the ImageLookupOrg is used when finding AMIs
 				m.
-					DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
-						Filters: []*ec2.Filter{
-							{
-								Name:   aws.String("owner-id"),
-								Values: []*string{aws.String("cluster-level-image-lookup-org")},
-							},
-							{
-								Name:   aws.String("name"),
-								Values: []*string{aws.String(amiName)},
-							},
-							{
-								Name:   aws.String("architecture"),
-								Values: []*string{aws.String("x86_64")},
-							},
-							{
-								Name:   aws.String("state"),
-								Values: []*string{aws.String("available")},
-							},
-							{
-								Name:   aws.String("virtualization-type"),
-								Values: []*string{aws.String("hvm")},
-							},
-						},
-					})).
-					Return(&ec2.DescribeImagesOutput{
-						Images: []*ec2.Image{
-							{
-								Name:         aws.String("ami-1"),
-								CreationDate: aws.String("2006-01-02T15:04:05.000Z"),
-							},
-						},
-					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
@@ -1226,7 +2626,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("subnet-1"),
+								SubnetId:       aws.String("public-subnet-1"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -1257,7 +2657,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "AWSMachine ImageLookupOrg overrides AWSCluster ImageLookupOrg",
+			name: "public IP true, public subnet ID given and MapPublicIpOnLaunch is false",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1266,31 +2666,30 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					Version: ptr.To[string]("v1.16.1"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
-				InstanceType:   "m5.large",
-				ImageLookupOrg: "machine-level-image-lookup-org",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					ID: aws.String("public-subnet-1"),
+				},
+				PublicIP: aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						Subnets: infrav1.Subnets{
-							infrav1.SubnetSpec{
-								ID:       "subnet-1",
-								IsPublic: false,
-							},
-							infrav1.SubnetSpec{
-								IsPublic: false,
-							},
-						},
 						VPC: infrav1.VPCSpec{
-							ID: "vpc-test",
+							ID: "vpc-id",
 						},
+						Subnets: infrav1.Subnets{{
+							ID:       "public-subnet-1",
+							IsPublic: true,
+						}},
 					},
-					ImageLookupOrg: "cluster-level-image-lookup-org",
 				},
 				Status: infrav1.AWSClusterStatus{
 					Network: infrav1.NetworkStatus{
@@ -1312,10 +2711,20 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				amiName, err := GenerateAmiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-18.04", "1.16.1")
-				if err != nil {
-					t.Fatalf("Failed to process ami format: %v", err)
-				}
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"public-subnet-1"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:            aws.String("public-subnet-1"),
+							AvailabilityZone:    aws.String("us-east-1b"),
+							MapPublicIpOnLaunch: aws.Bool(false),
+						}},
+					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
@@ -1333,42 +2742,22 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				// verify that the ImageLookupOrg is used when finding AMIs
 				m.
-					DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
-						Filters: []*ec2.Filter{
-							{
-								Name:   aws.String("owner-id"),
-								Values: []*string{aws.String("machine-level-image-lookup-org")},
-							},
-							{
-								Name:   aws.String("name"),
-								Values: []*string{aws.String(amiName)},
-							},
-							{
-								Name:   aws.String("architecture"),
-								Values: []*string{aws.String("x86_64")},
-							},
-							{
-								Name:   aws.String("state"),
-								Values: []*string{aws.String("available")},
-							},
-							{
-								Name:   aws.String("virtualization-type"),
-								Values: []*string{aws.String("hvm")},
-							},
-						},
-					})).
-					Return(&ec2.DescribeImagesOutput{
-						Images: []*ec2.Image{
-							{
-								Name:         aws.String("ami-1"),
-								CreationDate: aws.String("2006-01-02T15:04:05.000Z"),
-							},
-						},
-					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
+						if len(in.NetworkInterfaces) == 0 {
+							t.Fatalf("expected a NetworkInterface to be defined")
+						}
+						if !aws.BoolValue(in.NetworkInterfaces[0].AssociatePublicIpAddress) {
+							t.Fatalf("expected AssociatePublicIpAddress to be set and true")
+						}
+						if subnet := aws.StringValue(in.NetworkInterfaces[0].SubnetId); subnet != "public-subnet-1" {
+							t.Fatalf("expected subnet ID to be \"public-subnet-1\", got %q", subnet)
+						}
+						if in.NetworkInterfaces[0].Groups == nil {
+							t.Fatalf("expected security groups to be set")
+						}
+					}).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -1380,7 +2769,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("subnet-1"),
+								SubnetId:       aws.String("public-subnet-1"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -1411,7 +2800,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "subnet filter and failureDomain defined",
+			name: "public IP false and public subnet ID given with MapPublicIpOnLaunch true",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1420,7 +2809,6 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					FailureDomain: aws.String("us-east-1b"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
@@ -1429,11 +2817,9 @@ func TestCreateInstance(t *testing.T) {
 				},
 				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					Filters: []infrav1.Filter{{
-						Name:   "tag:some-tag",
-						Values: []string{"some-value"},
-					}},
+					ID: aws.String("public-subnet-1"),
 				},
+				PublicIP: aws.Bool(false),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -1442,6 +2828,10 @@ func TestCreateInstance(t *testing.T) {
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
 						},
+						Subnets: infrav1.Subnets{{
+							ID:       "public-subnet-1",
+							IsPublic: true,
+						}},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -1464,6 +2854,20 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"public-subnet-1"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:            aws.String("public-subnet-1"),
+							AvailabilityZone:    aws.String("us-east-1b"),
+							MapPublicIpOnLaunch: aws.Bool(true),
+						}},
+					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
@@ -1481,21 +2885,22 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("tag:some-tag"), Values: aws.StringSlice([]string{"some-value"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId:         aws.String("filtered-subnet-1"),
-							AvailabilityZone: aws.String("us-east-1b"),
-						}},
-					}, nil)
 				m.
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
+						if len(in.NetworkInterfaces) == 0 {
+							t.Fatalf("expected a NetworkInterface to be defined")
+						}
+						if v := in.NetworkInterfaces[0].AssociatePublicIpAddress; v == nil || *v {
+							t.Fatalf("expected AssociatePublicIpAddress to be set and false")
+						}
+						if subnet := aws.StringValue(in.NetworkInterfaces[0].SubnetId); subnet != "public-subnet-1" {
+							t.Fatalf("expected subnet ID to be \"public-subnet-1\", got %q", subnet)
+						}
+						if in.NetworkInterfaces[0].Groups == nil {
+							t.Fatalf("expected security groups to be set")
+						}
+					}).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -1507,7 +2912,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("subnet-1"),
+								SubnetId:       aws.String("public-subnet-1"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -1538,7 +2943,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "with subnet ID that belongs to Cluster",
+			name: "public IP true and private subnet ID given",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1555,8 +2960,9 @@ func TestCreateInstance(t *testing.T) {
 				},
 				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("matching-subnet"),
+					ID: aws.String("private-subnet-1"),
 				},
+				PublicIP: aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -1566,7 +2972,8 @@ func TestCreateInstance(t *testing.T) {
 							ID: "vpc-id",
 						},
 						Subnets: infrav1.Subnets{{
-							ID: "matching-subnet",
+							ID:       "private-subnet-1",
+							IsPublic: false,
 						}},
 					},
 				},
@@ -1594,13 +3001,14 @@ func TestCreateInstance(t *testing.T) {
 					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
 						Filters: []*ec2.Filter{
 							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"matching-subnet"})},
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"private-subnet-1"})},
 						},
 					}).
 					Return(&ec2.DescribeSubnetsOutput{
 						Subnets: []*ec2.Subnet{{
-							SubnetId:         aws.String("matching-subnet"),
-							AvailabilityZone: aws.String("us-east-1b"),
+							SubnetId:            aws.String("private-subnet-1"),
+							AvailabilityZone:    aws.String("us-east-1b"),
+							MapPublicIpOnLaunch: aws.Bool(false),
 						}},
 					}, nil)
 				m.
@@ -1620,51 +3028,20 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.Reservation{
-						Instances: []*ec2.Instance{
-							{
-								State: &ec2.InstanceState{
-									Name: aws.String(ec2.InstanceStateNamePending),
-								},
-								IamInstanceProfile: &ec2.IamInstanceProfile{
-									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-								},
-								InstanceId:     aws.String("two"),
-								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("matching-subnet"),
-								ImageId:        aws.String("ami-1"),
-								RootDeviceName: aws.String("device-1"),
-								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-									{
-										DeviceName: aws.String("device-1"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-1"),
-										},
-									},
-								},
-								Placement: &ec2.Placement{
-									AvailabilityZone: &az,
-								},
-							},
-						},
-					}, nil)
-				m.
-					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.DescribeNetworkInterfacesOutput{
-						NetworkInterfaces: []*ec2.NetworkInterface{},
-						NextToken:         nil,
-					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				if err != nil {
-					t.Fatalf("did not expect error: %v", err)
+				expectedErrMsg := "failed to run machine \"aws-test1\", found 1 subnets matching criteria but post-filtering failed. subnet \"private-subnet-1\" is a private subnet."
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
 				}
 			},
 		},
 		{
-			name: "with subnet ID that does not exist",
+			name: "both public IP and subnet filter defined",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1681,8 +3058,12 @@ func TestCreateInstance(t *testing.T) {
 				},
 				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("non-matching-subnet"),
+					Filters: []infrav1.Filter{{
+						Name:   "tag:some-tag",
+						Values: []string{"some-value"},
+					}},
 				},
+				PublicIP: aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -1691,9 +3072,16 @@ func TestCreateInstance(t *testing.T) {
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
 						},
-						Subnets: infrav1.Subnets{{
-							ID: "subnet-1",
-						}},
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "private-subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								ID:       "public-subnet-1",
+								IsPublic: true,
+							},
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -1737,26 +3125,60 @@ func TestCreateInstance(t *testing.T) {
 					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
 						Filters: []*ec2.Filter{
 							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"non-matching-subnet"})},
+							{Name: aws.String("tag:some-tag"), Values: aws.StringSlice([]string{"some-value"})},
 						},
 					}).
 					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{},
+						Subnets: []*ec2.Subnet{{
+							SubnetId:            aws.String("public-subnet-1"),
+							MapPublicIpOnLaunch: aws.Bool(true),
+						}},
+					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("public-subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
 					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				expectedErrMsg := "failed to run machine \"aws-test1\", no subnets available matching criteria"
-				if err == nil {
-					t.Fatalf("Expected error, but got nil")
-				}
-
-				if !strings.Contains(err.Error(), expectedErrMsg) {
-					t.Fatalf("Expected error: %s\nInstead got: %s", expectedErrMsg, err.Error())
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
 				}
 			},
 		},
 		{
-			name: "with subnet ID that does not belong to Cluster",
+			name: "both public IP, subnet filter defined and MapPublicIpOnLaunch is false",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1773,8 +3195,12 @@ func TestCreateInstance(t *testing.T) {
 				},
 				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("matching-subnet"),
+					Filters: []infrav1.Filter{{
+						Name:   "tag:some-tag",
+						Values: []string{"some-value"},
+					}},
 				},
+				PublicIP: aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -1783,9 +3209,16 @@ func TestCreateInstance(t *testing.T) {
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
 						},
-						Subnets: infrav1.Subnets{{
-							ID: "subnet-1",
-						}},
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "private-subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								ID:       "public-subnet-1",
+								IsPublic: true,
+							},
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -1808,8 +3241,52 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("tag:some-tag"), Values: aws.StringSlice([]string{"some-value"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:            aws.String("public-subnet-1"),
+							MapPublicIpOnLaunch: aws.Bool(false),
+						}},
+					}, nil)
 				m.
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
+						if len(in.NetworkInterfaces) == 0 {
+							t.Fatalf("expected a NetworkInterface to be defined")
+						}
+						if !aws.BoolValue(in.NetworkInterfaces[0].AssociatePublicIpAddress) {
+							t.Fatalf("expected AssociatePublicIpAddress to be set and true")
+						}
+						if subnet := aws.StringValue(in.NetworkInterfaces[0].SubnetId); subnet != "public-subnet-1" {
+							t.Fatalf("expected subnet ID to be \"public-subnet-1\", got %q", subnet)
+						}
+						if in.NetworkInterfaces[0].Groups == nil {
+							t.Fatalf("expected security groups to be set")
+						}
+					}).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -1821,7 +3298,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("matching-subnet"),
+								SubnetId:       aws.String("public-subnet-1"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -1838,35 +3315,6 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.
-					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
-						InstanceTypes: []*string{
-							aws.String("m5.large"),
-						},
-					})).
-					Return(&ec2.DescribeInstanceTypesOutput{
-						InstanceTypes: []*ec2.InstanceTypeInfo{
-							{
-								ProcessorInfo: &ec2.ProcessorInfo{
-									SupportedArchitectures: []*string{
-										aws.String("x86_64"),
-									},
-								},
-							},
-						},
-					}, nil)
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"matching-subnet"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId: aws.String("matching-subnet"),
-						}},
-					}, nil)
 				m.
 					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeNetworkInterfacesOutput{
@@ -1881,7 +3329,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "subnet id and failureDomain don't match",
+			name: "public IP true and public subnet exists",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1890,7 +3338,6 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					FailureDomain: aws.String("us-east-1b"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
@@ -1898,9 +3345,7 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("subnet-1"),
-				},
+				PublicIP:     aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -1909,10 +3354,16 @@ func TestCreateInstance(t *testing.T) {
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
 						},
-						Subnets: infrav1.Subnets{{
-							ID:               "subnet-1",
-							AvailabilityZone: "us-west-1b",
-						}},
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "private-subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								ID:       "public-subnet-1",
+								IsPublic: true,
+							},
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -1935,19 +3386,6 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"subnet-1"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId:         aws.String("subnet-1"),
-							AvailabilityZone: aws.String("us-west-1b"),
-						}},
-					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
@@ -1965,20 +3403,51 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("public-subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				expectedErrMsg := "failed to run machine \"aws-test1\", found 1 subnets matching criteria but post-filtering failed. subnet \"subnet-1\" availability zone \"us-west-1b\" does not match failure domain \"us-east-1b\""
-				if err == nil {
-					t.Fatalf("Expected error, but got nil")
-				}
-
-				if !strings.Contains(err.Error(), expectedErrMsg) {
-					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
 				}
 			},
 		},
 		{
-			name: "public IP true and failureDomain doesn't have public subnet",
+			name: "public IP true, public subnet exists and MapPublicIpOnLaunch is false",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1987,7 +3456,6 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					FailureDomain: aws.String("us-east-1b"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
@@ -2004,11 +3472,16 @@ func TestCreateInstance(t *testing.T) {
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
 						},
-						Subnets: infrav1.Subnets{{
-							ID:               "private-subnet-1",
-							AvailabilityZone: "us-east-1b",
-							IsPublic:         false,
-						}},
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "private-subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								ID:       "public-subnet-1",
+								IsPublic: true,
+							},
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -2048,20 +3521,65 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
+						if len(in.NetworkInterfaces) == 0 {
+							t.Fatalf("expected a NetworkInterface to be defined")
+						}
+						if !aws.BoolValue(in.NetworkInterfaces[0].AssociatePublicIpAddress) {
+							t.Fatalf("expected AssociatePublicIpAddress to be set and true")
+						}
+						if subnet := aws.StringValue(in.NetworkInterfaces[0].SubnetId); subnet != "public-subnet-1" {
+							t.Fatalf("expected subnet ID to be \"public-subnet-1\", got %q", subnet)
+						}
+						if in.NetworkInterfaces[0].Groups == nil {
+							t.Fatalf("expected security groups to be set")
+						}
+					}).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("public-subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				expectedErrMsg := "failed to run machine \"aws-test1\" with public IP, no public subnets available in availability zone \"us-east-1b\""
-				if err == nil {
-					t.Fatalf("Expected error, but got nil")
-				}
-
-				if !strings.Contains(err.Error(), expectedErrMsg) {
-					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
 				}
 			},
 		},
 		{
-			name: "public IP true and public subnet ID given",
+			name: "public IP true and no public subnet exists",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2077,10 +3595,7 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("public-subnet-1"),
-				},
-				PublicIP: aws.Bool(true),
+				PublicIP:     aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -2089,10 +3604,12 @@ func TestCreateInstance(t *testing.T) {
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
 						},
-						Subnets: infrav1.Subnets{{
-							ID:       "public-subnet-1",
-							IsPublic: true,
-						}},
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "private-subnet-1",
+								IsPublic: false,
+							},
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -2115,20 +3632,6 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"public-subnet-1"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId:            aws.String("public-subnet-1"),
-							AvailabilityZone:    aws.String("us-east-1b"),
-							MapPublicIpOnLaunch: aws.Bool(true),
-						}},
-					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
@@ -2143,54 +3646,23 @@ func TestCreateInstance(t *testing.T) {
 										aws.String("x86_64"),
 									},
 								},
-							},
-						},
-					}, nil)
-				m.
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.Reservation{
-						Instances: []*ec2.Instance{
-							{
-								State: &ec2.InstanceState{
-									Name: aws.String(ec2.InstanceStateNamePending),
-								},
-								IamInstanceProfile: &ec2.IamInstanceProfile{
-									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-								},
-								InstanceId:     aws.String("two"),
-								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("public-subnet-1"),
-								ImageId:        aws.String("ami-1"),
-								RootDeviceName: aws.String("device-1"),
-								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-									{
-										DeviceName: aws.String("device-1"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-1"),
-										},
-									},
-								},
-								Placement: &ec2.Placement{
-									AvailabilityZone: &az,
-								},
-							},
-						},
-					}, nil)
-				m.
-					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.DescribeNetworkInterfacesOutput{
-						NetworkInterfaces: []*ec2.NetworkInterface{},
-						NextToken:         nil,
+							},
+						},
 					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				if err != nil {
-					t.Fatalf("did not expect error: %v", err)
+				expectedErrMsg := "failed to run machine \"aws-test1\" with public IP, no public subnets available"
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: %s", expectedErrMsg, err.Error())
 				}
 			},
 		},
 		{
-			name: "public IP true, public subnet ID given and MapPublicIpOnLaunch is false",
+			name: "with multiple block device mappings",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2206,22 +3678,27 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("public-subnet-1"),
-				},
-				PublicIP: aws.Bool(true),
+				NonRootVolumes: []infrav1.Volume{{
+					DeviceName: "device-2",
+					Size:       8,
+				}},
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
 						VPC: infrav1.VPCSpec{
-							ID: "vpc-id",
+							ID: "vpc-test",
 						},
-						Subnets: infrav1.Subnets{{
-							ID:       "public-subnet-1",
-							IsPublic: true,
-						}},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -2244,20 +3721,6 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"public-subnet-1"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId:            aws.String("public-subnet-1"),
-							AvailabilityZone:    aws.String("us-east-1b"),
-							MapPublicIpOnLaunch: aws.Bool(false),
-						}},
-					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
@@ -2275,22 +3738,8 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.
+				m. // TODO: Restore these parameters, but with the tags as well
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
-						if len(in.NetworkInterfaces) == 0 {
-							t.Fatalf("expected a NetworkInterface to be defined")
-						}
-						if !aws.BoolValue(in.NetworkInterfaces[0].AssociatePublicIpAddress) {
-							t.Fatalf("expected AssociatePublicIpAddress to be set and true")
-						}
-						if subnet := aws.StringValue(in.NetworkInterfaces[0].SubnetId); subnet != "public-subnet-1" {
-							t.Fatalf("expected subnet ID to be \"public-subnet-1\", got %q", subnet)
-						}
-						if in.NetworkInterfaces[0].Groups == nil {
-							t.Fatalf("expected security groups to be set")
-						}
-					}).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -2302,7 +3751,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("public-subnet-1"),
+								SubnetId:       aws.String("subnet-1"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -2312,6 +3761,12 @@ func TestCreateInstance(t *testing.T) {
 											VolumeId: aws.String("volume-1"),
 										},
 									},
+									{
+										DeviceName: aws.String("device-2"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-2"),
+										},
+									},
 								},
 								Placement: &ec2.Placement{
 									AvailabilityZone: &az,
@@ -2333,7 +3788,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "public IP true and private subnet ID given",
+			name: "with bottlerocket user data, the root volume is applied to the data volume",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2349,22 +3804,23 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("private-subnet-1"),
+				RootVolume: &infrav1.Volume{
+					Size: 50,
 				},
-				PublicIP: aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+						},
 						VPC: infrav1.VPCSpec{
-							ID: "vpc-id",
+							ID: "vpc-test",
 						},
-						Subnets: infrav1.Subnets{{
-							ID:       "private-subnet-1",
-							IsPublic: false,
-						}},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -2386,27 +3842,10 @@ func TestCreateInstance(t *testing.T) {
 					},
 				},
 			},
+			userDataFormat: "bottlerocket",
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
 				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"private-subnet-1"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId:            aws.String("private-subnet-1"),
-							AvailabilityZone:    aws.String("us-east-1b"),
-							MapPublicIpOnLaunch: aws.Bool(false),
-						}},
-					}, nil)
-				m.
-					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
-						InstanceTypes: []*string{
-							aws.String("m5.large"),
-						},
-					})).
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeInstanceTypesOutput{
 						InstanceTypes: []*ec2.InstanceTypeInfo{
 							{
@@ -2418,20 +3857,53 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, input *ec2.RunInstancesInput, requestOptions ...request.Option) (*ec2.Reservation, error) {
+						if len(input.BlockDeviceMappings) != 1 {
+							t.Fatalf("Expected a single block device mapping, got %d", len(input.BlockDeviceMappings))
+						}
+						if aws.StringValue(input.BlockDeviceMappings[0].DeviceName) != "/dev/xvdb" {
+							t.Fatalf("Expected the block device mapping of the data volume, got %q", aws.StringValue(input.BlockDeviceMappings[0].DeviceName))
+						}
+						if aws.Int64Value(input.BlockDeviceMappings[0].Ebs.VolumeSize) != 50 {
+							t.Fatalf("Expected a data volume of 50GiB, got %d", aws.Int64Value(input.BlockDeviceMappings[0].Ebs.VolumeSize))
+						}
+						if aws.StringValue(input.UserData) != base64.StdEncoding.EncodeToString(data) {
+							t.Fatalf("Expected the user data to be passed as is")
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-1"),
+									ImageId:      aws.String("abc"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				expectedErrMsg := "failed to run machine \"aws-test1\", found 1 subnets matching criteria but post-filtering failed. subnet \"private-subnet-1\" is a private subnet."
-				if err == nil {
-					t.Fatalf("Expected error, but got nil")
-				}
-
-				if !strings.Contains(err.Error(), expectedErrMsg) {
-					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
 				}
 			},
 		},
 		{
-			name: "both public IP and subnet filter defined",
+			name: "with additional security groups selected by tags",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2447,30 +3919,22 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				Subnet: &infrav1.AWSResourceReference{
-					Filters: []infrav1.Filter{{
-						Name:   "tag:some-tag",
-						Values: []string{"some-value"},
-					}},
+				AdditionalSecurityGroupSelectors: []infrav1.SecurityGroupSelector{
+					{Tags: map[string]string{"app": "monitoring"}},
 				},
-				PublicIP: aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						VPC: infrav1.VPCSpec{
-							ID: "vpc-id",
-						},
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
-								ID:       "private-subnet-1",
+								ID:       "subnet-1",
 								IsPublic: false,
 							},
-							infrav1.SubnetSpec{
-								ID:       "public-subnet-1",
-								IsPublic: true,
-							},
+						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
 						},
 					},
 				},
@@ -2495,11 +3959,17 @@ func TestCreateInstance(t *testing.T) {
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
 				m.
-					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
-						InstanceTypes: []*string{
-							aws.String("m5.large"),
+					DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSecurityGroupsInput{
+						Filters: []*ec2.Filter{
+							{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-test"})},
+							{Name: aws.String("tag:app"), Values: aws.StringSlice([]string{"monitoring"})},
 						},
 					})).
+					Return(&ec2.DescribeSecurityGroupsOutput{
+						SecurityGroups: []*ec2.SecurityGroup{{GroupId: aws.String("sg-monitoring")}},
+					}, nil)
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeInstanceTypesOutput{
 						InstanceTypes: []*ec2.InstanceTypeInfo{
 							{
@@ -2511,49 +3981,29 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("tag:some-tag"), Values: aws.StringSlice([]string{"some-value"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId:            aws.String("public-subnet-1"),
-							MapPublicIpOnLaunch: aws.Bool(true),
-						}},
-					}, nil)
 				m.
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.Reservation{
-						Instances: []*ec2.Instance{
-							{
-								State: &ec2.InstanceState{
-									Name: aws.String(ec2.InstanceStateNamePending),
-								},
-								IamInstanceProfile: &ec2.IamInstanceProfile{
-									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-								},
-								InstanceId:     aws.String("two"),
-								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("public-subnet-1"),
-								ImageId:        aws.String("ami-1"),
-								RootDeviceName: aws.String("device-1"),
-								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-									{
-										DeviceName: aws.String("device-1"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-1"),
-										},
+					DoAndReturn(func(ctx context.Context, input *ec2.RunInstancesInput, requestOptions ...request.Option) (*ec2.Reservation, error) {
+						if diff := cmp.Diff([]string{"2", "3", "sg-monitoring"}, aws.StringValueSlice(input.SecurityGroupIds)); diff != "" {
+							t.Fatalf("Expected the selected security group to be launched with the instance, diff: %s", diff)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-1"),
+									ImageId:      aws.String("abc"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
 									},
-								},
-								Placement: &ec2.Placement{
-									AvailabilityZone: &az,
 								},
 							},
-						},
-					}, nil)
+						}, nil
+					})
 				m.
 					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeNetworkInterfacesOutput{
@@ -2568,10 +4018,12 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "both public IP, subnet filter defined and MapPublicIpOnLaunch is false",
+			name: "with dedicated tenancy cloud-config",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"set": "node"},
+					Labels:    map[string]string{"set": "node"},
+					Namespace: "default",
+					Name:      "machine-aws-test1",
 				},
 				Spec: clusterv1.MachineSpec{
 					Bootstrap: clusterv1.Bootstrap{
@@ -2583,32 +4035,25 @@ func TestCreateInstance(t *testing.T) {
 				AMI: infrav1.AMIReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType: "m5.large",
-				Subnet: &infrav1.AWSResourceReference{
-					Filters: []infrav1.Filter{{
-						Name:   "tag:some-tag",
-						Values: []string{"some-value"},
-					}},
-				},
-				PublicIP: aws.Bool(true),
+				InstanceType:         "m5.large",
+				Tenancy:              "dedicated",
+				UncompressedUserData: &isUncompressedFalse,
 			},
 			awsCluster: &infrav1.AWSCluster{
-				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						VPC: infrav1.VPCSpec{
-							ID: "vpc-id",
-						},
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
-								ID:       "private-subnet-1",
+								ID:       "subnet-1",
 								IsPublic: false,
 							},
 							infrav1.SubnetSpec{
-								ID:       "public-subnet-1",
-								IsPublic: true,
+								IsPublic: false,
 							},
 						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -2631,52 +4076,47 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.
-					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
-						InstanceTypes: []*string{
-							aws.String("m5.large"),
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstancesWithContext(context.TODO(), gomock.Eq(&ec2.RunInstancesInput{
+						ImageId:      aws.String("abc"),
+						InstanceType: aws.String("m5.large"),
+						KeyName:      aws.String("default"),
+						MaxCount:     aws.Int64(1),
+						MinCount:     aws.Int64(1),
+						Placement: &ec2.Placement{
+							Tenancy: &tenancy,
 						},
-					})).
-					Return(&ec2.DescribeInstanceTypesOutput{
-						InstanceTypes: []*ec2.InstanceTypeInfo{
+						SecurityGroupIds: []*string{aws.String("2"), aws.String("3")},
+						SubnetId:         aws.String("subnet-1"),
+						TagSpecifications: []*ec2.TagSpecification{
 							{
-								ProcessorInfo: &ec2.ProcessorInfo{
-									SupportedArchitectures: []*string{
-										aws.String("x86_64"),
+								ResourceType: aws.String("instance"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("default/machine-aws-test1"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("node"),
 									},
 								},
 							},
 						},
-					}, nil)
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("tag:some-tag"), Values: aws.StringSlice([]string{"some-value"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId:            aws.String("public-subnet-1"),
-							MapPublicIpOnLaunch: aws.Bool(false),
-						}},
-					}, nil)
-				m.
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
-						if len(in.NetworkInterfaces) == 0 {
-							t.Fatalf("expected a NetworkInterface to be defined")
-						}
-						if !aws.BoolValue(in.NetworkInterfaces[0].AssociatePublicIpAddress) {
-							t.Fatalf("expected AssociatePublicIpAddress to be set and true")
-						}
-						if subnet := aws.StringValue(in.NetworkInterfaces[0].SubnetId); subnet != "public-subnet-1" {
-							t.Fatalf("expected subnet ID to be \"public-subnet-1\", got %q", subnet)
-						}
-						if in.NetworkInterfaces[0].Groups == nil {
-							t.Fatalf("expected security groups to be set")
-						}
-					}).
+						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
+					})).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -2688,7 +4128,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("public-subnet-1"),
+								SubnetId:       aws.String("subnet-1"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -2701,6 +4141,24 @@ func TestCreateInstance(t *testing.T) {
 								},
 								Placement: &ec2.Placement{
 									AvailabilityZone: &az,
+									Tenancy:          &tenancy,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
 								},
 							},
 						},
@@ -2719,10 +4177,12 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "public IP true and public subnet exists",
+			name: "with nitro enclaves enabled",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"set": "node"},
+					Labels:    map[string]string{"set": "node"},
+					Namespace: "default",
+					Name:      "machine-aws-test1",
 				},
 				Spec: clusterv1.MachineSpec{
 					Bootstrap: clusterv1.Bootstrap{
@@ -2734,26 +4194,25 @@ func TestCreateInstance(t *testing.T) {
 				AMI: infrav1.AMIReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType: "m5.large",
-				PublicIP:     aws.Bool(true),
+				InstanceType:         "m5.large",
+				EnclaveOptions:       &infrav1.EnclaveOptions{Enabled: true},
+				UncompressedUserData: &isUncompressedFalse,
 			},
 			awsCluster: &infrav1.AWSCluster{
-				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						VPC: infrav1.VPCSpec{
-							ID: "vpc-id",
-						},
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
-								ID:       "private-subnet-1",
+								ID:       "subnet-1",
 								IsPublic: false,
 							},
 							infrav1.SubnetSpec{
-								ID:       "public-subnet-1",
-								IsPublic: true,
+								IsPublic: false,
 							},
 						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -2776,25 +4235,47 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.
-					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
-						InstanceTypes: []*string{
-							aws.String("m5.large"),
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstancesWithContext(context.TODO(), gomock.Eq(&ec2.RunInstancesInput{
+						ImageId:      aws.String("abc"),
+						InstanceType: aws.String("m5.large"),
+						KeyName:      aws.String("default"),
+						MaxCount:     aws.Int64(1),
+						MinCount:     aws.Int64(1),
+						EnclaveOptions: &ec2.EnclaveOptionsRequest{
+							Enabled: aws.Bool(true),
 						},
-					})).
-					Return(&ec2.DescribeInstanceTypesOutput{
-						InstanceTypes: []*ec2.InstanceTypeInfo{
+						SecurityGroupIds: []*string{aws.String("2"), aws.String("3")},
+						SubnetId:         aws.String("subnet-1"),
+						TagSpecifications: []*ec2.TagSpecification{
 							{
-								ProcessorInfo: &ec2.ProcessorInfo{
-									SupportedArchitectures: []*string{
-										aws.String("x86_64"),
+								ResourceType: aws.String("instance"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("default/machine-aws-test1"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("node"),
 									},
 								},
 							},
 						},
-					}, nil)
-				m.
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
+						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
+					})).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -2806,7 +4287,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("public-subnet-1"),
+								SubnetId:       aws.String("subnet-1"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -2823,6 +4304,23 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
 				m.
 					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeNetworkInterfacesOutput{
@@ -2837,10 +4335,12 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "public IP true, public subnet exists and MapPublicIpOnLaunch is false",
+			name: "with hibernation configured",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"set": "node"},
+					Labels:    map[string]string{"set": "node"},
+					Namespace: "default",
+					Name:      "machine-aws-test1",
 				},
 				Spec: clusterv1.MachineSpec{
 					Bootstrap: clusterv1.Bootstrap{
@@ -2852,26 +4352,25 @@ func TestCreateInstance(t *testing.T) {
 				AMI: infrav1.AMIReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType: "m5.large",
-				PublicIP:     aws.Bool(true),
+				InstanceType:         "m5.large",
+				HibernationOptions:   &infrav1.HibernationOptions{Configured: true},
+				UncompressedUserData: &isUncompressedFalse,
 			},
 			awsCluster: &infrav1.AWSCluster{
-				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						VPC: infrav1.VPCSpec{
-							ID: "vpc-id",
-						},
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
-								ID:       "private-subnet-1",
+								ID:       "subnet-1",
 								IsPublic: false,
 							},
 							infrav1.SubnetSpec{
-								ID:       "public-subnet-1",
-								IsPublic: true,
+								IsPublic: false,
 							},
 						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -2894,39 +4393,47 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.
-					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
-						InstanceTypes: []*string{
-							aws.String("m5.large"),
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstancesWithContext(context.TODO(), gomock.Eq(&ec2.RunInstancesInput{
+						ImageId:      aws.String("abc"),
+						InstanceType: aws.String("m5.large"),
+						KeyName:      aws.String("default"),
+						MaxCount:     aws.Int64(1),
+						MinCount:     aws.Int64(1),
+						HibernationOptions: &ec2.HibernationOptionsRequest{
+							Configured: aws.Bool(true),
 						},
-					})).
-					Return(&ec2.DescribeInstanceTypesOutput{
-						InstanceTypes: []*ec2.InstanceTypeInfo{
+						SecurityGroupIds: []*string{aws.String("2"), aws.String("3")},
+						SubnetId:         aws.String("subnet-1"),
+						TagSpecifications: []*ec2.TagSpecification{
 							{
-								ProcessorInfo: &ec2.ProcessorInfo{
-									SupportedArchitectures: []*string{
-										aws.String("x86_64"),
+								ResourceType: aws.String("instance"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("default/machine-aws-test1"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("node"),
 									},
 								},
 							},
 						},
-					}, nil)
-				m.
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
-						if len(in.NetworkInterfaces) == 0 {
-							t.Fatalf("expected a NetworkInterface to be defined")
-						}
-						if !aws.BoolValue(in.NetworkInterfaces[0].AssociatePublicIpAddress) {
-							t.Fatalf("expected AssociatePublicIpAddress to be set and true")
-						}
-						if subnet := aws.StringValue(in.NetworkInterfaces[0].SubnetId); subnet != "public-subnet-1" {
-							t.Fatalf("expected subnet ID to be \"public-subnet-1\", got %q", subnet)
-						}
-						if in.NetworkInterfaces[0].Groups == nil {
-							t.Fatalf("expected security groups to be set")
-						}
-					}).
+						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
+					})).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -2938,7 +4445,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("public-subnet-1"),
+								SubnetId:       aws.String("subnet-1"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -2955,6 +4462,23 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
 				m.
 					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeNetworkInterfacesOutput{
@@ -2969,10 +4493,12 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "public IP true and no public subnet exists",
+			name: "control plane machine with termination protection by default",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"set": "node"},
+					Labels:    map[string]string{"set": "node", clusterv1.MachineControlPlaneLabel: ""},
+					Namespace: "default",
+					Name:      "machine-aws-test1",
 				},
 				Spec: clusterv1.MachineSpec{
 					Bootstrap: clusterv1.Bootstrap{
@@ -2984,22 +4510,24 @@ func TestCreateInstance(t *testing.T) {
 				AMI: infrav1.AMIReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType: "m5.large",
-				PublicIP:     aws.Bool(true),
+				InstanceType:         "m5.large",
+				UncompressedUserData: &isUncompressedFalse,
 			},
 			awsCluster: &infrav1.AWSCluster{
-				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						VPC: infrav1.VPCSpec{
-							ID: "vpc-id",
-						},
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
-								ID:       "private-subnet-1",
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
 								IsPublic: false,
 							},
 						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -3022,6 +4550,74 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstancesWithContext(context.TODO(), gomock.Eq(&ec2.RunInstancesInput{
+						ImageId:               aws.String("abc"),
+						InstanceType:          aws.String("m5.large"),
+						KeyName:               aws.String("default"),
+						MaxCount:              aws.Int64(1),
+						MinCount:              aws.Int64(1),
+						DisableApiTermination: aws.Bool(true),
+						DisableApiStop:        aws.Bool(true),
+						SecurityGroupIds:      []*string{aws.String("2"), aws.String("3"), aws.String("1")},
+						SubnetId:              aws.String("subnet-1"),
+						TagSpecifications: []*ec2.TagSpecification{
+							{
+								ResourceType: aws.String("instance"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("default/machine-aws-test1"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("control-plane"),
+									},
+								},
+							},
+						},
+						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
+					})).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
@@ -3039,23 +4635,26 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				expectedErrMsg := "failed to run machine \"aws-test1\" with public IP, no public subnets available"
-				if err == nil {
-					t.Fatalf("Expected error, but got nil")
-				}
-
-				if !strings.Contains(err.Error(), expectedErrMsg) {
-					t.Fatalf("Expected error: %s\nInstead got: %s", expectedErrMsg, err.Error())
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
 				}
 			},
 		},
 		{
-			name: "with multiple block device mappings",
+			name: "with an ami matching the filters",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"set": "node"},
+					Labels:    map[string]string{"set": "node"},
+					Namespace: "default",
+					Name:      "machine-aws-test1",
 				},
 				Spec: clusterv1.MachineSpec{
 					Bootstrap: clusterv1.Bootstrap{
@@ -3065,16 +4664,17 @@ func TestCreateInstance(t *testing.T) {
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
 				AMI: infrav1.AMIReference{
-					ID: aws.String("abc"),
+					Filters: []infrav1.Filter{
+						{
+							Name:   "name",
+							Values: []string{"Deep Learning Base OSS Nvidia Driver GPU AMI*"},
+						},
+					},
 				},
-				InstanceType: "m5.large",
-				NonRootVolumes: []infrav1.Volume{{
-					DeviceName: "device-2",
-					Size:       8,
-				}},
+				InstanceType:         "m5.large",
+				UncompressedUserData: &isUncompressedFalse,
 			},
 			awsCluster: &infrav1.AWSCluster{
-				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
@@ -3112,24 +4712,53 @@ func TestCreateInstance(t *testing.T) {
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
 				m.
-					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
-						InstanceTypes: []*string{
-							aws.String("m5.large"),
+					DescribeImagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								ImageId:      aws.String("gpu-ami"),
+								CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+							},
 						},
-					})).
-					Return(&ec2.DescribeInstanceTypesOutput{
-						InstanceTypes: []*ec2.InstanceTypeInfo{
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstancesWithContext(context.TODO(), gomock.Eq(&ec2.RunInstancesInput{
+						ImageId:          aws.String("gpu-ami"),
+						InstanceType:     aws.String("m5.large"),
+						KeyName:          aws.String("default"),
+						MaxCount:         aws.Int64(1),
+						MinCount:         aws.Int64(1),
+						SecurityGroupIds: []*string{aws.String("2"), aws.String("3")},
+						SubnetId:         aws.String("subnet-1"),
+						TagSpecifications: []*ec2.TagSpecification{
 							{
-								ProcessorInfo: &ec2.ProcessorInfo{
-									SupportedArchitectures: []*string{
-										aws.String("x86_64"),
+								ResourceType: aws.String("instance"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("default/machine-aws-test1"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("node"),
 									},
 								},
 							},
 						},
-					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
+						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
+					})).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -3151,12 +4780,6 @@ func TestCreateInstance(t *testing.T) {
 											VolumeId: aws.String("volume-1"),
 										},
 									},
-									{
-										DeviceName: aws.String("device-2"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-2"),
-										},
-									},
 								},
 								Placement: &ec2.Placement{
 									AvailabilityZone: &az,
@@ -3164,6 +4787,23 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
 				m.
 					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeNetworkInterfacesOutput{
@@ -3178,7 +4818,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "with dedicated tenancy cloud-config",
+			name: "with dedicated host cloud-config",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:    map[string]string{"set": "node"},
@@ -3196,7 +4836,8 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType:         "m5.large",
-				Tenancy:              "dedicated",
+				Tenancy:              "host",
+				HostID:               aws.String("h-0123456789abcdef0"),
 				UncompressedUserData: &isUncompressedFalse,
 			},
 			awsCluster: &infrav1.AWSCluster{
@@ -3244,7 +4885,8 @@ func TestCreateInstance(t *testing.T) {
 						MaxCount:     aws.Int64(1),
 						MinCount:     aws.Int64(1),
 						Placement: &ec2.Placement{
-							Tenancy: &tenancy,
+							Tenancy: aws.String("host"),
+							HostId:  aws.String("h-0123456789abcdef0"),
 						},
 						SecurityGroupIds: []*string{aws.String("2"), aws.String("3")},
 						SubnetId:         aws.String("subnet-1"),
@@ -3301,7 +4943,8 @@ func TestCreateInstance(t *testing.T) {
 								},
 								Placement: &ec2.Placement{
 									AvailabilityZone: &az,
-									Tenancy:          &tenancy,
+									Tenancy:          aws.String("host"),
+									HostId:           aws.String("h-0123456789abcdef0"),
 								},
 							},
 						},
@@ -4928,7 +6571,7 @@ func TestCreateInstance(t *testing.T) {
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
 
-			instance, err := s.CreateInstance(machineScope, data, "")
+			instance, err := s.CreateInstance(machineScope, data, tc.userDataFormat)
 			tc.check(instance, err)
 		})
 	}
@@ -4983,6 +6626,47 @@ func TestGetInstanceMarketOptionsRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with terminate InterruptionBehavior specified",
+			spotMarketOptions: &infrav1.SpotMarketOptions{
+				InterruptionBehavior: infrav1.InstanceInterruptionBehaviorTerminate,
+			},
+			expectedRequest: &ec2.InstanceMarketOptionsRequest{
+				MarketType: aws.String(ec2.MarketTypeSpot),
+				SpotOptions: &ec2.SpotMarketOptions{
+					InstanceInterruptionBehavior: aws.String(ec2.InstanceInterruptionBehaviorTerminate),
+					SpotInstanceType:             aws.String(ec2.SpotInstanceTypeOneTime),
+				},
+			},
+		},
+		{
+			name: "with stop InterruptionBehavior specified",
+			spotMarketOptions: &infrav1.SpotMarketOptions{
+				InterruptionBehavior: infrav1.InstanceInterruptionBehaviorStop,
+			},
+			expectedRequest: &ec2.InstanceMarketOptionsRequest{
+				MarketType: aws.String(ec2.MarketTypeSpot),
+				SpotOptions: &ec2.SpotMarketOptions{
+					InstanceInterruptionBehavior: aws.String(ec2.InstanceInterruptionBehaviorStop),
+					SpotInstanceType:             aws.String(ec2.SpotInstanceTypePersistent),
+				},
+			},
+		},
+		{
+			name: "with hibernate InterruptionBehavior and MaxPrice specified",
+			spotMarketOptions: &infrav1.SpotMarketOptions{
+				MaxPrice:             aws.String("0.01"),
+				InterruptionBehavior: infrav1.InstanceInterruptionBehaviorHibernate,
+			},
+			expectedRequest: &ec2.InstanceMarketOptionsRequest{
+				MarketType: aws.String(ec2.MarketTypeSpot),
+				SpotOptions: &ec2.SpotMarketOptions{
+					InstanceInterruptionBehavior: aws.String(ec2.InstanceInterruptionBehaviorHibernate),
+					SpotInstanceType:             aws.String(ec2.SpotInstanceTypePersistent),
+					MaxPrice:                     aws.String("0.01"),
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4995,6 +6679,51 @@ func TestGetInstanceMarketOptionsRequest(t *testing.T) {
 	}
 }
 
+func TestGetCapacityReservationSpecification(t *testing.T) {
+	testCases := []struct {
+		name            string
+		target          *infrav1.CapacityReservationTarget
+		expectedRequest *ec2.CapacityReservationSpecification
+	}{
+		{
+			name:            "with no Capacity Reservation target specified",
+			target:          nil,
+			expectedRequest: nil,
+		},
+		{
+			name: "with a Capacity Reservation ID specified",
+			target: &infrav1.CapacityReservationTarget{
+				ID: aws.String("cr-0123456789abcdef0"),
+			},
+			expectedRequest: &ec2.CapacityReservationSpecification{
+				CapacityReservationTarget: &ec2.CapacityReservationTarget{
+					CapacityReservationId: aws.String("cr-0123456789abcdef0"),
+				},
+			},
+		},
+		{
+			name: "with a Capacity Reservation resource group specified",
+			target: &infrav1.CapacityReservationTarget{
+				ResourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/my-reservations"),
+			},
+			expectedRequest: &ec2.CapacityReservationSpecification{
+				CapacityReservationTarget: &ec2.CapacityReservationTarget{
+					CapacityReservationResourceGroupArn: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/my-reservations"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getCapacityReservationSpecification(tc.target)
+			if !cmp.Equal(request, tc.expectedRequest) {
+				t.Errorf("Case: %s. Got: %v, expected: %v", tc.name, request, tc.expectedRequest)
+			}
+		})
+	}
+}
+
 func TestGetFilteredSecurityGroupID(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
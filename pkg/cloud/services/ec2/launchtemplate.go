@@ -27,6 +27,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
@@ -35,6 +36,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
@@ -68,6 +70,13 @@ func (s *Service) ReconcileLaunchTemplate(
 		record.Eventf(scope.GetMachinePool(), corev1.EventTypeWarning, "FailedGetBootstrapData", err.Error())
 		return err
 	}
+	if preBootstrapUserData := scope.GetLaunchTemplate().PreBootstrapUserData; preBootstrapUserData != "" {
+		bootstrapData, err = userdata.PrependUserData(preBootstrapUserData, bootstrapData)
+		if err != nil {
+			record.Eventf(scope.GetMachinePool(), corev1.EventTypeWarning, "FailedPrependUserData", err.Error())
+			return err
+		}
+	}
 	bootstrapDataHash := userdata.ComputeHash(bootstrapData)
 
 	scope.Info("checking for existing launch template")
@@ -158,8 +167,8 @@ func (s *Service) ReconcileLaunchTemplate(
 	if needsUpdate || tagsChanged || amiChanged || userDataHashChanged || userDataSecretKeyChanged || launchTemplateNeedsUserDataSecretKeyTag {
 		scope.Info("creating new version for launch template", "existing", launchTemplate, "incoming", scope.GetLaunchTemplate(), "needsUpdate", needsUpdate, "tagsChanged", tagsChanged, "amiChanged", amiChanged, "userDataHashChanged", userDataHashChanged, "userDataSecretKeyChanged", userDataSecretKeyChanged)
 		// There is a limit to the number of Launch Template Versions.
-		// We ensure that the number of versions does not grow without bound by following a simple rule: Before we create a new version, we delete one old version, if there is at least one old version that is not in use.
-		if err := ec2svc.PruneLaunchTemplateVersions(scope.GetLaunchTemplateIDStatus()); err != nil {
+		// We ensure that the number of versions does not grow without bound by following a simple rule: Before we create a new version, we delete the old versions beyond the ones to keep.
+		if err := ec2svc.PruneLaunchTemplateVersions(scope.GetLaunchTemplateIDStatus(), scope.GetLaunchTemplate().GetVersionsToKeep()); err != nil {
 			return err
 		}
 		if err := ec2svc.CreateLaunchTemplateVersion(scope.GetLaunchTemplateIDStatus(), scope, imageID, *bootstrapDataSecretKey, bootstrapData); err != nil {
@@ -472,10 +481,23 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 		sshKeyNamePtr = lt.SSHKeyName
 	}
 
+	if compressLaunchTemplateUserData(lt) {
+		var err error
+		userData, err = userdata.GzipBytes(userData)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to gzip userdata")
+		}
+	}
+
 	data := &ec2.RequestLaunchTemplateData{
-		InstanceType: aws.String(lt.InstanceType),
-		KeyName:      sshKeyNamePtr,
-		UserData:     ptr.To[string](base64.StdEncoding.EncodeToString(userData)),
+		KeyName:              sshKeyNamePtr,
+		UserData:             ptr.To[string](base64.StdEncoding.EncodeToString(userData)),
+		InstanceRequirements: getLaunchTemplateInstanceRequirementsRequest(lt.InstanceRequirements),
+	}
+
+	// The instance type cannot be set along with the instance requirements.
+	if lt.InstanceType != "" {
+		data.InstanceType = aws.String(lt.InstanceType)
 	}
 
 	if lt.InstanceMetadataOptions != nil {
@@ -514,11 +536,37 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 	}
 	data.SecurityGroupIds = append(data.SecurityGroupIds, aws.StringSlice(securityGroupIDs)...)
 
+	// The public IP option can only be set on a network interface, which then holds the security groups.
+	if lt.PublicIP != nil {
+		data.NetworkInterfaces = []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+			{
+				DeviceIndex:              aws.Int64(0),
+				AssociatePublicIpAddress: lt.PublicIP,
+				Groups:                   data.SecurityGroupIds,
+			},
+		}
+		data.SecurityGroupIds = nil
+	}
+
 	// set the AMI ID
 	data.ImageId = imageID
 
 	data.InstanceMarketOptions = getLaunchTemplateInstanceMarketOptionsRequest(scope.GetLaunchTemplate().SpotMarketOptions)
 	data.PrivateDnsNameOptions = getLaunchTemplatePrivateDNSNameOptionsRequest(scope.GetLaunchTemplate().PrivateDNSName)
+	data.CapacityReservationSpecification = getLaunchTemplateCapacityReservationSpecificationRequest(scope.GetLaunchTemplate().CapacityReservationTarget)
+	if placementGroup := scope.GetLaunchTemplate().PlacementGroup; placementGroup != nil {
+		if err := s.reconcilePlacementGroup(placementGroup); err != nil {
+			return nil, err
+		}
+	}
+	data.Placement = getLaunchTemplatePlacementRequest(scope.GetLaunchTemplate())
+	if enclaveOptions := scope.GetLaunchTemplate().EnclaveOptions; enclaveOptions != nil {
+		data.EnclaveOptions = &ec2.LaunchTemplateEnclaveOptionsRequest{
+			Enabled: aws.Bool(enclaveOptions.Enabled),
+		}
+	}
+
+	volumes := []*infrav1.Volume{}
 
 	// Set up root volume
 	if lt.RootVolume != nil {
@@ -528,11 +576,29 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 		}
 
 		lt.RootVolume.DeviceName = aws.StringValue(rootDeviceName)
+		volumes = append(volumes, lt.RootVolume)
+	}
 
-		req := volumeToLaunchTemplateBlockDeviceMappingRequest(lt.RootVolume)
-		data.BlockDeviceMappings = []*ec2.LaunchTemplateBlockDeviceMappingRequest{
-			req,
+	for vi := range lt.NonRootVolumes {
+		if lt.NonRootVolumes[vi].DeviceName == "" {
+			return nil, errors.Errorf("non root volume should have device name specified")
 		}
+		volumes = append(volumes, &lt.NonRootVolumes[vi])
+	}
+
+	if err := s.checkVolumeEncryption(volumes...); err != nil {
+		return nil, err
+	}
+	keyARNs, err := s.checkEncryptionKeys(volumes...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAutoScalingGrants(keyARNs); err != nil {
+		return nil, err
+	}
+
+	for _, volume := range volumes {
+		data.BlockDeviceMappings = append(data.BlockDeviceMappings, volumeToLaunchTemplateBlockDeviceMappingRequest(volume))
 	}
 
 	data.TagSpecifications = s.buildLaunchTemplateTagSpecificationRequest(scope, userDataSecretKey)
@@ -542,7 +608,7 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 
 func volumeToLaunchTemplateBlockDeviceMappingRequest(v *infrav1.Volume) *ec2.LaunchTemplateBlockDeviceMappingRequest {
 	ltEbsDevice := &ec2.LaunchTemplateEbsBlockDeviceRequest{
-		DeleteOnTermination: aws.Bool(true),
+		DeleteOnTermination: aws.Bool(ptr.Deref(v.DeleteOnTermination, true)),
 		VolumeSize:          aws.Int64(v.Size),
 		Encrypted:           v.Encrypted,
 	}
@@ -586,39 +652,49 @@ func (s *Service) DeleteLaunchTemplate(id string) error {
 	return nil
 }
 
-// PruneLaunchTemplateVersions deletes one old launch template version.
+// PruneLaunchTemplateVersions deletes the old launch template versions before a new version is created,
+// so that versionsToKeep versions remain once it is created.
 // It does not delete the "latest" version, because that version may still be in use.
 // It does not delete the "default" version, because that version cannot be deleted.
 // It does not assume that versions are sequential. Versions may be deleted out of band.
-func (s *Service) PruneLaunchTemplateVersions(id string) error {
-	// When there is one version available, it is the default and the latest.
-	// When there are two versions available, one the is the default, the other is the latest.
-	// Therefore we only prune when there are at least 3 versions available.
-	const minCountToAllowPrune = 3
-
+func (s *Service) PruneLaunchTemplateVersions(id string, versionsToKeep int64) error {
 	input := &ec2.DescribeLaunchTemplateVersionsInput{
 		LaunchTemplateId: aws.String(id),
-		MinVersion:       aws.String("0"),
-		MaxVersion:       aws.String(expinfrav1.LaunchTemplateLatestVersion),
-		MaxResults:       aws.Int64(minCountToAllowPrune),
 	}
 
-	out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(context.TODO(), input)
-	if err != nil {
-		s.scope.Info("", "aerr", err.Error())
-		return err
+	var versions []*ec2.LaunchTemplateVersion
+	for {
+		out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(context.TODO(), input)
+		if err != nil {
+			s.scope.Info("", "aerr", err.Error())
+			return err
+		}
+		for _, version := range out.LaunchTemplateVersions {
+			if !aws.BoolValue(version.DefaultVersion) {
+				versions = append(versions, version)
+			}
+		}
+		if aws.StringValue(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
 	}
 
-	// len(out.LaunchTemplateVersions)	|	items
-	// -------------------------------- + -----------------------
-	// 								1	|	[default/latest]
-	// 								2	|	[default, latest]
-	// 								3	| 	[default, versionToPrune, latest]
-	if len(out.LaunchTemplateVersions) < minCountToAllowPrune {
+	// The new version is one of the versions to keep, and the latest version is always kept.
+	keep := max(versionsToKeep-1, 1)
+	if int64(len(versions)) <= keep {
 		return nil
 	}
-	versionToPrune := out.LaunchTemplateVersions[1].VersionNumber
-	return s.deleteLaunchTemplateVersion(id, versionToPrune)
+
+	sort.Slice(versions, func(i, j int) bool {
+		return aws.Int64Value(versions[i].VersionNumber) > aws.Int64Value(versions[j].VersionNumber)
+	})
+	for _, version := range versions[keep:] {
+		if err := s.deleteLaunchTemplateVersion(id, version.VersionNumber); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // GetLaunchTemplateLatestVersion returns the latest version of a launch template.
@@ -691,6 +767,10 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		}
 	}
 
+	if v.InstanceRequirements != nil {
+		i.InstanceRequirements = sdkToInstanceRequirements(v.InstanceRequirements)
+	}
+
 	if v.PrivateDnsNameOptions != nil {
 		i.PrivateDNSName = &infrav1.PrivateDNSName{
 			EnableResourceNameDNSAAAARecord: v.PrivateDnsNameOptions.EnableResourceNameDnsAAAARecord,
@@ -699,6 +779,54 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		}
 	}
 
+	if v.CapacityReservationSpecification != nil && v.CapacityReservationSpecification.CapacityReservationTarget != nil {
+		i.CapacityReservationTarget = &infrav1.CapacityReservationTarget{
+			ID:               v.CapacityReservationSpecification.CapacityReservationTarget.CapacityReservationId,
+			ResourceGroupARN: v.CapacityReservationSpecification.CapacityReservationTarget.CapacityReservationResourceGroupArn,
+		}
+	}
+
+	if v.EnclaveOptions != nil {
+		i.EnclaveOptions = &infrav1.EnclaveOptions{
+			Enabled: aws.BoolValue(v.EnclaveOptions.Enabled),
+		}
+	}
+
+	if v.Placement != nil {
+		i.Tenancy = aws.StringValue(v.Placement.Tenancy)
+		i.HostID = v.Placement.HostId
+		i.HostResourceGroupARN = v.Placement.HostResourceGroupArn
+		// The strategy of the placement group is not part of the launch template.
+		if v.Placement.GroupName != nil {
+			i.PlacementGroup = &infrav1.PlacementGroup{
+				Name:      aws.StringValue(v.Placement.GroupName),
+				Partition: aws.Int64Value(v.Placement.PartitionNumber),
+			}
+		}
+	}
+
+	// The block device mapping of the root volume comes first, followed by the ones of the non root volumes.
+	for idx, mapping := range v.BlockDeviceMappings {
+		if mapping.Ebs == nil {
+			continue
+		}
+		volume := infrav1.Volume{
+			DeviceName:          aws.StringValue(mapping.DeviceName),
+			Size:                aws.Int64Value(mapping.Ebs.VolumeSize),
+			Type:                infrav1.VolumeType(aws.StringValue(mapping.Ebs.VolumeType)),
+			IOPS:                aws.Int64Value(mapping.Ebs.Iops),
+			Throughput:          mapping.Ebs.Throughput,
+			Encrypted:           mapping.Ebs.Encrypted,
+			EncryptionKey:       aws.StringValue(mapping.Ebs.KmsKeyId),
+			DeleteOnTermination: mapping.Ebs.DeleteOnTermination,
+		}
+		if idx == 0 {
+			i.RootVolume = &volume
+			continue
+		}
+		i.NonRootVolumes = append(i.NonRootVolumes, volume)
+	}
+
 	if v.IamInstanceProfile != nil {
 		i.IamInstanceProfile = aws.StringValue(v.IamInstanceProfile.Name)
 	}
@@ -718,6 +846,16 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		i.AdditionalSecurityGroups = append(i.AdditionalSecurityGroups, infrav1.AWSResourceReference{ID: id})
 	}
 
+	for _, ni := range v.NetworkInterfaces {
+		if aws.Int64Value(ni.DeviceIndex) != 0 {
+			continue
+		}
+		i.PublicIP = ni.AssociatePublicIpAddress
+		for _, id := range ni.Groups {
+			i.AdditionalSecurityGroups = append(i.AdditionalSecurityGroups, infrav1.AWSResourceReference{ID: id})
+		}
+	}
+
 	if v.UserData == nil {
 		return i, userdata.ComputeHash(nil), nil, nil
 	}
@@ -725,21 +863,42 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 	if err != nil {
 		return nil, "", nil, errors.Wrap(err, "unable to decode UserData")
 	}
+	// The hash is computed on the uncompressed user data to compare it with the bootstrap data.
+	if userdata.IsGzipped(decodedUserData) {
+		i.UncompressedUserData = ptr.To[bool](false)
+		decodedUserData, err = userdata.GunzipBytes(decodedUserData)
+		if err != nil {
+			return nil, "", nil, errors.Wrap(err, "unable to decompress UserData")
+		}
+	}
 	decodedUserDataHash := userdata.ComputeHash(decodedUserData)
 
+	resourceTags := map[string]infrav1.Tags{}
 	for _, tagSpecification := range v.TagSpecifications {
-		if tagSpecification.ResourceType != nil && *tagSpecification.ResourceType == ec2.ResourceTypeInstance {
-			for _, tag := range tagSpecification.Tags {
-				if tag.Key != nil && *tag.Key == infrav1.LaunchTemplateBootstrapDataSecret && tag.Value != nil && strings.Contains(*tag.Value, "/") {
-					parts := strings.SplitN(*tag.Value, "/", 2)
-					launchTemplateUserDataSecretKey := &apimachinerytypes.NamespacedName{
-						Namespace: parts[0],
-						Name:      parts[1],
-					}
-					return i, decodedUserDataHash, launchTemplateUserDataSecretKey, nil
-				}
-			}
+		tags := infrav1.Tags{}
+		for _, tag := range tagSpecification.Tags {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		resourceTags[aws.StringValue(tagSpecification.ResourceType)] = tags
+	}
+
+	// Only the tags of the volumes and network interfaces which differ from the ones of the instances are
+	// specific to them, the others are shared by all the resources.
+	instanceTags := resourceTags[ec2.ResourceTypeInstance]
+	if volumeTags := resourceTags[ec2.ResourceTypeVolume].Difference(instanceTags); len(volumeTags) > 0 {
+		i.AdditionalVolumeTags = volumeTags
+	}
+	if networkInterfaceTags := resourceTags[ec2.ResourceTypeNetworkInterface].Difference(instanceTags); len(networkInterfaceTags) > 0 {
+		i.AdditionalNetworkInterfaceTags = networkInterfaceTags
+	}
+
+	if value := instanceTags[infrav1.LaunchTemplateBootstrapDataSecret]; strings.Contains(value, "/") {
+		parts := strings.SplitN(value, "/", 2)
+		launchTemplateUserDataSecretKey := &apimachinerytypes.NamespacedName{
+			Namespace: parts[0],
+			Name:      parts[1],
 		}
+		return i, decodedUserDataHash, launchTemplateUserDataSecretKey, nil
 	}
 
 	return i, decodedUserDataHash, nil, nil
@@ -757,9 +916,51 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, inc
 	if incoming.InstanceType != existing.InstanceType {
 		return true, nil
 	}
+	if instanceRequirementsNeedUpdate(incoming.InstanceRequirements, existing.InstanceRequirements) {
+		return true, nil
+	}
 	if !cmp.Equal(incoming.InstanceMetadataOptions, existing.InstanceMetadataOptions) {
 		return true, nil
 	}
+	if !cmp.Equal(incoming.PublicIP, existing.PublicIP) {
+		return true, nil
+	}
+	if !cmp.Equal(incoming.CapacityReservationTarget, existing.CapacityReservationTarget) {
+		return true, nil
+	}
+	if incoming.Tenancy != existing.Tenancy {
+		return true, nil
+	}
+	if !cmp.Equal(incoming.HostID, existing.HostID) || !cmp.Equal(incoming.HostResourceGroupARN, existing.HostResourceGroupARN) {
+		return true, nil
+	}
+	if placementGroupNeedsUpdate(incoming.PlacementGroup, existing.PlacementGroup) {
+		return true, nil
+	}
+	if incoming.EnclaveOptions.IsEnabled() != existing.EnclaveOptions.IsEnabled() {
+		return true, nil
+	}
+	if compressLaunchTemplateUserData(incoming) != compressLaunchTemplateUserData(existing) {
+		return true, nil
+	}
+	if rootVolumePerformanceNeedsUpdate(incoming.RootVolume, existing.RootVolume) {
+		return true, nil
+	}
+	if nonRootVolumesNeedUpdate(incoming, existing) {
+		return true, nil
+	}
+	if len(incoming.AdditionalVolumeTags) > 0 || len(existing.AdditionalVolumeTags) > 0 ||
+		len(incoming.AdditionalNetworkInterfaceTags) > 0 || len(existing.AdditionalNetworkInterfaceTags) > 0 {
+		// The tags read back from the existing launch template only hold the ones specific to the
+		// resource type, so the shared tags are removed before comparing.
+		tags := s.launchTemplateTags(scope)
+		if !cmp.Equal(incoming.AdditionalVolumeTags.Difference(tags), existing.AdditionalVolumeTags, cmpopts.EquateEmpty()) {
+			return true, nil
+		}
+		if !cmp.Equal(incoming.AdditionalNetworkInterfaceTags.Difference(tags), existing.AdditionalNetworkInterfaceTags, cmpopts.EquateEmpty()) {
+			return true, nil
+		}
+	}
 
 	incomingIDs, err := s.GetAdditionalSecurityGroupsIDs(incoming.AdditionalSecurityGroups)
 	if err != nil {
@@ -794,6 +995,38 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 		return lt.AMI.ID, nil
 	}
 
+	filters := lt.AMI.Filters
+	if lt.AMI.MarketplaceProductCode != nil {
+		filters = marketplaceAMIFilters(*lt.AMI.MarketplaceProductCode)
+	}
+	if len(filters) > 0 {
+		// If instance type is not specified on a launch template, we can safely assume the instance type will be a `t3.medium`,
+		// the architecture defaults to `x86_64` as a result.
+		imageArchitecture := Amd64ArchitectureTag
+		if lt.ImageLookupArchitecture != "" {
+			imageArchitecture = lt.ImageLookupArchitecture
+		} else if lt.InstanceType != "" {
+			var err error
+			imageArchitecture, err = s.pickArchitectureForInstanceType(lt.InstanceType)
+			if err != nil {
+				return nil, err
+			}
+		}
+		lookupAMI, err := s.filteredAMIIDLookup(filters, imageArchitecture)
+		if err != nil {
+			return nil, err
+		}
+		return aws.String(lookupAMI), nil
+	}
+
+	if lt.AMI.SSMParameter != nil {
+		lookupAMI, err := s.ssmParameterAMIIDLookup(*lt.AMI.SSMParameter)
+		if err != nil {
+			return nil, err
+		}
+		return aws.String(lookupAMI), nil
+	}
+
 	templateVersion := scope.GetMachinePool().Spec.Template.Spec.Version
 	if templateVersion == nil {
 		err := errors.New("Either AWSMachinePool's spec.awslaunchtemplate.ami.id or MachinePool's spec.template.spec.version must be defined")
@@ -826,7 +1059,9 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 	// We will set the default architecture to `x86_64` as a result.
 	imageArchitecture := Amd64ArchitectureTag
 
-	if instanceType != "" {
+	if lt.ImageLookupArchitecture != "" {
+		imageArchitecture = lt.ImageLookupArchitecture
+	} else if instanceType != "" {
 		imageArchitecture, err = s.pickArchitectureForInstanceType(instanceType)
 		if err != nil {
 			return nil, err
@@ -849,6 +1084,7 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 			imageLookupBaseOS,
 			imageArchitecture,
 			*templateVersion,
+			lt.ImageLookupRootDeviceType,
 		)
 		if err != nil {
 			return nil, err
@@ -878,52 +1114,102 @@ func (s *Service) GetAdditionalSecurityGroupsIDs(securityGroups []infrav1.AWSRes
 	return additionalSecurityGroupsIDs, nil
 }
 
+// GetSecurityGroupSelectorsIDs returns the IDs of the security groups of the cluster VPC matched by the selectors.
+// It fails if a selector doesn't match exactly one security group.
+func (s *Service) GetSecurityGroupSelectorsIDs(selectors []infrav1.SecurityGroupSelector) ([]string, error) {
+	ids := make([]string, 0, len(selectors))
+	for _, selector := range selectors {
+		keys := make([]string, 0, len(selector.Tags))
+		for key := range selector.Tags {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		filters := []*ec2.Filter{filter.EC2.VPC(s.scope.VPC().ID)}
+		for _, key := range keys {
+			filters = append(filters, &ec2.Filter{Name: aws.String("tag:" + key), Values: aws.StringSlice([]string{selector.Tags[key]})})
+		}
+
+		out, err := s.EC2Client.DescribeSecurityGroupsWithContext(context.TODO(), &ec2.DescribeSecurityGroupsInput{Filters: filters})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to describe security groups matching tags %v", selector.Tags)
+		}
+
+		switch len(out.SecurityGroups) {
+		case 0:
+			return nil, errors.Errorf("no security group in VPC %q matches tags %v", s.scope.VPC().ID, selector.Tags)
+		case 1:
+			ids = append(ids, aws.StringValue(out.SecurityGroups[0].GroupId))
+		default:
+			matched := make([]string, 0, len(out.SecurityGroups))
+			for _, sg := range out.SecurityGroups {
+				matched = append(matched, aws.StringValue(sg.GroupId))
+			}
+			return nil, errors.Errorf("tags %v are ambiguous, they match security groups %v", selector.Tags, matched)
+		}
+	}
+
+	return ids, nil
+}
+
 func (s *Service) buildLaunchTemplateTagSpecificationRequest(scope scope.LaunchTemplateScope, userDataSecretKey apimachinerytypes.NamespacedName) []*ec2.LaunchTemplateTagSpecificationRequest {
 	tagSpecifications := make([]*ec2.LaunchTemplateTagSpecificationRequest, 0)
+	tags := s.launchTemplateTags(scope)
+	lt := scope.GetLaunchTemplate()
+
+	// tag instances
+	{
+		instanceTags := tags.DeepCopy()
+		instanceTags[infrav1.LaunchTemplateBootstrapDataSecret] = userDataSecretKey.String()
+		tagSpecifications = append(tagSpecifications, launchTemplateTagSpecificationRequest(ec2.ResourceTypeInstance, instanceTags))
+	}
+
+	// tag EBS volumes
+	if volumeTags := launchTemplateResourceTags(tags, lt.AdditionalVolumeTags); len(volumeTags) > 0 {
+		tagSpecifications = append(tagSpecifications, launchTemplateTagSpecificationRequest(ec2.ResourceTypeVolume, volumeTags))
+	}
+
+	// tag network interfaces, only when requested so the existing launch templates are left unchanged
+	if len(lt.AdditionalNetworkInterfaceTags) > 0 {
+		tagSpecifications = append(tagSpecifications, launchTemplateTagSpecificationRequest(ec2.ResourceTypeNetworkInterface, launchTemplateResourceTags(tags, lt.AdditionalNetworkInterfaceTags)))
+	}
+
+	return tagSpecifications
+}
+
+// launchTemplateTags returns the tags shared by the resources launched with the launch template.
+func (s *Service) launchTemplateTags(scope scope.LaunchTemplateScope) infrav1.Tags {
 	additionalTags := scope.AdditionalTags()
 	// Set the cloud provider tag
 	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.KubernetesClusterName())] = string(infrav1.ResourceLifecycleOwned)
 
-	tags := infrav1.Build(infrav1.BuildParams{
+	return infrav1.Build(infrav1.BuildParams{
 		ClusterName: s.scope.KubernetesClusterName(),
 		Lifecycle:   infrav1.ResourceLifecycleOwned,
 		Name:        aws.String(scope.LaunchTemplateName()),
 		Role:        aws.String("node"),
 		Additional:  additionalTags,
 	})
+}
 
-	// tag instances
-	{
-		instanceTags := tags.DeepCopy()
-		instanceTags[infrav1.LaunchTemplateBootstrapDataSecret] = userDataSecretKey.String()
-
-		spec := &ec2.LaunchTemplateTagSpecificationRequest{ResourceType: aws.String(ec2.ResourceTypeInstance)}
-		for key, value := range instanceTags {
-			spec.Tags = append(spec.Tags, &ec2.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
-		// Sort so that unit tests can expect a stable order
-		sort.Slice(spec.Tags, func(i, j int) bool { return *spec.Tags[i].Key < *spec.Tags[j].Key })
-		tagSpecifications = append(tagSpecifications, spec)
-	}
-
-	// tag EBS volumes
-	if len(tags) > 0 {
-		spec := &ec2.LaunchTemplateTagSpecificationRequest{ResourceType: aws.String(ec2.ResourceTypeVolume)}
-		for key, value := range tags {
-			spec.Tags = append(spec.Tags, &ec2.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
-		// Sort so that unit tests can expect a stable order
-		sort.Slice(spec.Tags, func(i, j int) bool { return *spec.Tags[i].Key < *spec.Tags[j].Key })
-		tagSpecifications = append(tagSpecifications, spec)
-	}
+// launchTemplateResourceTags returns the shared tags merged with the tags specific to a resource type.
+func launchTemplateResourceTags(tags, resourceTags infrav1.Tags) infrav1.Tags {
+	merged := tags.DeepCopy()
+	merged.Merge(resourceTags)
+	return merged
+}
 
-	return tagSpecifications
+func launchTemplateTagSpecificationRequest(resourceType string, tags infrav1.Tags) *ec2.LaunchTemplateTagSpecificationRequest {
+	spec := &ec2.LaunchTemplateTagSpecificationRequest{ResourceType: aws.String(resourceType)}
+	for key, value := range tags {
+		spec.Tags = append(spec.Tags, &ec2.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+	// Sort so that unit tests can expect a stable order
+	sort.Slice(spec.Tags, func(i, j int) bool { return *spec.Tags[i].Key < *spec.Tags[j].Key })
+	return spec
 }
 
 // getFilteredSecurityGroupIDs get security group IDs using filters.
@@ -972,6 +1258,185 @@ func getLaunchTemplateInstanceMarketOptionsRequest(spotMarketOptions *infrav1.Sp
 	return launchTemplateInstanceMarketOptionsRequest
 }
 
+func getLaunchTemplateCapacityReservationSpecificationRequest(target *infrav1.CapacityReservationTarget) *ec2.LaunchTemplateCapacityReservationSpecificationRequest {
+	if target == nil {
+		return nil
+	}
+
+	return &ec2.LaunchTemplateCapacityReservationSpecificationRequest{
+		CapacityReservationTarget: &ec2.CapacityReservationTarget{
+			CapacityReservationId:               target.ID,
+			CapacityReservationResourceGroupArn: target.ResourceGroupARN,
+		},
+	}
+}
+
+func getLaunchTemplatePlacementRequest(lt *expinfrav1.AWSLaunchTemplate) *ec2.LaunchTemplatePlacementRequest {
+	if lt.Tenancy == "" && lt.HostID == nil && lt.HostResourceGroupARN == nil && lt.PlacementGroup == nil {
+		return nil
+	}
+
+	placement := &ec2.LaunchTemplatePlacementRequest{
+		HostId:               lt.HostID,
+		HostResourceGroupArn: lt.HostResourceGroupARN,
+	}
+	if lt.Tenancy != "" {
+		placement.Tenancy = aws.String(lt.Tenancy)
+	}
+	if lt.PlacementGroup != nil {
+		placement.GroupName = aws.String(lt.PlacementGroup.Name)
+		if lt.PlacementGroup.Partition != 0 {
+			placement.PartitionNumber = aws.Int64(lt.PlacementGroup.Partition)
+		}
+	}
+	return placement
+}
+
+func getLaunchTemplateInstanceRequirementsRequest(requirements *expinfrav1.InstanceRequirements) *ec2.InstanceRequirementsRequest {
+	if requirements == nil {
+		return nil
+	}
+
+	// The minimum number of vCPUs and the minimum amount of memory are required by EC2.
+	request := &ec2.InstanceRequirementsRequest{
+		VCpuCount: &ec2.VCpuCountRangeRequest{
+			Min: aws.Int64(ptr.Deref(requirements.VCPUCount.Min, 0)),
+			Max: requirements.VCPUCount.Max,
+		},
+		MemoryMiB: &ec2.MemoryMiBRequest{
+			Min: aws.Int64(ptr.Deref(requirements.MemoryMiB.Min, 0)),
+			Max: requirements.MemoryMiB.Max,
+		},
+		CpuManufacturers:      aws.StringSlice(requirements.CPUManufacturers),
+		ExcludedInstanceTypes: aws.StringSlice(requirements.ExcludedInstanceTypes),
+		InstanceGenerations:   aws.StringSlice(requirements.InstanceGenerations),
+	}
+	if requirements.AcceleratorCount != nil {
+		request.AcceleratorCount = &ec2.AcceleratorCountRequest{
+			Min: requirements.AcceleratorCount.Min,
+			Max: requirements.AcceleratorCount.Max,
+		}
+	}
+	if requirements.BurstablePerformance != "" {
+		request.BurstablePerformance = aws.String(requirements.BurstablePerformance)
+	}
+	return request
+}
+
+func sdkToInstanceRequirements(requirements *ec2.InstanceRequirements) *expinfrav1.InstanceRequirements {
+	i := &expinfrav1.InstanceRequirements{
+		BurstablePerformance: aws.StringValue(requirements.BurstablePerformance),
+	}
+	if len(requirements.CpuManufacturers) > 0 {
+		i.CPUManufacturers = aws.StringValueSlice(requirements.CpuManufacturers)
+	}
+	if len(requirements.ExcludedInstanceTypes) > 0 {
+		i.ExcludedInstanceTypes = aws.StringValueSlice(requirements.ExcludedInstanceTypes)
+	}
+	if len(requirements.InstanceGenerations) > 0 {
+		i.InstanceGenerations = aws.StringValueSlice(requirements.InstanceGenerations)
+	}
+	if requirements.VCpuCount != nil {
+		i.VCPUCount = expinfrav1.InstanceRequirementsRange{Min: requirements.VCpuCount.Min, Max: requirements.VCpuCount.Max}
+	}
+	if requirements.MemoryMiB != nil {
+		i.MemoryMiB = expinfrav1.InstanceRequirementsRange{Min: requirements.MemoryMiB.Min, Max: requirements.MemoryMiB.Max}
+	}
+	if requirements.AcceleratorCount != nil {
+		i.AcceleratorCount = &expinfrav1.InstanceRequirementsRange{Min: requirements.AcceleratorCount.Min, Max: requirements.AcceleratorCount.Max}
+	}
+	return i
+}
+
+// instanceRequirementsNeedUpdate compares the instance requirements, considering the unset minimum number of vCPUs
+// and minimum amount of memory as 0, the value sent to EC2.
+func instanceRequirementsNeedUpdate(incoming, existing *expinfrav1.InstanceRequirements) bool {
+	if incoming == nil || existing == nil {
+		return incoming != existing
+	}
+	incoming = incoming.DeepCopy()
+	incoming.VCPUCount.Min = ptr.To[int64](ptr.Deref(incoming.VCPUCount.Min, 0))
+	incoming.MemoryMiB.Min = ptr.To[int64](ptr.Deref(incoming.MemoryMiB.Min, 0))
+	return !cmp.Equal(incoming, existing, cmpopts.EquateEmpty())
+}
+
+// placementGroupNeedsUpdate compares the placement group name and partition only, as the strategy
+// of the placement group is not part of the launch template.
+func placementGroupNeedsUpdate(incoming, existing *infrav1.PlacementGroup) bool {
+	if incoming == nil || existing == nil {
+		return incoming != existing
+	}
+	return incoming.Name != existing.Name || incoming.Partition != existing.Partition
+}
+
+// rootVolumePerformanceNeedsUpdate returns true when the IOPS or the throughput requested for the root volume
+// differ from the launch template. Unset values are left to the defaults of the volume type, so they are ignored.
+func rootVolumePerformanceNeedsUpdate(incoming, existing *infrav1.Volume) bool {
+	if incoming == nil {
+		return false
+	}
+	if existing == nil {
+		return incoming.IOPS != 0 || incoming.Throughput != nil
+	}
+	if incoming.IOPS != 0 && incoming.IOPS != existing.IOPS {
+		return true
+	}
+	return incoming.Throughput != nil && !cmp.Equal(incoming.Throughput, existing.Throughput)
+}
+
+// compressLaunchTemplateUserData returns true if the user data of the launch template is gzip-compressed.
+func compressLaunchTemplateUserData(lt *expinfrav1.AWSLaunchTemplate) bool {
+	return lt.UncompressedUserData != nil && !*lt.UncompressedUserData
+}
+
+// nonRootVolumesNeedUpdate returns true when the non root volumes requested differ from the launch template.
+// The existing volumes are matched by device name, as the root volume may not be part of the launch template.
+func nonRootVolumesNeedUpdate(incoming, existing *expinfrav1.AWSLaunchTemplate) bool {
+	existingVolumes := map[string]infrav1.Volume{}
+	if existing.RootVolume != nil {
+		existingVolumes[existing.RootVolume.DeviceName] = *existing.RootVolume
+	}
+	for _, volume := range existing.NonRootVolumes {
+		existingVolumes[volume.DeviceName] = volume
+	}
+
+	expected := len(incoming.NonRootVolumes)
+	if incoming.RootVolume != nil {
+		expected++
+	}
+	if expected != len(existingVolumes) {
+		return true
+	}
+
+	for _, volume := range incoming.NonRootVolumes {
+		existingVolume, ok := existingVolumes[volume.DeviceName]
+		if !ok || volumeNeedsUpdate(volume, existingVolume) {
+			return true
+		}
+	}
+	return false
+}
+
+// volumeNeedsUpdate returns true when the volume requested differs from the existing one. Unset values are left
+// to the defaults of the volume type or of the account, so they are ignored.
+func volumeNeedsUpdate(incoming, existing infrav1.Volume) bool {
+	switch {
+	case incoming.Size != existing.Size:
+		return true
+	case incoming.Type != "" && incoming.Type != existing.Type:
+		return true
+	case incoming.IOPS != 0 && incoming.IOPS != existing.IOPS:
+		return true
+	case incoming.Throughput != nil && !cmp.Equal(incoming.Throughput, existing.Throughput):
+		return true
+	case incoming.EncryptionKey != "" && incoming.EncryptionKey != existing.EncryptionKey:
+		return true
+	case incoming.Encrypted != nil && ptr.Deref(incoming.Encrypted, false) != ptr.Deref(existing.Encrypted, false):
+		return true
+	}
+	return ptr.Deref(incoming.DeleteOnTermination, true) != ptr.Deref(existing.DeleteOnTermination, true)
+}
+
 func getLaunchTemplatePrivateDNSNameOptionsRequest(privateDNSName *infrav1.PrivateDNSName) *ec2.LaunchTemplatePrivateDnsNameOptionsRequest {
 	if privateDNSName == nil {
 		return nil
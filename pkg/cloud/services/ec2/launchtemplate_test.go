@@ -80,6 +80,15 @@ users:
 
 var testUserDataHash = userdata.ComputeHash([]byte(testUserData))
 
+func gzippedTestUserData(t *testing.T) string {
+	t.Helper()
+	compressed, err := userdata.GzipBytes([]byte(testUserData))
+	if err != nil {
+		t.Fatalf("failed to gzip user data: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(compressed)
+}
+
 func defaultEC2AndUserDataSecretKeyTags(name string, clusterName string, userDataSecretKey types.NamespacedName) []*ec2.Tag {
 	tags := defaultEC2Tags(name, clusterName)
 	tags = append(tags, &ec2.Tag{
@@ -206,9 +215,15 @@ func TestGetLaunchTemplate(t *testing.T) {
 					AMI: infrav1.AMIReference{
 						ID: aws.String("foo-image"),
 					},
-					IamInstanceProfile:       "foo-profile",
-					SSHKeyName:               aws.String("foo-keyname"),
-					VersionNumber:            aws.Int64(1),
+					IamInstanceProfile: "foo-profile",
+					SSHKeyName:         aws.String("foo-keyname"),
+					VersionNumber:      aws.Int64(1),
+					RootVolume: &infrav1.Volume{
+						DeviceName: "foo-device",
+						Size:       16,
+						Type:       "cool",
+						Encrypted:  aws.Bool(true),
+					},
 					AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-id")}},
 				}
 
@@ -264,9 +279,15 @@ func TestGetLaunchTemplate(t *testing.T) {
 					AMI: infrav1.AMIReference{
 						ID: aws.String("foo-image"),
 					},
-					IamInstanceProfile:       "foo-profile",
-					SSHKeyName:               aws.String("foo-keyname"),
-					VersionNumber:            aws.Int64(1),
+					IamInstanceProfile: "foo-profile",
+					SSHKeyName:         aws.String("foo-keyname"),
+					VersionNumber:      aws.Int64(1),
+					RootVolume: &infrav1.Volume{
+						DeviceName: "foo-device",
+						Size:       16,
+						Type:       "cool",
+						Encrypted:  aws.Bool(true),
+					},
 					AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-id")}},
 				}
 
@@ -327,7 +348,9 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 							Ebs: &ec2.LaunchTemplateEbsBlockDevice{
 								Encrypted:  aws.Bool(true),
 								VolumeSize: aws.Int64(16),
-								VolumeType: aws.String("cool"),
+								VolumeType: aws.String("gp3"),
+								Iops:       aws.Int64(4000),
+								Throughput: aws.Int64(250),
 							},
 						},
 					},
@@ -337,6 +360,15 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 							Groups:      []*string{aws.String("foo-group")},
 						},
 					},
+					Placement: &ec2.LaunchTemplatePlacement{
+						Tenancy:              aws.String("host"),
+						HostResourceGroupArn: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/my-hosts"),
+					},
+					CapacityReservationSpecification: &ec2.LaunchTemplateCapacityReservationSpecificationResponse{
+						CapacityReservationTarget: &ec2.CapacityReservationTargetResponse{
+							CapacityReservationId: aws.String("cr-0123456789abcdef0"),
+						},
+					},
 					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
 				},
 				VersionNumber: aws.Int64(1),
@@ -349,10 +381,60 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 				IamInstanceProfile: "foo-profile",
 				SSHKeyName:         aws.String("foo-keyname"),
 				VersionNumber:      aws.Int64(1),
+				RootVolume: &infrav1.Volume{
+					DeviceName: "foo-device",
+					Size:       16,
+					Type:       infrav1.VolumeTypeGP3,
+					IOPS:       4000,
+					Throughput: aws.Int64(250),
+					Encrypted:  aws.Bool(true),
+				},
+				CapacityReservationTarget: &infrav1.CapacityReservationTarget{
+					ID: aws.String("cr-0123456789abcdef0"),
+				},
+				Tenancy:              "host",
+				HostResourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/my-hosts"),
 			},
 			wantHash:          testUserDataHash,
 			wantDataSecretKey: nil, // respective tag is not given
 		},
+		{
+			name: "instance requirements",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId: aws.String("foo-image"),
+					InstanceRequirements: &ec2.InstanceRequirements{
+						VCpuCount:             &ec2.VCpuCountRange{Min: aws.Int64(2), Max: aws.Int64(8)},
+						MemoryMiB:             &ec2.MemoryMiB{Min: aws.Int64(4096)},
+						AcceleratorCount:      &ec2.AcceleratorCount{Max: aws.Int64(0)},
+						CpuManufacturers:      aws.StringSlice([]string{"intel", "amd"}),
+						ExcludedInstanceTypes: aws.StringSlice([]string{"t2.*"}),
+						BurstablePerformance:  aws.String("excluded"),
+					},
+					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				InstanceRequirements: &expinfrav1.InstanceRequirements{
+					VCPUCount:             expinfrav1.InstanceRequirementsRange{Min: aws.Int64(2), Max: aws.Int64(8)},
+					MemoryMiB:             expinfrav1.InstanceRequirementsRange{Min: aws.Int64(4096)},
+					AcceleratorCount:      &expinfrav1.InstanceRequirementsRange{Max: aws.Int64(0)},
+					CPUManufacturers:      []string{"intel", "amd"},
+					ExcludedInstanceTypes: []string{"t2.*"},
+					BurstablePerformance:  "excluded",
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantHash:          testUserDataHash,
+			wantDataSecretKey: nil,
+		},
 		{
 			name: "tag of bootstrap secret",
 			input: &ec2.LaunchTemplateVersion{
@@ -403,10 +485,171 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 				IamInstanceProfile: "foo-profile",
 				SSHKeyName:         aws.String("foo-keyname"),
 				VersionNumber:      aws.Int64(1),
+				RootVolume: &infrav1.Volume{
+					DeviceName: "foo-device",
+					Size:       16,
+					Type:       "cool",
+					Encrypted:  aws.Bool(true),
+				},
 			},
 			wantHash:          testUserDataHash,
 			wantDataSecretKey: &types.NamespacedName{Namespace: "bootstrap-secret-ns", Name: "bootstrap-secret"},
 		},
+		{
+			name: "public IP on the primary network interface",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId: aws.String("foo-image"),
+					NetworkInterfaces: []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecification{
+						{
+							DeviceIndex:              aws.Int64(0),
+							AssociatePublicIpAddress: aws.Bool(false),
+							Groups:                   []*string{aws.String("sg-111")},
+						},
+					},
+					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				VersionNumber: aws.Int64(1),
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+				},
+				PublicIP: aws.Bool(false),
+			},
+			wantHash: testUserDataHash,
+		},
+		{
+			name: "gzipped user data",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId:  aws.String("foo-image"),
+					UserData: aws.String(gzippedTestUserData(t)),
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				VersionNumber:        aws.Int64(1),
+				UncompressedUserData: aws.Bool(false),
+			},
+			wantHash: testUserDataHash,
+		},
+		{
+			name: "non root volumes",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId: aws.String("foo-image"),
+					BlockDeviceMappings: []*ec2.LaunchTemplateBlockDeviceMapping{
+						{
+							DeviceName: aws.String("/dev/xvda"),
+							Ebs: &ec2.LaunchTemplateEbsBlockDevice{
+								DeleteOnTermination: aws.Bool(true),
+								VolumeSize:          aws.Int64(16),
+								VolumeType:          aws.String("gp3"),
+							},
+						},
+						{
+							DeviceName: aws.String("/dev/sdb"),
+							Ebs: &ec2.LaunchTemplateEbsBlockDevice{
+								DeleteOnTermination: aws.Bool(false),
+								VolumeSize:          aws.Int64(100),
+								VolumeType:          aws.String("io2"),
+								Iops:                aws.Int64(5000),
+							},
+						},
+					},
+					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				VersionNumber: aws.Int64(1),
+				RootVolume: &infrav1.Volume{
+					DeviceName:          "/dev/xvda",
+					Size:                16,
+					Type:                infrav1.VolumeTypeGP3,
+					DeleteOnTermination: aws.Bool(true),
+				},
+				NonRootVolumes: []infrav1.Volume{
+					{
+						DeviceName:          "/dev/sdb",
+						Size:                100,
+						Type:                infrav1.VolumeTypeIO2,
+						IOPS:                5000,
+						DeleteOnTermination: aws.Bool(false),
+					},
+				},
+			},
+			wantHash: testUserDataHash,
+		},
+		{
+			name: "volume and network interface tags",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId:  aws.String("foo-image"),
+					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
+					TagSpecifications: []*ec2.LaunchTemplateTagSpecification{
+						{
+							ResourceType: aws.String(ec2.ResourceTypeInstance),
+							Tags: []*ec2.Tag{
+								{Key: aws.String("Name"), Value: aws.String("foo")},
+								{Key: aws.String(infrav1.LaunchTemplateBootstrapDataSecret), Value: aws.String("bootstrap-secret-ns/bootstrap-secret")},
+							},
+						},
+						{
+							ResourceType: aws.String(ec2.ResourceTypeVolume),
+							Tags: []*ec2.Tag{
+								{Key: aws.String("Name"), Value: aws.String("foo")},
+								{Key: aws.String("cost-center"), Value: aws.String("storage")},
+							},
+						},
+						{
+							ResourceType: aws.String(ec2.ResourceTypeNetworkInterface),
+							Tags: []*ec2.Tag{
+								{Key: aws.String("Name"), Value: aws.String("foo")},
+								{Key: aws.String("cost-center"), Value: aws.String("network")},
+							},
+						},
+					},
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				VersionNumber:                  aws.Int64(1),
+				AdditionalVolumeTags:           infrav1.Tags{"cost-center": "storage"},
+				AdditionalNetworkInterfaceTags: infrav1.Tags{"cost-center": "network"},
+			},
+			wantHash: testUserDataHash,
+			wantDataSecretKey: &types.NamespacedName{
+				Namespace: "bootstrap-secret-ns",
+				Name:      "bootstrap-secret",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -501,6 +744,55 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "Should return false if the unset minimums of the instance requirements are 0 in the existing launch template",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				InstanceRequirements: &expinfrav1.InstanceRequirements{
+					VCPUCount: expinfrav1.InstanceRequirementsRange{Max: aws.Int64(8)},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				InstanceRequirements: &expinfrav1.InstanceRequirements{
+					VCPUCount: expinfrav1.InstanceRequirementsRange{Min: aws.Int64(0), Max: aws.Int64(8)},
+					MemoryMiB: expinfrav1.InstanceRequirementsRange{Min: aws.Int64(0)},
+				},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Should return true if incoming instance requirements are not same as existing instance requirements",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				InstanceRequirements: &expinfrav1.InstanceRequirements{
+					VCPUCount:             expinfrav1.InstanceRequirementsRange{Min: aws.Int64(2)},
+					MemoryMiB:             expinfrav1.InstanceRequirementsRange{Min: aws.Int64(4096)},
+					ExcludedInstanceTypes: []string{"t2.*"},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				InstanceRequirements: &expinfrav1.InstanceRequirements{
+					VCPUCount: expinfrav1.InstanceRequirementsRange{Min: aws.Int64(2)},
+					MemoryMiB: expinfrav1.InstanceRequirementsRange{Min: aws.Int64(4096)},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if instance requirements replace the instance type",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				InstanceRequirements: &expinfrav1.InstanceRequirements{
+					VCPUCount: expinfrav1.InstanceRequirementsRange{Min: aws.Int64(2)},
+					MemoryMiB: expinfrav1.InstanceRequirementsRange{Min: aws.Int64(4096)},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				InstanceType: "t3.large",
+			},
+			want: true,
+		},
 		{
 			name: "Should return true if incoming InstanceType is not same as existing InstanceType",
 			incoming: &expinfrav1.AWSLaunchTemplate{
@@ -533,16 +825,221 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "new launch template instance metadata options, requiring IMDSv2",
+			name: "new launch template instance metadata options, requiring IMDSv2",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
+					HTTPPutResponseHopLimit: 1,
+					HTTPTokens:              infrav1.HTTPTokensStateRequired,
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+			wantErr:  false,
+		},
+		{
+			name: "Should return true if incoming user data compression is not same as existing",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				UncompressedUserData: aws.Bool(false),
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "Should return true if incoming PublicIP is not same as existing PublicIP",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				PublicIP: aws.Bool(false),
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "Should return true if incoming CapacityReservationTarget is not same as existing CapacityReservationTarget",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				CapacityReservationTarget: &infrav1.CapacityReservationTarget{
+					ID: aws.String("cr-0123456789abcdef0"),
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "Should return true if incoming Tenancy is not same as existing Tenancy",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				Tenancy: "host",
+				HostID:  aws.String("h-0123456789abcdef0"),
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				Tenancy: "dedicated",
+			},
+			want: true,
+		},
+		{
+			name: "Should return false if only the strategy of the placement group differs",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				PlacementGroup: &infrav1.PlacementGroup{Name: "hpc", Strategy: infrav1.PlacementGroupStrategyCluster},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				PlacementGroup: &infrav1.PlacementGroup{Name: "hpc"},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Should return true if incoming PlacementGroup is not same as existing PlacementGroup",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				PlacementGroup: &infrav1.PlacementGroup{Name: "hpc", Strategy: infrav1.PlacementGroupStrategyCluster},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "Should return true if the root volume throughput changed",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16, Type: infrav1.VolumeTypeGP3, Throughput: aws.Int64(500)},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16, Type: infrav1.VolumeTypeGP3, IOPS: 3000, Throughput: aws.Int64(125)},
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if the root volume IOPS changed",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16, Type: infrav1.VolumeTypeGP3, IOPS: 6000},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16, Type: infrav1.VolumeTypeGP3, IOPS: 3000, Throughput: aws.Int64(125)},
+			},
+			want: true,
+		},
+		{
+			name: "Should return false if the root volume IOPS and throughput are left to the defaults",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16, Type: infrav1.VolumeTypeGP3},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16, Type: infrav1.VolumeTypeGP3, IOPS: 3000, Throughput: aws.Int64(125)},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Should return true if a non root volume is added",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 50},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16},
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if the size of a non root volume changed",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 100},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 50, DeleteOnTermination: aws.Bool(true)},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if a non root volume is no longer deleted on termination",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 50, DeleteOnTermination: aws.Bool(false)},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 50, DeleteOnTermination: aws.Bool(true)},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Should return false if the non root volumes are unchanged",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 50, Type: infrav1.VolumeTypeGP3},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 50, Type: infrav1.VolumeTypeGP3, IOPS: 3000, Throughput: aws.Int64(125), DeleteOnTermination: aws.Bool(true)},
+				},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Should return true if Nitro Enclaves are enabled",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				EnclaveOptions: &infrav1.EnclaveOptions{Enabled: true},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				EnclaveOptions: &infrav1.EnclaveOptions{Enabled: false},
+			},
+			want: true,
+		},
+		{
+			name: "the same volume and network interface tags",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				AdditionalVolumeTags:           infrav1.Tags{"cost-center": "storage", "Name": "aws-mp-name"},
+				AdditionalNetworkInterfaceTags: infrav1.Tags{"cost-center": "network"},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+				AdditionalVolumeTags:           infrav1.Tags{"cost-center": "storage"},
+				AdditionalNetworkInterfaceTags: infrav1.Tags{"cost-center": "network"},
+			},
+			want: false,
+		},
+		{
+			name: "volume tags changed",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				AdditionalVolumeTags: infrav1.Tags{"cost-center": "backup"},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+				AdditionalVolumeTags: infrav1.Tags{"cost-center": "storage"},
+			},
+			want: true,
+		},
+		{
+			name: "network interface tags added",
 			incoming: &expinfrav1.AWSLaunchTemplate{
-				InstanceMetadataOptions: &infrav1.InstanceMetadataOptions{
-					HTTPPutResponseHopLimit: 1,
-					HTTPTokens:              infrav1.HTTPTokensStateRequired,
+				AdditionalNetworkInterfaceTags: infrav1.Tags{"cost-center": "network"},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
 				},
 			},
-			existing: &expinfrav1.AWSLaunchTemplate{},
-			want:     true,
-			wantErr:  false,
+			want: true,
 		},
 		{
 			name:     "new launch template instance metadata options, removing IMDSv2 requirement",
@@ -578,12 +1075,14 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 			s := &Service{
 				scope: &scope.ClusterScope{
 					AWSCluster: ac,
+					Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-name"}},
 				},
 			}
 			machinePoolScope := &scope.MachinePoolScope{
 				InfraCluster: &scope.ClusterScope{
 					AWSCluster: ac,
 				},
+				AWSMachinePool: newAWSMachinePool(),
 			}
 			mockEC2Client := mocks.NewMockEC2API(mockCtrl)
 			s.EC2Client = mockEC2Client
@@ -815,6 +1314,7 @@ func TestCreateLaunchTemplate(t *testing.T) {
 	testCases := []struct {
 		name                 string
 		awsResourceReference []infrav1.AWSResourceReference
+		nonRootVolumes       []infrav1.Volume
 		expect               func(g *WithT, m *mocks.MockEC2APIMockRecorder)
 		check                func(g *WithT, s string, e error)
 	}{
@@ -997,6 +1497,26 @@ func TestCreateLaunchTemplate(t *testing.T) {
 				g.Expect(err).To(HaveOccurred())
 			},
 		},
+		{
+			name:                 "Should return with error if a non root volume is requested unencrypted with EBS encryption by default",
+			awsResourceReference: []infrav1.AWSResourceReference{{ID: aws.String("1")}},
+			nonRootVolumes: []infrav1.Volume{
+				{
+					DeviceName: "/dev/sdb",
+					Size:       100,
+					Encrypted:  aws.Bool(false),
+				},
+			},
+			expect: func(g *WithT, m *mocks.MockEC2APIMockRecorder) {
+				m.GetEbsEncryptionByDefaultWithContext(context.TODO(), gomock.Any()).Return(&ec2.GetEbsEncryptionByDefaultOutput{
+					EbsEncryptionByDefault: aws.Bool(true),
+				}, nil)
+			},
+			check: func(g *WithT, id string, err error) {
+				g.Expect(id).Should(BeEmpty())
+				g.Expect(err).To(MatchError(ContainSubstring("/dev/sdb")))
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1014,6 +1534,7 @@ func TestCreateLaunchTemplate(t *testing.T) {
 			g.Expect(err).NotTo(HaveOccurred())
 
 			ms.AWSMachinePool.Spec.AWSLaunchTemplate.AdditionalSecurityGroups = tc.awsResourceReference
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate.NonRootVolumes = tc.nonRootVolumes
 
 			s := NewService(cs)
 			s.EC2Client = mockEC2Client
@@ -1219,8 +1740,10 @@ func TestBuildLaunchTemplateTagSpecificationRequest(t *testing.T) {
 		Name:      "bootstrap-secret",
 	}
 	testCases := []struct {
-		name  string
-		check func(g *WithT, m []*ec2.LaunchTemplateTagSpecificationRequest)
+		name                           string
+		additionalVolumeTags           infrav1.Tags
+		additionalNetworkInterfaceTags infrav1.Tags
+		check                          func(g *WithT, m []*ec2.LaunchTemplateTagSpecificationRequest)
 	}{
 		{
 			name: "Should create tag specification request for building Launch template tags",
@@ -1242,6 +1765,35 @@ func TestBuildLaunchTemplateTagSpecificationRequest(t *testing.T) {
 				g.Expect(res).Should(Equal(expected))
 			},
 		},
+		{
+			name:                           "Should add the volume and network interface tags to their tag specification requests",
+			additionalVolumeTags:           infrav1.Tags{"cost-center": "storage"},
+			additionalNetworkInterfaceTags: infrav1.Tags{"cost-center": "network"},
+			check: func(g *WithT, res []*ec2.LaunchTemplateTagSpecificationRequest) {
+				volumeTags := append(defaultEC2Tags("aws-mp-name", "cluster-name"), &ec2.Tag{Key: aws.String("cost-center"), Value: aws.String("storage")})
+				networkInterfaceTags := append(defaultEC2Tags("aws-mp-name", "cluster-name"), &ec2.Tag{Key: aws.String("cost-center"), Value: aws.String("network")})
+				sortTags(volumeTags)
+				sortTags(networkInterfaceTags)
+				expected := []*ec2.LaunchTemplateTagSpecificationRequest{
+					{
+						ResourceType: aws.String(ec2.ResourceTypeInstance),
+						Tags:         defaultEC2AndUserDataSecretKeyTags("aws-mp-name", "cluster-name", userDataSecretKey),
+					},
+					{
+						ResourceType: aws.String(ec2.ResourceTypeVolume),
+						Tags:         volumeTags,
+					},
+					{
+						ResourceType: aws.String(ec2.ResourceTypeNetworkInterface),
+						Tags:         networkInterfaceTags,
+					},
+				}
+				for _, each := range res {
+					sortTags(each.Tags)
+				}
+				g.Expect(res).Should(Equal(expected))
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1256,6 +1808,8 @@ func TestBuildLaunchTemplateTagSpecificationRequest(t *testing.T) {
 
 			ms, err := setupMachinePoolScope(client, cs)
 			g.Expect(err).NotTo(HaveOccurred())
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate.AdditionalVolumeTags = tc.additionalVolumeTags
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate.AdditionalNetworkInterfaceTags = tc.additionalNetworkInterfaceTags
 
 			s := NewService(cs)
 			tc.check(g, s.buildLaunchTemplateTagSpecificationRequest(ms, userDataSecretKey))
@@ -1328,6 +1882,86 @@ func TestDiscoverLaunchTemplateAMI(t *testing.T) {
 				g.Expect(err).NotTo(HaveOccurred())
 			},
 		},
+		{
+			name: "Should return the latest AMI matching the filters without a version",
+			awsLaunchTemplate: expinfrav1.AWSLaunchTemplate{
+				Name: "aws-launch-tmpl",
+				AMI: infrav1.AMIReference{
+					Filters: []infrav1.Filter{
+						{
+							Name:   "name",
+							Values: []string{"Deep Learning Base OSS Nvidia Driver GPU AMI*"},
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								ImageId:      aws.String("ancient"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+							{
+								ImageId:      aws.String("latest"),
+								CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(res).Should(Equal(aws.String("latest")))
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
+		{
+			name: "Should return the latest AMI of the AWS Marketplace product",
+			awsLaunchTemplate: expinfrav1.AWSLaunchTemplate{
+				Name: "aws-launch-tmpl",
+				AMI: infrav1.AMIReference{
+					MarketplaceProductCode: aws.String("prod-abc123"),
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("architecture"),
+							Values: []*string{aws.String("x86_64")},
+						},
+						{
+							Name:   aws.String("state"),
+							Values: []*string{aws.String("available")},
+						},
+						{
+							Name:   aws.String("product-code"),
+							Values: []*string{aws.String("prod-abc123")},
+						},
+						{
+							Name:   aws.String("owner-alias"),
+							Values: []*string{aws.String("aws-marketplace")},
+						},
+					},
+				})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								ImageId:      aws.String("ancient"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+							{
+								ImageId:      aws.String("latest"),
+								CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(res).Should(Equal(aws.String("latest")))
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
 		{
 			name: "Should return AMI and use infra cluster image details, if not passed in aws launchtemplate",
 			awsLaunchTemplate: expinfrav1.AWSLaunchTemplate{
@@ -1379,6 +2013,64 @@ func TestDiscoverLaunchTemplateAMI(t *testing.T) {
 				g.Expect(err).NotTo(HaveOccurred())
 			},
 		},
+		{
+			name: "Should look up the AMI of the image lookup architecture instead of the instance type one, if passed in aws launchtemplate",
+			awsLaunchTemplate: expinfrav1.AWSLaunchTemplate{
+				Name:                      "aws-launch-tmpl",
+				InstanceType:              "m5.large",
+				ImageLookupFormat:         "golden-{{.K8sVersion}}-*",
+				ImageLookupOrg:            "123456789012",
+				ImageLookupArchitecture:   "arm64",
+				ImageLookupRootDeviceType: "ebs",
+			},
+			machineTemplate: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Version: aws.String("v1.29.0"),
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("owner-id"),
+							Values: []*string{aws.String("123456789012")},
+						},
+						{
+							Name:   aws.String("name"),
+							Values: []*string{aws.String("golden-1.29.0-*")},
+						},
+						{
+							Name:   aws.String("architecture"),
+							Values: []*string{aws.String("arm64")},
+						},
+						{
+							Name:   aws.String("state"),
+							Values: []*string{aws.String("available")},
+						},
+						{
+							Name:   aws.String("virtualization-type"),
+							Values: []*string{aws.String("hvm")},
+						},
+						{
+							Name:   aws.String("root-device-type"),
+							Values: []*string{aws.String("ebs")},
+						},
+					},
+				})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								ImageId:      aws.String("latest"),
+								CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(res).Should(Equal(aws.String("latest")))
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
 		{
 			name: "Should return arm64 AMI and use infra cluster image details, if not passed in aws launchtemplate",
 			awsLaunchTemplate: expinfrav1.AWSLaunchTemplate{
@@ -1675,3 +2367,182 @@ func TestDeleteLaunchTemplateVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestPruneLaunchTemplateVersions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	version := func(number int64, isDefault bool) *ec2.LaunchTemplateVersion {
+		return &ec2.LaunchTemplateVersion{VersionNumber: aws.Int64(number), DefaultVersion: aws.Bool(isDefault)}
+	}
+
+	testCases := []struct {
+		name           string
+		versionsToKeep int64
+		expect         func(m *mocks.MockEC2APIMockRecorder)
+		wantErr        bool
+	}{
+		{
+			name:           "Should not delete any version if there are no versions beyond the ones to keep",
+			versionsToKeep: 2,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateId: aws.String("id"),
+				})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+					LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{version(1, true), version(2, false)},
+				}, nil)
+			},
+		},
+		{
+			name:           "Should delete the versions beyond the ones to keep, except the default version",
+			versionsToKeep: 2,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateId: aws.String("id"),
+				})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+					LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{version(1, true), version(2, false), version(3, false), version(5, false)},
+				}, nil)
+				m.DeleteLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteLaunchTemplateVersionsInput{
+					LaunchTemplateId: aws.String("id"),
+					Versions:         aws.StringSlice([]string{"3"}),
+				})).Return(&ec2.DeleteLaunchTemplateVersionsOutput{}, nil)
+				m.DeleteLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteLaunchTemplateVersionsInput{
+					LaunchTemplateId: aws.String("id"),
+					Versions:         aws.StringSlice([]string{"2"}),
+				})).Return(&ec2.DeleteLaunchTemplateVersionsOutput{}, nil)
+			},
+		},
+		{
+			name:           "Should keep more versions across pages of versions",
+			versionsToKeep: 3,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateId: aws.String("id"),
+				})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+					LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{version(1, true), version(2, false)},
+					NextToken:              aws.String("next"),
+				}, nil)
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateId: aws.String("id"),
+					NextToken:        aws.String("next"),
+				})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+					LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{version(3, false), version(4, false)},
+				}, nil)
+				m.DeleteLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteLaunchTemplateVersionsInput{
+					LaunchTemplateId: aws.String("id"),
+					Versions:         aws.StringSlice([]string{"2"}),
+				})).Return(&ec2.DeleteLaunchTemplateVersionsOutput{}, nil)
+			},
+		},
+		{
+			name:           "Should return error if AWS unable to describe launch template versions",
+			versionsToKeep: 2,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeLaunchTemplateVersionsInput{})).
+					Return(nil, awserrors.NewFailedDependency("dependency-failure"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+
+			tc.expect(ec2Mock.EXPECT())
+
+			err = s.PruneLaunchTemplateVersions("id", tc.versionsToKeep)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestGetSecurityGroupSelectorsIDs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	selectors := []infrav1.SecurityGroupSelector{{Tags: infrav1.Tags{"role": "monitoring", "env": "prod"}}}
+	expectDescribe := func(m *mocks.MockEC2APIMockRecorder, groupIDs ...string) {
+		groups := make([]*ec2.SecurityGroup, 0, len(groupIDs))
+		for _, id := range groupIDs {
+			groups = append(groups, &ec2.SecurityGroup{GroupId: aws.String(id)})
+		}
+		m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSecurityGroupsInput{
+			Filters: []*ec2.Filter{
+				{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-1"})},
+				{Name: aws.String("tag:env"), Values: aws.StringSlice([]string{"prod"})},
+				{Name: aws.String("tag:role"), Values: aws.StringSlice([]string{"monitoring"})},
+			},
+		})).Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: groups}, nil)
+	}
+
+	testCases := []struct {
+		name    string
+		expect  func(m *mocks.MockEC2APIMockRecorder)
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "returns the security group matched by the tags",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectDescribe(m, "sg-1")
+			},
+			want: []string{"sg-1"},
+		},
+		{
+			name: "fails when no security group matches",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectDescribe(m)
+			},
+			wantErr: "no security group",
+		},
+		{
+			name: "fails when the tags match several security groups",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectDescribe(m, "sg-1", "sg-2")
+			},
+			wantErr: "ambiguous",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			cs.VPC().ID = "vpc-1"
+			mockEC2Client := mocks.NewMockEC2API(mockCtrl)
+
+			s := NewService(cs)
+			s.EC2Client = mockEC2Client
+			tc.expect(mockEC2Client.EXPECT())
+
+			ids, err := s.GetSecurityGroupSelectorsIDs(selectors)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(ids).To(Equal(tc.want))
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// reconcilePlacementGroup creates the placement group, tagged as owned by the cluster, unless it already
+// exists. An existing placement group must have the requested strategy.
+func (s *Service) reconcilePlacementGroup(placementGroup *infrav1.PlacementGroup) error {
+	out, err := s.EC2Client.DescribePlacementGroupsWithContext(context.TODO(), &ec2.DescribePlacementGroupsInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("group-name"), Values: aws.StringSlice([]string{placementGroup.Name})},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe placement group %q", placementGroup.Name)
+	}
+
+	for _, pg := range out.PlacementGroups {
+		if aws.StringValue(pg.State) == ec2.PlacementGroupStateDeleted {
+			continue
+		}
+		if strategy := aws.StringValue(pg.Strategy); strategy != string(placementGroup.Strategy) {
+			return errors.Errorf("placement group %q already exists with strategy %q, expected %q", placementGroup.Name, strategy, placementGroup.Strategy)
+		}
+		return nil
+	}
+
+	input := &ec2.CreatePlacementGroupInput{
+		GroupName: aws.String(placementGroup.Name),
+		Strategy:  aws.String(string(placementGroup.Strategy)),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypePlacementGroup, infrav1.BuildParams{
+				ClusterName: s.scope.KubernetesClusterName(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        aws.String(placementGroup.Name),
+				Additional:  s.scope.AdditionalTags(),
+			}),
+		},
+	}
+	if placementGroup.PartitionCount != 0 {
+		input.PartitionCount = aws.Int64(placementGroup.PartitionCount)
+	}
+
+	if _, err := s.EC2Client.CreatePlacementGroupWithContext(context.TODO(), input); err != nil {
+		// The placement group may have been created concurrently for another machine.
+		if code, _ := awserrors.Code(errors.Cause(err)); code == awserrors.PlacementGroupDuplicate {
+			return nil
+		}
+		record.Warnf(s.scope.InfraCluster(), "FailedCreatePlacementGroup", "Failed to create placement group %q: %v", placementGroup.Name, err)
+		return errors.Wrapf(err, "failed to create placement group %q", placementGroup.Name)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreatePlacementGroup", "Created placement group %q with strategy %q", placementGroup.Name, placementGroup.Strategy)
+	s.scope.Info("Created placement group", "placement-group", placementGroup.Name, "strategy", placementGroup.Strategy)
+	return nil
+}
+
+// DeletePlacementGroups deletes the placement groups owned by the cluster. Placement groups can be shared by the
+// machines and machine pools of the cluster, so they are deleted along with the cluster, once its instances are
+// terminated.
+func (s *Service) DeletePlacementGroups() error {
+	out, err := s.EC2Client.DescribePlacementGroupsWithContext(context.TODO(), &ec2.DescribePlacementGroupsInput{
+		Filters: []*ec2.Filter{filter.EC2.ClusterOwned(s.scope.KubernetesClusterName())},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to describe placement groups")
+	}
+
+	for _, pg := range out.PlacementGroups {
+		if state := aws.StringValue(pg.State); state == ec2.PlacementGroupStateDeleting || state == ec2.PlacementGroupStateDeleted {
+			continue
+		}
+		name := aws.StringValue(pg.GroupName)
+		if _, err := s.EC2Client.DeletePlacementGroupWithContext(context.TODO(), &ec2.DeletePlacementGroupInput{
+			GroupName: pg.GroupName,
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeletePlacementGroup", "Failed to delete placement group %q: %v", name, err)
+			return errors.Wrapf(err, "failed to delete placement group %q", name)
+		}
+
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeletePlacementGroup", "Deleted placement group %q", name)
+		s.scope.Info("Deleted placement group", "placement-group", name)
+	}
+	return nil
+}
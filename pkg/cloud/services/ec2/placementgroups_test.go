@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcilePlacementGroup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeInput := &ec2.DescribePlacementGroupsInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("group-name"), Values: aws.StringSlice([]string{"etcd"})},
+		},
+	}
+	createInput := &ec2.CreatePlacementGroupInput{
+		GroupName:      aws.String("etcd"),
+		Strategy:       aws.String(ec2.PlacementStrategyPartition),
+		PartitionCount: aws.Int64(3),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypePlacementGroup),
+				Tags: []*ec2.Tag{
+					{Key: aws.String("Name"), Value: aws.String("etcd")},
+					{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("owned")},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		expect    func(m *mocks.MockEC2APIMockRecorder)
+		expectErr bool
+	}{
+		{
+			name: "placement group is created when it does not exist",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{GroupName: aws.String("etcd"), Strategy: aws.String(ec2.PlacementStrategyCluster), State: aws.String(ec2.PlacementGroupStateDeleted)},
+						},
+					}, nil)
+				m.CreatePlacementGroupWithContext(context.TODO(), gomock.Eq(createInput)).
+					Return(&ec2.CreatePlacementGroupOutput{}, nil)
+			},
+		},
+		{
+			name: "existing placement group is used",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{GroupName: aws.String("etcd"), Strategy: aws.String(ec2.PlacementStrategyPartition), State: aws.String(ec2.PlacementGroupStateAvailable)},
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "existing placement group has a different strategy",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{GroupName: aws.String("etcd"), Strategy: aws.String(ec2.PlacementStrategySpread), State: aws.String(ec2.PlacementGroupStateAvailable)},
+						},
+					}, nil)
+			},
+			expectErr: true,
+		},
+		{
+			name: "placement group created concurrently",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{}, nil)
+				m.CreatePlacementGroupWithContext(context.TODO(), gomock.Eq(createInput)).
+					Return(nil, awserr.New(awserrors.PlacementGroupDuplicate, "The Placement Group 'etcd' already exists.", nil))
+			},
+		},
+		{
+			name: "create fails",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{}, nil)
+				m.CreatePlacementGroupWithContext(context.TODO(), gomock.Eq(createInput)).
+					Return(nil, awserr.New(awserrors.UnauthorizedOperation, "not authorized", nil))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.reconcilePlacementGroup(&infrav1.PlacementGroup{
+				Name:           "etcd",
+				Strategy:       infrav1.PlacementGroupStrategyPartition,
+				PartitionCount: 3,
+			})
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestDeletePlacementGroups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeInput := &ec2.DescribePlacementGroupsInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Values: aws.StringSlice([]string{"owned"})},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		expect    func(m *mocks.MockEC2APIMockRecorder)
+		expectErr bool
+	}{
+		{
+			name: "owned placement groups are deleted",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{GroupName: aws.String("etcd"), State: aws.String(ec2.PlacementGroupStateAvailable)},
+							{GroupName: aws.String("workers"), State: aws.String(ec2.PlacementGroupStateDeleting)},
+						},
+					}, nil)
+				m.DeletePlacementGroupWithContext(context.TODO(), gomock.Eq(&ec2.DeletePlacementGroupInput{GroupName: aws.String("etcd")})).
+					Return(&ec2.DeletePlacementGroupOutput{}, nil)
+			},
+		},
+		{
+			name: "placement group still in use",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{GroupName: aws.String("etcd"), State: aws.String(ec2.PlacementGroupStateAvailable)},
+						},
+					}, nil)
+				m.DeletePlacementGroupWithContext(context.TODO(), gomock.Eq(&ec2.DeletePlacementGroupInput{GroupName: aws.String("etcd")})).
+					Return(nil, awserr.New("InvalidPlacementGroup.InUse", "The placement group 'etcd' is in use.", nil))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.DeletePlacementGroups()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
@@ -19,7 +19,9 @@ package ec2
 
 import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
@@ -35,6 +37,10 @@ type Service struct {
 
 	// SSMClient is used to look up the official EKS AMI ID
 	SSMClient ssmiface.SSMAPI
+
+	// KMSClient and STSClient are used to check the customer managed KMS keys of the volumes
+	KMSClient kmsiface.KMSAPI
+	STSClient stsiface.STSAPI
 }
 
 // NewService returns a new service given the ec2 api client.
@@ -43,6 +49,8 @@ func NewService(clusterScope scope.EC2Scope) *Service {
 		scope:      clusterScope,
 		EC2Client:  scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
 		SSMClient:  scope.NewSSMClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		KMSClient:  scope.NewKMSClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		STSClient:  scope.NewSTSClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
 		netService: network.NewService(clusterScope.(scope.NetworkScope)),
 	}
 }
@@ -0,0 +1,343 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// reconcileAccessEntries creates, updates and deletes the access entries of the cluster, and their access
+// policies, to match the spec. Only the access entries created by the controller are deleted, recreated or
+// have access policies removed, so that the access entries created outside of the controller, such as the
+// one of the cluster creator, keep their access.
+func (s *Service) reconcileAccessEntries(ctx context.Context) error {
+	if s.scope.ControlPlane.Spec.GetAuthenticationMode() == ekscontrolplanev1.EKSAuthenticationModeConfigMap {
+		return nil
+	}
+	s.scope.Debug("Reconciling EKS access entries")
+
+	eksClusterName := s.scope.KubernetesClusterName()
+
+	existing := map[string]struct{}{}
+	if err := s.EKSClient.ListAccessEntriesPagesWithContext(ctx, &eks.ListAccessEntriesInput{
+		ClusterName: aws.String(eksClusterName),
+	}, func(page *eks.ListAccessEntriesOutput, _ bool) bool {
+		for _, principalARN := range page.AccessEntries {
+			existing[aws.StringValue(principalARN)] = struct{}{}
+		}
+		return true
+	}); err != nil {
+		return errors.Wrap(err, "failed to list access entries")
+	}
+
+	desired := map[string]struct{}{}
+	accessEntries := s.desiredAccessEntries()
+	for i := range accessEntries {
+		entry := &accessEntries[i]
+		desired[entry.PrincipalARN] = struct{}{}
+
+		owned := true
+		if _, ok := existing[entry.PrincipalARN]; ok {
+			var err error
+			if owned, err = s.updateAccessEntry(ctx, entry); err != nil {
+				return err
+			}
+		} else if err := s.createAccessEntry(ctx, entry); err != nil {
+			return err
+		}
+
+		if err := s.reconcileAccessPolicies(ctx, entry, owned); err != nil {
+			return err
+		}
+	}
+
+	for principalARN := range existing {
+		if _, ok := desired[principalARN]; ok {
+			continue
+		}
+		if err := s.deleteOwnedAccessEntry(ctx, principalARN); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// desiredAccessEntries returns the access entries of the spec. With the API authentication mode, the aws-auth
+// role and user mappings are converted to access entries as the aws-auth config map isn't used by the cluster.
+func (s *Service) desiredAccessEntries() []ekscontrolplanev1.AccessEntry {
+	var accessEntries []ekscontrolplanev1.AccessEntry
+	if s.scope.ControlPlane.Spec.AccessConfig != nil {
+		accessEntries = append(accessEntries, s.scope.ControlPlane.Spec.AccessConfig.AccessEntries...)
+	}
+
+	iamAuthConfig := s.scope.ControlPlane.Spec.IAMAuthenticatorConfig
+	if s.scope.ControlPlane.Spec.GetAuthenticationMode() != ekscontrolplanev1.EKSAuthenticationModeAPI || iamAuthConfig == nil {
+		return accessEntries
+	}
+	for _, mapping := range iamAuthConfig.RoleMappings {
+		accessEntries = append(accessEntries, ekscontrolplanev1.AccessEntry{
+			PrincipalARN:     mapping.RoleARN,
+			Type:             ekscontrolplanev1.AccessEntryTypeStandard,
+			KubernetesGroups: mapping.Groups,
+			Username:         mapping.UserName,
+		})
+	}
+	for _, mapping := range iamAuthConfig.UserMappings {
+		accessEntries = append(accessEntries, ekscontrolplanev1.AccessEntry{
+			PrincipalARN:     mapping.UserARN,
+			Type:             ekscontrolplanev1.AccessEntryTypeStandard,
+			KubernetesGroups: mapping.Groups,
+			Username:         mapping.UserName,
+		})
+	}
+
+	return accessEntries
+}
+
+func (s *Service) createAccessEntry(ctx context.Context, entry *ekscontrolplanev1.AccessEntry) error {
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Additional:  s.scope.AdditionalTags(),
+	})
+
+	input := &eks.CreateAccessEntryInput{
+		ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+		PrincipalArn: aws.String(entry.PrincipalARN),
+		Type:         aws.String(string(accessEntryType(entry))),
+		Tags:         aws.StringMap(tags),
+	}
+	if len(entry.KubernetesGroups) > 0 {
+		input.KubernetesGroups = aws.StringSlice(entry.KubernetesGroups)
+	}
+	if entry.Username != "" {
+		input.Username = aws.String(entry.Username)
+	}
+
+	if _, err := s.EKSClient.CreateAccessEntryWithContext(ctx, input); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedCreateEKSAccessEntry", "Failed to create access entry for %s: %v", entry.PrincipalARN, err)
+		return errors.Wrapf(err, "failed to create access entry for %s", entry.PrincipalARN)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulCreateEKSAccessEntry", "Created access entry for %s", entry.PrincipalARN)
+
+	return nil
+}
+
+// updateAccessEntry updates the kubernetes groups and username of an access entry, and returns whether it was
+// created by the controller. The type of an access entry cannot be changed, the access entry is recreated instead
+// if it was created by the controller.
+func (s *Service) updateAccessEntry(ctx context.Context, entry *ekscontrolplanev1.AccessEntry) (bool, error) {
+	out, err := s.EKSClient.DescribeAccessEntryWithContext(ctx, &eks.DescribeAccessEntryInput{
+		ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+		PrincipalArn: aws.String(entry.PrincipalARN),
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to describe access entry for %s", entry.PrincipalARN)
+	}
+	current := out.AccessEntry
+	owned := infrav1.Tags(aws.StringValueMap(current.Tags)).HasOwned(s.scope.Name())
+
+	if aws.StringValue(current.Type) != string(accessEntryType(entry)) {
+		if !owned {
+			record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSAccessEntry", "Access entry for %s has type %s instead of %s, and is not recreated as it was not created by the controller",
+				entry.PrincipalARN, aws.StringValue(current.Type), accessEntryType(entry))
+			return false, nil
+		}
+		if _, err := s.EKSClient.DeleteAccessEntryWithContext(ctx, &eks.DeleteAccessEntryInput{
+			ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+			PrincipalArn: aws.String(entry.PrincipalARN),
+		}); err != nil {
+			return false, errors.Wrapf(err, "failed to delete access entry for %s", entry.PrincipalARN)
+		}
+		return true, s.createAccessEntry(ctx, entry)
+	}
+
+	groupsChanged := !stringSetsEqual(aws.StringValueSlice(current.KubernetesGroups), entry.KubernetesGroups)
+	// the username generated by EKS is kept if none is set
+	usernameChanged := entry.Username != "" && entry.Username != aws.StringValue(current.Username)
+	if !groupsChanged && !usernameChanged {
+		return owned, nil
+	}
+
+	input := &eks.UpdateAccessEntryInput{
+		ClusterName:      aws.String(s.scope.KubernetesClusterName()),
+		PrincipalArn:     aws.String(entry.PrincipalARN),
+		KubernetesGroups: aws.StringSlice(entry.KubernetesGroups),
+	}
+	if entry.Username != "" {
+		input.Username = aws.String(entry.Username)
+	}
+	if _, err := s.EKSClient.UpdateAccessEntryWithContext(ctx, input); err != nil {
+		return false, errors.Wrapf(err, "failed to update access entry for %s", entry.PrincipalARN)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulUpdateEKSAccessEntry", "Updated access entry for %s", entry.PrincipalARN)
+
+	return owned, nil
+}
+
+// reconcileAccessPolicies associates the access policies of the spec with the principal of an access entry,
+// and disassociates the other ones if the access entry was created by the controller.
+func (s *Service) reconcileAccessPolicies(ctx context.Context, entry *ekscontrolplanev1.AccessEntry, owned bool) error {
+	eksClusterName := s.scope.KubernetesClusterName()
+
+	associated := map[string]*eks.AccessScope{}
+	if err := s.EKSClient.ListAssociatedAccessPoliciesPagesWithContext(ctx, &eks.ListAssociatedAccessPoliciesInput{
+		ClusterName:  aws.String(eksClusterName),
+		PrincipalArn: aws.String(entry.PrincipalARN),
+	}, func(page *eks.ListAssociatedAccessPoliciesOutput, _ bool) bool {
+		for _, policy := range page.AssociatedAccessPolicies {
+			associated[aws.StringValue(policy.PolicyArn)] = policy.AccessScope
+		}
+		return true
+	}); err != nil {
+		return errors.Wrapf(err, "failed to list access policies of %s", entry.PrincipalARN)
+	}
+
+	desired := map[string]struct{}{}
+	for _, policy := range entry.AccessPolicies {
+		desired[policy.PolicyARN] = struct{}{}
+		scope := makeEksAccessScope(policy.AccessScope)
+		if current, ok := associated[policy.PolicyARN]; ok && accessScopesEqual(current, scope) {
+			continue
+		}
+
+		// associating a policy which is already associated updates its scope
+		if _, err := s.EKSClient.AssociateAccessPolicyWithContext(ctx, &eks.AssociateAccessPolicyInput{
+			ClusterName:  aws.String(eksClusterName),
+			PrincipalArn: aws.String(entry.PrincipalARN),
+			PolicyArn:    aws.String(policy.PolicyARN),
+			AccessScope:  scope,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to associate access policy %s with %s", policy.PolicyARN, entry.PrincipalARN)
+		}
+	}
+
+	for policyARN := range associated {
+		if _, ok := desired[policyARN]; ok || !owned {
+			continue
+		}
+		if _, err := s.EKSClient.DisassociateAccessPolicyWithContext(ctx, &eks.DisassociateAccessPolicyInput{
+			ClusterName:  aws.String(eksClusterName),
+			PrincipalArn: aws.String(entry.PrincipalARN),
+			PolicyArn:    aws.String(policyARN),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to disassociate access policy %s from %s", policyARN, entry.PrincipalARN)
+		}
+	}
+
+	return nil
+}
+
+// deleteOwnedAccessEntry deletes an access entry if it was created by the controller.
+func (s *Service) deleteOwnedAccessEntry(ctx context.Context, principalARN string) error {
+	out, err := s.EKSClient.DescribeAccessEntryWithContext(ctx, &eks.DescribeAccessEntryInput{
+		ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+		PrincipalArn: aws.String(principalARN),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eks.ErrCodeResourceNotFoundException {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to describe access entry for %s", principalARN)
+	}
+
+	tags := infrav1.Tags(aws.StringValueMap(out.AccessEntry.Tags))
+	if !tags.HasOwned(s.scope.Name()) {
+		return nil
+	}
+
+	if _, err := s.EKSClient.DeleteAccessEntryWithContext(ctx, &eks.DeleteAccessEntryInput{
+		ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+		PrincipalArn: aws.String(principalARN),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to delete access entry for %s", principalARN)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulDeleteEKSAccessEntry", "Deleted access entry for %s", principalARN)
+
+	return nil
+}
+
+func makeEksAccessConfig(accessConfig *ekscontrolplanev1.AccessConfig) *eks.CreateAccessConfigRequest {
+	if accessConfig == nil {
+		return nil
+	}
+
+	req := &eks.CreateAccessConfigRequest{
+		BootstrapClusterCreatorAdminPermissions: accessConfig.BootstrapClusterCreatorAdminPermissions,
+	}
+	if accessConfig.AuthenticationMode != "" {
+		req.AuthenticationMode = aws.String(string(accessConfig.AuthenticationMode))
+	}
+	return req
+}
+
+func makeEksAccessScope(accessScope ekscontrolplanev1.AccessScope) *eks.AccessScope {
+	scopeType := accessScope.Type
+	if scopeType == "" {
+		scopeType = ekscontrolplanev1.AccessScopeTypeCluster
+	}
+
+	scope := &eks.AccessScope{
+		Type: aws.String(string(scopeType)),
+	}
+	if len(accessScope.Namespaces) > 0 {
+		scope.Namespaces = aws.StringSlice(accessScope.Namespaces)
+	}
+	return scope
+}
+
+func accessEntryType(entry *ekscontrolplanev1.AccessEntry) ekscontrolplanev1.AccessEntryType {
+	if entry.Type == "" {
+		return ekscontrolplanev1.AccessEntryTypeStandard
+	}
+	return entry.Type
+}
+
+func accessScopesEqual(a, b *eks.AccessScope) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return aws.StringValue(a.Type) == aws.StringValue(b.Type) &&
+		stringSetsEqual(aws.StringValueSlice(a.Namespaces), aws.StringValueSlice(b.Namespaces))
+}
+
+func stringSetsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	as := append([]string{}, a...)
+	bs := append([]string{}, b...)
+	sort.Strings(as)
+	sort.Strings(bs)
+	for i := range as {
+		if as[i] != bs[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,366 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileAccessEntries(t *testing.T) {
+	clusterName := "default.cluster"
+	principalARN := "arn:aws:iam::123456789012:role/admin"
+	policyARN := "arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy"
+
+	listEntries := func(m *mock_eksiface.MockEKSAPIMockRecorder, principalARNs ...string) {
+		m.ListAccessEntriesPagesWithContext(gomock.Any(), &eks.ListAccessEntriesInput{ClusterName: aws.String(clusterName)}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *eks.ListAccessEntriesInput, fn func(*eks.ListAccessEntriesOutput, bool) bool, _ ...request.Option) error {
+				fn(&eks.ListAccessEntriesOutput{AccessEntries: aws.StringSlice(principalARNs)}, true)
+				return nil
+			})
+	}
+	listPolicies := func(m *mock_eksiface.MockEKSAPIMockRecorder, policies ...*eks.AssociatedAccessPolicy) {
+		m.ListAssociatedAccessPoliciesPagesWithContext(gomock.Any(), &eks.ListAssociatedAccessPoliciesInput{
+			ClusterName:  aws.String(clusterName),
+			PrincipalArn: aws.String(principalARN),
+		}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *eks.ListAssociatedAccessPoliciesInput, fn func(*eks.ListAssociatedAccessPoliciesOutput, bool) bool, _ ...request.Option) error {
+				fn(&eks.ListAssociatedAccessPoliciesOutput{AssociatedAccessPolicies: policies}, true)
+				return nil
+			})
+	}
+
+	ownedTags := aws.StringMap(map[string]string{
+		infrav1.ClusterTagKey(clusterName): string(infrav1.ResourceLifecycleOwned),
+	})
+
+	adminEntry := ekscontrolplanev1.AccessEntry{
+		PrincipalARN:     principalARN,
+		KubernetesGroups: []string{"admins"},
+		AccessPolicies: []ekscontrolplanev1.AccessPolicyReference{
+			{
+				PolicyARN: policyARN,
+				AccessScope: ekscontrolplanev1.AccessScope{
+					Type: ekscontrolplanev1.AccessScopeTypeCluster,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		accessConfig  *ekscontrolplanev1.AccessConfig
+		iamAuthConfig *ekscontrolplanev1.IAMAuthenticatorConfig
+		expect        func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectError   bool
+	}{
+		{
+			name:         "config map authentication mode",
+			accessConfig: nil,
+			expect:       func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+		},
+		{
+			name: "creates missing access entry and associates its policies",
+			accessConfig: &ekscontrolplanev1.AccessConfig{
+				AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI,
+				AccessEntries:      []ekscontrolplanev1.AccessEntry{adminEntry},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m)
+				m.CreateAccessEntryWithContext(gomock.Any(), &eks.CreateAccessEntryInput{
+					ClusterName:      aws.String(clusterName),
+					PrincipalArn:     aws.String(principalARN),
+					Type:             aws.String("STANDARD"),
+					KubernetesGroups: aws.StringSlice([]string{"admins"}),
+					Tags: aws.StringMap(map[string]string{
+						infrav1.ClusterTagKey(clusterName): string(infrav1.ResourceLifecycleOwned),
+					}),
+				}).Return(&eks.CreateAccessEntryOutput{}, nil)
+				listPolicies(m)
+				m.AssociateAccessPolicyWithContext(gomock.Any(), &eks.AssociateAccessPolicyInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String(principalARN),
+					PolicyArn:    aws.String(policyARN),
+					AccessScope:  &eks.AccessScope{Type: aws.String("cluster")},
+				}).Return(&eks.AssociateAccessPolicyOutput{}, nil)
+			},
+		},
+		{
+			name: "updates access entry and disassociates removed policies",
+			accessConfig: &ekscontrolplanev1.AccessConfig{
+				AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPIAndConfigMap,
+				AccessEntries:      []ekscontrolplanev1.AccessEntry{adminEntry},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m, principalARN)
+				m.DescribeAccessEntryWithContext(gomock.Any(), gomock.Any()).Return(&eks.DescribeAccessEntryOutput{
+					AccessEntry: &eks.AccessEntry{
+						PrincipalArn:     aws.String(principalARN),
+						Type:             aws.String("STANDARD"),
+						KubernetesGroups: aws.StringSlice([]string{"viewers"}),
+						Username:         aws.String("generated"),
+						Tags:             ownedTags,
+					},
+				}, nil)
+				m.UpdateAccessEntryWithContext(gomock.Any(), &eks.UpdateAccessEntryInput{
+					ClusterName:      aws.String(clusterName),
+					PrincipalArn:     aws.String(principalARN),
+					KubernetesGroups: aws.StringSlice([]string{"admins"}),
+				}).Return(&eks.UpdateAccessEntryOutput{}, nil)
+				listPolicies(m,
+					&eks.AssociatedAccessPolicy{PolicyArn: aws.String(policyARN), AccessScope: &eks.AccessScope{Type: aws.String("cluster")}},
+					&eks.AssociatedAccessPolicy{PolicyArn: aws.String("arn:aws:eks::aws:cluster-access-policy/AmazonEKSViewPolicy"), AccessScope: &eks.AccessScope{Type: aws.String("cluster")}},
+				)
+				m.DisassociateAccessPolicyWithContext(gomock.Any(), &eks.DisassociateAccessPolicyInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String(principalARN),
+					PolicyArn:    aws.String("arn:aws:eks::aws:cluster-access-policy/AmazonEKSViewPolicy"),
+				}).Return(&eks.DisassociateAccessPolicyOutput{}, nil)
+			},
+		},
+		{
+			name: "recreates access entry whose type changed",
+			accessConfig: &ekscontrolplanev1.AccessConfig{
+				AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI,
+				AccessEntries: []ekscontrolplanev1.AccessEntry{
+					{PrincipalARN: principalARN, Type: ekscontrolplanev1.AccessEntryTypeEC2Linux},
+				},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m, principalARN)
+				m.DescribeAccessEntryWithContext(gomock.Any(), gomock.Any()).Return(&eks.DescribeAccessEntryOutput{
+					AccessEntry: &eks.AccessEntry{
+						PrincipalArn: aws.String(principalARN),
+						Type:         aws.String("STANDARD"),
+						Tags:         ownedTags,
+					},
+				}, nil)
+				m.DeleteAccessEntryWithContext(gomock.Any(), gomock.Any()).Return(&eks.DeleteAccessEntryOutput{}, nil)
+				m.CreateAccessEntryWithContext(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, input *eks.CreateAccessEntryInput, _ ...request.Option) (*eks.CreateAccessEntryOutput, error) {
+						if aws.StringValue(input.Type) != "EC2_LINUX" {
+							return nil, awserr.New("InvalidParameterException", "unexpected type", nil)
+						}
+						return &eks.CreateAccessEntryOutput{}, nil
+					})
+				listPolicies(m)
+			},
+		},
+		{
+			name: "keeps the access policies of an access entry not created by the controller",
+			accessConfig: &ekscontrolplanev1.AccessConfig{
+				AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI,
+				AccessEntries: []ekscontrolplanev1.AccessEntry{
+					{
+						PrincipalARN: principalARN,
+						AccessPolicies: []ekscontrolplanev1.AccessPolicyReference{
+							{PolicyARN: "arn:aws:eks::aws:cluster-access-policy/AmazonEKSViewPolicy"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m, principalARN)
+				m.DescribeAccessEntryWithContext(gomock.Any(), gomock.Any()).Return(&eks.DescribeAccessEntryOutput{
+					AccessEntry: &eks.AccessEntry{
+						PrincipalArn: aws.String(principalARN),
+						Type:         aws.String("STANDARD"),
+					},
+				}, nil)
+				listPolicies(m,
+					&eks.AssociatedAccessPolicy{PolicyArn: aws.String(policyARN), AccessScope: &eks.AccessScope{Type: aws.String("cluster")}},
+				)
+				m.AssociateAccessPolicyWithContext(gomock.Any(), &eks.AssociateAccessPolicyInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String(principalARN),
+					PolicyArn:    aws.String("arn:aws:eks::aws:cluster-access-policy/AmazonEKSViewPolicy"),
+					AccessScope:  &eks.AccessScope{Type: aws.String("cluster")},
+				}).Return(&eks.AssociateAccessPolicyOutput{}, nil)
+			},
+		},
+		{
+			name: "does not recreate an access entry not created by the controller whose type changed",
+			accessConfig: &ekscontrolplanev1.AccessConfig{
+				AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI,
+				AccessEntries: []ekscontrolplanev1.AccessEntry{
+					{PrincipalARN: principalARN, Type: ekscontrolplanev1.AccessEntryTypeEC2Linux},
+				},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m, principalARN)
+				m.DescribeAccessEntryWithContext(gomock.Any(), gomock.Any()).Return(&eks.DescribeAccessEntryOutput{
+					AccessEntry: &eks.AccessEntry{
+						PrincipalArn: aws.String(principalARN),
+						Type:         aws.String("STANDARD"),
+					},
+				}, nil)
+				listPolicies(m)
+			},
+		},
+		{
+			name: "deletes only owned access entries which are not in the spec",
+			accessConfig: &ekscontrolplanev1.AccessConfig{
+				AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI,
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m, "arn:aws:iam::123456789012:role/owned", "arn:aws:iam::123456789012:role/nodes")
+				m.DescribeAccessEntryWithContext(gomock.Any(), &eks.DescribeAccessEntryInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String("arn:aws:iam::123456789012:role/owned"),
+				}).Return(&eks.DescribeAccessEntryOutput{
+					AccessEntry: &eks.AccessEntry{
+						Tags: aws.StringMap(map[string]string{
+							infrav1.ClusterTagKey(clusterName): string(infrav1.ResourceLifecycleOwned),
+						}),
+					},
+				}, nil)
+				m.DescribeAccessEntryWithContext(gomock.Any(), &eks.DescribeAccessEntryInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String("arn:aws:iam::123456789012:role/nodes"),
+				}).Return(&eks.DescribeAccessEntryOutput{
+					AccessEntry: &eks.AccessEntry{},
+				}, nil)
+				m.DeleteAccessEntryWithContext(gomock.Any(), &eks.DeleteAccessEntryInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String("arn:aws:iam::123456789012:role/owned"),
+				}).Return(&eks.DeleteAccessEntryOutput{}, nil)
+			},
+		},
+		{
+			name: "creates access entries for aws-auth mappings with the API authentication mode",
+			accessConfig: &ekscontrolplanev1.AccessConfig{
+				AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI,
+			},
+			iamAuthConfig: &ekscontrolplanev1.IAMAuthenticatorConfig{
+				UserMappings: []ekscontrolplanev1.UserMapping{
+					{
+						UserARN: principalARN,
+						KubernetesMapping: ekscontrolplanev1.KubernetesMapping{
+							UserName: "admin",
+							Groups:   []string{"admins"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m)
+				m.CreateAccessEntryWithContext(gomock.Any(), &eks.CreateAccessEntryInput{
+					ClusterName:      aws.String(clusterName),
+					PrincipalArn:     aws.String(principalARN),
+					Type:             aws.String("STANDARD"),
+					KubernetesGroups: aws.StringSlice([]string{"admins"}),
+					Username:         aws.String("admin"),
+					Tags: aws.StringMap(map[string]string{
+						infrav1.ClusterTagKey(clusterName): string(infrav1.ResourceLifecycleOwned),
+					}),
+				}).Return(&eks.CreateAccessEntryOutput{}, nil)
+				listPolicies(m)
+			},
+		},
+		{
+			name: "ignores aws-auth mappings with the API_AND_CONFIG_MAP authentication mode",
+			accessConfig: &ekscontrolplanev1.AccessConfig{
+				AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPIAndConfigMap,
+			},
+			iamAuthConfig: &ekscontrolplanev1.IAMAuthenticatorConfig{
+				UserMappings: []ekscontrolplanev1.UserMapping{
+					{
+						UserARN: principalARN,
+						KubernetesMapping: ekscontrolplanev1.KubernetesMapping{
+							UserName: "admin",
+							Groups:   []string{"system:masters"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m)
+			},
+		},
+		{
+			name: "list error",
+			accessConfig: &ekscontrolplanev1.AccessConfig{
+				AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI,
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.ListAccessEntriesPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(awserr.New(eks.ErrCodeServerException, "", nil))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName:         clusterName,
+						AccessConfig:           tc.accessConfig,
+						IAMAuthenticatorConfig: tc.iamAuthConfig,
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			tc.expect(eksMock.EXPECT())
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			err = s.reconcileAccessEntries(context.TODO())
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
@@ -19,6 +19,7 @@ package eks
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/eks"
@@ -28,6 +29,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	eksaddons "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/addons"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func (s *Service) reconcileAddons(ctx context.Context) error {
@@ -50,11 +53,16 @@ func (s *Service) reconcileAddons(ctx context.Context) error {
 	}
 
 	// Get the addons from the spec we want for the cluster
-	desiredAddons := s.translateAPIToAddon(s.scope.Addons())
+	addons, err := s.desiredAddons(installed)
+	if err != nil {
+		return fmt.Errorf("getting desired eks addons: %w", err)
+	}
+	desiredAddons := s.translateAPIToAddon(addons)
 
 	// If there are no addons desired or installed then do nothing
 	if len(installed) == 0 && len(desiredAddons) == 0 {
 		s.scope.Info("no addons installed and no addons to install, no action needed")
+		conditions.Delete(s.scope.ControlPlane, ekscontrolplanev1.EKSAddonsHealthyCondition)
 		return nil
 	}
 
@@ -85,7 +93,11 @@ func (s *Service) reconcileAddons(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("getting installed state of eks addons: %w", err)
 	}
+	for i := range addonState {
+		addonState[i].PreserveOnDelete = s.addonPreserveOnDelete(addonState[i].Name)
+	}
 	s.scope.ControlPlane.Status.Addons = addonState
+	s.reconcileAddonsHealth()
 
 	// Persist status and record event
 	if err := s.scope.PatchObject(); err != nil {
@@ -129,6 +141,7 @@ func (s *Service) getClusterAddonsInstalled(eksClusterName string, addonNames []
 			Tags:                  infrav1.Tags{},
 			Status:                describeOutput.Addon.Status,
 			ServiceAccountRoleARN: describeOutput.Addon.ServiceAccountRoleArn,
+			Preserve:              s.addonPreserveOnDelete(*describeOutput.Addon.AddonName),
 		}
 		for k, v := range describeOutput.Addon.Tags {
 			installedAddon.Tags[k] = *v
@@ -199,8 +212,9 @@ func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon) []*eksad
 			Version:               &addon.Version,
 			Configuration:         &addon.Configuration,
 			Tags:                  ngTags(s.scope.Cluster.Name, s.scope.AdditionalTags()),
-			ResolveConflict:       convertConflictResolution(*addon.ConflictResolution),
+			ResolveConflict:       convertConflictResolution(addon.ConflictResolution),
 			ServiceAccountRoleARN: addon.ServiceAccountRoleArn,
+			Preserve:              addon.PreserveOnDelete,
 		}
 
 		converted = append(converted, convertedAddon)
@@ -209,9 +223,62 @@ func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon) []*eksad
 	return converted
 }
 
-func convertConflictResolution(conflict ekscontrolplanev1.AddonResolution) *string {
-	if conflict == ekscontrolplanev1.AddonResolutionNone {
+func convertConflictResolution(conflict *ekscontrolplanev1.AddonResolution) *string {
+	if conflict == nil {
+		return aws.String(eks.ResolveConflictsOverwrite)
+	}
+	switch *conflict {
+	case ekscontrolplanev1.AddonResolutionNone:
 		return aws.String(eks.ResolveConflictsNone)
+	case ekscontrolplanev1.AddonResolutionPreserve:
+		return aws.String(eks.ResolveConflictsPreserve)
+	default:
+		return aws.String(eks.ResolveConflictsOverwrite)
+	}
+}
+
+// addonPreserveOnDelete returns whether the resources of an addon are kept when it is deleted. The setting of
+// an addon removed from the spec is taken from the status, where it was recorded while the addon was desired.
+func (s *Service) addonPreserveOnDelete(name string) bool {
+	for _, addon := range s.scope.Addons() {
+		if addon.Name == name {
+			return addon.PreserveOnDelete
+		}
+	}
+	for _, state := range s.scope.ControlPlane.Status.Addons {
+		if state.Name == name {
+			return state.PreserveOnDelete
+		}
+	}
+	return false
+}
+
+// reconcileAddonsHealth reports the addons which EKS reports as degraded or failed.
+func (s *Service) reconcileAddonsHealth() {
+	unhealthy := []string{}
+	for _, state := range s.scope.ControlPlane.Status.Addons {
+		switch aws.StringValue(state.Status) {
+		case eks.AddonStatusDegraded, eks.AddonStatusCreateFailed, eks.AddonStatusUpdateFailed, eks.AddonStatusDeleteFailed:
+		default:
+			continue
+		}
+
+		message := fmt.Sprintf("%s is %s", state.Name, aws.StringValue(state.Status))
+		issues := []string{}
+		for _, issue := range state.Issues {
+			issues = append(issues, fmt.Sprintf("%s: %s", aws.StringValue(issue.Code), aws.StringValue(issue.Message)))
+		}
+		if len(issues) > 0 {
+			message = fmt.Sprintf("%s (%s)", message, strings.Join(issues, ", "))
+		}
+		unhealthy = append(unhealthy, message)
+	}
+
+	if len(unhealthy) == 0 {
+		conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSAddonsHealthyCondition)
+		return
 	}
-	return aws.String(eks.ResolveConflictsOverwrite)
+	// Addons may be degraded until there are worker nodes, so this is only a warning.
+	conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSAddonsHealthyCondition, ekscontrolplanev1.EKSAddonsDegradedReason, clusterv1.ConditionSeverityWarning,
+		"unhealthy addons: %s", strings.Join(unhealthy, "; "))
 }
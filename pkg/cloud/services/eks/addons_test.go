@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func newTestAddonsService(t *testing.T, addons []ekscontrolplanev1.Addon, states []ekscontrolplanev1.AddonState) *Service {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = ekscontrolplanev1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "default.cluster",
+			},
+		},
+		ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+			Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+				EKSClusterName: "default.cluster",
+				Addons:         &addons,
+			},
+			Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+				Addons: states,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewService(scope)
+}
+
+func TestReconcileAddonsHealth(t *testing.T) {
+	tests := []struct {
+		name           string
+		states         []ekscontrolplanev1.AddonState
+		expectHealthy  bool
+		expectedDetail string
+	}{
+		{
+			name: "all addons active",
+			states: []ekscontrolplanev1.AddonState{
+				{Name: "vpc-cni", Status: aws.String(eks.AddonStatusActive)},
+				{Name: "kube-proxy", Status: aws.String(eks.AddonStatusUpdating)},
+			},
+			expectHealthy: true,
+		},
+		{
+			name: "degraded addon",
+			states: []ekscontrolplanev1.AddonState{
+				{Name: "vpc-cni", Status: aws.String(eks.AddonStatusActive)},
+				{
+					Name:   "coredns",
+					Status: aws.String(eks.AddonStatusDegraded),
+					Issues: []ekscontrolplanev1.AddonIssue{
+						{Code: aws.String(eks.AddonIssueCodeInsufficientNumberOfReplicas), Message: aws.String("no nodes")},
+					},
+				},
+			},
+			expectedDetail: "coredns is DEGRADED (InsufficientNumberOfReplicas: no nodes)",
+		},
+		{
+			name: "failed addon update",
+			states: []ekscontrolplanev1.AddonState{
+				{Name: "kube-proxy", Status: aws.String(eks.AddonStatusUpdateFailed)},
+			},
+			expectedDetail: "kube-proxy is UPDATE_FAILED",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := newTestAddonsService(t, nil, tc.states)
+			s.reconcileAddonsHealth()
+
+			controlPlane := s.scope.ControlPlane
+			if tc.expectHealthy {
+				g.Expect(conditions.IsTrue(controlPlane, ekscontrolplanev1.EKSAddonsHealthyCondition)).To(BeTrue())
+				return
+			}
+			g.Expect(conditions.IsFalse(controlPlane, ekscontrolplanev1.EKSAddonsHealthyCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(controlPlane, ekscontrolplanev1.EKSAddonsHealthyCondition)).To(Equal(ekscontrolplanev1.EKSAddonsDegradedReason))
+			g.Expect(conditions.GetMessage(controlPlane, ekscontrolplanev1.EKSAddonsHealthyCondition)).To(ContainSubstring(tc.expectedDetail))
+		})
+	}
+}
+
+func TestAddonPreserveOnDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newTestAddonsService(t,
+		[]ekscontrolplanev1.Addon{
+			{Name: "vpc-cni", PreserveOnDelete: true},
+			{Name: "kube-proxy"},
+		},
+		[]ekscontrolplanev1.AddonState{
+			{Name: "kube-proxy", PreserveOnDelete: true},
+			{Name: "coredns", PreserveOnDelete: true},
+		},
+	)
+
+	// The spec takes precedence over the recorded status.
+	g.Expect(s.addonPreserveOnDelete("vpc-cni")).To(BeTrue())
+	g.Expect(s.addonPreserveOnDelete("kube-proxy")).To(BeFalse())
+	// Addons removed from the spec use the recorded status.
+	g.Expect(s.addonPreserveOnDelete("coredns")).To(BeTrue())
+	g.Expect(s.addonPreserveOnDelete("aws-ebs-csi-driver")).To(BeFalse())
+}
+
+func TestConvertConflictResolution(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(convertConflictResolution(nil)).To(Equal(aws.String(eks.ResolveConflictsOverwrite)))
+	g.Expect(convertConflictResolution(&ekscontrolplanev1.AddonResolutionOverwrite)).To(Equal(aws.String(eks.ResolveConflictsOverwrite)))
+	g.Expect(convertConflictResolution(&ekscontrolplanev1.AddonResolutionNone)).To(Equal(aws.String(eks.ResolveConflictsNone)))
+	g.Expect(convertConflictResolution(&ekscontrolplanev1.AddonResolutionPreserve)).To(Equal(aws.String(eks.ResolveConflictsPreserve)))
+}
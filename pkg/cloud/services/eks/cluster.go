@@ -28,6 +28,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/klog/v2"
@@ -49,6 +50,10 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 
 	eksClusterName := s.scope.KubernetesClusterName()
 
+	if err := s.reconcileEncryptionKey(); err != nil {
+		return errors.Wrap(err, "failed reconciling encryption key")
+	}
+
 	cluster, err := s.describeEKSCluster(eksClusterName)
 	if err != nil {
 		return errors.Wrap(err, "failed to describe eks clusters")
@@ -81,7 +86,12 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 
 	// Wait for our cluster to be ready if necessary
 	switch *cluster.Status {
-	case eks.ClusterStatusUpdating, eks.ClusterStatusCreating:
+	case eks.ClusterStatusUpdating:
+		if err := s.checkClusterUpdateDeadline(); err != nil {
+			return err
+		}
+		cluster, err = s.waitForClusterActive()
+	case eks.ClusterStatusCreating:
 		cluster, err = s.waitForClusterActive()
 	default:
 		break
@@ -89,6 +99,9 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to wait for cluster to be active")
 	}
+	if conditions.GetReason(s.scope.ControlPlane, infrav1.AsyncOperationsReadyCondition) == infrav1.EKSControlPlaneUpdateTimedOutReason {
+		conditions.MarkTrue(s.scope.ControlPlane, infrav1.AsyncOperationsReadyCondition)
+	}
 
 	if !s.scope.ControlPlane.Status.Ready {
 		return nil
@@ -125,6 +138,10 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 		return errors.Wrap(err, "failed reconciling logging")
 	}
 
+	if err := s.reconcileAccessConfig(cluster.AccessConfig); err != nil {
+		return errors.Wrap(err, "failed reconciling access config")
+	}
+
 	if err := s.reconcileEKSEncryptionConfig(cluster.EncryptionConfig); err != nil {
 		return errors.Wrap(err, "failed reconciling eks encryption config")
 	}
@@ -137,6 +154,10 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 		return errors.Wrap(err, "failed reconciling OIDC provider for cluster")
 	}
 
+	if err := s.reconcileIRSARoles(); err != nil {
+		return errors.Wrap(err, "failed reconciling IRSA roles")
+	}
+
 	return nil
 }
 
@@ -358,9 +379,29 @@ func makeEksLogging(loggingSpec *ekscontrolplanev1.ControlPlaneLoggingSpec) *eks
 	return nil
 }
 
+// loggingEqual returns whether the log types enabled on the cluster are the ones of the spec. Log types
+// missing from the cluster logging are disabled.
+func loggingEqual(loggingSpec *ekscontrolplanev1.ControlPlaneLoggingSpec, logging *eks.Logging) bool {
+	enabled := map[string]bool{}
+	if logging != nil {
+		for _, logSetup := range logging.ClusterLogging {
+			for _, l := range logSetup.Types {
+				enabled[aws.StringValue(l)] = aws.BoolValue(logSetup.Enabled)
+			}
+		}
+	}
+
+	for _, logType := range eks.LogType_Values() {
+		if loggingSpec.IsLogEnabled(logType) != enabled[logType] {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *Service) createCluster(eksClusterName string) (*eks.Cluster, error) {
 	logging := makeEksLogging(s.scope.ControlPlane.Spec.Logging)
-	encryptionConfigs := makeEksEncryptionConfigs(s.scope.ControlPlane.Spec.EncryptionConfig)
+	encryptionConfigs := makeEksEncryptionConfigs(s.encryptionConfig())
 	vpcConfig, err := makeVpcConfig(s.scope.Subnets(), s.scope.ControlPlane.Spec.EndpointAccess, s.scope.SecurityGroups())
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't create vpc config for cluster")
@@ -412,6 +453,7 @@ func (s *Service) createCluster(eksClusterName string) (*eks.Cluster, error) {
 		RoleArn:                 role.Arn,
 		Tags:                    tags,
 		KubernetesNetworkConfig: netConfig,
+		AccessConfig:            makeEksAccessConfig(s.scope.ControlPlane.Spec.AccessConfig),
 	}
 
 	var out *eks.CreateClusterOutput
@@ -434,6 +476,26 @@ func (s *Service) createCluster(eksClusterName string) (*eks.Cluster, error) {
 	return out.Cluster, nil
 }
 
+// clusterUpdateTimeout is the deadline for an EKS control plane update initiated by the controller to complete.
+const clusterUpdateTimeout = 2 * time.Hour
+
+// checkClusterUpdateDeadline reports the EKS control plane updates in progress past their deadline in the
+// AsyncOperationsReady condition. EKS updates cannot be cancelled, so the reconciliation stops waiting for them
+// and returns an error until the update completes.
+func (s *Service) checkClusterUpdateDeadline() error {
+	updating := conditions.Get(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneUpdatingCondition)
+	if updating == nil || updating.Status != corev1.ConditionTrue || time.Since(updating.LastTransitionTime.Time) <= clusterUpdateTimeout {
+		return nil
+	}
+
+	message := fmt.Sprintf("EKS control plane %s has been updating for more than %s", s.scope.KubernetesClusterName(), clusterUpdateTimeout)
+	if conditions.GetReason(s.scope.ControlPlane, infrav1.AsyncOperationsReadyCondition) != infrav1.EKSControlPlaneUpdateTimedOutReason {
+		record.Warnf(s.scope.ControlPlane, "EKSControlPlaneUpdateTimedOut", "%s, EKS updates cannot be cancelled", message)
+	}
+	conditions.MarkFalse(s.scope.ControlPlane, infrav1.AsyncOperationsReadyCondition, infrav1.EKSControlPlaneUpdateTimedOutReason, clusterv1.ConditionSeverityWarning, message)
+	return errors.New(message)
+}
+
 func (s *Service) waitForClusterActive() (*eks.Cluster, error) {
 	eksClusterName := s.scope.KubernetesClusterName()
 	req := eks.DescribeClusterInput{
@@ -492,16 +554,17 @@ func (s *Service) reconcileClusterConfig(cluster *eks.Cluster) error {
 	return nil
 }
 
+// reconcileLogging updates the control plane log types sent to CloudWatch when they differ from the spec.
+// The log types of the cluster are left untouched when the spec has no logging configuration.
 func (s *Service) reconcileLogging(logging *eks.Logging) error {
+	if s.scope.ControlPlane.Spec.Logging == nil {
+		return nil
+	}
+
 	input := eks.UpdateClusterConfigInput{Name: aws.String(s.scope.KubernetesClusterName())}
 
-	for _, logSetup := range logging.ClusterLogging {
-		for _, l := range logSetup.Types {
-			enabled := s.scope.ControlPlane.Spec.Logging.IsLogEnabled(*l)
-			if enabled != *logSetup.Enabled {
-				input.Logging = makeEksLogging(s.scope.ControlPlane.Spec.Logging)
-			}
-		}
+	if !loggingEqual(s.scope.ControlPlane.Spec.Logging, logging) {
+		input.Logging = makeEksLogging(s.scope.ControlPlane.Spec.Logging)
 	}
 
 	if input.Logging != nil {
@@ -528,6 +591,43 @@ func (s *Service) reconcileLogging(logging *eks.Logging) error {
 	return nil
 }
 
+// reconcileAccessConfig updates the authentication mode of the cluster. EKS only allows switching from
+// CONFIG_MAP to API_AND_CONFIG_MAP and from API_AND_CONFIG_MAP to API, which the webhook enforces.
+func (s *Service) reconcileAccessConfig(accessConfig *eks.AccessConfigResponse) error {
+	if s.scope.ControlPlane.Spec.AccessConfig == nil {
+		return nil
+	}
+
+	expectedMode := string(s.scope.ControlPlane.Spec.GetAuthenticationMode())
+	if accessConfig != nil && aws.StringValue(accessConfig.AuthenticationMode) == expectedMode {
+		return nil
+	}
+
+	input := eks.UpdateClusterConfigInput{
+		Name: aws.String(s.scope.KubernetesClusterName()),
+		AccessConfig: &eks.UpdateAccessConfigRequest{
+			AuthenticationMode: aws.String(expectedMode),
+		},
+	}
+
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		if _, err := s.EKSClient.UpdateClusterConfig(&input); err != nil {
+			if aerr, ok := err.(awserr.Error); ok {
+				return false, aerr
+			}
+			return false, err
+		}
+		conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneUpdatingCondition)
+		record.Eventf(s.scope.ControlPlane, "InitiatedUpdateEKSControlPlane", "Initiated authentication mode update for EKS control plane %s", s.scope.KubernetesClusterName())
+		return true, nil
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "Failed to update EKS control plane authentication mode: %v", err)
+		return errors.Wrapf(err, "failed to update EKS cluster")
+	}
+
+	return nil
+}
+
 func publicAccessCIDRsEqual(as []*string, bs []*string) bool {
 	all := "0.0.0.0/0"
 	if len(as) == 0 {
@@ -566,8 +666,7 @@ func (s *Service) reconcileEKSEncryptionConfig(currentClusterConfig []*eks.Encry
 		currentClusterConfig = []*eks.EncryptionConfig{}
 	}
 
-	encryptionConfigs := s.scope.ControlPlane.Spec.EncryptionConfig
-	updatedEncryptionConfigs := makeEksEncryptionConfigs(encryptionConfigs)
+	updatedEncryptionConfigs := makeEksEncryptionConfigs(s.encryptionConfig())
 
 	if compareEncryptionConfig(currentClusterConfig, updatedEncryptionConfigs) {
 		s.Debug("encryption configuration unchanged, no action")
@@ -18,13 +18,16 @@ package eks
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/version"
@@ -37,6 +40,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestMakeEKSEncryptionConfigs(t *testing.T) {
@@ -357,6 +361,135 @@ func TestMakeEKSLogging(t *testing.T) {
 	}
 }
 
+func TestReconcileLogging(t *testing.T) {
+	clusterName := "default.cluster"
+	allDisabled := &eks.Logging{
+		ClusterLogging: []*eks.LogSetup{
+			{
+				Enabled: aws.Bool(false),
+				Types:   aws.StringSlice(eks.LogType_Values()),
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		loggingSpec *ekscontrolplanev1.ControlPlaneLoggingSpec
+		logging     *eks.Logging
+		expect      func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectError bool
+	}{
+		{
+			name:        "no logging spec",
+			loggingSpec: nil,
+			logging: &eks.Logging{
+				ClusterLogging: []*eks.LogSetup{
+					{
+						Enabled: aws.Bool(true),
+						Types:   aws.StringSlice(eks.LogType_Values()),
+					},
+				},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+		},
+		{
+			name:        "no update necessary",
+			loggingSpec: &ekscontrolplanev1.ControlPlaneLoggingSpec{},
+			logging:     allDisabled,
+			expect:      func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+		},
+		{
+			name: "enables log types",
+			loggingSpec: &ekscontrolplanev1.ControlPlaneLoggingSpec{
+				APIServer: true,
+				Audit:     true,
+			},
+			logging: allDisabled,
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(&eks.UpdateClusterConfigInput{
+					Name: aws.String(clusterName),
+					Logging: &eks.Logging{
+						ClusterLogging: []*eks.LogSetup{
+							{
+								Enabled: aws.Bool(true),
+								Types:   aws.StringSlice([]string{eks.LogTypeApi, eks.LogTypeAudit}),
+							},
+							{
+								Enabled: aws.Bool(false),
+								Types:   aws.StringSlice([]string{eks.LogTypeAuthenticator, eks.LogTypeControllerManager, eks.LogTypeScheduler}),
+							},
+						},
+					},
+				}).Return(&eks.UpdateClusterConfigOutput{}, nil)
+			},
+		},
+		{
+			name: "log types missing from the cluster logging",
+			loggingSpec: &ekscontrolplanev1.ControlPlaneLoggingSpec{
+				Scheduler: true,
+			},
+			logging: nil,
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(gomock.AssignableToTypeOf(&eks.UpdateClusterConfigInput{})).Return(&eks.UpdateClusterConfigOutput{}, nil)
+			},
+		},
+		{
+			name: "update fails",
+			loggingSpec: &ekscontrolplanev1.ControlPlaneLoggingSpec{
+				Authenticator: true,
+			},
+			logging: allDisabled,
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(gomock.AssignableToTypeOf(&eks.UpdateClusterConfigInput{})).
+					Return(nil, awserr.New(eks.ErrCodeInvalidParameterException, "", nil))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName: clusterName,
+						Logging:        tc.loggingSpec,
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			tc.expect(eksMock.EXPECT())
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			err = s.reconcileLogging(tc.logging)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
+
 func TestReconcileClusterVersion(t *testing.T) {
 	clusterName := "default.cluster"
 	tests := []struct {
@@ -673,6 +806,78 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 	}
 }
 
+func TestCheckClusterUpdateDeadline(t *testing.T) {
+	tests := []struct {
+		name        string
+		updating    *clusterv1.Condition
+		expectError bool
+	}{
+		{
+			name:        "no update in progress",
+			expectError: false,
+		},
+		{
+			name: "update in progress within its deadline",
+			updating: &clusterv1.Condition{
+				Type:               ekscontrolplanev1.EKSControlPlaneUpdatingCondition,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+			},
+			expectError: false,
+		},
+		{
+			name: "update in progress past its deadline",
+			updating: &clusterv1.Condition{
+				Type:               ekscontrolplanev1.EKSControlPlaneUpdatingCondition,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-3 * time.Hour)),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName: "default.cluster",
+				},
+			}
+			if tc.updating != nil {
+				controlPlane.Status.Conditions = clusterv1.Conditions{*tc.updating}
+			}
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "default.cluster",
+					},
+				},
+				ControlPlane: controlPlane,
+			})
+			g.Expect(err).To(BeNil())
+
+			s := NewService(scope)
+			err = s.checkClusterUpdateDeadline()
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(conditions.IsFalse(controlPlane, infrav1.AsyncOperationsReadyCondition)).To(BeTrue())
+				g.Expect(conditions.GetReason(controlPlane, infrav1.AsyncOperationsReadyCondition)).To(Equal(infrav1.EKSControlPlaneUpdateTimedOutReason))
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(conditions.Has(controlPlane, infrav1.AsyncOperationsReadyCondition)).To(BeFalse())
+		})
+	}
+}
+
 func TestCreateIPv6Cluster(t *testing.T) {
 	g := NewWithT(t)
 
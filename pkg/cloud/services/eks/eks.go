@@ -63,6 +63,20 @@ func (s *Service) ReconcileControlPlane(ctx context.Context) error {
 	}
 	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSIdentityProviderConfiguredCondition)
 
+	// EKS Access Entries
+	if err := s.reconcileAccessEntries(ctx); err != nil {
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSAccessEntriesConfiguredCondition, ekscontrolplanev1.EKSAccessEntriesConfiguredFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return errors.Wrap(err, "failed reconciling eks access entries")
+	}
+	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSAccessEntriesConfiguredCondition)
+
+	// EKS Pod Identity Associations
+	if err := s.reconcilePodIdentityAssociations(ctx); err != nil {
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSPodIdentityAssociationsConfiguredCondition, ekscontrolplanev1.EKSPodIdentityAssociationsConfiguredFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return errors.Wrap(err, "failed reconciling eks pod identity associations")
+	}
+	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSPodIdentityAssociationsConfiguredCondition)
+
 	s.scope.Debug("Reconcile EKS control plane completed successfully")
 	return nil
 }
@@ -81,11 +95,21 @@ func (s *Service) DeleteControlPlane() (err error) {
 		return err
 	}
 
+	// IRSA roles
+	if err := s.deleteIRSARoles(); err != nil {
+		return err
+	}
+
 	// OIDC Provider
 	if err := s.deleteOIDCProvider(); err != nil {
 		return err
 	}
 
+	// Encryption key
+	if err := s.deleteEncryptionKey(); err != nil {
+		return err
+	}
+
 	s.scope.Debug("Delete EKS control plane completed successfully")
 	return nil
 }
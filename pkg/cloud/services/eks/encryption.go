@@ -0,0 +1,252 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	tagConverter "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+const (
+	// encryptionKeyDeletionWindowDays is the waiting period before a deleted encryption key is removed.
+	encryptionKeyDeletionWindowDays = 7
+)
+
+// encryptionKeyClusterRoleActions are the operations the control plane IAM role needs on the KMS key to
+// encrypt the resources of the cluster.
+var encryptionKeyClusterRoleActions = iamv1.Actions{
+	"kms:Encrypt",
+	"kms:Decrypt",
+	"kms:ListGrants",
+	"kms:DescribeKey",
+}
+
+// encryptionConfig returns the encryption configuration of the cluster, with the KMS key created by the
+// provider when requested. The provider is empty until the key is created.
+func (s *Service) encryptionConfig() *ekscontrolplanev1.EncryptionConfig {
+	encryptionConfig := s.scope.ControlPlane.Spec.EncryptionConfig
+	if encryptionConfig == nil || !encryptionConfig.CreateKey {
+		return encryptionConfig
+	}
+
+	encryptionConfig = encryptionConfig.DeepCopy()
+	encryptionConfig.Provider = aws.String(s.scope.ControlPlane.Status.EncryptionKeyARN)
+	return encryptionConfig
+}
+
+// encryptionKeyAlias returns the alias of the KMS key of the cluster. It starts with the KMS alias prefix
+// clusterawsadm restricts the permissions of the controllers to, without its trailing wildcard.
+func (s *Service) encryptionKeyAlias() string {
+	return "alias/" + strings.TrimSuffix(s.scope.KMSAliasPrefix(), "*") + s.scope.KubernetesClusterName()
+}
+
+// reconcileEncryptionKey creates the KMS key encrypting the resources of the cluster when the provider
+// manages it. The key is found through its alias, so that it isn't created twice if the status is lost.
+func (s *Service) reconcileEncryptionKey() error {
+	if s.scope.ControlPlane.Spec.EncryptionConfig == nil || !s.scope.ControlPlane.Spec.EncryptionConfig.CreateKey {
+		return nil
+	}
+
+	s.scope.Debug("Reconciling EKS encryption key")
+
+	alias := s.encryptionKeyAlias()
+	aliasedKey, err := s.describeEncryptionKey(alias)
+	if err != nil {
+		return err
+	}
+	// The alias of a key pending deletion belongs to a previous cluster with the same name.
+	aliasUsable := aliasedKey != nil && aws.StringValue(aliasedKey.KeyState) != kms.KeyStatePendingDeletion
+
+	keyARN := s.scope.ControlPlane.Status.EncryptionKeyARN
+	switch {
+	case keyARN != "":
+	case aliasUsable:
+		keyARN = aws.StringValue(aliasedKey.Arn)
+	default:
+		keyARN, err = s.createEncryptionKey()
+		if err != nil {
+			record.Warnf(s.scope.ControlPlane, "FailedCreateEncryptionKey", "Failed to create KMS key for EKS cluster %s: %v", s.scope.KubernetesClusterName(), err)
+			return err
+		}
+		record.Eventf(s.scope.ControlPlane, "SuccessfulCreateEncryptionKey", "Created KMS key %s for EKS cluster %s", keyARN, s.scope.KubernetesClusterName())
+	}
+	s.scope.ControlPlane.Status.EncryptionKeyARN = keyARN
+
+	switch {
+	case aliasedKey == nil:
+		if _, err := s.KMSClient.CreateAlias(&kms.CreateAliasInput{
+			AliasName:   aws.String(alias),
+			TargetKeyId: aws.String(keyARN),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to create alias %s for KMS key %s", alias, keyARN)
+		}
+	case aws.StringValue(aliasedKey.Arn) != keyARN:
+		if _, err := s.KMSClient.UpdateAlias(&kms.UpdateAliasInput{
+			AliasName:   aws.String(alias),
+			TargetKeyId: aws.String(keyARN),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to update alias %s to KMS key %s", alias, keyARN)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) createEncryptionKey() (string, error) {
+	role, err := s.GetIAMRole(aws.StringValue(s.scope.ControlPlane.Spec.RoleName))
+	if err != nil {
+		return "", errors.Wrapf(err, "error getting control plane iam role: %s", aws.StringValue(s.scope.ControlPlane.Spec.RoleName))
+	}
+
+	policy, err := buildEncryptionKeyPolicy(aws.StringValue(role.Arn))
+	if err != nil {
+		return "", err
+	}
+	policyJSON, err := converters.IAMPolicyDocumentToJSON(*policy)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal KMS key policy")
+	}
+
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(s.encryptionKeyAlias()),
+		Role:        aws.String(infrav1.CommonRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	})
+
+	out, err := s.KMSClient.CreateKey(&kms.CreateKeyInput{
+		Description: aws.String(fmt.Sprintf("Encryption key of EKS cluster %s", s.scope.KubernetesClusterName())),
+		KeySpec:     aws.String(kms.KeySpecSymmetricDefault),
+		KeyUsage:    aws.String(kms.KeyUsageTypeEncryptDecrypt),
+		Policy:      aws.String(policyJSON),
+		Tags:        tagConverter.MapToKMSTags(tags),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create KMS key")
+	}
+
+	return aws.StringValue(out.KeyMetadata.Arn), nil
+}
+
+// describeEncryptionKey returns the metadata of a KMS key, or nil if it doesn't exist.
+func (s *Service) describeEncryptionKey(keyID string) (*kms.KeyMetadata, error) {
+	out, err := s.KMSClient.DescribeKey(&kms.DescribeKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		if code, _ := awserrors.Code(errors.Cause(err)); code == kms.ErrCodeNotFoundException {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to describe KMS key %s", keyID)
+	}
+	return out.KeyMetadata, nil
+}
+
+// deleteEncryptionKey schedules the deletion of the KMS key created for the cluster. Keys which aren't
+// tagged as owned by the cluster are left untouched.
+func (s *Service) deleteEncryptionKey() error {
+	keyID := s.scope.ControlPlane.Status.EncryptionKeyARN
+	if keyID == "" {
+		if s.scope.ControlPlane.Spec.EncryptionConfig == nil || !s.scope.ControlPlane.Spec.EncryptionConfig.CreateKey {
+			return nil
+		}
+		keyID = s.encryptionKeyAlias()
+	}
+
+	key, err := s.describeEncryptionKey(keyID)
+	if err != nil {
+		return err
+	}
+	if key == nil || aws.StringValue(key.KeyState) == kms.KeyStatePendingDeletion {
+		s.scope.ControlPlane.Status.EncryptionKeyARN = ""
+		return nil
+	}
+
+	out, err := s.KMSClient.ListResourceTags(&kms.ListResourceTagsInput{KeyId: key.Arn})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list tags of KMS key %s", aws.StringValue(key.Arn))
+	}
+	if !tagConverter.KMSTagsToMap(out.Tags).HasOwned(s.scope.Name()) {
+		s.scope.Debug("Skipping, KMS key deletion as key is unmanaged", "key", aws.StringValue(key.Arn))
+		s.scope.ControlPlane.Status.EncryptionKeyARN = ""
+		return nil
+	}
+
+	if _, err := s.KMSClient.ScheduleKeyDeletion(&kms.ScheduleKeyDeletionInput{
+		KeyId:               key.Arn,
+		PendingWindowInDays: aws.Int64(encryptionKeyDeletionWindowDays),
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedDeleteEncryptionKey", "Failed to schedule deletion of KMS key %s: %v", aws.StringValue(key.Arn), err)
+		return errors.Wrapf(err, "failed to schedule deletion of KMS key %s", aws.StringValue(key.Arn))
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulDeleteEncryptionKey", "Scheduled deletion of KMS key %s", aws.StringValue(key.Arn))
+
+	// The alias is deleted last, as the controllers are only allowed to use the keys having one.
+	if _, err := s.KMSClient.DeleteAlias(&kms.DeleteAliasInput{AliasName: aws.String(s.encryptionKeyAlias())}); err != nil {
+		if code, _ := awserrors.Code(errors.Cause(err)); code != kms.ErrCodeNotFoundException {
+			return errors.Wrapf(err, "failed to delete alias %s", s.encryptionKeyAlias())
+		}
+	}
+	s.scope.ControlPlane.Status.EncryptionKeyARN = ""
+
+	return nil
+}
+
+// buildEncryptionKeyPolicy returns the key policy of the KMS key of a cluster. It keeps the key manageable with
+// IAM policies of the account, and allows the control plane IAM role to use the key.
+func buildEncryptionKeyPolicy(roleARN string) (*iamv1.PolicyDocument, error) {
+	parsed, err := arn.Parse(roleARN)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse control plane iam role ARN %q", roleARN)
+	}
+
+	return &iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		Statement: iamv1.Statements{
+			{
+				Sid:    "EnableIAMPolicies",
+				Effect: iamv1.EffectAllow,
+				Principal: iamv1.Principals{
+					iamv1.PrincipalAWS: iamv1.PrincipalID{fmt.Sprintf("arn:%s:iam::%s:root", parsed.Partition, parsed.AccountID)},
+				},
+				Action:   iamv1.Actions{"kms:*"},
+				Resource: iamv1.Resources{"*"},
+			},
+			{
+				Sid:    "AllowClusterRole",
+				Effect: iamv1.EffectAllow,
+				Principal: iamv1.Principals{
+					iamv1.PrincipalAWS: iamv1.PrincipalID{roleARN},
+				},
+				Action:   encryptionKeyClusterRoleActions,
+				Resource: iamv1.Resources{"*"},
+			},
+		},
+	}, nil
+}
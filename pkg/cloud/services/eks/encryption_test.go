@@ -0,0 +1,317 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/kms/mock_kmsiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	testEncryptionKeyARN = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	testOtherKeyARN      = "arn:aws:kms:us-east-1:123456789012:key/0987dcba-09fe-87dc-65ba-ab0987654321"
+	testClusterRoleARN   = "arn:aws:iam::123456789012:role/eks-controlplane"
+)
+
+func TestReconcileEncryptionKey(t *testing.T) {
+	alias := "alias/cluster-api-provider-aws-cluster-test"
+	notFound := awserr.New(kms.ErrCodeNotFoundException, "not found", nil)
+
+	tests := []struct {
+		name             string
+		encryptionConfig *ekscontrolplanev1.EncryptionConfig
+		statusKeyARN     string
+		kmsAliasPrefix   string
+		expectKMS        func(m *mock_kmsiface.MockKMSAPIMockRecorder)
+		expectIAM        func(m *mock_iamauth.MockIAMAPIMockRecorder)
+		expectedKeyARN   string
+		expectError      bool
+	}{
+		{
+			name: "key not created by the provider",
+			encryptionConfig: &ekscontrolplanev1.EncryptionConfig{
+				Provider:  aws.String(testOtherKeyARN),
+				Resources: []*string{aws.String("secrets")},
+			},
+			expectKMS: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {},
+		},
+		{
+			name: "creates the key and its alias",
+			encryptionConfig: &ekscontrolplanev1.EncryptionConfig{
+				CreateKey: true,
+				Resources: []*string{aws.String("secrets")},
+			},
+			expectIAM: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String("eks-controlplane")}).Return(&iam.GetRoleOutput{
+					Role: &iam.Role{
+						RoleName: aws.String("eks-controlplane"),
+						Arn:      aws.String(testClusterRoleARN),
+					},
+				}, nil)
+			},
+			expectKMS: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				m.DescribeKey(&kms.DescribeKeyInput{KeyId: aws.String(alias)}).Return(nil, notFound)
+				m.CreateKey(gomock.AssignableToTypeOf(&kms.CreateKeyInput{})).
+					DoAndReturn(func(input *kms.CreateKeyInput) (*kms.CreateKeyOutput, error) {
+						if !strings.Contains(aws.StringValue(input.Policy), testClusterRoleARN) ||
+							!strings.Contains(aws.StringValue(input.Policy), "arn:aws:iam::123456789012:root") {
+							return nil, awserr.New(kms.ErrCodeMalformedPolicyDocumentException, "unexpected key policy", nil)
+						}
+						return &kms.CreateKeyOutput{
+							KeyMetadata: &kms.KeyMetadata{Arn: aws.String(testEncryptionKeyARN)},
+						}, nil
+					})
+				m.CreateAlias(&kms.CreateAliasInput{
+					AliasName:   aws.String(alias),
+					TargetKeyId: aws.String(testEncryptionKeyARN),
+				}).Return(&kms.CreateAliasOutput{}, nil)
+			},
+			expectedKeyARN: testEncryptionKeyARN,
+		},
+		{
+			name: "uses the aliased key when the status is lost",
+			encryptionConfig: &ekscontrolplanev1.EncryptionConfig{
+				CreateKey: true,
+			},
+			expectKMS: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				m.DescribeKey(&kms.DescribeKeyInput{KeyId: aws.String(alias)}).Return(&kms.DescribeKeyOutput{
+					KeyMetadata: &kms.KeyMetadata{
+						Arn:      aws.String(testEncryptionKeyARN),
+						KeyState: aws.String(kms.KeyStateEnabled),
+					},
+				}, nil)
+			},
+			expectedKeyARN: testEncryptionKeyARN,
+		},
+		{
+			name: "uses the configured KMS alias prefix",
+			encryptionConfig: &ekscontrolplanev1.EncryptionConfig{
+				CreateKey: true,
+			},
+			kmsAliasPrefix: "my-prefix-*",
+			expectKMS: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				m.DescribeKey(&kms.DescribeKeyInput{KeyId: aws.String("alias/my-prefix-cluster-test")}).Return(&kms.DescribeKeyOutput{
+					KeyMetadata: &kms.KeyMetadata{
+						Arn:      aws.String(testEncryptionKeyARN),
+						KeyState: aws.String(kms.KeyStateEnabled),
+					},
+				}, nil)
+			},
+			expectedKeyARN: testEncryptionKeyARN,
+		},
+		{
+			name: "moves the alias of a key pending deletion",
+			encryptionConfig: &ekscontrolplanev1.EncryptionConfig{
+				CreateKey: true,
+			},
+			statusKeyARN: testEncryptionKeyARN,
+			expectKMS: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				m.DescribeKey(&kms.DescribeKeyInput{KeyId: aws.String(alias)}).Return(&kms.DescribeKeyOutput{
+					KeyMetadata: &kms.KeyMetadata{
+						Arn:      aws.String(testOtherKeyARN),
+						KeyState: aws.String(kms.KeyStatePendingDeletion),
+					},
+				}, nil)
+				m.UpdateAlias(&kms.UpdateAliasInput{
+					AliasName:   aws.String(alias),
+					TargetKeyId: aws.String(testEncryptionKeyARN),
+				}).Return(&kms.UpdateAliasOutput{}, nil)
+			},
+			expectedKeyARN: testEncryptionKeyARN,
+		},
+		{
+			name: "fails to describe the key",
+			encryptionConfig: &ekscontrolplanev1.EncryptionConfig{
+				CreateKey: true,
+			},
+			expectKMS: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				m.DescribeKey(gomock.Any()).Return(nil, awserr.New("AccessDeniedException", "", nil))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			kmsMock := mock_kmsiface.NewMockKMSAPI(mockControl)
+			iamMock := mock_iamauth.NewMockIAMAPI(mockControl)
+
+			controlPlane := newEncryptionTestControlPlane(tc.encryptionConfig, tc.statusKeyARN)
+			s := newEncryptionTestService(g, controlPlane, tc.kmsAliasPrefix)
+			s.KMSClient = kmsMock
+			s.IAMClient = iamMock
+
+			tc.expectKMS(kmsMock.EXPECT())
+			if tc.expectIAM != nil {
+				tc.expectIAM(iamMock.EXPECT())
+			}
+
+			err := s.reconcileEncryptionKey()
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(controlPlane.Status.EncryptionKeyARN).To(Equal(tc.expectedKeyARN))
+		})
+	}
+}
+
+func TestDeleteEncryptionKey(t *testing.T) {
+	alias := "alias/cluster-api-provider-aws-cluster-test"
+
+	tests := []struct {
+		name         string
+		statusKeyARN string
+		expectKMS    func(m *mock_kmsiface.MockKMSAPIMockRecorder)
+	}{
+		{
+			name:      "no key created",
+			expectKMS: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {},
+		},
+		{
+			name:         "schedules the deletion of an owned key",
+			statusKeyARN: testEncryptionKeyARN,
+			expectKMS: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				m.DescribeKey(&kms.DescribeKeyInput{KeyId: aws.String(testEncryptionKeyARN)}).Return(&kms.DescribeKeyOutput{
+					KeyMetadata: &kms.KeyMetadata{
+						Arn:      aws.String(testEncryptionKeyARN),
+						KeyState: aws.String(kms.KeyStateEnabled),
+					},
+				}, nil)
+				m.ListResourceTags(&kms.ListResourceTagsInput{KeyId: aws.String(testEncryptionKeyARN)}).Return(&kms.ListResourceTagsOutput{
+					Tags: []*kms.Tag{
+						{
+							TagKey:   aws.String(infrav1.ClusterTagKey("cluster-test")),
+							TagValue: aws.String(string(infrav1.ResourceLifecycleOwned)),
+						},
+					},
+				}, nil)
+				m.ScheduleKeyDeletion(&kms.ScheduleKeyDeletionInput{
+					KeyId:               aws.String(testEncryptionKeyARN),
+					PendingWindowInDays: aws.Int64(7),
+				}).Return(&kms.ScheduleKeyDeletionOutput{}, nil)
+				m.DeleteAlias(&kms.DeleteAliasInput{AliasName: aws.String(alias)}).Return(&kms.DeleteAliasOutput{}, nil)
+			},
+		},
+		{
+			name:         "keeps an unmanaged key",
+			statusKeyARN: testEncryptionKeyARN,
+			expectKMS: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				m.DescribeKey(gomock.Any()).Return(&kms.DescribeKeyOutput{
+					KeyMetadata: &kms.KeyMetadata{
+						Arn:      aws.String(testEncryptionKeyARN),
+						KeyState: aws.String(kms.KeyStateEnabled),
+					},
+				}, nil)
+				m.ListResourceTags(gomock.Any()).Return(&kms.ListResourceTagsOutput{}, nil)
+			},
+		},
+		{
+			name:         "key already pending deletion",
+			statusKeyARN: testEncryptionKeyARN,
+			expectKMS: func(m *mock_kmsiface.MockKMSAPIMockRecorder) {
+				m.DescribeKey(gomock.Any()).Return(&kms.DescribeKeyOutput{
+					KeyMetadata: &kms.KeyMetadata{
+						Arn:      aws.String(testEncryptionKeyARN),
+						KeyState: aws.String(kms.KeyStatePendingDeletion),
+					},
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			kmsMock := mock_kmsiface.NewMockKMSAPI(mockControl)
+
+			controlPlane := newEncryptionTestControlPlane(nil, tc.statusKeyARN)
+			s := newEncryptionTestService(g, controlPlane, "")
+			s.KMSClient = kmsMock
+
+			tc.expectKMS(kmsMock.EXPECT())
+
+			g.Expect(s.deleteEncryptionKey()).To(Succeed())
+			g.Expect(controlPlane.Status.EncryptionKeyARN).To(BeEmpty())
+		})
+	}
+}
+
+func newEncryptionTestControlPlane(encryptionConfig *ekscontrolplanev1.EncryptionConfig, keyARN string) *ekscontrolplanev1.AWSManagedControlPlane {
+	return &ekscontrolplanev1.AWSManagedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "cluster-test",
+		},
+		Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+			EKSClusterName:   "cluster-test",
+			RoleName:         aws.String("eks-controlplane"),
+			EncryptionConfig: encryptionConfig,
+		},
+		Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+			EncryptionKeyARN: keyARN,
+		},
+	}
+}
+
+func newEncryptionTestService(g *WithT, controlPlane *ekscontrolplanev1.AWSManagedControlPlane, kmsAliasPrefix string) *Service {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = ekscontrolplanev1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "cluster-test",
+			},
+		},
+		ControlPlane:   controlPlane,
+		KMSAliasPrefix: kmsAliasPrefix,
+	})
+	g.Expect(err).To(BeNil())
+
+	return NewService(scope)
+}
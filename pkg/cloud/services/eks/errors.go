@@ -37,4 +37,7 @@ var (
 	ErrCannotUseAdditionalRoles = errors.New("additional rules cannot be added as this has been disabled")
 	// ErrNoSecurityGroup is an error when no security group is found for an EKS cluster.
 	ErrNoSecurityGroup = errors.New("no security group for EKS cluster")
+	// ErrNatGatewayIPsUnavailable is an error when an ingress rule uses the NAT gateway IPs as source
+	// before they are available.
+	ErrNatGatewayIPsUnavailable = errors.New("NAT Gateway IPs are not available yet")
 )
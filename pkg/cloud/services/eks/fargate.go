@@ -18,6 +18,7 @@ package eks
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -49,6 +50,7 @@ func (s *FargateService) Reconcile() (reconcile.Result, error) {
 
 	requeue, err := s.reconcileFargateIAMRole()
 	if err != nil {
+		record.Warnf(s.scope.FargateProfile, "FailedReconcileEKSFargateRole", "Failed to reconcile EKS fargate profile role %s: %v", s.scope.RoleName(), err)
 		conditions.MarkFalse(
 			s.scope.FargateProfile,
 			expinfrav1.IAMFargateRolesReadyCondition,
@@ -93,8 +95,18 @@ func (s *FargateService) reconcileFargateProfile() (requeue bool, err error) {
 	}
 
 	if eksClusterName := s.scope.KubernetesClusterName(); profile == nil {
-		profile, err = s.createFargateProfile()
+		subnets, err := s.profileSubnets()
 		if err != nil {
+			// The cluster network may not be reconciled yet, so keep retrying.
+			conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateSubnetsValidCondition, expinfrav1.EKSFargateInvalidSubnetsReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return false, err
+		}
+		conditions.MarkTrue(s.scope.FargateProfile, expinfrav1.EKSFargateSubnetsValidCondition)
+
+		profile, err = s.createFargateProfile(subnets)
+		if err != nil {
+			record.Warnf(s.scope.FargateProfile, "FailedCreateEKSFargateProfile", "Failed to create EKS fargate profile %s: %v", profileName, err)
+			conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateProfileReadyCondition, expinfrav1.EKSFargateCreateFailedReason, clusterv1.ConditionSeverityError, err.Error())
 			return false, errors.Wrap(err, "failed to create profile")
 		}
 		// Force status to creating
@@ -113,9 +125,58 @@ func (s *FargateService) reconcileFargateProfile() (requeue bool, err error) {
 		return false, errors.Wrapf(err, "failed to reconcile profile tags")
 	}
 
+	s.reconcileRoleAttached(profile)
+
 	return s.handleStatus(profile), nil
 }
 
+// profileSubnets returns the subnets to run the pods of the profile in. Fargate only supports private
+// subnets, so subnets known to be public in the cluster network are rejected, and the private subnets
+// of the cluster network are used when none are specified.
+func (s *FargateService) profileSubnets() ([]string, error) {
+	networkSubnets := s.scope.ControlPlane.Spec.NetworkSpec.Subnets
+
+	if len(s.scope.FargateProfile.Spec.SubnetIDs) > 0 {
+		for _, id := range s.scope.FargateProfile.Spec.SubnetIDs {
+			if subnet := networkSubnets.FindByID(id); subnet != nil && subnet.IsPublic {
+				return nil, errors.Errorf("subnet %s is public, fargate profiles only support private subnets", id)
+			}
+		}
+		return s.scope.FargateProfile.Spec.SubnetIDs, nil
+	}
+
+	subnets := []string{}
+	for _, subnet := range networkSubnets.FilterPrivate() {
+		if subnet.ID != "" {
+			subnets = append(subnets, subnet.ID)
+		}
+	}
+	if len(subnets) == 0 {
+		return nil, errors.New("no private subnets found in the cluster network")
+	}
+	return subnets, nil
+}
+
+// reconcileRoleAttached reports whether the profile uses the pod execution role managed for it.
+func (s *FargateService) reconcileRoleAttached(profile *eks.FargateProfile) {
+	roleName := s.scope.RoleName()
+	if roleName == "" || profile.PodExecutionRoleArn == nil {
+		return
+	}
+
+	arn := aws.StringValue(profile.PodExecutionRoleArn)
+	if arn[strings.LastIndex(arn, "/")+1:] != roleName {
+		if !conditions.IsFalse(s.scope.FargateProfile, expinfrav1.EKSFargateRoleAttachedCondition) {
+			record.Warnf(s.scope.FargateProfile, "EKSFargateProfileRoleMismatch", "EKS fargate profile %s uses pod execution role %s instead of %s", s.scope.FargateProfile.Spec.ProfileName, arn, roleName)
+		}
+		conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateRoleAttachedCondition, expinfrav1.EKSFargateRoleMismatchReason, clusterv1.ConditionSeverityWarning,
+			"profile uses pod execution role %s, expected %s", arn, roleName)
+		return
+	}
+
+	conditions.MarkTrue(s.scope.FargateProfile, expinfrav1.EKSFargateRoleAttachedCondition)
+}
+
 func (s *FargateService) handleStatus(profile *eks.FargateProfile) (requeue bool) {
 	s.Debug("fargate profile", "status", *profile.Status)
 	switch *profile.Status {
@@ -131,10 +192,24 @@ func (s *FargateService) handleStatus(profile *eks.FargateProfile) (requeue bool
 		conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateProfileReadyCondition, expinfrav1.EKSFargateCreatingReason, clusterv1.ConditionSeverityInfo, "")
 	case eks.FargateProfileStatusCreateFailed, eks.FargateProfileStatusDeleteFailed:
 		s.scope.FargateProfile.Status.Ready = false
-		s.scope.FargateProfile.Status.FailureMessage = aws.String(fmt.Sprintf("unexpected profile status: %s", *profile.Status))
+		message := fmt.Sprintf("unexpected profile status: %s", *profile.Status)
+		s.scope.FargateProfile.Status.FailureMessage = aws.String(message)
 		reason := capierrors.MachineStatusError(expinfrav1.EKSFargateFailedReason)
 		s.scope.FargateProfile.Status.FailureReason = &reason
-		conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateProfileReadyCondition, expinfrav1.EKSFargateFailedReason, clusterv1.ConditionSeverityError, "")
+
+		conditionReason := expinfrav1.EKSFargateCreateFailedReason
+		if *profile.Status == eks.FargateProfileStatusDeleteFailed {
+			conditionReason = expinfrav1.EKSFargateDeleteFailedReason
+		}
+		if conditions.IsTrue(s.scope.FargateProfile, expinfrav1.EKSFargateCreatingCondition) {
+			record.Warnf(s.scope.FargateProfile, "FailedCreateEKSFargateProfile", "Failed to create EKS fargate profile %s: %s", s.scope.FargateProfile.Spec.ProfileName, message)
+			conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateCreatingCondition, conditionReason, clusterv1.ConditionSeverityError, message)
+		}
+		if conditions.IsTrue(s.scope.FargateProfile, expinfrav1.EKSFargateDeletingCondition) {
+			record.Warnf(s.scope.FargateProfile, "FailedDeleteEKSFargateProfile", "Failed to delete EKS fargate profile %s: %s", s.scope.FargateProfile.Spec.ProfileName, message)
+			conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateDeletingCondition, conditionReason, clusterv1.ConditionSeverityError, message)
+		}
+		conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateProfileReadyCondition, conditionReason, clusterv1.ConditionSeverityError, message)
 	case eks.FargateProfileStatusActive:
 		s.scope.FargateProfile.Status.Ready = true
 		if conditions.IsTrue(s.scope.FargateProfile, expinfrav1.EKSFargateCreatingCondition) {
@@ -148,7 +223,8 @@ func (s *FargateService) handleStatus(profile *eks.FargateProfile) (requeue bool
 			record.Eventf(s.scope.FargateProfile, "InitiatedDeleteEKSFargateProfile", "Started deleting EKS fargate profile %s", s.scope.FargateProfile.Spec.ProfileName)
 			conditions.MarkTrue(s.scope.FargateProfile, expinfrav1.EKSFargateDeletingCondition)
 		}
-		conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateProfileReadyCondition, expinfrav1.EKSFargateDeletingReason, clusterv1.ConditionSeverityInfo, "")
+		conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateProfileReadyCondition, expinfrav1.EKSFargateDeletingReason, clusterv1.ConditionSeverityInfo,
+			"waiting for EKS fargate profile %s to be deleted", s.scope.FargateProfile.Spec.ProfileName)
 	}
 	switch *profile.Status {
 	case eks.FargateProfileStatusCreating, eks.FargateProfileStatusDeleting:
@@ -180,6 +256,7 @@ func (s *FargateService) ReconcileDelete() (reconcile.Result, error) {
 
 	err = s.deleteFargateIAMRole()
 	if err != nil {
+		record.Warnf(s.scope.FargateProfile, "FailedDeleteEKSFargateRole", "Failed to delete EKS fargate profile role %s: %v", s.scope.RoleName(), err)
 		conditions.MarkFalse(
 			s.scope.FargateProfile,
 			expinfrav1.IAMFargateRolesReadyCondition,
@@ -210,7 +287,7 @@ func (s *FargateService) describeFargateProfile() (*eks.FargateProfile, error) {
 	return out.FargateProfile, nil
 }
 
-func (s *FargateService) createFargateProfile() (*eks.FargateProfile, error) {
+func (s *FargateService) createFargateProfile(subnets []string) (*eks.FargateProfile, error) {
 	eksClusterName := s.scope.KubernetesClusterName()
 	profileName := s.scope.FargateProfile.Spec.ProfileName
 
@@ -223,14 +300,6 @@ func (s *FargateService) createFargateProfile() (*eks.FargateProfile, error) {
 
 	tags := ngTags(s.scope.ClusterName(), additionalTags)
 
-	subnets := s.scope.FargateProfile.Spec.SubnetIDs
-	if len(subnets) == 0 {
-		subnets = []string{}
-		for _, s := range s.scope.ControlPlane.Spec.NetworkSpec.Subnets.FilterPrivate() {
-			subnets = append(subnets, s.ID)
-		}
-	}
-
 	selectors := []*eks.FargateProfileSelector{}
 	for _, s := range s.scope.FargateProfile.Spec.Selectors {
 		selectors = append(selectors, &eks.FargateProfileSelector{
@@ -292,6 +361,7 @@ func (s *FargateService) deleteFargateProfile() (requeue bool, err error) {
 
 	out, err := s.EKSClient.DeleteFargateProfile(input)
 	if err != nil {
+		record.Warnf(s.scope.FargateProfile, "FailedDeleteEKSFargateProfile", "Failed to delete EKS fargate profile %s: %v", profileName, err)
 		return false, errors.Wrap(err, "failed to delete fargate profile")
 	}
 
@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/gomega"
+	"k8s.io/klog/v2"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func newTestFargateService(profile *expinfrav1.AWSFargateProfile) *FargateService {
+	log := logger.NewLogger(klog.Background())
+	return &FargateService{
+		scope: &scope.FargateProfileScope{
+			Logger:         *log,
+			FargateProfile: profile,
+		},
+		IAMService: iam.IAMService{Wrapper: log},
+	}
+}
+
+func TestFargateHandleStatus(t *testing.T) {
+	testCases := []struct {
+		name             string
+		status           string
+		creating         bool
+		expectRequeue    bool
+		expectReady      bool
+		expectReason     string
+		expectFailureMsg bool
+	}{
+		{
+			name:          "creating",
+			status:        eks.FargateProfileStatusCreating,
+			expectRequeue: true,
+			expectReason:  expinfrav1.EKSFargateCreatingReason,
+		},
+		{
+			name:        "active",
+			status:      eks.FargateProfileStatusActive,
+			creating:    true,
+			expectReady: true,
+		},
+		{
+			name:             "create failed",
+			status:           eks.FargateProfileStatusCreateFailed,
+			creating:         true,
+			expectReason:     expinfrav1.EKSFargateCreateFailedReason,
+			expectFailureMsg: true,
+		},
+		{
+			name:             "delete failed",
+			status:           eks.FargateProfileStatusDeleteFailed,
+			expectReason:     expinfrav1.EKSFargateDeleteFailedReason,
+			expectFailureMsg: true,
+		},
+		{
+			name:          "deleting",
+			status:        eks.FargateProfileStatusDeleting,
+			expectRequeue: true,
+			expectReason:  expinfrav1.EKSFargateDeletingReason,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			profile := &expinfrav1.AWSFargateProfile{
+				Spec: expinfrav1.FargateProfileSpec{ProfileName: "profile"},
+			}
+			if tc.creating {
+				conditions.MarkTrue(profile, expinfrav1.EKSFargateCreatingCondition)
+			}
+			s := newTestFargateService(profile)
+
+			requeue := s.handleStatus(&eks.FargateProfile{Status: aws.String(tc.status)})
+			g.Expect(requeue).To(Equal(tc.expectRequeue))
+			g.Expect(profile.Status.Ready).To(Equal(tc.expectReady))
+			if tc.expectReady {
+				g.Expect(conditions.IsTrue(profile, expinfrav1.EKSFargateProfileReadyCondition)).To(BeTrue())
+			} else {
+				g.Expect(conditions.GetReason(profile, expinfrav1.EKSFargateProfileReadyCondition)).To(Equal(tc.expectReason))
+			}
+			g.Expect(profile.Status.FailureMessage != nil).To(Equal(tc.expectFailureMsg))
+			if tc.creating {
+				g.Expect(conditions.IsTrue(profile, expinfrav1.EKSFargateCreatingCondition)).To(BeFalse())
+			}
+		})
+	}
+}
+
+func TestFargateReconcileRoleAttached(t *testing.T) {
+	g := NewWithT(t)
+	profile := &expinfrav1.AWSFargateProfile{
+		Spec: expinfrav1.FargateProfileSpec{ProfileName: "profile", RoleName: "fargate-role"},
+	}
+	s := newTestFargateService(profile)
+
+	s.reconcileRoleAttached(&eks.FargateProfile{PodExecutionRoleArn: aws.String("arn:aws:iam::123456789012:role/other-role")})
+	g.Expect(conditions.IsFalse(profile, expinfrav1.EKSFargateRoleAttachedCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(profile, expinfrav1.EKSFargateRoleAttachedCondition)).To(Equal(expinfrav1.EKSFargateRoleMismatchReason))
+
+	s.reconcileRoleAttached(&eks.FargateProfile{PodExecutionRoleArn: aws.String("arn:aws:iam::123456789012:role/fargate-role")})
+	g.Expect(conditions.IsTrue(profile, expinfrav1.EKSFargateRoleAttachedCondition)).To(BeTrue())
+}
+
+func TestFargateProfileSubnets(t *testing.T) {
+	networkSubnets := infrav1.Subnets{
+		{ID: "subnet-private-1", IsPublic: false},
+		{ID: "subnet-public-1", IsPublic: true},
+		{ID: "subnet-private-2", IsPublic: false},
+	}
+	testCases := []struct {
+		name            string
+		subnetIDs       []string
+		networkSubnets  infrav1.Subnets
+		expectedSubnets []string
+		expectErr       bool
+	}{
+		{
+			name:            "private subnets of the cluster network are selected",
+			networkSubnets:  networkSubnets,
+			expectedSubnets: []string{"subnet-private-1", "subnet-private-2"},
+		},
+		{
+			name:           "no private subnets in the cluster network",
+			networkSubnets: infrav1.Subnets{{ID: "subnet-public-1", IsPublic: true}},
+			expectErr:      true,
+		},
+		{
+			name:            "specified subnets are used",
+			subnetIDs:       []string{"subnet-private-2", "subnet-external"},
+			networkSubnets:  networkSubnets,
+			expectedSubnets: []string{"subnet-private-2", "subnet-external"},
+		},
+		{
+			name:           "specified public subnet is rejected",
+			subnetIDs:      []string{"subnet-private-1", "subnet-public-1"},
+			networkSubnets: networkSubnets,
+			expectErr:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := newTestFargateService(&expinfrav1.AWSFargateProfile{
+				Spec: expinfrav1.FargateProfileSpec{SubnetIDs: tc.subnetIDs},
+			})
+			s.scope.ControlPlane = &ekscontrolplanev1.AWSManagedControlPlane{
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					NetworkSpec: infrav1.NetworkSpec{Subnets: tc.networkSubnets},
+				},
+			}
+
+			subnets, err := s.profileSubnets()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(subnets).To(Equal(tc.expectedSubnets))
+		})
+	}
+}
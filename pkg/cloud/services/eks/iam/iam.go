@@ -167,6 +167,99 @@ func (s *IAMService) EnsurePoliciesAttached(role *iam.Role, policies []*string)
 	return updatedPolicies, nil
 }
 
+// EnsureInlinePolicies will ensure the role embeds exactly the given inline policies, keyed by name.
+func (s *IAMService) EnsureInlinePolicies(role *iam.Role, policies map[string]string) (bool, error) {
+	s.Debug("Ensuring inline policies are set on role")
+	existingNames, err := s.getIAMRoleInlinePolicyNames(*role.RoleName)
+	if err != nil {
+		return false, err
+	}
+
+	var updatedPolicies bool
+	for _, name := range existingNames {
+		if _, ok := policies[name]; ok {
+			continue
+		}
+		updatedPolicies = true
+		if _, err := s.IAMClient.DeleteRolePolicy(&iam.DeleteRolePolicyInput{
+			RoleName:   role.RoleName,
+			PolicyName: aws.String(name),
+		}); err != nil {
+			return false, errors.Wrapf(err, "error deleting inline policy %s from role %s", name, *role.RoleName)
+		}
+		s.Debug("Deleted inline policy from role", "role", role.RoleName, "policy", name)
+	}
+
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if findStringInSlice(aws.StringSlice(existingNames), name) {
+			out, err := s.IAMClient.GetRolePolicy(&iam.GetRolePolicyInput{
+				RoleName:   role.RoleName,
+				PolicyName: aws.String(name),
+			})
+			if err != nil {
+				return false, errors.Wrapf(err, "error getting inline policy %s of role %s", name, *role.RoleName)
+			}
+			equal, err := policyDocumentsEqual(aws.StringValue(out.PolicyDocument), policies[name])
+			if err != nil {
+				return false, errors.Wrapf(err, "error comparing inline policy %s of role %s", name, *role.RoleName)
+			}
+			if equal {
+				continue
+			}
+		}
+
+		updatedPolicies = true
+		if _, err := s.IAMClient.PutRolePolicy(&iam.PutRolePolicyInput{
+			RoleName:       role.RoleName,
+			PolicyName:     aws.String(name),
+			PolicyDocument: aws.String(policies[name]),
+		}); err != nil {
+			return false, errors.Wrapf(err, "error putting inline policy %s on role %s", name, *role.RoleName)
+		}
+		s.Debug("Put inline policy on role", "role", role.RoleName, "policy", name)
+	}
+
+	return updatedPolicies, nil
+}
+
+func (s *IAMService) getIAMRoleInlinePolicyNames(roleName string) ([]string, error) {
+	var names []string
+	if err := s.IAMClient.ListRolePoliciesPages(&iam.ListRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	}, func(out *iam.ListRolePoliciesOutput, last bool) bool {
+		names = append(names, aws.StringValueSlice(out.PolicyNames)...)
+		return true
+	}); err != nil {
+		return nil, errors.Wrapf(err, "error listing inline policies for %s", roleName)
+	}
+
+	return names, nil
+}
+
+// policyDocumentsEqual compares the URL encoded policy document returned by IAM with a JSON policy document.
+func policyDocumentsEqual(encoded, document string) (bool, error) {
+	decoded, err := url.PathUnescape(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	var current, desired interface{}
+	if err := json.Unmarshal([]byte(decoded), &current); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(document), &desired); err != nil {
+		return false, err
+	}
+
+	return cmp.Equal(current, desired), nil
+}
+
 // RoleTags returns the tags for the given role.
 func RoleTags(key string, additionalTags infrav1.Tags) []*iam.Tag {
 	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(key)] = string(infrav1.ResourceLifecycleOwned)
@@ -316,6 +409,19 @@ func (s *IAMService) DeleteRole(name string) error {
 		return errors.Wrapf(err, "error detaching policies for role %s", name)
 	}
 
+	inlinePolicies, err := s.getIAMRoleInlinePolicyNames(name)
+	if err != nil {
+		return err
+	}
+	for _, policy := range inlinePolicies {
+		if _, err := s.IAMClient.DeleteRolePolicy(&iam.DeleteRolePolicyInput{
+			RoleName:   aws.String(name),
+			PolicyName: aws.String(policy),
+		}); err != nil {
+			return errors.Wrapf(err, "error deleting inline policy %s from role %s", policy, name)
+		}
+	}
+
 	input := &iam.DeleteRoleInput{
 		RoleName: aws.String(name),
 	}
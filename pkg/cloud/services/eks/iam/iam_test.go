@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+)
+
+func TestEnsureInlinePolicies(t *testing.T) {
+	const (
+		roleName  = "nodegroup-role"
+		ebsPolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["ec2:AttachVolume"],"Resource":["*"]}]}`
+		cwPolicy  = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["logs:PutLogEvents"],"Resource":["*"]}]}`
+	)
+
+	listPolicies := func(m *mock_iamauth.MockIAMAPIMockRecorder, names ...string) {
+		m.ListRolePoliciesPages(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *iam.ListRolePoliciesInput, fn func(*iam.ListRolePoliciesOutput, bool) bool) error {
+				fn(&iam.ListRolePoliciesOutput{PolicyNames: aws.StringSlice(names)}, true)
+				return nil
+			})
+	}
+
+	testCases := []struct {
+		name        string
+		policies    map[string]string
+		expect      func(m *mock_iamauth.MockIAMAPIMockRecorder)
+		wantUpdated bool
+	}{
+		{
+			name:     "puts missing policies",
+			policies: map[string]string{"ebs": ebsPolicy},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				listPolicies(m)
+				m.PutRolePolicy(gomock.Eq(&iam.PutRolePolicyInput{
+					RoleName:       aws.String(roleName),
+					PolicyName:     aws.String("ebs"),
+					PolicyDocument: aws.String(ebsPolicy),
+				})).Return(&iam.PutRolePolicyOutput{}, nil)
+			},
+			wantUpdated: true,
+		},
+		{
+			name:     "keeps up to date policies and removes stale ones",
+			policies: map[string]string{"ebs": ebsPolicy},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				listPolicies(m, "ebs", "cloudwatch")
+				m.DeleteRolePolicy(gomock.Eq(&iam.DeleteRolePolicyInput{
+					RoleName:   aws.String(roleName),
+					PolicyName: aws.String("cloudwatch"),
+				})).Return(&iam.DeleteRolePolicyOutput{}, nil)
+				m.GetRolePolicy(gomock.Any()).Return(&iam.GetRolePolicyOutput{
+					PolicyDocument: aws.String(url.PathEscape(ebsPolicy)),
+				}, nil)
+			},
+			wantUpdated: true,
+		},
+		{
+			name:     "updates changed policies",
+			policies: map[string]string{"ebs": ebsPolicy},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				listPolicies(m, "ebs")
+				m.GetRolePolicy(gomock.Any()).Return(&iam.GetRolePolicyOutput{
+					PolicyDocument: aws.String(url.PathEscape(cwPolicy)),
+				}, nil)
+				m.PutRolePolicy(gomock.Any()).Return(&iam.PutRolePolicyOutput{}, nil)
+			},
+			wantUpdated: true,
+		},
+		{
+			name:     "does nothing when the policies match",
+			policies: map[string]string{"ebs": ebsPolicy},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				listPolicies(m, "ebs")
+				m.GetRolePolicy(gomock.Any()).Return(&iam.GetRolePolicyOutput{
+					PolicyDocument: aws.String(url.PathEscape(ebsPolicy)),
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			iamMock := mock_iamauth.NewMockIAMAPI(mockCtrl)
+			tc.expect(iamMock.EXPECT())
+
+			s := &IAMService{
+				Wrapper:   logger.NewLogger(klog.Background()),
+				IAMClient: iamMock,
+			}
+
+			updated, err := s.EnsureInlinePolicies(&iam.Role{RoleName: aws.String(roleName)}, tc.policies)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(updated).To(Equal(tc.wantUpdated))
+		})
+	}
+}
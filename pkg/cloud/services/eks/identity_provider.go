@@ -29,9 +29,11 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/identityprovider"
 )
 
+// reconcileIdentityProvider associates the OIDC identity provider of the spec with the cluster. The identity
+// provider is disassociated when it is removed from the spec.
 func (s *Service) reconcileIdentityProvider(ctx context.Context) error {
 	s.scope.Info("reconciling oidc identity provider")
-	if s.scope.OIDCIdentityProviderConfig() == nil {
+	if s.scope.OIDCIdentityProviderConfig() == nil && s.scope.ControlPlane.Status.IdentityProviderStatus.ARN == "" {
 		s.scope.Info("no oidc provider config, skipping reconcile")
 		return nil
 	}
@@ -44,56 +46,52 @@ func (s *Service) reconcileIdentityProvider(ctx context.Context) error {
 
 	desired := converters.ConvertSDKToIdentityProvider(s.scope.OIDCIdentityProviderConfig())
 
-	if desired == nil && current == nil {
-		s.scope.Info("no identity provider required or installed, no action needed")
-		return nil
-	}
-
-	s.scope.Debug("creating oidc provider plan", "desired", desired, "current", current)
-	procedures, err := identityprovider.
-		NewPlan(clusterName, current, desired, s.EKSClient, s.scope).
-		Create(ctx)
-	if err != nil {
-		s.scope.Error(err, "failed creating eks identity provider plan")
-		return fmt.Errorf("creating eks identity provider plan: %w", err)
-	}
-
-	// nothing will be done, we can leave
-	if len(procedures) == 0 {
-		return nil
-	}
-
-	s.scope.Debug("computed EKS identity provider plan", "numprocs", len(procedures))
-
-	// Perform required operations
-	for _, procedure := range procedures {
-		s.scope.Info("Executing identity provider procedure", "name", procedure.Name())
-		if err := procedure.Do(ctx); err != nil {
-			s.scope.Error(err, "failed executing identity provider procedure", "name", procedure.Name())
-			return fmt.Errorf("%s: %w", procedure.Name(), err)
+	latest := current
+	if desired != nil || current != nil {
+		s.scope.Debug("creating oidc provider plan", "desired", desired, "current", current)
+		procedures, err := identityprovider.
+			NewPlan(clusterName, current, desired, s.EKSClient, s.scope).
+			Create(ctx)
+		if err != nil {
+			s.scope.Error(err, "failed creating eks identity provider plan")
+			return fmt.Errorf("creating eks identity provider plan: %w", err)
 		}
-	}
 
-	latest, err := s.getAssociatedIdentityProvider(ctx, clusterName)
-	if err != nil {
-		return errors.Wrap(err, "getting associated identity provider")
+		if len(procedures) > 0 {
+			s.scope.Debug("computed EKS identity provider plan", "numprocs", len(procedures))
+
+			// Perform required operations
+			for _, procedure := range procedures {
+				s.scope.Info("Executing identity provider procedure", "name", procedure.Name())
+				if err := procedure.Do(ctx); err != nil {
+					s.scope.Error(err, "failed executing identity provider procedure", "name", procedure.Name())
+					return fmt.Errorf("%s: %w", procedure.Name(), err)
+				}
+			}
+
+			latest, err = s.getAssociatedIdentityProvider(ctx, clusterName)
+			if err != nil {
+				return errors.Wrap(err, "getting associated identity provider")
+			}
+		}
 	}
 
-	if latest == nil {
-		return nil
+	// the identity provider is disassociated once it isn't listed anymore
+	status := ekscontrolplanev1.IdentityProviderStatus{}
+	if latest != nil {
+		status = ekscontrolplanev1.IdentityProviderStatus{
+			ARN:    latest.IdentityProviderConfigArn,
+			Status: latest.Status,
+		}
 	}
 
 	// don't patch if arn/status is the same
-	if latest.IdentityProviderConfigArn == s.scope.ControlPlane.Status.IdentityProviderStatus.ARN &&
-		latest.Status == s.scope.ControlPlane.Status.IdentityProviderStatus.Status {
+	if status == s.scope.ControlPlane.Status.IdentityProviderStatus {
 		return nil
 	}
 
 	// idp status has changed, patch the control plane
-	s.scope.ControlPlane.Status.IdentityProviderStatus = ekscontrolplanev1.IdentityProviderStatus{
-		ARN:    latest.IdentityProviderConfigArn,
-		Status: latest.Status,
-	}
+	s.scope.ControlPlane.Status.IdentityProviderStatus = status
 
 	if err := s.scope.PatchObject(); err != nil {
 		return errors.Wrap(err, "updating identity provider status")
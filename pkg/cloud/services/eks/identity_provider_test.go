@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileIdentityProvider(t *testing.T) {
+	clusterName := "default.cluster"
+	configARN := "arn:aws:eks:us-east-1:123456789012:identityproviderconfig/default.cluster/oidc/corporate-sso/1234"
+	providerConfig := &ekscontrolplanev1.OIDCIdentityProviderConfig{
+		ClientID:                   "kubernetes",
+		IdentityProviderConfigName: "corporate-sso",
+		IssuerURL:                  "https://sso.example.com",
+	}
+	describeProvider := func(m *mock_eksiface.MockEKSAPIMockRecorder, status string) {
+		m.ListIdentityProviderConfigsWithContext(gomock.Any(), &eks.ListIdentityProviderConfigsInput{
+			ClusterName: aws.String(clusterName),
+		}).Return(&eks.ListIdentityProviderConfigsOutput{
+			IdentityProviderConfigs: []*eks.IdentityProviderConfig{
+				{Name: aws.String("corporate-sso"), Type: aws.String("oidc")},
+			},
+		}, nil)
+		m.DescribeIdentityProviderConfigWithContext(gomock.Any(), gomock.Any()).Return(&eks.DescribeIdentityProviderConfigOutput{
+			IdentityProviderConfig: &eks.IdentityProviderConfigResponse{
+				Oidc: &eks.OidcIdentityProviderConfig{
+					ClientId:                   aws.String("kubernetes"),
+					IdentityProviderConfigArn:  aws.String(configARN),
+					IdentityProviderConfigName: aws.String("corporate-sso"),
+					IssuerUrl:                  aws.String("https://sso.example.com"),
+					Status:                     aws.String(status),
+				},
+			},
+		}, nil)
+	}
+
+	tests := []struct {
+		name           string
+		providerConfig *ekscontrolplanev1.OIDCIdentityProviderConfig
+		status         ekscontrolplanev1.IdentityProviderStatus
+		expect         func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectedStatus ekscontrolplanev1.IdentityProviderStatus
+	}{
+		{
+			name:   "no identity provider",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+		},
+		{
+			name:           "identity provider already associated",
+			providerConfig: providerConfig,
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				describeProvider(m, eks.ConfigStatusActive)
+			},
+			expectedStatus: ekscontrolplanev1.IdentityProviderStatus{ARN: configARN, Status: eks.ConfigStatusActive},
+		},
+		{
+			name:   "identity provider removed from the spec",
+			status: ekscontrolplanev1.IdentityProviderStatus{ARN: configARN, Status: eks.ConfigStatusActive},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				describeProvider(m, eks.ConfigStatusActive)
+				m.DisassociateIdentityProviderConfigWithContext(gomock.Any(), &eks.DisassociateIdentityProviderConfigInput{
+					ClusterName: aws.String(clusterName),
+					IdentityProviderConfig: &eks.IdentityProviderConfig{
+						Name: aws.String("corporate-sso"),
+						Type: aws.String("oidc"),
+					},
+				}).Return(&eks.DisassociateIdentityProviderConfigOutput{}, nil)
+				describeProvider(m, eks.ConfigStatusDeleting)
+			},
+			expectedStatus: ekscontrolplanev1.IdentityProviderStatus{ARN: configARN, Status: eks.ConfigStatusDeleting},
+		},
+		{
+			name:   "identity provider disassociated",
+			status: ekscontrolplanev1.IdentityProviderStatus{ARN: configARN, Status: eks.ConfigStatusDeleting},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.ListIdentityProviderConfigsWithContext(gomock.Any(), gomock.Any()).Return(&eks.ListIdentityProviderConfigsOutput{}, nil)
+			},
+			expectedStatus: ekscontrolplanev1.IdentityProviderStatus{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns",
+					Name:      clusterName,
+				},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName:             clusterName,
+					OIDCIdentityProviderConfig: tc.providerConfig,
+				},
+				Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+					IdentityProviderStatus: tc.status,
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane.DeepCopy()).WithStatusSubresource(&ekscontrolplanev1.AWSManagedControlPlane{}).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				ControlPlane: controlPlane,
+			})
+			g.Expect(err).To(BeNil())
+
+			tc.expect(eksMock.EXPECT())
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			g.Expect(s.reconcileIdentityProvider(context.TODO())).To(Succeed())
+			g.Expect(controlPlane.Status.IdentityProviderStatus).To(Equal(tc.expectedStatus))
+		})
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// reconcileIRSARoles creates the IAM roles for service accounts of the spec, and deletes the ones which were
+// removed from the spec. The roles can only be created once the OIDC provider of the cluster exists.
+func (s *Service) reconcileIRSARoles() error {
+	if len(s.scope.ControlPlane.Spec.IRSARoles) == 0 && len(s.scope.ControlPlane.Status.OIDCProvider.IRSARoles) == 0 {
+		return nil
+	}
+	if s.scope.ControlPlane.Status.OIDCProvider.ARN == "" {
+		return nil
+	}
+	if !s.scope.EnableIAM() {
+		return errors.New("'IRSARoles' provided without enabling the 'EKSEnableIAM' feature flag")
+	}
+
+	s.scope.Debug("Reconciling IRSA roles")
+
+	desired := map[string]struct{}{}
+	statuses := make([]ekscontrolplanev1.IRSARoleStatus, 0, len(s.scope.ControlPlane.Spec.IRSARoles))
+	for _, irsaRole := range s.scope.ControlPlane.Spec.IRSARoles {
+		desired[irsaRole.RoleName] = struct{}{}
+
+		roleARN, err := s.reconcileIRSARole(irsaRole)
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, ekscontrolplanev1.IRSARoleStatus{
+			RoleName: irsaRole.RoleName,
+			ARN:      roleARN,
+		})
+	}
+
+	for _, status := range s.scope.ControlPlane.Status.OIDCProvider.IRSARoles {
+		if _, ok := desired[status.RoleName]; ok {
+			continue
+		}
+		if err := s.deleteIRSARole(status.RoleName); err != nil {
+			return err
+		}
+	}
+	s.scope.ControlPlane.Status.OIDCProvider.IRSARoles = statuses
+
+	return nil
+}
+
+func (s *Service) reconcileIRSARole(irsaRole ekscontrolplanev1.IRSARole) (string, error) {
+	trustPolicy, err := s.buildIRSATrustPolicy(irsaRole.ServiceAccount)
+	if err != nil {
+		return "", err
+	}
+
+	role, err := s.GetIAMRole(irsaRole.RoleName)
+	if err != nil {
+		if !isNotFound(err) {
+			return "", err
+		}
+
+		role, err = s.CreateRole(irsaRole.RoleName, s.scope.Name(), trustPolicy, s.scope.AdditionalTags())
+		if err != nil {
+			record.Warnf(s.scope.ControlPlane, "FailedIAMRoleCreation", "Failed to create IRSA role %q: %v", irsaRole.RoleName, err)
+			return "", fmt.Errorf("creating role %s: %w", irsaRole.RoleName, err)
+		}
+		record.Eventf(s.scope.ControlPlane, "SuccessfulIAMRoleCreation", "Created IRSA role %q", irsaRole.RoleName)
+	}
+
+	if s.IsUnmanaged(role, s.scope.Name()) {
+		s.scope.Debug("Skipping, IRSA role policy assignment as role is unmanaged", "role", irsaRole.RoleName)
+		return aws.StringValue(role.Arn), nil
+	}
+
+	if _, err := s.EnsureTagsAndPolicy(role, s.scope.Name(), trustPolicy, s.scope.AdditionalTags()); err != nil {
+		return "", errors.Wrapf(err, "error ensuring tags and trust policy of role %s", irsaRole.RoleName)
+	}
+
+	policies := aws.StringSlice(irsaRole.PolicyARNs)
+	if _, err := s.EnsurePoliciesAttached(role, policies); err != nil {
+		return "", errors.Wrapf(err, "error ensuring policies are attached: %v", irsaRole.PolicyARNs)
+	}
+
+	return aws.StringValue(role.Arn), nil
+}
+
+func (s *Service) deleteIRSARoles() error {
+	for _, status := range s.scope.ControlPlane.Status.OIDCProvider.IRSARoles {
+		if err := s.deleteIRSARole(status.RoleName); err != nil {
+			return err
+		}
+	}
+	s.scope.ControlPlane.Status.OIDCProvider.IRSARoles = nil
+
+	return nil
+}
+
+func (s *Service) deleteIRSARole(roleName string) error {
+	if !s.scope.EnableIAM() {
+		s.scope.Debug("EKS IAM disabled, skipping deleting IRSA role", "role", roleName)
+		return nil
+	}
+
+	role, err := s.GetIAMRole(roleName)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "getting IRSA role %s", roleName)
+	}
+
+	if s.IsUnmanaged(role, s.scope.Name()) {
+		s.scope.Debug("Skipping, IRSA role deletion as role is unmanaged", "role", roleName)
+		return nil
+	}
+
+	if err := s.DeleteRole(roleName); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedIAMRoleDeletion", "Failed to delete IRSA role %q: %v", roleName, err)
+		return err
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulIAMRoleDeletion", "Deleted IRSA role %q", roleName)
+
+	return nil
+}
+
+// buildIRSATrustPolicy returns the trust policy allowing a service account to assume a role through the OIDC
+// provider of the cluster. The policy is decoded from its JSON form, so that it compares equal to the trust
+// policy of an existing role.
+func (s *Service) buildIRSATrustPolicy(serviceAccount ekscontrolplanev1.ServiceAccountReference) (*iamv1.PolicyDocument, error) {
+	providerARN := s.scope.ControlPlane.Status.OIDCProvider.ARN
+	issuer := providerARN[strings.Index(providerARN, "/")+1:]
+
+	policy := iamv1.PolicyDocument{
+		Version: "2012-10-17",
+		Statement: iamv1.Statements{
+			iamv1.StatementEntry{
+				Effect: "Allow",
+				Principal: iamv1.Principals{
+					iamv1.PrincipalFederated: iamv1.PrincipalID{providerARN},
+				},
+				Action: iamv1.Actions{"sts:AssumeRoleWithWebIdentity"},
+				Condition: iamv1.Conditions{
+					iamv1.StringEquals: map[string]string{
+						issuer + ":sub": fmt.Sprintf("system:serviceaccount:%s:%s", serviceAccount.Namespace, serviceAccount.Name),
+						issuer + ":aud": "sts.amazonaws.com",
+					},
+				},
+			},
+		},
+	}
+
+	raw, err := json.Marshal(policy)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal IRSA trust policy")
+	}
+	decoded := &iamv1.PolicyDocument{}
+	if err := json.Unmarshal(raw, decoded); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal IRSA trust policy")
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileIRSARoles(t *testing.T) {
+	clusterName := "default.cluster"
+	policyARN := "arn:aws:iam::123456789012:policy/external-dns"
+	issuer := "oidc.eks.us-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE"
+	providerARN := "arn:aws:iam::123456789012:oidc-provider/" + issuer
+	ownedTags := []*iam.Tag{
+		{
+			Key:   aws.String(infrav1.ClusterAWSCloudProviderTagKey(clusterName)),
+			Value: aws.String(string(infrav1.ResourceLifecycleOwned)),
+		},
+	}
+
+	irsaRole := ekscontrolplanev1.IRSARole{
+		RoleName: "external-dns",
+		ServiceAccount: ekscontrolplanev1.ServiceAccountReference{
+			Namespace: "kube-system",
+			Name:      "external-dns",
+		},
+		PolicyARNs: []string{policyARN},
+	}
+
+	tests := []struct {
+		name           string
+		providerARN    string
+		irsaRoles      []ekscontrolplanev1.IRSARole
+		statusRoles    []ekscontrolplanev1.IRSARoleStatus
+		expect         func(m *mock_iamauth.MockIAMAPIMockRecorder)
+		expectedStatus []ekscontrolplanev1.IRSARoleStatus
+		expectError    bool
+	}{
+		{
+			name:        "OIDC provider not created yet",
+			providerARN: "",
+			irsaRoles:   []ekscontrolplanev1.IRSARole{irsaRole},
+			expect:      func(m *mock_iamauth.MockIAMAPIMockRecorder) {},
+		},
+		{
+			name:        "creates missing role",
+			providerARN: providerARN,
+			irsaRoles:   []ekscontrolplanev1.IRSARole{irsaRole},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String("external-dns")}).
+					Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "", nil))
+				m.CreateRole(gomock.AssignableToTypeOf(&iam.CreateRoleInput{})).
+					DoAndReturn(func(input *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
+						if !strings.Contains(aws.StringValue(input.AssumeRolePolicyDocument), `"`+issuer+`:sub": "system:serviceaccount:kube-system:external-dns"`) {
+							return nil, awserr.New(iam.ErrCodeMalformedPolicyDocumentException, "unexpected trust policy", nil)
+						}
+						return &iam.CreateRoleOutput{
+							Role: &iam.Role{
+								RoleName:                 input.RoleName,
+								Arn:                      aws.String("arn:aws:iam::123456789012:role/external-dns"),
+								AssumeRolePolicyDocument: input.AssumeRolePolicyDocument,
+								Tags:                     input.Tags,
+							},
+						}, nil
+					})
+				m.ListAttachedRolePolicies(gomock.Any()).Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+				m.GetPolicy(&iam.GetPolicyInput{PolicyArn: aws.String(policyARN)}).Return(&iam.GetPolicyOutput{}, nil)
+				m.AttachRolePolicy(&iam.AttachRolePolicyInput{
+					RoleName:  aws.String("external-dns"),
+					PolicyArn: aws.String(policyARN),
+				}).Return(&iam.AttachRolePolicyOutput{}, nil)
+			},
+			expectedStatus: []ekscontrolplanev1.IRSARoleStatus{
+				{RoleName: "external-dns", ARN: "arn:aws:iam::123456789012:role/external-dns"},
+			},
+		},
+		{
+			name:        "deletes role removed from the spec",
+			providerARN: providerARN,
+			statusRoles: []ekscontrolplanev1.IRSARoleStatus{
+				{RoleName: "external-dns", ARN: "arn:aws:iam::123456789012:role/external-dns"},
+			},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String("external-dns")}).Return(&iam.GetRoleOutput{
+					Role: &iam.Role{
+						RoleName: aws.String("external-dns"),
+						Tags:     ownedTags,
+					},
+				}, nil)
+				m.ListAttachedRolePolicies(gomock.Any()).Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+				m.ListRolePoliciesPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.DeleteRole(&iam.DeleteRoleInput{RoleName: aws.String("external-dns")}).Return(&iam.DeleteRoleOutput{}, nil)
+			},
+			expectedStatus: []ekscontrolplanev1.IRSARoleStatus{},
+		},
+		{
+			name:        "keeps unmanaged role",
+			providerARN: providerARN,
+			statusRoles: []ekscontrolplanev1.IRSARoleStatus{
+				{RoleName: "external-dns", ARN: "arn:aws:iam::123456789012:role/external-dns"},
+			},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(gomock.Any()).Return(&iam.GetRoleOutput{
+					Role: &iam.Role{RoleName: aws.String("external-dns")},
+				}, nil)
+			},
+			expectedStatus: []ekscontrolplanev1.IRSARoleStatus{},
+		},
+		{
+			name:        "create error",
+			providerARN: providerARN,
+			irsaRoles:   []ekscontrolplanev1.IRSARole{irsaRole},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(gomock.Any()).Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "", nil))
+				m.CreateRole(gomock.Any()).Return(nil, awserr.New(iam.ErrCodeLimitExceededException, "", nil))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			iamMock := mock_iamauth.NewMockIAMAPI(mockControl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName:        clusterName,
+						AssociateOIDCProvider: true,
+						IRSARoles:             tc.irsaRoles,
+					},
+					Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+						OIDCProvider: ekscontrolplanev1.OIDCProviderStatus{
+							ARN:       tc.providerARN,
+							IRSARoles: tc.statusRoles,
+						},
+					},
+				},
+				EnableIAM: true,
+			})
+			g.Expect(err).To(BeNil())
+
+			tc.expect(iamMock.EXPECT())
+			s := NewService(scope)
+			s.IAMClient = iamMock
+
+			err = s.reconcileIRSARoles()
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			if tc.expectedStatus != nil {
+				g.Expect(scope.ControlPlane.Status.OIDCProvider.IRSARoles).To(Equal(tc.expectedStatus))
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package eks
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -28,6 +29,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
@@ -369,12 +371,14 @@ func (s *NodegroupService) reconcileNodegroupVersion(ng *eks.Nodegroup) error {
 		}
 
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			if _, err := s.EKSClient.UpdateNodegroupVersion(input); err != nil {
+			out, err := s.EKSClient.UpdateNodegroupVersion(input)
+			if err != nil {
 				if aerr, ok := err.(awserr.Error); ok {
 					return false, aerr
 				}
 				return false, err
 			}
+			s.setUpdate(out.Update)
 			record.Eventf(s.scope.ManagedMachinePool, "SuccessfulUpdateEKSNodegroup", "Updated EKS nodegroup %s %s", eksClusterName, updateMsg)
 			return true, nil
 		}); err != nil {
@@ -483,8 +487,9 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 		input.ScalingConfig = s.scalingConfig()
 		needsUpdate = true
 	}
+	// EKS defaults the update configuration of the node groups, which is kept when the spec doesn't set it.
 	currentUpdateConfig := converters.NodegroupUpdateconfigFromSDK(ng.UpdateConfig)
-	if !cmp.Equal(managedPool.UpdateConfig, currentUpdateConfig) {
+	if managedPool.UpdateConfig != nil && !cmp.Equal(managedPool.UpdateConfig, currentUpdateConfig) {
 		s.Debug("Nodegroup update configuration differs from spec, updating the nodegroup update config", "nodegroup", ng.NodegroupName)
 		input.UpdateConfig = s.updateConfig()
 		needsUpdate = true
@@ -497,14 +502,77 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 		return errors.Wrap(err, "created invalid UpdateNodegroupConfigInput")
 	}
 
-	_, err = s.EKSClient.UpdateNodegroupConfig(input)
+	out, err := s.EKSClient.UpdateNodegroupConfig(input)
 	if err != nil {
+		record.Warnf(s.scope.ManagedMachinePool, "FailedUpdateEKSNodegroupConfig", "Failed to update the configuration of EKS nodegroup %s: %v", *ng.NodegroupName, err)
 		return errors.Wrap(err, "failed to update nodegroup config")
 	}
+	s.setUpdate(out.Update)
+	record.Eventf(s.scope.ManagedMachinePool, "SuccessfulUpdateEKSNodegroupConfig", "Updating the configuration of EKS nodegroup %s", *ng.NodegroupName)
+
+	return nil
+}
+
+// reconcileUpdates refreshes the status of the updates of the node group which were in progress, and reports the
+// ones which didn't succeed.
+func (s *NodegroupService) reconcileUpdates() error {
+	for _, update := range s.scope.ManagedMachinePool.Status.Updates {
+		if update.Status != eks.UpdateStatusInProgress {
+			continue
+		}
+
+		out, err := s.EKSClient.DescribeUpdate(&eks.DescribeUpdateInput{
+			Name:          aws.String(s.scope.KubernetesClusterName()),
+			NodegroupName: aws.String(s.scope.NodegroupName()),
+			UpdateId:      aws.String(update.ID),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe nodegroup update %s", update.ID)
+		}
+		s.setUpdate(out.Update)
+
+		switch aws.StringValue(out.Update.Status) {
+		case eks.UpdateStatusFailed, eks.UpdateStatusCancelled:
+			record.Warnf(s.scope.ManagedMachinePool, "FailedUpdateEKSNodegroup", "Update %s of EKS nodegroup %s is %s: %s", update.ID, s.scope.NodegroupName(),
+				strings.ToLower(aws.StringValue(out.Update.Status)), strings.Join(updateErrors(out.Update), ", "))
+		case eks.UpdateStatusSuccessful:
+			record.Eventf(s.scope.ManagedMachinePool, "SuccessfulUpdateEKSNodegroup", "Update %s of EKS nodegroup %s succeeded", update.ID, s.scope.NodegroupName())
+		}
+	}
 
 	return nil
 }
 
+// setUpdate records the status of an update of the node group in place of the previous update of the same type.
+func (s *NodegroupService) setUpdate(update *eks.Update) {
+	if update == nil {
+		return
+	}
+
+	nodegroupUpdate := expinfrav1.NodegroupUpdate{
+		ID:     aws.StringValue(update.Id),
+		Type:   aws.StringValue(update.Type),
+		Status: aws.StringValue(update.Status),
+		Errors: updateErrors(update),
+	}
+	status := &s.scope.ManagedMachinePool.Status
+	for i := range status.Updates {
+		if status.Updates[i].Type == nodegroupUpdate.Type {
+			status.Updates[i] = nodegroupUpdate
+			return
+		}
+	}
+	status.Updates = append(status.Updates, nodegroupUpdate)
+}
+
+func updateErrors(update *eks.Update) []string {
+	var errs []string
+	for _, detail := range update.Errors {
+		errs = append(errs, aws.StringValue(detail.ErrorMessage))
+	}
+	return errs
+}
+
 func (s *NodegroupService) reconcileNodegroup(ctx context.Context) error {
 	ng, err := s.describeNodegroup()
 	if err != nil {
@@ -538,11 +606,11 @@ func (s *NodegroupService) reconcileNodegroup(ctx context.Context) error {
 	}
 
 	if annotations.ReplicasManagedByExternalAutoscaler(s.scope.MachinePool) {
-		// Set MachinePool replicas to the node group DesiredCapacity
+		// Set MachinePool replicas to the node group DesiredCapacity, the replicas may not be set yet.
 		ngDesiredCapacity := int32(aws.Int64Value(ng.ScalingConfig.DesiredSize))
-		if *s.scope.MachinePool.Spec.Replicas != ngDesiredCapacity {
+		if !ptr.Equal(s.scope.MachinePool.Spec.Replicas, &ngDesiredCapacity) {
 			s.scope.Info("Setting MachinePool replicas to node group DesiredCapacity",
-				"local", *s.scope.MachinePool.Spec.Replicas,
+				"local", s.scope.MachinePool.Spec.Replicas,
 				"external", ngDesiredCapacity)
 			s.scope.MachinePool.Spec.Replicas = &ngDesiredCapacity
 			if err := s.scope.PatchCAPIMachinePoolObject(ctx); err != nil {
@@ -555,6 +623,10 @@ func (s *NodegroupService) reconcileNodegroup(ctx context.Context) error {
 		return errors.Wrap(err, "failed to wait for nodegroup to be active")
 	}
 
+	if err := s.reconcileUpdates(); err != nil {
+		return errors.Wrap(err, "failed to reconcile nodegroup updates")
+	}
+
 	if err := s.reconcileNodegroupVersion(ng); err != nil {
 		return errors.Wrap(err, "failed to reconcile nodegroup version")
 	}
@@ -0,0 +1,239 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func TestReconcileNodegroupUpdates(t *testing.T) {
+	configUpdate := expinfrav1.NodegroupUpdate{ID: "config-1", Type: eks.UpdateTypeConfigUpdate, Status: eks.UpdateStatusInProgress}
+	versionUpdate := expinfrav1.NodegroupUpdate{ID: "version-1", Type: eks.UpdateTypeVersionUpdate, Status: eks.UpdateStatusSuccessful}
+
+	tests := []struct {
+		name            string
+		updates         []expinfrav1.NodegroupUpdate
+		expect          func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectedUpdates []expinfrav1.NodegroupUpdate
+	}{
+		{
+			name:   "no updates",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+		},
+		{
+			name:            "completed updates are not described",
+			updates:         []expinfrav1.NodegroupUpdate{versionUpdate},
+			expect:          func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectedUpdates: []expinfrav1.NodegroupUpdate{versionUpdate},
+		},
+		{
+			name:    "update in progress succeeded",
+			updates: []expinfrav1.NodegroupUpdate{versionUpdate, configUpdate},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeUpdate(&eks.DescribeUpdateInput{
+					Name:          aws.String("cluster"),
+					NodegroupName: aws.String("nodegroup"),
+					UpdateId:      aws.String("config-1"),
+				}).Return(&eks.DescribeUpdateOutput{
+					Update: &eks.Update{
+						Id:     aws.String("config-1"),
+						Type:   aws.String(eks.UpdateTypeConfigUpdate),
+						Status: aws.String(eks.UpdateStatusSuccessful),
+					},
+				}, nil)
+			},
+			expectedUpdates: []expinfrav1.NodegroupUpdate{
+				versionUpdate,
+				{ID: "config-1", Type: eks.UpdateTypeConfigUpdate, Status: eks.UpdateStatusSuccessful},
+			},
+		},
+		{
+			name:    "update in progress failed",
+			updates: []expinfrav1.NodegroupUpdate{configUpdate},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeUpdate(gomock.Any()).Return(&eks.DescribeUpdateOutput{
+					Update: &eks.Update{
+						Id:     aws.String("config-1"),
+						Type:   aws.String(eks.UpdateTypeConfigUpdate),
+						Status: aws.String(eks.UpdateStatusFailed),
+						Errors: []*eks.ErrorDetail{
+							{ErrorCode: aws.String(eks.ErrorCodeNodeCreationFailure), ErrorMessage: aws.String("taint is invalid")},
+						},
+					},
+				}, nil)
+			},
+			expectedUpdates: []expinfrav1.NodegroupUpdate{
+				{ID: "config-1", Type: eks.UpdateTypeConfigUpdate, Status: eks.UpdateStatusFailed, Errors: []string{"taint is invalid"}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+			tc.expect(eksMock.EXPECT())
+
+			s := &NodegroupService{
+				scope: &scope.ManagedMachinePoolScope{
+					ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+						Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster"},
+					},
+					ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pool"},
+						Spec:       expinfrav1.AWSManagedMachinePoolSpec{EKSNodegroupName: "nodegroup"},
+						Status:     expinfrav1.AWSManagedMachinePoolStatus{Updates: tc.updates},
+					},
+				},
+				EKSClient: eksMock,
+			}
+
+			g.Expect(s.reconcileUpdates()).To(Succeed())
+			g.Expect(s.scope.ManagedMachinePool.Status.Updates).To(Equal(tc.expectedUpdates))
+		})
+	}
+}
+
+func TestSetNodegroupUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &NodegroupService{
+		scope: &scope.ManagedMachinePoolScope{
+			ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+				Status: expinfrav1.AWSManagedMachinePoolStatus{
+					Updates: []expinfrav1.NodegroupUpdate{
+						{ID: "version-1", Type: eks.UpdateTypeVersionUpdate, Status: eks.UpdateStatusSuccessful},
+						{ID: "config-1", Type: eks.UpdateTypeConfigUpdate, Status: eks.UpdateStatusFailed, Errors: []string{"taint is invalid"}},
+					},
+				},
+			},
+		},
+	}
+
+	s.setUpdate(&eks.Update{
+		Id:     aws.String("config-2"),
+		Type:   aws.String(eks.UpdateTypeConfigUpdate),
+		Status: aws.String(eks.UpdateStatusInProgress),
+	})
+	g.Expect(s.scope.ManagedMachinePool.Status.Updates).To(Equal([]expinfrav1.NodegroupUpdate{
+		{ID: "version-1", Type: eks.UpdateTypeVersionUpdate, Status: eks.UpdateStatusSuccessful},
+		{ID: "config-2", Type: eks.UpdateTypeConfigUpdate, Status: eks.UpdateStatusInProgress},
+	}))
+}
+
+func TestReconcileNodegroupUpdateConfig(t *testing.T) {
+	tests := []struct {
+		name                string
+		specUpdateConfig    *expinfrav1.UpdateConfig
+		currentUpdateConfig *eks.NodegroupUpdateConfig
+		expectedUpdate      *eks.NodegroupUpdateConfig
+	}{
+		{
+			name:                "default update config is kept without update config in the spec",
+			currentUpdateConfig: &eks.NodegroupUpdateConfig{MaxUnavailable: aws.Int64(1)},
+		},
+		{
+			name:                "update config matching the spec",
+			specUpdateConfig:    &expinfrav1.UpdateConfig{MaxUnavailable: ptr.To[int](3)},
+			currentUpdateConfig: &eks.NodegroupUpdateConfig{MaxUnavailable: aws.Int64(3)},
+		},
+		{
+			name:                "max unavailable changed",
+			specUpdateConfig:    &expinfrav1.UpdateConfig{MaxUnavailable: ptr.To[int](3)},
+			currentUpdateConfig: &eks.NodegroupUpdateConfig{MaxUnavailable: aws.Int64(1)},
+			expectedUpdate:      &eks.NodegroupUpdateConfig{MaxUnavailable: aws.Int64(3)},
+		},
+		{
+			name:                "max unavailable replaced by a percentage",
+			specUpdateConfig:    &expinfrav1.UpdateConfig{MaxUnavailablePercentage: ptr.To[int](25)},
+			currentUpdateConfig: &eks.NodegroupUpdateConfig{MaxUnavailable: aws.Int64(1)},
+			expectedUpdate:      &eks.NodegroupUpdateConfig{MaxUnavailablePercentage: aws.Int64(25)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+			if tc.expectedUpdate != nil {
+				eksMock.EXPECT().UpdateNodegroupConfig(&eks.UpdateNodegroupConfigInput{
+					ClusterName:   aws.String("cluster"),
+					NodegroupName: aws.String("nodegroup"),
+					UpdateConfig:  tc.expectedUpdate,
+				}).Return(&eks.UpdateNodegroupConfigOutput{
+					Update: &eks.Update{
+						Id:     aws.String("config-1"),
+						Type:   aws.String(eks.UpdateTypeConfigUpdate),
+						Status: aws.String(eks.UpdateStatusInProgress),
+					},
+				}, nil)
+			}
+
+			machinePoolScope := &scope.ManagedMachinePoolScope{
+				Logger: *logger.NewLogger(logr.Discard()),
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster"},
+				},
+				ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pool"},
+					Spec: expinfrav1.AWSManagedMachinePoolSpec{
+						EKSNodegroupName: "nodegroup",
+						UpdateConfig:     tc.specUpdateConfig,
+					},
+				},
+				MachinePool: &expclusterv1.MachinePool{
+					Spec: expclusterv1.MachinePoolSpec{Replicas: ptr.To[int32](2)},
+				},
+			}
+			s := &NodegroupService{
+				scope:      machinePoolScope,
+				EKSClient:  eksMock,
+				IAMService: iam.IAMService{Wrapper: &machinePoolScope.Logger},
+			}
+
+			g.Expect(s.reconcileNodegroupConfig(&eks.Nodegroup{
+				NodegroupName: aws.String("nodegroup"),
+				ScalingConfig: &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(2)},
+				UpdateConfig:  tc.currentUpdateConfig,
+			})).To(Succeed())
+		})
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	eksaddons "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/addons"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// podIdentityAgentAddon is the name of the EKS addon installing the EKS Pod Identity Agent.
+const podIdentityAgentAddon = "eks-pod-identity-agent"
+
+// reconcilePodIdentityAssociations creates, updates and deletes the pod identity associations of the cluster
+// to match the associations of the spec and of its addons. Only the associations created by the controller
+// are deleted.
+func (s *Service) reconcilePodIdentityAssociations(ctx context.Context) error {
+	s.scope.Debug("Reconciling EKS pod identity associations")
+
+	existing := map[string]string{}
+	if err := s.EKSClient.ListPodIdentityAssociationsPagesWithContext(ctx, &eks.ListPodIdentityAssociationsInput{
+		ClusterName: aws.String(s.scope.KubernetesClusterName()),
+	}, func(page *eks.ListPodIdentityAssociationsOutput, _ bool) bool {
+		for _, association := range page.Associations {
+			key := serviceAccountKey(aws.StringValue(association.Namespace), aws.StringValue(association.ServiceAccount))
+			existing[key] = aws.StringValue(association.AssociationId)
+		}
+		return true
+	}); err != nil {
+		return errors.Wrap(err, "failed to list pod identity associations")
+	}
+
+	desired := map[string]struct{}{}
+	for _, association := range s.desiredPodIdentityAssociations() {
+		key := serviceAccountKey(association.ServiceAccount.Namespace, association.ServiceAccount.Name)
+		desired[key] = struct{}{}
+
+		if associationID, ok := existing[key]; ok {
+			if err := s.updatePodIdentityAssociation(ctx, associationID, association); err != nil {
+				return err
+			}
+		} else if err := s.createPodIdentityAssociation(ctx, association); err != nil {
+			return err
+		}
+	}
+
+	for key, associationID := range existing {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		if err := s.deleteOwnedPodIdentityAssociation(ctx, associationID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// desiredPodIdentityAssociations returns the pod identity associations of the spec, followed by the ones of the
+// addons of the spec.
+func (s *Service) desiredPodIdentityAssociations() []ekscontrolplanev1.PodIdentityAssociation {
+	associations := append([]ekscontrolplanev1.PodIdentityAssociation{}, s.scope.ControlPlane.Spec.PodIdentityAssociations...)
+	if s.scope.ControlPlane.Spec.Addons == nil {
+		return associations
+	}
+	for _, addon := range *s.scope.ControlPlane.Spec.Addons {
+		associations = append(associations, addon.PodIdentityAssociations...)
+	}
+	return associations
+}
+
+func (s *Service) createPodIdentityAssociation(ctx context.Context, association ekscontrolplanev1.PodIdentityAssociation) error {
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Additional:  s.scope.AdditionalTags(),
+	})
+
+	serviceAccount := serviceAccountKey(association.ServiceAccount.Namespace, association.ServiceAccount.Name)
+	if _, err := s.EKSClient.CreatePodIdentityAssociationWithContext(ctx, &eks.CreatePodIdentityAssociationInput{
+		ClusterName:    aws.String(s.scope.KubernetesClusterName()),
+		Namespace:      aws.String(association.ServiceAccount.Namespace),
+		ServiceAccount: aws.String(association.ServiceAccount.Name),
+		RoleArn:        aws.String(association.RoleARN),
+		Tags:           aws.StringMap(tags),
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedCreateEKSPodIdentityAssociation", "Failed to create pod identity association for %s: %v", serviceAccount, err)
+		return errors.Wrapf(err, "failed to create pod identity association for %s", serviceAccount)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulCreateEKSPodIdentityAssociation", "Created pod identity association for %s", serviceAccount)
+
+	return nil
+}
+
+// updatePodIdentityAssociation updates the role of a pod identity association if it differs from the spec. The
+// associations not created by the controller are left in place, and the conflict is reported.
+func (s *Service) updatePodIdentityAssociation(ctx context.Context, associationID string, association ekscontrolplanev1.PodIdentityAssociation) error {
+	out, err := s.EKSClient.DescribePodIdentityAssociationWithContext(ctx, &eks.DescribePodIdentityAssociationInput{
+		ClusterName:   aws.String(s.scope.KubernetesClusterName()),
+		AssociationId: aws.String(associationID),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe pod identity association %s", associationID)
+	}
+	if aws.StringValue(out.Association.RoleArn) == association.RoleARN {
+		return nil
+	}
+
+	serviceAccount := serviceAccountKey(association.ServiceAccount.Namespace, association.ServiceAccount.Name)
+	if !infrav1.Tags(aws.StringValueMap(out.Association.Tags)).HasOwned(s.scope.Name()) {
+		record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSPodIdentityAssociation", "Pod identity association for %s uses role %s instead of %s, and is not updated as it was not created by the controller",
+			serviceAccount, aws.StringValue(out.Association.RoleArn), association.RoleARN)
+		return nil
+	}
+
+	if _, err := s.EKSClient.UpdatePodIdentityAssociationWithContext(ctx, &eks.UpdatePodIdentityAssociationInput{
+		ClusterName:   aws.String(s.scope.KubernetesClusterName()),
+		AssociationId: aws.String(associationID),
+		RoleArn:       aws.String(association.RoleARN),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to update pod identity association for %s", serviceAccount)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulUpdateEKSPodIdentityAssociation", "Updated pod identity association for %s", serviceAccount)
+
+	return nil
+}
+
+// deleteOwnedPodIdentityAssociation deletes a pod identity association if it was created by the controller.
+func (s *Service) deleteOwnedPodIdentityAssociation(ctx context.Context, associationID string) error {
+	out, err := s.EKSClient.DescribePodIdentityAssociationWithContext(ctx, &eks.DescribePodIdentityAssociationInput{
+		ClusterName:   aws.String(s.scope.KubernetesClusterName()),
+		AssociationId: aws.String(associationID),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eks.ErrCodeResourceNotFoundException {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to describe pod identity association %s", associationID)
+	}
+
+	tags := infrav1.Tags(aws.StringValueMap(out.Association.Tags))
+	if !tags.HasOwned(s.scope.Name()) {
+		return nil
+	}
+
+	serviceAccount := serviceAccountKey(aws.StringValue(out.Association.Namespace), aws.StringValue(out.Association.ServiceAccount))
+	if _, err := s.EKSClient.DeletePodIdentityAssociationWithContext(ctx, &eks.DeletePodIdentityAssociationInput{
+		ClusterName:   aws.String(s.scope.KubernetesClusterName()),
+		AssociationId: aws.String(associationID),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to delete pod identity association for %s", serviceAccount)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulDeleteEKSPodIdentityAssociation", "Deleted pod identity association for %s", serviceAccount)
+
+	return nil
+}
+
+// desiredAddons returns the addons of the spec, along with the EKS Pod Identity Agent addon when it is enabled.
+// Without a version in the spec, the installed version of the agent is kept, and it is installed with the default
+// version of the addon for the Kubernetes version of the cluster.
+func (s *Service) desiredAddons(installed []*eksaddons.EKSAddon) ([]ekscontrolplanev1.Addon, error) {
+	addons := s.scope.Addons()
+	agent := s.scope.ControlPlane.Spec.PodIdentityAgent
+	if !agent.Enable {
+		return addons, nil
+	}
+
+	agentVersion := agent.Version
+	if agentVersion == "" {
+		for _, addon := range installed {
+			if aws.StringValue(addon.Name) == podIdentityAgentAddon {
+				agentVersion = aws.StringValue(addon.Version)
+			}
+		}
+	}
+	if agentVersion == "" {
+		var err error
+		if agentVersion, err = s.defaultAddonVersion(podIdentityAgentAddon); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(append([]ekscontrolplanev1.Addon{}, addons...), ekscontrolplanev1.Addon{
+		Name:    podIdentityAgentAddon,
+		Version: agentVersion,
+	}), nil
+}
+
+// defaultAddonVersion returns the version of an addon which EKS installs by default for the Kubernetes version of
+// the cluster.
+func (s *Service) defaultAddonVersion(addonName string) (string, error) {
+	cluster, err := s.describeEKSCluster(s.scope.KubernetesClusterName())
+	if err != nil {
+		return "", err
+	}
+	if cluster == nil {
+		return "", errors.Errorf("eks cluster %s not found", s.scope.KubernetesClusterName())
+	}
+
+	out, err := s.EKSClient.DescribeAddonVersions(&eks.DescribeAddonVersionsInput{
+		AddonName:         aws.String(addonName),
+		KubernetesVersion: cluster.Version,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe versions of addon %s", addonName)
+	}
+	for _, addon := range out.Addons {
+		for _, addonVersion := range addon.AddonVersions {
+			for _, compatibility := range addonVersion.Compatibilities {
+				if aws.BoolValue(compatibility.DefaultVersion) {
+					return aws.StringValue(addonVersion.AddonVersion), nil
+				}
+			}
+		}
+	}
+
+	return "", errors.Errorf("no default version of addon %s for kubernetes %s", addonName, aws.StringValue(cluster.Version))
+}
+
+func serviceAccountKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
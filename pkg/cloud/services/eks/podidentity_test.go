@@ -0,0 +1,353 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	eksaddons "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/addons"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcilePodIdentityAssociations(t *testing.T) {
+	clusterName := "default.cluster"
+	roleARN := "arn:aws:iam::123456789012:role/external-dns"
+	ownedTags := map[string]*string{
+		infrav1.ClusterTagKey(clusterName): aws.String(string(infrav1.ResourceLifecycleOwned)),
+	}
+
+	externalDNS := ekscontrolplanev1.PodIdentityAssociation{
+		ServiceAccount: ekscontrolplanev1.ServiceAccountReference{Namespace: "kube-system", Name: "external-dns"},
+		RoleARN:        roleARN,
+	}
+	ebsCSIAddon := ekscontrolplanev1.Addon{
+		Name:    "aws-ebs-csi-driver",
+		Version: "v1.25.0-eksbuild.1",
+		PodIdentityAssociations: []ekscontrolplanev1.PodIdentityAssociation{
+			{
+				ServiceAccount: ekscontrolplanev1.ServiceAccountReference{Namespace: "kube-system", Name: "ebs-csi-controller-sa"},
+				RoleARN:        "arn:aws:iam::123456789012:role/ebs-csi",
+			},
+		},
+	}
+
+	listAssociations := func(m *mock_eksiface.MockEKSAPIMockRecorder, associations ...*eks.PodIdentityAssociationSummary) {
+		m.ListPodIdentityAssociationsPagesWithContext(gomock.Any(), &eks.ListPodIdentityAssociationsInput{ClusterName: aws.String(clusterName)}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *eks.ListPodIdentityAssociationsInput, fn func(*eks.ListPodIdentityAssociationsOutput, bool) bool, _ ...interface{}) error {
+				fn(&eks.ListPodIdentityAssociationsOutput{Associations: associations}, true)
+				return nil
+			})
+	}
+	externalDNSSummary := &eks.PodIdentityAssociationSummary{
+		AssociationId:  aws.String("a-1"),
+		Namespace:      aws.String("kube-system"),
+		ServiceAccount: aws.String("external-dns"),
+	}
+
+	tests := []struct {
+		name         string
+		associations []ekscontrolplanev1.PodIdentityAssociation
+		addons       *[]ekscontrolplanev1.Addon
+		expect       func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectError  bool
+	}{
+		{
+			name: "no associations",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m)
+			},
+		},
+		{
+			name:         "creates the associations of the spec and of the addons",
+			associations: []ekscontrolplanev1.PodIdentityAssociation{externalDNS},
+			addons:       &[]ekscontrolplanev1.Addon{ebsCSIAddon},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m)
+				m.CreatePodIdentityAssociationWithContext(gomock.Any(), &eks.CreatePodIdentityAssociationInput{
+					ClusterName:    aws.String(clusterName),
+					Namespace:      aws.String("kube-system"),
+					ServiceAccount: aws.String("external-dns"),
+					RoleArn:        aws.String(roleARN),
+					Tags:           ownedTags,
+				}).Return(&eks.CreatePodIdentityAssociationOutput{}, nil)
+				m.CreatePodIdentityAssociationWithContext(gomock.Any(), &eks.CreatePodIdentityAssociationInput{
+					ClusterName:    aws.String(clusterName),
+					Namespace:      aws.String("kube-system"),
+					ServiceAccount: aws.String("ebs-csi-controller-sa"),
+					RoleArn:        aws.String("arn:aws:iam::123456789012:role/ebs-csi"),
+					Tags:           ownedTags,
+				}).Return(&eks.CreatePodIdentityAssociationOutput{}, nil)
+			},
+		},
+		{
+			name:         "association up to date",
+			associations: []ekscontrolplanev1.PodIdentityAssociation{externalDNS},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m, externalDNSSummary)
+				m.DescribePodIdentityAssociationWithContext(gomock.Any(), &eks.DescribePodIdentityAssociationInput{
+					ClusterName:   aws.String(clusterName),
+					AssociationId: aws.String("a-1"),
+				}).Return(&eks.DescribePodIdentityAssociationOutput{
+					Association: &eks.PodIdentityAssociation{RoleArn: aws.String(roleARN)},
+				}, nil)
+			},
+		},
+		{
+			name:         "updates the role of an association",
+			associations: []ekscontrolplanev1.PodIdentityAssociation{externalDNS},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m, externalDNSSummary)
+				m.DescribePodIdentityAssociationWithContext(gomock.Any(), gomock.Any()).Return(&eks.DescribePodIdentityAssociationOutput{
+					Association: &eks.PodIdentityAssociation{
+						RoleArn: aws.String("arn:aws:iam::123456789012:role/previous"),
+						Tags:    ownedTags,
+					},
+				}, nil)
+				m.UpdatePodIdentityAssociationWithContext(gomock.Any(), &eks.UpdatePodIdentityAssociationInput{
+					ClusterName:   aws.String(clusterName),
+					AssociationId: aws.String("a-1"),
+					RoleArn:       aws.String(roleARN),
+				}).Return(&eks.UpdatePodIdentityAssociationOutput{}, nil)
+			},
+		},
+		{
+			name:         "keeps the role of an unmanaged association",
+			associations: []ekscontrolplanev1.PodIdentityAssociation{externalDNS},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m, externalDNSSummary)
+				m.DescribePodIdentityAssociationWithContext(gomock.Any(), gomock.Any()).Return(&eks.DescribePodIdentityAssociationOutput{
+					Association: &eks.PodIdentityAssociation{RoleArn: aws.String("arn:aws:iam::123456789012:role/previous")},
+				}, nil)
+			},
+		},
+		{
+			name: "deletes owned association removed from the spec",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m, externalDNSSummary)
+				m.DescribePodIdentityAssociationWithContext(gomock.Any(), gomock.Any()).Return(&eks.DescribePodIdentityAssociationOutput{
+					Association: &eks.PodIdentityAssociation{
+						Namespace:      aws.String("kube-system"),
+						ServiceAccount: aws.String("external-dns"),
+						RoleArn:        aws.String(roleARN),
+						Tags:           ownedTags,
+					},
+				}, nil)
+				m.DeletePodIdentityAssociationWithContext(gomock.Any(), &eks.DeletePodIdentityAssociationInput{
+					ClusterName:   aws.String(clusterName),
+					AssociationId: aws.String("a-1"),
+				}).Return(&eks.DeletePodIdentityAssociationOutput{}, nil)
+			},
+		},
+		{
+			name: "keeps unmanaged association",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m, externalDNSSummary)
+				m.DescribePodIdentityAssociationWithContext(gomock.Any(), gomock.Any()).Return(&eks.DescribePodIdentityAssociationOutput{
+					Association: &eks.PodIdentityAssociation{RoleArn: aws.String(roleARN)},
+				}, nil)
+			},
+		},
+		{
+			name:         "create error",
+			associations: []ekscontrolplanev1.PodIdentityAssociation{externalDNS},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m)
+				m.CreatePodIdentityAssociationWithContext(gomock.Any(), gomock.Any()).
+					Return(nil, awserr.New(eks.ErrCodeInvalidParameterException, "", nil))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName:          clusterName,
+						PodIdentityAssociations: tc.associations,
+						Addons:                  tc.addons,
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			tc.expect(eksMock.EXPECT())
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			err = s.reconcilePodIdentityAssociations(context.TODO())
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
+
+func TestDesiredAddonsPodIdentityAgent(t *testing.T) {
+	clusterName := "default.cluster"
+	specAddons := []ekscontrolplanev1.Addon{
+		{Name: "vpc-cni", Version: "v1.16.0-eksbuild.1"},
+	}
+	installedAgent := &eksaddons.EKSAddon{
+		Name:    aws.String(podIdentityAgentAddon),
+		Version: aws.String("v1.0.0-eksbuild.1"),
+	}
+
+	tests := []struct {
+		name           string
+		agent          ekscontrolplanev1.PodIdentityAgent
+		installed      []*eksaddons.EKSAddon
+		expect         func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectedAddons []ekscontrolplanev1.Addon
+	}{
+		{
+			name:           "pod identity agent disabled",
+			expect:         func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectedAddons: specAddons,
+		},
+		{
+			name:   "pod identity agent with a version",
+			agent:  ekscontrolplanev1.PodIdentityAgent{Enable: true, Version: "v1.2.0-eksbuild.1"},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectedAddons: append(specAddons, ekscontrolplanev1.Addon{
+				Name:    podIdentityAgentAddon,
+				Version: "v1.2.0-eksbuild.1",
+			}),
+		},
+		{
+			name:      "keeps the installed version of the pod identity agent",
+			agent:     ekscontrolplanev1.PodIdentityAgent{Enable: true},
+			installed: []*eksaddons.EKSAddon{installedAgent},
+			expect:    func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectedAddons: append(specAddons, ekscontrolplanev1.Addon{
+				Name:    podIdentityAgentAddon,
+				Version: "v1.0.0-eksbuild.1",
+			}),
+		},
+		{
+			name:  "installs the default version of the pod identity agent",
+			agent: ekscontrolplanev1.PodIdentityAgent{Enable: true},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeCluster(&eks.DescribeClusterInput{Name: aws.String(clusterName)}).Return(&eks.DescribeClusterOutput{
+					Cluster: &eks.Cluster{Name: aws.String(clusterName), Version: aws.String("1.29")},
+				}, nil)
+				m.DescribeAddonVersions(&eks.DescribeAddonVersionsInput{
+					AddonName:         aws.String(podIdentityAgentAddon),
+					KubernetesVersion: aws.String("1.29"),
+				}).Return(&eks.DescribeAddonVersionsOutput{
+					Addons: []*eks.AddonInfo{
+						{
+							AddonName: aws.String(podIdentityAgentAddon),
+							AddonVersions: []*eks.AddonVersionInfo{
+								{
+									AddonVersion:    aws.String("v1.2.0-eksbuild.1"),
+									Compatibilities: []*eks.Compatibility{{DefaultVersion: aws.Bool(false)}},
+								},
+								{
+									AddonVersion:    aws.String("v1.1.0-eksbuild.1"),
+									Compatibilities: []*eks.Compatibility{{DefaultVersion: aws.Bool(true)}},
+								},
+							},
+						},
+					},
+				}, nil)
+			},
+			expectedAddons: append(specAddons, ekscontrolplanev1.Addon{
+				Name:    podIdentityAgentAddon,
+				Version: "v1.1.0-eksbuild.1",
+			}),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			addons := append([]ekscontrolplanev1.Addon{}, specAddons...)
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName:   clusterName,
+						Addons:           &addons,
+						PodIdentityAgent: tc.agent,
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			tc.expect(eksMock.EXPECT())
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			desired, err := s.desiredAddons(tc.installed)
+			g.Expect(err).To(BeNil())
+			g.Expect(desired).To(Equal(tc.expectedAddons))
+			g.Expect(*scope.ControlPlane.Spec.Addons).To(Equal(specAddons))
+		})
+	}
+}
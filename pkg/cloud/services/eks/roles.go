@@ -240,6 +240,23 @@ func (s *NodegroupService) reconcileNodegroupIAMRole() error {
 		return errors.Wrapf(err, "error ensuring policies are attached: %v", policies)
 	}
 
+	if !s.scope.AllowAdditionalRoles() {
+		if len(s.scope.ManagedMachinePool.Spec.RoleInlinePolicies) > 0 {
+			return ErrCannotUseAdditionalRoles
+		}
+		return nil
+	}
+
+	inlinePolicies := make(map[string]string, len(s.scope.ManagedMachinePool.Spec.RoleInlinePolicies))
+	for _, policy := range s.scope.ManagedMachinePool.Spec.RoleInlinePolicies {
+		inlinePolicies[policy.Name] = policy.Document
+	}
+
+	_, err = s.EnsureInlinePolicies(role, inlinePolicies)
+	if err != nil {
+		return errors.Wrap(err, "error ensuring inline policies are set on node role")
+	}
+
 	return nil
 }
 
@@ -379,7 +396,7 @@ func (s *FargateService) deleteFargateIAMRole() (reterr error) {
 
 	s.scope.Debug("Deleting EKS fargate IAM Role")
 
-	_, err := s.GetIAMRole(roleName)
+	role, err := s.GetIAMRole(roleName)
 	if err != nil {
 		if isNotFound(err) {
 			s.Debug("EKS fargate IAM Role already deleted")
@@ -389,6 +406,11 @@ func (s *FargateService) deleteFargateIAMRole() (reterr error) {
 		return errors.Wrap(err, "getting EKS fargate iam role")
 	}
 
+	if s.IsUnmanaged(role, s.scope.ClusterName()) {
+		s.Debug("Skipping, EKS fargate iam role deletion as role is unmanaged")
+		return nil
+	}
+
 	err = s.DeleteRole(s.scope.RoleName())
 	if err != nil {
 		record.Eventf(s.scope.FargateProfile, "FailedIAMRoleDeletion", "Failed to delete fargate IAM role %q: %v", s.scope.RoleName(), err)
@@ -19,15 +19,20 @@ package eks
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/eks"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
 
 func (s *Service) reconcileSecurityGroups(cluster *eks.Cluster) error {
@@ -73,11 +78,195 @@ func (s *Service) reconcileSecurityGroups(cluster *eks.Cluster) error {
 		return fmt.Errorf("describing EKS cluster security group: %w", err)
 	}
 
+	clusterSecurityGroupID := aws.StringValue(cluster.ResourcesVpcConfig.ClusterSecurityGroupId)
 	s.scope.ControlPlane.Status.Network.SecurityGroups[ekscontrolplanev1.SecurityGroupCluster] = infrav1.SecurityGroup{
-		ID:   aws.StringValue(cluster.ResourcesVpcConfig.ClusterSecurityGroupId),
+		ID:   clusterSecurityGroupID,
 		Name: *output.SecurityGroups[0].GroupName,
 		Tags: converters.TagsToMap(output.SecurityGroups[0].Tags),
 	}
+	s.scope.ControlPlane.Status.ClusterSecurityGroupID = clusterSecurityGroupID
+
+	return s.reconcileClusterSecurityGroupIngressRules(clusterSecurityGroupID)
+}
+
+// clusterSecurityGroupRule is a single ingress permission of the cluster security group,
+// with exactly one source.
+type clusterSecurityGroupRule struct {
+	protocol    string
+	fromPort    int64
+	toPort      int64
+	cidr        string
+	ipv6Cidr    string
+	groupID     string
+	description string
+}
+
+// reconcileClusterSecurityGroupIngressRules ensures the ingress rules of the EKS-created cluster security group
+// managed by this controller match the spec. Rules are tagged on creation so that the rules added by EKS, or
+// outside of this controller, are never revoked.
+func (s *Service) reconcileClusterSecurityGroupIngressRules(groupID string) error {
+	desired, err := s.desiredClusterSecurityGroupRules()
+	if err != nil {
+		return err
+	}
+
+	current := map[clusterSecurityGroupRule]string{}
+	input := &ec2.DescribeSecurityGroupRulesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("group-id"), Values: aws.StringSlice([]string{groupID})},
+			filter.EC2.ClusterOwned(s.scope.Name()),
+		},
+	}
+	if err := s.EC2Client.DescribeSecurityGroupRulesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeSecurityGroupRulesOutput, last bool) bool {
+		for _, rule := range out.SecurityGroupRules {
+			if aws.BoolValue(rule.IsEgress) {
+				continue
+			}
+			key := clusterSecurityGroupRule{
+				protocol:    aws.StringValue(rule.IpProtocol),
+				fromPort:    aws.Int64Value(rule.FromPort),
+				toPort:      aws.Int64Value(rule.ToPort),
+				cidr:        aws.StringValue(rule.CidrIpv4),
+				ipv6Cidr:    aws.StringValue(rule.CidrIpv6),
+				description: aws.StringValue(rule.Description),
+			}
+			if rule.ReferencedGroupInfo != nil {
+				key.groupID = aws.StringValue(rule.ReferencedGroupInfo.GroupId)
+			}
+			current[key] = aws.StringValue(rule.SecurityGroupRuleId)
+		}
+		return true
+	}); err != nil {
+		return fmt.Errorf("describing rules of EKS cluster security group %q: %w", groupID, err)
+	}
+
+	var toRevoke []string
+	for key, ruleID := range current {
+		if !desired.Has(key) {
+			toRevoke = append(toRevoke, ruleID)
+		}
+	}
+	if len(toRevoke) > 0 {
+		sort.Strings(toRevoke)
+		if _, err := s.EC2Client.RevokeSecurityGroupIngressWithContext(context.TODO(), &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:              aws.String(groupID),
+			SecurityGroupRuleIds: aws.StringSlice(toRevoke),
+		}); err != nil {
+			record.Warnf(s.scope.ControlPlane, "FailedRevokeSecurityGroupIngressRules", "Failed to revoke ingress rules of EKS cluster security group %q: %v", groupID, err)
+			return fmt.Errorf("revoking ingress rules of EKS cluster security group %q: %w", groupID, err)
+		}
+		record.Eventf(s.scope.ControlPlane, "SuccessfulRevokeSecurityGroupIngressRules", "Revoked %d ingress rules of EKS cluster security group %q", len(toRevoke), groupID)
+	}
+
+	var permissions []*ec2.IpPermission
+	for _, key := range desired.UnsortedList() {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		permissions = append(permissions, key.toIPPermission())
+	}
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	if _, err := s.EC2Client.AuthorizeSecurityGroupIngressWithContext(context.TODO(), &ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:       aws.String(groupID),
+		IpPermissions: permissions,
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeSecurityGroupRule, infrav1.BuildParams{
+				ClusterName: s.scope.Name(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Additional:  s.scope.AdditionalTags(),
+			}),
+		},
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedAuthorizeSecurityGroupIngressRules", "Failed to authorize ingress rules of EKS cluster security group %q: %v", groupID, err)
+		return fmt.Errorf("authorizing ingress rules of EKS cluster security group %q: %w", groupID, err)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulAuthorizeSecurityGroupIngressRules", "Authorized %d ingress rules of EKS cluster security group %q", len(permissions), groupID)
 
 	return nil
 }
+
+// desiredClusterSecurityGroupRules expands the ingress rules in the spec into single source rules.
+func (s *Service) desiredClusterSecurityGroupRules() (sets.Set[clusterSecurityGroupRule], error) {
+	desired := sets.New[clusterSecurityGroupRule]()
+
+	for _, rule := range s.scope.ControlPlane.Spec.ClusterSecurityGroupIngressRules {
+		base := clusterSecurityGroupRule{
+			protocol:    string(rule.Protocol),
+			fromPort:    rule.FromPort,
+			toPort:      rule.ToPort,
+			description: rule.Description,
+		}
+		switch rule.Protocol {
+		case infrav1.SecurityGroupProtocolAll:
+			// EKS reports the port range of rules allowing all traffic as -1.
+			base.fromPort, base.toPort = -1, -1
+		case infrav1.SecurityGroupProtocolICMPv6:
+			base.protocol = "icmpv6"
+		}
+
+		cidrBlocks := rule.CidrBlocks
+		if rule.NatGatewaysIPsSource {
+			natGatewaysIPs := s.scope.GetNatGatewaysIPs()
+			if len(natGatewaysIPs) == 0 {
+				return nil, ErrNatGatewayIPsUnavailable
+			}
+			for _, ip := range natGatewaysIPs {
+				cidrBlocks = append(cidrBlocks, fmt.Sprintf("%s/32", ip))
+			}
+		}
+		for _, cidr := range cidrBlocks {
+			r := base
+			r.cidr = cidr
+			desired.Insert(r)
+		}
+		for _, cidr := range rule.IPv6CidrBlocks {
+			r := base
+			r.ipv6Cidr = cidr
+			desired.Insert(r)
+		}
+
+		groupIDs := sets.New(rule.SourceSecurityGroupIDs...)
+		for _, role := range rule.SourceSecurityGroupRoles {
+			sg, ok := s.scope.Network().SecurityGroups[role]
+			if !ok || sg.ID == "" {
+				return nil, fmt.Errorf("security group with role %q not found for EKS cluster security group ingress rule", role)
+			}
+			groupIDs.Insert(sg.ID)
+		}
+		for _, groupID := range sets.List(groupIDs) {
+			r := base
+			r.groupID = groupID
+			desired.Insert(r)
+		}
+	}
+
+	return desired, nil
+}
+
+func (r clusterSecurityGroupRule) toIPPermission() *ec2.IpPermission {
+	permission := &ec2.IpPermission{
+		IpProtocol: aws.String(r.protocol),
+	}
+	if r.protocol != string(infrav1.SecurityGroupProtocolAll) {
+		permission.FromPort = aws.Int64(r.fromPort)
+		permission.ToPort = aws.Int64(r.toPort)
+	}
+
+	var description *string
+	if r.description != "" {
+		description = aws.String(r.description)
+	}
+	switch {
+	case r.cidr != "":
+		permission.IpRanges = []*ec2.IpRange{{CidrIp: aws.String(r.cidr), Description: description}}
+	case r.ipv6Cidr != "":
+		permission.Ipv6Ranges = []*ec2.Ipv6Range{{CidrIpv6: aws.String(r.ipv6Cidr), Description: description}}
+	case r.groupID != "":
+		permission.UserIdGroupPairs = []*ec2.UserIdGroupPair{{GroupId: aws.String(r.groupID), Description: description}}
+	}
+
+	return permission
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileClusterSecurityGroupIngressRules(t *testing.T) {
+	const (
+		clusterName = "capi-name"
+		groupID     = "sg-cluster"
+	)
+
+	describeRules := func(m *mocks.MockEC2APIMockRecorder, rules ...*ec2.SecurityGroupRule) {
+		m.DescribeSecurityGroupRulesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, input *ec2.DescribeSecurityGroupRulesInput, fn func(*ec2.DescribeSecurityGroupRulesOutput, bool) bool, _ ...interface{}) error {
+				fn(&ec2.DescribeSecurityGroupRulesOutput{SecurityGroupRules: rules}, true)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name        string
+		rules       []infrav1.IngressRule
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "authorizes missing rules",
+			rules: []infrav1.IngressRule{
+				{
+					Description: "management cluster",
+					Protocol:    infrav1.SecurityGroupProtocolTCP,
+					FromPort:    443,
+					ToPort:      443,
+					CidrBlocks:  []string{"10.0.0.0/16"},
+				},
+				{
+					Description:              "bastion",
+					Protocol:                 infrav1.SecurityGroupProtocolAll,
+					SourceSecurityGroupRoles: []infrav1.SecurityGroupRole{infrav1.SecurityGroupBastion},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeRules(m)
+				m.AuthorizeSecurityGroupIngressWithContext(context.TODO(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, input *ec2.AuthorizeSecurityGroupIngressInput, _ ...interface{}) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+						g := NewWithT(t)
+						g.Expect(aws.StringValue(input.GroupId)).To(Equal(groupID))
+						g.Expect(input.IpPermissions).To(ConsistOf(
+							&ec2.IpPermission{
+								IpProtocol: aws.String("tcp"),
+								FromPort:   aws.Int64(443),
+								ToPort:     aws.Int64(443),
+								IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16"), Description: aws.String("management cluster")}},
+							},
+							&ec2.IpPermission{
+								IpProtocol:       aws.String("-1"),
+								UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-bastion"), Description: aws.String("bastion")}},
+							},
+						))
+						g.Expect(input.TagSpecifications).To(HaveLen(1))
+						g.Expect(aws.StringValue(input.TagSpecifications[0].ResourceType)).To(Equal(ec2.ResourceTypeSecurityGroupRule))
+						return &ec2.AuthorizeSecurityGroupIngressOutput{}, nil
+					})
+			},
+		},
+		{
+			name: "revokes managed rules no longer in the spec",
+			rules: []infrav1.IngressRule{
+				{
+					Description: "management cluster",
+					Protocol:    infrav1.SecurityGroupProtocolTCP,
+					FromPort:    443,
+					ToPort:      443,
+					CidrBlocks:  []string{"10.0.0.0/16"},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeRules(m,
+					&ec2.SecurityGroupRule{
+						SecurityGroupRuleId: aws.String("sgr-1"),
+						IpProtocol:          aws.String("tcp"),
+						FromPort:            aws.Int64(443),
+						ToPort:              aws.Int64(443),
+						CidrIpv4:            aws.String("10.0.0.0/16"),
+						Description:         aws.String("management cluster"),
+					},
+					&ec2.SecurityGroupRule{
+						SecurityGroupRuleId: aws.String("sgr-2"),
+						IpProtocol:          aws.String("tcp"),
+						FromPort:            aws.Int64(22),
+						ToPort:              aws.Int64(22),
+						CidrIpv4:            aws.String("10.0.0.0/16"),
+					},
+					&ec2.SecurityGroupRule{
+						SecurityGroupRuleId: aws.String("sgr-3"),
+						IsEgress:            aws.Bool(true),
+						IpProtocol:          aws.String("-1"),
+						CidrIpv4:            aws.String("0.0.0.0/0"),
+					},
+				)
+				m.RevokeSecurityGroupIngressWithContext(context.TODO(), gomock.Eq(&ec2.RevokeSecurityGroupIngressInput{
+					GroupId:              aws.String(groupID),
+					SecurityGroupRuleIds: aws.StringSlice([]string{"sgr-2"}),
+				})).Return(&ec2.RevokeSecurityGroupIngressOutput{}, nil)
+			},
+		},
+		{
+			name: "fails when the source security group role is unknown",
+			rules: []infrav1.IngressRule{
+				{
+					Protocol:                 infrav1.SecurityGroupProtocolTCP,
+					FromPort:                 443,
+					ToPort:                   443,
+					SourceSecurityGroupRoles: []infrav1.SecurityGroupRole{infrav1.SecurityGroupNode},
+				},
+			},
+			expect:      func(m *mocks.MockEC2APIMockRecorder) {},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockControl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						ClusterSecurityGroupIngressRules: tc.rules,
+					},
+					Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+						Network: infrav1.NetworkStatus{
+							SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+								infrav1.SecurityGroupBastion: {ID: "sg-bastion"},
+							},
+						},
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.reconcileClusterSecurityGroupIngressRules(groupID)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// defaultClassicELBAccessLogsEmitInterval is the interval, in minutes, at which classic load balancers
+// publish their access logs when none is set.
+const defaultClassicELBAccessLogsEmitInterval = 60
+
+// accessLogsBucket returns the bucket the access logs are delivered to, defaulting to the cluster bucket.
+func (s *Service) accessLogsBucket(accessLogs *infrav1.LoadBalancerAccessLogs) string {
+	if accessLogs.Bucket != "" {
+		return accessLogs.Bucket
+	}
+	if bucket := s.scope.Bucket(); bucket != nil {
+		return bucket.Name
+	}
+	return ""
+}
+
+// classicELBAccessLogs returns the desired access logs of the classic load balancer, or nil when
+// they are disabled.
+func (s *Service) classicELBAccessLogs(lbSpec *infrav1.AWSLoadBalancerSpec) *infrav1.LoadBalancerAccessLogs {
+	if lbSpec == nil || lbSpec.AccessLogs == nil {
+		return nil
+	}
+
+	return &infrav1.LoadBalancerAccessLogs{
+		Bucket:       s.accessLogsBucket(lbSpec.AccessLogs),
+		Prefix:       lbSpec.AccessLogs.Prefix,
+		EmitInterval: ptr.To(ptr.Deref(lbSpec.AccessLogs.EmitInterval, defaultClassicELBAccessLogsEmitInterval)),
+	}
+}
+
+// setAccessLogsAttributes sets the access logs attributes of a v2 load balancer. Access logs that are
+// no longer configured are disabled if they are still enabled on the existing load balancer.
+func (s *Service) setAccessLogsAttributes(attributes map[string]*string, lbSpec *infrav1.AWSLoadBalancerSpec, existing map[string]*string) {
+	if lbSpec.AccessLogs == nil {
+		if aws.StringValue(existing[infrav1.LoadBalancerAttributeAccessLogsEnabled]) == strconv.FormatBool(true) {
+			attributes[infrav1.LoadBalancerAttributeAccessLogsEnabled] = aws.String(strconv.FormatBool(false))
+		}
+		return
+	}
+
+	attributes[infrav1.LoadBalancerAttributeAccessLogsEnabled] = aws.String(strconv.FormatBool(true))
+	attributes[infrav1.LoadBalancerAttributeAccessLogsBucket] = aws.String(s.accessLogsBucket(lbSpec.AccessLogs))
+	attributes[infrav1.LoadBalancerAttributeAccessLogsPrefix] = aws.String(lbSpec.AccessLogs.Prefix)
+}
+
+func toSDKClassicELBAccessLog(accessLogs *infrav1.LoadBalancerAccessLogs) *elb.AccessLog {
+	if accessLogs == nil {
+		return &elb.AccessLog{Enabled: aws.Bool(false)}
+	}
+
+	return &elb.AccessLog{
+		Enabled:        aws.Bool(true),
+		S3BucketName:   aws.String(accessLogs.Bucket),
+		S3BucketPrefix: aws.String(accessLogs.Prefix),
+		EmitInterval:   accessLogs.EmitInterval,
+	}
+}
+
+func fromSDKClassicELBAccessLog(accessLog *elb.AccessLog) *infrav1.LoadBalancerAccessLogs {
+	if accessLog == nil || !aws.BoolValue(accessLog.Enabled) {
+		return nil
+	}
+
+	return &infrav1.LoadBalancerAccessLogs{
+		Bucket:       aws.StringValue(accessLog.S3BucketName),
+		Prefix:       aws.StringValue(accessLog.S3BucketPrefix),
+		EmitInterval: accessLog.EmitInterval,
+	}
+}
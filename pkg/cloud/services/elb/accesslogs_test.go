@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestSetAccessLogsAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		accessLogs *infrav1.LoadBalancerAccessLogs
+		existing   map[string]*string
+		expect     map[string]*string
+	}{
+		{
+			name:       "enables access logs to the cluster bucket by default",
+			accessLogs: &infrav1.LoadBalancerAccessLogs{Prefix: "apiserver"},
+			expect: map[string]*string{
+				infrav1.LoadBalancerAttributeAccessLogsEnabled: aws.String("true"),
+				infrav1.LoadBalancerAttributeAccessLogsBucket:  aws.String("cluster-bucket"),
+				infrav1.LoadBalancerAttributeAccessLogsPrefix:  aws.String("apiserver"),
+			},
+		},
+		{
+			name:       "enables access logs to another bucket",
+			accessLogs: &infrav1.LoadBalancerAccessLogs{Bucket: "logs"},
+			expect: map[string]*string{
+				infrav1.LoadBalancerAttributeAccessLogsEnabled: aws.String("true"),
+				infrav1.LoadBalancerAttributeAccessLogsBucket:  aws.String("logs"),
+				infrav1.LoadBalancerAttributeAccessLogsPrefix:  aws.String(""),
+			},
+		},
+		{
+			name: "disables access logs no longer configured",
+			existing: map[string]*string{
+				infrav1.LoadBalancerAttributeAccessLogsEnabled: aws.String("true"),
+			},
+			expect: map[string]*string{
+				infrav1.LoadBalancerAttributeAccessLogsEnabled: aws.String("false"),
+			},
+		},
+		{
+			name: "leaves disabled access logs untouched",
+			existing: map[string]*string{
+				infrav1.LoadBalancerAttributeAccessLogsEnabled: aws.String("false"),
+			},
+			expect: map[string]*string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			lbSpec := &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeALB,
+				AccessLogs:       tc.accessLogs,
+			}
+			s := &Service{scope: newAccessLogsClusterScope(t, lbSpec)}
+
+			attributes := map[string]*string{}
+			s.setAccessLogsAttributes(attributes, lbSpec, tc.existing)
+			g.Expect(attributes).To(Equal(tc.expect))
+		})
+	}
+}
+
+func TestClassicELBAccessLogs(t *testing.T) {
+	g := NewWithT(t)
+
+	lbSpec := &infrav1.AWSLoadBalancerSpec{
+		LoadBalancerType: infrav1.LoadBalancerTypeClassic,
+		AccessLogs:       &infrav1.LoadBalancerAccessLogs{Prefix: "apiserver"},
+	}
+	s := &Service{scope: newAccessLogsClusterScope(t, lbSpec)}
+
+	accessLogs := s.classicELBAccessLogs(lbSpec)
+	g.Expect(accessLogs).To(Equal(&infrav1.LoadBalancerAccessLogs{
+		Bucket:       "cluster-bucket",
+		Prefix:       "apiserver",
+		EmitInterval: ptr.To[int64](60),
+	}))
+
+	// The access logs read back from the load balancer match the desired ones, so that they are not reconfigured.
+	g.Expect(fromSDKClassicELBAccessLog(toSDKClassicELBAccessLog(accessLogs))).To(Equal(accessLogs))
+	g.Expect(fromSDKClassicELBAccessLog(toSDKClassicELBAccessLog(nil))).To(BeNil())
+	g.Expect(s.classicELBAccessLogs(&infrav1.AWSLoadBalancerSpec{})).To(BeNil())
+}
+
+func newAccessLogsClusterScope(t *testing.T, lbSpec *infrav1.AWSLoadBalancerSpec) *scope.ClusterScope {
+	t.Helper()
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: infrav1.AWSClusterSpec{
+				ControlPlaneLoadBalancer: lbSpec,
+				S3Bucket:                 &infrav1.S3Bucket{Name: "cluster-bucket"},
+			},
+		},
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return clusterScope
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// reconcileEndpointService ensures the VPC endpoint service exposing the load balancer matches
+// the spec, and records it in the load balancer status. The endpoint service is deleted when it
+// is no longer requested.
+func (s *Service) reconcileEndpointService(lb *infrav1.LoadBalancer, lbSpec *infrav1.AWSLoadBalancerSpec) error {
+	config, err := s.describeEndpointService(lb.ARN)
+	if err != nil {
+		return err
+	}
+
+	if lbSpec.EndpointService == nil {
+		if config != nil {
+			if err := s.deleteEndpointService(config); err != nil {
+				return err
+			}
+		}
+		lb.EndpointService = nil
+		return nil
+	}
+
+	if config == nil {
+		if config, err = s.createEndpointService(lb, lbSpec.EndpointService); err != nil {
+			return err
+		}
+	} else if aws.BoolValue(config.AcceptanceRequired) != lbSpec.EndpointService.AcceptanceRequired {
+		if _, err := s.EC2Client.ModifyVpcEndpointServiceConfiguration(&ec2.ModifyVpcEndpointServiceConfigurationInput{
+			ServiceId:          config.ServiceId,
+			AcceptanceRequired: aws.Bool(lbSpec.EndpointService.AcceptanceRequired),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to modify endpoint service %q", aws.StringValue(config.ServiceId))
+		}
+	}
+
+	if err := s.reconcileEndpointServicePermissions(aws.StringValue(config.ServiceId), lbSpec.EndpointService.AllowedPrincipals); err != nil {
+		return err
+	}
+
+	lb.EndpointService = &infrav1.EndpointServiceStatus{
+		ID:          aws.StringValue(config.ServiceId),
+		ServiceName: aws.StringValue(config.ServiceName),
+	}
+
+	return nil
+}
+
+// deleteLoadBalancerEndpointService deletes the VPC endpoint service exposing the load balancer, if any.
+// Load balancers cannot be deleted while they are associated with an endpoint service.
+func (s *Service) deleteLoadBalancerEndpointService(lbARN string) error {
+	config, err := s.describeEndpointService(lbARN)
+	if err != nil || config == nil {
+		return err
+	}
+
+	return s.deleteEndpointService(config)
+}
+
+func (s *Service) describeEndpointService(lbARN string) (*ec2.ServiceConfiguration, error) {
+	input := &ec2.DescribeVpcEndpointServiceConfigurationsInput{
+		Filters: []*ec2.Filter{filter.EC2.Cluster(s.scope.Name())},
+	}
+
+	var found *ec2.ServiceConfiguration
+	if err := s.EC2Client.DescribeVpcEndpointServiceConfigurationsPages(input, func(out *ec2.DescribeVpcEndpointServiceConfigurationsOutput, last bool) bool {
+		for _, config := range out.ServiceConfigurations {
+			if aws.StringValue(config.ServiceState) == ec2.ServiceStateDeleted || aws.StringValue(config.ServiceState) == ec2.ServiceStateDeleting {
+				continue
+			}
+			for _, arn := range config.NetworkLoadBalancerArns {
+				if aws.StringValue(arn) == lbARN {
+					found = config
+					return false
+				}
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to describe endpoint services for load balancer %q", lbARN)
+	}
+
+	return found, nil
+}
+
+func (s *Service) createEndpointService(lb *infrav1.LoadBalancer, spec *infrav1.EndpointServiceSpec) (*ec2.ServiceConfiguration, error) {
+	out, err := s.EC2Client.CreateVpcEndpointServiceConfiguration(&ec2.CreateVpcEndpointServiceConfigurationInput{
+		NetworkLoadBalancerArns: aws.StringSlice([]string{lb.ARN}),
+		AcceptanceRequired:      aws.Bool(spec.AcceptanceRequired),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeVpcEndpointService, infrav1.BuildParams{
+				ClusterName: s.scope.Name(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        aws.String(fmt.Sprintf("%s-endpoint-service", lb.Name)),
+				Role:        aws.String(infrav1.APIServerRoleTagValue),
+				Additional:  s.scope.AdditionalTags(),
+			}),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateVPCEndpointService", "Failed to create endpoint service for load balancer %q: %v", lb.Name, err)
+		return nil, errors.Wrapf(err, "failed to create endpoint service for load balancer %q", lb.Name)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateVPCEndpointService", "Created endpoint service %q for load balancer %q", aws.StringValue(out.ServiceConfiguration.ServiceId), lb.Name)
+	s.scope.Info("Created endpoint service", "service-id", aws.StringValue(out.ServiceConfiguration.ServiceId), "load-balancer", lb.Name)
+
+	return out.ServiceConfiguration, nil
+}
+
+func (s *Service) reconcileEndpointServicePermissions(serviceID string, allowedPrincipals []string) error {
+	current := sets.New[string]()
+	input := &ec2.DescribeVpcEndpointServicePermissionsInput{
+		ServiceId: aws.String(serviceID),
+	}
+	for {
+		out, err := s.EC2Client.DescribeVpcEndpointServicePermissions(input)
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe permissions of endpoint service %q", serviceID)
+		}
+		for _, p := range out.AllowedPrincipals {
+			current.Insert(aws.StringValue(p.Principal))
+		}
+		if aws.StringValue(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	desired := sets.New[string](allowedPrincipals...)
+	toAdd := sets.List(desired.Difference(current))
+	toRemove := sets.List(current.Difference(desired))
+	if len(toAdd) == 0 && len(toRemove) == 0 {
+		return nil
+	}
+
+	modifyInput := &ec2.ModifyVpcEndpointServicePermissionsInput{
+		ServiceId: aws.String(serviceID),
+	}
+	if len(toAdd) > 0 {
+		modifyInput.AddAllowedPrincipals = aws.StringSlice(toAdd)
+	}
+	if len(toRemove) > 0 {
+		modifyInput.RemoveAllowedPrincipals = aws.StringSlice(toRemove)
+	}
+	if _, err := s.EC2Client.ModifyVpcEndpointServicePermissions(modifyInput); err != nil {
+		return errors.Wrapf(err, "failed to modify permissions of endpoint service %q", serviceID)
+	}
+
+	s.scope.Debug("Updated endpoint service permissions", "service-id", serviceID, "added", toAdd, "removed", toRemove)
+	return nil
+}
+
+func (s *Service) deleteEndpointService(config *ec2.ServiceConfiguration) error {
+	serviceID := aws.StringValue(config.ServiceId)
+
+	// Endpoint services with connected endpoints cannot be deleted, so reject them first.
+	var endpointIDs []*string
+	if err := s.EC2Client.DescribeVpcEndpointConnectionsPages(&ec2.DescribeVpcEndpointConnectionsInput{
+		Filters: []*ec2.Filter{{Name: aws.String("service-id"), Values: aws.StringSlice([]string{serviceID})}},
+	}, func(out *ec2.DescribeVpcEndpointConnectionsOutput, last bool) bool {
+		for _, conn := range out.VpcEndpointConnections {
+			switch aws.StringValue(conn.VpcEndpointState) {
+			case ec2.StateRejected, ec2.StateDeleted, ec2.StateDeleting, ec2.StateFailed:
+				continue
+			}
+			endpointIDs = append(endpointIDs, conn.VpcEndpointId)
+		}
+		return true
+	}); err != nil {
+		return errors.Wrapf(err, "failed to describe connections of endpoint service %q", serviceID)
+	}
+
+	if len(endpointIDs) > 0 {
+		if _, err := s.EC2Client.RejectVpcEndpointConnections(&ec2.RejectVpcEndpointConnectionsInput{
+			ServiceId:      aws.String(serviceID),
+			VpcEndpointIds: endpointIDs,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to reject connections of endpoint service %q", serviceID)
+		}
+	}
+
+	out, err := s.EC2Client.DeleteVpcEndpointServiceConfigurations(&ec2.DeleteVpcEndpointServiceConfigurationsInput{
+		ServiceIds: aws.StringSlice([]string{serviceID}),
+	})
+	if err == nil && len(out.Unsuccessful) > 0 && out.Unsuccessful[0].Error != nil {
+		err = errors.New(aws.StringValue(out.Unsuccessful[0].Error.Message))
+	}
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteVPCEndpointService", "Failed to delete endpoint service %q: %v", serviceID, err)
+		return errors.Wrapf(err, "failed to delete endpoint service %q", serviceID)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteVPCEndpointService", "Deleted endpoint service %q", serviceID)
+	s.scope.Info("Deleted endpoint service", "service-id", serviceID)
+	return nil
+}
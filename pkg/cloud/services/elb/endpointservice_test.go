@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileEndpointService(t *testing.T) {
+	const (
+		lbArn       = "arn::apiserver"
+		serviceID   = "vpce-svc-123"
+		serviceName = "com.amazonaws.vpce.us-east-1.vpce-svc-123"
+	)
+
+	existing := &ec2.ServiceConfiguration{
+		ServiceId:               aws.String(serviceID),
+		ServiceName:             aws.String(serviceName),
+		ServiceState:            aws.String(ec2.ServiceStateAvailable),
+		AcceptanceRequired:      aws.Bool(false),
+		NetworkLoadBalancerArns: aws.StringSlice([]string{lbArn}),
+	}
+	describeServices := func(m *mocks.MockEC2APIMockRecorder, configs ...*ec2.ServiceConfiguration) {
+		m.DescribeVpcEndpointServiceConfigurationsPages(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *ec2.DescribeVpcEndpointServiceConfigurationsInput, fn func(*ec2.DescribeVpcEndpointServiceConfigurationsOutput, bool) bool) error {
+				fn(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{ServiceConfigurations: configs}, true)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name         string
+		spec         *infrav1.EndpointServiceSpec
+		ec2Mocks     func(m *mocks.MockEC2APIMockRecorder)
+		expectStatus *infrav1.EndpointServiceStatus
+	}{
+		{
+			name: "creates the endpoint service and allows principals",
+			spec: &infrav1.EndpointServiceSpec{AllowedPrincipals: []string{"arn:aws:iam::123456789012:root"}},
+			ec2Mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				describeServices(m)
+				m.CreateVpcEndpointServiceConfiguration(gomock.Any()).
+					DoAndReturn(func(input *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error) {
+						if aws.StringValue(input.NetworkLoadBalancerArns[0]) != lbArn {
+							t.Fatalf("unexpected load balancer %q", aws.StringValue(input.NetworkLoadBalancerArns[0]))
+						}
+						return &ec2.CreateVpcEndpointServiceConfigurationOutput{ServiceConfiguration: existing}, nil
+					})
+				m.DescribeVpcEndpointServicePermissions(gomock.Any()).Return(&ec2.DescribeVpcEndpointServicePermissionsOutput{}, nil)
+				m.ModifyVpcEndpointServicePermissions(gomock.Eq(&ec2.ModifyVpcEndpointServicePermissionsInput{
+					ServiceId:            aws.String(serviceID),
+					AddAllowedPrincipals: aws.StringSlice([]string{"arn:aws:iam::123456789012:root"}),
+				})).Return(&ec2.ModifyVpcEndpointServicePermissionsOutput{}, nil)
+			},
+			expectStatus: &infrav1.EndpointServiceStatus{ID: serviceID, ServiceName: serviceName},
+		},
+		{
+			name: "updates acceptance and removes stale principals of an existing endpoint service",
+			spec: &infrav1.EndpointServiceSpec{AcceptanceRequired: true},
+			ec2Mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				describeServices(m, existing)
+				m.ModifyVpcEndpointServiceConfiguration(gomock.Eq(&ec2.ModifyVpcEndpointServiceConfigurationInput{
+					ServiceId:          aws.String(serviceID),
+					AcceptanceRequired: aws.Bool(true),
+				})).Return(&ec2.ModifyVpcEndpointServiceConfigurationOutput{}, nil)
+				m.DescribeVpcEndpointServicePermissions(gomock.Any()).Return(&ec2.DescribeVpcEndpointServicePermissionsOutput{
+					AllowedPrincipals: []*ec2.AllowedPrincipal{{Principal: aws.String("arn:aws:iam::210987654321:root")}},
+				}, nil)
+				m.ModifyVpcEndpointServicePermissions(gomock.Eq(&ec2.ModifyVpcEndpointServicePermissionsInput{
+					ServiceId:               aws.String(serviceID),
+					RemoveAllowedPrincipals: aws.StringSlice([]string{"arn:aws:iam::210987654321:root"}),
+				})).Return(&ec2.ModifyVpcEndpointServicePermissionsOutput{}, nil)
+			},
+			expectStatus: &infrav1.EndpointServiceStatus{ID: serviceID, ServiceName: serviceName},
+		},
+		{
+			name: "deletes the endpoint service when it is no longer requested",
+			ec2Mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				describeServices(m, existing)
+				m.DescribeVpcEndpointConnectionsPages(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *ec2.DescribeVpcEndpointConnectionsInput, fn func(*ec2.DescribeVpcEndpointConnectionsOutput, bool) bool) error {
+						fn(&ec2.DescribeVpcEndpointConnectionsOutput{
+							VpcEndpointConnections: []*ec2.VpcEndpointConnection{
+								{VpcEndpointId: aws.String("vpce-1"), VpcEndpointState: aws.String(ec2.StateAvailable)},
+								{VpcEndpointId: aws.String("vpce-2"), VpcEndpointState: aws.String(ec2.StateRejected)},
+							},
+						}, true)
+						return nil
+					})
+				m.RejectVpcEndpointConnections(gomock.Eq(&ec2.RejectVpcEndpointConnectionsInput{
+					ServiceId:      aws.String(serviceID),
+					VpcEndpointIds: aws.StringSlice([]string{"vpce-1"}),
+				})).Return(&ec2.RejectVpcEndpointConnectionsOutput{}, nil)
+				m.DeleteVpcEndpointServiceConfigurations(gomock.Eq(&ec2.DeleteVpcEndpointServiceConfigurationsInput{
+					ServiceIds: aws.StringSlice([]string{serviceID}),
+				})).Return(&ec2.DeleteVpcEndpointServiceConfigurationsOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			lbSpec := &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				EndpointService:  tc.spec,
+			}
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       infrav1.AWSClusterSpec{ControlPlaneLoadBalancer: lbSpec},
+			}
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"},
+				},
+				AWSCluster: awsCluster,
+				Client:     fake.NewClientBuilder().WithScheme(scheme).Build(),
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.ec2Mocks(ec2Mock.EXPECT())
+
+			s := &Service{
+				scope:     clusterScope,
+				EC2Client: ec2Mock,
+			}
+
+			lb := &infrav1.LoadBalancer{ARN: lbArn, Name: "bar-apiserver"}
+			g.Expect(s.reconcileEndpointService(lb, lbSpec)).To(Succeed())
+			g.Expect(lb.EndpointService).To(Equal(tc.expectStatus))
+		})
+	}
+}
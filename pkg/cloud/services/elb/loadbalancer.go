@@ -128,6 +128,10 @@ func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 			return errors.Wrapf(err, "failed to create target groups/listeners for load balancer %q", lb.Name)
 		}
 
+		s.setAccessLogsAttributes(desiredLB.ELBAttributes, lbSpec, lb.ELBAttributes)
+		if !lbSpec.DeletionProtection && aws.StringValue(lb.ELBAttributes[infrav1.LoadBalancerAttributeDeletionProtection]) == strconv.FormatBool(true) {
+			desiredLB.ELBAttributes[infrav1.LoadBalancerAttributeDeletionProtection] = aws.String(strconv.FormatBool(false))
+		}
 		if !cmp.Equal(desiredLB.ELBAttributes, lb.ELBAttributes) {
 			if err := s.configureLBAttributes(lb.ARN, desiredLB.ELBAttributes); err != nil {
 				return err
@@ -163,6 +167,12 @@ func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 				return errors.Wrapf(err, "failed to apply security groups to load balancer %q", lb.Name)
 			}
 		}
+
+		if lbSpec.EndpointService != nil || s.loadBalancerStatus(lbSpec).EndpointService != nil {
+			if err := s.reconcileEndpointService(lb, lbSpec); err != nil {
+				return errors.Wrapf(err, "failed to reconcile endpoint service for load balancer %q", lb.Name)
+			}
+		}
 	} else {
 		s.scope.Trace("Unmanaged control plane load balancer, skipping load balancer configuration", "api-server-elb", lb)
 	}
@@ -176,11 +186,36 @@ func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 	return nil
 }
 
+// loadBalancerIPAddressType returns the IP address type of the control plane load balancer, defaulting to dual-stack
+// when IPv6 is enabled in the VPC.
+func (s *Service) loadBalancerIPAddressType(lbSpec *infrav1.AWSLoadBalancerSpec) infrav1.LoadBalancerIPAddressType {
+	if lbSpec != nil && lbSpec.IPAddressType != nil {
+		return *lbSpec.IPAddressType
+	}
+	if s.scope.VPC().IsIPv6Enabled() {
+		return infrav1.LoadBalancerIPAddressTypeDualStack
+	}
+	return infrav1.LoadBalancerIPAddressTypeIPv4
+}
+
+// loadBalancerStatus returns the status of the given control plane load balancer.
+func (s *Service) loadBalancerStatus(lbSpec *infrav1.AWSLoadBalancerSpec) *infrav1.LoadBalancer {
+	// Scopes without control plane load balancers, such as the managed control plane scope, return nil.
+	if lbSpecs := s.scope.ControlPlaneLoadBalancers(); len(lbSpecs) > 1 && lbSpecs[1] != nil && lbSpec == lbSpecs[1] {
+		return &s.scope.Network().SecondaryAPIServerELB
+	}
+	return &s.scope.Network().APIServerELB
+}
+
 // getAPITargetGroupHealthCheck creates the health check for the Kube apiserver target group,
-// limiting the customization for the health check probe counters (skipping standarized/reserved
-// fields: Protocol, Port or Path). To customize the health check protocol, use HealthCheckProtocol instead.
+// applying the port, path, success codes and probe counters customized in HealthCheck. To customize the health
+// check protocol, use HealthCheckProtocol instead.
 func (s *Service) getAPITargetGroupHealthCheck(lbSpec *infrav1.AWSLoadBalancerSpec) *infrav1.TargetGroupHealthCheck {
 	apiHealthCheckProtocol := infrav1.ELBProtocolTCP.String()
+	if lbSpec != nil && lbSpec.LoadBalancerType == infrav1.LoadBalancerTypeALB {
+		// Application Load Balancers only support HTTP and HTTPS health checks.
+		apiHealthCheckProtocol = infrav1.ELBProtocolHTTPS.String()
+	}
 	if lbSpec != nil && lbSpec.HealthCheckProtocol != nil {
 		s.scope.Trace("Found API health check protocol override in the Load Balancer spec, applying it to the API Target Group", "api-server-elb", lbSpec.HealthCheckProtocol.String())
 		apiHealthCheckProtocol = lbSpec.HealthCheckProtocol.String()
@@ -200,6 +235,15 @@ func (s *Service) getAPITargetGroupHealthCheck(lbSpec *infrav1.AWSLoadBalancerSp
 
 	if lbSpec != nil && lbSpec.HealthCheck != nil {
 		s.scope.Trace("Found API health check override in the Load Balancer spec, applying it to the API Target Group", "api-server-elb", lbSpec.HealthCheck)
+		if lbSpec.HealthCheck.Port != nil {
+			apiHealthCheck.Port = aws.String(strconv.FormatInt(*lbSpec.HealthCheck.Port, 10))
+		}
+		if lbSpec.HealthCheck.Path != nil && apiHealthCheck.Path != nil {
+			apiHealthCheck.Path = lbSpec.HealthCheck.Path
+		}
+		if lbSpec.HealthCheck.SuccessCodes != nil && apiHealthCheck.Path != nil {
+			apiHealthCheck.SuccessCodes = lbSpec.HealthCheck.SuccessCodes
+		}
 		if lbSpec.HealthCheck.IntervalSeconds != nil {
 			apiHealthCheck.IntervalSeconds = lbSpec.HealthCheck.IntervalSeconds
 		}
@@ -220,8 +264,8 @@ func (s *Service) getAPITargetGroupHealthCheck(lbSpec *infrav1.AWSLoadBalancerSp
 // Additional listeners allows to set customized attributes for health check.
 func (s *Service) getAdditionalTargetGroupHealthCheck(ln infrav1.AdditionalListenerSpec) *infrav1.TargetGroupHealthCheck {
 	healthCheck := &infrav1.TargetGroupHealthCheck{
-		Port:                    aws.String(fmt.Sprintf("%d", ln.Port)),
-		Protocol:                aws.String(ln.Protocol.String()),
+		Port:                    aws.String(fmt.Sprintf("%d", ln.GetTargetPort())),
+		Protocol:                aws.String(additionalListenerHealthCheckProtocol(ln)),
 		Path:                    nil,
 		IntervalSeconds:         aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
 		TimeoutSeconds:          aws.Int64(infrav1.DefaultAPIServerHealthCheckTimeoutSec),
@@ -256,11 +300,20 @@ func (s *Service) getAdditionalTargetGroupHealthCheck(ln infrav1.AdditionalListe
 	return healthCheck
 }
 
+// additionalListenerHealthCheckProtocol returns the default health check protocol of the target group of
+// an additional listener. Target groups cannot be health checked over TLS, so TCP is used instead.
+func additionalListenerHealthCheckProtocol(ln infrav1.AdditionalListenerSpec) string {
+	if ln.Protocol == infrav1.ELBProtocolTLS {
+		return infrav1.ELBProtocolTCP.String()
+	}
+	return ln.Protocol.String()
+}
+
 func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBalancerSpec) (*infrav1.LoadBalancer, error) {
 	var securityGroupIDs []string
 	if lbSpec != nil {
 		securityGroupIDs = append(securityGroupIDs, lbSpec.AdditionalSecurityGroups...)
-		securityGroupIDs = append(securityGroupIDs, s.scope.SecurityGroups()[infrav1.SecurityGroupAPIServerLB].ID)
+		securityGroupIDs = append(securityGroupIDs, s.loadBalancerSecurityGroups(lbSpec)...)
 	}
 
 	// Since we're no longer relying on s.scope.ControlPlaneLoadBalancerScheme to do the defaulting for us, do it here.
@@ -271,18 +324,24 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 
 	// The default API health check is TCP, allowing customization to HTTP or HTTPS when HealthCheckProtocol is set.
 	apiHealthCheck := s.getAPITargetGroupHealthCheck(lbSpec)
+	// Application Load Balancers terminate TLS on the API server listener, and re-encrypt the traffic to the API server.
+	apiProtocol := infrav1.ELBProtocolTCP
+	if lbSpec != nil && lbSpec.LoadBalancerType == infrav1.LoadBalancerTypeALB {
+		apiProtocol = infrav1.ELBProtocolHTTPS
+	}
 	res := &infrav1.LoadBalancer{
 		Name:          elbName,
 		Scheme:        scheme,
+		IPAddressType: s.loadBalancerIPAddressType(lbSpec),
 		ELBAttributes: make(map[string]*string),
 		ELBListeners: []infrav1.Listener{
 			{
-				Protocol: infrav1.ELBProtocolTCP,
+				Protocol: apiProtocol,
 				Port:     infrav1.DefaultAPIServerPort,
 				TargetGroup: infrav1.TargetGroupSpec{
 					Name:        names.SimpleNameGenerator.GenerateName(apiServerTargetGroupPrefix),
 					Port:        infrav1.DefaultAPIServerPort,
-					Protocol:    infrav1.ELBProtocolTCP,
+					Protocol:    apiProtocol,
 					VpcID:       s.scope.VPC().ID,
 					HealthCheck: apiHealthCheck,
 				},
@@ -294,8 +353,8 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 	if lbSpec != nil {
 		for _, listener := range lbSpec.AdditionalListeners {
 			lnHealthCheck := &infrav1.TargetGroupHealthCheck{
-				Protocol: aws.String(string(listener.Protocol)),
-				Port:     aws.String(strconv.FormatInt(listener.Port, 10)),
+				Protocol: aws.String(additionalListenerHealthCheckProtocol(listener)),
+				Port:     aws.String(strconv.FormatInt(listener.GetTargetPort(), 10)),
 			}
 			if listener.HealthCheck != nil {
 				s.scope.Trace("Found health check override in the additional listener spec, applying it to the Target Group", listener.HealthCheck)
@@ -306,7 +365,7 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 				Port:     listener.Port,
 				TargetGroup: infrav1.TargetGroupSpec{
 					Name:        names.SimpleNameGenerator.GenerateName(additionalTargetGroupPrefix),
-					Port:        listener.Port,
+					Port:        listener.GetTargetPort(),
 					Protocol:    listener.Protocol,
 					VpcID:       s.scope.VPC().ID,
 					HealthCheck: lnHealthCheck,
@@ -317,9 +376,20 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 
 	if lbSpec != nil && lbSpec.LoadBalancerType != infrav1.LoadBalancerTypeNLB {
 		res.ELBAttributes[infrav1.LoadBalancerAttributeIdleTimeTimeoutSeconds] = aws.String(infrav1.LoadBalancerAttributeIdleTimeDefaultTimeoutSecondsInSeconds)
+		if lbSpec.IdleTimeoutSeconds != nil {
+			res.ELBAttributes[infrav1.LoadBalancerAttributeIdleTimeTimeoutSeconds] = aws.String(strconv.FormatInt(*lbSpec.IdleTimeoutSeconds, 10))
+		}
+		if lbSpec.ClientKeepAliveSeconds != nil {
+			res.ELBAttributes[infrav1.LoadBalancerAttributeClientKeepAliveSeconds] = aws.String(strconv.FormatInt(*lbSpec.ClientKeepAliveSeconds, 10))
+		}
 	}
 
-	if lbSpec != nil {
+	if lbSpec != nil && lbSpec.DeletionProtection {
+		res.ELBAttributes[infrav1.LoadBalancerAttributeDeletionProtection] = aws.String(strconv.FormatBool(true))
+	}
+
+	// Application Load Balancers always balance across zones and do not support the attribute.
+	if lbSpec != nil && lbSpec.LoadBalancerType != infrav1.LoadBalancerTypeALB {
 		isCrossZoneLB := lbSpec.CrossZoneLoadBalancing
 		res.ELBAttributes[infrav1.LoadBalancerAttributeEnableLoadBalancingCrossZone] = aws.String(strconv.FormatBool(isCrossZoneLB))
 	}
@@ -349,7 +419,7 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 		}
 	} else {
 		// The load balancer APIs require us to only attach one subnet for each AZ.
-		subnets := s.scope.Subnets().FilterPrivate()
+		subnets := s.scope.Subnets().FilterPrivate().FilterNonCni()
 
 		if scheme == infrav1.ELBSchemeInternetFacing {
 			subnets = s.scope.Subnets().FilterPublic()
@@ -391,8 +461,8 @@ func (s *Service) createLB(spec *infrav1.LoadBalancer, lbSpec *infrav1.AWSLoadBa
 		Type:           t,
 	}
 
-	if s.scope.VPC().IsIPv6Enabled() {
-		input.IpAddressType = aws.String("dualstack")
+	if spec.IPAddressType == infrav1.LoadBalancerIPAddressTypeDualStack {
+		input.IpAddressType = aws.String(string(infrav1.LoadBalancerIPAddressTypeDualStack))
 	}
 
 	// Allocate custom addresses (Elastic IP) to internet-facing Load Balancers, when defined.
@@ -428,6 +498,7 @@ func (s *Service) createLB(spec *infrav1.LoadBalancer, lbSpec *infrav1.AWSLoadBa
 	s.scope.Debug("applying load balancer DNS to result", "dns", *out.LoadBalancers[0].DNSName)
 	res.DNSName = *out.LoadBalancers[0].DNSName
 	res.ARN = *out.LoadBalancers[0].LoadBalancerArn
+	res.CanonicalHostedZoneID = aws.StringValue(out.LoadBalancers[0].CanonicalHostedZoneId)
 	return res, nil
 }
 
@@ -526,6 +597,13 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 			}
 		}
 
+		if apiELB.HealthCheck != nil && !cmp.Equal(spec.HealthCheck, apiELB.HealthCheck) {
+			if err := s.configureHealthCheck(apiELB.Name, spec.HealthCheck); err != nil {
+				return errors.Wrapf(err, "failed to configure health check for apiserver load balancer %q", apiELB.Name)
+			}
+			apiELB.HealthCheck = spec.HealthCheck
+		}
+
 		if err := s.reconcileELBTags(apiELB, spec.Tags); err != nil {
 			return errors.Wrapf(err, "failed to reconcile tags for apiserver load balancer %q", apiELB.Name)
 		}
@@ -703,6 +781,25 @@ func (s *Service) deleteExistingNLB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 		s.scope.Debug("Found unmanaged load balancer for apiserver, skipping deletion", "api-server-elb-name", lb.Name)
 		return nil
 	}
+	if lbSpec.EndpointService != nil || s.loadBalancerStatus(lbSpec).EndpointService != nil {
+		if err := s.deleteLoadBalancerEndpointService(lb.ARN); err != nil {
+			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+			return err
+		}
+		s.loadBalancerStatus(lbSpec).EndpointService = nil
+	}
+
+	// Load balancers cannot be deleted while deletion protection is enabled.
+	if aws.StringValue(lb.ELBAttributes[infrav1.LoadBalancerAttributeDeletionProtection]) == strconv.FormatBool(true) {
+		s.scope.Debug("disabling deletion protection of load balancer", "name", name)
+		if err := s.configureLBAttributes(lb.ARN, map[string]*string{
+			infrav1.LoadBalancerAttributeDeletionProtection: aws.String(strconv.FormatBool(false)),
+		}); err != nil {
+			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+			return err
+		}
+	}
+
 	s.scope.Debug("deleting load balancer", "name", name)
 	if err := s.deleteLB(lb.ARN); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
@@ -1042,7 +1139,7 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 	if controlPlaneLoadBalancer != nil && len(controlPlaneLoadBalancer.AdditionalSecurityGroups) != 0 {
 		securityGroupIDs = append(securityGroupIDs, controlPlaneLoadBalancer.AdditionalSecurityGroups...)
 	}
-	securityGroupIDs = append(securityGroupIDs, s.scope.SecurityGroups()[infrav1.SecurityGroupAPIServerLB].ID)
+	securityGroupIDs = append(securityGroupIDs, s.loadBalancerSecurityGroups(controlPlaneLoadBalancer)...)
 
 	scheme := infrav1.ELBSchemeInternetFacing
 	if controlPlaneLoadBalancer != nil && controlPlaneLoadBalancer.Scheme != nil {
@@ -1060,21 +1157,19 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 				InstancePort:     infrav1.DefaultAPIServerPort,
 			},
 		},
-		HealthCheck: &infrav1.ClassicELBHealthCheck{
-			Target:             s.getHealthCheckTarget(),
-			Interval:           infrav1.DefaultAPIServerHealthCheckIntervalSec * time.Second,
-			Timeout:            infrav1.DefaultAPIServerHealthCheckTimeoutSec * time.Second,
-			HealthyThreshold:   infrav1.DefaultAPIServerHealthThresholdCount,
-			UnhealthyThreshold: infrav1.DefaultAPIServerUnhealthThresholdCount,
-		},
+		HealthCheck:      s.getClassicELBHealthCheck(),
 		SecurityGroupIDs: securityGroupIDs,
 		ClassicElbAttributes: infrav1.ClassicELBAttributes{
-			IdleTimeout: 10 * time.Minute,
+			IdleTimeout: infrav1.DefaultAPIServerClassicELBIdleTimeoutSec * time.Second,
 		},
 	}
 
 	if s.scope.ControlPlaneLoadBalancer() != nil {
 		res.ClassicElbAttributes.CrossZoneLoadBalancing = s.scope.ControlPlaneLoadBalancer().CrossZoneLoadBalancing
+		if s.scope.ControlPlaneLoadBalancer().IdleTimeoutSeconds != nil {
+			res.ClassicElbAttributes.IdleTimeout = time.Duration(*s.scope.ControlPlaneLoadBalancer().IdleTimeoutSeconds) * time.Second
+		}
+		res.ClassicElbAttributes.AccessLogs = s.classicELBAccessLogs(s.scope.ControlPlaneLoadBalancer())
 	}
 
 	res.Tags = infrav1.Build(infrav1.BuildParams{
@@ -1102,7 +1197,7 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 		}
 	} else {
 		// The load balancer APIs require us to only attach one subnet for each AZ.
-		subnets := s.scope.Subnets().FilterPrivate()
+		subnets := s.scope.Subnets().FilterPrivate().FilterNonCni()
 
 		if scheme == infrav1.ELBSchemeInternetFacing {
 			subnets = s.scope.Subnets().FilterPublic()
@@ -1149,21 +1244,7 @@ func (s *Service) createClassicELB(spec *infrav1.LoadBalancer) (*infrav1.LoadBal
 	}
 
 	if spec.HealthCheck != nil {
-		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			if _, err := s.ELBClient.ConfigureHealthCheck(&elb.ConfigureHealthCheckInput{
-				LoadBalancerName: aws.String(spec.Name),
-				HealthCheck: &elb.HealthCheck{
-					Target:             aws.String(spec.HealthCheck.Target),
-					Interval:           aws.Int64(int64(spec.HealthCheck.Interval.Seconds())),
-					Timeout:            aws.Int64(int64(spec.HealthCheck.Timeout.Seconds())),
-					HealthyThreshold:   aws.Int64(spec.HealthCheck.HealthyThreshold),
-					UnhealthyThreshold: aws.Int64(spec.HealthCheck.UnhealthyThreshold),
-				},
-			}); err != nil {
-				return false, err
-			}
-			return true, nil
-		}, awserrors.LoadBalancerNotFound); err != nil {
+		if err := s.configureHealthCheck(spec.Name, spec.HealthCheck); err != nil {
 			return nil, errors.Wrapf(err, "failed to configure health check for classic load balancer: %v", spec)
 		}
 	}
@@ -1175,6 +1256,24 @@ func (s *Service) createClassicELB(spec *infrav1.LoadBalancer) (*infrav1.LoadBal
 	return res, nil
 }
 
+func (s *Service) configureHealthCheck(name string, healthCheck *infrav1.ClassicELBHealthCheck) error {
+	return wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		if _, err := s.ELBClient.ConfigureHealthCheck(&elb.ConfigureHealthCheckInput{
+			LoadBalancerName: aws.String(name),
+			HealthCheck: &elb.HealthCheck{
+				Target:             aws.String(healthCheck.Target),
+				Interval:           aws.Int64(int64(healthCheck.Interval.Seconds())),
+				Timeout:            aws.Int64(int64(healthCheck.Timeout.Seconds())),
+				HealthyThreshold:   aws.Int64(healthCheck.HealthyThreshold),
+				UnhealthyThreshold: aws.Int64(healthCheck.UnhealthyThreshold),
+			},
+		}); err != nil {
+			return false, err
+		}
+		return true, nil
+	}, awserrors.LoadBalancerNotFound)
+}
+
 func (s *Service) configureAttributes(name string, attributes infrav1.ClassicELBAttributes) error {
 	attrs := &elb.ModifyLoadBalancerAttributesInput{
 		LoadBalancerName: aws.String(name),
@@ -1182,6 +1281,7 @@ func (s *Service) configureAttributes(name string, attributes infrav1.ClassicELB
 			CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{
 				Enabled: aws.Bool(attributes.CrossZoneLoadBalancing),
 			},
+			AccessLog: toSDKClassicELBAccessLog(attributes.AccessLogs),
 		},
 	}
 
@@ -1578,8 +1678,6 @@ func (s *Service) reconcileTargetGroupsAndListeners(lbARN string, spec *infrav1.
 	createdTargetGroups := make([]*elbv2.TargetGroup, 0, len(spec.ELBListeners))
 	createdListeners := make([]*elbv2.Listener, 0, len(spec.ELBListeners))
 
-	// TODO(Skarlso): Add options to set up SSL.
-	// https://github.com/kubernetes-sigs/cluster-api-provider-aws/issues/3899
 	for _, ln := range spec.ELBListeners {
 		var group *elbv2.TargetGroup
 		tgSpec := ln.TargetGroup
@@ -1591,62 +1689,84 @@ func (s *Service) reconcileTargetGroupsAndListeners(lbARN string, spec *infrav1.
 		}
 		// create the target group first
 		if group == nil {
-			group, err = s.createTargetGroup(ln, spec.Tags)
+			group, err = s.createTargetGroup(ln, spec.IPAddressType, spec.Tags)
 			if err != nil {
 				return nil, nil, err
 			}
 			createdTargetGroups = append(createdTargetGroups, group)
 
-			if !lbSpec.PreserveClientIP {
-				targetGroupAttributeInput := &elbv2.ModifyTargetGroupAttributesInput{
-					TargetGroupArn: group.TargetGroupArn,
-					Attributes: []*elbv2.TargetGroupAttribute{
-						{
-							Key:   aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP),
-							Value: aws.String("false"),
-						},
-					},
-				}
-				if _, err := s.ELBV2Client.ModifyTargetGroupAttributes(targetGroupAttributeInput); err != nil {
-					return nil, nil, errors.Wrapf(err, "failed to modify target group attribute")
-				}
+			// New target groups only need the attributes that differ from their defaults.
+			if err := s.modifyTargetGroupAttributes(group, targetGroupAttributes(lbSpec), defaultTargetGroupAttributes); err != nil {
+				return nil, nil, err
+			}
+		} else {
+			if err := s.reconcileTargetGroupHealthCheck(group, tgSpec.HealthCheck); err != nil {
+				return nil, nil, err
+			}
+			if err := s.reconcileTargetGroupAttributes(group, lbSpec); err != nil {
+				return nil, nil, err
 			}
 		}
 
 		var listener *elbv2.Listener
 		for _, l := range existingListeners.Listeners {
-			if l.DefaultActions != nil && len(l.DefaultActions) > 0 && *l.DefaultActions[0].TargetGroupArn == *group.TargetGroupArn {
+			if isSDKListenerForTargetGroup(l, ln, group) {
 				listener = l
 				break
 			}
 		}
 
 		if listener == nil {
-			listener, err = s.createListener(ln, group, lbARN, spec.Tags)
+			listener, err = s.createListener(ln, group, lbARN, lbSpec, spec.Tags)
 			if err != nil {
 				return nil, nil, err
 			}
 			createdListeners = append(createdListeners, listener)
+		} else {
+			if err := s.reconcileListenerCertificates(listener, lbSpec); err != nil {
+				return nil, nil, err
+			}
+			if err := s.reconcileListenerRules(listener, group, lbSpec, spec.Tags); err != nil {
+				return nil, nil, err
+			}
 		}
 	}
 
 	return createdTargetGroups, createdListeners, nil
 }
 
-// createListener creates a single Listener.
-func (s *Service) createListener(ln infrav1.Listener, group *elbv2.TargetGroup, lbARN string, tags map[string]string) (*elbv2.Listener, error) {
+// createListener creates a single Listener, along with its rules.
+func (s *Service) createListener(ln infrav1.Listener, group *elbv2.TargetGroup, lbARN string, lbSpec *infrav1.AWSLoadBalancerSpec, tags map[string]string) (*elbv2.Listener, error) {
+	forward := &elbv2.Action{
+		TargetGroupArn: group.TargetGroupArn,
+		Type:           aws.String(elbv2.ActionTypeEnumForward),
+	}
 	listenerInput := &elbv2.CreateListenerInput{
-		DefaultActions: []*elbv2.Action{
-			{
-				TargetGroupArn: group.TargetGroupArn,
-				Type:           aws.String(elbv2.ActionTypeEnumForward),
-			},
-		},
+		DefaultActions:  []*elbv2.Action{forward},
 		LoadBalancerArn: aws.String(lbARN),
 		Port:            aws.Int64(ln.Port),
 		Protocol:        aws.String(string(ln.Protocol)),
 		Tags:            converters.MapToV2Tags(tags),
 	}
+	servesCertificates := terminatesTLS(ln.Protocol) && lbSpec != nil && lbSpec.CertificateARN != nil
+	if servesCertificates {
+		listenerInput.Certificates = []*elbv2.Certificate{{CertificateArn: lbSpec.CertificateARN}}
+		listenerInput.SslPolicy = lbSpec.SSLPolicy
+	}
+
+	rules := listenerRules(lbSpec, ln.Port)
+	if len(rules) > 0 {
+		// Only the requests matching the rules are forwarded to the targets.
+		listenerInput.DefaultActions = []*elbv2.Action{
+			{
+				Type: aws.String(elbv2.ActionTypeEnumFixedResponse),
+				FixedResponseConfig: &elbv2.FixedResponseActionConfig{
+					StatusCode: aws.String("404"),
+				},
+			},
+		}
+	}
+
 	// Create ClassicELBListeners
 	listener, err := s.ELBV2Client.CreateListener(listenerInput)
 	if err != nil {
@@ -1658,11 +1778,255 @@ func (s *Service) createListener(ln infrav1.Listener, group *elbv2.TargetGroup,
 	if len(listener.Listeners) > 1 {
 		return nil, errors.New("more than one listener created; expected only one")
 	}
+
+	if servesCertificates && len(lbSpec.AdditionalCertificateARNs) > 0 {
+		if err := s.addListenerCertificates(listener.Listeners[0].ListenerArn, lbSpec.AdditionalCertificateARNs); err != nil {
+			return nil, errors.Wrapf(err, "failed to add certificates to listener on port %d", ln.Port)
+		}
+	}
+
+	for _, rule := range rules {
+		if _, err := s.ELBV2Client.CreateRule(&elbv2.CreateRuleInput{
+			ListenerArn: listener.Listeners[0].ListenerArn,
+			Priority:    aws.Int64(rule.Priority),
+			Conditions:  listenerRuleConditions(rule),
+			Actions:     []*elbv2.Action{forward},
+			Tags:        converters.MapToV2Tags(tags),
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to create rule with priority %d for listener on port %d", rule.Priority, ln.Port)
+		}
+	}
+
 	return listener.Listeners[0], nil
 }
 
+// terminatesTLS reports whether the listeners of the protocol terminate TLS with the certificates of the load balancer.
+func terminatesTLS(protocol infrav1.ELBProtocol) bool {
+	return protocol == infrav1.ELBProtocolHTTPS || protocol == infrav1.ELBProtocolTLS
+}
+
+// reconcileListenerCertificates updates the default certificate and the security policy of an existing
+// HTTPS or TLS listener, along with the additional certificates it selects with SNI.
+func (s *Service) reconcileListenerCertificates(listener *elbv2.Listener, lbSpec *infrav1.AWSLoadBalancerSpec) error {
+	if !terminatesTLS(infrav1.ELBProtocol(aws.StringValue(listener.Protocol))) || lbSpec == nil || lbSpec.CertificateARN == nil {
+		return nil
+	}
+
+	input := &elbv2.ModifyListenerInput{ListenerArn: listener.ListenerArn}
+	if len(listener.Certificates) == 0 || aws.StringValue(listener.Certificates[0].CertificateArn) != *lbSpec.CertificateARN {
+		input.Certificates = []*elbv2.Certificate{{CertificateArn: lbSpec.CertificateARN}}
+	}
+	// The default security policy of the load balancer is kept when none is set.
+	if lbSpec.SSLPolicy != nil && aws.StringValue(listener.SslPolicy) != *lbSpec.SSLPolicy {
+		input.SslPolicy = lbSpec.SSLPolicy
+	}
+	if input.Certificates != nil || input.SslPolicy != nil {
+		if _, err := s.ELBV2Client.ModifyListener(input); err != nil {
+			return errors.Wrapf(err, "failed to modify listener on port %d", aws.Int64Value(listener.Port))
+		}
+	}
+
+	current := sets.New[string]()
+	describeInput := &elbv2.DescribeListenerCertificatesInput{ListenerArn: listener.ListenerArn}
+	for {
+		out, err := s.ELBV2Client.DescribeListenerCertificates(describeInput)
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe certificates of listener on port %d", aws.Int64Value(listener.Port))
+		}
+		for _, c := range out.Certificates {
+			// The default certificate is listed along with the additional ones.
+			if !aws.BoolValue(c.IsDefault) {
+				current.Insert(aws.StringValue(c.CertificateArn))
+			}
+		}
+		if aws.StringValue(out.NextMarker) == "" {
+			break
+		}
+		describeInput.Marker = out.NextMarker
+	}
+
+	desired := sets.New[string](lbSpec.AdditionalCertificateARNs...)
+	if missing := desired.Difference(current); missing.Len() > 0 {
+		if err := s.addListenerCertificates(listener.ListenerArn, sets.List(missing)); err != nil {
+			return errors.Wrapf(err, "failed to add certificates to listener on port %d", aws.Int64Value(listener.Port))
+		}
+	}
+	if stale := current.Difference(desired); stale.Len() > 0 {
+		if _, err := s.ELBV2Client.RemoveListenerCertificates(&elbv2.RemoveListenerCertificatesInput{
+			ListenerArn:  listener.ListenerArn,
+			Certificates: toSDKCertificates(sets.List(stale)),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to remove certificates from listener on port %d", aws.Int64Value(listener.Port))
+		}
+	}
+	return nil
+}
+
+func (s *Service) addListenerCertificates(listenerARN *string, certificateARNs []string) error {
+	_, err := s.ELBV2Client.AddListenerCertificates(&elbv2.AddListenerCertificatesInput{
+		ListenerArn:  listenerARN,
+		Certificates: toSDKCertificates(certificateARNs),
+	})
+	return err
+}
+
+func toSDKCertificates(certificateARNs []string) []*elbv2.Certificate {
+	certificates := make([]*elbv2.Certificate, 0, len(certificateARNs))
+	for _, arn := range certificateARNs {
+		certificates = append(certificates, &elbv2.Certificate{CertificateArn: aws.String(arn)})
+	}
+	return certificates
+}
+
+// listenerRules returns the rules of the additional listener on the given port.
+func listenerRules(lbSpec *infrav1.AWSLoadBalancerSpec, port int64) []infrav1.ListenerRule {
+	if lbSpec == nil {
+		return nil
+	}
+	for _, ln := range lbSpec.AdditionalListeners {
+		if ln.Port == port {
+			return ln.Rules
+		}
+	}
+	return nil
+}
+
+// reconcileListenerRules updates the rules of an existing HTTP or HTTPS listener to match the rules of its additional
+// listener, creating the missing rules and deleting the rules removed from the spec. The default action of the listener
+// forwards the requests to the target group when no rules are set, and answers them with a fixed response otherwise.
+func (s *Service) reconcileListenerRules(listener *elbv2.Listener, group *elbv2.TargetGroup, lbSpec *infrav1.AWSLoadBalancerSpec, tags map[string]string) error {
+	protocol := infrav1.ELBProtocol(aws.StringValue(listener.Protocol))
+	if protocol != infrav1.ELBProtocolHTTP && protocol != infrav1.ELBProtocolHTTPS {
+		return nil
+	}
+	port := aws.Int64Value(listener.Port)
+	rules := listenerRules(lbSpec, port)
+	forward := &elbv2.Action{
+		TargetGroupArn: group.TargetGroupArn,
+		Type:           aws.String(elbv2.ActionTypeEnumForward),
+	}
+
+	existing := make(map[string]*elbv2.Rule)
+	describeInput := &elbv2.DescribeRulesInput{ListenerArn: listener.ListenerArn}
+	for {
+		out, err := s.ELBV2Client.DescribeRules(describeInput)
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe rules of listener on port %d", port)
+		}
+		for _, r := range out.Rules {
+			if !aws.BoolValue(r.IsDefault) {
+				existing[aws.StringValue(r.Priority)] = r
+			}
+		}
+		if aws.StringValue(out.NextMarker) == "" {
+			break
+		}
+		describeInput.Marker = out.NextMarker
+	}
+
+	for _, rule := range rules {
+		priority := strconv.FormatInt(rule.Priority, 10)
+		current, ok := existing[priority]
+		delete(existing, priority)
+		switch {
+		case !ok:
+			if _, err := s.ELBV2Client.CreateRule(&elbv2.CreateRuleInput{
+				ListenerArn: listener.ListenerArn,
+				Priority:    aws.Int64(rule.Priority),
+				Conditions:  listenerRuleConditions(rule),
+				Actions:     []*elbv2.Action{forward},
+				Tags:        converters.MapToV2Tags(tags),
+			}); err != nil {
+				return errors.Wrapf(err, "failed to create rule with priority %d for listener on port %d", rule.Priority, port)
+			}
+		case !isSDKRuleEqualToListenerRule(current, rule, group):
+			if _, err := s.ELBV2Client.ModifyRule(&elbv2.ModifyRuleInput{
+				RuleArn:    current.RuleArn,
+				Conditions: listenerRuleConditions(rule),
+				Actions:    []*elbv2.Action{forward},
+			}); err != nil {
+				return errors.Wrapf(err, "failed to modify rule with priority %d of listener on port %d", rule.Priority, port)
+			}
+		}
+	}
+
+	// The default action is switched once the rules are in place, and before the stale rules are deleted, so that
+	// the requests are not dropped while the rules are added or removed.
+	defaultAction := forward
+	if len(rules) > 0 {
+		defaultAction = &elbv2.Action{
+			Type: aws.String(elbv2.ActionTypeEnumFixedResponse),
+			FixedResponseConfig: &elbv2.FixedResponseActionConfig{
+				StatusCode: aws.String("404"),
+			},
+		}
+	}
+	if len(listener.DefaultActions) == 0 || aws.StringValue(listener.DefaultActions[0].Type) != aws.StringValue(defaultAction.Type) {
+		if _, err := s.ELBV2Client.ModifyListener(&elbv2.ModifyListenerInput{
+			ListenerArn:    listener.ListenerArn,
+			DefaultActions: []*elbv2.Action{defaultAction},
+		}); err != nil {
+			return errors.Wrapf(err, "failed to modify the default action of listener on port %d", port)
+		}
+	}
+
+	for _, r := range existing {
+		if _, err := s.ELBV2Client.DeleteRule(&elbv2.DeleteRuleInput{RuleArn: r.RuleArn}); err != nil {
+			return errors.Wrapf(err, "failed to delete rule with priority %s of listener on port %d", aws.StringValue(r.Priority), port)
+		}
+	}
+	return nil
+}
+
+// isSDKRuleEqualToListenerRule reports whether the rule forwards the requests matching the conditions of the listener
+// rule to the target group.
+func isSDKRuleEqualToListenerRule(r *elbv2.Rule, rule infrav1.ListenerRule, group *elbv2.TargetGroup) bool {
+	if len(r.Actions) != 1 || aws.StringValue(r.Actions[0].TargetGroupArn) != aws.StringValue(group.TargetGroupArn) {
+		return false
+	}
+	var pathPatterns, hostHeaders []string
+	for _, c := range r.Conditions {
+		switch {
+		case c.PathPatternConfig != nil:
+			pathPatterns = aws.StringValueSlice(c.PathPatternConfig.Values)
+		case c.HostHeaderConfig != nil:
+			hostHeaders = aws.StringValueSlice(c.HostHeaderConfig.Values)
+		}
+	}
+	return sets.New(pathPatterns...).Equal(sets.New(rule.PathPatterns...)) && sets.New(hostHeaders...).Equal(sets.New(rule.HostHeaders...))
+}
+
+func listenerRuleConditions(rule infrav1.ListenerRule) []*elbv2.RuleCondition {
+	var conditions []*elbv2.RuleCondition
+	if len(rule.PathPatterns) > 0 {
+		conditions = append(conditions, &elbv2.RuleCondition{
+			Field:             aws.String("path-pattern"),
+			PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: aws.StringSlice(rule.PathPatterns)},
+		})
+	}
+	if len(rule.HostHeaders) > 0 {
+		conditions = append(conditions, &elbv2.RuleCondition{
+			Field:            aws.String("host-header"),
+			HostHeaderConfig: &elbv2.HostHeaderConditionConfig{Values: aws.StringSlice(rule.HostHeaders)},
+		})
+	}
+	return conditions
+}
+
+// isSDKListenerForTargetGroup reports whether the listener serves the target group. Listeners with rules
+// answer the unmatched requests with a fixed response, so they are matched by their port instead.
+func isSDKListenerForTargetGroup(l *elbv2.Listener, ln infrav1.Listener, group *elbv2.TargetGroup) bool {
+	if len(l.DefaultActions) == 0 {
+		return false
+	}
+	if l.DefaultActions[0].TargetGroupArn != nil {
+		return aws.StringValue(l.DefaultActions[0].TargetGroupArn) == aws.StringValue(group.TargetGroupArn)
+	}
+	return aws.StringValue(l.DefaultActions[0].Type) == elbv2.ActionTypeEnumFixedResponse && aws.Int64Value(l.Port) == ln.Port
+}
+
 // createTargetGroup creates a single Target Group.
-func (s *Service) createTargetGroup(ln infrav1.Listener, tags map[string]string) (*elbv2.TargetGroup, error) {
+func (s *Service) createTargetGroup(ln infrav1.Listener, ipAddressType infrav1.LoadBalancerIPAddressType, tags map[string]string) (*elbv2.TargetGroup, error) {
 	targetGroupInput := &elbv2.CreateTargetGroupInput{
 		Name:                       aws.String(ln.TargetGroup.Name),
 		Port:                       aws.Int64(ln.TargetGroup.Port),
@@ -1674,7 +2038,8 @@ func (s *Service) createTargetGroup(ln infrav1.Listener, tags map[string]string)
 		HealthyThresholdCount:      aws.Int64(infrav1.DefaultAPIServerHealthThresholdCount),
 		UnhealthyThresholdCount:    aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
 	}
-	if s.scope.VPC().IsIPv6Enabled() {
+	// IPv4 load balancers cannot forward the traffic to IPv6 target groups.
+	if s.scope.VPC().IsIPv6Enabled() && ipAddressType == infrav1.LoadBalancerIPAddressTypeDualStack {
 		targetGroupInput.IpAddressType = aws.String("ipv6")
 	}
 	if ln.TargetGroup.HealthCheck != nil {
@@ -1696,6 +2061,9 @@ func (s *Service) createTargetGroup(ln infrav1.Listener, tags map[string]string)
 		if ln.TargetGroup.HealthCheck.UnhealthyThresholdCount != nil {
 			targetGroupInput.UnhealthyThresholdCount = ln.TargetGroup.HealthCheck.UnhealthyThresholdCount
 		}
+		if ln.TargetGroup.HealthCheck.SuccessCodes != nil {
+			targetGroupInput.Matcher = &elbv2.Matcher{HttpCode: ln.TargetGroup.HealthCheck.SuccessCodes}
+		}
 	}
 	s.scope.Debug("creating target group", "group", targetGroupInput, "listener", ln)
 	group, err := s.ELBV2Client.CreateTargetGroup(targetGroupInput)
@@ -1711,34 +2079,185 @@ func (s *Service) createTargetGroup(ln infrav1.Listener, tags map[string]string)
 	return group.TargetGroups[0], nil
 }
 
+// getClassicELBHealthCheck creates the health check for the classic load balancer, applying the
+// customizations of the control plane load balancer spec.
+func (s *Service) getClassicELBHealthCheck() *infrav1.ClassicELBHealthCheck {
+	healthCheck := &infrav1.ClassicELBHealthCheck{
+		Target:             s.getHealthCheckTarget(),
+		Interval:           infrav1.DefaultAPIServerHealthCheckIntervalSec * time.Second,
+		Timeout:            infrav1.DefaultAPIServerHealthCheckTimeoutSec * time.Second,
+		HealthyThreshold:   infrav1.DefaultAPIServerHealthThresholdCount,
+		UnhealthyThreshold: infrav1.DefaultAPIServerUnhealthThresholdCount,
+	}
+
+	controlPlaneELB := s.scope.ControlPlaneLoadBalancer()
+	if controlPlaneELB == nil || controlPlaneELB.HealthCheck == nil {
+		return healthCheck
+	}
+	if controlPlaneELB.HealthCheck.IntervalSeconds != nil {
+		healthCheck.Interval = time.Duration(*controlPlaneELB.HealthCheck.IntervalSeconds) * time.Second
+	}
+	if controlPlaneELB.HealthCheck.TimeoutSeconds != nil {
+		healthCheck.Timeout = time.Duration(*controlPlaneELB.HealthCheck.TimeoutSeconds) * time.Second
+	}
+	if controlPlaneELB.HealthCheck.ThresholdCount != nil {
+		healthCheck.HealthyThreshold = *controlPlaneELB.HealthCheck.ThresholdCount
+	}
+	if controlPlaneELB.HealthCheck.UnhealthyThresholdCount != nil {
+		healthCheck.UnhealthyThreshold = *controlPlaneELB.HealthCheck.UnhealthyThresholdCount
+	}
+	return healthCheck
+}
+
+// reconcileTargetGroupHealthCheck updates the health check of an existing target group when it
+// differs from the spec.
+func (s *Service) reconcileTargetGroupHealthCheck(group *elbv2.TargetGroup, healthCheck *infrav1.TargetGroupHealthCheck) error {
+	if healthCheck == nil || isSDKTargetGroupHealthCheckEqual(group, healthCheck) {
+		return nil
+	}
+
+	input := &elbv2.ModifyTargetGroupInput{
+		TargetGroupArn:             group.TargetGroupArn,
+		HealthCheckEnabled:         aws.Bool(true),
+		HealthCheckProtocol:        healthCheck.Protocol,
+		HealthCheckPort:            healthCheck.Port,
+		HealthCheckPath:            healthCheck.Path,
+		HealthCheckIntervalSeconds: healthCheck.IntervalSeconds,
+		HealthCheckTimeoutSeconds:  healthCheck.TimeoutSeconds,
+		HealthyThresholdCount:      healthCheck.ThresholdCount,
+		UnhealthyThresholdCount:    healthCheck.UnhealthyThresholdCount,
+	}
+	if healthCheck.SuccessCodes != nil {
+		input.Matcher = &elbv2.Matcher{HttpCode: healthCheck.SuccessCodes}
+	}
+	if _, err := s.ELBV2Client.ModifyTargetGroup(input); err != nil {
+		return errors.Wrapf(err, "failed to modify health check of target group %q", aws.StringValue(group.TargetGroupName))
+	}
+	s.scope.Debug("Updated target group health check", "target-group", aws.StringValue(group.TargetGroupName), "health-check", healthCheck)
+	return nil
+}
+
+// defaultTargetGroupAttributes are the values of the managed attributes of new target groups.
+var defaultTargetGroupAttributes = map[string]string{
+	infrav1.TargetGroupAttributeEnablePreserveClientIP:     "true",
+	infrav1.TargetGroupAttributeEnableProxyProtocolV2:      "false",
+	infrav1.TargetGroupAttributeDeregistrationDelayTimeout: "300",
+}
+
+// targetGroupAttributes returns the target group attributes managed for the load balancer.
+// Client IP preservation and the proxy protocol are only configurable on Network Load Balancer target groups.
+func targetGroupAttributes(lbSpec *infrav1.AWSLoadBalancerSpec) map[string]string {
+	attributes := map[string]string{}
+	if lbSpec == nil {
+		return attributes
+	}
+	if lbSpec.LoadBalancerType != infrav1.LoadBalancerTypeALB {
+		attributes[infrav1.TargetGroupAttributeEnablePreserveClientIP] = strconv.FormatBool(lbSpec.PreserveClientIP)
+		attributes[infrav1.TargetGroupAttributeEnableProxyProtocolV2] = strconv.FormatBool(lbSpec.ProxyProtocolV2)
+	}
+	if lbSpec.DeregistrationDelaySeconds != nil {
+		attributes[infrav1.TargetGroupAttributeDeregistrationDelayTimeout] = strconv.FormatInt(*lbSpec.DeregistrationDelaySeconds, 10)
+	}
+	return attributes
+}
+
+// reconcileTargetGroupAttributes updates the managed attributes of an existing target group that do not match the spec.
+func (s *Service) reconcileTargetGroupAttributes(group *elbv2.TargetGroup, lbSpec *infrav1.AWSLoadBalancerSpec) error {
+	desired := targetGroupAttributes(lbSpec)
+	if len(desired) == 0 {
+		return nil
+	}
+
+	out, err := s.ELBV2Client.DescribeTargetGroupAttributes(&elbv2.DescribeTargetGroupAttributesInput{
+		TargetGroupArn: group.TargetGroupArn,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe attributes of target group %q", aws.StringValue(group.TargetGroupName))
+	}
+
+	current := map[string]string{}
+	for _, attr := range out.Attributes {
+		current[aws.StringValue(attr.Key)] = aws.StringValue(attr.Value)
+	}
+	return s.modifyTargetGroupAttributes(group, desired, current)
+}
+
+// modifyTargetGroupAttributes sets the desired attributes whose value differs from the current one.
+func (s *Service) modifyTargetGroupAttributes(group *elbv2.TargetGroup, desired, current map[string]string) error {
+	var attributes []*elbv2.TargetGroupAttribute
+	for _, key := range sets.List(sets.KeySet(desired)) {
+		if value, ok := current[key]; ok && value == desired[key] {
+			continue
+		}
+		attributes = append(attributes, &elbv2.TargetGroupAttribute{
+			Key:   aws.String(key),
+			Value: aws.String(desired[key]),
+		})
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	if _, err := s.ELBV2Client.ModifyTargetGroupAttributes(&elbv2.ModifyTargetGroupAttributesInput{
+		TargetGroupArn: group.TargetGroupArn,
+		Attributes:     attributes,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to modify attributes of target group %q", aws.StringValue(group.TargetGroupName))
+	}
+	s.scope.Debug("Updated target group attributes", "target-group", aws.StringValue(group.TargetGroupName), "attributes", attributes)
+	return nil
+}
+
 func (s *Service) getHealthCheckTarget() string {
 	controlPlaneELB := s.scope.ControlPlaneLoadBalancer()
 	protocol := &infrav1.ELBProtocolSSL
+	port := int64(infrav1.DefaultAPIServerPort)
+	path := infrav1.DefaultAPIServerHealthCheckPath
+	if controlPlaneELB != nil && controlPlaneELB.HealthCheck != nil {
+		if controlPlaneELB.HealthCheck.Port != nil {
+			port = *controlPlaneELB.HealthCheck.Port
+		}
+		if controlPlaneELB.HealthCheck.Path != nil {
+			path = *controlPlaneELB.HealthCheck.Path
+		}
+	}
 	if controlPlaneELB != nil && controlPlaneELB.HealthCheckProtocol != nil {
 		protocol = controlPlaneELB.HealthCheckProtocol
 		if protocol.String() == infrav1.ELBProtocolHTTP.String() || protocol.String() == infrav1.ELBProtocolHTTPS.String() {
-			return fmt.Sprintf("%v:%d%s", protocol, infrav1.DefaultAPIServerPort, infrav1.DefaultAPIServerHealthCheckPath)
+			return fmt.Sprintf("%v:%d%s", protocol, port, path)
 		}
 	}
-	return fmt.Sprintf("%v:%d", protocol, infrav1.DefaultAPIServerPort)
+	return fmt.Sprintf("%v:%d", protocol, port)
 }
 
 func fromSDKTypeToClassicELB(v *elb.LoadBalancerDescription, attrs *elb.LoadBalancerAttributes, tags []*elb.Tag) *infrav1.LoadBalancer {
 	res := &infrav1.LoadBalancer{
-		Name:             aws.StringValue(v.LoadBalancerName),
-		Scheme:           infrav1.ELBScheme(*v.Scheme),
-		SubnetIDs:        aws.StringValueSlice(v.Subnets),
-		SecurityGroupIDs: aws.StringValueSlice(v.SecurityGroups),
-		DNSName:          aws.StringValue(v.DNSName),
-		Tags:             converters.ELBTagsToMap(tags),
-		LoadBalancerType: infrav1.LoadBalancerTypeClassic,
+		Name:                  aws.StringValue(v.LoadBalancerName),
+		Scheme:                infrav1.ELBScheme(*v.Scheme),
+		SubnetIDs:             aws.StringValueSlice(v.Subnets),
+		SecurityGroupIDs:      aws.StringValueSlice(v.SecurityGroups),
+		DNSName:               aws.StringValue(v.DNSName),
+		CanonicalHostedZoneID: aws.StringValue(v.CanonicalHostedZoneNameID),
+		Tags:                  converters.ELBTagsToMap(tags),
+		LoadBalancerType:      infrav1.LoadBalancerTypeClassic,
 	}
 
 	if attrs.ConnectionSettings != nil && attrs.ConnectionSettings.IdleTimeout != nil {
 		res.ClassicElbAttributes.IdleTimeout = time.Duration(*attrs.ConnectionSettings.IdleTimeout) * time.Second
 	}
 
+	if v.HealthCheck != nil {
+		res.HealthCheck = &infrav1.ClassicELBHealthCheck{
+			Target:             aws.StringValue(v.HealthCheck.Target),
+			Interval:           time.Duration(aws.Int64Value(v.HealthCheck.Interval)) * time.Second,
+			Timeout:            time.Duration(aws.Int64Value(v.HealthCheck.Timeout)) * time.Second,
+			HealthyThreshold:   aws.Int64Value(v.HealthCheck.HealthyThreshold),
+			UnhealthyThreshold: aws.Int64Value(v.HealthCheck.UnhealthyThreshold),
+		}
+	}
+
 	res.ClassicElbAttributes.CrossZoneLoadBalancing = aws.BoolValue(attrs.CrossZoneLoadBalancing.Enabled)
+	res.ClassicElbAttributes.AccessLogs = fromSDKClassicELBAccessLog(attrs.AccessLog)
 
 	return res
 }
@@ -1751,14 +2270,16 @@ func fromSDKTypeToLB(v *elbv2.LoadBalancer, attrs []*elbv2.LoadBalancerAttribute
 		availabilityZones[i] = az.ZoneName
 	}
 	res := &infrav1.LoadBalancer{
-		ARN:               aws.StringValue(v.LoadBalancerArn),
-		Name:              aws.StringValue(v.LoadBalancerName),
-		Scheme:            infrav1.ELBScheme(aws.StringValue(v.Scheme)),
-		SubnetIDs:         aws.StringValueSlice(subnetIDs),
-		SecurityGroupIDs:  aws.StringValueSlice(v.SecurityGroups),
-		AvailabilityZones: aws.StringValueSlice(availabilityZones),
-		DNSName:           aws.StringValue(v.DNSName),
-		Tags:              converters.V2TagsToMap(tags),
+		ARN:                   aws.StringValue(v.LoadBalancerArn),
+		Name:                  aws.StringValue(v.LoadBalancerName),
+		Scheme:                infrav1.ELBScheme(aws.StringValue(v.Scheme)),
+		SubnetIDs:             aws.StringValueSlice(subnetIDs),
+		SecurityGroupIDs:      aws.StringValueSlice(v.SecurityGroups),
+		AvailabilityZones:     aws.StringValueSlice(availabilityZones),
+		DNSName:               aws.StringValue(v.DNSName),
+		CanonicalHostedZoneID: aws.StringValue(v.CanonicalHostedZoneId),
+		IPAddressType:         infrav1.LoadBalancerIPAddressType(aws.StringValue(v.IpAddressType)),
+		Tags:                  converters.V2TagsToMap(tags),
 	}
 
 	infraAttrs := make(map[string]*string, len(attrs))
@@ -1783,6 +2304,15 @@ func chunkELBs(names []string) [][]string {
 	return chunked
 }
 
+// loadBalancerSecurityGroups returns the security groups set in the load balancer spec, or the managed
+// apiserver-lb security group when they are not replaced.
+func (s *Service) loadBalancerSecurityGroups(lbSpec *infrav1.AWSLoadBalancerSpec) []string {
+	if lbSpec != nil && len(lbSpec.SecurityGroups) > 0 {
+		return lbSpec.SecurityGroups
+	}
+	return []string{s.scope.SecurityGroups()[infrav1.SecurityGroupAPIServerLB].ID}
+}
+
 func shouldReconcileSGs(scope scope.ELBScope, lb *infrav1.LoadBalancer, specSGs []string) bool {
 	// Backwards compat: NetworkLoadBalancers were not always capable of having security groups attached.
 	// Once created without a security group, the NLB can never have any added.
@@ -1798,6 +2328,20 @@ func shouldReconcileSGs(scope scope.ELBScope, lb *infrav1.LoadBalancer, specSGs
 }
 
 // isSDKTargetGroupEqualToTargetGroup checks if a given AWS SDK target group matches a target group spec.
+func isSDKTargetGroupHealthCheckEqual(elbTG *elbv2.TargetGroup, hc *infrav1.TargetGroupHealthCheck) bool {
+	optionalEqual := func(current, desired *int64) bool {
+		return desired == nil || ptr.Deref(current, 0) == *desired
+	}
+	return strings.EqualFold(aws.StringValue(elbTG.HealthCheckProtocol), aws.StringValue(hc.Protocol)) &&
+		aws.StringValue(elbTG.HealthCheckPort) == aws.StringValue(hc.Port) &&
+		(hc.Path == nil || aws.StringValue(elbTG.HealthCheckPath) == *hc.Path) &&
+		optionalEqual(elbTG.HealthCheckIntervalSeconds, hc.IntervalSeconds) &&
+		optionalEqual(elbTG.HealthCheckTimeoutSeconds, hc.TimeoutSeconds) &&
+		optionalEqual(elbTG.HealthyThresholdCount, hc.ThresholdCount) &&
+		optionalEqual(elbTG.UnhealthyThresholdCount, hc.UnhealthyThresholdCount) &&
+		(hc.SuccessCodes == nil || elbTG.Matcher != nil && aws.StringValue(elbTG.Matcher.HttpCode) == *hc.SuccessCodes)
+}
+
 func isSDKTargetGroupEqualToTargetGroup(elbTG *elbv2.TargetGroup, spec *infrav1.TargetGroupSpec) bool {
 	// We can't check only the target group's name because it's randomly generated every time we get a spec
 	// But CAPA-created target groups are guaranteed to have the "apiserver-target-" or "additional-listener-" prefix.
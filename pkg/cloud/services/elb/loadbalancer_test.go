@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -196,6 +197,17 @@ func TestGetAPIServerClassicELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "load balancer config with idle timeout",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				IdleTimeoutSeconds: aws.Int64(3600),
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ClassicElbAttributes.IdleTimeout).To(Equal(time.Hour))
+			},
+		},
 		{
 			name: "load balancer config with subnets specified",
 			lb: &infrav1.AWSLoadBalancerSpec{
@@ -244,6 +256,18 @@ func TestGetAPIServerClassicELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "load balancer config with security groups replacing the managed security group",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				AdditionalSecurityGroups: []string{"sg-00001"},
+				SecurityGroups:           []string{"sg-00002"},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.SecurityGroupIDs).To(Equal([]string{"sg-00001", "sg-00002"}))
+			},
+		},
 		{
 			name: "Should create load balancer spec if elb health check protocol specified in config",
 			lb: &infrav1.AWSLoadBalancerSpec{
@@ -266,6 +290,30 @@ func TestGetAPIServerClassicELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				g.Expect(expectedTarget).To(Equal(res.HealthCheck.Target))
 			},
 		},
+		{
+			name: "Should create load balancer spec with custom elb health check settings",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				HealthCheckProtocol: &infrav1.ELBProtocolHTTPS,
+				HealthCheck: &infrav1.TargetGroupHealthCheckAPISpec{
+					Path:                    aws.String("/livez"),
+					IntervalSeconds:         aws.Int64(30),
+					TimeoutSeconds:          aws.Int64(10),
+					ThresholdCount:          aws.Int64(3),
+					UnhealthyThresholdCount: aws.Int64(2),
+				},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.HealthCheck).To(Equal(&infrav1.ClassicELBHealthCheck{
+					Target:             "HTTPS:6443/livez",
+					Interval:           30 * time.Second,
+					Timeout:            10 * time.Second,
+					HealthyThreshold:   3,
+					UnhealthyThreshold: 2,
+				}))
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -345,6 +393,43 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "network load balancer config with cross zone disabled",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeEnableLoadBalancingCrossZone, aws.String("false")))
+			},
+		},
+		{
+			name: "application load balancer config with cross zone enabled",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType:       infrav1.LoadBalancerTypeALB,
+				CrossZoneLoadBalancing: true,
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBAttributes).NotTo(HaveKey(infrav1.LoadBalancerAttributeEnableLoadBalancingCrossZone))
+			},
+		},
+		{
+			name: "application load balancer config with idle timeout and client keep alive",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType:       infrav1.LoadBalancerTypeALB,
+				IdleTimeoutSeconds:     aws.Int64(3600),
+				ClientKeepAliveSeconds: aws.Int64(7200),
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeIdleTimeTimeoutSeconds, aws.String("3600")))
+				g.Expect(res.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeClientKeepAliveSeconds, aws.String("7200")))
+			},
+		},
 		{
 			name: "load balancer config with subnets specified",
 			lb: &infrav1.AWSLoadBalancerSpec{
@@ -394,6 +479,53 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "load balancer config with security groups replacing the managed security group",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				SecurityGroups:   []string{"sg-00001", "sg-00002"},
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.SecurityGroupIDs).To(Equal([]string{"sg-00001", "sg-00002"}))
+			},
+		},
+		{
+			name: "load balancer config with deletion protection",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType:   infrav1.LoadBalancerTypeNLB,
+				DeletionProtection: true,
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeDeletionProtection, aws.String("true")))
+			},
+		},
+		{
+			name: "load balancer config defaults to IPv4 outside of IPv6 VPCs",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.IPAddressType).To(Equal(infrav1.LoadBalancerIPAddressTypeIPv4))
+			},
+		},
+		{
+			name: "dual-stack load balancer config",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				IPAddressType:    ptr.To(infrav1.LoadBalancerIPAddressTypeDualStack),
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.IPAddressType).To(Equal(infrav1.LoadBalancerIPAddressTypeDualStack))
+			},
+		},
 		{
 			name: "A base listener is set up for NLB",
 			lb: &infrav1.AWSLoadBalancerSpec{
@@ -407,6 +539,22 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "A base HTTPS listener is set up for ALB",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeALB,
+				CertificateARN:   aws.String("arn::certificate"),
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBListeners).To(HaveLen(1))
+				g.Expect(res.ELBListeners[0].Protocol).To(Equal(infrav1.ELBProtocolHTTPS))
+				g.Expect(res.ELBListeners[0].TargetGroup.Protocol).To(Equal(infrav1.ELBProtocolHTTPS))
+				g.Expect(res.ELBListeners[0].TargetGroup.HealthCheck.Protocol).To(Equal(aws.String("HTTPS")))
+				g.Expect(res.ELBListeners[0].TargetGroup.HealthCheck.Path).To(Equal(aws.String(infrav1.DefaultAPIServerHealthCheckPath)))
+			},
+		},
 		{
 			name: "A base listener is set up for NLB, with additional listeners",
 			lb: &infrav1.AWSLoadBalancerSpec{
@@ -426,6 +574,28 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "An additional listener forwards to its target port",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				AdditionalListeners: []infrav1.AdditionalListenerSpec{
+					{
+						Port:       8132,
+						Protocol:   infrav1.ELBProtocolTCP,
+						TargetPort: aws.Int64(8134),
+					},
+				},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBListeners).To(HaveLen(2))
+				ln := res.ELBListeners[1]
+				g.Expect(ln.Port).To(Equal(int64(8132)))
+				g.Expect(ln.TargetGroup.Port).To(Equal(int64(8134)))
+				g.Expect(ln.TargetGroup.HealthCheck.Port).To(Equal(aws.String("8134")))
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1218,6 +1388,7 @@ func TestCreateNLB(t *testing.T) {
 		{
 			name: "created with ipv6 vpc",
 			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.IPAddressType = infrav1.LoadBalancerIPAddressTypeDualStack
 				return spec
 			},
 			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
@@ -1262,6 +1433,54 @@ func TestCreateNLB(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "created as an IPv4 load balancer in an ipv6 vpc",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.IPAddressType = infrav1.LoadBalancerIPAddressTypeIPv4
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.NetworkSpec.VPC.IPv6 = &infrav1.IPv6{
+					CidrBlock: "2022:1234::/64",
+					PoolID:    "pool-id",
+				}
+				acl.Spec.ControlPlaneLoadBalancer.IPAddressType = ptr.To(infrav1.LoadBalancerIPAddressTypeIPv4)
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.CreateLoadBalancer(gomock.Eq(&elbv2.CreateLoadBalancerInput{
+					Name:           aws.String(elbName),
+					Scheme:         aws.String("internet-facing"),
+					SecurityGroups: aws.StringSlice([]string{}),
+					Type:           aws.String("network"),
+					Subnets:        aws.StringSlice([]string{clusterSubnetID}),
+					Tags: []*elbv2.Tag{
+						{
+							Key:   aws.String("test"),
+							Value: aws.String("tag"),
+						},
+					},
+				})).Return(&elbv2.CreateLoadBalancerOutput{
+					LoadBalancers: []*elbv2.LoadBalancer{
+						{
+							LoadBalancerArn:  aws.String(elbArn),
+							LoadBalancerName: aws.String(elbName),
+							Scheme:           aws.String(string(infrav1.ELBSchemeInternetFacing)),
+							DNSName:          aws.String(dns),
+						},
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, lb *infrav1.LoadBalancer, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if lb.IPAddressType != infrav1.LoadBalancerIPAddressTypeIPv4 {
+					t.Fatalf("IPAddressType did not equal expected value; was: '%s'", lb.IPAddressType)
+				}
+			},
+		},
 		{
 			name: "creating a load balancer fails",
 			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
@@ -1598,6 +1817,7 @@ func TestReconcileTargetGroupsAndListeners(t *testing.T) {
 		{
 			name: "created with ipv6 vpc",
 			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.IPAddressType = infrav1.LoadBalancerIPAddressTypeDualStack
 				return spec
 			},
 			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
@@ -2122,115 +2342,779 @@ func TestReconcileTargetGroupsAndListeners(t *testing.T) {
 				}
 			},
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			mockCtrl := gomock.NewController(t)
-			defer mockCtrl.Finish()
-			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
-
-			scheme, err := setupScheme()
-			if err != nil {
-				t.Fatal(err)
-			}
-			awsCluster := &infrav1.AWSCluster{
-				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
-				Spec: infrav1.AWSClusterSpec{
-					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
-						Name:             aws.String(elbName),
-						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
-					},
-					NetworkSpec: infrav1.NetworkSpec{
-						VPC: infrav1.VPCSpec{
-							ID: vpcID,
-						},
-					},
-				},
-			}
-			client := fake.NewClientBuilder().WithScheme(scheme).Build()
-			cluster := tc.awsCluster(*awsCluster)
-			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
-				Client: client,
-				Cluster: &clusterv1.Cluster{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: namespace,
-						Name:      clusterName,
-					},
-				},
-				AWSCluster: &cluster,
-			})
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			tc.elbV2APIMocks(elbV2APIMocks.EXPECT())
-
-			s := &Service{
-				scope:       clusterScope,
-				ELBV2Client: elbV2APIMocks,
-			}
-
-			loadBalancerSpec := &infrav1.LoadBalancer{
-				ARN:    elbArn,
-				Name:   elbName,
-				Scheme: infrav1.ELBSchemeInternetFacing,
-				Tags: map[string]string{
-					"test": "tag",
-				},
-				ELBListeners: []infrav1.Listener{
+		{
+			name: "ALB HTTPS listener with rules",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.LoadBalancerType = infrav1.LoadBalancerTypeALB
+				spec.ELBListeners = []infrav1.Listener{
 					{
-						Protocol: "TCP",
-						Port:     infrav1.DefaultAPIServerPort,
+						Protocol: infrav1.ELBProtocolHTTPS,
+						Port:     8443,
 						TargetGroup: infrav1.TargetGroupSpec{
 							Name:     "name",
-							Port:     infrav1.DefaultAPIServerPort,
-							Protocol: "TCP",
+							Port:     8443,
+							Protocol: infrav1.ELBProtocolHTTPS,
 							VpcID:    vpcID,
-							HealthCheck: &infrav1.TargetGroupHealthCheck{
-								Protocol: aws.String("tcp"),
-								Port:     aws.String(infrav1.DefaultAPIServerPortString),
-							},
 						},
 					},
-				},
-				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
-				SubnetIDs:        []string{clusterSubnetID},
-			}
-
-			spec := tc.spec(*loadBalancerSpec)
-			tgs, listeners, err := s.reconcileTargetGroupsAndListeners(spec.ARN, &spec, clusterScope.ControlPlaneLoadBalancer())
-			tc.check(t, tgs, listeners, err)
-		})
-	}
-}
-
-func TestReconcileV2LB(t *testing.T) {
-	const (
-		namespace       = "foo"
-		clusterName     = "bar"
-		clusterSubnetID = "subnet-1"
-		elbName         = "bar-apiserver"
-		elbArn          = "arn::apiserver"
-		tgArn           = "arn::target-group"
-		vpcID           = "vpc-id"
-		az              = "us-west-1a"
-	)
-
-	tests := []struct {
-		name          string
-		elbV2APIMocks func(m *mocks.MockELBV2APIMockRecorder)
-		check         func(t *testing.T, lb *infrav1.LoadBalancer, err error)
-		awsCluster    func(acl infrav1.AWSCluster) infrav1.AWSCluster
-		spec          func(spec infrav1.LoadBalancer) infrav1.LoadBalancer
-	}{
-		{
-			name: "ensure status populated with BYO NLB",
-			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				}
 				return spec
 			},
 			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
-				acl.Spec.ControlPlaneLoadBalancer.Name = aws.String(elbName)
+				acl.Spec.ControlPlaneLoadBalancer.LoadBalancerType = infrav1.LoadBalancerTypeALB
+				acl.Spec.ControlPlaneLoadBalancer.CertificateARN = aws.String("arn::certificate")
+				acl.Spec.ControlPlaneLoadBalancer.AdditionalListeners = []infrav1.AdditionalListenerSpec{
+					{
+						Port:     8443,
+						Protocol: infrav1.ELBProtocolHTTPS,
+						Rules: []infrav1.ListenerRule{
+							{Priority: 10, PathPatterns: []string{"/healthz"}, HostHeaders: []string{"api.example.com"}},
+						},
+					},
+				}
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Any()).Return(&elbv2.DescribeTargetGroupsOutput{}, nil)
+				m.CreateTargetGroup(gomock.Any()).Return(&elbv2.CreateTargetGroupOutput{
+					TargetGroups: []*elbv2.TargetGroup{
+						{
+							TargetGroupArn:  aws.String(tgArn),
+							TargetGroupName: aws.String("name"),
+						},
+					},
+				}, nil)
+				m.DescribeListeners(gomock.Any()).Return(&elbv2.DescribeListenersOutput{}, nil)
+				m.CreateListener(gomock.Eq(&elbv2.CreateListenerInput{
+					Certificates: []*elbv2.Certificate{{CertificateArn: aws.String("arn::certificate")}},
+					DefaultActions: []*elbv2.Action{
+						{
+							Type:                aws.String(elbv2.ActionTypeEnumFixedResponse),
+							FixedResponseConfig: &elbv2.FixedResponseActionConfig{StatusCode: aws.String("404")},
+						},
+					},
+					LoadBalancerArn: aws.String(elbArn),
+					Port:            aws.Int64(8443),
+					Protocol:        aws.String("HTTPS"),
+					Tags: []*elbv2.Tag{
+						{
+							Key:   aws.String("test"),
+							Value: aws.String("tag"),
+						},
+					},
+				})).Return(&elbv2.CreateListenerOutput{
+					Listeners: []*elbv2.Listener{{ListenerArn: aws.String("listener::arn")}},
+				}, nil)
+				m.CreateRule(gomock.Eq(&elbv2.CreateRuleInput{
+					ListenerArn: aws.String("listener::arn"),
+					Priority:    aws.Int64(10),
+					Conditions: []*elbv2.RuleCondition{
+						{
+							Field:             aws.String("path-pattern"),
+							PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: aws.StringSlice([]string{"/healthz"})},
+						},
+						{
+							Field:            aws.String("host-header"),
+							HostHeaderConfig: &elbv2.HostHeaderConditionConfig{Values: aws.StringSlice([]string{"api.example.com"})},
+						},
+					},
+					Actions: []*elbv2.Action{
+						{
+							TargetGroupArn: aws.String(tgArn),
+							Type:           aws.String(elbv2.ActionTypeEnumForward),
+						},
+					},
+					Tags: []*elbv2.Tag{
+						{
+							Key:   aws.String("test"),
+							Value: aws.String("tag"),
+						},
+					},
+				})).Return(&elbv2.CreateRuleOutput{}, nil)
+			},
+			check: func(t *testing.T, tgs []*elbv2.TargetGroup, listeners []*elbv2.Listener, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if len(listeners) != 1 {
+					t.Fatalf("expected 1 listener to be created, got %d", len(listeners))
+				}
+			},
+		},
+		{
+			name: "updates the rules of an existing ALB HTTPS listener",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.LoadBalancerType = infrav1.LoadBalancerTypeALB
+				spec.ELBListeners = []infrav1.Listener{
+					{
+						Protocol: infrav1.ELBProtocolHTTPS,
+						Port:     8443,
+						TargetGroup: infrav1.TargetGroupSpec{
+							Name:     "additional-listener-new",
+							Port:     8443,
+							Protocol: infrav1.ELBProtocolHTTPS,
+							VpcID:    vpcID,
+						},
+					},
+				}
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.ControlPlaneLoadBalancer.LoadBalancerType = infrav1.LoadBalancerTypeALB
+				acl.Spec.ControlPlaneLoadBalancer.CertificateARN = aws.String("arn::certificate")
+				acl.Spec.ControlPlaneLoadBalancer.AdditionalListeners = []infrav1.AdditionalListenerSpec{
+					{
+						Port:     8443,
+						Protocol: infrav1.ELBProtocolHTTPS,
+						Rules: []infrav1.ListenerRule{
+							{Priority: 10, PathPatterns: []string{"/healthz"}},
+							{Priority: 20, PathPatterns: []string{"/readyz"}},
+							{Priority: 30, HostHeaders: []string{"api.example.com"}},
+						},
+					},
+				}
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Any()).Return(&elbv2.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2.TargetGroup{
+						{
+							TargetGroupArn:  aws.String(tgArn),
+							TargetGroupName: aws.String("additional-listener-old"),
+							Port:            aws.Int64(8443),
+							Protocol:        aws.String("HTTPS"),
+						},
+					},
+				}, nil)
+				m.DescribeListeners(gomock.Any()).Return(&elbv2.DescribeListenersOutput{
+					Listeners: []*elbv2.Listener{
+						{
+							Certificates: []*elbv2.Certificate{{CertificateArn: aws.String("arn::certificate")}},
+							DefaultActions: []*elbv2.Action{
+								{
+									Type:                aws.String(elbv2.ActionTypeEnumFixedResponse),
+									FixedResponseConfig: &elbv2.FixedResponseActionConfig{StatusCode: aws.String("404")},
+								},
+							},
+							ListenerArn: aws.String("listener::arn"),
+							Port:        aws.Int64(8443),
+							Protocol:    aws.String("HTTPS"),
+						},
+					},
+				}, nil)
+				m.DescribeListenerCertificates(gomock.Any()).Return(&elbv2.DescribeListenerCertificatesOutput{
+					Certificates: []*elbv2.Certificate{
+						{CertificateArn: aws.String("arn::certificate"), IsDefault: aws.Bool(true)},
+					},
+				}, nil)
+				forward := []*elbv2.Action{
+					{
+						TargetGroupArn: aws.String(tgArn),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+					},
+				}
+				m.DescribeRules(gomock.Eq(&elbv2.DescribeRulesInput{
+					ListenerArn: aws.String("listener::arn"),
+				})).Return(&elbv2.DescribeRulesOutput{
+					Rules: []*elbv2.Rule{
+						{
+							IsDefault: aws.Bool(true),
+							Priority:  aws.String("default"),
+							RuleArn:   aws.String("rule::default"),
+						},
+						{
+							Actions: forward,
+							Conditions: []*elbv2.RuleCondition{
+								{
+									Field:             aws.String("path-pattern"),
+									PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: aws.StringSlice([]string{"/healthz"})},
+								},
+							},
+							Priority: aws.String("10"),
+							RuleArn:  aws.String("rule::10"),
+						},
+						{
+							Actions: forward,
+							Conditions: []*elbv2.RuleCondition{
+								{
+									Field:             aws.String("path-pattern"),
+									PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: aws.StringSlice([]string{"/livez"})},
+								},
+							},
+							Priority: aws.String("20"),
+							RuleArn:  aws.String("rule::20"),
+						},
+						{
+							Actions: forward,
+							Conditions: []*elbv2.RuleCondition{
+								{
+									Field:             aws.String("path-pattern"),
+									PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: aws.StringSlice([]string{"/metrics"})},
+								},
+							},
+							Priority: aws.String("40"),
+							RuleArn:  aws.String("rule::40"),
+						},
+					},
+				}, nil)
+				m.ModifyRule(gomock.Eq(&elbv2.ModifyRuleInput{
+					RuleArn: aws.String("rule::20"),
+					Conditions: []*elbv2.RuleCondition{
+						{
+							Field:             aws.String("path-pattern"),
+							PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: aws.StringSlice([]string{"/readyz"})},
+						},
+					},
+					Actions: forward,
+				})).Return(&elbv2.ModifyRuleOutput{}, nil)
+				m.CreateRule(gomock.Eq(&elbv2.CreateRuleInput{
+					ListenerArn: aws.String("listener::arn"),
+					Priority:    aws.Int64(30),
+					Conditions: []*elbv2.RuleCondition{
+						{
+							Field:            aws.String("host-header"),
+							HostHeaderConfig: &elbv2.HostHeaderConditionConfig{Values: aws.StringSlice([]string{"api.example.com"})},
+						},
+					},
+					Actions: forward,
+					Tags: []*elbv2.Tag{
+						{
+							Key:   aws.String("test"),
+							Value: aws.String("tag"),
+						},
+					},
+				})).Return(&elbv2.CreateRuleOutput{}, nil)
+				m.DeleteRule(gomock.Eq(&elbv2.DeleteRuleInput{
+					RuleArn: aws.String("rule::40"),
+				})).Return(&elbv2.DeleteRuleOutput{}, nil)
+			},
+			check: func(t *testing.T, tgs []*elbv2.TargetGroup, listeners []*elbv2.Listener, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if len(tgs) != 0 || len(listeners) != 0 {
+					t.Fatalf("did not expect target groups or listeners to be created")
+				}
+			},
+		},
+		{
+			name: "forwards the requests of an existing ALB listener once its rules are removed",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.LoadBalancerType = infrav1.LoadBalancerTypeALB
+				spec.ELBListeners = []infrav1.Listener{
+					{
+						Protocol: infrav1.ELBProtocolHTTP,
+						Port:     8080,
+						TargetGroup: infrav1.TargetGroupSpec{
+							Name:     "additional-listener-new",
+							Port:     8080,
+							Protocol: infrav1.ELBProtocolHTTP,
+							VpcID:    vpcID,
+						},
+					},
+				}
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.ControlPlaneLoadBalancer.LoadBalancerType = infrav1.LoadBalancerTypeALB
+				acl.Spec.ControlPlaneLoadBalancer.AdditionalListeners = []infrav1.AdditionalListenerSpec{
+					{
+						Port:     8080,
+						Protocol: infrav1.ELBProtocolHTTP,
+					},
+				}
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Any()).Return(&elbv2.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2.TargetGroup{
+						{
+							TargetGroupArn:  aws.String(tgArn),
+							TargetGroupName: aws.String("additional-listener-old"),
+							Port:            aws.Int64(8080),
+							Protocol:        aws.String("HTTP"),
+						},
+					},
+				}, nil)
+				m.DescribeListeners(gomock.Any()).Return(&elbv2.DescribeListenersOutput{
+					Listeners: []*elbv2.Listener{
+						{
+							DefaultActions: []*elbv2.Action{
+								{
+									Type:                aws.String(elbv2.ActionTypeEnumFixedResponse),
+									FixedResponseConfig: &elbv2.FixedResponseActionConfig{StatusCode: aws.String("404")},
+								},
+							},
+							ListenerArn: aws.String("listener::arn"),
+							Port:        aws.Int64(8080),
+							Protocol:    aws.String("HTTP"),
+						},
+					},
+				}, nil)
+				forward := []*elbv2.Action{
+					{
+						TargetGroupArn: aws.String(tgArn),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+					},
+				}
+				m.DescribeRules(gomock.Any()).Return(&elbv2.DescribeRulesOutput{
+					Rules: []*elbv2.Rule{
+						{
+							Actions: forward,
+							Conditions: []*elbv2.RuleCondition{
+								{
+									Field:             aws.String("path-pattern"),
+									PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: aws.StringSlice([]string{"/healthz"})},
+								},
+							},
+							Priority: aws.String("10"),
+							RuleArn:  aws.String("rule::10"),
+						},
+					},
+				}, nil)
+				gomock.InOrder(
+					m.ModifyListener(gomock.Eq(&elbv2.ModifyListenerInput{
+						ListenerArn:    aws.String("listener::arn"),
+						DefaultActions: forward,
+					})).Return(&elbv2.ModifyListenerOutput{}, nil),
+					m.DeleteRule(gomock.Eq(&elbv2.DeleteRuleInput{
+						RuleArn: aws.String("rule::10"),
+					})).Return(&elbv2.DeleteRuleOutput{}, nil),
+				)
+			},
+			check: func(t *testing.T, tgs []*elbv2.TargetGroup, listeners []*elbv2.Listener, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "NLB TLS listener with additional certificates and a security policy",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.ELBListeners = []infrav1.Listener{
+					{
+						Protocol: infrav1.ELBProtocolTLS,
+						Port:     8443,
+						TargetGroup: infrav1.TargetGroupSpec{
+							Name:     "name",
+							Port:     8443,
+							Protocol: infrav1.ELBProtocolTLS,
+							VpcID:    vpcID,
+						},
+					},
+				}
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.ControlPlaneLoadBalancer.CertificateARN = aws.String("arn::certificate")
+				acl.Spec.ControlPlaneLoadBalancer.AdditionalCertificateARNs = []string{"arn::sni"}
+				acl.Spec.ControlPlaneLoadBalancer.SSLPolicy = aws.String("ELBSecurityPolicy-TLS13-1-2-2021-06")
+				acl.Spec.ControlPlaneLoadBalancer.AdditionalListeners = []infrav1.AdditionalListenerSpec{
+					{
+						Port:     8443,
+						Protocol: infrav1.ELBProtocolTLS,
+					},
+				}
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Any()).Return(&elbv2.DescribeTargetGroupsOutput{}, nil)
+				m.CreateTargetGroup(gomock.Any()).Return(&elbv2.CreateTargetGroupOutput{
+					TargetGroups: []*elbv2.TargetGroup{
+						{
+							TargetGroupArn:  aws.String(tgArn),
+							TargetGroupName: aws.String("name"),
+						},
+					},
+				}, nil)
+				m.ModifyTargetGroupAttributes(gomock.Any()).Return(nil, nil)
+				m.DescribeListeners(gomock.Any()).Return(&elbv2.DescribeListenersOutput{}, nil)
+				m.CreateListener(gomock.Eq(&elbv2.CreateListenerInput{
+					Certificates: []*elbv2.Certificate{{CertificateArn: aws.String("arn::certificate")}},
+					DefaultActions: []*elbv2.Action{
+						{
+							TargetGroupArn: aws.String(tgArn),
+							Type:           aws.String(elbv2.ActionTypeEnumForward),
+						},
+					},
+					LoadBalancerArn: aws.String(elbArn),
+					Port:            aws.Int64(8443),
+					Protocol:        aws.String("TLS"),
+					SslPolicy:       aws.String("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+					Tags: []*elbv2.Tag{
+						{
+							Key:   aws.String("test"),
+							Value: aws.String("tag"),
+						},
+					},
+				})).Return(&elbv2.CreateListenerOutput{
+					Listeners: []*elbv2.Listener{{ListenerArn: aws.String("listener::arn")}},
+				}, nil)
+				m.AddListenerCertificates(gomock.Eq(&elbv2.AddListenerCertificatesInput{
+					ListenerArn:  aws.String("listener::arn"),
+					Certificates: []*elbv2.Certificate{{CertificateArn: aws.String("arn::sni")}},
+				})).Return(&elbv2.AddListenerCertificatesOutput{}, nil)
+			},
+			check: func(t *testing.T, tgs []*elbv2.TargetGroup, listeners []*elbv2.Listener, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if len(listeners) != 1 {
+					t.Fatalf("expected 1 listener to be created, got %d", len(listeners))
+				}
+			},
+		},
+		{
+			name: "updates the certificates and the security policy of an existing TLS listener",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.ELBListeners = []infrav1.Listener{
+					{
+						Protocol: infrav1.ELBProtocolTLS,
+						Port:     8443,
+						TargetGroup: infrav1.TargetGroupSpec{
+							Name:     "additional-listener-new",
+							Port:     8443,
+							Protocol: infrav1.ELBProtocolTLS,
+							VpcID:    vpcID,
+						},
+					},
+				}
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.ControlPlaneLoadBalancer.CertificateARN = aws.String("arn::certificate")
+				acl.Spec.ControlPlaneLoadBalancer.AdditionalCertificateARNs = []string{"arn::sni-kept", "arn::sni-new"}
+				acl.Spec.ControlPlaneLoadBalancer.SSLPolicy = aws.String("ELBSecurityPolicy-TLS13-1-2-2021-06")
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Any()).Return(&elbv2.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2.TargetGroup{
+						{
+							TargetGroupArn:  aws.String(tgArn),
+							TargetGroupName: aws.String("additional-listener-old"),
+							Port:            aws.Int64(8443),
+							Protocol:        aws.String("TLS"),
+						},
+					},
+				}, nil)
+				m.DescribeTargetGroupAttributes(gomock.Any()).Return(&elbv2.DescribeTargetGroupAttributesOutput{
+					Attributes: []*elbv2.TargetGroupAttribute{
+						{Key: aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP), Value: aws.String("false")},
+						{Key: aws.String(infrav1.TargetGroupAttributeEnableProxyProtocolV2), Value: aws.String("false")},
+					},
+				}, nil)
+				m.DescribeListeners(gomock.Any()).Return(&elbv2.DescribeListenersOutput{
+					Listeners: []*elbv2.Listener{
+						{
+							Certificates: []*elbv2.Certificate{{CertificateArn: aws.String("arn::old-certificate")}},
+							DefaultActions: []*elbv2.Action{
+								{
+									TargetGroupArn: aws.String(tgArn),
+									Type:           aws.String(elbv2.ActionTypeEnumForward),
+								},
+							},
+							ListenerArn: aws.String("listener::arn"),
+							Port:        aws.Int64(8443),
+							Protocol:    aws.String("TLS"),
+							SslPolicy:   aws.String("ELBSecurityPolicy-2016-08"),
+						},
+					},
+				}, nil)
+				m.ModifyListener(gomock.Eq(&elbv2.ModifyListenerInput{
+					ListenerArn:  aws.String("listener::arn"),
+					Certificates: []*elbv2.Certificate{{CertificateArn: aws.String("arn::certificate")}},
+					SslPolicy:    aws.String("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+				})).Return(&elbv2.ModifyListenerOutput{}, nil)
+				m.DescribeListenerCertificates(gomock.Eq(&elbv2.DescribeListenerCertificatesInput{
+					ListenerArn: aws.String("listener::arn"),
+				})).Return(&elbv2.DescribeListenerCertificatesOutput{
+					Certificates: []*elbv2.Certificate{
+						{CertificateArn: aws.String("arn::certificate"), IsDefault: aws.Bool(true)},
+						{CertificateArn: aws.String("arn::sni-kept"), IsDefault: aws.Bool(false)},
+						{CertificateArn: aws.String("arn::sni-stale"), IsDefault: aws.Bool(false)},
+					},
+				}, nil)
+				m.AddListenerCertificates(gomock.Eq(&elbv2.AddListenerCertificatesInput{
+					ListenerArn:  aws.String("listener::arn"),
+					Certificates: []*elbv2.Certificate{{CertificateArn: aws.String("arn::sni-new")}},
+				})).Return(&elbv2.AddListenerCertificatesOutput{}, nil)
+				m.RemoveListenerCertificates(gomock.Eq(&elbv2.RemoveListenerCertificatesInput{
+					ListenerArn:  aws.String("listener::arn"),
+					Certificates: []*elbv2.Certificate{{CertificateArn: aws.String("arn::sni-stale")}},
+				})).Return(&elbv2.RemoveListenerCertificatesOutput{}, nil)
+			},
+			check: func(t *testing.T, tgs []*elbv2.TargetGroup, listeners []*elbv2.Listener, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if len(tgs) != 0 || len(listeners) != 0 {
+					t.Fatalf("did not expect target groups or listeners to be created")
+				}
+			},
+		},
+		{
+			name: "updates the health check of an existing target group",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.ELBListeners[0].TargetGroup.Name = "apiserver-target-new"
+				spec.ELBListeners[0].TargetGroup.HealthCheck = &infrav1.TargetGroupHealthCheck{
+					Protocol:        aws.String("HTTPS"),
+					Port:            aws.String(infrav1.DefaultAPIServerPortString),
+					Path:            aws.String("/livez"),
+					IntervalSeconds: aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
+					ThresholdCount:  aws.Int64(3),
+					SuccessCodes:    aws.String("200"),
+				}
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Eq(&elbv2.DescribeTargetGroupsInput{
+					LoadBalancerArn: aws.String(elbArn),
+				})).Return(&elbv2.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2.TargetGroup{
+						{
+							TargetGroupArn:             aws.String(tgArn),
+							TargetGroupName:            aws.String("apiserver-target-old"),
+							Port:                       aws.Int64(infrav1.DefaultAPIServerPort),
+							Protocol:                   aws.String("TCP"),
+							HealthCheckProtocol:        aws.String("TCP"),
+							HealthCheckPort:            aws.String(infrav1.DefaultAPIServerPortString),
+							HealthCheckIntervalSeconds: aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
+							HealthyThresholdCount:      aws.Int64(infrav1.DefaultAPIServerHealthThresholdCount),
+						},
+					},
+				}, nil)
+				m.DescribeListeners(gomock.Eq(&elbv2.DescribeListenersInput{
+					LoadBalancerArn: aws.String(elbArn),
+				})).Return(&elbv2.DescribeListenersOutput{
+					Listeners: []*elbv2.Listener{
+						{
+							DefaultActions: []*elbv2.Action{
+								{
+									TargetGroupArn: aws.String(tgArn),
+									Type:           aws.String(elbv2.ActionTypeEnumForward),
+								},
+							},
+							ListenerArn: aws.String("listener::arn"),
+							Port:        aws.Int64(infrav1.DefaultAPIServerPort),
+							Protocol:    aws.String("TCP"),
+						},
+					},
+				}, nil)
+				m.ModifyTargetGroup(gomock.Eq(&elbv2.ModifyTargetGroupInput{
+					TargetGroupArn:             aws.String(tgArn),
+					HealthCheckEnabled:         aws.Bool(true),
+					HealthCheckProtocol:        aws.String("HTTPS"),
+					HealthCheckPort:            aws.String(infrav1.DefaultAPIServerPortString),
+					HealthCheckPath:            aws.String("/livez"),
+					HealthCheckIntervalSeconds: aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
+					HealthyThresholdCount:      aws.Int64(3),
+					Matcher:                    &elbv2.Matcher{HttpCode: aws.String("200")},
+				})).Return(&elbv2.ModifyTargetGroupOutput{}, nil)
+				m.DescribeTargetGroupAttributes(gomock.Eq(&elbv2.DescribeTargetGroupAttributesInput{
+					TargetGroupArn: aws.String(tgArn),
+				})).Return(&elbv2.DescribeTargetGroupAttributesOutput{
+					Attributes: []*elbv2.TargetGroupAttribute{
+						{Key: aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP), Value: aws.String("false")},
+						{Key: aws.String(infrav1.TargetGroupAttributeEnableProxyProtocolV2), Value: aws.String("false")},
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, tgs []*elbv2.TargetGroup, listeners []*elbv2.Listener, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if len(tgs) != 0 || len(listeners) != 0 {
+					t.Fatalf("did not expect target groups or listeners to be created")
+				}
+			},
+		},
+		{
+			name: "updates the attributes of an existing target group",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.ELBListeners[0].TargetGroup.Name = "apiserver-target-new"
+				spec.ELBListeners[0].TargetGroup.HealthCheck = nil
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.ControlPlaneLoadBalancer.PreserveClientIP = true
+				acl.Spec.ControlPlaneLoadBalancer.ProxyProtocolV2 = true
+				acl.Spec.ControlPlaneLoadBalancer.DeregistrationDelaySeconds = aws.Int64(30)
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Eq(&elbv2.DescribeTargetGroupsInput{
+					LoadBalancerArn: aws.String(elbArn),
+				})).Return(&elbv2.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2.TargetGroup{
+						{
+							TargetGroupArn:  aws.String(tgArn),
+							TargetGroupName: aws.String("apiserver-target-old"),
+							Port:            aws.Int64(infrav1.DefaultAPIServerPort),
+							Protocol:        aws.String("TCP"),
+						},
+					},
+				}, nil)
+				m.DescribeListeners(gomock.Eq(&elbv2.DescribeListenersInput{
+					LoadBalancerArn: aws.String(elbArn),
+				})).Return(&elbv2.DescribeListenersOutput{
+					Listeners: []*elbv2.Listener{
+						{
+							DefaultActions: []*elbv2.Action{
+								{
+									TargetGroupArn: aws.String(tgArn),
+									Type:           aws.String(elbv2.ActionTypeEnumForward),
+								},
+							},
+							ListenerArn: aws.String("listener::arn"),
+							Port:        aws.Int64(infrav1.DefaultAPIServerPort),
+							Protocol:    aws.String("TCP"),
+						},
+					},
+				}, nil)
+				m.DescribeTargetGroupAttributes(gomock.Eq(&elbv2.DescribeTargetGroupAttributesInput{
+					TargetGroupArn: aws.String(tgArn),
+				})).Return(&elbv2.DescribeTargetGroupAttributesOutput{
+					Attributes: []*elbv2.TargetGroupAttribute{
+						{Key: aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP), Value: aws.String("false")},
+						{Key: aws.String(infrav1.TargetGroupAttributeEnableProxyProtocolV2), Value: aws.String("false")},
+						{Key: aws.String(infrav1.TargetGroupAttributeDeregistrationDelayTimeout), Value: aws.String("300")},
+					},
+				}, nil)
+				m.ModifyTargetGroupAttributes(gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
+					TargetGroupArn: aws.String(tgArn),
+					Attributes: []*elbv2.TargetGroupAttribute{
+						{Key: aws.String(infrav1.TargetGroupAttributeDeregistrationDelayTimeout), Value: aws.String("30")},
+						{Key: aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP), Value: aws.String("true")},
+						{Key: aws.String(infrav1.TargetGroupAttributeEnableProxyProtocolV2), Value: aws.String("true")},
+					},
+				})).Return(&elbv2.ModifyTargetGroupAttributesOutput{}, nil)
+			},
+			check: func(t *testing.T, tgs []*elbv2.TargetGroup, listeners []*elbv2.Listener, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			if err != nil {
+				t.Fatal(err)
+			}
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+				Spec: infrav1.AWSClusterSpec{
+					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+						Name:             aws.String(elbName),
+						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: vpcID,
+						},
+					},
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			cluster := tc.awsCluster(*awsCluster)
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      clusterName,
+					},
+				},
+				AWSCluster: &cluster,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tc.elbV2APIMocks(elbV2APIMocks.EXPECT())
+
+			s := &Service{
+				scope:       clusterScope,
+				ELBV2Client: elbV2APIMocks,
+			}
+
+			loadBalancerSpec := &infrav1.LoadBalancer{
+				ARN:    elbArn,
+				Name:   elbName,
+				Scheme: infrav1.ELBSchemeInternetFacing,
+				Tags: map[string]string{
+					"test": "tag",
+				},
+				ELBListeners: []infrav1.Listener{
+					{
+						Protocol: "TCP",
+						Port:     infrav1.DefaultAPIServerPort,
+						TargetGroup: infrav1.TargetGroupSpec{
+							Name:     "name",
+							Port:     infrav1.DefaultAPIServerPort,
+							Protocol: "TCP",
+							VpcID:    vpcID,
+							HealthCheck: &infrav1.TargetGroupHealthCheck{
+								Protocol: aws.String("tcp"),
+								Port:     aws.String(infrav1.DefaultAPIServerPortString),
+							},
+						},
+					},
+				},
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				SubnetIDs:        []string{clusterSubnetID},
+			}
+
+			spec := tc.spec(*loadBalancerSpec)
+			tgs, listeners, err := s.reconcileTargetGroupsAndListeners(spec.ARN, &spec, clusterScope.ControlPlaneLoadBalancer())
+			tc.check(t, tgs, listeners, err)
+		})
+	}
+}
+
+func TestReconcileV2LB(t *testing.T) {
+	const (
+		namespace       = "foo"
+		clusterName     = "bar"
+		clusterSubnetID = "subnet-1"
+		elbName         = "bar-apiserver"
+		elbArn          = "arn::apiserver"
+		tgArn           = "arn::target-group"
+		vpcID           = "vpc-id"
+		az              = "us-west-1a"
+	)
+
+	tests := []struct {
+		name          string
+		elbV2APIMocks func(m *mocks.MockELBV2APIMockRecorder)
+		check         func(t *testing.T, lb *infrav1.LoadBalancer, err error)
+		awsCluster    func(acl infrav1.AWSCluster) infrav1.AWSCluster
+		spec          func(spec infrav1.LoadBalancer) infrav1.LoadBalancer
+	}{
+		{
+			name: "ensure status populated with BYO NLB",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.ControlPlaneLoadBalancer.Name = aws.String(elbName)
 				return acl
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
@@ -3044,6 +3928,94 @@ func TestDeleteNLB(t *testing.T) {
 				m.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(tgArn)}).Return(&elbv2.DeleteTargetGroupOutput{}, nil)
 				// delete the load balancer
 
+				m.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(elbArn)}).Return(
+					&elbv2.DeleteLoadBalancerOutput{}, nil)
+
+				m.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{Names: []*string{aws.String(elbName)}}).Return(
+					&elbv2.DescribeLoadBalancersOutput{
+						LoadBalancers: []*elbv2.LoadBalancer{},
+					},
+					nil,
+				)
+			},
+		},
+		{
+			name: "if control plane NLB is protected from deletion, disable the protection before deleting it",
+			elbv2ApiMock: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{Names: []*string{aws.String(elbName)}}).Return(
+					&elbv2.DescribeLoadBalancersOutput{
+						LoadBalancers: []*elbv2.LoadBalancer{
+							{
+								LoadBalancerArn:  aws.String(elbArn),
+								LoadBalancerName: aws.String(elbName),
+								Scheme:           aws.String(string(infrav1.ELBSchemeInternetFacing)),
+							},
+						},
+					},
+					nil,
+				)
+
+				m.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
+					&elbv2.DescribeLoadBalancerAttributesOutput{
+						Attributes: []*elbv2.LoadBalancerAttribute{
+							{
+								Key:   aws.String("load_balancing.cross_zone.enabled"),
+								Value: aws.String("false"),
+							},
+							{
+								Key:   aws.String(infrav1.LoadBalancerAttributeDeletionProtection),
+								Value: aws.String("true"),
+							},
+						},
+					},
+					nil,
+				)
+
+				m.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
+					&elbv2.DescribeTagsOutput{
+						TagDescriptions: []*elbv2.TagDescription{
+							{
+								ResourceArn: aws.String(elbArn),
+								Tags: []*elbv2.Tag{{
+									Key:   aws.String(infrav1.ClusterTagKey(clusterName)),
+									Value: aws.String(string(infrav1.ResourceLifecycleOwned)),
+								}},
+							},
+						},
+					},
+					nil,
+				)
+
+				m.ModifyLoadBalancerAttributes(&elbv2.ModifyLoadBalancerAttributesInput{
+					LoadBalancerArn: aws.String(elbArn),
+					Attributes: []*elbv2.LoadBalancerAttribute{
+						{
+							Key:   aws.String(infrav1.LoadBalancerAttributeDeletionProtection),
+							Value: aws.String("false"),
+						},
+					},
+				}).Return(&elbv2.ModifyLoadBalancerAttributesOutput{}, nil)
+
+				// delete listeners
+				m.DescribeListeners(&elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(elbArn)}).Return(&elbv2.DescribeListenersOutput{
+					Listeners: []*elbv2.Listener{
+						{
+							ListenerArn: aws.String("listener::arn"),
+						},
+					},
+				}, nil)
+				m.DeleteListener(&elbv2.DeleteListenerInput{ListenerArn: aws.String("listener::arn")}).Return(&elbv2.DeleteListenerOutput{}, nil)
+				// delete target groups
+				m.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(elbArn)}).Return(&elbv2.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2.TargetGroup{
+						{
+							TargetGroupArn: aws.String(tgArn),
+						},
+					},
+				}, nil)
+				m.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(tgArn)}).Return(&elbv2.DeleteTargetGroupOutput{}, nil)
+				// delete the load balancer
+
 				m.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(elbArn)}).Return(
 					&elbv2.DeleteLoadBalancerOutput{}, nil)
 
@@ -3494,6 +4466,28 @@ func TestGetHealthCheckProtocol(t *testing.T) {
 			},
 			"TCP:6443",
 		},
+		{
+			"protocol https with custom port and path",
+			&infrav1.AWSLoadBalancerSpec{
+				HealthCheckProtocol: &testHTTPS,
+				HealthCheck: &infrav1.TargetGroupHealthCheckAPISpec{
+					Port: aws.Int64(8443),
+					Path: aws.String("/livez"),
+				},
+			},
+			"HTTPS:8443/livez",
+		},
+		{
+			"protocol tcp with custom port and path",
+			&infrav1.AWSLoadBalancerSpec{
+				HealthCheckProtocol: &testTCP,
+				HealthCheck: &infrav1.TargetGroupHealthCheckAPISpec{
+					Port: aws.Int64(8443),
+					Path: aws.String("/livez"),
+				},
+			},
+			"TCP:8443",
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.testName, func(t *testing.T) {
@@ -3646,6 +4640,64 @@ func TestService_getAPITargetGroupHealthCheck(t *testing.T) {
 				UnhealthyThresholdCount: aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
 			},
 		},
+		{
+			name: "custom port, path and thresholds, API health check HTTPS",
+			lbSpec: &infrav1.AWSLoadBalancerSpec{
+				HealthCheckProtocol: &infrav1.ELBProtocolHTTPS,
+				HealthCheck: &infrav1.TargetGroupHealthCheckAPISpec{
+					Port:                    aws.Int64(8443),
+					Path:                    aws.String("/livez"),
+					IntervalSeconds:         aws.Int64(5),
+					ThresholdCount:          aws.Int64(2),
+					UnhealthyThresholdCount: aws.Int64(2),
+				},
+			},
+			want: &infrav1.TargetGroupHealthCheck{
+				Protocol:                aws.String("HTTPS"),
+				Port:                    aws.String("8443"),
+				Path:                    aws.String("/livez"),
+				IntervalSeconds:         aws.Int64(5),
+				TimeoutSeconds:          aws.Int64(infrav1.DefaultAPIServerHealthCheckTimeoutSec),
+				ThresholdCount:          aws.Int64(2),
+				UnhealthyThresholdCount: aws.Int64(2),
+			},
+		},
+		{
+			name: "custom success codes, API health check HTTPS",
+			lbSpec: &infrav1.AWSLoadBalancerSpec{
+				HealthCheckProtocol: &infrav1.ELBProtocolHTTPS,
+				HealthCheck: &infrav1.TargetGroupHealthCheckAPISpec{
+					SuccessCodes: aws.String("200-399"),
+				},
+			},
+			want: &infrav1.TargetGroupHealthCheck{
+				Protocol:                aws.String("HTTPS"),
+				Port:                    aws.String("6443"),
+				Path:                    aws.String("/readyz"),
+				IntervalSeconds:         aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
+				TimeoutSeconds:          aws.Int64(infrav1.DefaultAPIServerHealthCheckTimeoutSec),
+				ThresholdCount:          aws.Int64(infrav1.DefaultAPIServerHealthThresholdCount),
+				UnhealthyThresholdCount: aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
+				SuccessCodes:            aws.String("200-399"),
+			},
+		},
+		{
+			name: "custom path ignored, API health check TCP",
+			lbSpec: &infrav1.AWSLoadBalancerSpec{
+				HealthCheck: &infrav1.TargetGroupHealthCheckAPISpec{
+					Path: aws.String("/livez"),
+				},
+			},
+			want: &infrav1.TargetGroupHealthCheck{
+				Protocol:                aws.String("TCP"),
+				Port:                    aws.String("6443"),
+				Path:                    nil,
+				IntervalSeconds:         aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
+				TimeoutSeconds:          aws.Int64(infrav1.DefaultAPIServerHealthCheckTimeoutSec),
+				ThresholdCount:          aws.Int64(infrav1.DefaultAPIServerHealthThresholdCount),
+				UnhealthyThresholdCount: aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3679,6 +4731,21 @@ func TestService_getAdditionalTargetGroupHealthCheck(t *testing.T) {
 				UnhealthyThresholdCount: aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
 			},
 		},
+		{
+			name: "Listener TLS, Health check protocol defaults to TCP",
+			listener: infrav1.AdditionalListenerSpec{
+				Port:     8443,
+				Protocol: infrav1.ELBProtocolTLS,
+			},
+			want: &infrav1.TargetGroupHealthCheck{
+				Protocol:                aws.String("TCP"),
+				Port:                    aws.String("8443"),
+				IntervalSeconds:         aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
+				TimeoutSeconds:          aws.Int64(infrav1.DefaultAPIServerHealthCheckTimeoutSec),
+				ThresholdCount:          aws.Int64(infrav1.DefaultAPIServerHealthThresholdCount),
+				UnhealthyThresholdCount: aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
+			},
+		},
 		{
 			name: "Listener TCP, Health check protocol TCP, probe defaults",
 			listener: infrav1.AdditionalListenerSpec{
@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	rgapi "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/annotations"
@@ -33,6 +34,7 @@ import (
 const (
 	serviceNameTag    = "kubernetes.io/service-name"
 	eksClusterNameTag = "aws:eks:cluster-name"
+	ebsCSIClusterTag  = "ebs.csi.aws.com/cluster"
 )
 
 // ReconcileDelete is responsible for determining if the infra cluster needs to be garbage collected. If
@@ -41,14 +43,9 @@ const (
 func (s *Service) ReconcileDelete(ctx context.Context) error {
 	s.scope.Info("reconciling deletion for garbage collection", "cluster", s.scope.InfraClusterName())
 
-	val, found := annotations.Get(s.scope.InfraCluster(), infrav1.ExternalResourceGCAnnotation)
-	if !found {
-		val = "true"
-	}
-
-	shouldGC, err := strconv.ParseBool(val)
+	shouldGC, err := s.isGCEnabled()
 	if err != nil {
-		return fmt.Errorf("converting value %s of annotation %s to bool: %w", val, infrav1.ExternalResourceGCAnnotation, err)
+		return err
 	}
 
 	if !shouldGC {
@@ -60,32 +57,83 @@ func (s *Service) ReconcileDelete(ctx context.Context) error {
 	return s.deleteResources(ctx)
 }
 
-func (s *Service) deleteResources(ctx context.Context) error {
-	s.scope.Info("deleting aws resources created by tenant cluster", "cluster", s.scope.InfraClusterName())
+// PlannedDeletion is a resource that the garbage collection would delete, along with the GC task deleting it.
+type PlannedDeletion struct {
+	Task     infrav1.GCTask
+	Resource *AWSResource
+}
+
+// PlanDeletion returns the resources that ReconcileDelete would delete, in the order they would be deleted,
+// without deleting them. No resources are returned when the cluster opted-out of garbage collection.
+func (s *Service) PlanDeletion(ctx context.Context) ([]PlannedDeletion, error) {
+	shouldGC, err := s.isGCEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	if !shouldGC {
+		return nil, nil
+	}
+
+	val, _ := annotations.Get(s.scope.InfraCluster(), infrav1.ExternalResourceGCTasksAnnotation)
+	tasks, err := s.selectGCTasks(val)
+	if err != nil {
+		return nil, err
+	}
 
 	resources, err := s.collectFuncs.Execute(ctx)
 	if err != nil {
-		return fmt.Errorf("collecting resources: %w", err)
+		return nil, fmt.Errorf("collecting resources: %w", err)
+	}
+
+	planned := []PlannedDeletion{}
+	for _, t := range tasks {
+		for _, resource := range resources {
+			if t.selects(resource) {
+				planned = append(planned, PlannedDeletion{Task: t.task, Resource: resource})
+			}
+		}
+	}
+
+	return planned, nil
+}
+
+func (s *Service) isGCEnabled() (bool, error) {
+	val, found := annotations.Get(s.scope.InfraCluster(), infrav1.ExternalResourceGCAnnotation)
+	if !found {
+		val = "true"
+	}
+
+	shouldGC, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, fmt.Errorf("converting value %s of annotation %s to bool: %w", val, infrav1.ExternalResourceGCAnnotation, err)
 	}
 
+	return shouldGC, nil
+}
+
+func (s *Service) deleteResources(ctx context.Context) error {
+	s.scope.Info("deleting aws resources created by tenant cluster", "cluster", s.scope.InfraClusterName())
+
 	cleanupFuncs := s.cleanupFuncs
 
-	if val, found := annotations.Get(s.scope.InfraCluster(), infrav1.ExternalResourceGCTasksAnnotation); found {
-		var gcTaskToFunc = map[infrav1.GCTask]ResourceCleanupFunc{
-			infrav1.GCTaskLoadBalancer:  s.deleteLoadBalancers,
-			infrav1.GCTaskTargetGroup:   s.deleteTargetGroups,
-			infrav1.GCTaskSecurityGroup: s.deleteSecurityGroups,
+	if val, found := annotations.Get(s.scope.InfraCluster(), infrav1.ExternalResourceGCTasksAnnotation); found && val != "" {
+		tasks, err := s.selectGCTasks(val)
+		if err != nil {
+			return err
 		}
 
 		cleanupFuncs = ResourceCleanupFuncs{}
-
-		tasks := strings.Split(val, ",")
-
-		for _, task := range tasks {
-			cleanupFuncs = append(cleanupFuncs, gcTaskToFunc[infrav1.GCTask(task)])
+		for _, t := range tasks {
+			cleanupFuncs = append(cleanupFuncs, t.cleanup)
 		}
 	}
 
+	resources, err := s.collectFuncs.Execute(ctx)
+	if err != nil {
+		return fmt.Errorf("collecting resources: %w", err)
+	}
+
 	if deleteErr := cleanupFuncs.Execute(ctx, resources); deleteErr != nil {
 		return fmt.Errorf("deleting resources: %w", deleteErr)
 	}
@@ -93,6 +141,59 @@ func (s *Service) deleteResources(ctx context.Context) error {
 	return nil
 }
 
+// gcTaskFuncs holds the functions of a GC task, to clean up its resources and to select the resources it
+// cleans up.
+type gcTaskFuncs struct {
+	task    infrav1.GCTask
+	cleanup ResourceCleanupFunc
+	selects func(resource *AWSResource) bool
+}
+
+// selectGCTasks returns the functions of the comma separated GC tasks, or of the default tasks when empty.
+// The tasks are returned in the order of the default clean up functions, whatever the order of the tasks,
+// as load balancers have to be deleted before the target groups and security groups they use. EBS volumes and
+// Route53 records, which are not cleaned up by default, come last.
+func (s *Service) selectGCTasks(val string) ([]gcTaskFuncs, error) {
+	tasks := sets.New[infrav1.GCTask]()
+	for _, task := range strings.Split(val, ",") {
+		if task = strings.TrimSpace(task); task != "" {
+			tasks.Insert(infrav1.GCTask(task))
+		}
+	}
+	if tasks.Len() == 0 {
+		tasks.Insert(infrav1.GCTaskLoadBalancer, infrav1.GCTaskTargetGroup, infrav1.GCTaskSecurityGroup)
+	}
+
+	orderedTasks := []gcTaskFuncs{
+		{
+			task:    infrav1.GCTaskLoadBalancer,
+			cleanup: s.deleteLoadBalancers,
+			selects: func(resource *AWSResource) bool { return s.isELBResourceToDelete(resource, "loadbalancer") },
+		},
+		{
+			task:    infrav1.GCTaskTargetGroup,
+			cleanup: s.deleteTargetGroups,
+			selects: func(resource *AWSResource) bool { return s.isELBResourceToDelete(resource, "targetgroup") },
+		},
+		{task: infrav1.GCTaskSecurityGroup, cleanup: s.deleteSecurityGroups, selects: s.isSecurityGroupToDelete},
+		{task: infrav1.GCTaskEBSVolume, cleanup: s.deleteVolumes, selects: s.isVolumeToDelete},
+		{task: infrav1.GCTaskRoute53Record, cleanup: s.deleteRoute53Records, selects: s.isRoute53RecordToDelete},
+	}
+
+	selected := []gcTaskFuncs{}
+	for _, t := range orderedTasks {
+		if tasks.Has(t.task) {
+			selected = append(selected, t)
+			tasks.Delete(t.task)
+		}
+	}
+	if tasks.Len() > 0 {
+		return nil, fmt.Errorf("annotation %s contains unsupported GC tasks %v", infrav1.ExternalResourceGCTasksAnnotation, sets.List(tasks))
+	}
+
+	return selected, nil
+}
+
 func (s *Service) defaultGetResources(ctx context.Context) ([]*AWSResource, error) {
 	s.scope.Info("get aws resources created by tenant cluster with resource group tagging API", "cluster", s.scope.InfraClusterName())
 
@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elb"
@@ -37,6 +39,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -795,6 +798,226 @@ func TestReconcileDelete(t *testing.T) {
 			ec2Mocks:   func(m *mocks.MockEC2APIMockRecorder) {},
 			expectErr:  false,
 		},
+		{
+			name:         "eks cluster with unordered clean-up funcs",
+			clusterScope: createManageScope(t, "", "security-group, load-balancer"),
+			rgAPIMocks: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
+				m.GetResourcesWithContext(gomock.Any(), &rgapi.GetResourcesInput{
+					TagFilters: []*rgapi.TagFilter{
+						{
+							Key:    aws.String("kubernetes.io/cluster/eks-test-cluster"),
+							Values: []*string{aws.String("owned")},
+						},
+					},
+				}).DoAndReturn(func(awsCtx context.Context, input *rgapi.GetResourcesInput, opts ...request.Option) (*rgapi.GetResourcesOutput, error) {
+					return &rgapi.GetResourcesOutput{
+						ResourceTagMappingList: []*rgapi.ResourceTagMapping{
+							{
+								ResourceARN: aws.String("arn:aws:elasticloadbalancing:eu-west-2:1234567890:targetgroup/k8s-default-podinfo-2c868b281a/e979fe9bd6825433"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String(serviceNameTag),
+										Value: aws.String("default/svc1"),
+									},
+								},
+							},
+							{
+								ResourceARN: aws.String("arn:aws:elasticloadbalancing:eu-west-2:1234567890:loadbalancer/aec24434cd2ce4630bd14a955413ee37"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String(serviceNameTag),
+										Value: aws.String("default/svc1"),
+									},
+								},
+							},
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:security-group/sg-123456"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String(serviceNameTag),
+										Value: aws.String("default/svc1"),
+									},
+								},
+							},
+						},
+					}, nil
+				})
+			},
+			elbMocks: func(m *mocks.MockELBAPIMockRecorder) {
+				m.DeleteLoadBalancerWithContext(gomock.Any(), &elb.DeleteLoadBalancerInput{
+					LoadBalancerName: aws.String("aec24434cd2ce4630bd14a955413ee37"),
+				}).Return(&elb.DeleteLoadBalancerOutput{}, nil)
+			},
+			elbv2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {},
+			ec2Mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DeleteSecurityGroupWithContext(gomock.Any(), &ec2.DeleteSecurityGroupInput{
+					GroupId: aws.String("sg-123456"),
+				})
+			},
+			expectErr: false,
+		},
+		{
+			name:         "eks cluster with unsupported clean-up func",
+			clusterScope: createManageScope(t, "", "load-balancer,volume"),
+			rgAPIMocks:   func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {},
+			elbMocks:     func(m *mocks.MockELBAPIMockRecorder) {},
+			elbv2Mocks:   func(m *mocks.MockELBV2APIMockRecorder) {},
+			ec2Mocks:     func(m *mocks.MockEC2APIMockRecorder) {},
+			expectErr:    true,
+		},
+		{
+			name:         "eks cluster with ebs volumes not cleaned up by default",
+			clusterScope: createManageScope(t, "", ""),
+			rgAPIMocks: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
+				m.GetResourcesWithContext(gomock.Any(), &rgapi.GetResourcesInput{
+					TagFilters: []*rgapi.TagFilter{
+						{
+							Key:    aws.String("kubernetes.io/cluster/eks-test-cluster"),
+							Values: []*string{aws.String("owned")},
+						},
+					},
+				}).DoAndReturn(func(awsCtx context.Context, input *rgapi.GetResourcesInput, opts ...request.Option) (*rgapi.GetResourcesOutput, error) {
+					return &rgapi.GetResourcesOutput{
+						ResourceTagMappingList: []*rgapi.ResourceTagMapping{
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:volume/vol-1"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/eks-test-cluster"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String(ebsCSIClusterTag),
+										Value: aws.String("true"),
+									},
+								},
+							},
+						},
+					}, nil
+				})
+			},
+			elbMocks:   func(m *mocks.MockELBAPIMockRecorder) {},
+			elbv2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {},
+			ec2Mocks:   func(m *mocks.MockEC2APIMockRecorder) {},
+			expectErr:  false,
+		},
+		{
+			name:         "eks cluster with ebs volume clean-up func",
+			clusterScope: createManageScope(t, "", "ebs-volume"),
+			rgAPIMocks: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
+				m.GetResourcesWithContext(gomock.Any(), &rgapi.GetResourcesInput{
+					TagFilters: []*rgapi.TagFilter{
+						{
+							Key:    aws.String("kubernetes.io/cluster/eks-test-cluster"),
+							Values: []*string{aws.String("owned")},
+						},
+					},
+				}).DoAndReturn(func(awsCtx context.Context, input *rgapi.GetResourcesInput, opts ...request.Option) (*rgapi.GetResourcesOutput, error) {
+					return &rgapi.GetResourcesOutput{
+						ResourceTagMappingList: []*rgapi.ResourceTagMapping{
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:volume/vol-1"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/eks-test-cluster"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String(ebsCSIClusterTag),
+										Value: aws.String("true"),
+									},
+								},
+							},
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:volume/vol-2"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/eks-test-cluster"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:volume/vol-3"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/eks-test-cluster"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String(ebsCSIClusterTag),
+										Value: aws.String("true"),
+									},
+								},
+							},
+						},
+					}, nil
+				})
+			},
+			elbMocks:   func(m *mocks.MockELBAPIMockRecorder) {},
+			elbv2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {},
+			ec2Mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DeleteVolumeWithContext(gomock.Any(), &ec2.DeleteVolumeInput{
+					VolumeId: aws.String("vol-1"),
+				}).Return(&ec2.DeleteVolumeOutput{}, nil)
+				m.DeleteVolumeWithContext(gomock.Any(), &ec2.DeleteVolumeInput{
+					VolumeId: aws.String("vol-3"),
+				}).Return(nil, awserr.New(awserrors.VolumeInUse, "volume is attached", nil))
+			},
+			expectErr: false,
+		},
+		{
+			name:         "eks cluster with ebs volume clean-up failure",
+			clusterScope: createManageScope(t, "", "ebs-volume"),
+			rgAPIMocks: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
+				m.GetResourcesWithContext(gomock.Any(), &rgapi.GetResourcesInput{
+					TagFilters: []*rgapi.TagFilter{
+						{
+							Key:    aws.String("kubernetes.io/cluster/eks-test-cluster"),
+							Values: []*string{aws.String("owned")},
+						},
+					},
+				}).DoAndReturn(func(awsCtx context.Context, input *rgapi.GetResourcesInput, opts ...request.Option) (*rgapi.GetResourcesOutput, error) {
+					return &rgapi.GetResourcesOutput{
+						ResourceTagMappingList: []*rgapi.ResourceTagMapping{
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:volume/vol-1"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/eks-test-cluster"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String(ebsCSIClusterTag),
+										Value: aws.String("true"),
+									},
+								},
+							},
+						},
+					}, nil
+				})
+			},
+			elbMocks:   func(m *mocks.MockELBAPIMockRecorder) {},
+			elbv2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {},
+			ec2Mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DeleteVolumeWithContext(gomock.Any(), &ec2.DeleteVolumeInput{
+					VolumeId: aws.String("vol-1"),
+				}).Return(nil, awserr.New(awserrors.UnauthorizedOperation, "not authorized", nil))
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -835,6 +1058,115 @@ func TestReconcileDelete(t *testing.T) {
 	}
 }
 
+func TestPlanDeletion(t *testing.T) {
+	mockResources := func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
+		m.GetResourcesWithContext(gomock.Any(), &rgapi.GetResourcesInput{
+			TagFilters: []*rgapi.TagFilter{
+				{
+					Key:    aws.String("kubernetes.io/cluster/eks-test-cluster"),
+					Values: []*string{aws.String("owned")},
+				},
+			},
+		}).Return(&rgapi.GetResourcesOutput{
+			ResourceTagMappingList: []*rgapi.ResourceTagMapping{
+				{
+					ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:security-group/sg-123456"),
+				},
+				{
+					ResourceARN: aws.String("arn:aws:elasticloadbalancing:eu-west-2:1234567890:loadbalancer/aec24434cd2ce4630bd14a955413ee37"),
+					Tags: []*rgapi.Tag{
+						{
+							Key:   aws.String(serviceNameTag),
+							Value: aws.String("default/svc1"),
+						},
+					},
+				},
+				{
+					ResourceARN: aws.String("arn:aws:elasticloadbalancing:eu-west-2:1234567890:loadbalancer/net/non-service/e979fe9bd6825433"),
+				},
+				{
+					ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:volume/vol-123456"),
+					Tags: []*rgapi.Tag{
+						{
+							Key:   aws.String(ebsCSIClusterTag),
+							Value: aws.String("true"),
+						},
+					},
+				},
+			},
+		}, nil)
+	}
+
+	testCases := []struct {
+		name         string
+		clusterScope cloud.ClusterScoper
+		rgAPIMocks   func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder)
+		expected     []PlannedDeletion
+		expectErr    bool
+	}{
+		{
+			name:         "cluster opt-out",
+			clusterScope: createManageScope(t, "false", ""),
+			rgAPIMocks:   func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {},
+		},
+		{
+			name:         "default clean-up funcs",
+			clusterScope: createManageScope(t, "", ""),
+			rgAPIMocks:   mockResources,
+			expected: []PlannedDeletion{
+				{Task: infrav1.GCTaskLoadBalancer, Resource: &AWSResource{ARN: &arn.ARN{Partition: "aws", Service: "elasticloadbalancing", Region: "eu-west-2", AccountID: "1234567890", Resource: "loadbalancer/aec24434cd2ce4630bd14a955413ee37"}, Tags: map[string]string{serviceNameTag: "default/svc1"}}},
+				{Task: infrav1.GCTaskSecurityGroup, Resource: &AWSResource{ARN: &arn.ARN{Partition: "aws", Service: "ec2", Region: "eu-west-2", AccountID: "1234567890", Resource: "security-group/sg-123456"}, Tags: map[string]string{}}},
+			},
+		},
+		{
+			name:         "ebs volume clean-up func",
+			clusterScope: createManageScope(t, "", "ebs-volume"),
+			rgAPIMocks:   mockResources,
+			expected: []PlannedDeletion{
+				{Task: infrav1.GCTaskEBSVolume, Resource: &AWSResource{ARN: &arn.ARN{Partition: "aws", Service: "ec2", Region: "eu-west-2", AccountID: "1234567890", Resource: "volume/vol-123456"}, Tags: map[string]string{ebsCSIClusterTag: "true"}}},
+			},
+		},
+		{
+			name:         "unsupported clean-up func",
+			clusterScope: createManageScope(t, "", "load-balancer,volume"),
+			rgAPIMocks:   func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {},
+			expectErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			rgapiMock := mocks.NewMockResourceGroupsTaggingAPIAPI(mockCtrl)
+			tc.rgAPIMocks(rgapiMock.EXPECT())
+
+			wkSvc := NewService(tc.clusterScope,
+				withELBClient(mocks.NewMockELBAPI(mockCtrl)),
+				withELBv2Client(mocks.NewMockELBV2API(mockCtrl)),
+				withResourceTaggingClient(rgapiMock),
+				withEC2Client(mocks.NewMockEC2API(mockCtrl)),
+				WithGCStrategy(false),
+			)
+			planned, err := wkSvc.PlanDeletion(context.TODO())
+
+			if tc.expectErr {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+
+			g.Expect(err).To(BeNil())
+			if tc.expected == nil {
+				g.Expect(planned).To(BeEmpty())
+				return
+			}
+			g.Expect(planned).To(Equal(tc.expected))
+		})
+	}
+}
+
 func createManageScope(t *testing.T, gcAnnotationValue, gcTasksAnnotationValue string) *scope.ManagedControlPlaneScope {
 	t.Helper()
 	g := NewWithT(t)
@@ -25,13 +25,15 @@ import (
 )
 
 const (
-	fakePartition     = "aws"
-	fakeRegion        = "fake-region"
-	fakeAccount       = "fake-account"
-	elbService        = "elasticloadbalancing"
-	elbResourcePrefix = "loadbalancer/"
-	sgService         = "ec2"
-	sgResourcePrefix  = "security-group/"
+	fakePartition        = "aws"
+	fakeRegion           = "fake-region"
+	fakeAccount          = "fake-account"
+	elbService           = "elasticloadbalancing"
+	elbResourcePrefix    = "loadbalancer/"
+	sgService            = "ec2"
+	sgResourcePrefix     = "security-group/"
+	volumeService        = "ec2"
+	volumeResourcePrefix = "volume/"
 
 	// maxDescribeTagsRequest is the maximum number of resources for the DescribeTags API call
 	// see: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeTags.html.
@@ -24,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 )
@@ -98,3 +99,84 @@ func (s *Service) getProviderOwnedSecurityGroups(_ context.Context) ([]*AWSResou
 
 	return resources, nil
 }
+
+func (s *Service) deleteVolumes(ctx context.Context, resources []*AWSResource) error {
+	for _, resource := range resources {
+		if !s.isVolumeToDelete(resource) {
+			s.scope.Debug("Resource not a volume for deletion", "arn", resource.ARN.String())
+			continue
+		}
+
+		volumeID := strings.TrimPrefix(resource.ARN.Resource, volumeResourcePrefix)
+		if err := s.deleteVolume(ctx, volumeID); err != nil {
+			return fmt.Errorf("deleting volume %q with ID %s: %w", resource.ARN, volumeID, err)
+		}
+	}
+	s.scope.Debug("Finished processing resources for volume deletion")
+
+	return nil
+}
+
+func (s *Service) isVolumeToDelete(resource *AWSResource) bool {
+	if !s.isMatchingResource(resource, ec2.ServiceName, "volume") {
+		return false
+	}
+	if resource.Tags[ebsCSIClusterTag] != "true" {
+		s.scope.Debug("Volume was not provisioned by the EBS CSI driver", "arn", resource.ARN.String(), "check", "volume")
+		return false
+	}
+	s.scope.Debug("Resource is a volume to delete", "arn", resource.ARN.String(), "check", "volume")
+
+	return true
+}
+
+// deleteVolume deletes the volume. Volumes still attached to an instance, for instance one that
+// does not belong to the cluster, cannot be deleted and are reported instead.
+func (s *Service) deleteVolume(ctx context.Context, volumeID string) error {
+	input := ec2.DeleteVolumeInput{
+		VolumeId: aws.String(volumeID),
+	}
+
+	s.scope.Debug("Deleting volume", "volume_id", volumeID)
+	if _, err := s.ec2Client.DeleteVolumeWithContext(ctx, &input); err != nil {
+		code, _ := awserrors.Code(err)
+		switch code {
+		case awserrors.VolumeNotFound:
+			s.scope.Debug("Volume already deleted", "volume_id", volumeID)
+		case awserrors.VolumeInUse:
+			s.scope.Info("Skipping deletion of volume attached to an instance", "volume_id", volumeID)
+		default:
+			return fmt.Errorf("deleting volume: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// getProviderOwnedVolumes gets the volumes provisioned by the EBS CSI driver for this cluster, filtering by tag: kubernetes.io/cluster/<cluster-name>:owned.
+func (s *Service) getProviderOwnedVolumes(ctx context.Context) ([]*AWSResource, error) {
+	input := &ec2.DescribeVolumesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderOwned(s.scope.KubernetesClusterName()),
+		},
+	}
+
+	var resources []*AWSResource
+	err := s.ec2Client.DescribeVolumesPagesWithContext(ctx, input, func(out *ec2.DescribeVolumesOutput, last bool) bool {
+		for _, volume := range out.Volumes {
+			arn := composeFakeArn(volumeService, volumeResourcePrefix+*volume.VolumeId)
+			resource, err := composeAWSResource(arn, converters.TagsToMap(volume.Tags))
+			if err != nil {
+				s.scope.Error(err, "error compose aws volume resource: %v", "name", arn)
+				continue
+			}
+			resources = append(resources, resource)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe volumes error: %w", err)
+	}
+
+	return resources, nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 )
 
 // ServiceOption is an option for creating the service.
@@ -54,6 +55,13 @@ func withEC2Client(client ec2iface.EC2API) ServiceOption {
 	}
 }
 
+// withRoute53Client is an option for specifying a AWS Route53 Client.
+func withRoute53Client(client route53iface.Route53API) ServiceOption {
+	return func(s *Service) {
+		s.route53Client = client
+	}
+}
+
 // WithGCStrategy is an option for specifying using the alternative GC strategy.
 func WithGCStrategy(alternativeGCStrategy bool) ServiceOption {
 	if alternativeGCStrategy {
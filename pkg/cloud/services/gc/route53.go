@@ -0,0 +1,246 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/annotations"
+)
+
+const (
+	route53Service            = "route53"
+	recordSetResourcePrefix   = "recordset/"
+	externalDNSHeritage       = "heritage=external-dns"
+	externalDNSOwnerAttribute = "external-dns/owner="
+)
+
+// externalDNSRecordTypes are the types of the records that external-dns registers with a TXT record.
+var externalDNSRecordTypes = []string{
+	route53.RRTypeA,
+	route53.RRTypeAaaa,
+	route53.RRTypeCname,
+	route53.RRTypeNs,
+	route53.RRTypeMx,
+	route53.RRTypeSrv,
+	route53.RRTypeNaptr,
+}
+
+func (s *Service) deleteRoute53Records(ctx context.Context, resources []*AWSResource) error {
+	for _, resource := range resources {
+		if !s.isRoute53RecordToDelete(resource) {
+			s.scope.Debug("Resource not a route53 record for deletion", "arn", resource.ARN.String())
+			continue
+		}
+
+		zoneID, recordType, name, err := parseRecordSetResource(resource.ARN.Resource)
+		if err != nil {
+			return err
+		}
+		if err := s.deleteRoute53Record(ctx, zoneID, recordType, name); err != nil {
+			return fmt.Errorf("deleting route53 record %q of type %s in hosted zone %s: %w", name, recordType, zoneID, err)
+		}
+	}
+	s.scope.Debug("Finished processing resources for route53 record deletion")
+
+	return nil
+}
+
+func (s *Service) isRoute53RecordToDelete(resource *AWSResource) bool {
+	if !s.isMatchingResource(resource, route53Service, "recordset") {
+		return false
+	}
+	s.scope.Debug("Resource is a route53 record to delete", "arn", resource.ARN.String(), "check", "route53record")
+
+	return true
+}
+
+// deleteRoute53Record deletes the record sets of the given name and type. Deleting a record set requires
+// its exact values, so they are listed again, which also covers the record sets with a set identifier.
+func (s *Service) deleteRoute53Record(ctx context.Context, zoneID, recordType, name string) error {
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(zoneID),
+		StartRecordName: aws.String(name),
+		StartRecordType: aws.String(recordType),
+	}
+
+	changes := []*route53.Change{}
+	err := s.route53Client.ListResourceRecordSetsPagesWithContext(ctx, input, func(out *route53.ListResourceRecordSetsOutput, last bool) bool {
+		for _, recordSet := range out.ResourceRecordSets {
+			if aws.StringValue(recordSet.Name) != name || aws.StringValue(recordSet.Type) != recordType {
+				return false
+			}
+			changes = append(changes, &route53.Change{
+				Action:            aws.String(route53.ChangeActionDelete),
+				ResourceRecordSet: recordSet,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("listing record sets: %w", err)
+	}
+
+	if len(changes) == 0 {
+		s.scope.Debug("Route53 record already deleted", "hosted_zone_id", zoneID, "name", name, "type", recordType)
+		return nil
+	}
+
+	s.scope.Debug("Deleting route53 record", "hosted_zone_id", zoneID, "name", name, "type", recordType)
+	if _, err := s.route53Client.ChangeResourceRecordSetsWithContext(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: changes,
+		},
+	}); err != nil {
+		return fmt.Errorf("changing record sets: %w", err)
+	}
+
+	return nil
+}
+
+// getExternalDNSRecords gets the route53 records created by external-dns for this cluster, that is the records
+// registered by a TXT record owned by the cluster: heritage=external-dns,external-dns/owner=<cluster-name>.
+// Route53 records cannot be tagged, so the records are only listed when the route53-record GC task is requested,
+// as it requires listing the records of every hosted zone of the account.
+func (s *Service) getExternalDNSRecords(ctx context.Context) ([]*AWSResource, error) {
+	if !s.isGCTaskRequested(infrav1.GCTaskRoute53Record) {
+		return nil, nil
+	}
+
+	var zoneIDs []string
+	err := s.route53Client.ListHostedZonesPagesWithContext(ctx, &route53.ListHostedZonesInput{}, func(out *route53.ListHostedZonesOutput, last bool) bool {
+		for _, zone := range out.HostedZones {
+			zoneIDs = append(zoneIDs, strings.TrimPrefix(aws.StringValue(zone.Id), "/hostedzone/"))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list hosted zones error: %w", err)
+	}
+
+	var resources []*AWSResource
+	for _, zoneID := range zoneIDs {
+		var recordSets []*route53.ResourceRecordSet
+		err := s.route53Client.ListResourceRecordSetsPagesWithContext(ctx, &route53.ListResourceRecordSetsInput{
+			HostedZoneId: aws.String(zoneID),
+		}, func(out *route53.ListResourceRecordSetsOutput, last bool) bool {
+			recordSets = append(recordSets, out.ResourceRecordSets...)
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list record sets of hosted zone %s error: %w", zoneID, err)
+		}
+
+		for _, recordSet := range s.selectExternalDNSRecordSets(recordSets) {
+			arn := composeFakeArn(route53Service, recordSetResourcePrefix+zoneID+"/"+aws.StringValue(recordSet.Type)+"/"+aws.StringValue(recordSet.Name))
+			resource, err := composeAWSResource(arn, infrav1.Tags{})
+			if err != nil {
+				s.scope.Error(err, "error compose aws route53 record resource: %v", "name", arn)
+				continue
+			}
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources, nil
+}
+
+// selectExternalDNSRecordSets returns the record sets registered by a TXT record owned by the cluster, followed by
+// the TXT records themselves, so the ownership of a record is only released once the record is deleted. Both the
+// TXT record formats of external-dns are supported: <name> and <record type>-<name>.
+func (s *Service) selectExternalDNSRecordSets(recordSets []*route53.ResourceRecordSet) []*route53.ResourceRecordSet {
+	ownedNames := map[string]bool{}
+	var ownership []*route53.ResourceRecordSet
+	for _, recordSet := range recordSets {
+		if aws.StringValue(recordSet.Type) == route53.RRTypeTxt && s.isOwnedByCluster(recordSet) {
+			ownedNames[aws.StringValue(recordSet.Name)] = true
+			ownership = append(ownership, recordSet)
+		}
+	}
+
+	selected := []*route53.ResourceRecordSet{}
+	for _, recordSet := range recordSets {
+		recordType := aws.StringValue(recordSet.Type)
+		if !isExternalDNSRecordType(recordType) {
+			continue
+		}
+		name := aws.StringValue(recordSet.Name)
+		if ownedNames[name] || ownedNames[strings.ToLower(recordType)+"-"+name] {
+			selected = append(selected, recordSet)
+		}
+	}
+
+	return append(selected, ownership...)
+}
+
+// isOwnedByCluster reports whether the TXT record is an external-dns registry record owned by the cluster.
+func (s *Service) isOwnedByCluster(recordSet *route53.ResourceRecordSet) bool {
+	for _, record := range recordSet.ResourceRecords {
+		heritage, owned := false, false
+		for _, attribute := range strings.Split(strings.Trim(aws.StringValue(record.Value), `"`), ",") {
+			switch attribute {
+			case externalDNSHeritage:
+				heritage = true
+			case externalDNSOwnerAttribute + s.scope.KubernetesClusterName():
+				owned = true
+			}
+		}
+		if heritage && owned {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Service) isGCTaskRequested(task infrav1.GCTask) bool {
+	val, _ := annotations.Get(s.scope.InfraCluster(), infrav1.ExternalResourceGCTasksAnnotation)
+	for _, t := range strings.Split(val, ",") {
+		if infrav1.GCTask(strings.TrimSpace(t)) == task {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isExternalDNSRecordType(recordType string) bool {
+	for _, t := range externalDNSRecordTypes {
+		if t == recordType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseRecordSetResource parses the resource of a record set fake arn: recordset/<hosted zone id>/<type>/<name>.
+func parseRecordSetResource(resource string) (string, string, string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(resource, recordSetResourcePrefix), "/", 3)
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("invalid route53 record resource %q", resource)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
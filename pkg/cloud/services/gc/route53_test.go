@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	rgapi "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/route53/mock_route53iface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestReconcileDeleteRoute53Records(t *testing.T) {
+	txtRecord := func(name, owner string) *route53.ResourceRecordSet {
+		return &route53.ResourceRecordSet{
+			Name: aws.String(name),
+			Type: aws.String(route53.RRTypeTxt),
+			TTL:  aws.Int64(300),
+			ResourceRecords: []*route53.ResourceRecord{
+				{Value: aws.String(`"heritage=external-dns,external-dns/owner=` + owner + `,external-dns/resource=service/default/svc1"`)},
+			},
+		}
+	}
+	aRecord := func(name string) *route53.ResourceRecordSet {
+		return &route53.ResourceRecordSet{
+			Name: aws.String(name),
+			Type: aws.String(route53.RRTypeA),
+			AliasTarget: &route53.AliasTarget{
+				DNSName:      aws.String("lb-123.eu-west-2.elb.amazonaws.com."),
+				HostedZoneId: aws.String("ZHURV8PSTC4K8"),
+			},
+		}
+	}
+	recordSets := []*route53.ResourceRecordSet{
+		{Name: aws.String("example.com."), Type: aws.String(route53.RRTypeNs)},
+		aRecord("svc1.example.com."),
+		txtRecord("a-svc1.example.com.", "eks-test-cluster"),
+		txtRecord("svc1.example.com.", "eks-test-cluster"),
+		aRecord("svc2.example.com."),
+		txtRecord("a-svc2.example.com.", "other-cluster"),
+	}
+	mockListRecords := func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+		m.ListHostedZonesPagesWithContext(gomock.Any(), &route53.ListHostedZonesInput{}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *route53.ListHostedZonesInput, fn func(*route53.ListHostedZonesOutput, bool) bool, _ ...request.Option) error {
+				fn(&route53.ListHostedZonesOutput{
+					HostedZones: []*route53.HostedZone{{Id: aws.String("/hostedzone/Z123"), Name: aws.String("example.com.")}},
+				}, true)
+				return nil
+			})
+		m.ListResourceRecordSetsPagesWithContext(gomock.Any(), &route53.ListResourceRecordSetsInput{HostedZoneId: aws.String("Z123")}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool, _ ...request.Option) error {
+				fn(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: recordSets}, true)
+				return nil
+			})
+	}
+	mockDeleteRecord := func(m *mock_route53iface.MockRoute53APIMockRecorder, recordSet *route53.ResourceRecordSet, err error) *gomock.Call {
+		m.ListResourceRecordSetsPagesWithContext(gomock.Any(), &route53.ListResourceRecordSetsInput{
+			HostedZoneId:    aws.String("Z123"),
+			StartRecordName: recordSet.Name,
+			StartRecordType: recordSet.Type,
+		}, gomock.Any()).DoAndReturn(func(_ context.Context, _ *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool, _ ...request.Option) error {
+			fn(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []*route53.ResourceRecordSet{recordSet, aRecord("svc3.example.com.")}}, true)
+			return nil
+		})
+		return m.ChangeResourceRecordSetsWithContext(gomock.Any(), &route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: aws.String("Z123"),
+			ChangeBatch: &route53.ChangeBatch{
+				Changes: []*route53.Change{{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: recordSet}},
+			},
+		}).Return(&route53.ChangeResourceRecordSetsOutput{}, err)
+	}
+
+	testCases := []struct {
+		name         string
+		clusterScope cloud.ClusterScoper
+		route53Mocks func(m *mock_route53iface.MockRoute53APIMockRecorder)
+		expectErr    bool
+	}{
+		{
+			name:         "route53 records not requested",
+			clusterScope: createManageScope(t, "", "load-balancer"),
+			route53Mocks: func(m *mock_route53iface.MockRoute53APIMockRecorder) {},
+		},
+		{
+			name:         "records owned by the cluster are deleted before their TXT records",
+			clusterScope: createManageScope(t, "", "route53-record"),
+			route53Mocks: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				mockListRecords(m)
+				gomock.InOrder(
+					mockDeleteRecord(m, recordSets[1], nil),
+					mockDeleteRecord(m, recordSets[2], nil),
+					mockDeleteRecord(m, recordSets[3], nil),
+				)
+			},
+		},
+		{
+			name:         "record already deleted",
+			clusterScope: createManageScope(t, "", "route53-record"),
+			route53Mocks: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				mockListRecords(m)
+				m.ListResourceRecordSetsPagesWithContext(gomock.Any(), &route53.ListResourceRecordSetsInput{
+					HostedZoneId:    aws.String("Z123"),
+					StartRecordName: aws.String("svc1.example.com."),
+					StartRecordType: aws.String(route53.RRTypeA),
+				}, gomock.Any()).Return(nil)
+				mockDeleteRecord(m, recordSets[2], nil)
+				mockDeleteRecord(m, recordSets[3], nil)
+			},
+		},
+		{
+			name:         "record deletion failure",
+			clusterScope: createManageScope(t, "", "route53-record"),
+			route53Mocks: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				mockListRecords(m)
+				mockDeleteRecord(m, recordSets[1], errors.New("access denied"))
+			},
+			expectErr: true,
+		},
+		{
+			name:         "hosted zones listing failure",
+			clusterScope: createManageScope(t, "", "route53-record"),
+			route53Mocks: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListHostedZonesPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("access denied"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			rgapiMock := mocks.NewMockResourceGroupsTaggingAPIAPI(mockCtrl)
+			rgapiMock.EXPECT().GetResourcesWithContext(gomock.Any(), gomock.Any()).Return(&rgapi.GetResourcesOutput{}, nil).AnyTimes()
+			route53Mock := mock_route53iface.NewMockRoute53API(mockCtrl)
+			tc.route53Mocks(route53Mock.EXPECT())
+
+			wkSvc := NewService(tc.clusterScope,
+				withELBClient(mocks.NewMockELBAPI(mockCtrl)),
+				withELBv2Client(mocks.NewMockELBV2API(mockCtrl)),
+				withResourceTaggingClient(rgapiMock),
+				withEC2Client(mocks.NewMockEC2API(mockCtrl)),
+				withRoute53Client(route53Mock),
+				WithGCStrategy(false),
+			)
+			err := wkSvc.ReconcileDelete(context.TODO())
+
+			if tc.expectErr {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
@@ -37,6 +38,7 @@ type Service struct {
 	elbv2Client           elbv2iface.ELBV2API
 	resourceTaggingClient resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
 	ec2Client             ec2iface.EC2API
+	route53Client         route53iface.Route53API
 	cleanupFuncs          ResourceCleanupFuncs
 	collectFuncs          ResourceCollectFuncs
 }
@@ -49,6 +51,7 @@ func NewService(clusterScope cloud.ClusterScoper, opts ...ServiceOption) *Servic
 		elbv2Client:           scope.NewELBv2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
 		resourceTaggingClient: scope.NewResourgeTaggingClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
 		ec2Client:             scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		route53Client:         scope.NewRoute53Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
 		cleanupFuncs:          ResourceCleanupFuncs{},
 		collectFuncs:          ResourceCollectFuncs{},
 	}
@@ -72,6 +75,7 @@ func addDefaultCleanupFuncs(s *Service) {
 func addDefaultCollectFuncs(s *Service) {
 	s.collectFuncs = []ResourceCollectFunc{
 		s.defaultGetResources,
+		s.getExternalDNSRecords,
 	}
 }
 
@@ -81,6 +85,8 @@ func addAlternativeCollectFuncs(s *Service) {
 		s.getProviderOwnedLoadBalancersV2,
 		s.getProviderOwnedTargetgroups,
 		s.getProviderOwnedSecurityGroups,
+		s.getProviderOwnedVolumes,
+		s.getExternalDNSRecords,
 	}
 }
 
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iamauth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"k8s.io/klog/v2"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+)
+
+// reconcileNodeAccessEntries creates an EC2_LINUX access entry for the IAM role of each group of workers.
+// The access entries aren't tagged as owned by the cluster, so that they aren't deleted with the access
+// entries of the spec. EKS deletes them along with the cluster.
+func (s *Service) reconcileNodeAccessEntries(ctx context.Context) error {
+	s.scope.Info("Reconciling node access entries", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
+
+	nodeRoles, err := s.getRolesForWorkers(ctx)
+	if err != nil {
+		s.scope.Error(err, "getting roles for remote workers")
+		return fmt.Errorf("getting roles for remote workers: %w", err)
+	}
+	for roleName := range nodeRoles {
+		roleARN, err := s.getARNForRole(roleName)
+		if err != nil {
+			return fmt.Errorf("failed to get ARN for role %s: %w", roleName, err)
+		}
+		if err := s.ensureNodeAccessEntry(ctx, roleARN); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) ensureNodeAccessEntry(ctx context.Context, roleARN string) error {
+	_, err := s.EKSClient.DescribeAccessEntryWithContext(ctx, &eks.DescribeAccessEntryInput{
+		ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+		PrincipalArn: aws.String(roleARN),
+	})
+	if err == nil {
+		return nil
+	}
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != eks.ErrCodeResourceNotFoundException {
+		return fmt.Errorf("describing access entry for node role %s: %w", roleARN, err)
+	}
+
+	s.scope.Debug("Creating access entry for node IAM role", "iam-role", roleARN)
+	if _, err := s.EKSClient.CreateAccessEntryWithContext(ctx, &eks.CreateAccessEntryInput{
+		ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+		PrincipalArn: aws.String(roleARN),
+		Type:         aws.String(string(ekscontrolplanev1.AccessEntryTypeEC2Linux)),
+	}); err != nil {
+		return fmt.Errorf("creating access entry for node role %s: %w", roleARN, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iamauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestEnsureNodeAccessEntry(t *testing.T) {
+	roleARN := "arn:aws:iam::123456789012:role/nodes.cluster-api-provider-aws.sigs.k8s.io"
+
+	tests := []struct {
+		name        string
+		expect      func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "access entry exists",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeAccessEntryWithContext(gomock.Any(), gomock.Any()).Return(&eks.DescribeAccessEntryOutput{}, nil)
+			},
+		},
+		{
+			name: "access entry is created",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeAccessEntryWithContext(gomock.Any(), gomock.Any()).
+					Return(nil, awserr.New(eks.ErrCodeResourceNotFoundException, "", nil))
+				m.CreateAccessEntryWithContext(gomock.Any(), &eks.CreateAccessEntryInput{
+					ClusterName:  aws.String("default_eks"),
+					PrincipalArn: aws.String(roleARN),
+					Type:         aws.String("EC2_LINUX"),
+				}).Return(&eks.CreateAccessEntryOutput{}, nil)
+			},
+		},
+		{
+			name: "describe error",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeAccessEntryWithContext(gomock.Any(), gomock.Any()).
+					Return(nil, awserr.New(eks.ErrCodeServerException, "", nil))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+			tc.expect(eksMock.EXPECT())
+
+			managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: testEnv,
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName: "default_eks",
+						AccessConfig: &ekscontrolplanev1.AccessConfig{
+							AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI,
+						},
+					},
+				},
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "default",
+						Namespace: "default",
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			authService := NewService(managedScope, BackendTypeConfigMap, managedScope.Client)
+			authService.EKSClient = eksMock
+
+			err = authService.ensureNodeAccessEntry(context.TODO(), roleARN)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
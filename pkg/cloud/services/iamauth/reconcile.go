@@ -36,6 +36,11 @@ import (
 
 // ReconcileIAMAuthenticator is used to create the aws-iam-authenticator in a cluster.
 func (s *Service) ReconcileIAMAuthenticator(ctx context.Context) error {
+	if s.scope.AuthenticationMode() == ekscontrolplanev1.EKSAuthenticationModeAPI {
+		// the aws-auth config map isn't used by the cluster, the nodes are authorized by access entries
+		return s.reconcileNodeAccessEntries(ctx)
+	}
+
 	s.scope.Info("Reconciling aws-iam-authenticator configuration", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
 
 	remoteClient, err := s.scope.RemoteClient()
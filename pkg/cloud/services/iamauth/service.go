@@ -18,6 +18,7 @@ limitations under the License.
 package iamauth
 
 import (
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -30,6 +31,7 @@ type Service struct {
 	backend   BackendType
 	client    client.Client
 	IAMClient iamiface.IAMAPI
+	EKSClient eksiface.EKSAPI
 }
 
 // NewService will create a new Service object.
@@ -39,5 +41,6 @@ func NewService(iamScope scope.IAMAuthScope, backend BackendType, client client.
 		backend:   backend,
 		client:    client,
 		IAMClient: scope.NewIAMClient(iamScope, iamScope, iamScope, iamScope.InfraCluster()),
+		EKSClient: scope.NewEKSClient(iamScope, iamScope, iamScope, iamScope.InfraCluster()),
 	}
 }
@@ -25,8 +25,17 @@ func (s Service) ReconcileEC2Events() error {
 	return s.reconcileRules()
 }
 
+// ReconcileSpotInterruptionEvents will forward the EC2 Spot interruption warnings to the Service's queue.
+// The queue is expected to be reconciled by ReconcileEC2Events first.
+func (s Service) ReconcileSpotInterruptionEvents() error {
+	return s.reconcileSpotInterruptionRule()
+}
+
 // DeleteEC2Events will delete a Service's EC2 events.
 func (s Service) DeleteEC2Events() error {
+	if err := s.deleteSpotInterruptionRule(); err != nil {
+		return err
+	}
 	if err := s.deleteRules(); err != nil {
 		return err
 	}
@@ -82,6 +82,18 @@ func (s *Service) createPolicyForRule(input *createPolicyForRuleInput) error {
 			},
 		},
 	}
+	if input.SpotInterruptionRuleArn != "" {
+		policy.Statement = append(policy.Statement, iamv1.StatementEntry{
+			Sid:       fmt.Sprintf("CAPAEvents_%s_%s", s.getSpotInterruptionRuleName(), GenerateQueueName(s.scope.Name())),
+			Effect:    iamv1.EffectAllow,
+			Principal: iamv1.Principals{iamv1.PrincipalService: iamv1.PrincipalID{"events.amazonaws.com"}},
+			Action:    iamv1.Actions{"sqs:SendMessage"},
+			Resource:  iamv1.Resources{input.QueueArn},
+			Condition: iamv1.Conditions{
+				"ArnEquals": map[string]string{"aws:SourceArn": input.SpotInterruptionRuleArn},
+			},
+		})
+	}
 	policyData, err := json.Marshal(policy)
 	if err != nil {
 		return errors.Wrap(err, "unable to JSON marshal policy")
@@ -115,4 +127,6 @@ type createPolicyForRuleInput struct {
 	QueueArn string
 	QueueURL string
 	RuleArn  string
+	// SpotInterruptionRuleArn is the ARN of the rule forwarding the Spot interruption warnings, if any.
+	SpotInterruptionRuleArn string
 }
@@ -216,6 +216,26 @@ func TestCreatePolicyForRule(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "creates a policy for the given rule and the Spot interruption rule",
+			input: &createPolicyForRuleInput{
+				QueueArn:                "test-cluster-queue-arn",
+				QueueURL:                "test-cluster-queue-url",
+				RuleArn:                 "test-cluster-rule-arn",
+				SpotInterruptionRuleArn: "test-cluster-spot-interruption-rule-arn",
+			},
+			expect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				buffer := new(bytes.Buffer)
+				_ = json.Compact(buffer, []byte(expectedSpotInterruptionPolicyJSON))
+				attrs := make(map[string]string)
+				attrs[sqs.QueueAttributeNamePolicy] = buffer.String()
+				m.SetQueueAttributes(&sqs.SetQueueAttributesInput{
+					QueueUrl:   aws.String("test-cluster-queue-url"),
+					Attributes: aws.StringMap(attrs),
+				}).Return(nil, nil)
+			},
+			expectErr: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -290,3 +310,50 @@ const expectedPolicyJSON = `{
   ],
   "Id": "test-cluster-queue-arn"
 }`
+
+const expectedSpotInterruptionPolicyJSON = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "CAPAEvents_test-cluster-ec2-rule_test-cluster-queue",
+      "Principal": {
+        "Service": [
+          "events.amazonaws.com"
+        ]
+      },
+      "Effect": "Allow",
+      "Action": [
+        "sqs:SendMessage"
+      ],
+      "Resource": [
+        "test-cluster-queue-arn"
+      ],
+      "Condition": {
+        "ArnEquals": {
+          "aws:SourceArn": "test-cluster-rule-arn"
+        }
+      }
+    },
+    {
+      "Sid": "CAPAEvents_test-cluster-spot-interruption-rule_test-cluster-queue",
+      "Principal": {
+        "Service": [
+          "events.amazonaws.com"
+        ]
+      },
+      "Effect": "Allow",
+      "Action": [
+        "sqs:SendMessage"
+      ],
+      "Resource": [
+        "test-cluster-queue-arn"
+      ],
+      "Condition": {
+        "ArnEquals": {
+          "aws:SourceArn": "test-cluster-spot-interruption-rule-arn"
+        }
+      }
+    }
+  ],
+  "Id": "test-cluster-queue-arn"
+}`
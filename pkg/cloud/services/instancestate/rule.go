@@ -19,6 +19,7 @@ package instancestate
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -32,6 +33,9 @@ import (
 // Ec2StateChangeNotification defines the EC2 instance's state change notification.
 const Ec2StateChangeNotification = "EC2 Instance State-change Notification"
 
+// Ec2SpotInterruptionWarning defines the EC2 Spot Instance's interruption warning.
+const Ec2SpotInterruptionWarning = "EC2 Spot Instance Interruption Warning"
+
 // reconcileRules creates rules and attaches the queue as a target.
 func (s Service) reconcileRules() error {
 	var ruleNotFound bool
@@ -144,6 +148,94 @@ func (s Service) createRule() error {
 	return err
 }
 
+// reconcileSpotInterruptionRule creates the rule forwarding the Spot interruption warnings to the queue, and
+// authorizes it to emit messages to the queue.
+func (s Service) reconcileSpotInterruptionRule() error {
+	eventPattern := eventPattern{
+		Source:     []string{"aws.ec2"},
+		DetailType: []string{Ec2SpotInterruptionWarning},
+	}
+	data, err := json.Marshal(eventPattern)
+	if err != nil {
+		return err
+	}
+	// the warnings are not filtered by instance: the rule would otherwise have to be updated whenever a Spot
+	// instance of a machine pool is launched. Warnings of instances which aren't backing a machine are ignored.
+	ruleResp, err := s.EventBridgeClient.PutRule(&eventbridge.PutRuleInput{
+		Name:         aws.String(s.getSpotInterruptionRuleName()),
+		EventPattern: aws.String(string(data)),
+		State:        aws.String(eventbridge.RuleStateEnabled),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to put rule %s", s.getSpotInterruptionRuleName())
+	}
+
+	queueURLResp, err := s.SQSClient.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName: aws.String(GenerateQueueName(s.scope.Name())),
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to get queue URL")
+	}
+	queueAttrs, err := s.SQSClient.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		AttributeNames: aws.StringSlice([]string{sqs.QueueAttributeNameQueueArn, sqs.QueueAttributeNamePolicy}),
+		QueueUrl:       queueURLResp.QueueUrl,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to get queue attributes")
+	}
+
+	// PutTargets is idempotent for a target with the same id.
+	_, err = s.EventBridgeClient.PutTargets(&eventbridge.PutTargetsInput{
+		Rule: aws.String(s.getSpotInterruptionRuleName()),
+		Targets: []*eventbridge.Target{{
+			Arn: queueAttrs.Attributes[sqs.QueueAttributeNameQueueArn],
+			Id:  aws.String(GenerateQueueName(s.scope.Name())),
+		}},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to add SQS target %s to rule %s", GenerateQueueName(s.scope.Name()), s.getSpotInterruptionRuleName())
+	}
+
+	policy := aws.StringValue(queueAttrs.Attributes[sqs.QueueAttributeNamePolicy])
+	if strings.Contains(policy, aws.StringValue(ruleResp.RuleArn)) {
+		return nil
+	}
+
+	// the queue policy is replaced, so it has to authorize the state change rule as well
+	ec2RuleResp, err := s.EventBridgeClient.DescribeRule(&eventbridge.DescribeRuleInput{
+		Name: aws.String(s.getEC2RuleName()),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to describe rule %s", s.getEC2RuleName())
+	}
+
+	return s.createPolicyForRule(&createPolicyForRuleInput{
+		QueueArn:                aws.StringValue(queueAttrs.Attributes[sqs.QueueAttributeNameQueueArn]),
+		QueueURL:                aws.StringValue(queueURLResp.QueueUrl),
+		RuleArn:                 aws.StringValue(ec2RuleResp.Arn),
+		SpotInterruptionRuleArn: aws.StringValue(ruleResp.RuleArn),
+	})
+}
+
+func (s Service) deleteSpotInterruptionRule() error {
+	_, err := s.EventBridgeClient.RemoveTargets(&eventbridge.RemoveTargetsInput{
+		Rule: aws.String(s.getSpotInterruptionRuleName()),
+		Ids:  aws.StringSlice([]string{GenerateQueueName(s.scope.Name())}),
+	})
+	if err != nil && !resourceNotFoundError(err) {
+		return errors.Wrapf(err, "unable to remove target %s for rule %s", GenerateQueueName(s.scope.Name()), s.getSpotInterruptionRuleName())
+	}
+	_, err = s.EventBridgeClient.DeleteRule(&eventbridge.DeleteRuleInput{
+		Name: aws.String(s.getSpotInterruptionRuleName()),
+	})
+
+	if err != nil && resourceNotFoundError(err) {
+		return nil
+	}
+
+	return err
+}
+
 func (s Service) deleteRules() error {
 	_, err := s.EventBridgeClient.RemoveTargets(&eventbridge.RemoveTargetsInput{
 		Rule: aws.String(s.getEC2RuleName()),
@@ -245,6 +337,10 @@ func (s Service) getEC2RuleName() string {
 	return fmt.Sprintf("%s-ec2-rule", s.scope.Name())
 }
 
+func (s Service) getSpotInterruptionRuleName() string {
+	return fmt.Sprintf("%s-spot-interruption-rule", s.scope.Name())
+}
+
 func resourceNotFoundError(err error) bool {
 	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eventbridge.ErrCodeResourceNotFoundException {
 		return true
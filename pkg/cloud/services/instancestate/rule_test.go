@@ -18,6 +18,7 @@ package instancestate
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -233,6 +234,174 @@ func TestDeleteRules(t *testing.T) {
 	}
 }
 
+func TestReconcileSpotInterruptionRule(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ruleName := "test-cluster-spot-interruption-rule"
+
+	testCases := []struct {
+		name              string
+		eventBridgeExpect func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder)
+		sqsExpect         func(m *mock_sqsiface.MockSQSAPIMockRecorder)
+		expectErr         bool
+	}{
+		{
+			name: "creates rule and target, and authorizes both rules in the queue policy",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				e := &eventPattern{
+					Source:     []string{"aws.ec2"},
+					DetailType: []string{Ec2SpotInterruptionWarning},
+				}
+				data, err := json.Marshal(e)
+				if err != nil {
+					t.Fatalf("got an unexpected error: %v", err)
+				}
+				m.PutRule(gomock.Eq(&eventbridge.PutRuleInput{
+					Name:         aws.String(ruleName),
+					State:        aws.String(eventbridge.RuleStateEnabled),
+					EventPattern: aws.String(string(data)),
+				})).Return(&eventbridge.PutRuleOutput{RuleArn: aws.String("spot-rule-arn")}, nil)
+				m.PutTargets(gomock.Eq(&eventbridge.PutTargetsInput{
+					Rule: aws.String(ruleName),
+					Targets: []*eventbridge.Target{{
+						Arn: aws.String("test-cluster-queue-arn"),
+						Id:  aws.String("test-cluster-queue"),
+					}},
+				})).Return(&eventbridge.PutTargetsOutput{}, nil)
+				m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+					Name: aws.String("test-cluster-ec2-rule"),
+				})).Return(&eventbridge.DescribeRuleOutput{Name: aws.String("test-cluster-ec2-rule"), Arn: aws.String("rule-arn")}, nil)
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.Eq(&sqs.GetQueueUrlInput{
+					QueueName: aws.String("test-cluster-queue"),
+				})).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("test-cluster-queue-url")}, nil)
+				attrs := make(map[string]string)
+				attrs[sqs.QueueAttributeNameQueueArn] = "test-cluster-queue-arn"
+				attrs[sqs.QueueAttributeNamePolicy] = "policy authorizing rule-arn"
+				m.GetQueueAttributes(gomock.AssignableToTypeOf(&sqs.GetQueueAttributesInput{})).Return(&sqs.GetQueueAttributesOutput{Attributes: aws.StringMap(attrs)}, nil)
+				m.SetQueueAttributes(gomock.AssignableToTypeOf(&sqs.SetQueueAttributesInput{})).DoAndReturn(func(input *sqs.SetQueueAttributesInput) (*sqs.SetQueueAttributesOutput, error) {
+					policy := aws.StringValue(input.Attributes[sqs.QueueAttributeNamePolicy])
+					if !strings.Contains(policy, `"rule-arn"`) || !strings.Contains(policy, `"spot-rule-arn"`) {
+						t.Fatalf("queue policy doesn't authorize both rules: %s", policy)
+					}
+					return nil, nil
+				})
+			},
+			expectErr: false,
+		},
+		{
+			name: "skips updating the queue policy if it already authorizes the rule",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.PutRule(gomock.AssignableToTypeOf(&eventbridge.PutRuleInput{})).Return(&eventbridge.PutRuleOutput{RuleArn: aws.String("spot-rule-arn")}, nil)
+				m.PutTargets(gomock.AssignableToTypeOf(&eventbridge.PutTargetsInput{})).Return(&eventbridge.PutTargetsOutput{}, nil)
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.AssignableToTypeOf(&sqs.GetQueueUrlInput{})).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("test-cluster-queue-url")}, nil)
+				attrs := make(map[string]string)
+				attrs[sqs.QueueAttributeNameQueueArn] = "test-cluster-queue-arn"
+				attrs[sqs.QueueAttributeNamePolicy] = "policy authorizing rule-arn and spot-rule-arn"
+				m.GetQueueAttributes(gomock.AssignableToTypeOf(&sqs.GetQueueAttributesInput{})).Return(&sqs.GetQueueAttributesOutput{Attributes: aws.StringMap(attrs)}, nil)
+			},
+			expectErr: false,
+		},
+		{
+			name: "returns error if PutRule runs into unexpected error",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.PutRule(gomock.AssignableToTypeOf(&eventbridge.PutRuleInput{})).Return(nil, errors.New("some error"))
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			eventbridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+			sqsMock := mock_sqsiface.NewMockSQSAPI(mockCtrl)
+			clusterScope, err := setupCluster("test-cluster")
+			g.Expect(err).To(Not(HaveOccurred()))
+			tc.sqsExpect(sqsMock.EXPECT())
+			tc.eventBridgeExpect(eventbridgeMock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EventBridgeClient = eventbridgeMock
+			s.SQSClient = sqsMock
+
+			err = s.reconcileSpotInterruptionRule()
+			if tc.expectErr {
+				g.Expect(err).NotTo(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestDeleteSpotInterruptionRule(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name              string
+		eventBridgeExpect func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder)
+		expectErr         bool
+	}{
+		{
+			name: "removes target and rule successfully when they both exist",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.RemoveTargets(gomock.Eq(&eventbridge.RemoveTargetsInput{
+					Rule: aws.String("test-cluster-spot-interruption-rule"),
+					Ids:  aws.StringSlice([]string{"test-cluster-queue"}),
+				})).Return(nil, nil)
+				m.DeleteRule(gomock.Eq(&eventbridge.DeleteRuleInput{
+					Name: aws.String("test-cluster-spot-interruption-rule"),
+				})).Return(nil, nil)
+			},
+			expectErr: false,
+		},
+		{
+			name: "succeeds when the rule was never created",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.RemoveTargets(gomock.AssignableToTypeOf(&eventbridge.RemoveTargetsInput{})).
+					Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil))
+				m.DeleteRule(gomock.AssignableToTypeOf(&eventbridge.DeleteRuleInput{})).
+					Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil))
+			},
+			expectErr: false,
+		},
+		{
+			name: "returns error when delete rule fails unexpectedly",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.RemoveTargets(gomock.AssignableToTypeOf(&eventbridge.RemoveTargetsInput{})).Return(nil, nil)
+				m.DeleteRule(gomock.AssignableToTypeOf(&eventbridge.DeleteRuleInput{})).Return(nil, errors.New("some error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			eventbridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+			clusterScope, err := setupCluster("test-cluster")
+			g.Expect(err).To(Not(HaveOccurred()))
+			tc.eventBridgeExpect(eventbridgeMock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EventBridgeClient = eventbridgeMock
+
+			err = s.deleteSpotInterruptionRule()
+			if tc.expectErr {
+				g.Expect(err).NotTo(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
 func TestAddInstanceToRule(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
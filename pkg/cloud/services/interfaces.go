@@ -19,6 +19,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
 
@@ -45,11 +46,27 @@ type ASGInterface interface {
 	UpdateASG(scope *scope.MachinePoolScope) error
 	StartASGInstanceRefresh(scope *scope.MachinePoolScope) error
 	CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (bool, error)
+	UpdateInstanceRefreshStatus(scope *scope.MachinePoolScope) error
 	UpdateResourceTags(resourceID *string, create, remove map[string]string) error
 	DeleteASGAndWait(id string) error
 	SuspendProcesses(name string, processes []string) error
 	ResumeProcesses(name string, processes []string) error
+	PutWarmPool(name string, warmPool *expinfrav1.WarmPool) error
+	DeleteWarmPool(name string) error
+	SetInstanceProtection(name string, instanceIDs []string, protected bool) error
+	GetInstanceLaunchTimes(instanceIDs []string) (map[string]time.Time, error)
+	ReplaceInstance(instanceID string) error
+	EnableMetricsCollection(name, granularity string, metrics []string) error
+	DisableMetricsCollection(name string, metrics []string) error
+	AttachLoadBalancerTargetGroups(name string, targetGroupARNs []string) error
+	DetachLoadBalancerTargetGroups(name string, targetGroupARNs []string) error
+	AttachLoadBalancers(name string, loadBalancerNames []string) error
+	DetachLoadBalancers(name string, loadBalancerNames []string) error
 	SubnetIDs(scope *scope.MachinePoolScope) ([]string, error)
+	ReconcileTerminationLifecycleHook(scope *scope.MachinePoolScope) error
+	DeleteTerminationLifecycleHook(scope *scope.MachinePoolScope) error
+	GetTerminationLifecycleActions(scope *scope.MachinePoolScope) ([]expinfrav1.LifecycleAction, error)
+	CompleteTerminationLifecycleAction(action expinfrav1.LifecycleAction) error
 }
 
 // EC2Interface encapsulates the methods exposed to the machine
@@ -57,15 +74,24 @@ type ASGInterface interface {
 type EC2Interface interface {
 	InstanceIfExists(id *string) (*infrav1.Instance, error)
 	TerminateInstance(id string) error
+	DisableTerminationProtection(instanceID string) error
+	CancelSpotInstanceRequests(instanceID string) error
+	HibernateInstance(instanceID string) error
+	StartInstance(instanceID string) error
 	CreateInstance(scope *scope.MachineScope, userData []byte, userDataFormat string) (*infrav1.Instance, error)
 	GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.Instance, error)
 
 	GetAdditionalSecurityGroupsIDs(securityGroup []infrav1.AWSResourceReference) ([]string, error)
+	GetSecurityGroupSelectorsIDs(selectors []infrav1.SecurityGroupSelector) ([]string, error)
 	GetCoreSecurityGroups(machine *scope.MachineScope) ([]string, error)
 	GetInstanceSecurityGroups(instanceID string) (map[string][]string, error)
 	UpdateInstanceSecurityGroups(id string, securityGroups []string) error
+	UpdateInstanceSourceDestCheck(id string, enabled bool) error
 	UpdateResourceTags(resourceID *string, create, remove map[string]string) error
 	ModifyInstanceMetadataOptions(instanceID string, options *infrav1.InstanceMetadataOptions) error
+	GetConsoleOutput(instanceID string) (string, error)
+	GetConsoleScreenshot(instanceID string) ([]byte, error)
+	GetAMIDeprecationTime(imageID string) (*time.Time, error)
 
 	TerminateInstanceAndWait(instanceID string) error
 	DetachSecurityGroupsFromNetworkInterface(groups []string, interfaceID string) error
@@ -76,11 +102,19 @@ type EC2Interface interface {
 	GetLaunchTemplateLatestVersion(id string) (string, error)
 	CreateLaunchTemplate(scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) (string, error)
 	CreateLaunchTemplateVersion(id string, scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) error
-	PruneLaunchTemplateVersions(id string) error
+	PruneLaunchTemplateVersions(id string, versionsToKeep int64) error
 	DeleteLaunchTemplate(id string) error
 	LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) (bool, error)
+
+	GetFleet(id string) (*expinfrav1.Fleet, error)
+	GetFleetInstances(launchTemplateID string) ([]infrav1.Instance, error)
+	CreateFleet(scope *scope.MachinePoolScope, subnetIDs []string, totalCapacity, onDemandCapacity int64) (string, []string, error)
+	ModifyFleetTargetCapacity(id string, totalCapacity, onDemandCapacity int64) error
+	DeleteFleet(id string) error
+
 	DeleteBastion() error
 	ReconcileBastion() error
+	DeletePlacementGroups() error
 	// ReconcileElasticIPFromPublicPool reconciles the elastic IP from a custom Public IPv4 Pool.
 	ReconcileElasticIPFromPublicPool(pool *infrav1.ElasticIPPool, instance *infrav1.Instance) error
 
@@ -131,6 +165,14 @@ type SecurityGroupInterface interface {
 	ReconcileSecurityGroups() error
 }
 
+// DNSInterface encapsulates the methods exposed to the cluster controller
+// to publish the control plane endpoint.
+type DNSInterface interface {
+	DeleteControlPlaneDNS() error
+	ReconcileControlPlaneDNS() error
+	RecordName() string
+}
+
 // ObjectStoreInterface encapsulates the methods exposed to the machine actuator.
 type ObjectStoreInterface interface {
 	DeleteBucket() error
@@ -139,6 +181,13 @@ type ObjectStoreInterface interface {
 	Create(m *scope.MachineScope, data []byte) (objectURL string, err error)
 }
 
+// EKSInterface encapsulates the methods exposed to the managed control plane
+// controller.
+type EKSInterface interface {
+	ReconcileControlPlane(ctx context.Context) error
+	DeleteControlPlane() error
+}
+
 // AWSNodeInterface installs the CNI for EKS clusters.
 type AWSNodeInterface interface {
 	ReconcileCNI(ctx context.Context) error
@@ -22,6 +22,7 @@ package mock_services
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	v1beta2 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
@@ -66,6 +67,34 @@ func (mr *MockASGInterfaceMockRecorder) ASGIfExists(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ASGIfExists", reflect.TypeOf((*MockASGInterface)(nil).ASGIfExists), arg0)
 }
 
+// AttachLoadBalancerTargetGroups mocks base method.
+func (m *MockASGInterface) AttachLoadBalancerTargetGroups(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachLoadBalancerTargetGroups", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachLoadBalancerTargetGroups indicates an expected call of AttachLoadBalancerTargetGroups.
+func (mr *MockASGInterfaceMockRecorder) AttachLoadBalancerTargetGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachLoadBalancerTargetGroups", reflect.TypeOf((*MockASGInterface)(nil).AttachLoadBalancerTargetGroups), arg0, arg1)
+}
+
+// AttachLoadBalancers mocks base method.
+func (m *MockASGInterface) AttachLoadBalancers(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachLoadBalancers", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachLoadBalancers indicates an expected call of AttachLoadBalancers.
+func (mr *MockASGInterfaceMockRecorder) AttachLoadBalancers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachLoadBalancers", reflect.TypeOf((*MockASGInterface)(nil).AttachLoadBalancers), arg0, arg1)
+}
+
 // CanStartASGInstanceRefresh mocks base method.
 func (m *MockASGInterface) CanStartASGInstanceRefresh(arg0 *scope.MachinePoolScope) (bool, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +110,20 @@ func (mr *MockASGInterfaceMockRecorder) CanStartASGInstanceRefresh(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanStartASGInstanceRefresh", reflect.TypeOf((*MockASGInterface)(nil).CanStartASGInstanceRefresh), arg0)
 }
 
+// CompleteTerminationLifecycleAction mocks base method.
+func (m *MockASGInterface) CompleteTerminationLifecycleAction(arg0 v1beta2.LifecycleAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteTerminationLifecycleAction", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteTerminationLifecycleAction indicates an expected call of CompleteTerminationLifecycleAction.
+func (mr *MockASGInterfaceMockRecorder) CompleteTerminationLifecycleAction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteTerminationLifecycleAction", reflect.TypeOf((*MockASGInterface)(nil).CompleteTerminationLifecycleAction), arg0)
+}
+
 // CreateASG mocks base method.
 func (m *MockASGInterface) CreateASG(arg0 *scope.MachinePoolScope) (*v1beta2.AutoScalingGroup, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +153,90 @@ func (mr *MockASGInterfaceMockRecorder) DeleteASGAndWait(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteASGAndWait", reflect.TypeOf((*MockASGInterface)(nil).DeleteASGAndWait), arg0)
 }
 
+// DeleteTerminationLifecycleHook mocks base method.
+func (m *MockASGInterface) DeleteTerminationLifecycleHook(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTerminationLifecycleHook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTerminationLifecycleHook indicates an expected call of DeleteTerminationLifecycleHook.
+func (mr *MockASGInterfaceMockRecorder) DeleteTerminationLifecycleHook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTerminationLifecycleHook", reflect.TypeOf((*MockASGInterface)(nil).DeleteTerminationLifecycleHook), arg0)
+}
+
+// DeleteWarmPool mocks base method.
+func (m *MockASGInterface) DeleteWarmPool(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWarmPool", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWarmPool indicates an expected call of DeleteWarmPool.
+func (mr *MockASGInterfaceMockRecorder) DeleteWarmPool(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWarmPool", reflect.TypeOf((*MockASGInterface)(nil).DeleteWarmPool), arg0)
+}
+
+// DetachLoadBalancerTargetGroups mocks base method.
+func (m *MockASGInterface) DetachLoadBalancerTargetGroups(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachLoadBalancerTargetGroups", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachLoadBalancerTargetGroups indicates an expected call of DetachLoadBalancerTargetGroups.
+func (mr *MockASGInterfaceMockRecorder) DetachLoadBalancerTargetGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachLoadBalancerTargetGroups", reflect.TypeOf((*MockASGInterface)(nil).DetachLoadBalancerTargetGroups), arg0, arg1)
+}
+
+// DetachLoadBalancers mocks base method.
+func (m *MockASGInterface) DetachLoadBalancers(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachLoadBalancers", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachLoadBalancers indicates an expected call of DetachLoadBalancers.
+func (mr *MockASGInterfaceMockRecorder) DetachLoadBalancers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachLoadBalancers", reflect.TypeOf((*MockASGInterface)(nil).DetachLoadBalancers), arg0, arg1)
+}
+
+// DisableMetricsCollection mocks base method.
+func (m *MockASGInterface) DisableMetricsCollection(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMetricsCollection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMetricsCollection indicates an expected call of DisableMetricsCollection.
+func (mr *MockASGInterfaceMockRecorder) DisableMetricsCollection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMetricsCollection", reflect.TypeOf((*MockASGInterface)(nil).DisableMetricsCollection), arg0, arg1)
+}
+
+// EnableMetricsCollection mocks base method.
+func (m *MockASGInterface) EnableMetricsCollection(arg0, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMetricsCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableMetricsCollection indicates an expected call of EnableMetricsCollection.
+func (mr *MockASGInterfaceMockRecorder) EnableMetricsCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMetricsCollection", reflect.TypeOf((*MockASGInterface)(nil).EnableMetricsCollection), arg0, arg1, arg2)
+}
+
 // GetASGByName mocks base method.
 func (m *MockASGInterface) GetASGByName(arg0 *scope.MachinePoolScope) (*v1beta2.AutoScalingGroup, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +252,78 @@ func (mr *MockASGInterfaceMockRecorder) GetASGByName(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetASGByName", reflect.TypeOf((*MockASGInterface)(nil).GetASGByName), arg0)
 }
 
+// GetInstanceLaunchTimes mocks base method.
+func (m *MockASGInterface) GetInstanceLaunchTimes(arg0 []string) (map[string]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceLaunchTimes", arg0)
+	ret0, _ := ret[0].(map[string]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstanceLaunchTimes indicates an expected call of GetInstanceLaunchTimes.
+func (mr *MockASGInterfaceMockRecorder) GetInstanceLaunchTimes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceLaunchTimes", reflect.TypeOf((*MockASGInterface)(nil).GetInstanceLaunchTimes), arg0)
+}
+
+// GetTerminationLifecycleActions mocks base method.
+func (m *MockASGInterface) GetTerminationLifecycleActions(arg0 *scope.MachinePoolScope) ([]v1beta2.LifecycleAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTerminationLifecycleActions", arg0)
+	ret0, _ := ret[0].([]v1beta2.LifecycleAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTerminationLifecycleActions indicates an expected call of GetTerminationLifecycleActions.
+func (mr *MockASGInterfaceMockRecorder) GetTerminationLifecycleActions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTerminationLifecycleActions", reflect.TypeOf((*MockASGInterface)(nil).GetTerminationLifecycleActions), arg0)
+}
+
+// PutWarmPool mocks base method.
+func (m *MockASGInterface) PutWarmPool(arg0 string, arg1 *v1beta2.WarmPool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutWarmPool", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutWarmPool indicates an expected call of PutWarmPool.
+func (mr *MockASGInterfaceMockRecorder) PutWarmPool(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutWarmPool", reflect.TypeOf((*MockASGInterface)(nil).PutWarmPool), arg0, arg1)
+}
+
+// ReconcileTerminationLifecycleHook mocks base method.
+func (m *MockASGInterface) ReconcileTerminationLifecycleHook(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileTerminationLifecycleHook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileTerminationLifecycleHook indicates an expected call of ReconcileTerminationLifecycleHook.
+func (mr *MockASGInterfaceMockRecorder) ReconcileTerminationLifecycleHook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileTerminationLifecycleHook", reflect.TypeOf((*MockASGInterface)(nil).ReconcileTerminationLifecycleHook), arg0)
+}
+
+// ReplaceInstance mocks base method.
+func (m *MockASGInterface) ReplaceInstance(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceInstance", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceInstance indicates an expected call of ReplaceInstance.
+func (mr *MockASGInterfaceMockRecorder) ReplaceInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceInstance", reflect.TypeOf((*MockASGInterface)(nil).ReplaceInstance), arg0)
+}
+
 // ResumeProcesses mocks base method.
 func (m *MockASGInterface) ResumeProcesses(arg0 string, arg1 []string) error {
 	m.ctrl.T.Helper()
@@ -139,6 +338,20 @@ func (mr *MockASGInterfaceMockRecorder) ResumeProcesses(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeProcesses", reflect.TypeOf((*MockASGInterface)(nil).ResumeProcesses), arg0, arg1)
 }
 
+// SetInstanceProtection mocks base method.
+func (m *MockASGInterface) SetInstanceProtection(arg0 string, arg1 []string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetInstanceProtection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetInstanceProtection indicates an expected call of SetInstanceProtection.
+func (mr *MockASGInterfaceMockRecorder) SetInstanceProtection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInstanceProtection", reflect.TypeOf((*MockASGInterface)(nil).SetInstanceProtection), arg0, arg1, arg2)
+}
+
 // StartASGInstanceRefresh mocks base method.
 func (m *MockASGInterface) StartASGInstanceRefresh(arg0 *scope.MachinePoolScope) error {
 	m.ctrl.T.Helper()
@@ -196,6 +409,20 @@ func (mr *MockASGInterfaceMockRecorder) UpdateASG(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateASG", reflect.TypeOf((*MockASGInterface)(nil).UpdateASG), arg0)
 }
 
+// UpdateInstanceRefreshStatus mocks base method.
+func (m *MockASGInterface) UpdateInstanceRefreshStatus(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstanceRefreshStatus", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInstanceRefreshStatus indicates an expected call of UpdateInstanceRefreshStatus.
+func (mr *MockASGInterfaceMockRecorder) UpdateInstanceRefreshStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceRefreshStatus", reflect.TypeOf((*MockASGInterface)(nil).UpdateInstanceRefreshStatus), arg0)
+}
+
 // UpdateResourceTags mocks base method.
 func (m *MockASGInterface) UpdateResourceTags(arg0 *string, arg1, arg2 map[string]string) error {
 	m.ctrl.T.Helper()
@@ -22,6 +22,7 @@ package mock_services
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	types "k8s.io/apimachinery/pkg/types"
@@ -53,6 +54,36 @@ func (m *MockEC2Interface) EXPECT() *MockEC2InterfaceMockRecorder {
 	return m.recorder
 }
 
+// CancelSpotInstanceRequests mocks base method.
+func (m *MockEC2Interface) CancelSpotInstanceRequests(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSpotInstanceRequests", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSpotInstanceRequests indicates an expected call of CancelSpotInstanceRequests.
+func (mr *MockEC2InterfaceMockRecorder) CancelSpotInstanceRequests(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSpotInstanceRequests", reflect.TypeOf((*MockEC2Interface)(nil).CancelSpotInstanceRequests), arg0)
+}
+
+// CreateFleet mocks base method.
+func (m *MockEC2Interface) CreateFleet(arg0 *scope.MachinePoolScope, arg1 []string, arg2 int64, arg3 int64) (string, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFleet", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateFleet indicates an expected call of CreateFleet.
+func (mr *MockEC2InterfaceMockRecorder) CreateFleet(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFleet", reflect.TypeOf((*MockEC2Interface)(nil).CreateFleet), arg0, arg1, arg2, arg3)
+}
+
 // CreateInstance mocks base method.
 func (m *MockEC2Interface) CreateInstance(arg0 *scope.MachineScope, arg1 []byte, arg2 string) (*v1beta2.Instance, error) {
 	m.ctrl.T.Helper()
@@ -111,6 +142,20 @@ func (mr *MockEC2InterfaceMockRecorder) DeleteBastion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBastion", reflect.TypeOf((*MockEC2Interface)(nil).DeleteBastion))
 }
 
+// DeleteFleet mocks base method.
+func (m *MockEC2Interface) DeleteFleet(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFleet", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFleet indicates an expected call of DeleteFleet.
+func (mr *MockEC2InterfaceMockRecorder) DeleteFleet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFleet", reflect.TypeOf((*MockEC2Interface)(nil).DeleteFleet), arg0)
+}
+
 // DeleteLaunchTemplate mocks base method.
 func (m *MockEC2Interface) DeleteLaunchTemplate(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -125,6 +170,20 @@ func (mr *MockEC2InterfaceMockRecorder) DeleteLaunchTemplate(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLaunchTemplate", reflect.TypeOf((*MockEC2Interface)(nil).DeleteLaunchTemplate), arg0)
 }
 
+// DeletePlacementGroups mocks base method.
+func (m *MockEC2Interface) DeletePlacementGroups() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlacementGroups")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlacementGroups indicates an expected call of DeletePlacementGroups.
+func (mr *MockEC2InterfaceMockRecorder) DeletePlacementGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlacementGroups", reflect.TypeOf((*MockEC2Interface)(nil).DeletePlacementGroups))
+}
+
 // DetachSecurityGroupsFromNetworkInterface mocks base method.
 func (m *MockEC2Interface) DetachSecurityGroupsFromNetworkInterface(arg0 []string, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -139,6 +198,20 @@ func (mr *MockEC2InterfaceMockRecorder) DetachSecurityGroupsFromNetworkInterface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachSecurityGroupsFromNetworkInterface", reflect.TypeOf((*MockEC2Interface)(nil).DetachSecurityGroupsFromNetworkInterface), arg0, arg1)
 }
 
+// DisableTerminationProtection mocks base method.
+func (m *MockEC2Interface) DisableTerminationProtection(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTerminationProtection", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTerminationProtection indicates an expected call of DisableTerminationProtection.
+func (mr *MockEC2InterfaceMockRecorder) DisableTerminationProtection(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTerminationProtection", reflect.TypeOf((*MockEC2Interface)(nil).DisableTerminationProtection), arg0)
+}
+
 // DiscoverLaunchTemplateAMI mocks base method.
 func (m *MockEC2Interface) DiscoverLaunchTemplateAMI(arg0 scope.LaunchTemplateScope) (*string, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +227,21 @@ func (mr *MockEC2InterfaceMockRecorder) DiscoverLaunchTemplateAMI(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverLaunchTemplateAMI", reflect.TypeOf((*MockEC2Interface)(nil).DiscoverLaunchTemplateAMI), arg0)
 }
 
+// GetAMIDeprecationTime mocks base method.
+func (m *MockEC2Interface) GetAMIDeprecationTime(arg0 string) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAMIDeprecationTime", arg0)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAMIDeprecationTime indicates an expected call of GetAMIDeprecationTime.
+func (mr *MockEC2InterfaceMockRecorder) GetAMIDeprecationTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAMIDeprecationTime", reflect.TypeOf((*MockEC2Interface)(nil).GetAMIDeprecationTime), arg0)
+}
+
 // GetAdditionalSecurityGroupsIDs mocks base method.
 func (m *MockEC2Interface) GetAdditionalSecurityGroupsIDs(arg0 []v1beta2.AWSResourceReference) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +257,36 @@ func (mr *MockEC2InterfaceMockRecorder) GetAdditionalSecurityGroupsIDs(arg0 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdditionalSecurityGroupsIDs", reflect.TypeOf((*MockEC2Interface)(nil).GetAdditionalSecurityGroupsIDs), arg0)
 }
 
+// GetConsoleOutput mocks base method.
+func (m *MockEC2Interface) GetConsoleOutput(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsoleOutput", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsoleOutput indicates an expected call of GetConsoleOutput.
+func (mr *MockEC2InterfaceMockRecorder) GetConsoleOutput(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsoleOutput", reflect.TypeOf((*MockEC2Interface)(nil).GetConsoleOutput), arg0)
+}
+
+// GetConsoleScreenshot mocks base method.
+func (m *MockEC2Interface) GetConsoleScreenshot(arg0 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsoleScreenshot", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsoleScreenshot indicates an expected call of GetConsoleScreenshot.
+func (mr *MockEC2InterfaceMockRecorder) GetConsoleScreenshot(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsoleScreenshot", reflect.TypeOf((*MockEC2Interface)(nil).GetConsoleScreenshot), arg0)
+}
+
 // GetCoreSecurityGroups mocks base method.
 func (m *MockEC2Interface) GetCoreSecurityGroups(arg0 *scope.MachineScope) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -184,6 +302,36 @@ func (mr *MockEC2InterfaceMockRecorder) GetCoreSecurityGroups(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoreSecurityGroups", reflect.TypeOf((*MockEC2Interface)(nil).GetCoreSecurityGroups), arg0)
 }
 
+// GetFleet mocks base method.
+func (m *MockEC2Interface) GetFleet(arg0 string) (*v1beta20.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFleet", arg0)
+	ret0, _ := ret[0].(*v1beta20.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFleet indicates an expected call of GetFleet.
+func (mr *MockEC2InterfaceMockRecorder) GetFleet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFleet", reflect.TypeOf((*MockEC2Interface)(nil).GetFleet), arg0)
+}
+
+// GetFleetInstances mocks base method.
+func (m *MockEC2Interface) GetFleetInstances(arg0 string) ([]v1beta2.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFleetInstances", arg0)
+	ret0, _ := ret[0].([]v1beta2.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFleetInstances indicates an expected call of GetFleetInstances.
+func (mr *MockEC2InterfaceMockRecorder) GetFleetInstances(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFleetInstances", reflect.TypeOf((*MockEC2Interface)(nil).GetFleetInstances), arg0)
+}
+
 // GetInstanceSecurityGroups mocks base method.
 func (m *MockEC2Interface) GetInstanceSecurityGroups(arg0 string) (map[string][]string, error) {
 	m.ctrl.T.Helper()
@@ -261,6 +409,35 @@ func (mr *MockEC2InterfaceMockRecorder) GetRunningInstanceByTags(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningInstanceByTags", reflect.TypeOf((*MockEC2Interface)(nil).GetRunningInstanceByTags), arg0)
 }
 
+// GetSecurityGroupSelectorsIDs mocks base method.
+func (m *MockEC2Interface) GetSecurityGroupSelectorsIDs(arg0 []v1beta2.SecurityGroupSelector) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityGroupSelectorsIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecurityGroupSelectorsIDs indicates an expected call of GetSecurityGroupSelectorsIDs.
+func (mr *MockEC2InterfaceMockRecorder) GetSecurityGroupSelectorsIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityGroupSelectorsIDs", reflect.TypeOf((*MockEC2Interface)(nil).GetSecurityGroupSelectorsIDs), arg0)
+}
+
+// HibernateInstance mocks base method.
+func (m *MockEC2Interface) HibernateInstance(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HibernateInstance", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HibernateInstance indicates an expected call of HibernateInstance.
+func (mr *MockEC2InterfaceMockRecorder) HibernateInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HibernateInstance", reflect.TypeOf((*MockEC2Interface)(nil).HibernateInstance), arg0)
+}
+
 // InstanceIfExists mocks base method.
 func (m *MockEC2Interface) InstanceIfExists(arg0 *string) (*v1beta2.Instance, error) {
 	m.ctrl.T.Helper()
@@ -291,6 +468,20 @@ func (mr *MockEC2InterfaceMockRecorder) LaunchTemplateNeedsUpdate(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LaunchTemplateNeedsUpdate", reflect.TypeOf((*MockEC2Interface)(nil).LaunchTemplateNeedsUpdate), arg0, arg1, arg2)
 }
 
+// ModifyFleetTargetCapacity mocks base method.
+func (m *MockEC2Interface) ModifyFleetTargetCapacity(arg0 string, arg1 int64, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyFleetTargetCapacity", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModifyFleetTargetCapacity indicates an expected call of ModifyFleetTargetCapacity.
+func (mr *MockEC2InterfaceMockRecorder) ModifyFleetTargetCapacity(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyFleetTargetCapacity", reflect.TypeOf((*MockEC2Interface)(nil).ModifyFleetTargetCapacity), arg0, arg1, arg2)
+}
+
 // ModifyInstanceMetadataOptions mocks base method.
 func (m *MockEC2Interface) ModifyInstanceMetadataOptions(arg0 string, arg1 *v1beta2.InstanceMetadataOptions) error {
 	m.ctrl.T.Helper()
@@ -306,17 +497,17 @@ func (mr *MockEC2InterfaceMockRecorder) ModifyInstanceMetadataOptions(arg0, arg1
 }
 
 // PruneLaunchTemplateVersions mocks base method.
-func (m *MockEC2Interface) PruneLaunchTemplateVersions(arg0 string) error {
+func (m *MockEC2Interface) PruneLaunchTemplateVersions(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PruneLaunchTemplateVersions", arg0)
+	ret := m.ctrl.Call(m, "PruneLaunchTemplateVersions", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // PruneLaunchTemplateVersions indicates an expected call of PruneLaunchTemplateVersions.
-func (mr *MockEC2InterfaceMockRecorder) PruneLaunchTemplateVersions(arg0 interface{}) *gomock.Call {
+func (mr *MockEC2InterfaceMockRecorder) PruneLaunchTemplateVersions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneLaunchTemplateVersions", reflect.TypeOf((*MockEC2Interface)(nil).PruneLaunchTemplateVersions), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneLaunchTemplateVersions", reflect.TypeOf((*MockEC2Interface)(nil).PruneLaunchTemplateVersions), arg0, arg1)
 }
 
 // ReconcileBastion mocks base method.
@@ -361,6 +552,20 @@ func (mr *MockEC2InterfaceMockRecorder) ReleaseElasticIP(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseElasticIP", reflect.TypeOf((*MockEC2Interface)(nil).ReleaseElasticIP), arg0)
 }
 
+// StartInstance mocks base method.
+func (m *MockEC2Interface) StartInstance(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartInstance", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartInstance indicates an expected call of StartInstance.
+func (mr *MockEC2InterfaceMockRecorder) StartInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartInstance", reflect.TypeOf((*MockEC2Interface)(nil).StartInstance), arg0)
+}
+
 // TerminateInstance mocks base method.
 func (m *MockEC2Interface) TerminateInstance(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -403,6 +608,20 @@ func (mr *MockEC2InterfaceMockRecorder) UpdateInstanceSecurityGroups(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceSecurityGroups", reflect.TypeOf((*MockEC2Interface)(nil).UpdateInstanceSecurityGroups), arg0, arg1)
 }
 
+// UpdateInstanceSourceDestCheck mocks base method.
+func (m *MockEC2Interface) UpdateInstanceSourceDestCheck(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstanceSourceDestCheck", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInstanceSourceDestCheck indicates an expected call of UpdateInstanceSourceDestCheck.
+func (mr *MockEC2InterfaceMockRecorder) UpdateInstanceSourceDestCheck(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceSourceDestCheck", reflect.TypeOf((*MockEC2Interface)(nil).UpdateInstanceSourceDestCheck), arg0, arg1)
+}
+
 // UpdateResourceTags mocks base method.
 func (m *MockEC2Interface) UpdateResourceTags(arg0 *string, arg1, arg2 map[string]string) error {
 	m.ctrl.T.Helper()
@@ -41,9 +41,16 @@ func (s *Service) getAvailableZones() ([]string, error) {
 
 	zones := make([]string, 0, len(out.AvailabilityZones))
 	for _, zone := range out.AvailabilityZones {
+		if !s.scope.VPC().IsAvailabilityZoneAllowed(*zone.ZoneName) {
+			continue
+		}
 		zones = append(zones, *zone.ZoneName)
 	}
 
+	if len(zones) == 0 {
+		return nil, errors.New("none of the available availability zones are allowed by the VPC allowed and excluded availability zones")
+	}
+
 	sort.Strings(zones)
 	return zones, nil
 }
@@ -43,6 +43,10 @@ func (s *Service) reconcileInternetGateways() error {
 
 	s.scope.Debug("Reconciling internet gateways")
 
+	if id := s.scope.VPC().ExternalInternetGatewayID; id != nil {
+		return s.reconcileExternalInternetGateway(*id)
+	}
+
 	igs, err := s.describeVpcInternetGateways()
 	if awserrors.IsNotFound(err) {
 		if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
@@ -77,6 +81,50 @@ func (s *Service) reconcileInternetGateways() error {
 	return nil
 }
 
+// reconcileExternalInternetGateway makes sure the existing internet gateway is attached to the VPC.
+// The internet gateway isn't owned by the cluster, so it isn't tagged.
+func (s *Service) reconcileExternalInternetGateway(id string) error {
+	out, err := s.EC2Client.DescribeInternetGatewaysWithContext(context.TODO(), &ec2.DescribeInternetGatewaysInput{
+		InternetGatewayIds: aws.StringSlice([]string{id}),
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeInternetGateway", "Failed to describe internet gateway %q: %v", id, err)
+		return errors.Wrapf(err, "failed to describe internet gateway %q", id)
+	}
+	if len(out.InternetGateways) == 0 {
+		return errors.Errorf("internet gateway %q not found", id)
+	}
+
+	attached := false
+	for _, attachment := range out.InternetGateways[0].Attachments {
+		if aws.StringValue(attachment.VpcId) != s.scope.VPC().ID {
+			return errors.Errorf("internet gateway %q is attached to another VPC %q", id, aws.StringValue(attachment.VpcId))
+		}
+		attached = true
+	}
+
+	if !attached {
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			if _, err := s.EC2Client.AttachInternetGatewayWithContext(context.TODO(), &ec2.AttachInternetGatewayInput{
+				InternetGatewayId: aws.String(id),
+				VpcId:             aws.String(s.scope.VPC().ID),
+			}); err != nil {
+				return false, err
+			}
+			return true, nil
+		}, awserrors.InternetGatewayNotFound); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedAttachInternetGateway", "Failed to attach Internet Gateway %q to vpc %q: %v", id, s.scope.VPC().ID, err)
+			return errors.Wrapf(err, "failed to attach internet gateway %q to vpc %q", id, s.scope.VPC().ID)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulAttachInternetGateway", "Internet Gateway %q attached to VPC %q", id, s.scope.VPC().ID)
+		s.scope.Debug("attached internet gateway to VPC", "internet-gateway-id", id, "vpc-id", s.scope.VPC().ID)
+	}
+
+	s.scope.VPC().InternetGatewayID = aws.String(id)
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.InternetGatewayReadyCondition)
+	return nil
+}
+
 func (s *Service) deleteInternetGateways() error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping internet gateway deletion in unmanaged mode")
@@ -104,6 +152,11 @@ func (s *Service) deleteInternetGateways() error {
 		record.Eventf(s.scope.InfraCluster(), "SuccessfulDetachInternetGateway", "Detached Internet Gateway %q from VPC %q", *ig.InternetGatewayId, s.scope.VPC().ID)
 		s.scope.Debug("Detached internet gateway from VPC", "internet-gateway-id", *ig.InternetGatewayId, "vpc-id", s.scope.VPC().ID)
 
+		// Existing internet gateways aren't owned by the cluster, and are kept for reuse.
+		if aws.StringValue(ig.InternetGatewayId) == aws.StringValue(s.scope.VPC().ExternalInternetGatewayID) {
+			continue
+		}
+
 		deleteReq := &ec2.DeleteInternetGatewayInput{
 			InternetGatewayId: ig.InternetGatewayId,
 		}
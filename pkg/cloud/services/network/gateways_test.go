@@ -115,6 +115,32 @@ func TestReconcileInternetGateways(t *testing.T) {
 					Return(&ec2.AttachInternetGatewayOutput{}, nil)
 			},
 		},
+		{
+			name: "external igw, attaches it without creating or tagging",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                        "vpc-gateways",
+					ExternalInternetGatewayID: aws.String("igw-2"),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInternetGatewaysWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInternetGatewaysInput{
+					InternetGatewayIds: aws.StringSlice([]string{"igw-2"}),
+				})).
+					Return(&ec2.DescribeInternetGatewaysOutput{
+						InternetGateways: []*ec2.InternetGateway{{InternetGatewayId: aws.String("igw-2")}},
+					}, nil)
+
+				m.AttachInternetGatewayWithContext(context.TODO(), gomock.Eq(&ec2.AttachInternetGatewayInput{
+					InternetGatewayId: aws.String("igw-2"),
+					VpcId:             aws.String("vpc-gateways"),
+				})).
+					Return(&ec2.AttachInternetGatewayOutput{}, nil)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -226,6 +252,38 @@ func TestDeleteInternetGateways(t *testing.T) {
 				}).Return(&ec2.DeleteInternetGatewayOutput{}, nil)
 			},
 		},
+		{
+			name: "Should only detach the external internet gateway",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                        "vpc-gateways",
+					ExternalInternetGatewayID: aws.String("igw-2"),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInternetGatewaysWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeInternetGatewaysInput{})).
+					Return(&ec2.DescribeInternetGatewaysOutput{
+						InternetGateways: []*ec2.InternetGateway{
+							{
+								InternetGatewayId: aws.String("igw-2"),
+								Attachments: []*ec2.InternetGatewayAttachment{
+									{
+										State: aws.String(ec2.AttachmentStatusAttached),
+										VpcId: aws.String("vpc-gateways"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.DetachInternetGatewayWithContext(context.TODO(), &ec2.DetachInternetGatewayInput{
+					InternetGatewayId: aws.String("igw-2"),
+					VpcId:             aws.String("vpc-gateways"),
+				}).Return(&ec2.DetachInternetGatewayOutput{}, nil)
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
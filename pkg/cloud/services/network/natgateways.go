@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -38,6 +40,10 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
+// natGatewayPendingTimeout is the deadline for a NAT gateway to become available. NAT gateways stuck pending
+// past the deadline are deleted, so that they are created again.
+const natGatewayPendingTimeout = 20 * time.Minute
+
 func (s *Service) reconcileNatGateways() error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping NAT gateway reconcile in unmanaged mode")
@@ -73,6 +79,7 @@ func (s *Service) reconcileNatGateways() error {
 
 	natGatewaysIPs := []string{}
 	subnetIDs := []string{}
+	timedOut := []*ec2.NatGateway{}
 
 	for _, sn := range s.scope.Subnets().FilterPublic() {
 		if sn.GetResourceID() == "" {
@@ -80,6 +87,10 @@ func (s *Service) reconcileNatGateways() error {
 		}
 
 		if ngw, ok := existing[sn.GetResourceID()]; ok {
+			if aws.StringValue(ngw.State) == ec2.NatGatewayStatePending && ngw.CreateTime != nil && time.Since(*ngw.CreateTime) > natGatewayPendingTimeout {
+				timedOut = append(timedOut, ngw)
+				continue
+			}
 			if len(ngw.NatGatewayAddresses) > 0 && ngw.NatGatewayAddresses[0].PublicIp != nil {
 				natGatewaysIPs = append(natGatewaysIPs, *ngw.NatGatewayAddresses[0].PublicIp)
 			}
@@ -102,6 +113,13 @@ func (s *Service) reconcileNatGateways() error {
 		subnetIDs = append(subnetIDs, sn.GetResourceID())
 	}
 
+	if len(timedOut) > 0 {
+		return s.deleteTimedOutNatGateways(timedOut)
+	}
+	if conditions.GetReason(s.scope.InfraCluster(), infrav1.AsyncOperationsReadyCondition) == infrav1.NatGatewaysCreationTimedOutReason {
+		conditions.MarkTrue(s.scope.InfraCluster(), infrav1.AsyncOperationsReadyCondition)
+	}
+
 	s.scope.SetNatGatewaysIPs(natGatewaysIPs)
 
 	// Batch the creation of NAT gateways
@@ -133,6 +151,27 @@ func (s *Service) reconcileNatGateways() error {
 	return nil
 }
 
+// deleteTimedOutNatGateways deletes the NAT gateways stuck pending past their deadline, and reports them in the
+// AsyncOperationsReady condition. The NAT gateways are created again once they are deleted.
+func (s *Service) deleteTimedOutNatGateways(ngws []*ec2.NatGateway) error {
+	ids := make([]string, 0, len(ngws))
+	for _, ngw := range ngws {
+		id := aws.StringValue(ngw.NatGatewayId)
+		ids = append(ids, id)
+		record.Warnf(s.scope.InfraCluster(), "NATGatewayCreationTimedOut", "NAT Gateway %q has been pending for more than %s, deleting it to retry", id, natGatewayPendingTimeout)
+		if _, err := s.EC2Client.DeleteNatGatewayWithContext(context.TODO(), &ec2.DeleteNatGatewayInput{
+			NatGatewayId: aws.String(id),
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteNATGateway", "Failed to delete NAT Gateway %q: %v", id, err)
+			return errors.Wrapf(err, "failed to delete timed out nat gateway %q", id)
+		}
+	}
+
+	message := fmt.Sprintf("NAT gateways %s have been pending for more than %s and are being recreated", strings.Join(ids, ", "), natGatewayPendingTimeout)
+	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.AsyncOperationsReadyCondition, infrav1.NatGatewaysCreationTimedOutReason, clusterv1.ConditionSeverityWarning, message)
+	return errors.New(message)
+}
+
 func (s *Service) deleteNatGateways() error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping NAT gateway deletion in unmanaged mode")
@@ -183,6 +222,41 @@ func (s *Service) deleteNatGateways() error {
 	return kerrors.NewAggregate(errs)
 }
 
+// deleteOrphanedNatGateways deletes the NAT gateways owned by the cluster that are left in the VPC, for example
+// in subnets that are no longer part of the cluster spec, or because a previous deletion failed half-way.
+// They keep their Elastic IPs associated and block the deletion of the VPC otherwise.
+func (s *Service) deleteOrphanedNatGateways() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) || s.scope.VPC().ID == "" {
+		return nil
+	}
+
+	var ngIDs []string
+	if err := s.EC2Client.DescribeNatGatewaysPagesWithContext(context.TODO(), &ec2.DescribeNatGatewaysInput{
+		Filter: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.ClusterOwned(s.scope.Name()),
+			filter.EC2.NATGatewayStates(ec2.NatGatewayStatePending, ec2.NatGatewayStateAvailable),
+		},
+	}, func(page *ec2.DescribeNatGatewaysOutput, lastPage bool) bool {
+		for _, ng := range page.NatGateways {
+			ngIDs = append(ngIDs, aws.StringValue(ng.NatGatewayId))
+		}
+		return !lastPage
+	}); err != nil {
+		return errors.Wrapf(err, "failed to describe NAT gateways in vpc %q", s.scope.VPC().ID)
+	}
+
+	errs := []error{}
+	for _, id := range ngIDs {
+		s.scope.Info("Deleting orphaned NAT gateway", "nat-gateway-id", id, "vpc-id", s.scope.VPC().ID)
+		if err := s.deleteNatGateway(id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
 func (s *Service) describeNatGatewaysBySubnet() (map[string]*ec2.NatGateway, error) {
 	describeNatGatewayInput := &ec2.DescribeNatGatewaysInput{
 		Filter: []*ec2.Filter{
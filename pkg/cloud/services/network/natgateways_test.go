@@ -19,6 +19,7 @@ package network
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
@@ -35,6 +36,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const (
@@ -46,9 +48,10 @@ func TestReconcileNatGateways(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	testCases := []struct {
-		name   string
-		input  []infrav1.SubnetSpec
-		expect func(m *mocks.MockEC2APIMockRecorder)
+		name      string
+		input     []infrav1.SubnetSpec
+		expect    func(m *mocks.MockEC2APIMockRecorder)
+		expectErr bool
 	}{
 		{
 			name: "single private subnet exists, should create no NAT gateway",
@@ -340,6 +343,41 @@ func TestReconcileNatGateways(t *testing.T) {
 				m.CreateNatGatewayWithContext(context.TODO(), gomock.Any()).Times(0)
 			},
 		},
+		{
+			name: "public & private subnet, and one NAT gateway pending past its deadline, should delete it",
+			input: []infrav1.SubnetSpec{
+				{
+					ID:               "subnet-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.10.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-2",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.12.0/24",
+					IsPublic:         false,
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, _, y interface{}, requestOptions ...request.Option) {
+						funct := y.(func(page *ec2.DescribeNatGatewaysOutput, lastPage bool) bool)
+						funct(&ec2.DescribeNatGatewaysOutput{NatGateways: []*ec2.NatGateway{{
+							NatGatewayId: aws.String("gateway"),
+							SubnetId:     aws.String("subnet-1"),
+							State:        aws.String(ec2.NatGatewayStatePending),
+							CreateTime:   aws.Time(time.Now().Add(-time.Hour)),
+						}}}, true)
+					}).Return(nil)
+
+				m.DeleteNatGatewayWithContext(context.TODO(), gomock.Eq(&ec2.DeleteNatGatewayInput{
+					NatGatewayId: aws.String("gateway"),
+				})).Return(&ec2.DeleteNatGatewayOutput{}, nil)
+				m.CreateNatGatewayWithContext(context.TODO(), gomock.Any()).Times(0)
+			},
+			expectErr: true,
+		},
 		{
 			name: "public & private subnet declared, but don't exist yet",
 			input: []infrav1.SubnetSpec{
@@ -401,7 +439,17 @@ func TestReconcileNatGateways(t *testing.T) {
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
 
-			if err := s.reconcileNatGateways(); err != nil {
+			err = s.reconcileNatGateways()
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if !conditions.IsFalse(awsCluster, infrav1.AsyncOperationsReadyCondition) {
+					t.Fatalf("expected condition %s to be false", infrav1.AsyncOperationsReadyCondition)
+				}
+				return
+			}
+			if err != nil {
 				t.Fatalf("got an unexpected error: %v", err)
 			}
 		})
@@ -722,6 +770,119 @@ func TestDeleteNatGateways(t *testing.T) {
 	}
 }
 
+func TestDeleteOrphanedNatGateways(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name           string
+		isUnmanagedVPC bool
+		expect         func(m *mocks.MockEC2APIMockRecorder)
+		wantErr        bool
+	}{
+		{
+			name:           "Should skip deletion if vpc is unmanaged",
+			isUnmanagedVPC: true,
+		},
+		{
+			name: "Should delete the natgateways owned by the cluster",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(),
+					gomock.Eq(&ec2.DescribeNatGatewaysInput{
+						Filter: []*ec2.Filter{
+							{
+								Name:   aws.String("vpc-id"),
+								Values: []*string{aws.String("managed-vpc")},
+							},
+							{
+								Name:   aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+								Values: []*string{aws.String("owned")},
+							},
+							{
+								Name:   aws.String("state"),
+								Values: []*string{aws.String("pending"), aws.String("available")},
+							},
+						},
+					}),
+					gomock.Any()).Do(mockDescribeNatGatewaysOutput).Return(nil)
+
+				m.DeleteNatGatewayWithContext(context.TODO(), gomock.Eq(&ec2.DeleteNatGatewayInput{
+					NatGatewayId: aws.String("natgateway"),
+				})).Return(&ec2.DeleteNatGatewayOutput{}, nil)
+
+				m.DescribeNatGatewaysWithContext(context.TODO(), gomock.Eq(&ec2.DescribeNatGatewaysInput{
+					NatGatewayIds: []*string{aws.String("natgateway")},
+				})).Return(&ec2.DescribeNatGatewaysOutput{
+					NatGateways: []*ec2.NatGateway{
+						{
+							State: aws.String("deleted"),
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "Should return error if delete natgateway fails",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(),
+					gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).Do(mockDescribeNatGatewaysOutput).Return(nil)
+
+				m.DeleteNatGatewayWithContext(context.TODO(), gomock.Eq(&ec2.DeleteNatGatewayInput{
+					NatGatewayId: aws.String("natgateway"),
+				})).Return(nil, awserrors.NewFailedDependency("failed dependency"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "managed-vpc",
+							Tags: infrav1.Tags{
+								infrav1.ClusterTagKey("test-cluster"): "owned",
+							},
+						},
+					},
+				},
+			}
+			if tc.isUnmanagedVPC {
+				awsCluster.Spec.NetworkSpec.VPC.Tags = infrav1.Tags{}
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: awsCluster,
+				Client:     client,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			err = s.deleteOrphanedNatGateways()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
 var mockDescribeNatGatewaysOutput = func(ctx context.Context, _, y interface{}, requestOptions ...request.Option) {
 	funct := y.(func(page *ec2.DescribeNatGatewaysOutput, lastPage bool) bool)
 	funct(&ec2.DescribeNatGatewaysOutput{NatGateways: []*ec2.NatGateway{{
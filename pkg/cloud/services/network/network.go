@@ -145,6 +145,12 @@ func (s *Service) DeleteNetwork() (err error) {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
 		return err
 	}
+
+	// NAT gateways owned by the cluster that are not in its subnets anymore, e.g. after a failed deletion.
+	if err := s.deleteOrphanedNatGateways(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 
 	// EIPs.
@@ -191,6 +197,12 @@ func (s *Service) DeleteNetwork() (err error) {
 		return err
 	}
 
+	// Network interfaces left behind in the subnets, e.g. by the VPC CNI.
+	if err := s.deleteOrphanedNetworkInterfaces(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
 	if err := s.deleteSubnets(); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
 		return err
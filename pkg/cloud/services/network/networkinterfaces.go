@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// deleteOrphanedNetworkInterfaces deletes the detached network interfaces left in the VPC, such as the
+// ones leaked by the VPC CNI when nodes are terminated, which block the deletion of the subnets.
+// Network interfaces managed by AWS services are skipped, they are released along with their resources.
+func (s *Service) deleteOrphanedNetworkInterfaces() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping network interfaces deletion in unmanaged mode")
+		return nil
+	}
+
+	if s.scope.VPC().ID == "" {
+		return nil
+	}
+
+	var enis []*ec2.NetworkInterface
+	if err := s.EC2Client.DescribeNetworkInterfacesPagesWithContext(context.TODO(), &ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.NetworkInterfaceStates(ec2.NetworkInterfaceStatusAvailable),
+		},
+	}, func(page *ec2.DescribeNetworkInterfacesOutput, lastPage bool) bool {
+		enis = append(enis, page.NetworkInterfaces...)
+		return !lastPage
+	}); err != nil {
+		return errors.Wrapf(err, "failed to describe network interfaces in vpc %q", s.scope.VPC().ID)
+	}
+
+	errs := []error{}
+	for _, eni := range enis {
+		if aws.BoolValue(eni.RequesterManaged) {
+			continue
+		}
+		if err := s.deleteNetworkInterface(aws.StringValue(eni.NetworkInterfaceId)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+func (s *Service) deleteNetworkInterface(id string) error {
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		if _, err := s.EC2Client.DeleteNetworkInterfaceWithContext(context.TODO(), &ec2.DeleteNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String(id),
+		}); err != nil {
+			if code, _ := awserrors.Code(errors.Cause(err)); code != awserrors.NetworkInterfaceNotFound {
+				return false, err
+			}
+		}
+		return true, nil
+	}, awserrors.NetworkInterfaceInUse); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteNetworkInterface", "Failed to delete orphaned network interface %q in VPC %q: %v", id, s.scope.VPC().ID, err)
+		return errors.Wrapf(err, "failed to delete network interface %q", id)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteNetworkInterface", "Deleted orphaned network interface %q in VPC %q", id, s.scope.VPC().ID)
+	s.scope.Info("Deleted orphaned network interface", "network-interface-id", id, "vpc-id", s.scope.VPC().ID)
+	return nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestDeleteOrphanedNetworkInterfaces(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeNetworkInterfaces := func(m *mocks.MockEC2APIMockRecorder) {
+		m.DescribeNetworkInterfacesPagesWithContext(context.TODO(),
+			gomock.Eq(&ec2.DescribeNetworkInterfacesInput{
+				Filters: []*ec2.Filter{
+					{
+						Name:   aws.String("vpc-id"),
+						Values: []*string{aws.String("managed-vpc")},
+					},
+					{
+						Name:   aws.String("status"),
+						Values: []*string{aws.String("available")},
+					},
+				},
+			}),
+			gomock.Any()).Do(func(_ context.Context, _ *ec2.DescribeNetworkInterfacesInput, fn func(*ec2.DescribeNetworkInterfacesOutput, bool) bool, _ ...request.Option) {
+			fn(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []*ec2.NetworkInterface{
+				{NetworkInterfaceId: aws.String("eni-cni")},
+				{NetworkInterfaceId: aws.String("eni-elb"), RequesterManaged: aws.Bool(true)},
+			}}, true)
+		}).Return(nil)
+	}
+
+	testCases := []struct {
+		name           string
+		isUnmanagedVPC bool
+		expect         func(m *mocks.MockEC2APIMockRecorder)
+		wantErr        bool
+	}{
+		{
+			name:           "Should skip deletion if vpc is unmanaged",
+			isUnmanagedVPC: true,
+		},
+		{
+			name: "Should delete the detached network interfaces not managed by AWS",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNetworkInterfaces(m)
+				m.DeleteNetworkInterfaceWithContext(context.TODO(), gomock.Eq(&ec2.DeleteNetworkInterfaceInput{
+					NetworkInterfaceId: aws.String("eni-cni"),
+				})).Return(&ec2.DeleteNetworkInterfaceOutput{}, nil)
+			},
+		},
+		{
+			name: "Should retry deletion while the network interface is in use",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNetworkInterfaces(m)
+				gomock.InOrder(
+					m.DeleteNetworkInterfaceWithContext(context.TODO(), gomock.Any()).
+						Return(nil, awserr.New(awserrors.NetworkInterfaceInUse, "in use", nil)),
+					m.DeleteNetworkInterfaceWithContext(context.TODO(), gomock.Any()).
+						Return(&ec2.DeleteNetworkInterfaceOutput{}, nil),
+				)
+			},
+		},
+		{
+			name: "Should ignore network interfaces already deleted",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNetworkInterfaces(m)
+				m.DeleteNetworkInterfaceWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(awserrors.NetworkInterfaceNotFound, "not found", nil))
+			},
+		},
+		{
+			name: "Should return error if delete network interface fails",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNetworkInterfaces(m)
+				m.DeleteNetworkInterfaceWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("failed dependency"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error if describe network interfaces fails",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNetworkInterfacesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+					Return(awserrors.NewFailedDependency("failed dependency"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "managed-vpc",
+							Tags: infrav1.Tags{
+								infrav1.ClusterTagKey("test-cluster"): "owned",
+							},
+						},
+					},
+				},
+			}
+			if tc.isUnmanagedVPC {
+				awsCluster.Spec.NetworkSpec.VPC.Tags = infrav1.Tags{}
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: awsCluster,
+				Client:     client,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			err = s.deleteOrphanedNetworkInterfaces()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
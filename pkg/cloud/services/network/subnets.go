@@ -19,6 +19,7 @@ package network
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/rand"
 	"net"
 	"sort"
@@ -27,6 +28,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
@@ -102,29 +104,15 @@ func (s *Service) reconcileSubnets() error {
 	}
 
 	if s.scope.SecondaryCidrBlock() != nil {
-		subnetCIDRs, err := cidr.SplitIntoSubnetsIPv4(*s.scope.SecondaryCidrBlock(), *s.scope.VPC().AvailabilityZoneUsageLimit)
+		secondarySubnets, err := s.getSecondarySubnets()
 		if err != nil {
 			return err
 		}
 
-		zones, err := s.getAvailableZones()
-		if err != nil {
-			return err
-		}
-
-		for i, sub := range subnetCIDRs {
-			secondarySub := infrav1.SubnetSpec{
-				ID:               fmt.Sprintf("%s-subnet-%s-%s", s.scope.Name(), infrav1.SecondarySubnetTagValue, zones[i]),
-				CidrBlock:        sub.String(),
-				AvailabilityZone: zones[i],
-				IsPublic:         false,
-				Tags: infrav1.Tags{
-					infrav1.NameAWSSubnetAssociation: infrav1.SecondarySubnetTagValue,
-				},
-			}
-			existingSubnet := existing.FindEqual(&secondarySub)
+		for i := range secondarySubnets {
+			existingSubnet := existing.FindEqual(&secondarySubnets[i])
 			if existingSubnet == nil {
-				subnets = append(subnets, secondarySub)
+				subnets = append(subnets, secondarySubnets[i])
 			}
 		}
 	}
@@ -139,8 +127,10 @@ func (s *Service) reconcileSubnets() error {
 				existingSubnet.ID = sub.ID
 			}
 
-			// Update subnet spec with the existing subnet details
+			// Update subnet spec with the existing subnet details, preserving the user-defined settings.
+			mapPublicIPOnLaunch := sub.MapPublicIPOnLaunch
 			existingSubnet.DeepCopyInto(sub)
+			sub.MapPublicIPOnLaunch = mapPublicIPOnLaunch
 
 			// Make sure tags are up-to-date.
 			subnetTags := sub.Tags
@@ -201,6 +191,11 @@ func (s *Service) reconcileSubnets() error {
 
 			// If we have a ResourceID (i.e. subnet-<xyz>), the resource was already created.
 			if subnet.ResourceID != "" {
+				if existingSubnet := existing.FindByID(subnet.ResourceID); existingSubnet != nil {
+					if err := s.reconcileSubnetMapPublicIPOnLaunch(subnet, aws.BoolValue(existingSubnet.MapPublicIPOnLaunch)); err != nil {
+						return err
+					}
+				}
 				continue
 			}
 
@@ -212,11 +207,97 @@ func (s *Service) reconcileSubnets() error {
 		}
 	}
 
+	s.setPodSubnetsStatus(subnets)
+
 	s.scope.Debug("Reconciled subnets", "subnets", subnets)
 	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition)
 	return nil
 }
 
+// reconcileSubnetMapPublicIPOnLaunch updates an existing public subnet to map public IPs on launch
+// as set in its spec. Subnets without an explicit setting are left as they are.
+func (s *Service) reconcileSubnetMapPublicIPOnLaunch(sn *infrav1.SubnetSpec, mapsPublicIPOnLaunch bool) error {
+	if !sn.IsPublic || sn.IsEdgeWavelength() || sn.MapPublicIPOnLaunch == nil || *sn.MapPublicIPOnLaunch == mapsPublicIPOnLaunch {
+		return nil
+	}
+
+	if _, err := s.EC2Client.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{
+		SubnetId: aws.String(sn.GetResourceID()),
+		MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
+			Value: sn.MapPublicIPOnLaunch,
+		},
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedModifySubnetAttributes", "Failed modifying managed Subnet %q attributes: %v", sn.GetResourceID(), err)
+		return errors.Wrapf(err, "failed to set subnet %q attribute map public ip on launch", sn.GetResourceID())
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulModifySubnetAttributes", "Modified managed Subnet %q attributes", sn.GetResourceID())
+	return nil
+}
+
+// getSecondarySubnets returns the subnets to allocate from the secondary CIDR block, either as
+// declared by the pod network or split across the availability zones. Zones may hold several
+// secondary subnets, which are then told apart by an index.
+func (s *Service) getSecondarySubnets() (infrav1.Subnets, error) {
+	zoneSubnets := map[string]int{}
+	secondarySubnet := func(cidrBlock, zone string) infrav1.SubnetSpec {
+		id := fmt.Sprintf("%s-subnet-%s-%s", s.scope.Name(), infrav1.SecondarySubnetTagValue, zone)
+		if n := zoneSubnets[zone]; n > 0 {
+			id = fmt.Sprintf("%s-%d", id, n)
+		}
+		zoneSubnets[zone]++
+		return infrav1.SubnetSpec{
+			ID:               id,
+			CidrBlock:        cidrBlock,
+			AvailabilityZone: zone,
+			IsPublic:         false,
+			Tags: infrav1.Tags{
+				infrav1.NameAWSSubnetAssociation: infrav1.SecondarySubnetTagValue,
+			},
+		}
+	}
+
+	subnets := infrav1.Subnets{}
+	if podNetwork := s.scope.PodNetwork(); podNetwork != nil && len(podNetwork.Subnets) > 0 {
+		for _, sub := range podNetwork.Subnets {
+			subnets = append(subnets, secondarySubnet(sub.CidrBlock, sub.AvailabilityZone))
+		}
+		return subnets, nil
+	}
+
+	subnetCIDRs, err := cidr.SplitIntoSubnetsIPv4(*s.scope.SecondaryCidrBlock(), *s.scope.VPC().AvailabilityZoneUsageLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := s.getAvailableZones()
+	if err != nil {
+		return nil, err
+	}
+
+	// When fewer zones are allowed than the subnets split from the CIDR block, the zones are reused,
+	// so that the whole block is allocated.
+	for i, sub := range subnetCIDRs {
+		subnets = append(subnets, secondarySubnet(sub.String(), zones[i%len(zones)]))
+	}
+	return subnets, nil
+}
+
+// setPodSubnetsStatus records the created secondary subnets in the network status.
+func (s *Service) setPodSubnetsStatus(subnets infrav1.Subnets) {
+	var podSubnets []infrav1.PodSubnetStatus
+	for _, sub := range subnets {
+		if sub.Tags[infrav1.NameAWSSubnetAssociation] != infrav1.SecondarySubnetTagValue || sub.ResourceID == "" {
+			continue
+		}
+		podSubnets = append(podSubnets, infrav1.PodSubnetStatus{
+			ID:               sub.ResourceID,
+			AvailabilityZone: sub.AvailabilityZone,
+			CidrBlock:        sub.CidrBlock,
+		})
+	}
+	s.scope.Network().PodSubnets = podSubnets
+}
+
 func (s *Service) retrieveZoneInfo(zoneNames []string) ([]*ec2.AvailabilityZone, error) {
 	zones, err := s.EC2Client.DescribeAvailabilityZonesWithContext(context.TODO(), &ec2.DescribeAvailabilityZonesInput{
 		ZoneNames: aws.StringSlice(zoneNames),
@@ -290,11 +371,19 @@ func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
 		return nil, errors.Wrapf(err, "failed splitting VPC CIDR %q into subnets", s.scope.VPC().CidrBlock)
 	}
 
-	publicSubnetCIDRs, err = cidr.SplitIntoSubnetsIPv4(subnetCIDRs[0].String(), len(zones))
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed splitting CIDR %q into public subnets", subnetCIDRs[0].String())
+	var privateSubnetCIDRs []*net.IPNet
+	if s.scope.VPC().PublicSubnetMaskSize != nil || s.scope.VPC().PrivateSubnetMaskSize != nil {
+		publicSubnetCIDRs, privateSubnetCIDRs, err = s.allocateSubnetCIDRs(subnetCIDRs, len(zones))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		publicSubnetCIDRs, err = cidr.SplitIntoSubnetsIPv4(subnetCIDRs[0].String(), len(zones))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed splitting CIDR %q into public subnets", subnetCIDRs[0].String())
+		}
+		privateSubnetCIDRs = append(subnetCIDRs[:0], subnetCIDRs[1:]...)
 	}
-	privateSubnetCIDRs := append(subnetCIDRs[:0], subnetCIDRs[1:]...)
 
 	if s.scope.VPC().IsIPv6Enabled() {
 		ipv6SubnetCIDRs, err = cidr.SplitIntoSubnetsIPv6(s.scope.VPC().IPv6.CidrBlock, numSubnets)
@@ -339,6 +428,34 @@ func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
 	return subnets, nil
 }
 
+// allocateSubnetCIDRs divides the VPC CIDR into public and private subnets of the requested
+// netmask sizes. A size that isn't set falls back to the one of the even split.
+func (s *Service) allocateSubnetCIDRs(evenSplit []*net.IPNet, numZones int) ([]*net.IPNet, []*net.IPNet, error) {
+	privateMaskSize, _ := evenSplit[0].Mask.Size()
+	if s.scope.VPC().PrivateSubnetMaskSize != nil {
+		privateMaskSize = *s.scope.VPC().PrivateSubnetMaskSize
+	}
+	publicMaskSize, _ := evenSplit[0].Mask.Size()
+	publicMaskSize += int(math.Ceil(math.Log2(float64(numZones))))
+	if s.scope.VPC().PublicSubnetMaskSize != nil {
+		publicMaskSize = *s.scope.VPC().PublicSubnetMaskSize
+	}
+
+	prefixLengths := make([]int, 0, 2*numZones)
+	for i := 0; i < numZones; i++ {
+		prefixLengths = append(prefixLengths, publicMaskSize)
+	}
+	for i := 0; i < numZones; i++ {
+		prefixLengths = append(prefixLengths, privateMaskSize)
+	}
+
+	subnetCIDRs, err := cidr.AllocateSubnetsIPv4(s.scope.VPC().CidrBlock, prefixLengths)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed allocating /%d public and /%d private subnets in VPC CIDR %q", publicMaskSize, privateMaskSize, s.scope.VPC().CidrBlock)
+	}
+	return subnetCIDRs[:numZones], subnetCIDRs[numZones:], nil
+}
+
 func (s *Service) deleteSubnets() error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping subnets deletion in unmanaged mode")
@@ -385,6 +502,8 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 			ResourceID:       *ec2sn.SubnetId,
 			AvailabilityZone: *ec2sn.AvailabilityZone,
 			Tags:             converters.TagsToMap(ec2sn.Tags),
+			// Recording the current value lets managed subnets be updated when the spec changes.
+			MapPublicIPOnLaunch: ec2sn.MapPublicIpOnLaunch,
 		}
 		// For IPv6 subnets, both, ipv4 and 6 have to be defined so pods can have ipv6 cidr ranges.
 		spec.CidrBlock = aws.StringValue(ec2sn.CidrBlock)
@@ -537,7 +656,8 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 	// interface to associate Carrier IP Address on launch[2].
 	// [1] https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_ModifySubnetAttribute.html
 	// [2] https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_InstanceNetworkInterfaceSpecification.html
-	if sn.IsPublic && !sn.IsEdgeWavelength() {
+	// Public subnets map public IPs on launch unless explicitly disabled.
+	if sn.IsPublic && !sn.IsEdgeWavelength() && ptr.Deref(sn.MapPublicIPOnLaunch, true) {
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 			if _, err := s.EC2Client.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{
 				SubnetId: out.Subnet.SubnetId,
@@ -573,12 +693,13 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 
 	subnet := &infrav1.SubnetSpec{
 		// Preserve the original identifier. The AWS identifier `subnet-<xyz>` is stored in the ResourceID field.
-		ID:               sn.ID,
-		ResourceID:       *out.Subnet.SubnetId,
-		AvailabilityZone: *out.Subnet.AvailabilityZone,
-		CidrBlock:        *out.Subnet.CidrBlock, // TODO: this will panic in case of IPv6 only subnets...
-		IsPublic:         sn.IsPublic,
-		Tags:             sn.Tags,
+		ID:                  sn.ID,
+		ResourceID:          *out.Subnet.SubnetId,
+		AvailabilityZone:    *out.Subnet.AvailabilityZone,
+		CidrBlock:           *out.Subnet.CidrBlock, // TODO: this will panic in case of IPv6 only subnets...
+		IsPublic:            sn.IsPublic,
+		Tags:                sn.Tags,
+		MapPublicIPOnLaunch: sn.MapPublicIPOnLaunch,
 	}
 	for _, set := range out.Subnet.Ipv6CidrBlockAssociationSet {
 		if *set.Ipv6CidrBlockState.State == ec2.SubnetCidrBlockStateCodeAssociated {
@@ -2483,6 +2483,105 @@ func TestReconcileSubnets(t *testing.T) {
 					}, nil).AnyTimes()
 			},
 		},
+		{
+			name: "Managed VPC, existing subnets, mapPublicIpOnLaunch changed in spec, should update the public subnet",
+			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: []infrav1.SubnetSpec{
+					{
+						ID:                  "subnet-1",
+						AvailabilityZone:    "us-east-1a",
+						CidrBlock:           "10.0.0.0/17",
+						IsPublic:            true,
+						MapPublicIPOnLaunch: aws.Bool(false),
+					},
+					{
+						ID:                  "subnet-2",
+						AvailabilityZone:    "us-east-1a",
+						CidrBlock:           "10.0.128.0/17",
+						IsPublic:            false,
+						MapPublicIPOnLaunch: aws.Bool(true),
+					},
+				},
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("state"),
+							Values: []*string{aws.String("pending"), aws.String("available")},
+						},
+						{
+							Name:   aws.String("vpc-id"),
+							Values: []*string{aws.String(subnetsVPCID)},
+						},
+					},
+				})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								VpcId:               aws.String(subnetsVPCID),
+								SubnetId:            aws.String("subnet-1"),
+								AvailabilityZone:    aws.String("us-east-1a"),
+								CidrBlock:           aws.String("10.0.0.0/17"),
+								MapPublicIpOnLaunch: aws.Bool(true),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("public"),
+									},
+								},
+							},
+							{
+								VpcId:               aws.String(subnetsVPCID),
+								SubnetId:            aws.String("subnet-2"),
+								AvailabilityZone:    aws.String("us-east-1a"),
+								CidrBlock:           aws.String("10.0.128.0/17"),
+								MapPublicIpOnLaunch: aws.Bool(false),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("private"),
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+
+				m.CreateTagsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil).AnyTimes()
+
+				m.DescribeAvailabilityZonesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeAvailabilityZonesOutput{
+						AvailabilityZones: []*ec2.AvailabilityZone{
+							{
+								ZoneName: aws.String("us-east-1a"),
+								ZoneType: aws.String("availability-zone"),
+							},
+						},
+					}, nil).AnyTimes()
+
+				// Only the public subnet maps public IPs on launch.
+				m.ModifySubnetAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifySubnetAttributeInput{
+					SubnetId: aws.String("subnet-1"),
+					MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
+						Value: aws.Bool(false),
+					},
+				})).
+					Return(&ec2.ModifySubnetAttributeOutput{}, nil)
+			},
+		},
 		{
 			name: "Managed VPC, existing public subnet, 2 subnets in spec, should create 1 subnet, custom Name tag",
 			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
@@ -3467,6 +3566,174 @@ type ScopeBuilder interface {
 	Build() (scope.NetworkScope, error)
 }
 
+func TestPodNetworkSubnets(t *testing.T) {
+	g := NewWithT(t)
+
+	scope, err := NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+		VPC: infrav1.VPCSpec{AvailabilityZoneUsageLimit: aws.Int(2)},
+		PodNetwork: &infrav1.PodNetworkSpec{
+			CidrBlock: "100.64.0.0/16",
+			Subnets: []infrav1.PodSubnetSpec{
+				{CidrBlock: "100.64.0.0/18", AvailabilityZone: "us-east-1a"},
+				{CidrBlock: "100.64.64.0/18", AvailabilityZone: "us-east-1b"},
+			},
+		},
+	}).Build()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(scope)
+	secondarySubnets, err := s.getSecondarySubnets()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(secondarySubnets).To(HaveLen(2))
+	g.Expect(secondarySubnets[0].ID).To(Equal("test-cluster-subnet-secondary-us-east-1a"))
+	g.Expect(secondarySubnets[0].Tags).To(HaveKeyWithValue(infrav1.NameAWSSubnetAssociation, infrav1.SecondarySubnetTagValue))
+	g.Expect(secondarySubnets[1].CidrBlock).To(Equal("100.64.64.0/18"))
+
+	// Only created secondary subnets are reported, and they are never used to place machines.
+	secondarySubnets[0].ResourceID = "subnet-pod-1"
+	subnets := append(infrav1.Subnets{
+		{ID: "subnet-private", ResourceID: "subnet-private", AvailabilityZone: "us-east-1a", CidrBlock: "10.0.0.0/24"},
+	}, secondarySubnets...)
+	s.setPodSubnetsStatus(subnets)
+	g.Expect(scope.Network().PodSubnets).To(Equal([]infrav1.PodSubnetStatus{
+		{ID: "subnet-pod-1", AvailabilityZone: "us-east-1a", CidrBlock: "100.64.0.0/18"},
+	}))
+	g.Expect(subnets.FilterPrivate().FilterNonCni().IDs()).To(ConsistOf("subnet-private"))
+}
+
+func TestGetSecondarySubnets(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().DescribeAvailabilityZonesWithContext(context.TODO(), gomock.Any()).
+		Return(&ec2.DescribeAvailabilityZonesOutput{
+			AvailabilityZones: []*ec2.AvailabilityZone{
+				{ZoneName: aws.String("us-east-1a")},
+				{ZoneName: aws.String("us-east-1b")},
+				{ZoneName: aws.String("us-east-1c")},
+			},
+		}, nil)
+
+	scope, err := NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+		VPC: infrav1.VPCSpec{
+			AvailabilityZoneUsageLimit: aws.Int(3),
+			AllowedAvailabilityZones:   []string{"us-east-1a", "us-east-1b"},
+		},
+		PodNetwork: &infrav1.PodNetworkSpec{CidrBlock: "100.64.0.0/16"},
+	}).Build()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+
+	// All the subnets split from the CIDR block are kept, even with fewer allowed zones.
+	secondarySubnets, err := s.getSecondarySubnets()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(secondarySubnets).To(HaveLen(3))
+	g.Expect(secondarySubnets[0].ID).To(Equal("test-cluster-subnet-secondary-us-east-1a"))
+	g.Expect(secondarySubnets[1].ID).To(Equal("test-cluster-subnet-secondary-us-east-1b"))
+	g.Expect(secondarySubnets[2].ID).To(Equal("test-cluster-subnet-secondary-us-east-1a-1"))
+	g.Expect(secondarySubnets[2].AvailabilityZone).To(Equal("us-east-1a"))
+	g.Expect(secondarySubnets[2].CidrBlock).NotTo(Equal(secondarySubnets[0].CidrBlock))
+}
+
+func TestGetAvailableZones(t *testing.T) {
+	testCases := []struct {
+		name        string
+		vpc         infrav1.VPCSpec
+		want        []string
+		expectError bool
+	}{
+		{
+			name: "returns all available zones by default",
+			want: []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+		},
+		{
+			name: "returns only the allowed zones",
+			vpc:  infrav1.VPCSpec{AllowedAvailabilityZones: []string{"us-east-1c", "us-east-1a", "us-east-1z"}},
+			want: []string{"us-east-1a", "us-east-1c"},
+		},
+		{
+			name: "skips the excluded zones",
+			vpc:  infrav1.VPCSpec{ExcludedAvailabilityZones: []string{"us-east-1b"}},
+			want: []string{"us-east-1a", "us-east-1c"},
+		},
+		{
+			name:        "fails when no zone is allowed",
+			vpc:         infrav1.VPCSpec{AllowedAvailabilityZones: []string{"us-east-1z"}},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeAvailabilityZonesWithContext(context.TODO(), gomock.Any()).
+				Return(&ec2.DescribeAvailabilityZonesOutput{
+					AvailabilityZones: []*ec2.AvailabilityZone{
+						{ZoneName: aws.String("us-east-1c")},
+						{ZoneName: aws.String("us-east-1a")},
+						{ZoneName: aws.String("us-east-1b")},
+					},
+				}, nil)
+
+			scope, err := NewClusterScope().WithNetwork(&infrav1.NetworkSpec{VPC: tc.vpc}).Build()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			zones, err := s.getAvailableZones()
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(zones).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestDefaultSubnetsMaskSize(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().DescribeAvailabilityZonesWithContext(context.TODO(), gomock.Any()).
+		Return(&ec2.DescribeAvailabilityZonesOutput{
+			AvailabilityZones: []*ec2.AvailabilityZone{
+				{ZoneName: aws.String("us-east-1a")},
+				{ZoneName: aws.String("us-east-1b")},
+			},
+		}, nil)
+
+	scope, err := NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+		VPC: infrav1.VPCSpec{
+			CidrBlock:             "10.0.0.0/16",
+			PublicSubnetMaskSize:  aws.Int(24),
+			PrivateSubnetMaskSize: aws.Int(20),
+		},
+	}).Build()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+
+	subnets, err := s.getDefaultSubnets()
+	g.Expect(err).NotTo(HaveOccurred())
+	cidrs := map[string]string{}
+	for _, subnet := range subnets {
+		cidrs[subnet.ID] = subnet.CidrBlock
+	}
+	g.Expect(cidrs).To(Equal(map[string]string{
+		"test-cluster-subnet-public-us-east-1a":  "10.0.32.0/24",
+		"test-cluster-subnet-public-us-east-1b":  "10.0.33.0/24",
+		"test-cluster-subnet-private-us-east-1a": "10.0.0.0/20",
+		"test-cluster-subnet-private-us-east-1b": "10.0.16.0/20",
+	}))
+}
+
 func NewClusterScope() *ClusterScopeBuilder {
 	return &ClusterScopeBuilder{
 		customizers: []func(p *scope.ClusterScopeParams){},
@@ -0,0 +1,451 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+const (
+	// hostedZoneIDPrefix is the prefix Route53 returns on hosted zone IDs.
+	hostedZoneIDPrefix = "/hostedzone/"
+
+	// maxCallerReferenceLength is the maximum length Route53 accepts for a caller reference.
+	maxCallerReferenceLength = 128
+)
+
+// ReconcileControlPlaneDNS ensures the hosted zone holds an alias record pointing at the API
+// server load balancer. Unless an existing hosted zone is referenced by ID, the private hosted
+// zone is created when missing and associated with the cluster VPC.
+// When an internal secondary control plane load balancer is configured, records in private hosted
+// zones point at it, and a referenced hosted zone is paired with a private hosted zone of the same
+// name, so clients within the VPC resolve the endpoint to the internal load balancer.
+func (s *Service) ReconcileControlPlaneDNS() error {
+	spec := s.scope.ControlPlaneDNS()
+	if spec == nil {
+		return nil
+	}
+
+	s.scope.Debug("Reconciling control plane DNS")
+
+	lb := s.scope.Network().APIServerELB
+	if lb.DNSName == "" || lb.CanonicalHostedZoneID == "" {
+		return awserrors.NewFailedDependency("control plane load balancer is not ready yet")
+	}
+
+	internalLB, err := s.internalLoadBalancer()
+	if err != nil {
+		return err
+	}
+
+	recordName := s.RecordName()
+	status := &infrav1.ControlPlaneDNSStatus{
+		HostedZoneID: trimHostedZoneID(spec.HostedZoneID),
+		RecordName:   recordName,
+	}
+	if status.HostedZoneID == "" {
+		if status.HostedZoneID, err = s.reconcileHostedZone(spec.HostedZoneName); err != nil {
+			return err
+		}
+		if internalLB != nil {
+			lb = *internalLB
+		}
+	} else if internalLB != nil {
+		internalZoneID, err := s.reconcileHostedZone(spec.HostedZoneName)
+		if err != nil {
+			return err
+		}
+		if internalZoneID == status.HostedZoneID {
+			// The referenced hosted zone is the private hosted zone of the VPC.
+			lb = *internalLB
+		} else {
+			if err := s.changeAliasRecords(route53.ChangeActionUpsert, internalZoneID, recordName, internalLB, aliasRecordTypes(internalLB)...); err != nil {
+				return errors.Wrapf(err, "failed to upsert record %q in hosted zone %q", recordName, internalZoneID)
+			}
+			status.InternalHostedZoneID = internalZoneID
+		}
+	}
+
+	if err := s.changeAliasRecords(route53.ChangeActionUpsert, status.HostedZoneID, recordName, &lb, aliasRecordTypes(&lb)...); err != nil {
+		return errors.Wrapf(err, "failed to upsert record %q in hosted zone %q", recordName, status.HostedZoneID)
+	}
+
+	s.scope.Network().ControlPlaneDNS = status
+
+	return nil
+}
+
+// DeleteControlPlaneDNS removes the control plane records, and the hosted zones when they were
+// created by this provider.
+func (s *Service) DeleteControlPlaneDNS() error {
+	spec := s.scope.ControlPlaneDNS()
+	status := s.scope.Network().ControlPlaneDNS
+	if spec == nil || status == nil || status.HostedZoneID == "" {
+		return nil
+	}
+
+	s.scope.Debug("Deleting control plane DNS")
+
+	primaryLB := s.scope.Network().APIServerELB
+	secondaryLB := s.scope.Network().SecondaryAPIServerELB
+
+	if status.InternalHostedZoneID != "" {
+		if err := s.deleteRecord(status.InternalHostedZoneID, status.RecordName, &secondaryLB); err != nil {
+			return err
+		}
+		if err := s.deleteOwnedHostedZone(status.InternalHostedZoneID); err != nil {
+			return err
+		}
+		status.InternalHostedZoneID = ""
+	}
+
+	// Records in private hosted zones may point at either load balancer, depending on whether
+	// the secondary control plane load balancer was ready, and only the matching one is deleted.
+	for _, lb := range []*infrav1.LoadBalancer{&primaryLB, &secondaryLB} {
+		if err := s.deleteRecord(status.HostedZoneID, status.RecordName, lb); err != nil {
+			return err
+		}
+	}
+
+	// Referenced hosted zones are managed outside of the cluster.
+	if spec.HostedZoneID == "" {
+		if err := s.deleteOwnedHostedZone(status.HostedZoneID); err != nil {
+			return err
+		}
+	}
+
+	s.scope.Network().ControlPlaneDNS = nil
+	return nil
+}
+
+// internalLoadBalancer returns the secondary control plane load balancer when it is internal, or
+// nil when there is none.
+func (s *Service) internalLoadBalancer() (*infrav1.LoadBalancer, error) {
+	lbSpecs := s.scope.ControlPlaneLoadBalancers()
+	if len(lbSpecs) < 2 || lbSpecs[1] == nil || ptr.Deref(lbSpecs[1].Scheme, infrav1.ELBSchemeInternal) != infrav1.ELBSchemeInternal {
+		return nil, nil
+	}
+
+	lb := s.scope.Network().SecondaryAPIServerELB
+	if lb.DNSName == "" || lb.CanonicalHostedZoneID == "" {
+		return nil, awserrors.NewFailedDependency("secondary control plane load balancer is not ready yet")
+	}
+	return &lb, nil
+}
+
+func (s *Service) deleteRecord(zoneID, recordName string, lb *infrav1.LoadBalancer) error {
+	if lb.DNSName == "" || lb.CanonicalHostedZoneID == "" {
+		return nil
+	}
+
+	// Records are deleted one at a time, so that a missing record does not prevent the deletion of the other one.
+	for _, recordType := range aliasRecordTypes(lb) {
+		found, err := s.hasAliasRecord(zoneID, recordName, recordType, lb)
+		switch {
+		case isHostedZoneNotFound(err):
+			return nil
+		case err != nil:
+			return errors.Wrapf(err, "failed to describe %s record %q in hosted zone %q", recordType, recordName, zoneID)
+		case !found:
+			continue
+		}
+
+		if err := s.changeAliasRecords(route53.ChangeActionDelete, zoneID, recordName, lb, recordType); err != nil && !isHostedZoneNotFound(err) {
+			return errors.Wrapf(err, "failed to delete %s record %q in hosted zone %q", recordType, recordName, zoneID)
+		}
+	}
+	return nil
+}
+
+// hasAliasRecord returns whether the hosted zone holds the record of the given type pointing at the load balancer.
+func (s *Service) hasAliasRecord(zoneID, recordName, recordType string, lb *infrav1.LoadBalancer) (bool, error) {
+	out, err := s.Route53Client.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(zoneID),
+		StartRecordName: aws.String(toFQDN(recordName)),
+		StartRecordType: aws.String(recordType),
+		MaxItems:        aws.String("1"),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// Record sets are listed from the given name and type, so the first one is the record when it exists.
+	if len(out.ResourceRecordSets) == 0 {
+		return false, nil
+	}
+	recordSet := out.ResourceRecordSets[0]
+	if !strings.EqualFold(aws.StringValue(recordSet.Name), toFQDN(recordName)) ||
+		aws.StringValue(recordSet.Type) != recordType ||
+		recordSet.AliasTarget == nil {
+		return false, nil
+	}
+	return aliasDNSName(aws.StringValue(recordSet.AliasTarget.DNSName)) == aliasDNSName(lb.DNSName), nil
+}
+
+func (s *Service) deleteOwnedHostedZone(zoneID string) error {
+	owned, err := s.isHostedZoneOwned(zoneID)
+	switch {
+	case isHostedZoneNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	case !owned:
+		return nil
+	}
+
+	if _, err := s.Route53Client.DeleteHostedZone(&route53.DeleteHostedZoneInput{
+		Id: aws.String(zoneID),
+	}); err != nil && !isHostedZoneNotFound(err) {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteHostedZone", "Failed to delete managed hosted zone %q: %v", zoneID, err)
+		return errors.Wrapf(err, "failed to delete hosted zone %q", zoneID)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteHostedZone", "Deleted managed hosted zone %q", zoneID)
+	return nil
+}
+
+// RecordName returns the fully qualified name of the control plane record.
+func (s *Service) RecordName() string {
+	return RecordName(s.scope.ControlPlaneDNS(), s.scope.Name())
+}
+
+// RecordName returns the fully qualified name of the control plane record of the cluster, or an
+// empty string when the control plane DNS is not configured.
+func RecordName(spec *infrav1.ControlPlaneDNS, clusterName string) string {
+	if spec == nil {
+		return ""
+	}
+
+	name := spec.RecordName
+	if name == "" {
+		name = fmt.Sprintf("api.%s", clusterName)
+	}
+
+	return fmt.Sprintf("%s.%s", name, strings.TrimSuffix(spec.HostedZoneName, "."))
+}
+
+func (s *Service) reconcileHostedZone(zoneName string) (string, error) {
+	zoneID, err := s.findPrivateHostedZone(zoneName)
+	if err != nil {
+		return "", err
+	}
+
+	if zoneID == "" {
+		return s.createHostedZone(zoneName)
+	}
+
+	if err := s.associateVPC(zoneID); err != nil {
+		return "", err
+	}
+
+	return zoneID, nil
+}
+
+func (s *Service) findPrivateHostedZone(zoneName string) (string, error) {
+	fqdn := toFQDN(zoneName)
+
+	input := &route53.ListHostedZonesByNameInput{
+		DNSName: aws.String(fqdn),
+	}
+	for {
+		out, err := s.Route53Client.ListHostedZonesByName(input)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to list hosted zones named %q", fqdn)
+		}
+
+		for _, zone := range out.HostedZones {
+			if aws.StringValue(zone.Name) != fqdn {
+				// Zones are returned in lexicographic order, so there are no more matches.
+				return "", nil
+			}
+			if zone.Config != nil && aws.BoolValue(zone.Config.PrivateZone) {
+				return trimHostedZoneID(aws.StringValue(zone.Id)), nil
+			}
+		}
+
+		if !aws.BoolValue(out.IsTruncated) {
+			return "", nil
+		}
+		input.DNSName = out.NextDNSName
+		input.HostedZoneId = out.NextHostedZoneId
+	}
+}
+
+func (s *Service) createHostedZone(zoneName string) (string, error) {
+	vpc := s.scope.VPC()
+	if vpc.ID == "" {
+		return "", awserrors.NewFailedDependency("cluster VPC is not ready yet")
+	}
+
+	out, err := s.Route53Client.CreateHostedZone(&route53.CreateHostedZoneInput{
+		Name:            aws.String(toFQDN(zoneName)),
+		CallerReference: aws.String(s.callerReference(zoneName)),
+		HostedZoneConfig: &route53.HostedZoneConfig{
+			Comment:     aws.String(fmt.Sprintf("Control plane hosted zone for cluster %s", s.scope.Name())),
+			PrivateZone: aws.Bool(true),
+		},
+		VPC: &route53.VPC{
+			VPCId:     aws.String(vpc.ID),
+			VPCRegion: aws.String(s.scope.Region()),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateHostedZone", "Failed to create managed hosted zone %q: %v", zoneName, err)
+		return "", errors.Wrapf(err, "failed to create hosted zone %q", zoneName)
+	}
+
+	zoneID := trimHostedZoneID(aws.StringValue(out.HostedZone.Id))
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateHostedZone", "Created managed hosted zone %q", zoneID)
+
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(zoneName),
+		Role:        aws.String(infrav1.APIServerRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	})
+	if _, err := s.Route53Client.ChangeTagsForResource(&route53.ChangeTagsForResourceInput{
+		ResourceId:   aws.String(zoneID),
+		ResourceType: aws.String(route53.TagResourceTypeHostedzone),
+		AddTags:      converters.MapToRoute53Tags(tags),
+	}); err != nil {
+		return "", errors.Wrapf(err, "failed to tag hosted zone %q", zoneID)
+	}
+
+	return zoneID, nil
+}
+
+func (s *Service) associateVPC(zoneID string) error {
+	vpc := s.scope.VPC()
+	if vpc.ID == "" {
+		return awserrors.NewFailedDependency("cluster VPC is not ready yet")
+	}
+
+	out, err := s.Route53Client.GetHostedZone(&route53.GetHostedZoneInput{
+		Id: aws.String(zoneID),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe hosted zone %q", zoneID)
+	}
+
+	for _, v := range out.VPCs {
+		if aws.StringValue(v.VPCId) == vpc.ID {
+			return nil
+		}
+	}
+
+	if _, err := s.Route53Client.AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+		HostedZoneId: aws.String(zoneID),
+		VPC: &route53.VPC{
+			VPCId:     aws.String(vpc.ID),
+			VPCRegion: aws.String(s.scope.Region()),
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to associate VPC %q with hosted zone %q", vpc.ID, zoneID)
+	}
+
+	s.scope.Info("Associated VPC with hosted zone", "vpc", vpc.ID, "hosted-zone", zoneID)
+	return nil
+}
+
+func (s *Service) isHostedZoneOwned(zoneID string) (bool, error) {
+	out, err := s.Route53Client.ListTagsForResource(&route53.ListTagsForResourceInput{
+		ResourceId:   aws.String(zoneID),
+		ResourceType: aws.String(route53.TagResourceTypeHostedzone),
+	})
+	if err != nil {
+		return false, err
+	}
+	if out.ResourceTagSet == nil {
+		return false, nil
+	}
+
+	return converters.Route53TagsToMap(out.ResourceTagSet.Tags).HasOwned(s.scope.Name()), nil
+}
+
+// aliasRecordTypes returns the types of the alias records pointing at the load balancer. Dual-stack load
+// balancers are also published with an AAAA record, so that IPv6-only clients resolve the endpoint.
+func aliasRecordTypes(lb *infrav1.LoadBalancer) []string {
+	if lb.IPAddressType == infrav1.LoadBalancerIPAddressTypeDualStack {
+		return []string{route53.RRTypeA, route53.RRTypeAaaa}
+	}
+	return []string{route53.RRTypeA}
+}
+
+func (s *Service) changeAliasRecords(action, zoneID, recordName string, lb *infrav1.LoadBalancer, recordTypes ...string) error {
+	changes := make([]*route53.Change, 0, len(recordTypes))
+	for _, recordType := range recordTypes {
+		changes = append(changes, &route53.Change{
+			Action: aws.String(action),
+			ResourceRecordSet: &route53.ResourceRecordSet{
+				Name: aws.String(toFQDN(recordName)),
+				Type: aws.String(recordType),
+				AliasTarget: &route53.AliasTarget{
+					DNSName:              aws.String(lb.DNSName),
+					HostedZoneId:         aws.String(lb.CanonicalHostedZoneID),
+					EvaluateTargetHealth: aws.Bool(false),
+				},
+			},
+		})
+	}
+
+	_, err := s.Route53Client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String(fmt.Sprintf("Control plane endpoint for cluster %s", s.scope.Name())),
+			Changes: changes,
+		},
+	})
+	return err
+}
+
+func (s *Service) callerReference(zoneName string) string {
+	ref := fmt.Sprintf("%s-%s-%s", s.scope.Namespace(), s.scope.Name(), strings.TrimSuffix(zoneName, "."))
+	if len(ref) > maxCallerReferenceLength {
+		ref = ref[:maxCallerReferenceLength]
+	}
+	return ref
+}
+
+func isHostedZoneNotFound(err error) bool {
+	code, ok := awserrors.Code(errors.Cause(err))
+	return ok && code == route53.ErrCodeNoSuchHostedZone
+}
+
+// aliasDNSName normalizes the DNS name of an alias target, which Route53 returns lowercased, fully qualified and
+// possibly prefixed with dualstack for load balancers.
+func aliasDNSName(name string) string {
+	return strings.TrimPrefix(strings.ToLower(toFQDN(name)), "dualstack.")
+}
+
+func toFQDN(name string) string {
+	return strings.TrimSuffix(name, ".") + "."
+}
+
+func trimHostedZoneID(id string) string {
+	return strings.TrimPrefix(id, hostedZoneIDPrefix)
+}
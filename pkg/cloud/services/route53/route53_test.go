@@ -0,0 +1,540 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/route53/mock_route53iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	testClusterName      = "test-cluster"
+	testClusterNamespace = "test-namespace"
+	testVPCID            = "vpc-123"
+	testZoneName         = "example.internal"
+	testZoneID           = "Z123"
+	testLBDNSName        = "test-cluster-apiserver.elb.us-east-1.amazonaws.com"
+	testLBZoneID         = "Z35SXDOTRQ7X7K"
+	testInternalDNSName  = "test-cluster-internal.elb.us-east-1.amazonaws.com"
+)
+
+func TestReconcileControlPlaneDNS(t *testing.T) {
+	testCases := []struct {
+		name        string
+		dns         *infrav1.ControlPlaneDNS
+		lb          infrav1.LoadBalancer
+		secondary   *infrav1.AWSLoadBalancerSpec
+		secondaryLB infrav1.LoadBalancer
+		expect      func(m *mock_route53iface.MockRoute53APIMockRecorder)
+		wantErr     bool
+		wantDepErr  bool
+		wantStatus  *infrav1.ControlPlaneDNSStatus
+	}{
+		{
+			name:   "does nothing when control plane DNS is not configured",
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {},
+		},
+		{
+			name:       "waits for the load balancer to be ready",
+			dns:        &infrav1.ControlPlaneDNS{HostedZoneName: testZoneName},
+			expect:     func(m *mock_route53iface.MockRoute53APIMockRecorder) {},
+			wantErr:    true,
+			wantDepErr: true,
+		},
+		{
+			name: "creates and tags the hosted zone when it does not exist",
+			dns:  &infrav1.ControlPlaneDNS{HostedZoneName: testZoneName},
+			lb:   infrav1.LoadBalancer{DNSName: testLBDNSName, CanonicalHostedZoneID: testLBZoneID},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListHostedZonesByName(gomock.Eq(&route53.ListHostedZonesByNameInput{
+					DNSName: aws.String(testZoneName + "."),
+				})).Return(&route53.ListHostedZonesByNameOutput{}, nil)
+				m.CreateHostedZone(gomock.Any()).DoAndReturn(func(input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error) {
+					if aws.StringValue(input.VPC.VPCId) != testVPCID {
+						t.Fatalf("unexpected VPC %q", aws.StringValue(input.VPC.VPCId))
+					}
+					if !aws.BoolValue(input.HostedZoneConfig.PrivateZone) {
+						t.Fatal("expected a private hosted zone")
+					}
+					return &route53.CreateHostedZoneOutput{
+						HostedZone: &route53.HostedZone{Id: aws.String("/hostedzone/" + testZoneID)},
+					}, nil
+				})
+				m.ChangeTagsForResource(gomock.Any()).Return(&route53.ChangeTagsForResourceOutput{}, nil)
+				m.ChangeResourceRecordSets(gomock.Any()).DoAndReturn(func(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+					change := input.ChangeBatch.Changes[0]
+					if aws.StringValue(change.Action) != route53.ChangeActionUpsert {
+						t.Fatalf("unexpected action %q", aws.StringValue(change.Action))
+					}
+					if aws.StringValue(change.ResourceRecordSet.Name) != "api.test-cluster.example.internal." {
+						t.Fatalf("unexpected record name %q", aws.StringValue(change.ResourceRecordSet.Name))
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				})
+			},
+			wantStatus: &infrav1.ControlPlaneDNSStatus{
+				HostedZoneID: testZoneID,
+				RecordName:   "api.test-cluster.example.internal",
+			},
+		},
+		{
+			name: "associates the VPC with an existing hosted zone",
+			dns:  &infrav1.ControlPlaneDNS{HostedZoneName: testZoneName, RecordName: "k8s"},
+			lb:   infrav1.LoadBalancer{DNSName: testLBDNSName, CanonicalHostedZoneID: testLBZoneID},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListHostedZonesByName(gomock.Any()).Return(&route53.ListHostedZonesByNameOutput{
+					HostedZones: []*route53.HostedZone{
+						{
+							Id:     aws.String("/hostedzone/" + testZoneID),
+							Name:   aws.String(testZoneName + "."),
+							Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)},
+						},
+					},
+				}, nil)
+				m.GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+					VPCs: []*route53.VPC{{VPCId: aws.String("vpc-other")}},
+				}, nil)
+				m.AssociateVPCWithHostedZone(gomock.Any()).Return(&route53.AssociateVPCWithHostedZoneOutput{}, nil)
+				m.ChangeResourceRecordSets(gomock.Any()).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+			},
+			wantStatus: &infrav1.ControlPlaneDNSStatus{
+				HostedZoneID: testZoneID,
+				RecordName:   "k8s.example.internal",
+			},
+		},
+		{
+			name: "uses a referenced hosted zone as is",
+			dns:  &infrav1.ControlPlaneDNS{HostedZoneName: "example.com", HostedZoneID: "/hostedzone/ZPUBLIC", RecordName: "k8s"},
+			lb:   infrav1.LoadBalancer{DNSName: testLBDNSName, CanonicalHostedZoneID: testLBZoneID},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ChangeResourceRecordSets(gomock.Any()).DoAndReturn(func(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+					if aws.StringValue(input.HostedZoneId) != "ZPUBLIC" {
+						t.Fatalf("unexpected hosted zone %q", aws.StringValue(input.HostedZoneId))
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				})
+			},
+			wantStatus: &infrav1.ControlPlaneDNSStatus{
+				HostedZoneID: "ZPUBLIC",
+				RecordName:   "k8s.example.com",
+			},
+		},
+		{
+			name: "publishes the internal load balancer in a private hosted zone next to a referenced hosted zone",
+			dns:  &infrav1.ControlPlaneDNS{HostedZoneName: "example.com", HostedZoneID: "ZPUBLIC", RecordName: "k8s"},
+			lb:   infrav1.LoadBalancer{DNSName: testLBDNSName, CanonicalHostedZoneID: testLBZoneID},
+			secondary: &infrav1.AWSLoadBalancerSpec{
+				Name:   aws.String("internal-apiserver"),
+				Scheme: &infrav1.ELBSchemeInternal,
+			},
+			secondaryLB: infrav1.LoadBalancer{DNSName: testInternalDNSName, CanonicalHostedZoneID: testLBZoneID},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListHostedZonesByName(gomock.Any()).Return(&route53.ListHostedZonesByNameOutput{
+					HostedZones: []*route53.HostedZone{
+						{
+							Id:     aws.String("/hostedzone/ZPUBLIC"),
+							Name:   aws.String("example.com."),
+							Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(false)},
+						},
+						{
+							Id:     aws.String("/hostedzone/" + testZoneID),
+							Name:   aws.String("example.com."),
+							Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)},
+						},
+					},
+				}, nil)
+				m.GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+					VPCs: []*route53.VPC{{VPCId: aws.String(testVPCID)}},
+				}, nil)
+				targets := map[string]string{}
+				m.ChangeResourceRecordSets(gomock.Any()).Times(2).DoAndReturn(func(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+					targets[aws.StringValue(input.HostedZoneId)] = aws.StringValue(input.ChangeBatch.Changes[0].ResourceRecordSet.AliasTarget.DNSName)
+					if len(targets) == 2 && (targets["ZPUBLIC"] != testLBDNSName || targets[testZoneID] != testInternalDNSName) {
+						t.Fatalf("unexpected record targets %v", targets)
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				})
+			},
+			wantStatus: &infrav1.ControlPlaneDNSStatus{
+				HostedZoneID:         "ZPUBLIC",
+				RecordName:           "k8s.example.com",
+				InternalHostedZoneID: testZoneID,
+			},
+		},
+		{
+			name: "points the record of a private hosted zone at the internal load balancer",
+			dns:  &infrav1.ControlPlaneDNS{HostedZoneName: testZoneName},
+			lb:   infrav1.LoadBalancer{DNSName: testLBDNSName, CanonicalHostedZoneID: testLBZoneID},
+			secondary: &infrav1.AWSLoadBalancerSpec{
+				Name:   aws.String("internal-apiserver"),
+				Scheme: &infrav1.ELBSchemeInternal,
+			},
+			secondaryLB: infrav1.LoadBalancer{DNSName: testInternalDNSName, CanonicalHostedZoneID: testLBZoneID},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListHostedZonesByName(gomock.Any()).Return(&route53.ListHostedZonesByNameOutput{
+					HostedZones: []*route53.HostedZone{
+						{
+							Id:     aws.String("/hostedzone/" + testZoneID),
+							Name:   aws.String(testZoneName + "."),
+							Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)},
+						},
+					},
+				}, nil)
+				m.GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+					VPCs: []*route53.VPC{{VPCId: aws.String(testVPCID)}},
+				}, nil)
+				m.ChangeResourceRecordSets(gomock.Any()).DoAndReturn(func(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+					if target := aws.StringValue(input.ChangeBatch.Changes[0].ResourceRecordSet.AliasTarget.DNSName); target != testInternalDNSName {
+						t.Fatalf("unexpected record target %q", target)
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				})
+			},
+			wantStatus: &infrav1.ControlPlaneDNSStatus{
+				HostedZoneID: testZoneID,
+				RecordName:   "api.test-cluster.example.internal",
+			},
+		},
+		{
+			name: "waits for the internal load balancer to be ready",
+			dns:  &infrav1.ControlPlaneDNS{HostedZoneName: "example.com", HostedZoneID: "ZPUBLIC"},
+			lb:   infrav1.LoadBalancer{DNSName: testLBDNSName, CanonicalHostedZoneID: testLBZoneID},
+			secondary: &infrav1.AWSLoadBalancerSpec{
+				Name:   aws.String("internal-apiserver"),
+				Scheme: &infrav1.ELBSchemeInternal,
+			},
+			expect:     func(m *mock_route53iface.MockRoute53APIMockRecorder) {},
+			wantErr:    true,
+			wantDepErr: true,
+		},
+		{
+			name: "publishes an AAAA record for a dual-stack load balancer",
+			dns:  &infrav1.ControlPlaneDNS{HostedZoneName: testZoneName, HostedZoneID: testZoneID},
+			lb: infrav1.LoadBalancer{
+				DNSName:               testLBDNSName,
+				CanonicalHostedZoneID: testLBZoneID,
+				IPAddressType:         infrav1.LoadBalancerIPAddressTypeDualStack,
+			},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ChangeResourceRecordSets(gomock.Any()).DoAndReturn(func(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+					var recordTypes []string
+					for _, change := range input.ChangeBatch.Changes {
+						recordTypes = append(recordTypes, aws.StringValue(change.ResourceRecordSet.Type))
+					}
+					if len(recordTypes) != 2 || recordTypes[0] != route53.RRTypeA || recordTypes[1] != route53.RRTypeAaaa {
+						t.Fatalf("unexpected record types %v", recordTypes)
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				})
+			},
+			wantStatus: &infrav1.ControlPlaneDNSStatus{
+				HostedZoneID: testZoneID,
+				RecordName:   "api.test-cluster.example.internal",
+			},
+		},
+		{
+			name: "ignores public hosted zones with the same name",
+			dns:  &infrav1.ControlPlaneDNS{HostedZoneName: testZoneName},
+			lb:   infrav1.LoadBalancer{DNSName: testLBDNSName, CanonicalHostedZoneID: testLBZoneID},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListHostedZonesByName(gomock.Any()).Return(&route53.ListHostedZonesByNameOutput{
+					HostedZones: []*route53.HostedZone{
+						{
+							Id:     aws.String("/hostedzone/ZPUBLIC"),
+							Name:   aws.String(testZoneName + "."),
+							Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(false)},
+						},
+					},
+				}, nil)
+				m.CreateHostedZone(gomock.Any()).Return(nil, awserr.New(route53.ErrCodeHostedZoneAlreadyExists, "already exists", nil))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			svc, m := testService(t, tc.dns)
+			svc.scope.(*scope.ClusterScope).AWSCluster.Spec.SecondaryControlPlaneLoadBalancer = tc.secondary
+			svc.scope.Network().APIServerELB = tc.lb
+			svc.scope.Network().SecondaryAPIServerELB = tc.secondaryLB
+			tc.expect(m.EXPECT())
+
+			err := svc.ReconcileControlPlaneDNS()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(awserrors.IsFailedDependency(err)).To(Equal(tc.wantDepErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(svc.scope.Network().ControlPlaneDNS).To(Equal(tc.wantStatus))
+		})
+	}
+}
+
+func TestDeleteControlPlaneDNS(t *testing.T) {
+	lb := infrav1.LoadBalancer{DNSName: testLBDNSName, CanonicalHostedZoneID: testLBZoneID}
+	status := &infrav1.ControlPlaneDNSStatus{HostedZoneID: testZoneID, RecordName: "api.test-cluster.example.internal"}
+	aliasRecord := func(recordName, recordType, dnsName string) *route53.ListResourceRecordSetsOutput {
+		return &route53.ListResourceRecordSetsOutput{
+			ResourceRecordSets: []*route53.ResourceRecordSet{
+				{
+					Name:        aws.String(recordName + "."),
+					Type:        aws.String(recordType),
+					AliasTarget: &route53.AliasTarget{DNSName: aws.String("dualstack." + dnsName + "."), HostedZoneId: aws.String(testLBZoneID)},
+				},
+			},
+		}
+	}
+	ownedTags := &route53.ListTagsForResourceOutput{
+		ResourceTagSet: &route53.ResourceTagSet{
+			Tags: []*route53.Tag{
+				{Key: aws.String(infrav1.ClusterTagKey(testClusterName)), Value: aws.String(string(infrav1.ResourceLifecycleOwned))},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		dns         *infrav1.ControlPlaneDNS
+		status      *infrav1.ControlPlaneDNSStatus
+		lb          infrav1.LoadBalancer
+		secondaryLB infrav1.LoadBalancer
+		expect      func(m *mock_route53iface.MockRoute53APIMockRecorder)
+		wantErr     bool
+	}{
+		{
+			name:   "does nothing when no hosted zone was recorded",
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {},
+		},
+		{
+			name:   "deletes the record and the owned hosted zone",
+			status: status,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				gomock.InOrder(
+					m.ListResourceRecordSets(gomock.Eq(&route53.ListResourceRecordSetsInput{
+						HostedZoneId:    aws.String(testZoneID),
+						StartRecordName: aws.String(status.RecordName + "."),
+						StartRecordType: aws.String(route53.RRTypeA),
+						MaxItems:        aws.String("1"),
+					})).Return(aliasRecord(status.RecordName, route53.RRTypeA, testLBDNSName), nil),
+					m.ChangeResourceRecordSets(gomock.Any()).DoAndReturn(func(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+						if action := aws.StringValue(input.ChangeBatch.Changes[0].Action); action != route53.ChangeActionDelete {
+							t.Fatalf("unexpected action %q", action)
+						}
+						return &route53.ChangeResourceRecordSetsOutput{}, nil
+					}),
+					m.ListTagsForResource(gomock.Any()).Return(ownedTags, nil),
+					m.DeleteHostedZone(gomock.Eq(&route53.DeleteHostedZoneInput{Id: aws.String(testZoneID)})).Return(&route53.DeleteHostedZoneOutput{}, nil),
+				)
+			},
+		},
+		{
+			name:   "keeps a hosted zone that is not owned by the cluster",
+			status: status,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(aliasRecord(status.RecordName, route53.RRTypeA, testLBDNSName), nil)
+				m.ChangeResourceRecordSets(gomock.Any()).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+				m.ListTagsForResource(gomock.Any()).Return(&route53.ListTagsForResourceOutput{
+					ResourceTagSet: &route53.ResourceTagSet{},
+				}, nil)
+			},
+		},
+		{
+			name:   "never deletes a referenced hosted zone",
+			dns:    &infrav1.ControlPlaneDNS{HostedZoneName: testZoneName, HostedZoneID: testZoneID},
+			status: status,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(aliasRecord(status.RecordName, route53.RRTypeA, testLBDNSName), nil)
+				m.ChangeResourceRecordSets(gomock.Any()).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+			},
+		},
+		{
+			name:   "skips a record that no longer exists",
+			dns:    &infrav1.ControlPlaneDNS{HostedZoneName: testZoneName, HostedZoneID: testZoneID},
+			status: status,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(aliasRecord("other.example.internal", route53.RRTypeA, testLBDNSName), nil)
+			},
+		},
+		{
+			name: "deletes the records and the owned private hosted zone of the internal load balancer",
+			dns:  &infrav1.ControlPlaneDNS{HostedZoneName: "example.com", HostedZoneID: "ZPUBLIC"},
+			status: &infrav1.ControlPlaneDNSStatus{
+				HostedZoneID:         "ZPUBLIC",
+				RecordName:           "api.test-cluster.example.com",
+				InternalHostedZoneID: testZoneID,
+			},
+			secondaryLB: infrav1.LoadBalancer{DNSName: testInternalDNSName, CanonicalHostedZoneID: testLBZoneID},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				gomock.InOrder(
+					m.ListResourceRecordSets(gomock.Any()).Return(aliasRecord("api.test-cluster.example.com", route53.RRTypeA, testInternalDNSName), nil),
+					m.ChangeResourceRecordSets(gomock.Any()).DoAndReturn(func(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+						if aws.StringValue(input.HostedZoneId) != testZoneID {
+							t.Fatalf("unexpected hosted zone %q", aws.StringValue(input.HostedZoneId))
+						}
+						return &route53.ChangeResourceRecordSetsOutput{}, nil
+					}),
+					m.ListTagsForResource(gomock.Any()).Return(ownedTags, nil),
+					m.DeleteHostedZone(gomock.Eq(&route53.DeleteHostedZoneInput{Id: aws.String(testZoneID)})).Return(&route53.DeleteHostedZoneOutput{}, nil),
+					// The record of the public hosted zone points at the primary load balancer.
+					m.ListResourceRecordSets(gomock.Any()).Return(aliasRecord("api.test-cluster.example.com", route53.RRTypeA, testLBDNSName), nil),
+					m.ChangeResourceRecordSets(gomock.Any()).DoAndReturn(func(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+						if dnsName := aws.StringValue(input.ChangeBatch.Changes[0].ResourceRecordSet.AliasTarget.DNSName); dnsName != testLBDNSName {
+							t.Fatalf("unexpected alias target %q", dnsName)
+						}
+						return &route53.ChangeResourceRecordSetsOutput{}, nil
+					}),
+					m.ListResourceRecordSets(gomock.Any()).Return(aliasRecord("api.test-cluster.example.com", route53.RRTypeA, testLBDNSName), nil),
+				)
+			},
+		},
+		{
+			name:   "deletes the A and AAAA records of a dual-stack load balancer separately",
+			dns:    &infrav1.ControlPlaneDNS{HostedZoneName: testZoneName, HostedZoneID: testZoneID},
+			status: status,
+			lb: infrav1.LoadBalancer{
+				DNSName:               testLBDNSName,
+				CanonicalHostedZoneID: testLBZoneID,
+				IPAddressType:         infrav1.LoadBalancerIPAddressTypeDualStack,
+			},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				gomock.InOrder(
+					m.ListResourceRecordSets(gomock.Any()).Return(aliasRecord(status.RecordName, route53.RRTypeA, testLBDNSName), nil),
+					m.ChangeResourceRecordSets(gomock.Any()).Return(&route53.ChangeResourceRecordSetsOutput{}, nil),
+					m.ListResourceRecordSets(gomock.Any()).Return(aliasRecord(status.RecordName, route53.RRTypeAaaa, testLBDNSName), nil),
+					m.ChangeResourceRecordSets(gomock.Any()).DoAndReturn(func(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+						if recordType := aws.StringValue(input.ChangeBatch.Changes[0].ResourceRecordSet.Type); recordType != route53.RRTypeAaaa {
+							t.Fatalf("unexpected record type %q", recordType)
+						}
+						return &route53.ChangeResourceRecordSetsOutput{}, nil
+					}),
+				)
+			},
+		},
+		{
+			name:   "tolerates an already deleted hosted zone",
+			status: status,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(nil, awserr.New(route53.ErrCodeNoSuchHostedZone, "not found", nil))
+				m.ListTagsForResource(gomock.Any()).Return(nil, awserr.New(route53.ErrCodeNoSuchHostedZone, "not found", nil))
+			},
+		},
+		{
+			name:   "returns an error when the record cannot be described",
+			status: status,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(nil, awserr.New("AccessDenied", "denied", nil))
+			},
+			wantErr: true,
+		},
+		{
+			name:   "returns an error when the record cannot be deleted",
+			status: status,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(aliasRecord(status.RecordName, route53.RRTypeA, testLBDNSName), nil)
+				m.ChangeResourceRecordSets(gomock.Any()).Return(nil, awserr.New(route53.ErrCodeInvalidChangeBatch, "values do not match", nil))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			dns := tc.dns
+			if dns == nil {
+				dns = &infrav1.ControlPlaneDNS{HostedZoneName: testZoneName}
+			}
+			svc, m := testService(t, dns)
+			svc.scope.Network().APIServerELB = lb
+			if tc.lb.DNSName != "" {
+				svc.scope.Network().APIServerELB = tc.lb
+			}
+			svc.scope.Network().SecondaryAPIServerELB = tc.secondaryLB
+			svc.scope.Network().ControlPlaneDNS = tc.status
+			tc.expect(m.EXPECT())
+
+			err := svc.DeleteControlPlaneDNS()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(svc.scope.Network().ControlPlaneDNS).To(BeNil())
+		})
+	}
+}
+
+func testService(t *testing.T, dns *infrav1.ControlPlaneDNS) (*Service, *mock_route53iface.MockRoute53API) {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+	route53Mock := mock_route53iface.NewMockRoute53API(mockCtrl)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testClusterName,
+				Namespace: testClusterNamespace,
+			},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testClusterName,
+				Namespace: testClusterNamespace,
+			},
+			Spec: infrav1.AWSClusterSpec{
+				Region:          "us-east-1",
+				ControlPlaneDNS: dns,
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{ID: testVPCID},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	svc := NewService(clusterScope)
+	svc.Route53Client = route53Mock
+
+	return svc, route53Mock
+}
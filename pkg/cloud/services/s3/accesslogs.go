@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"fmt"
+	"path"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	iam "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+)
+
+// elbAccountIDs are the accounts of Elastic Load Balancing delivering the access logs of classic and
+// Application Load Balancers, in the regions available before August 2022. Load balancers in more
+// recent regions deliver their access logs through the logdelivery.elasticloadbalancing.amazonaws.com
+// service principal instead.
+// See https://docs.aws.amazon.com/elasticloadbalancing/latest/application/enable-access-logging.html.
+var elbAccountIDs = map[string]string{
+	"us-east-1":      "127311923021",
+	"us-east-2":      "033677994240",
+	"us-west-1":      "027434742980",
+	"us-west-2":      "797873946194",
+	"af-south-1":     "098369216593",
+	"ap-east-1":      "754344448648",
+	"ap-southeast-3": "589379963580",
+	"ap-south-1":     "718504428378",
+	"ap-northeast-3": "383597477331",
+	"ap-northeast-2": "600734575887",
+	"ap-southeast-1": "114774131450",
+	"ap-southeast-2": "783225319266",
+	"ap-northeast-1": "582318560864",
+	"ca-central-1":   "985666609251",
+	"eu-central-1":   "054676820928",
+	"eu-west-1":      "156460612806",
+	"eu-west-2":      "652711504416",
+	"eu-south-1":     "635631232127",
+	"eu-west-3":      "009996457667",
+	"eu-north-1":     "897822967062",
+	"me-south-1":     "076674570225",
+	"sa-east-1":      "507241528517",
+	"us-gov-west-1":  "048591011584",
+	"us-gov-east-1":  "190560391635",
+	"cn-north-1":     "638102146993",
+	"cn-northwest-1": "037604701340",
+}
+
+// accessLogsStatements returns the bucket policy statements allowing the control plane load
+// balancers to deliver their access logs to the cluster bucket.
+func (s *Service) accessLogsStatements(partition, accountID, bucketName string) []iam.StatementEntry {
+	var elbResources, nlbResources []string
+	for _, lb := range s.scope.ControlPlaneLoadBalancers() {
+		if lb == nil || lb.AccessLogs == nil {
+			continue
+		}
+		if lb.AccessLogs.Bucket != "" && lb.AccessLogs.Bucket != bucketName {
+			continue
+		}
+
+		resource := fmt.Sprintf("arn:%s:s3:::%s/*", partition, path.Join(bucketName, lb.AccessLogs.Prefix, "AWSLogs", accountID))
+		if lb.LoadBalancerType == infrav1.LoadBalancerTypeNLB {
+			nlbResources = append(nlbResources, resource)
+		} else {
+			elbResources = append(elbResources, resource)
+		}
+	}
+
+	var statements []iam.StatementEntry
+	if len(elbResources) > 0 {
+		principal := map[iam.PrincipalType]iam.PrincipalID{
+			iam.PrincipalService: []string{"logdelivery.elasticloadbalancing.amazonaws.com"},
+		}
+		if elbAccountID, ok := elbAccountIDs[s.scope.Region()]; ok {
+			principal = map[iam.PrincipalType]iam.PrincipalID{
+				iam.PrincipalAWS: []string{fmt.Sprintf("arn:%s:iam::%s:root", partition, elbAccountID)},
+			}
+		}
+
+		statements = append(statements, iam.StatementEntry{
+			Sid:       "elb-access-logs",
+			Effect:    iam.EffectAllow,
+			Principal: principal,
+			Action:    []string{"s3:PutObject"},
+			Resource:  elbResources,
+		})
+	}
+
+	if len(nlbResources) > 0 {
+		principal := map[iam.PrincipalType]iam.PrincipalID{
+			iam.PrincipalService: []string{"delivery.logs.amazonaws.com"},
+		}
+		statements = append(statements,
+			iam.StatementEntry{
+				Sid:       "nlb-access-logs",
+				Effect:    iam.EffectAllow,
+				Principal: principal,
+				Action:    []string{"s3:PutObject"},
+				Resource:  nlbResources,
+				Condition: iam.Conditions{
+					"StringEquals": map[string]interface{}{
+						"s3:x-amz-acl":      "bucket-owner-full-control",
+						"aws:SourceAccount": accountID,
+					},
+				},
+			},
+			iam.StatementEntry{
+				Sid:       "nlb-access-logs-acl",
+				Effect:    iam.EffectAllow,
+				Principal: principal,
+				Action:    []string{"s3:GetBucketAcl"},
+				Resource:  []string{fmt.Sprintf("arn:%s:s3:::%s", partition, bucketName)},
+				Condition: iam.Conditions{
+					"StringEquals": map[string]interface{}{
+						"aws:SourceAccount": accountID,
+					},
+				},
+			},
+		)
+	}
+
+	return statements
+}
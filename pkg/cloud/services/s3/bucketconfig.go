@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+
+	iam "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+)
+
+// bootstrapDataPrefixes are the prefixes of the keys of the bootstrap data objects, see bootstrapDataKey.
+var bootstrapDataPrefixes = []string{"control-plane/", "node/"}
+
+// ensureBucketEncryption sets the customer managed KMS key as the default encryption key of the bucket.
+func (s *Service) ensureBucketEncryption(bucketName string) error {
+	kmsKeyID := s.scope.Bucket().KMSKeyID
+	if kmsKeyID == "" {
+		return nil
+	}
+
+	if _, err := s.S3Client.PutBucketEncryption(&s3.PutBucketEncryptionInput{
+		Bucket: aws.String(bucketName),
+		ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+			Rules: []*s3.ServerSideEncryptionRule{
+				{
+					ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+						SSEAlgorithm:   aws.String(s3.ServerSideEncryptionAwsKms),
+						KMSMasterKeyID: aws.String(kmsKeyID),
+					},
+					BucketKeyEnabled: aws.Bool(true),
+				},
+			},
+		},
+	}); err != nil {
+		return errors.Wrap(err, "setting S3 bucket encryption")
+	}
+
+	s.scope.Trace("Updated bucket encryption", "bucket_name", bucketName)
+
+	return nil
+}
+
+// ensureBucketVersioning enables the versioning of the bucket when requested.
+func (s *Service) ensureBucketVersioning(bucketName string) error {
+	if !s.scope.Bucket().Versioning {
+		return nil
+	}
+
+	if _, err := s.S3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket: aws.String(bucketName),
+		VersioningConfiguration: &s3.VersioningConfiguration{
+			Status: aws.String(s3.BucketVersioningStatusEnabled),
+		},
+	}); err != nil {
+		return errors.Wrap(err, "enabling S3 bucket versioning")
+	}
+
+	s.scope.Trace("Enabled bucket versioning", "bucket_name", bucketName)
+
+	return nil
+}
+
+// bootstrapDataLifecycleRuleID returns the ID of the lifecycle rule expiring the bootstrap data under the prefix.
+func bootstrapDataLifecycleRuleID(prefix string) string {
+	return "expire-" + prefix[:len(prefix)-1] + "-bootstrap-data"
+}
+
+// ensureBucketLifecycle expires the stale bootstrap data objects, and their noncurrent versions.
+func (s *Service) ensureBucketLifecycle(bucketName string) error {
+	expirationDays := s.scope.Bucket().ExpirationDays
+	if expirationDays == nil {
+		return s.deleteBucketLifecycle(bucketName)
+	}
+
+	rules := make([]*s3.LifecycleRule, 0, len(bootstrapDataPrefixes))
+	for _, prefix := range bootstrapDataPrefixes {
+		rules = append(rules, &s3.LifecycleRule{
+			ID:     aws.String(bootstrapDataLifecycleRuleID(prefix)),
+			Status: aws.String(s3.ExpirationStatusEnabled),
+			Filter: &s3.LifecycleRuleFilter{
+				Prefix: aws.String(prefix),
+			},
+			Expiration: &s3.LifecycleExpiration{
+				Days: expirationDays,
+			},
+			NoncurrentVersionExpiration: &s3.NoncurrentVersionExpiration{
+				NoncurrentDays: expirationDays,
+			},
+		})
+	}
+
+	if _, err := s.S3Client.PutBucketLifecycleConfiguration(&s3.PutBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucketName),
+		LifecycleConfiguration: &s3.BucketLifecycleConfiguration{
+			Rules: rules,
+		},
+	}); err != nil {
+		return errors.Wrap(err, "setting S3 bucket lifecycle configuration")
+	}
+
+	s.scope.Trace("Updated bucket lifecycle configuration", "bucket_name", bucketName)
+
+	return nil
+}
+
+// deleteBucketLifecycle removes the rules expiring the bootstrap data from the bucket, keeping any other rule.
+func (s *Service) deleteBucketLifecycle(bucketName string) error {
+	out, err := s.S3Client.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "NoSuchLifecycleConfiguration" {
+			return nil
+		}
+		return errors.Wrap(err, "getting S3 bucket lifecycle configuration")
+	}
+
+	ruleIDs := make(map[string]bool, len(bootstrapDataPrefixes))
+	for _, prefix := range bootstrapDataPrefixes {
+		ruleIDs[bootstrapDataLifecycleRuleID(prefix)] = true
+	}
+
+	rules := make([]*s3.LifecycleRule, 0, len(out.Rules))
+	for _, rule := range out.Rules {
+		if !ruleIDs[aws.StringValue(rule.ID)] {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == len(out.Rules) {
+		return nil
+	}
+
+	if len(rules) == 0 {
+		if _, err := s.S3Client.DeleteBucketLifecycle(&s3.DeleteBucketLifecycleInput{
+			Bucket: aws.String(bucketName),
+		}); err != nil {
+			return errors.Wrap(err, "deleting S3 bucket lifecycle configuration")
+		}
+	} else if _, err := s.S3Client.PutBucketLifecycleConfiguration(&s3.PutBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucketName),
+		LifecycleConfiguration: &s3.BucketLifecycleConfiguration{
+			Rules: rules,
+		},
+	}); err != nil {
+		return errors.Wrap(err, "setting S3 bucket lifecycle configuration")
+	}
+
+	s.scope.Trace("Removed bucket lifecycle rules", "bucket_name", bucketName)
+
+	return nil
+}
+
+// ensurePublicAccessBlock blocks all public access to the bucket when requested.
+func (s *Service) ensurePublicAccessBlock(bucketName string) error {
+	if !s.scope.Bucket().BlockPublicAccess {
+		return nil
+	}
+
+	if _, err := s.S3Client.PutPublicAccessBlock(&s3.PutPublicAccessBlockInput{
+		Bucket: aws.String(bucketName),
+		PublicAccessBlockConfiguration: &s3.PublicAccessBlockConfiguration{
+			BlockPublicAcls:       aws.Bool(true),
+			BlockPublicPolicy:     aws.Bool(true),
+			IgnorePublicAcls:      aws.Bool(true),
+			RestrictPublicBuckets: aws.Bool(true),
+		},
+	}); err != nil {
+		return errors.Wrap(err, "blocking S3 bucket public access")
+	}
+
+	s.scope.Trace("Blocked bucket public access", "bucket_name", bucketName)
+
+	return nil
+}
+
+// customPolicyStatements returns the statements of the custom bucket policy.
+func (s *Service) customPolicyStatements() ([]iam.StatementEntry, error) {
+	policy := s.scope.Bucket().Policy
+	if policy == "" {
+		return nil, nil
+	}
+
+	document := iam.PolicyDocument{}
+	if err := json.Unmarshal([]byte(policy), &document); err != nil {
+		return nil, errors.Wrap(err, "parsing custom bucket policy")
+	}
+
+	return document.Statement, nil
+}
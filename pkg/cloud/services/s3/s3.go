@@ -80,6 +80,22 @@ func (s *Service) ReconcileBucket() error {
 		return errors.Wrap(err, "tagging bucket")
 	}
 
+	if err := s.ensurePublicAccessBlock(bucketName); err != nil {
+		return errors.Wrap(err, "ensuring bucket public access block")
+	}
+
+	if err := s.ensureBucketEncryption(bucketName); err != nil {
+		return errors.Wrap(err, "ensuring bucket encryption")
+	}
+
+	if err := s.ensureBucketVersioning(bucketName); err != nil {
+		return errors.Wrap(err, "ensuring bucket versioning")
+	}
+
+	if err := s.ensureBucketLifecycle(bucketName); err != nil {
+		return errors.Wrap(err, "ensuring bucket lifecycle")
+	}
+
 	if err := s.ensureBucketPolicy(bucketName); err != nil {
 		return errors.Wrap(err, "ensuring bucket policy")
 	}
@@ -142,12 +158,17 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, error) {
 
 	s.scope.Info("Creating object", "bucket_name", bucket, "key", key)
 
-	if _, err := s.S3Client.PutObject(&s3.PutObjectInput{
+	input := &s3.PutObjectInput{
 		Body:                 aws.ReadSeekCloser(bytes.NewReader(data)),
 		Bucket:               aws.String(bucket),
 		Key:                  aws.String(key),
 		ServerSideEncryption: aws.String("aws:kms"),
-	}); err != nil {
+	}
+	if kmsKeyID := s.scope.Bucket().KMSKeyID; kmsKeyID != "" {
+		input.SSEKMSKeyId = aws.String(kmsKeyID)
+	}
+
+	if _, err := s.S3Client.PutObject(input); err != nil {
 		return "", errors.Wrap(err, "putting object")
 	}
 
@@ -383,6 +404,14 @@ func (s *Service) bucketPolicy(bucketName string) (string, error) {
 		}
 	}
 
+	statements = append(statements, s.accessLogsStatements(partition, *accountID.Account, bucketName)...)
+
+	customStatements, err := s.customPolicyStatements()
+	if err != nil {
+		return "", err
+	}
+	statements = append(statements, customStatements...)
+
 	policy := iam.PolicyDocument{
 		Version:   "2012-10-17",
 		Statement: statements,
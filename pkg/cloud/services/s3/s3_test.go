@@ -102,6 +102,7 @@ func TestReconcileBucket(t *testing.T) {
 		}
 
 		s3Mock.EXPECT().PutBucketTagging(gomock.Eq(taggingInput)).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().GetBucketLifecycleConfiguration(gomock.Any()).Return(nil, awserr.New("NoSuchLifecycleConfiguration", "", nil)).Times(1)
 
 		s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Return(nil, nil).Times(1)
 
@@ -158,6 +159,7 @@ func TestReconcileBucket(t *testing.T) {
 		}).Return(nil, nil).Times(1)
 
 		s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().GetBucketLifecycleConfiguration(gomock.Any()).Return(nil, awserr.New("NoSuchLifecycleConfiguration", "", nil)).Times(1)
 		s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Return(nil, nil).Times(1)
 
 		if err := svc.ReconcileBucket(); err != nil {
@@ -182,6 +184,7 @@ func TestReconcileBucket(t *testing.T) {
 
 		s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
 		s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().GetBucketLifecycleConfiguration(gomock.Any()).Return(nil, awserr.New("NoSuchLifecycleConfiguration", "", nil)).Times(1)
 		s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Do(func(input *s3svc.PutBucketPolicyInput) {
 			if input.Policy == nil {
 				t.Fatalf("Policy must be defined")
@@ -219,6 +222,218 @@ func TestReconcileBucket(t *testing.T) {
 		}
 	})
 
+	t.Run("creates_bucket_with_policy_allowing_load_balancers_to_deliver_access_logs", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name             string
+			region           string
+			loadBalancerType infrav1.LoadBalancerType
+			accessLogs       *infrav1.LoadBalancerAccessLogs
+			expected         []string
+			unexpected       []string
+		}{
+			{
+				name:             "classic load balancer in a region with an Elastic Load Balancing account",
+				region:           "us-west-2",
+				loadBalancerType: infrav1.LoadBalancerTypeClassic,
+				accessLogs:       &infrav1.LoadBalancerAccessLogs{Prefix: "logs"},
+				expected:         []string{"arn:aws:iam::797873946194:root", "arn:aws:s3:::bar/logs/AWSLogs/foo/*"},
+			},
+			{
+				name:             "application load balancer in a region without an Elastic Load Balancing account",
+				region:           "ap-southeast-4",
+				loadBalancerType: infrav1.LoadBalancerTypeALB,
+				accessLogs:       &infrav1.LoadBalancerAccessLogs{},
+				expected:         []string{"logdelivery.elasticloadbalancing.amazonaws.com", "arn:aws:s3:::bar/AWSLogs/foo/*"},
+			},
+			{
+				name:             "network load balancer",
+				region:           "us-west-2",
+				loadBalancerType: infrav1.LoadBalancerTypeNLB,
+				accessLogs:       &infrav1.LoadBalancerAccessLogs{Bucket: "bar"},
+				expected:         []string{"delivery.logs.amazonaws.com", "bucket-owner-full-control", "s3:GetBucketAcl", "arn:aws:s3:::bar/AWSLogs/foo/*"},
+			},
+			{
+				name:             "access logs delivered to another bucket",
+				region:           "us-west-2",
+				loadBalancerType: infrav1.LoadBalancerTypeClassic,
+				accessLogs:       &infrav1.LoadBalancerAccessLogs{Bucket: "logs"},
+				unexpected:       []string{"access-logs"},
+			},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				svc, s3Mock := testService(t, &testServiceInput{
+					Bucket: &infrav1.S3Bucket{Name: "bar"},
+					Region: tc.region,
+					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+						LoadBalancerType: tc.loadBalancerType,
+						AccessLogs:       tc.accessLogs,
+					},
+				})
+
+				s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
+				s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+				s3Mock.EXPECT().GetBucketLifecycleConfiguration(gomock.Any()).Return(nil, awserr.New("NoSuchLifecycleConfiguration", "", nil)).Times(1)
+				s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Do(func(input *s3svc.PutBucketPolicyInput) {
+					policy := aws.StringValue(input.Policy)
+					for _, expected := range tc.expected {
+						if !strings.Contains(policy, expected) {
+							t.Errorf("Expected policy to contain %q, got: %v", expected, policy)
+						}
+					}
+					for _, unexpected := range tc.unexpected {
+						if strings.Contains(policy, unexpected) {
+							t.Errorf("Expected policy not to contain %q, got: %v", unexpected, policy)
+						}
+					}
+				}).Return(nil, nil).Times(1)
+
+				if err := svc.ReconcileBucket(); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("configures_hardened_bucket", func(t *testing.T) {
+		t.Parallel()
+
+		bucketName := "bar"
+		kmsKeyID := "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+		svc, s3Mock := testService(t, &testServiceInput{
+			Bucket: &infrav1.S3Bucket{
+				Name:              bucketName,
+				KMSKeyID:          kmsKeyID,
+				Versioning:        true,
+				ExpirationDays:    aws.Int64(7),
+				BlockPublicAccess: true,
+				Policy:            `{"Version":"2012-10-17","Statement":[{"Sid":"audit","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:role/audit"},"Action":"s3:GetObject","Resource":"arn:aws:s3:::bar/*"}]}`,
+			},
+		})
+
+		s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutPublicAccessBlock(gomock.Eq(&s3svc.PutPublicAccessBlockInput{
+			Bucket: aws.String(bucketName),
+			PublicAccessBlockConfiguration: &s3svc.PublicAccessBlockConfiguration{
+				BlockPublicAcls:       aws.Bool(true),
+				BlockPublicPolicy:     aws.Bool(true),
+				IgnorePublicAcls:      aws.Bool(true),
+				RestrictPublicBuckets: aws.Bool(true),
+			},
+		})).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketEncryption(gomock.Eq(&s3svc.PutBucketEncryptionInput{
+			Bucket: aws.String(bucketName),
+			ServerSideEncryptionConfiguration: &s3svc.ServerSideEncryptionConfiguration{
+				Rules: []*s3svc.ServerSideEncryptionRule{
+					{
+						ApplyServerSideEncryptionByDefault: &s3svc.ServerSideEncryptionByDefault{
+							SSEAlgorithm:   aws.String("aws:kms"),
+							KMSMasterKeyID: aws.String(kmsKeyID),
+						},
+						BucketKeyEnabled: aws.Bool(true),
+					},
+				},
+			},
+		})).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketVersioning(gomock.Eq(&s3svc.PutBucketVersioningInput{
+			Bucket: aws.String(bucketName),
+			VersioningConfiguration: &s3svc.VersioningConfiguration{
+				Status: aws.String("Enabled"),
+			},
+		})).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketLifecycleConfiguration(gomock.Any()).Do(func(input *s3svc.PutBucketLifecycleConfigurationInput) {
+			rules := input.LifecycleConfiguration.Rules
+			if len(rules) != 2 {
+				t.Fatalf("Expected a lifecycle rule for the control plane and node bootstrap data, got: %v", rules)
+			}
+			for i, prefix := range []string{"control-plane/", "node/"} {
+				if aws.StringValue(rules[i].Filter.Prefix) != prefix {
+					t.Errorf("Expected lifecycle rule to apply to the %q prefix, got: %v", prefix, rules[i])
+				}
+				if aws.Int64Value(rules[i].Expiration.Days) != 7 || aws.Int64Value(rules[i].NoncurrentVersionExpiration.NoncurrentDays) != 7 {
+					t.Errorf("Expected lifecycle rule to expire objects and noncurrent versions after 7 days, got: %v", rules[i])
+				}
+			}
+		}).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Do(func(input *s3svc.PutBucketPolicyInput) {
+			policy := aws.StringValue(input.Policy)
+			for _, expected := range []string{"SecureTransport", "arn:aws:iam::123456789012:role/audit"} {
+				if !strings.Contains(policy, expected) {
+					t.Errorf("Expected policy to contain %q, got: %v", expected, policy)
+				}
+			}
+		}).Return(nil, nil).Times(1)
+
+		if err := svc.ReconcileBucket(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("removes_bootstrap_data_lifecycle_rules_when_expiration_is_unset", func(t *testing.T) {
+		t.Parallel()
+
+		bucketName := "bar"
+		customRule := &s3svc.LifecycleRule{
+			ID:     aws.String("custom"),
+			Status: aws.String("Enabled"),
+		}
+		bootstrapDataRules := []*s3svc.LifecycleRule{
+			{ID: aws.String("expire-control-plane-bootstrap-data")},
+			{ID: aws.String("expire-node-bootstrap-data")},
+		}
+
+		t.Run("keeps_other_rules", func(t *testing.T) {
+			t.Parallel()
+
+			svc, s3Mock := testService(t, &testServiceInput{Bucket: &infrav1.S3Bucket{Name: bucketName}})
+
+			s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
+			s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+			s3Mock.EXPECT().GetBucketLifecycleConfiguration(gomock.Any()).Return(&s3svc.GetBucketLifecycleConfigurationOutput{
+				Rules: append([]*s3svc.LifecycleRule{customRule}, bootstrapDataRules...),
+			}, nil).Times(1)
+			s3Mock.EXPECT().PutBucketLifecycleConfiguration(gomock.Eq(&s3svc.PutBucketLifecycleConfigurationInput{
+				Bucket: aws.String(bucketName),
+				LifecycleConfiguration: &s3svc.BucketLifecycleConfiguration{
+					Rules: []*s3svc.LifecycleRule{customRule},
+				},
+			})).Return(nil, nil).Times(1)
+			s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Return(nil, nil).Times(1)
+
+			if err := svc.ReconcileBucket(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+
+		t.Run("deletes_lifecycle_configuration_without_other_rules", func(t *testing.T) {
+			t.Parallel()
+
+			svc, s3Mock := testService(t, &testServiceInput{Bucket: &infrav1.S3Bucket{Name: bucketName}})
+
+			s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
+			s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+			s3Mock.EXPECT().GetBucketLifecycleConfiguration(gomock.Any()).Return(&s3svc.GetBucketLifecycleConfigurationOutput{
+				Rules: bootstrapDataRules,
+			}, nil).Times(1)
+			s3Mock.EXPECT().DeleteBucketLifecycle(gomock.Eq(&s3svc.DeleteBucketLifecycleInput{
+				Bucket: aws.String(bucketName),
+			})).Return(nil, nil).Times(1)
+			s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Return(nil, nil).Times(1)
+
+			if err := svc.ReconcileBucket(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	})
+
 	t.Run("is_idempotent", func(t *testing.T) {
 		t.Parallel()
 
@@ -226,6 +441,7 @@ func TestReconcileBucket(t *testing.T) {
 
 		s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(2)
 		s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(2)
+		s3Mock.EXPECT().GetBucketLifecycleConfiguration(gomock.Any()).Return(nil, awserr.New("NoSuchLifecycleConfiguration", "", nil)).Times(2)
 		s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Return(nil, nil).Times(2)
 
 		if err := svc.ReconcileBucket(); err != nil {
@@ -246,6 +462,7 @@ func TestReconcileBucket(t *testing.T) {
 
 		s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, err).Times(1)
 		s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().GetBucketLifecycleConfiguration(gomock.Any()).Return(nil, awserr.New("NoSuchLifecycleConfiguration", "", nil)).Times(1)
 		s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Return(nil, nil).Times(1)
 
 		if err := svc.ReconcileBucket(); err != nil {
@@ -287,6 +504,7 @@ func TestReconcileBucket(t *testing.T) {
 
 			s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
 			s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+			s3Mock.EXPECT().GetBucketLifecycleConfiguration(gomock.Any()).Return(nil, awserr.New("NoSuchLifecycleConfiguration", "", nil)).Times(1)
 
 			mockCtrl := gomock.NewController(t)
 			stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
@@ -305,6 +523,7 @@ func TestReconcileBucket(t *testing.T) {
 
 			s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
 			s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+			s3Mock.EXPECT().GetBucketLifecycleConfiguration(gomock.Any()).Return(nil, awserr.New("NoSuchLifecycleConfiguration", "", nil)).Times(1)
 			s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Return(nil, errors.New("error")).Times(1)
 
 			if err := svc.ReconcileBucket(); err == nil {
@@ -326,6 +545,7 @@ func TestReconcileBucket(t *testing.T) {
 
 			s3Mock.EXPECT().CreateBucket(gomock.Eq(input)).Return(nil, nil).Times(1)
 			s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+			s3Mock.EXPECT().GetBucketLifecycleConfiguration(gomock.Any()).Return(nil, awserr.New("NoSuchLifecycleConfiguration", "", nil)).Times(1)
 			s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Return(nil, nil).Times(1)
 
 			if err := svc.ReconcileBucket(); err != nil {
@@ -430,6 +650,37 @@ func TestCreateObject(t *testing.T) {
 		nodeName   = "aws-test1"
 	)
 
+	t.Run("encrypts_object_with_configured_kms_key", func(t *testing.T) {
+		t.Parallel()
+
+		kmsKeyID := "alias/bootstrap-data"
+		svc, s3Mock := testService(t, &testServiceInput{
+			Bucket: &infrav1.S3Bucket{
+				Name:     bucketName,
+				KMSKeyID: kmsKeyID,
+			},
+		})
+
+		machineScope := &scope.MachineScope{
+			Machine: &clusterv1.Machine{},
+			AWSMachine: &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName,
+				},
+			},
+		}
+
+		s3Mock.EXPECT().PutObject(gomock.Any()).Do(func(putObjectInput *s3svc.PutObjectInput) {
+			if aws.StringValue(putObjectInput.ServerSideEncryption) != "aws:kms" || aws.StringValue(putObjectInput.SSEKMSKeyId) != kmsKeyID {
+				t.Errorf("Expected object to be encrypted with KMS key %q, got: %v", kmsKeyID, putObjectInput)
+			}
+		}).Return(nil, nil).Times(1)
+
+		if _, err := svc.Create(machineScope, []byte("foobar")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
 	t.Run("for_machine", func(t *testing.T) {
 		t.Parallel()
 
@@ -856,8 +1107,9 @@ func TestDeleteObject(t *testing.T) {
 }
 
 type testServiceInput struct {
-	Bucket *infrav1.S3Bucket
-	Region string
+	Bucket                   *infrav1.S3Bucket
+	Region                   string
+	ControlPlaneLoadBalancer *infrav1.AWSLoadBalancerSpec
 }
 
 const testAWSRegion string = "us-west-2"
@@ -893,8 +1145,9 @@ func testService(t *testing.T, si *testServiceInput) (*s3.Service, *mock_s3iface
 		},
 		AWSCluster: &infrav1.AWSCluster{
 			Spec: infrav1.AWSClusterSpec{
-				S3Bucket: si.Bucket,
-				Region:   si.Region,
+				S3Bucket:                 si.Bucket,
+				Region:                   si.Region,
+				ControlPlaneLoadBalancer: si.ControlPlaneLoadBalancer,
 				AdditionalTags: infrav1.Tags{
 					"additional": "from-aws-cluster",
 				},
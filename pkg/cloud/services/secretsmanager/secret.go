@@ -19,6 +19,7 @@ package secretsmanager
 import (
 	"fmt"
 	"path"
+	"regexp"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
@@ -28,6 +29,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/bytes"
@@ -39,8 +41,14 @@ const (
 	// we set the max secret size to well below the 10240 byte limit, because this is limit after base64 encoding,
 	// but the aws sdk handles encoding for us, so we can't send a full 10240.
 	maxSecretSizeBytes = 7000
+
+	// bounds of the recovery window of deleted secrets enforced by AWS Secrets Manager.
+	minRecoveryWindowInDays = 7
+	maxRecoveryWindowInDays = 30
 )
 
+var namePrefixRe = regexp.MustCompile(`^[a-zA-Z0-9/_+=.@-]+$`)
+
 var retryableErrors = []string{
 	// Returned when the secret is scheduled for deletion
 	secretsmanager.ErrCodeInvalidRequestException,
@@ -65,7 +73,7 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 	// Build the prefix.
 	prefix := m.GetSecretPrefix()
 	if prefix == "" {
-		prefix = path.Join(entryPrefix, string(uuid.NewUUID()))
+		prefix = path.Join(s.namePrefix(), string(uuid.NewUUID()))
 	}
 	// Split the data into chunks and create the secrets on demand.
 	chunks := int32(0)
@@ -86,11 +94,15 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 
 // retryableCreateSecret is a function to be passed into a waiter. In a separate function for ease of reading.
 func (s *Service) retryableCreateSecret(name string, chunk []byte, tags infrav1.Tags) (bool, error) {
-	_, err := s.SecretsManagerClient.CreateSecret(&secretsmanager.CreateSecretInput{
+	input := &secretsmanager.CreateSecretInput{
 		Name:         aws.String(name),
 		SecretBinary: chunk,
 		Tags:         converters.MapToSecretsManagerTags(tags),
-	})
+	}
+	if s.options.KMSKeyID != "" {
+		input.KmsKeyId = aws.String(s.options.KMSKeyID)
+	}
+	_, err := s.SecretsManagerClient.CreateSecret(input)
 	// If the secret already exists, delete it, return request to retry, as deletes are eventually consistent
 	if awserrors.IsResourceExists(err) {
 		return false, s.forceDeleteSecretEntry(name)
@@ -113,11 +125,44 @@ func (s *Service) forceDeleteSecretEntry(name string) error {
 	return err
 }
 
+// deleteSecretEntry deletes a single secret, within the recovery window if one is configured,
+// ignoring if it is absent or already scheduled for deletion.
+func (s *Service) deleteSecretEntry(name string) error {
+	if s.options.RecoveryWindowInDays == 0 {
+		return s.forceDeleteSecretEntry(name)
+	}
+	_, err := s.SecretsManagerClient.DeleteSecret(&secretsmanager.DeleteSecretInput{
+		SecretId:             aws.String(name),
+		RecoveryWindowInDays: aws.Int64(s.options.RecoveryWindowInDays),
+	})
+	if awserrors.IsNotFound(err) {
+		return nil
+	}
+	if code, _ := awserrors.Code(err); code == secretsmanager.ErrCodeInvalidRequestException {
+		// Deleting a secret already scheduled for deletion, for example by the node once it fetched it, fails.
+		out, describeErr := s.SecretsManagerClient.DescribeSecret(&secretsmanager.DescribeSecretInput{
+			SecretId: aws.String(name),
+		})
+		if describeErr == nil && out.DeletedDate != nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (s *Service) namePrefix() string {
+	if s.options.NamePrefix != "" {
+		return s.options.NamePrefix
+	}
+	return entryPrefix
+}
+
 // Delete the secret belonging to a machine from AWS Secrets Manager.
 func (s *Service) Delete(m *scope.MachineScope) error {
 	var errs []error
 	for i := int32(0); i < m.GetSecretCount(); i++ {
-		if err := s.forceDeleteSecretEntry(fmt.Sprintf("%s-%d", m.GetSecretPrefix(), i)); err != nil {
+		if err := s.deleteSecretEntry(fmt.Sprintf("%s-%d", m.GetSecretPrefix(), i)); err != nil {
+			metrics.RecordSecretCleanupFailure(serviceID)
 			errs = append(errs, err)
 		}
 	}
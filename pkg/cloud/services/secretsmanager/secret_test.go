@@ -20,8 +20,10 @@ import (
 	"crypto/rand"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
@@ -84,6 +86,7 @@ func TestServiceCreate(t *testing.T) {
 
 	tests := []struct {
 		name           string
+		options        Options
 		bytesCount     int64
 		secretPrefix   string
 		expectedPrefix string
@@ -156,6 +159,22 @@ func TestServiceCreate(t *testing.T) {
 				m.CreateSecret(gomock.AssignableToTypeOf(&secretsmanager.CreateSecretInput{})).Return(&secretsmanager.CreateSecretOutput{}, nil)
 			},
 		},
+		{
+			name:           "Should use the configured name prefix and KMS key",
+			options:        Options{NamePrefix: "team-a/capa", KMSKeyID: "alias/capa-userdata"},
+			bytesCount:     10,
+			secretPrefix:   "",
+			expectedPrefix: "team-a/capa/",
+			wantErr:        false,
+			expect: func(g *WithT, m *mock_secretsmanageriface.MockSecretsManagerAPIMockRecorder) {
+				m.CreateSecret(gomock.AssignableToTypeOf(&secretsmanager.CreateSecretInput{})).Return(&secretsmanager.CreateSecretOutput{}, nil).Do(
+					func(createSecretInput *secretsmanager.CreateSecretInput) {
+						g.Expect(*(createSecretInput.Name)).To(HavePrefix("team-a/capa/"))
+						g.Expect(createSecretInput.KmsKeyId).To(Equal(aws.String("alias/capa-userdata")))
+					},
+				)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -168,7 +187,7 @@ func TestServiceCreate(t *testing.T) {
 
 			secretManagerClientMock := mock_secretsmanageriface.NewMockSecretsManagerAPI(mockCtrl)
 			tt.expect(g, secretManagerClientMock.EXPECT())
-			s := NewService(clusterScope)
+			s := NewService(clusterScope, tt.options)
 			s.SecretsManagerClient = secretManagerClientMock
 			ms, err := getMachineScope(client, clusterScope)
 			g.Expect(err).NotTo(HaveOccurred())
@@ -187,6 +206,7 @@ func TestServiceDelete(t *testing.T) {
 
 	tests := []struct {
 		name        string
+		options     Options
 		secretCount int32
 		expect      func(m *mock_secretsmanageriface.MockSecretsManagerAPIMockRecorder)
 		check       func(*WithT, error)
@@ -233,6 +253,37 @@ func TestServiceDelete(t *testing.T) {
 				g.Expect((err.Error())).To(Equal("[failed dependency, new conflict]"))
 			},
 		},
+		{
+			name:        "Should delete within the recovery window when configured",
+			options:     Options{RecoveryWindowInDays: 7},
+			secretCount: 1,
+			expect: func(m *mock_secretsmanageriface.MockSecretsManagerAPIMockRecorder) {
+				m.DeleteSecret(gomock.Eq(&secretsmanager.DeleteSecretInput{
+					SecretId:             aws.String("prefix-0"),
+					RecoveryWindowInDays: aws.Int64(7),
+				})).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+			},
+			check: func(g *WithT, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
+		{
+			name:        "Should not return error when the secret is already scheduled for deletion",
+			options:     Options{RecoveryWindowInDays: 7},
+			secretCount: 1,
+			expect: func(m *mock_secretsmanageriface.MockSecretsManagerAPIMockRecorder) {
+				m.DeleteSecret(gomock.Eq(&secretsmanager.DeleteSecretInput{
+					SecretId:             aws.String("prefix-0"),
+					RecoveryWindowInDays: aws.Int64(7),
+				})).Return(nil, awserr.New(secretsmanager.ErrCodeInvalidRequestException, "already scheduled for deletion", nil))
+				m.DescribeSecret(gomock.Eq(&secretsmanager.DescribeSecretInput{
+					SecretId: aws.String("prefix-0"),
+				})).Return(&secretsmanager.DescribeSecretOutput{DeletedDate: aws.Time(time.Now())}, nil)
+			},
+			check: func(g *WithT, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -245,7 +296,7 @@ func TestServiceDelete(t *testing.T) {
 			if tt.expect != nil {
 				tt.expect(secretManagerClientMock.EXPECT())
 			}
-			s := NewService(clusterScope)
+			s := NewService(clusterScope, tt.options)
 			s.SecretsManagerClient = secretManagerClientMock
 			ms, err := getMachineScope(client, clusterScope)
 			g.Expect(err).NotTo(HaveOccurred())
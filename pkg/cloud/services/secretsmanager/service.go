@@ -18,6 +18,8 @@ limitations under the License.
 package secretsmanager
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
@@ -29,13 +31,40 @@ import (
 // One alternative is to have a large list of functions from the ec2 client.
 type Service struct {
 	scope                cloud.ClusterScoper
+	options              Options
 	SecretsManagerClient secretsmanageriface.SecretsManagerAPI
 }
 
+// Options configures the secrets storing the userdata.
+type Options struct {
+	// NamePrefix is the prefix of the names of the secrets. Defaults to aws.cluster.x-k8s.io.
+	NamePrefix string
+
+	// KMSKeyID is the ID, ARN or alias of the KMS key encrypting the secrets. When empty, the secrets are
+	// encrypted with the AWS managed aws/secretsmanager key.
+	KMSKeyID string
+
+	// RecoveryWindowInDays is the number of days during which the secrets deleted by the controller can be
+	// recovered. When zero, the secrets are deleted without recovery.
+	RecoveryWindowInDays int64
+}
+
+// Validate returns an error if the options are invalid.
+func (o Options) Validate() error {
+	if o.RecoveryWindowInDays != 0 && (o.RecoveryWindowInDays < minRecoveryWindowInDays || o.RecoveryWindowInDays > maxRecoveryWindowInDays) {
+		return fmt.Errorf("recovery window must be 0 or between %d and %d days, got %d", minRecoveryWindowInDays, maxRecoveryWindowInDays, o.RecoveryWindowInDays)
+	}
+	if o.NamePrefix != "" && !namePrefixRe.MatchString(o.NamePrefix) {
+		return fmt.Errorf("invalid secret name prefix %q, only alphanumeric characters and /_+=.@- are allowed", o.NamePrefix)
+	}
+	return nil
+}
+
 // NewService returns a new service given the api clients.
-func NewService(secretsScope cloud.ClusterScoper) *Service {
+func NewService(secretsScope cloud.ClusterScoper, options Options) *Service {
 	return &Service{
 		scope:                secretsScope,
+		options:              options,
 		SecretsManagerClient: scope.NewSecretsManagerClient(secretsScope, secretsScope, secretsScope, secretsScope.InfraCluster()),
 	}
 }
@@ -49,3 +49,42 @@ func TestUserDataEndpoints(t *testing.T) {
 		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
 	}
 }
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr bool
+	}{
+		{
+			name:    "defaults",
+			options: Options{},
+		},
+		{
+			name:    "custom name prefix, KMS key and recovery window",
+			options: Options{NamePrefix: "team-a/capa", KMSKeyID: "alias/capa-userdata", RecoveryWindowInDays: 30},
+		},
+		{
+			name:    "recovery window too short",
+			options: Options{RecoveryWindowInDays: 1},
+			wantErr: true,
+		},
+		{
+			name:    "recovery window too long",
+			options: Options{RecoveryWindowInDays: 31},
+			wantErr: true,
+		},
+		{
+			name:    "invalid name prefix",
+			options: Options{NamePrefix: "team a"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("error mismatch: got %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
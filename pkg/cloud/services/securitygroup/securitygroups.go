@@ -26,6 +26,7 @@ import (
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
@@ -567,11 +568,11 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 				Protocol:    infrav1.SecurityGroupProtocolTCP,
 				FromPort:    infrav1.DefaultAPIServerPort,
 				ToPort:      infrav1.DefaultAPIServerPort,
-				SourceSecurityGroupIDs: []string{
+				SourceSecurityGroupIDs: append([]string{
 					s.scope.SecurityGroups()[infrav1.SecurityGroupAPIServerLB].ID,
 					s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID,
 					s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID,
-				},
+				}, s.replacedLoadBalancerSecurityGroups()...),
 			},
 			{
 				Description:            "etcd",
@@ -657,6 +658,20 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 		// Except if the load balancer type is NLB, and we have an AWS Cluster in which case we
 		// need to open port 6443 to the NLB traffic and health check inside the VPC.
 		for _, lb := range s.scope.ControlPlaneLoadBalancers() {
+			if lb != nil && lb.LoadBalancerType == infrav1.LoadBalancerTypeALB {
+				// Application Load Balancers reach the targets from their security group. The API server port
+				// is already open to it in the control plane security group.
+				for _, ln := range lb.AdditionalListeners {
+					rules = append(rules, infrav1.IngressRule{
+						Description:            fmt.Sprintf("Allow ALB traffic to the control plane instances on port %d.", ln.GetTargetPort()),
+						Protocol:               infrav1.SecurityGroupProtocolTCP,
+						FromPort:               ln.GetTargetPort(),
+						ToPort:                 ln.GetTargetPort(),
+						SourceSecurityGroupIDs: s.loadBalancerSecurityGroups(lb),
+					})
+				}
+				continue
+			}
 			if lb == nil || lb.LoadBalancerType != infrav1.LoadBalancerTypeNLB {
 				continue
 			}
@@ -690,10 +705,10 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 
 			for _, ln := range lb.AdditionalListeners {
 				rules = append(rules, infrav1.IngressRule{
-					Description:    fmt.Sprintf("Allow NLB traffic to the control plane instances on port %d.", ln.Port),
+					Description:    fmt.Sprintf("Allow NLB traffic to the control plane instances on port %d.", ln.GetTargetPort()),
 					Protocol:       infrav1.SecurityGroupProtocolTCP,
-					FromPort:       ln.Port,
-					ToPort:         ln.Port,
+					FromPort:       ln.GetTargetPort(),
+					ToPort:         ln.GetTargetPort(),
 					CidrBlocks:     ipv4CidrBlocks,
 					IPv6CidrBlocks: ipv6CidrBlocks,
 				})
@@ -907,6 +922,26 @@ func (s *Service) getIngressRulesToAllowKubeletToAccessTheControlPlaneLB() infra
 	return s.getIngressRuleToAllowAnyIPInTheAPIServer()
 }
 
+// loadBalancerSecurityGroups returns the security groups the load balancer reaches the control plane instances from.
+func (s *Service) loadBalancerSecurityGroups(lb *infrav1.AWSLoadBalancerSpec) []string {
+	if len(lb.SecurityGroups) > 0 {
+		return lb.SecurityGroups
+	}
+	return []string{s.scope.SecurityGroups()[infrav1.SecurityGroupAPIServerLB].ID}
+}
+
+// replacedLoadBalancerSecurityGroups returns the security groups used in place of the managed apiserver-lb
+// security group by the control plane load balancers.
+func (s *Service) replacedLoadBalancerSecurityGroups() []string {
+	var ids []string
+	for _, lb := range s.scope.ControlPlaneLoadBalancers() {
+		if lb != nil {
+			ids = append(ids, lb.SecurityGroups...)
+		}
+	}
+	return ids
+}
+
 // getControlPlaneLBIngressRules returns the ingress rules for the control plane LB.
 // We allow all traffic when no other rules are defined.
 func (s *Service) getControlPlaneLBIngressRules() infrav1.IngressRules {
@@ -921,7 +956,27 @@ func (s *Service) getControlPlaneLBIngressRules() infrav1.IngressRules {
 	}
 
 	// If no custom ingress rules have been defined we allow all traffic so that the MC can access the WC API
-	return s.getIngressRuleToAllowAnyIPInTheAPIServer()
+	ingressRules = s.getIngressRuleToAllowAnyIPInTheAPIServer()
+	if !s.scope.VPC().IsIPv6Enabled() && s.hasDualStackControlPlaneLoadBalancer() {
+		// Dual-stack load balancers in existing VPCs are also reached by IPv6 clients.
+		ingressRules = append(ingressRules, infrav1.IngressRule{
+			Description:    "Kubernetes API IPv6",
+			Protocol:       infrav1.SecurityGroupProtocolTCP,
+			FromPort:       int64(s.scope.APIServerPort()),
+			ToPort:         int64(s.scope.APIServerPort()),
+			IPv6CidrBlocks: []string{services.AnyIPv6CidrBlock},
+		})
+	}
+	return ingressRules
+}
+
+func (s *Service) hasDualStackControlPlaneLoadBalancer() bool {
+	for _, lb := range s.scope.ControlPlaneLoadBalancers() {
+		if lb != nil && ptr.Deref(lb.IPAddressType, infrav1.LoadBalancerIPAddressTypeIPv4) == infrav1.LoadBalancerIPAddressTypeDualStack {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *Service) getIngressRuleToAllowAnyIPInTheAPIServer() infrav1.IngressRules {
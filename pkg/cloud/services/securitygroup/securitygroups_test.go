@@ -31,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -898,6 +899,110 @@ func TestControlPlaneSecurityGroupNotOpenToAnyCIDR(t *testing.T) {
 	}
 }
 
+func TestLBSecurityGroupAllowsALBAdditionalListeners(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType: infrav1.LoadBalancerTypeALB,
+					AdditionalListeners: []infrav1.AdditionalListenerSpec{
+						{Port: 8443, Protocol: infrav1.ELBProtocolHTTPS},
+						{Port: 443, Protocol: infrav1.ELBProtocolHTTPS, TargetPort: aws.Int64(22623)},
+					},
+				},
+			},
+			Status: infrav1.AWSClusterStatus{
+				Network: infrav1.NetworkStatus{
+					SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+						infrav1.SecurityGroupAPIServerLB: {ID: "sg-apiserver-lb"},
+					},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(cs, testSecurityGroupRoles)
+	rules, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupLB)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(Equal(infrav1.IngressRules{
+		{
+			Description:            "Allow ALB traffic to the control plane instances on port 8443.",
+			Protocol:               infrav1.SecurityGroupProtocolTCP,
+			FromPort:               8443,
+			ToPort:                 8443,
+			SourceSecurityGroupIDs: []string{"sg-apiserver-lb"},
+		},
+		{
+			Description:            "Allow ALB traffic to the control plane instances on port 22623.",
+			Protocol:               infrav1.SecurityGroupProtocolTCP,
+			FromPort:               22623,
+			ToPort:                 22623,
+			SourceSecurityGroupIDs: []string{"sg-apiserver-lb"},
+		},
+	}))
+}
+
+func TestControlPlaneSecurityGroupAllowsReplacedLBSecurityGroups(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType: infrav1.LoadBalancerTypeALB,
+					SecurityGroups:   []string{"sg-user-lb"},
+					AdditionalListeners: []infrav1.AdditionalListenerSpec{
+						{Port: 8443, Protocol: infrav1.ELBProtocolHTTPS},
+					},
+				},
+			},
+			Status: infrav1.AWSClusterStatus{
+				Network: infrav1.NetworkStatus{
+					SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+						infrav1.SecurityGroupAPIServerLB:  {ID: "sg-apiserver-lb"},
+						infrav1.SecurityGroupControlPlane: {ID: "sg-control-plane"},
+						infrav1.SecurityGroupNode:         {ID: "sg-node"},
+					},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(cs, testSecurityGroupRoles)
+	rules, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupControlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules[0].Description).To(Equal("Kubernetes API"))
+	g.Expect(rules[0].SourceSecurityGroupIDs).To(ConsistOf("sg-apiserver-lb", "sg-control-plane", "sg-node", "sg-user-lb"))
+
+	rules, err = s.getSecurityGroupIngressRules(infrav1.SecurityGroupLB)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(Equal(infrav1.IngressRules{
+		{
+			Description:            "Allow ALB traffic to the control plane instances on port 8443.",
+			Protocol:               infrav1.SecurityGroupProtocolTCP,
+			FromPort:               8443,
+			ToPort:                 8443,
+			SourceSecurityGroupIDs: []string{"sg-user-lb"},
+		},
+	}))
+}
+
 func TestAdditionalControlPlaneSecurityGroup(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
@@ -1228,6 +1333,40 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "when no ingress rules are passed and the load balancer is dual-stack, IPv6 clients are allowed too",
+			awsCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+						IPAddressType:    ptr.To(infrav1.LoadBalancerIPAddressTypeDualStack),
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID:        "vpc-id",
+							CidrBlock: "10.0.0.0/16",
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{},
+			},
+			expectedIngresRules: infrav1.IngressRules{
+				infrav1.IngressRule{
+					Description: "Kubernetes API",
+					Protocol:    infrav1.SecurityGroupProtocolTCP,
+					FromPort:    6443,
+					ToPort:      6443,
+					CidrBlocks:  []string{services.AnyIPv4CidrBlock},
+				},
+				infrav1.IngressRule{
+					Description:    "Kubernetes API IPv6",
+					Protocol:       infrav1.SecurityGroupProtocolTCP,
+					FromPort:       6443,
+					ToPort:         6443,
+					IPv6CidrBlocks: []string{services.AnyIPv6CidrBlock},
+				},
+			},
+		},
 		{
 			name: "when no ingress rules are passed, allow the Nat Gateway IPs and default to allow all",
 			awsCluster: &infrav1.AWSCluster{
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const multipartHeader = "MIME-Version: 1.0\nContent-Type: multipart/mixed; boundary=\"%s\"\n\n"
+
+// partContentTypes maps the first line of a user data script to the content type cloud-init expects for it.
+var partContentTypes = []struct {
+	prefix      string
+	contentType string
+}{
+	{prefix: "#cloud-config", contentType: "text/cloud-config"},
+	{prefix: "#cloud-boothook", contentType: "text/cloud-boothook"},
+	{prefix: "#!", contentType: "text/x-shellscript"},
+}
+
+type part struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// PrependUserData returns a multipart MIME document which runs the given user data before the bootstrap data.
+// The user data must be a cloud-config, a boothook or a script. The bootstrap data is either a single cloud-init
+// document or a multipart MIME document, as generated for nodeadm, whose parts are kept in order.
+// The document is the same for the same inputs, so that it doesn't change the hash of the launch template user data.
+func PrependUserData(userData string, bootstrapData []byte) ([]byte, error) {
+	contentType, err := partContentType([]byte(userData))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid user data")
+	}
+	bootstrapParts, err := splitParts(bootstrapData)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid bootstrap data")
+	}
+
+	parts := append([]part{{
+		header: textproto.MIMEHeader{"Content-Type": {contentType}},
+		body:   []byte(userData),
+	}}, bootstrapParts...)
+
+	var buf bytes.Buffer
+	mpWriter := multipart.NewWriter(&buf)
+	if err := mpWriter.SetBoundary(fmt.Sprintf("%x", sha256.Sum256(append([]byte(userData), bootstrapData...)))); err != nil {
+		return nil, err
+	}
+	buf.WriteString(fmt.Sprintf(multipartHeader, mpWriter.Boundary()))
+	for _, p := range parts {
+		partWriter, err := mpWriter.CreatePart(p.header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := partWriter.Write(p.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := mpWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// splitParts returns the parts of a multipart MIME document, or the document itself as a single part.
+func splitParts(data []byte) ([]part, error) {
+	if !isMultipart(data) {
+		contentType, err := partContentType(data)
+		if err != nil {
+			return nil, err
+		}
+		return []part{{header: textproto.MIMEHeader{"Content-Type": {contentType}}, body: data}}, nil
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse MIME document")
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse MIME document content type")
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, errors.Errorf("unsupported MIME document content type %q", mediaType)
+	}
+
+	var parts []part
+	mpReader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := mpReader.NextRawPart()
+		if err == io.EOF {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read MIME document part")
+		}
+		body, err := io.ReadAll(p)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read MIME document part")
+		}
+		parts = append(parts, part{header: p.Header, body: body})
+	}
+}
+
+func isMultipart(data []byte) bool {
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	header := strings.ToLower(string(firstLine))
+	return strings.HasPrefix(header, "mime-version:") || strings.HasPrefix(header, "content-type:")
+}
+
+func partContentType(data []byte) (string, error) {
+	for _, t := range partContentTypes {
+		if bytes.HasPrefix(data, []byte(t.prefix)) {
+			return t.contentType, nil
+		}
+	}
+	return "", errors.New("must start with #cloud-config, #cloud-boothook or #!")
+}
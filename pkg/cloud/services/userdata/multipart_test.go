@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPrependUserData(t *testing.T) {
+	nodeadmUserData := `MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="//"
+
+--//
+Content-Type: application/node.eks.aws
+
+---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+
+--//
+Content-Type: text/cloud-config; charset="us-ascii"
+
+#cloud-config
+runcmd:
+- echo bootstrap
+
+--//--
+`
+
+	tests := []struct {
+		name          string
+		userData      string
+		bootstrapData string
+		expectedParts []part
+		expectErr     bool
+	}{
+		{
+			name:          "script before cloud-config",
+			userData:      "#!/bin/bash\necho pre\n",
+			bootstrapData: "#cloud-config\nruncmd:\n- echo bootstrap\n",
+			expectedParts: []part{
+				{header: map[string][]string{"Content-Type": {"text/x-shellscript"}}, body: []byte("#!/bin/bash\necho pre\n")},
+				{header: map[string][]string{"Content-Type": {"text/cloud-config"}}, body: []byte("#cloud-config\nruncmd:\n- echo bootstrap\n")},
+			},
+		},
+		{
+			name:          "cloud-config before nodeadm parts",
+			userData:      "#cloud-config\nbootcmd:\n- echo pre\n",
+			bootstrapData: nodeadmUserData,
+			expectedParts: []part{
+				{header: map[string][]string{"Content-Type": {"text/cloud-config"}}, body: []byte("#cloud-config\nbootcmd:\n- echo pre\n")},
+				{header: map[string][]string{"Content-Type": {"application/node.eks.aws"}}, body: []byte("---\napiVersion: node.eks.aws/v1alpha1\nkind: NodeConfig\n")},
+				{header: map[string][]string{"Content-Type": {`text/cloud-config; charset="us-ascii"`}}, body: []byte("#cloud-config\nruncmd:\n- echo bootstrap\n")},
+			},
+		},
+		{
+			name:          "user data without a header",
+			userData:      "echo pre\n",
+			bootstrapData: "#cloud-config\n",
+			expectErr:     true,
+		},
+		{
+			name:          "ignition bootstrap data",
+			userData:      "#!/bin/bash\necho pre\n",
+			bootstrapData: `{"ignition":{"version":"3.3.0"}}`,
+			expectErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			out, err := PrependUserData(tc.userData, []byte(tc.bootstrapData))
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			parts, err := splitParts(out)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(parts).To(Equal(tc.expectedParts))
+
+			again, err := PrependUserData(tc.userData, []byte(tc.bootstrapData))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(again).To(Equal(out))
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"strings"
 	"text/template"
 
@@ -58,6 +59,27 @@ func GzipBytes(dat []byte) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// GunzipBytes will gunzip a byte array.
+func GunzipBytes(dat []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(dat))
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "failed to gunzip bytes")
+	}
+	defer gz.Close()
+
+	out, err := io.ReadAll(gz)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "failed to gunzip bytes")
+	}
+
+	return out, nil
+}
+
+// IsGzipped returns true if the byte array starts with the gzip magic number.
+func IsGzipped(dat []byte) bool {
+	return len(dat) >= 2 && dat[0] == 0x1f && dat[1] == 0x8b
+}
+
 // ComputeHash returns the SHA256 hash of the user data byte array.
 func ComputeHash(dat []byte) string {
 	return fmt.Sprintf("%x", sha256.Sum256(dat))
@@ -36,6 +36,7 @@ func TestEKSAddonPlan(t *testing.T) {
 	addon1Name := "addon1"
 	addon1version := "1.0.0"
 	addon1Upgrade := "2.0.0"
+	addon1Configuration := `{"replicaCount": 3}`
 	addonStatusActive := string(eks.AddonStatusActive)
 	addonStatusUpdating := string(eks.AddonStatusUpdating)
 	addonStatusDeleting := string(eks.AddonStatusDeleting)
@@ -355,6 +356,86 @@ func TestEKSAddonPlan(t *testing.T) {
 			expectCreateError: false,
 			expectDoError:     false,
 		},
+		{
+			name: "1 installed and 0 desired - delete addon preserving its resources",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DeleteAddon(gomock.Eq(&eks.DeleteAddonInput{
+						AddonName:   &addon1Name,
+						ClusterName: &clusterName,
+						Preserve:    aws.Bool(true),
+					})).
+					Return(&eks.DeleteAddonOutput{}, nil)
+				m.WaitUntilAddonDeleted(gomock.Eq(&eks.DescribeAddonInput{
+					AddonName:   aws.String(addon1Name),
+					ClusterName: aws.String(clusterName),
+				})).Return(nil)
+			},
+			installedAddons: []*EKSAddon{
+				func() *EKSAddon {
+					installed := createInstalledAddon(addon1Name, addon1version, addonARN, addonStatusActive)
+					installed.Preserve = true
+					return installed
+				}(),
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
+		{
+			name: "1 installed and 1 desired - configuration update",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					UpdateAddon(gomock.Eq(&eks.UpdateAddonInput{
+						AddonName:           aws.String(addon1Name),
+						AddonVersion:        aws.String(addon1version),
+						ClusterName:         aws.String(clusterName),
+						ConfigurationValues: aws.String(addon1Configuration),
+						ResolveConflicts:    aws.String(eks.ResolveConflictsPreserve),
+					})).
+					Return(&eks.UpdateAddonOutput{}, nil)
+
+				out := &eks.DescribeAddonOutput{
+					Addon: &eks.Addon{
+						Status: aws.String(eks.AddonStatusActive),
+					},
+				}
+				m.DescribeAddon(gomock.Eq(&eks.DescribeAddonInput{
+					AddonName:   aws.String(addon1Name),
+					ClusterName: aws.String(clusterName),
+				})).Return(out, nil)
+			},
+			desiredAddons: []*EKSAddon{
+				func() *EKSAddon {
+					desired := createDesiredAddon(addon1Name, addon1version)
+					desired.Configuration = aws.String(addon1Configuration)
+					desired.ResolveConflict = aws.String(eks.ResolveConflictsPreserve)
+					return desired
+				}(),
+			},
+			installedAddons: []*EKSAddon{
+				createInstalledAddon(addon1Name, addon1version, addonARN, addonStatusActive),
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
+		{
+			name: "1 installed and 1 desired - empty configuration is unchanged",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				// Do nothing
+			},
+			desiredAddons: []*EKSAddon{
+				func() *EKSAddon {
+					desired := createDesiredAddon(addon1Name, addon1version)
+					desired.Configuration = aws.String("")
+					return desired
+				}(),
+			},
+			installedAddons: []*EKSAddon{
+				createInstalledAddon(addon1Name, addon1version, addonARN, addonStatusActive),
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
 		{
 			name: "1 installed and 0 desired - addon has status of deleting",
 			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
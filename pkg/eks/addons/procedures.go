@@ -48,6 +48,9 @@ func (p *DeleteAddonProcedure) Do(_ context.Context) error {
 		AddonName:   aws.String(p.name),
 		ClusterName: aws.String(p.plan.clusterName),
 	}
+	if installed := p.plan.getInstalled(p.name); installed != nil && installed.Preserve {
+		input.Preserve = aws.Bool(true)
+	}
 
 	if _, err := p.plan.eksClient.DeleteAddon(input); err != nil {
 		return fmt.Errorf("deleting eks addon %s: %w", p.name, err)
@@ -17,6 +17,7 @@ limitations under the License.
 package addons
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/google/go-cmp/cmp"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -32,6 +33,7 @@ type EKSAddon struct {
 	ResolveConflict       *string
 	ARN                   *string
 	Status                *string
+	Preserve              bool
 }
 
 // IsEqual determines if 2 EKSAddon are equal.
@@ -46,6 +48,10 @@ func (e *EKSAddon) IsEqual(other *EKSAddon, includeTags bool) bool {
 	if !cmp.Equal(e.ServiceAccountRoleARN, other.ServiceAccountRoleARN) {
 		return false
 	}
+	// An empty configuration is the same as no configuration.
+	if aws.StringValue(e.Configuration) != aws.StringValue(other.Configuration) {
+		return false
+	}
 
 	if includeTags {
 		diffTags := e.Tags.Difference(other.Tags)
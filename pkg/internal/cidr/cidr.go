@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"sort"
 
 	"github.com/pkg/errors"
 )
@@ -66,6 +67,53 @@ func SplitIntoSubnetsIPv4(cidrBlock string, numSubnets int) ([]*net.IPNet, error
 	return subnets, nil
 }
 
+// AllocateSubnetsIPv4 carves subnets with the given prefix lengths out of an IPv4 CIDR.
+// The subnets are returned in the order of the prefix lengths. Larger subnets are allocated
+// first so that every subnet is aligned without leaving gaps between them.
+func AllocateSubnetsIPv4(cidrBlock string, prefixLengths []int) ([]*net.IPNet, error) {
+	_, parent, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse CIDR")
+	}
+	ip4 := parent.IP.To4()
+	if ip4 == nil {
+		return nil, errors.Errorf("unexpected IP address type: %s", parent)
+	}
+
+	networkLen, _ := parent.Mask.Size()
+	order := make([]int, len(prefixLengths))
+	for i, prefixLength := range prefixLengths {
+		if prefixLength < networkLen || prefixLength > 32 {
+			return nil, errors.Errorf("cidr %s cannot accommodate a /%d subnet", cidrBlock, prefixLength)
+		}
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return prefixLengths[order[i]] < prefixLengths[order[j]]
+	})
+
+	start := uint64(binary.BigEndian.Uint32(ip4))
+	end := start + uint64(1)<<uint(32-networkLen)
+	next := start
+	subnets := make([]*net.IPNet, len(prefixLengths))
+	for _, i := range order {
+		size := uint64(1) << uint(32-prefixLengths[i])
+		if next+size > end {
+			return nil, errors.Errorf("cidr %s cannot accommodate the requested subnets", cidrBlock)
+		}
+
+		subnetIP := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(subnetIP, uint32(next))
+		subnets[i] = &net.IPNet{
+			IP:   subnetIP,
+			Mask: net.CIDRMask(prefixLengths[i], 32),
+		}
+		next += size
+	}
+
+	return subnets, nil
+}
+
 const subnetIDLocation = 7
 
 // SplitIntoSubnetsIPv6 splits a IPv6 address into a specified number of subnets.
@@ -93,6 +93,58 @@ func TestSplitIntoSubnetsIPv4(t *testing.T) {
 	}
 }
 
+func TestAllocateSubnetsIPv4(t *testing.T) {
+	RegisterTestingT(t)
+	tests := []struct {
+		name          string
+		cidrblock     string
+		prefixLengths []int
+		expected      []string
+		expectErr     bool
+	}{
+		{
+			name:          "allocates larger subnets first and keeps the requested order",
+			cidrblock:     "10.0.0.0/16",
+			prefixLengths: []int{24, 24, 20, 20},
+			expected:      []string{"10.0.32.0/24", "10.0.33.0/24", "10.0.0.0/20", "10.0.16.0/20"},
+		},
+		{
+			name:          "fills the whole block",
+			cidrblock:     "10.0.0.0/22",
+			prefixLengths: []int{23, 24, 24},
+			expected:      []string{"10.0.0.0/23", "10.0.2.0/24", "10.0.3.0/24"},
+		},
+		{
+			name:          "fails when the subnets do not fit",
+			cidrblock:     "10.0.0.0/22",
+			prefixLengths: []int{23, 23, 24},
+			expectErr:     true,
+		},
+		{
+			name:          "fails when a subnet is larger than the block",
+			cidrblock:     "10.0.0.0/22",
+			prefixLengths: []int{21},
+			expectErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := AllocateSubnetsIPv4(tc.cidrblock, tc.prefixLengths)
+			if tc.expectErr {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			var got []string
+			for _, subnet := range output {
+				got = append(got, subnet.String())
+			}
+			Expect(got).To(Equal(tc.expected))
+		})
+	}
+}
+
 var (
 	block = "2001:db8:1234:1a00::/56"
 )
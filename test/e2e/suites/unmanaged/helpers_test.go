@@ -747,6 +747,35 @@ func expectAWSClusterConditions(m *infrav1.AWSCluster, expected []conditionAsser
 	}
 }
 
+// triggerAWSClusterReconcile annotates the AWSCluster so it is reconciled without waiting for the sync period.
+func triggerAWSClusterReconcile(ctx context.Context, awsCluster *infrav1.AWSCluster) {
+	k8sClient := e2eCtx.Environment.BootstrapClusterProxy.GetClient()
+	Eventually(func() error {
+		current := &infrav1.AWSCluster{}
+		if err := k8sClient.Get(ctx, crclient.ObjectKeyFromObject(awsCluster), current); err != nil {
+			return err
+		}
+		annotations := current.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations["e2e.cluster.x-k8s.io/reconcile"] = time.Now().Format(time.RFC3339Nano)
+		current.SetAnnotations(annotations)
+		return k8sClient.Update(ctx, current)
+	}, 1*time.Minute, 5*time.Second).Should(Succeed())
+}
+
+// expectAWSClusterConditionEventually waits for the condition of the AWSCluster to be true.
+func expectAWSClusterConditionEventually(ctx context.Context, namespace, clusterName string, conditionType clusterv1.ConditionType) {
+	Eventually(func() bool {
+		awsCluster, err := GetAWSClusterByName(ctx, namespace, clusterName)
+		if err != nil {
+			return false
+		}
+		return conditions.IsTrue(awsCluster, conditionType)
+	}, e2eCtx.E2EConfig.GetIntervals("", "wait-cluster")...).Should(BeTrue(), fmt.Sprintf("Eventually failed waiting for the %s condition to be true", conditionType))
+}
+
 func createEFS() *efs.FileSystemDescription {
 	efs, err := shared.CreateEFS(e2eCtx, string(uuid.NewUUID()))
 	Expect(err).NotTo(HaveOccurred())
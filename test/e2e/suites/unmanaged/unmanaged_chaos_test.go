@@ -0,0 +1,225 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unmanaged
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/gofrs/flock"
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/e2e/shared"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// The chaos tests delete AWS resources owned by a running cluster out-of-band, and check the
+// controllers either repair them or surface the failure on the affected objects.
+var _ = ginkgo.Context("[unmanaged] [chaos]", func() {
+	var (
+		ctx               context.Context
+		result            *clusterctl.ApplyClusterTemplateAndWaitResult
+		requiredResources *shared.TestResource
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.TODO()
+		result = &clusterctl.ApplyClusterTemplateAndWaitResult{}
+	})
+
+	ginkgo.Describe("Out-of-band deletion of cluster resources", func() {
+		ginkgo.It("should recreate the NAT gateway and the security group rules", func() {
+			specName := "chaos-cluster-resources"
+			requiredResources = &shared.TestResource{EC2Normal: 1 * e2eCtx.Settings.InstanceVCPU, IGW: 1, NGW: 2, VPC: 1, ClassicLB: 1, EIP: 4}
+			requiredResources.WriteRequestedResources(e2eCtx, specName)
+			Expect(shared.AcquireResources(requiredResources, ginkgo.GinkgoParallelProcess(), flock.New(shared.ResourceQuotaFilePath))).To(Succeed())
+			defer shared.ReleaseResources(requiredResources, ginkgo.GinkgoParallelProcess(), flock.New(shared.ResourceQuotaFilePath))
+			namespace := shared.SetupSpecNamespace(ctx, specName, e2eCtx)
+			defer shared.DumpSpecResourcesAndCleanup(ctx, "", namespace, e2eCtx)
+			ginkgo.By("Creating a cluster")
+			clusterName := fmt.Sprintf("%s-%s", specName, util.RandomString(6))
+			configCluster := defaultConfigCluster(clusterName, namespace.Name)
+			_, _, _ = createCluster(ctx, configCluster, result)
+
+			awsCluster, err := GetAWSClusterByName(ctx, namespace.Name, clusterName)
+			Expect(err).To(BeNil())
+			var publicSubnet, privateSubnet infrav1.SubnetSpec
+			for _, subnet := range awsCluster.Spec.NetworkSpec.Subnets {
+				if subnet.IsPublic {
+					publicSubnet = subnet
+				} else {
+					privateSubnet = subnet
+				}
+			}
+			Expect(publicSubnet.NatGatewayID).ToNot(BeNil())
+			natGatewayID := *publicSubnet.NatGatewayID
+
+			ginkgo.By(fmt.Sprintf("Deleting the NAT gateway %s", natGatewayID))
+			Expect(shared.DeleteNatGateway(e2eCtx, natGatewayID)).To(BeTrue())
+			shared.WaitForNatGatewayState(e2eCtx, natGatewayID, ec2.NatGatewayStateDeleted)
+			triggerAWSClusterReconcile(ctx, awsCluster)
+
+			ginkgo.By("Waiting for a new NAT gateway to be created in the public subnet")
+			var newNatGatewayID string
+			Eventually(func() bool {
+				gateways, err := shared.ListNATGateways(e2eCtx)
+				if err != nil {
+					return false
+				}
+				gateway, ok := gateways[publicSubnet.GetResourceID()]
+				if !ok || aws.StringValue(gateway.NatGatewayId) == natGatewayID {
+					return false
+				}
+				newNatGatewayID = aws.StringValue(gateway.NatGatewayId)
+				return true
+			}, e2eCtx.E2EConfig.GetIntervals("", "wait-cluster")...).Should(BeTrue(), "Eventually failed waiting for the NAT gateway to be recreated")
+
+			ginkgo.By("Waiting for the private route table to use the new NAT gateway")
+			Eventually(func() bool {
+				routeTables, err := shared.ListSubnetRouteTables(e2eCtx, privateSubnet.GetResourceID())
+				if err != nil {
+					return false
+				}
+				for _, rt := range routeTables {
+					for _, route := range rt.Routes {
+						if aws.StringValue(route.DestinationCidrBlock) == "0.0.0.0/0" && aws.StringValue(route.NatGatewayId) == newNatGatewayID {
+							return true
+						}
+					}
+				}
+				return false
+			}, e2eCtx.E2EConfig.GetIntervals("", "wait-cluster")...).Should(BeTrue(), "Eventually failed waiting for the private route to use the new NAT gateway")
+			expectAWSClusterConditionEventually(ctx, namespace.Name, clusterName, infrav1.NatGatewaysReadyCondition)
+
+			nodeSecurityGroupID := awsCluster.Status.Network.SecurityGroups[infrav1.SecurityGroupNode].ID
+			rules, err := shared.ListSecurityGroupRules(e2eCtx, nodeSecurityGroupID)
+			Expect(err).To(BeNil())
+			var kubeletRule *ec2.SecurityGroupRule
+			for _, rule := range rules {
+				if !aws.BoolValue(rule.IsEgress) && aws.StringValue(rule.Description) == "Kubelet API" {
+					kubeletRule = rule
+					break
+				}
+			}
+			Expect(kubeletRule).ToNot(BeNil(), "Kubelet API ingress rule of the node security group not found")
+
+			ginkgo.By(fmt.Sprintf("Revoking the Kubelet API ingress rule %s of the node security group", aws.StringValue(kubeletRule.SecurityGroupRuleId)))
+			Expect(shared.DeleteSecurityGroupIngressRule(e2eCtx, nodeSecurityGroupID, aws.StringValue(kubeletRule.SecurityGroupRuleId))).To(BeTrue())
+			triggerAWSClusterReconcile(ctx, awsCluster)
+
+			ginkgo.By("Waiting for the Kubelet API ingress rule to be authorized again")
+			Eventually(func() bool {
+				rules, err := shared.ListSecurityGroupRules(e2eCtx, nodeSecurityGroupID)
+				if err != nil {
+					return false
+				}
+				for _, rule := range rules {
+					if !aws.BoolValue(rule.IsEgress) && aws.StringValue(rule.Description) == "Kubelet API" &&
+						aws.StringValue(rule.SecurityGroupRuleId) != aws.StringValue(kubeletRule.SecurityGroupRuleId) {
+						return true
+					}
+				}
+				return false
+			}, e2eCtx.E2EConfig.GetIntervals("", "wait-cluster")...).Should(BeTrue(), "Eventually failed waiting for the security group rule to be authorized again")
+			expectAWSClusterConditionEventually(ctx, namespace.Name, clusterName, infrav1.ClusterSecurityGroupsReadyCondition)
+		})
+	})
+
+	ginkgo.Describe("Out-of-band termination of a worker instance", func() {
+		ginkgo.It("should surface the failure and replace the machine once deleted", func() {
+			specName := "chaos-instance-termination"
+			requiredResources = &shared.TestResource{EC2Normal: 3 * e2eCtx.Settings.InstanceVCPU, IGW: 1, NGW: 1, VPC: 1, ClassicLB: 1, EIP: 3}
+			requiredResources.WriteRequestedResources(e2eCtx, specName)
+			Expect(shared.AcquireResources(requiredResources, ginkgo.GinkgoParallelProcess(), flock.New(shared.ResourceQuotaFilePath))).To(Succeed())
+			defer shared.ReleaseResources(requiredResources, ginkgo.GinkgoParallelProcess(), flock.New(shared.ResourceQuotaFilePath))
+			namespace := shared.SetupSpecNamespace(ctx, specName, e2eCtx)
+			defer shared.DumpSpecResourcesAndCleanup(ctx, "", namespace, e2eCtx)
+			ginkgo.By("Creating a cluster")
+			clusterName := fmt.Sprintf("%s-%s", specName, util.RandomString(6))
+			configCluster := defaultConfigCluster(clusterName, namespace.Name)
+			configCluster.WorkerMachineCount = ptr.To[int64](1)
+			cluster, mds, _ := createCluster(ctx, configCluster, result)
+			Expect(mds).To(HaveLen(1))
+
+			awsMachines := getAWSMachinesForDeployment(namespace.Name, *mds[0])
+			Expect(awsMachines.Items).To(HaveLen(1))
+			awsMachine := awsMachines.Items[0]
+			Expect(awsMachine.Spec.InstanceID).ToNot(BeNil())
+
+			terminateInstance(*awsMachine.Spec.InstanceID)
+
+			ginkgo.By("Waiting for the AWSMachine to report the termination")
+			k8sClient := e2eCtx.Environment.BootstrapClusterProxy.GetClient()
+			Eventually(func() bool {
+				current := &infrav1.AWSMachine{}
+				if err := k8sClient.Get(ctx, crclient.ObjectKeyFromObject(&awsMachine), current); err != nil {
+					return false
+				}
+				return conditions.IsFalse(current, infrav1.InstanceReadyCondition) &&
+					conditions.GetReason(current, infrav1.InstanceReadyCondition) == infrav1.InstanceTerminatedReason &&
+					current.Status.FailureReason != nil
+			}, e2eCtx.E2EConfig.GetIntervals("", "wait-worker-nodes")...).Should(BeTrue(), "Eventually failed waiting for the AWSMachine to report the instance termination")
+
+			ginkgo.By("Waiting for the failure event to be reported")
+			Eventually(func() bool {
+				for _, event := range getEvents(namespace.Name).Items {
+					if event.InvolvedObject.Name == awsMachine.Name && event.Type == corev1.EventTypeWarning && event.Reason == "InstanceUnexpectedTermination" {
+						return true
+					}
+				}
+				return false
+			}, e2eCtx.E2EConfig.GetIntervals("", "wait-worker-nodes")...).Should(BeTrue(), "Eventually failed waiting for the unexpected termination event")
+
+			ginkgo.By("Deleting the failed machine")
+			machines := framework.GetMachinesByMachineDeployments(ctx, framework.GetMachinesByMachineDeploymentsInput{
+				Lister:            k8sClient,
+				ClusterName:       clusterName,
+				Namespace:         namespace.Name,
+				MachineDeployment: *mds[0],
+			})
+			Expect(machines).To(HaveLen(1))
+			Expect(k8sClient.Delete(ctx, &machines[0])).To(Succeed())
+
+			ginkgo.By("Waiting for the machine deployment to replace the machine")
+			framework.WaitForMachineDeploymentNodesToExist(ctx, framework.WaitForMachineDeploymentNodesToExistInput{Lister: k8sClient, Cluster: cluster, MachineDeployment: mds[0]}, e2eCtx.E2EConfig.GetIntervals("", "wait-worker-nodes")...)
+			Eventually(func() bool {
+				replacements := framework.GetMachinesByMachineDeployments(ctx, framework.GetMachinesByMachineDeploymentsInput{
+					Lister:            k8sClient,
+					ClusterName:       clusterName,
+					Namespace:         namespace.Name,
+					MachineDeployment: *mds[0],
+				})
+				return len(replacements) == 1 && replacements[0].Name != machines[0].Name &&
+					replacements[0].Status.GetTypedPhase() == clusterv1.MachinePhaseRunning
+			}, e2eCtx.E2EConfig.GetIntervals("", "wait-worker-nodes")...).Should(BeTrue(), "Eventually failed waiting for the machine to be replaced")
+		})
+	})
+})